@@ -24,6 +24,7 @@ import (
 
 	"github.com/open-traffic-generator/snappi/gosnappi"
 	"github.com/openconfig/featureprofiles/internal/attrs"
+	"github.com/openconfig/featureprofiles/internal/bgprib"
 	"github.com/openconfig/featureprofiles/internal/cfgplugins"
 	"github.com/openconfig/featureprofiles/internal/deviations"
 	"github.com/openconfig/featureprofiles/internal/fptest"
@@ -753,28 +754,18 @@ func validateImportRoutingPolicyAllowAll(t *testing.T, dut *ondatra.DUTDevice, a
 	if !policyOK {
 		t.Fatalf("ImportPolicy IPv4 not set to allow-all")
 	}
-	found := 0
 	_, ok := gnmi.WatchAll(t, td.ate.OTG(), gnmi.OTG().BgpPeer(td.otgP2.Name()+".BGP4.peer").UnicastIpv4PrefixAny().State(), time.Minute, func(v *ygnmi.Value[*otgtelemetry.BgpPeer_UnicastIpv4Prefix]) bool {
 		_, present := v.Val()
 		return present
 	}).Await(t)
 	if ok {
-		bgpRIBPath := gnmi.OC().NetworkInstance(dni).Protocol(oc.PolicyTypes_INSTALL_PROTOCOL_TYPE_BGP, bgpName).Bgp().Rib()
-		locRib := gnmi.Get[*oc.NetworkInstance_Protocol_Bgp_Rib_AfiSafi_Ipv4Unicast_LocRib](t, dut, bgpRIBPath.
-			AfiSafi(oc.BgpTypes_AFI_SAFI_TYPE_IPV4_UNICAST).Ipv4Unicast().LocRib().State())
-		expected := map[string]bool{
-			advertisedIPv41.address + "/" + strconv.Itoa(int(advertisedIPv41.prefix)): true,
-			advertisedIPv42.address + "/" + strconv.Itoa(int(advertisedIPv42.prefix)): true,
-			advertisedIPv43.address + "/" + strconv.Itoa(int(advertisedIPv43.prefix)): true,
-		}
-		for route, prefix := range locRib.Route {
-			if expected[prefix.GetPrefix()] {
-				found++
-				t.Logf("Found Route(prefix %s, origin: %v, pathid: %d) => %s", route.Prefix, route.Origin, route.PathId, prefix.GetPrefix())
-			}
-		}
-		if found != len(expected) {
-			t.Fatalf("Not all V4 routes found. expected:%d got:%d", len(expected), found)
+		exps := locRIBExpectations(oc.BgpTypes_AFI_SAFI_TYPE_IPV4_UNICAST,
+			advertisedIPv41.address+"/"+strconv.Itoa(int(advertisedIPv41.prefix)),
+			advertisedIPv42.address+"/"+strconv.Itoa(int(advertisedIPv42.prefix)),
+			advertisedIPv43.address+"/"+strconv.Itoa(int(advertisedIPv43.prefix)),
+		)
+		if err := bgprib.Get(t, dut, dni, bgpName).Check(exps...); err != nil {
+			t.Fatalf("Not all V4 routes found:\n%v", err)
 		}
 	}
 	pathV6 := gnmi.OC().NetworkInstance(dni).Protocol(oc.PolicyTypes_INSTALL_PROTOCOL_TYPE_BGP, bgpName).Bgp().Neighbor(atePort1.IPv6).AfiSafi(oc.BgpTypes_AFI_SAFI_TYPE_IPV6_UNICAST).ApplyPolicy()
@@ -794,26 +785,22 @@ func validateImportRoutingPolicyAllowAll(t *testing.T, dut *ondatra.DUTDevice, a
 		return present
 	}).Await(t)
 	if oks {
-		bgpRIBPathV6 := gnmi.OC().NetworkInstance(dni).Protocol(oc.PolicyTypes_INSTALL_PROTOCOL_TYPE_BGP, bgpName).Bgp().Rib()
-		locRibv6 := gnmi.Get[*oc.NetworkInstance_Protocol_Bgp_Rib_AfiSafi_Ipv6Unicast_LocRib](t, dut, bgpRIBPathV6.AfiSafi(oc.BgpTypes_AFI_SAFI_TYPE_IPV6_UNICAST).Ipv6Unicast().LocRib().State())
-		found = 0
-		expectedV6 := map[string]bool{
-			v61RouteAdvertise: true,
-			v62RouteAdvertise: true,
-			v63RouteAdvertise: true,
-		}
-		for route, prefix := range locRibv6.Route {
-			if expectedV6[prefix.GetPrefix()] {
-				found++
-				t.Logf("Found Route(prefix %s, origin: %v, pathid: %d) => %s", route.Prefix, route.Origin, route.PathId, prefix.GetPrefix())
-			}
-		}
-		if found != len(expectedV6) {
-			t.Fatalf("Not all v6 Routes found expected: %d got: %d", len(expectedV6), found)
+		exps := locRIBExpectations(oc.BgpTypes_AFI_SAFI_TYPE_IPV6_UNICAST, v61RouteAdvertise, v62RouteAdvertise, v63RouteAdvertise)
+		if err := bgprib.Get(t, dut, dni, bgpName).Check(exps...); err != nil {
+			t.Fatalf("Not all v6 Routes found:\n%v", err)
 		}
 	}
 }
 
+// locRIBExpectations expects each prefix in the Loc-RIB.
+func locRIBExpectations(afisafi oc.E_BgpTypes_AFI_SAFI_TYPE, prefixes ...string) []*bgprib.Expectation {
+	var exps []*bgprib.Expectation
+	for _, p := range prefixes {
+		exps = append(exps, &bgprib.Expectation{Table: bgprib.LocRIB, AFISAFI: afisafi, Prefix: p})
+	}
+	return exps
+}
+
 func configureExtCommunityRoutingPolicy(t *testing.T, dut *ondatra.DUTDevice) {
 	root := &oc.Root{}
 	var communitySetCLIConfig string
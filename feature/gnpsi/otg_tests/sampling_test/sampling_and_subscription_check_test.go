@@ -20,6 +20,7 @@ import (
 	"github.com/openconfig/featureprofiles/internal/fptest"
 	"github.com/openconfig/featureprofiles/internal/gnoi"
 	"github.com/openconfig/featureprofiles/internal/otgutils"
+	"github.com/openconfig/featureprofiles/internal/sflow"
 	gnpsipb "github.com/openconfig/gnpsi/proto/gnpsi"
 	"github.com/openconfig/ondatra"
 	"github.com/openconfig/ondatra/binding/introspect"
@@ -276,22 +277,17 @@ func receiveSamples(t *testing.T, stream gnpsipb.GNPSI_SubscribeClient, sflowPac
 		if len(resp.Packet) == 0 {
 			continue
 		}
-		sflow := new(layers.SFlowDatagram)
-		if decodeErr := sflow.DecodeFromBytes(resp.Packet, gopacket.NilDecodeFeedback); decodeErr != nil {
+		dg, decodeErr := sflow.Decode(resp.Packet)
+		if decodeErr != nil {
 			t.Errorf("failed to decode SFlow packet: %v", decodeErr)
 			continue
 		}
 
-		for _, flow := range sflow.FlowSamples {
-			for _, record := range flow.Records {
-				switch r := record.(type) {
-				case layers.SFlowRawPacketFlowRecord:
-					sampleCount++
-					sflowPacketsToValidateChannel <- wrapSFlowPacket(r, flow)
-					t.Logf("Received GNPSI flow sample no %d:", sampleCount)
-				default:
-					continue
-				}
+		for _, flow := range dg.FlowSamples {
+			for _, header := range flow.Headers {
+				sampleCount++
+				sflowPacketsToValidateChannel <- wrapSFlowPacket(header, flow)
+				t.Logf("Received GNPSI flow sample no %d:", sampleCount)
 			}
 		}
 	}
@@ -698,13 +694,13 @@ func configureFlow(t *testing.T, config gosnappi.Config, fc flowConfig) {
 	}
 }
 
-func wrapSFlowPacket(record layers.SFlowRawPacketFlowRecord, flow layers.SFlowFlowSample) sFlowPacket {
+func wrapSFlowPacket(header *sflow.SampledHeader, flow *sflow.FlowSample) sFlowPacket {
 	return sFlowPacket{
 		sequenceNum:  flow.SequenceNumber,
-		ingressIntf:  flow.InputInterface,
-		egressIntf:   flow.OutputInterface,
+		ingressIntf:  flow.InputIfIndex,
+		egressIntf:   flow.OutputIfIndex,
 		samplingRate: flow.SamplingRate,
-		size:         record.FrameLength,
-		packet:       record.Header,
+		size:         header.FrameLength,
+		packet:       header.Packet,
 	}
 }
@@ -20,9 +20,6 @@ import (
 	"testing"
 	"time"
 
-	"github.com/google/go-cmp/cmp"
-	"google.golang.org/protobuf/testing/protocmp"
-
 	"github.com/openconfig/featureprofiles/internal/deviations"
 	"github.com/openconfig/featureprofiles/internal/fptest"
 	"github.com/openconfig/featureprofiles/internal/security/acctz"
@@ -67,20 +64,16 @@ func TestAccountzRecordSubscribeFull(t *testing.T) {
 	//	t.Fatalf("Failed receiving record response, error: %s", err)
 	//}
 
-	var wantRecords []*acctzpb.RecordResponse
-	nr := acctz.SendGnmiRPCs(t, dut)
-	wantRecords = append(wantRecords, nr...)
-	nr = acctz.SendGnoiRPCs(t, dut)
-	wantRecords = append(wantRecords, nr...)
-	nr = acctz.SendGnsiRPCs(t, dut)
-	wantRecords = append(wantRecords, nr...)
-	nr = acctz.SendGribiRPCs(t, dut)
+	var want []*acctz.Expectation
+	want = append(want, acctz.SendGnmiRPCs(t, dut)...)
+	want = append(want, acctz.SendGnoiRPCs(t, dut)...)
+	want = append(want, acctz.SendGnsiRPCs(t, dut)...)
+	nr := acctz.SendGribiRPCs(t, dut)
 	if !deviations.GribiRecordsUnsupported(dut) {
-		wantRecords = append(wantRecords, nr...)
+		want = append(want, nr...)
 	}
 	if !deviations.P4RTCapabilitiesUnsupported(dut) {
-		nr = acctz.SendP4rtRPCs(t, dut)
-		wantRecords = append(wantRecords, nr...)
+		want = append(want, acctz.SendP4rtRPCs(t, dut)...)
 	}
 
 	rec, err := deviceRecords(t, acctzSubClient, time.Minute)
@@ -117,26 +110,17 @@ func TestAccountzRecordSubscribeFull(t *testing.T) {
 		foundMap[key{path: path, id: id}] = true
 		gotRecords = append(gotRecords, r)
 	}
-	if len(wantRecords) != len(gotRecords) {
-		t.Errorf("Got %d records, want %d", len(gotRecords), len(wantRecords))
-	}
-
-	// Ignore proto fields which are set internally by the DUT (cannot be matched exactly)
-	// and compare them manually later.
-	popts := []cmp.Option{
-		protocmp.Transform(),
-		protocmp.IgnoreFields(&acctzpb.RecordResponse{}, "timestamp", "task_ids", "component_name"),
-		protocmp.IgnoreFields(&acctzpb.AuthzDetail{}, "detail"),
-		protocmp.IgnoreFields(&acctzpb.SessionInfo{}, "ip_proto", "channel_id", "local_address", "local_port", "remote_address", "remote_port", "status", "authn", "tty"),
-		protocmp.IgnoreFields(&acctzpb.UserDetail{}, "role"),
-		protocmp.IgnoreFields(&acctzpb.GrpcService{}, "proto_val", "payload_istruncated", "string_val"),
+	// Fields set internally by the DUT cannot be matched exactly and are
+	// checked separately below.
+	for _, e := range want {
+		e.Session, e.Authn, e.IPProto, e.Role, e.Payload = 0, 0, 0, "", nil
+		e.AuthzDetail = e.Authz == acctzpb.AuthzDetail_AUTHZ_STATUS_DENY
 	}
+	m := &acctz.Matcher{Ordered: true, Start: requestTimestamp.AsTime()}
+	acctz.VerifyRecords(t, m, gotRecords, want)
 
-	var recordIdx int
 	var lastTimestampUnixMillis int64
-	for recordIdx < len(gotRecords) && recordIdx < len(wantRecords) {
-		record := gotRecords[recordIdx]
-
+	for _, record := range gotRecords {
 		if record.GetHistoryIstruncated() {
 			t.Errorf("History is truncated but it shouldn't be, Record Details: %s", acctz.PrettyPrint(record))
 		}
@@ -148,16 +132,6 @@ func TestAccountzRecordSubscribeFull(t *testing.T) {
 		}
 		lastTimestampUnixMillis = timestamp.UnixMilli()
 
-		// Verify acctz proto bits.
-		if diff := cmp.Diff(record, wantRecords[recordIdx], popts...); diff != "" {
-			t.Errorf("Got diff in got/want: %s", diff)
-		}
-
-		// Verify record timestamp is after request timestamp.
-		if !timestamp.After(requestTimestamp.AsTime()) {
-			t.Errorf("Record timestamp is before record request timestamp %v, Record Details: %v", requestTimestamp.AsTime(), acctz.PrettyPrint(record))
-		}
-
 		// This channel check maybe should just go away entirely -- see:
 		// https://github.com/openconfig/gnsi/issues/98
 		// In case of Nokia this is being set to the aaa session id just to have some hopefully
@@ -167,14 +141,7 @@ func TestAccountzRecordSubscribeFull(t *testing.T) {
 			t.Errorf("Channel Id is not populated for record: %v", acctz.PrettyPrint(record))
 		}
 
-		// Verify authz detail is populated for denied rpcs.
-		authzInfo := record.GetGrpcService().GetAuthz()
-		if authzInfo.GetStatus() == acctzpb.AuthzDetail_AUTHZ_STATUS_DENY && authzInfo.GetDetail() == "" {
-			t.Errorf("Authorization detail is not populated for record: %v", acctz.PrettyPrint(record))
-		}
-
 		t.Logf("Processed Record: %s", acctz.PrettyPrint(record))
-		recordIdx++
 	}
 }
 
@@ -17,12 +17,11 @@ package recordsubscribenongrpc_test
 import (
 	"context"
 	"flag"
+	"slices"
 	"strings"
 	"testing"
 	"time"
 
-	"github.com/google/go-cmp/cmp"
-	"google.golang.org/protobuf/testing/protocmp"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/openconfig/featureprofiles/internal/deviations"
@@ -52,20 +51,17 @@ func TestAccountzRecordSubscribeNonGRPC(t *testing.T) {
 	setupVendorSpecificAcctzConfig(t, dut)
 
 	acctz.SetupUsers(t, dut, true)
-	var records []*acctzpb.RecordResponse
+	var want []*acctz.Expectation
 
 	// Get the current time from the router via gNMI to avoid clock skew issues.
 	startTime := helpers.GetRouterTime(t, dut)
 
-	newRecords := acctz.SendSuccessCliCommand(t, dut, *staticBinding)
-	records = append(records, newRecords...)
+	want = append(want, acctz.SendSuccessCliCommand(t, dut, *staticBinding)...)
 	if !deviations.AcctzRecordFailCommandUnsupported(dut) {
-		newRecords = acctz.SendFailCliCommand(t, dut, *staticBinding)
-		records = append(records, newRecords...)
+		want = append(want, acctz.SendFailCliCommand(t, dut, *staticBinding)...)
 	}
 	if !deviations.AcctzShellCmdAccountingUnsupported(dut) {
-		newRecords = acctz.SendShellCommand(t, dut, *staticBinding)
-		records = append(records, newRecords...)
+		want = append(want, acctz.SendShellCommand(t, dut, *staticBinding)...)
 	}
 
 	// Quick sleep to ensure all the records have been processed/ready for us.
@@ -85,26 +81,9 @@ func TestAccountzRecordSubscribeNonGRPC(t *testing.T) {
 	}
 	defer acctzSubClient.CloseSend()
 
-	var recordIdx int
-	var lastTimestampUnixMillis int64
+	var gotRecords []*acctzpb.RecordResponse
 	r := make(chan recordRequestResult)
-
-	// Ignore proto fields which are set internally by the DUT (cannot be matched exactly)
-	// and compare them manually later.
-	popts := []cmp.Option{protocmp.Transform(),
-		protocmp.IgnoreFields(&acctzpb.RecordResponse{}, "timestamp", "task_ids", "component_name"),
-		protocmp.IgnoreFields(&acctzpb.AuthzDetail{}, "detail"),
-		protocmp.IgnoreFields(&acctzpb.AuthnDetail{}, "type", "cause"),
-		protocmp.IgnoreFields(&acctzpb.UserDetail{}, "role"),
-		protocmp.IgnoreFields(&acctzpb.CommandService{}, "cmd", "cmd_args"),
-		protocmp.IgnoreFields(&acctzpb.SessionInfo{}, "channel_id", "tty", "local_address", "local_port", "remote_address", "remote_port"),
-	}
-	for {
-		if recordIdx >= len(records) {
-			t.Log("Out of records to process...")
-			break
-		}
-
+	for len(gotRecords) < len(want) {
 		// Read single acctz record from stream into channel.
 		go func(r chan recordRequestResult) {
 			var response *acctzpb.RecordResponse
@@ -157,69 +136,64 @@ func TestAccountzRecordSubscribeNonGRPC(t *testing.T) {
 		}
 
 		// Skip records from unknown users (e.g. gnetch-ro)
-		foundUser := false
 		userIdentity := resp.record.GetSessionInfo().GetUser().GetIdentity()
-		for _, r := range records {
-			if r.GetSessionInfo().GetUser().GetIdentity() == userIdentity {
-				foundUser = true
-				break
-			}
-		}
-		if !foundUser {
+		if !slices.ContainsFunc(want, func(e *acctz.Expectation) bool { return e.User == userIdentity }) {
 			t.Logf("Skipping record from unknown user: %s", userIdentity)
 			continue
 		}
+		gotRecords = append(gotRecords, resp.record)
+	}
 
-		timestamp := resp.record.Timestamp.AsTime()
-		if timestamp.UnixMilli() == lastTimestampUnixMillis {
-			// This ensures that timestamps are actually changing for each record.
-			t.Errorf("Timestamp is the same as the previous timestamp, this shouldn't be possible!, Record Details: %s", acctz.PrettyPrint(resp.record))
+	// Fields set internally by the DUT cannot be matched exactly.  The
+	// command is checked separately below, as a DUT may split it between
+	// cmd and cmd_args.
+	wantCmds := map[*acctz.Expectation]string{}
+	for _, e := range want {
+		wantCmds[e] = strings.TrimSpace(e.Cmd + " " + strings.Join(e.CmdArgs, " "))
+		e.Cmd, e.CmdArgs, e.Role = "", nil, ""
+		e.AuthzDetail = e.Authz == acctzpb.AuthzDetail_AUTHZ_STATUS_DENY
+	}
+	m := &acctz.Matcher{Ordered: true, Start: requestTimestamp.AsTime()}
+	report := acctz.VerifyRecords(t, m, gotRecords, want)
+	for _, match := range report.Matched {
+		// Verify command matches even if split between cmd and cmd_args.
+		cmd := match.Record.GetCmdService()
+		if gotCmd := strings.TrimSpace(cmd.GetCmd() + " " + strings.Join(cmd.GetCmdArgs(), " ")); gotCmd != wantCmds[match.Want] {
+			t.Errorf("Command mismatch: got %q, want %q", gotCmd, wantCmds[match.Want])
 		}
-		lastTimestampUnixMillis = timestamp.UnixMilli()
-
-		// Verify acctz proto bits.
-		if diff := cmp.Diff(resp.record, records[recordIdx], popts...); diff != "" {
-			t.Errorf("got diff in got/want: %s", diff)
+		if cmd.GetCmdIstruncated() {
+			t.Errorf("Command is truncated but it shouldn't be, Record Details: %s", acctz.PrettyPrint(match.Record))
 		}
+	}
 
-		// Verify command matches even if split between cmd and cmd_args.
-		gotCmd := strings.TrimSpace(resp.record.GetCmdService().GetCmd() + " " + strings.Join(resp.record.GetCmdService().GetCmdArgs(), " "))
-		wantCmd := strings.TrimSpace(records[recordIdx].GetCmdService().GetCmd() + " " + strings.Join(records[recordIdx].GetCmdService().GetCmdArgs(), " "))
-		if gotCmd != wantCmd {
-			t.Errorf("Command mismatch: got %q, want %q", gotCmd, wantCmd)
+	var lastTimestampUnixMillis int64
+	for _, record := range gotRecords {
+		if record.GetHistoryIstruncated() {
+			t.Errorf("History is truncated but it shouldn't be, Record Details: %s", acctz.PrettyPrint(record))
 		}
 
-		// Verify record timestamp is after request timestamp.
-		if !timestamp.After(requestTimestamp.AsTime()) {
-			t.Errorf("Record timestamp is before record request timestamp %v, Record Details: %v", requestTimestamp.AsTime(), acctz.PrettyPrint(resp.record))
+		timestamp := record.Timestamp.AsTime()
+		if timestamp.UnixMilli() == lastTimestampUnixMillis {
+			// This ensures that timestamps are actually changing for each record.
+			t.Errorf("Timestamp is the same as the previous timestamp, this shouldn't be possible!, Record Details: %s", acctz.PrettyPrint(record))
 		}
+		lastTimestampUnixMillis = timestamp.UnixMilli()
 
 		// This channel check maybe should just go away entirely -- see:
 		// https://github.com/openconfig/gnsi/issues/98
 		// In case of Nokia this is being set to the aaa session id just to have some hopefully
 		// useful info in this field to identify a "session" (even if it isn't necessarily ssh/grpc
 		// directly).
-		if resp.record.GetSessionInfo().GetChannelId() == "" && !deviations.AcctzRecordSessionChannelIdUnsupported(dut) {
-			t.Errorf("Channel Id is not populated for record: %v", acctz.PrettyPrint(resp.record))
+		if record.GetSessionInfo().GetChannelId() == "" && !deviations.AcctzRecordSessionChannelIdUnsupported(dut) {
+			t.Errorf("Channel Id is not populated for record: %v", acctz.PrettyPrint(record))
 		}
 
 		// Tty only set for ssh records.
-		if resp.record.GetSessionInfo().GetTty() == "" {
-			t.Errorf("Should have tty allocated but not set, Record Details: %s", acctz.PrettyPrint(resp.record))
+		if record.GetSessionInfo().GetTty() == "" {
+			t.Errorf("Should have tty allocated but not set, Record Details: %s", acctz.PrettyPrint(record))
 		}
 
-		// Verify authz detail is populated for denied cmds.
-		authzInfo := resp.record.GetCmdService().GetAuthz()
-		if authzInfo.Status == acctzpb.AuthzDetail_AUTHZ_STATUS_DENY && authzInfo.GetDetail() == "" {
-			t.Errorf("Authorization detail is not populated for record: %v", acctz.PrettyPrint(resp.record))
-		}
-
-		t.Logf("Processed Record: %s", acctz.PrettyPrint(resp.record))
-		recordIdx++
-	}
-	t.Logf("recordIdx: %d, len(records): %d", recordIdx, len(records))
-	if recordIdx != len(records) {
-		t.Fatal("Did not process all records.")
+		t.Logf("Processed Record: %s", acctz.PrettyPrint(record))
 	}
 }
 
@@ -947,7 +947,7 @@ func TestGribiEncapDecapScaling(t *testing.T) {
 		subIntfIPs = append(subIntfIPs, subIntf.nextHopIPAddress)
 	}
 
-	vrfConfigs := tescale.BuildVRFConfig(dut, subIntfIPs,
+	vrfConfigs, err := tescale.BuildVRFConfig(dut, subIntfIPs,
 		tescale.Param{
 			V4TunnelCount:         *fpargs.V4TunnelCount,
 			V4TunnelNHGCount:      *fpargs.V4TunnelNHGCount,
@@ -956,6 +956,9 @@ func TestGribiEncapDecapScaling(t *testing.T) {
 			V4ReEncapNHGCount:     *fpargs.V4ReEncapNHGCount,
 		},
 	)
+	if err != nil {
+		t.Fatalf("Could not build VRF configs: %v", err)
+	}
 	for _, vrfConfig := range vrfConfigs {
 		// skip adding unwanted entries
 		if vrfConfig.Name == "vrf_rd" {
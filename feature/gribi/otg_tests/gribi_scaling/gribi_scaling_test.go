@@ -302,7 +302,7 @@ func TestScaling(t *testing.T) {
 	}
 	gribi.BecomeLeader(t, client)
 
-	vrfConfigs := tescale.BuildVRFConfig(dut, subIntfIPs,
+	vrfConfigs, err := tescale.BuildVRFConfig(dut, subIntfIPs,
 		tescale.Param{
 			V4TunnelCount:         *fpargs.V4TunnelCount,
 			V4TunnelNHGCount:      *fpargs.V4TunnelNHGCount,
@@ -311,6 +311,9 @@ func TestScaling(t *testing.T) {
 			V4ReEncapNHGCount:     *fpargs.V4ReEncapNHGCount,
 		},
 	)
+	if err != nil {
+		t.Fatalf("Could not build VRF configs: %v", err)
+	}
 
 	createFlow(t, ate, top, vrfConfigs[1])
 
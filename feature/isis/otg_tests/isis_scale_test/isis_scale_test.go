@@ -15,6 +15,7 @@ import (
 	"github.com/openconfig/featureprofiles/internal/cfgplugins"
 	"github.com/openconfig/featureprofiles/internal/deviations"
 	"github.com/openconfig/featureprofiles/internal/fptest"
+	"github.com/openconfig/featureprofiles/internal/isislsdb"
 	otgconfighelpers "github.com/openconfig/featureprofiles/internal/otg_helpers/otg_config_helpers"
 	"github.com/openconfig/featureprofiles/internal/otgutils"
 	"github.com/openconfig/ondatra"
@@ -44,6 +45,7 @@ type testData struct {
 	correctAggInterfaceCount int
 	correctISISAdjCount      int
 	correctIPRouteCount      map[oc.E_Types_ADDRESS_FAMILY]int
+	ateTop                   gosnappi.Config
 }
 
 type dutData struct {
@@ -758,6 +760,7 @@ func setupTest(t *testing.T, testInfo *testData) *ondatra.DUTDevice {
 	testInfo.ateData.ATE = ondatra.ATE(t, "ate")
 	top := otgconfighelpers.ConfigureATE(t, testInfo.ateData.ATE, testInfo.ateData)
 	testInfo.ateData.ATE.OTG().PushConfig(t, top)
+	testInfo.ateTop = top
 	// testInfo.ateData.ATE.
 	testInfo.ateData.AppendTrafficFlows(t, top)
 	// Start protocols on ATE
@@ -826,6 +829,18 @@ func TestISISScale(t *testing.T) {
 				}
 			})
 
+			t.Run("LSDB_Topology", func(t *testing.T) {
+				if deviations.ISISLSPTlvsOCUnsupported(dut) {
+					t.Skip("ISIS LSP TLVs are not supported in OC")
+				}
+				// Every emulated router, adjacency and prefix must be in the LSDB with its metric.
+				want, err := isislsdb.FromOTG(testInfo.ateTop, isislsdb.OTGOptions{DUTSystemID: dutSysID})
+				if err != nil {
+					t.Fatalf("Could not derive the emulated ISIS topology: %v", err)
+				}
+				isislsdb.Await(t, dut, defaultNetworkInstance, defaultNetworkInstance, 2, want, 3*time.Minute)
+			})
+
 			t.Run("Route_Count", func(t *testing.T) {
 				var wg sync.WaitGroup
 				for _, f := range []oc.E_Types_ADDRESS_FAMILY{oc.Types_ADDRESS_FAMILY_IPV4, oc.Types_ADDRESS_FAMILY_IPV6} {
@@ -12,6 +12,7 @@ import (
 	"github.com/openconfig/featureprofiles/internal/deviations"
 	"github.com/openconfig/featureprofiles/internal/fptest"
 	"github.com/openconfig/featureprofiles/internal/otgutils"
+	"github.com/openconfig/featureprofiles/internal/qosverify"
 	"github.com/openconfig/ondatra"
 	"github.com/openconfig/ondatra/gnmi"
 	"github.com/openconfig/ondatra/gnmi/oc"
@@ -27,7 +28,7 @@ const (
 	pirValue            = 2000000000
 	trafficFrameSize    = 512
 	trafficDuration     = 20 * time.Second
-	lossVariation       = 0.01
+	throughputPct       = 1
 	schedulerName       = "group_A_2Gb"
 	inputPolicerName    = "input-policer-2Gb"
 	queue1              = "QUEUE_1"
@@ -80,12 +81,14 @@ var (
 	}
 
 	inputInterfaceName string
+
+	// policer is the policer configured on the DUT.
+	policer *qosverify.Policer
 )
 
 type testCase struct {
 	name     string
 	flowRate uint64
-	lossPct  float32
 }
 
 func TestMain(m *testing.M) {
@@ -111,8 +114,8 @@ func TestIngressPolicerTwoRateThreeColor(t *testing.T) {
 	otgutils.WaitForARP(t, ate.OTG(), top, ipv6)
 
 	testCases := []testCase{
-		{name: "DP-2.5.1 Low Traffic", flowRate: trafficRateLowMbps, lossPct: 0},
-		{name: "DP-2.5.2 High Traffic", flowRate: trafficRateHighMbps, lossPct: 0.5},
+		{name: "DP-2.5.1 Low Traffic", flowRate: trafficRateLowMbps},
+		{name: "DP-2.5.2 High Traffic", flowRate: trafficRateHighMbps},
 	}
 
 	for _, tc := range testCases {
@@ -152,6 +155,12 @@ func configureDUT(t *testing.T, dut *ondatra.DUTDevice) {
 		SequenceNumber: sequenceNumber,
 	}
 
+	var err error
+	policer, err = qosverify.PolicerFromOC(cfgplugins.TwoRateThreeColorSchedulerPolicy(schedulerParams), schedulerName, sequenceNumber)
+	if err != nil {
+		t.Fatalf("Could not read the policer: %v", err)
+	}
+
 	qosPath := gnmi.OC().Qos().Config()
 	cfgplugins.NewTwoRateThreeColorScheduler(t, dut, qosBatch, schedulerParams)
 	cfgplugins.ApplyQosPolicyOnInterface(t, dut, qosBatch, schedulerParams)
@@ -200,51 +209,23 @@ func configureFlows(config *gosnappi.Config, tc testCase, flowName string) {
 }
 
 func runTest(t *testing.T, dut *ondatra.DUTDevice, ate *ondatra.ATEDevice, config gosnappi.Config, tc testCase) {
-	abs := func(num int) int {
-		if num < 0 {
-			return -num
-		}
-		return num
-	}
-
 	otg := ate.OTG()
 	flowName := strings.ReplaceAll(tc.name, " ", "_")
 	configureFlows(&config, tc, flowName)
 	otg.PushConfig(t, config)
 
 	otg.StartProtocols(t)
+	before := qosverify.GetPolicerCounters(t, dut, inputInterfaceName, sequenceNumber)
 	otg.StartTraffic(t)
 	waitForTraffic(t, otg, flowName, trafficDuration*2)
 
 	otgutils.LogFlowMetrics(t, otg, config)
 	otgutils.LogPortMetrics(t, otg, config)
 
-	flowMetrics := gnmi.Get(t, ate.OTG(), gnmi.OTG().Flow(flowName).State())
-	sentPackets := *flowMetrics.Counters.OutPkts
-	receivedPackets := *flowMetrics.Counters.InPkts
-
-	if sentPackets == 0 {
-		t.Errorf("No packets transmitted")
-	}
-
-	if receivedPackets == 0 {
-		t.Errorf("No packets received")
-	}
-	lostPackets := abs(int(receivedPackets - sentPackets))
-	switch tc.lossPct {
-	case 0:
-		if lostPackets != 0 {
-			t.Errorf("Expected 0 lost packets, but got %d out of %d lost packets", lostPackets, sentPackets)
-		}
-	default:
-		expectedLostPackets := int(float32(sentPackets) * tc.lossPct)
-		lostPacketsVariation := int(float64(expectedLostPackets) * lossVariation)
-		if lostPackets < expectedLostPackets-lostPacketsVariation || lostPackets > expectedLostPackets+lostPacketsVariation {
-			t.Errorf("Expected lost packets to be within [%d, %d], but got %d", expectedLostPackets-lostPacketsVariation, expectedLostPackets+lostPacketsVariation, lostPackets)
-		}
-	}
-
-	validateSchedulerCounters(t, dut, inputInterfaceName, tc, uint64(lostPackets))
+	// The flow rate includes the preamble and inter-frame gap, which the
+	// policer does not meter.
+	rate := qosverify.L2Rate(float64(tc.flowRate)*1e6, trafficFrameSize)
+	qosverify.VerifyPolicer(t, dut, ate, inputInterfaceName, sequenceNumber, policer, flowName, rate, before, qosverify.CheckOptions{ThroughputPct: throughputPct})
 }
 
 func waitForTraffic(t *testing.T, otg *otg.OTG, flowName string, timeout time.Duration) {
@@ -261,11 +242,3 @@ func waitForTraffic(t *testing.T, otg *otg.OTG, flowName string, timeout time.Du
 		t.Logf("Traffic for flow %s has stopped", flowName)
 	}
 }
-
-func validateSchedulerCounters(t *testing.T, dut *ondatra.DUTDevice, intf string, tc testCase, droppedPackets uint64) {
-	scheduler := gnmi.Get(t, dut, gnmi.OC().Qos().Interface(intf).Input().SchedulerPolicy().Scheduler(sequenceNumber).State())
-	t.Logf("Scheduler counters for %s at %.1fGbps: conforming=%d, exceeding=%d, violating=%d", intf, float64(tc.flowRate/1000), *scheduler.ConformingPkts, *scheduler.ExceedingPkts, *scheduler.ViolatingPkts)
-	if *scheduler.ViolatingPkts != droppedPackets {
-		t.Errorf("Expected %d dropped packets, but got %d", droppedPackets, *scheduler.ViolatingPkts)
-	}
-}
@@ -17,20 +17,17 @@ package sflow_base_test
 import (
 	"fmt"
 	"net"
-	"os"
 	"slices"
 	"testing"
 	"time"
 
-	"github.com/google/gopacket"
-	"github.com/google/gopacket/layers"
-	"github.com/google/gopacket/pcap"
 	"github.com/open-traffic-generator/snappi/gosnappi"
 	"github.com/openconfig/featureprofiles/internal/attrs"
 	"github.com/openconfig/featureprofiles/internal/cfgplugins"
 	"github.com/openconfig/featureprofiles/internal/deviations"
 	"github.com/openconfig/featureprofiles/internal/fptest"
 	"github.com/openconfig/featureprofiles/internal/otgutils"
+	"github.com/openconfig/featureprofiles/internal/sflow"
 	"github.com/openconfig/ondatra"
 	"github.com/openconfig/ondatra/gnmi"
 	"github.com/openconfig/ondatra/gnmi/oc"
@@ -355,16 +352,10 @@ func stopCapture(t *testing.T, ate *ondatra.ATEDevice, cs gosnappi.ControlState)
 }
 
 func processCapture(t *testing.T, ate *ondatra.ATEDevice, config gosnappi.Config, ip IPType, fc flowConfig) {
-	bytes := ate.OTG().GetCapture(t, gosnappi.NewCaptureRequest().SetPortName(config.Ports().Items()[1].Name()))
-	pcapFile, err := os.CreateTemp("", "pcap")
-	if err != nil {
-		t.Errorf("ERROR: Could not create temporary pcap file: %v\n", err)
-	}
-	if _, err := pcapFile.Write(bytes); err != nil {
-		t.Errorf("ERROR: Could not write bytes to pcap file: %v\n", err)
-	}
-	pcapFile.Close()
-	validatePackets(t, pcapFile.Name(), ip, fc)
+	// Malformed sFlow packets are skipped, as validatePackets checks that enough
+	// samples were exported.
+	exports, _ := sflow.FromOTGCapture(t, ate, config.Ports().Items()[1].Name())
+	validatePackets(t, exports, ip, fc)
 }
 
 func configureATE(t *testing.T, ate *ondatra.ATEDevice) gosnappi.Config {
@@ -451,41 +442,20 @@ func createFlow(t *testing.T, ate *ondatra.ATEDevice, config gosnappi.Config, fc
 	ate.OTG().StartProtocols(t)
 }
 
-func validatePackets(t *testing.T, filename string, ip IPType, fc flowConfig) {
-	handle, err := pcap.OpenOffline(filename)
-	if err != nil {
-		t.Fatal(err)
-	}
-	defer handle.Close()
+func validatePackets(t *testing.T, exports []*sflow.Export, ip IPType, fc flowConfig) {
 	loopbackIP := net.ParseIP(dutlo0Attrs.IPv4)
 	if ip == IPv6 {
 		loopbackIP = net.ParseIP(dutlo0Attrs.IPv6)
 	}
-	packetSource := gopacket.NewPacketSource(handle, handle.LinkType())
 	packetCount := 0
 	sflowSamples := uint32(0)
 	expectedSampleCount := float64(fc.packetsToSend / fc.minSamplingRate)
 	minAllowedSamples := expectedSampleCount * sampleTolerance
-	for packet := range packetSource.Packets() {
-		if ipLayer := packet.Layer(layers.LayerTypeIPv4); ipLayer != nil {
-			ipv4, _ := ipLayer.(*layers.IPv4)
-			if ipv4.SrcIP.Equal(loopbackIP) {
-				if ipv4.TOS == 32 {
-					packetCount++
-				}
-			}
-		} else if ipLayer := packet.Layer(layers.LayerTypeIPv6); ipLayer != nil {
-			ipv6, _ := ipLayer.(*layers.IPv6)
-			if ipv6.SrcIP.Equal(loopbackIP) {
-				if ipv6.TrafficClass == 32 {
-					packetCount++
-				}
-			}
-		}
-		if sflowLayer := packet.Layer(layers.LayerTypeSFlow); sflowLayer != nil {
-			sflow := sflowLayer.(*layers.SFlowDatagram)
-			sflowSamples += sflow.SampleCount
+	for _, e := range exports {
+		if e.Source.Equal(loopbackIP) && e.DSCP != nil && *e.DSCP == 8 {
+			packetCount++
 		}
+		sflowSamples += uint32(len(e.Datagram.FlowSamples) + len(e.Datagram.CounterSamples))
 	}
 	t.Logf("SFlow Packet count: %v - SampleCount: %v", packetCount, sflowSamples)
 	if sflowSamples < uint32(minAllowedSamples) {
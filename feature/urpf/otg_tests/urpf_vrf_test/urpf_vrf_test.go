@@ -192,19 +192,17 @@ func configureDUTPort(t *testing.T, dut *ondatra.DUTDevice, intBatch *gnmi.SetBa
 	gnmi.BatchUpdate(intBatch, d.Interface(p.Name()).Config(), i)
 }
 
-// configureHardwareInit sets up the initial hardware configuration on the DUT.
-// It pushes hardware initialization configs for:
-//  1. VRF Selection Extended feature.
-//  2. Policy Forwarding feature.
+// configureHardwareInit sets up the initial hardware configuration on the DUT,
+// with one hardware profile combining the VRF Selection Extended and Policy
+// Forwarding features.
 func configureHardwareInit(t *testing.T, dut *ondatra.DUTDevice) {
 	t.Helper()
-	hardwareVRFCfg := cfgplugins.NewDUTHardwareInit(t, dut, cfgplugins.FeatureVrfSelectionExtended)
-	hardwarePfCfg := cfgplugins.NewDUTHardwareInit(t, dut, cfgplugins.FeaturePolicyForwarding)
-	if hardwareVRFCfg == "" || hardwarePfCfg == "" {
-		return
-	}
-	cfgplugins.PushDUTHardwareInitConfig(t, dut, hardwareVRFCfg)
-	cfgplugins.PushDUTHardwareInitConfig(t, dut, hardwarePfCfg)
+	cfgplugins.PushDUTHardwareProfile(t, dut, cfgplugins.HardwareProfileConfig{
+		Features: []cfgplugins.FeatureType{
+			cfgplugins.FeatureVrfSelectionExtended,
+			cfgplugins.FeaturePolicyForwarding,
+		},
+	})
 }
 
 // configureGUETunnel configures a GUE tunnel with optional ToS and TTL.
@@ -0,0 +1,267 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package aclverify builds ACLs, predicts which rule a packet hits, and
+// checks that the rules of an ACL on a DUT count the traffic sent at them.
+//
+// A test validates every rule of a large ACL without writing a flow per
+// term:
+//
+//	a := aclverify.New("acl-v4", oc.Acl_ACL_TYPE_ACL_IPV4).
+//		AddPrefixSet("servers", "192.0.2.0/28", "192.0.2.64/28").
+//		AddRule(&aclverify.Rule{Seq: 10, Action: oc.Acl_FORWARDING_ACTION_ACCEPT, DstSet: "servers", Protocol: 6, DstPort: aclverify.Port(443)}).
+//		AddRule(&aclverify.Rule{Seq: 20, Action: oc.Acl_FORWARDING_ACTION_DROP, Src: "0.0.0.0/0"})
+//	aclverify.Configure(t, dut, batch, a, dp1.Name(), true)
+//	batch.Set(t, dut)
+//	aclverify.ConfigureFromCLI(t, dut, a)
+//
+//	flows, err := a.AddFlows(top, aclverify.FlowSpec{TxPort: "port1", RxPort: "port2", SrcMAC: ateSrc.MAC, DstMAC: dutMAC, Packets: 1000})
+//	...
+//	before := aclverify.GetMatchedPackets(t, dut, a, dp1.Name(), true)
+//	ate.OTG().StartTraffic(t)
+//	...
+//	aclverify.Verify(t, dut, ate, a, dp1.Name(), true, flows, before)
+package aclverify
+
+import (
+	"fmt"
+	"net/netip"
+	"sort"
+
+	"github.com/openconfig/featureprofiles/internal/packetmatch"
+	"github.com/openconfig/ondatra/gnmi/oc"
+)
+
+// PortRange is an inclusive range of transport ports.  The zero value
+// matches any port.
+type PortRange = packetmatch.PortRange
+
+// Port returns the range holding only port p.
+func Port(p uint16) PortRange {
+	return PortRange{Lo: p, Hi: p}
+}
+
+// Ports returns the range of ports from lo to hi.
+func Ports(lo, hi uint16) PortRange {
+	return PortRange{Lo: lo, Hi: hi}
+}
+
+// Rule is an ACL entry.  Unset fields match any packet.
+type Rule struct {
+	Seq         uint32
+	Description string
+	Action      oc.E_Acl_FORWARDING_ACTION
+	Log         bool
+
+	// L2 fields, for L2 and mixed ACLs.  Masks default to all ones.
+	SrcMAC, SrcMACMask string
+	DstMAC, DstMACMask string
+	EtherType          uint16
+
+	// IP fields.  Src and Dst are prefixes, SrcSet and DstSet names of
+	// prefix sets of the ACL.
+	Src, Dst       string
+	SrcSet, DstSet string
+	Protocol       uint8
+	DSCP           []uint8
+	HopLimit       *uint8
+
+	// Transport fields.  SrcPortSet and DstPortSet are names of port sets
+	// of the ACL.  TCPFlags must all be set for a packet to match.
+	SrcPort, DstPort       PortRange
+	SrcPortSet, DstPortSet string
+	TCPFlags               []oc.E_PacketMatchTypes_TCP_FLAGS
+
+	// ICMP fields, for ICMP or ICMPv6 depending on the family of the rule.
+	ICMPType, ICMPCode *uint8
+}
+
+func (r *Rule) String() string {
+	if r.Description != "" {
+		return fmt.Sprintf("rule %d (%s)", r.Seq, r.Description)
+	}
+	return fmt.Sprintf("rule %d", r.Seq)
+}
+
+func (r *Rule) hasL2() bool {
+	return r.SrcMAC != "" || r.DstMAC != "" || r.EtherType != 0
+}
+
+func (r *Rule) hasIP() bool {
+	return r.Src != "" || r.Dst != "" || r.SrcSet != "" || r.DstSet != "" || r.Protocol != 0 ||
+		len(r.DSCP) > 0 || r.HopLimit != nil || r.hasTransport() || r.ICMPType != nil || r.ICMPCode != nil
+}
+
+func (r *Rule) hasTransport() bool {
+	return !r.SrcPort.Any() || !r.DstPort.Any() || r.SrcPortSet != "" || r.DstPortSet != "" || len(r.TCPFlags) > 0
+}
+
+// ACL is an ACL set and the defined sets its rules refer to.
+type ACL struct {
+	Name       string
+	Type       oc.E_Acl_ACL_TYPE
+	Rules      []*Rule
+	PrefixSets map[string][]string
+	PortSets   map[string][]PortRange
+}
+
+// New returns an empty ACL.
+func New(name string, typ oc.E_Acl_ACL_TYPE) *ACL {
+	return &ACL{Name: name, Type: typ, PrefixSets: map[string][]string{}, PortSets: map[string][]PortRange{}}
+}
+
+// AddRule adds a rule to a.
+func (a *ACL) AddRule(r *Rule) *ACL {
+	a.Rules = append(a.Rules, r)
+	return a
+}
+
+// AddPrefixSet adds a prefix set to a.  All prefixes of a set must be of
+// the same family.
+func (a *ACL) AddPrefixSet(name string, prefixes ...string) *ACL {
+	a.PrefixSets[name] = append(a.PrefixSets[name], prefixes...)
+	return a
+}
+
+// AddPortSet adds a port set to a.
+func (a *ACL) AddPortSet(name string, ports ...PortRange) *ACL {
+	a.PortSets[name] = append(a.PortSets[name], ports...)
+	return a
+}
+
+// SortedRules returns the rules in the order they are evaluated.
+func (a *ACL) SortedRules() []*Rule {
+	rules := append([]*Rule(nil), a.Rules...)
+	sort.SliceStable(rules, func(i, j int) bool { return rules[i].Seq < rules[j].Seq })
+	return rules
+}
+
+// Validate checks that the rules fit the type of a and refer to defined sets.
+func (a *ACL) Validate() error {
+	for name, prefixes := range a.PrefixSets {
+		if len(prefixes) == 0 {
+			return fmt.Errorf("prefix set %s is empty", name)
+		}
+		var is6 bool
+		for i, s := range prefixes {
+			p, err := netip.ParsePrefix(s)
+			if err != nil {
+				return fmt.Errorf("prefix set %s: %w", name, err)
+			}
+			if i == 0 {
+				is6 = p.Addr().Is6()
+			} else if p.Addr().Is6() != is6 {
+				return fmt.Errorf("prefix set %s mixes address families", name)
+			}
+		}
+	}
+	seen := map[uint32]bool{}
+	for _, r := range a.Rules {
+		if seen[r.Seq] {
+			return fmt.Errorf("duplicate sequence %d", r.Seq)
+		}
+		seen[r.Seq] = true
+		if err := a.validateRule(r); err != nil {
+			return fmt.Errorf("%v: %w", r, err)
+		}
+	}
+	return nil
+}
+
+func (a *ACL) validateRule(r *Rule) error {
+	switch a.Type {
+	case oc.Acl_ACL_TYPE_ACL_L2:
+		if r.hasIP() {
+			return fmt.Errorf("IP fields in an L2 ACL")
+		}
+	case oc.Acl_ACL_TYPE_ACL_IPV4, oc.Acl_ACL_TYPE_ACL_IPV6:
+		if r.hasL2() {
+			return fmt.Errorf("L2 fields in an IP ACL")
+		}
+	case oc.Acl_ACL_TYPE_ACL_MIXED:
+	default:
+		return fmt.Errorf("unsupported ACL type %v", a.Type)
+	}
+	for _, mac := range []string{r.SrcMAC, r.SrcMACMask, r.DstMAC, r.DstMACMask} {
+		if mac != "" {
+			if _, err := packetmatch.ParseMAC(mac); err != nil {
+				return err
+			}
+		}
+	}
+	if _, err := a.family(r); err != nil {
+		return err
+	}
+	for _, set := range []string{r.SrcPortSet, r.DstPortSet} {
+		if _, ok := a.PortSets[set]; set != "" && !ok {
+			return fmt.Errorf("unknown port set %q", set)
+		}
+	}
+	if r.hasTransport() && r.Protocol != packetmatch.ProtocolTCP && r.Protocol != packetmatch.ProtocolUDP {
+		return fmt.Errorf("transport fields need protocol TCP or UDP, got %d", r.Protocol)
+	}
+	if len(r.TCPFlags) > 0 && r.Protocol != packetmatch.ProtocolTCP {
+		return fmt.Errorf("TCP flags need protocol TCP")
+	}
+	return nil
+}
+
+// family returns whether the IP fields of r are IPv6, from the type of a or
+// the addresses r matches in a mixed ACL.
+func (a *ACL) family(r *Rule) (bool, error) {
+	var families []bool
+	for _, s := range []string{r.Src, r.Dst} {
+		if s == "" {
+			continue
+		}
+		p, err := netip.ParsePrefix(s)
+		if err != nil {
+			return false, err
+		}
+		families = append(families, p.Addr().Is6())
+	}
+	for _, set := range []string{r.SrcSet, r.DstSet} {
+		if set == "" {
+			continue
+		}
+		prefixes, ok := a.PrefixSets[set]
+		if !ok {
+			return false, fmt.Errorf("unknown prefix set %q", set)
+		}
+		p, err := netip.ParsePrefix(prefixes[0])
+		if err != nil {
+			return false, err
+		}
+		families = append(families, p.Addr().Is6())
+	}
+	is6 := a.Type == oc.Acl_ACL_TYPE_ACL_IPV6
+	if a.Type == oc.Acl_ACL_TYPE_ACL_MIXED && len(families) > 0 {
+		is6 = families[0]
+	}
+	for _, f := range families {
+		if f != is6 {
+			return false, fmt.Errorf("addresses do not match the address family of the rule")
+		}
+	}
+	if r.ICMPType != nil || r.ICMPCode != nil {
+		icmp := uint8(packetmatch.ProtocolICMP)
+		if is6 {
+			icmp = packetmatch.ProtocolICMPv6
+		}
+		if r.Protocol != icmp {
+			return false, fmt.Errorf("ICMP fields need protocol %d", icmp)
+		}
+	}
+	return is6, nil
+}
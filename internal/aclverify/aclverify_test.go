@@ -0,0 +1,291 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aclverify
+
+import (
+	"net/netip"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/open-traffic-generator/snappi/gosnappi"
+	"github.com/openconfig/featureprofiles/internal/otgutils"
+	"github.com/openconfig/featureprofiles/internal/packetmatch"
+	"github.com/openconfig/ondatra/gnmi/oc"
+	"github.com/openconfig/ygot/ygot"
+)
+
+const (
+	accept = oc.Acl_FORWARDING_ACTION_ACCEPT
+	drop   = oc.Acl_FORWARDING_ACTION_DROP
+)
+
+func testACL() *ACL {
+	return New("acl-v4", oc.Acl_ACL_TYPE_ACL_IPV4).
+		AddPrefixSet("servers", "192.0.2.0/28", "192.0.2.64/28").
+		AddPortSet("web", Port(80), Port(443), Ports(8000, 8080)).
+		AddRule(&Rule{Seq: 10, Action: drop, Src: "198.51.100.0/24", Dst: "192.0.2.1/32"}).
+		AddRule(&Rule{Seq: 20, Action: accept, DstSet: "servers", Protocol: packetmatch.ProtocolTCP, DstPortSet: "web"}).
+		AddRule(&Rule{Seq: 30, Action: accept, Protocol: packetmatch.ProtocolICMP, ICMPType: ygot.Uint8(8)}).
+		AddRule(&Rule{Seq: 40, Action: drop, Protocol: packetmatch.ProtocolTCP, TCPFlags: []oc.E_PacketMatchTypes_TCP_FLAGS{oc.PacketMatchTypes_TCP_FLAGS_TCP_SYN}}).
+		AddRule(&Rule{Seq: 50, Action: accept, DSCP: []uint8{46, 48}}).
+		AddRule(&Rule{Seq: 60, Action: accept, Src: "0.0.0.0/0"})
+}
+
+func TestEvaluate(t *testing.T) {
+	a := testACL()
+	addr := netip.MustParseAddr
+	tests := []struct {
+		desc    string
+		p       *Packet
+		wantSeq uint32
+	}{{
+		desc:    "denied host",
+		p:       &Packet{Src: addr("198.51.100.7"), Dst: addr("192.0.2.1"), Protocol: packetmatch.ProtocolTCP, DstPort: 443},
+		wantSeq: 10,
+	}, {
+		desc:    "web server",
+		p:       &Packet{Src: addr("203.0.113.1"), Dst: addr("192.0.2.70"), Protocol: packetmatch.ProtocolTCP, DstPort: 8042, TCPFlags: []oc.E_PacketMatchTypes_TCP_FLAGS{oc.PacketMatchTypes_TCP_FLAGS_TCP_SYN}},
+		wantSeq: 20,
+	}, {
+		desc:    "server outside the set",
+		p:       &Packet{Src: addr("203.0.113.1"), Dst: addr("192.0.2.20"), Protocol: packetmatch.ProtocolTCP, DstPort: 443, TCPFlags: []oc.E_PacketMatchTypes_TCP_FLAGS{oc.PacketMatchTypes_TCP_FLAGS_TCP_SYN, oc.PacketMatchTypes_TCP_FLAGS_TCP_ACK}},
+		wantSeq: 40,
+	}, {
+		desc:    "echo request",
+		p:       &Packet{Src: addr("203.0.113.1"), Dst: addr("192.0.2.20"), Protocol: packetmatch.ProtocolICMP, ICMPType: 8},
+		wantSeq: 30,
+	}, {
+		desc:    "voice",
+		p:       &Packet{Src: addr("203.0.113.1"), Dst: addr("192.0.2.20"), Protocol: packetmatch.ProtocolUDP, DSCP: 46},
+		wantSeq: 50,
+	}, {
+		desc:    "catch all",
+		p:       &Packet{Src: addr("203.0.113.1"), Dst: addr("192.0.2.20"), Protocol: packetmatch.ProtocolUDP},
+		wantSeq: 60,
+	}, {
+		desc: "IPv6 hits implicit deny",
+		p:    &Packet{Src: addr("2001:db8::1"), Dst: addr("2001:db8::2"), Protocol: packetmatch.ProtocolUDP},
+	}}
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			var got uint32
+			if r := a.Evaluate(tc.p); r != nil {
+				got = r.Seq
+			}
+			if got != tc.wantSeq {
+				t.Errorf("Evaluate(%v) hit rule %d, want %d", tc.p, got, tc.wantSeq)
+			}
+		})
+	}
+}
+
+func TestEvaluateL2(t *testing.T) {
+	a := New("acl-l2", oc.Acl_ACL_TYPE_ACL_L2).
+		AddRule(&Rule{Seq: 10, Action: drop, SrcMAC: "02:00:00:00:01:00", SrcMACMask: "ff:ff:ff:ff:ff:00"}).
+		AddRule(&Rule{Seq: 20, Action: accept, EtherType: packetmatch.EtherTypeIPv6})
+	for _, tc := range []struct {
+		p       *Packet
+		wantSeq uint32
+	}{
+		{&Packet{SrcMAC: "02:00:00:00:01:42", EtherType: packetmatch.EtherTypeIPv6}, 10},
+		{&Packet{SrcMAC: "02:00:00:00:02:42", EtherType: packetmatch.EtherTypeIPv6}, 20},
+		{&Packet{SrcMAC: "02:00:00:00:02:42", EtherType: packetmatch.EtherTypeIPv4}, 0},
+	} {
+		var got uint32
+		if r := a.Evaluate(tc.p); r != nil {
+			got = r.Seq
+		}
+		if got != tc.wantSeq {
+			t.Errorf("Evaluate(%v) hit rule %d, want %d", tc.p, got, tc.wantSeq)
+		}
+	}
+}
+
+func TestSample(t *testing.T) {
+	a := testACL()
+	for _, r := range a.SortedRules() {
+		p, err := a.Sample(r, "02:00:01:01:01:01", "02:00:02:01:01:01")
+		if err != nil {
+			t.Errorf("Sample(%v) failed: %v", r, err)
+			continue
+		}
+		if got := a.Evaluate(p); got != r {
+			t.Errorf("Sample(%v) = %v, which hits %v", r, p, got)
+		}
+	}
+
+	// A rule covered entirely by an earlier one cannot be hit.
+	a.AddRule(&Rule{Seq: 70, Action: drop, Dst: "192.0.2.0/24"})
+	if _, err := a.Sample(a.Rules[len(a.Rules)-1], "02:00:01:01:01:01", "02:00:02:01:01:01"); err == nil || !strings.Contains(err.Error(), "shadowed") {
+		t.Errorf("Sample(shadowed rule) got error %v, want shadowed", err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		desc    string
+		a       *ACL
+		wantErr string
+	}{{
+		desc:    "duplicate sequence",
+		a:       New("a", oc.Acl_ACL_TYPE_ACL_IPV4).AddRule(&Rule{Seq: 1}).AddRule(&Rule{Seq: 1}),
+		wantErr: "duplicate sequence",
+	}, {
+		desc:    "unknown prefix set",
+		a:       New("a", oc.Acl_ACL_TYPE_ACL_IPV4).AddRule(&Rule{Seq: 1, SrcSet: "nope"}),
+		wantErr: "unknown prefix set",
+	}, {
+		desc:    "family mismatch",
+		a:       New("a", oc.Acl_ACL_TYPE_ACL_IPV4).AddRule(&Rule{Seq: 1, Src: "2001:db8::/32"}),
+		wantErr: "address family",
+	}, {
+		desc:    "ports without protocol",
+		a:       New("a", oc.Acl_ACL_TYPE_ACL_IPV6).AddRule(&Rule{Seq: 1, DstPort: Port(22)}),
+		wantErr: "need protocol TCP or UDP",
+	}, {
+		desc:    "L2 fields in IP ACL",
+		a:       New("a", oc.Acl_ACL_TYPE_ACL_IPV6).AddRule(&Rule{Seq: 1, EtherType: packetmatch.EtherTypeIPv6}),
+		wantErr: "L2 fields",
+	}}
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			if err := tc.a.Validate(); err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("Validate() got error %v, want %q", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestAclSet(t *testing.T) {
+	a := testACL()
+	set, err := a.AclSet()
+	if err != nil {
+		t.Fatalf("AclSet() failed: %v", err)
+	}
+	if got, want := len(set.AclEntry), 6; got != want {
+		t.Fatalf("AclSet() has %d entries, want %d", got, want)
+	}
+	web := set.AclEntry[20]
+	if got, want := web.GetIpv4().GetDestinationAddressPrefixSet(), "servers"; got != want {
+		t.Errorf("entry 20 destination prefix set %q, want %q", got, want)
+	}
+	if got, want := web.GetTransport().GetDestinationPortSet(), "web"; got != want {
+		t.Errorf("entry 20 destination port set %q, want %q", got, want)
+	}
+	if got, want := set.AclEntry[40].GetTransport().GetExplicitTcpFlags(), []oc.E_PacketMatchTypes_TCP_FLAGS{oc.PacketMatchTypes_TCP_FLAGS_TCP_SYN}; !cmp.Equal(got, want) {
+		t.Errorf("entry 40 TCP flags %v, want %v", got, want)
+	}
+	if got, want := set.AclEntry[50].GetIpv4().GetDscpSet(), []uint8{46, 48}; !cmp.Equal(got, want) {
+		t.Errorf("entry 50 DSCP set %v, want %v", got, want)
+	}
+	if got, want := set.AclEntry[30].GetIpv4().GetIcmpv4().GetType(), oc.Icmpv4Types_TYPE_ECHO; got != want {
+		t.Errorf("entry 30 ICMP type %v, want %v", got, want)
+	}
+
+	ds := a.DefinedSets()
+	if got, want := ds.GetIpv4PrefixSet("servers").GetPrefix(), []string{"192.0.2.0/28", "192.0.2.64/28"}; !cmp.Equal(got, want) {
+		t.Errorf("prefix set servers %v, want %v", got, want)
+	}
+	wantPorts := []oc.DefinedSets_PortSet_Port_Union{oc.UnionUint16(80), oc.UnionUint16(443), oc.UnionString("8000..8080")}
+	if diff := cmp.Diff(wantPorts, ds.GetPortSet("web").GetPort()); diff != "" {
+		t.Errorf("port set web diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestWithNDP(t *testing.T) {
+	a := New("acl-v6", oc.Acl_ACL_TYPE_ACL_IPV6).
+		AddRule(&Rule{Seq: 10, Action: drop, Src: "2001:db8::/32"})
+	nd, err := a.withNDP()
+	if err != nil {
+		t.Fatalf("withNDP() failed: %v", err)
+	}
+	if got, want := len(a.Rules), 1; got != want {
+		t.Errorf("withNDP() changed the rules of a to %d, want %d", got, want)
+	}
+	set, err := nd.AclSet()
+	if err != nil {
+		t.Fatalf("AclSet() failed: %v", err)
+	}
+	want := map[uint32]oc.E_Icmpv6Types_TYPE{
+		950: oc.Icmpv6Types_TYPE_NEIGHBOR_ADVERTISEMENT,
+		960: oc.Icmpv6Types_TYPE_NEIGHBOR_SOLICITATION,
+		970: oc.Icmpv6Types_TYPE_ROUTER_SOLICITATION,
+		980: oc.Icmpv6Types_TYPE_ROUTER_ADVERTISEMENT,
+	}
+	for seq, typ := range want {
+		e := set.GetAclEntry(seq)
+		if got := e.GetIpv6().GetIcmpv6().GetType(); got != typ || e.GetActions().GetForwardingAction() != accept {
+			t.Errorf("entry %d permits ICMPv6 type %v, want %v", seq, got, typ)
+		}
+	}
+
+	a.AddRule(&Rule{Seq: 960, Action: accept, Src: "::/0"})
+	if _, err := a.withNDP(); err == nil || !strings.Contains(err.Error(), "duplicate sequence 960") {
+		t.Errorf("withNDP() error = %v, want duplicate sequence 960", err)
+	}
+	v4 := testACL()
+	if nd, err := v4.withNDP(); err != nil || nd != v4 {
+		t.Errorf("withNDP() of an IPv4 ACL = %v, %v, want it unchanged", nd, err)
+	}
+}
+
+func TestAddFlowsAndCheck(t *testing.T) {
+	a := testACL()
+	top := gosnappi.NewConfig()
+	flows, err := a.AddFlows(top, FlowSpec{TxPort: "port1", RxPort: "port2", SrcMAC: "02:00:01:01:01:01", DstMAC: "02:00:02:01:01:01", Packets: 100, PPS: 100})
+	if err != nil {
+		t.Fatalf("AddFlows() failed: %v", err)
+	}
+	if got, want := len(top.Flows().Items()), len(a.Rules); got != want {
+		t.Fatalf("AddFlows() added %d flows, want %d", got, want)
+	}
+	if got, want := top.Flows().Items()[0].Name(), "acl-v4-10"; got != want {
+		t.Errorf("AddFlows() first flow %q, want %q", got, want)
+	}
+
+	counters := map[string]otgutils.FlowCounters{}
+	before, after := map[uint32]uint64{}, map[uint32]uint64{}
+	for _, f := range flows {
+		c := otgutils.FlowCounters{TxPkts: 100}
+		if f.Rule.Action == accept {
+			c.RxPkts = 100
+		}
+		counters[f.Flow] = c
+		before[f.Rule.Seq], after[f.Rule.Seq] = 5, 105
+	}
+	if errs := Check(flows, counters, before, after); len(errs) != 0 {
+		t.Errorf("Check() = %v, want no errors", errs)
+	}
+
+	after[20] = 50
+	counters["acl-v4-10"] = otgutils.FlowCounters{TxPkts: 100, RxPkts: 3}
+	var got []string
+	for _, err := range Check(flows, counters, before, after) {
+		got = append(got, err.Error())
+	}
+	want := []string{
+		"rule 10 drops flow acl-v4-10, but 3 packets were received",
+		"rule 20: matched 45 packets, want >= 100 sent by flow acl-v4-20",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Check() = %q, want %d errors", got, len(want))
+	}
+	for i := range want {
+		if !strings.HasPrefix(got[i], want[i]) {
+			t.Errorf("Check() error %d = %q, want prefix %q", i, got[i], want[i])
+		}
+	}
+}
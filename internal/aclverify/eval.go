@@ -0,0 +1,290 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aclverify
+
+import (
+	"fmt"
+	"net/netip"
+	"slices"
+
+	"github.com/openconfig/featureprofiles/internal/packetmatch"
+	"github.com/openconfig/ondatra/gnmi/oc"
+)
+
+// Packet is the header fields of a packet an ACL matches on.
+type Packet struct {
+	SrcMAC, DstMAC string
+	EtherType      uint16
+	// Src and Dst are invalid for non-IP packets.
+	Src, Dst         netip.Addr
+	Protocol         uint8
+	DSCP             uint8
+	HopLimit         uint8
+	SrcPort, DstPort uint16
+	TCPFlags         []oc.E_PacketMatchTypes_TCP_FLAGS
+	ICMPType         uint8
+	ICMPCode         uint8
+}
+
+func (p *Packet) String() string {
+	if !p.Src.IsValid() {
+		return fmt.Sprintf("%s > %s ethertype %#04x", p.SrcMAC, p.DstMAC, p.EtherType)
+	}
+	s := fmt.Sprintf("%v > %v proto %d dscp %d hop-limit %d", p.Src, p.Dst, p.Protocol, p.DSCP, p.HopLimit)
+	switch p.Protocol {
+	case packetmatch.ProtocolTCP, packetmatch.ProtocolUDP:
+		s += fmt.Sprintf(" ports %d > %d", p.SrcPort, p.DstPort)
+		if len(p.TCPFlags) > 0 {
+			s += fmt.Sprintf(" flags %v", p.TCPFlags)
+		}
+	case packetmatch.ProtocolICMP, packetmatch.ProtocolICMPv6:
+		s += fmt.Sprintf(" type %d code %d", p.ICMPType, p.ICMPCode)
+	}
+	return s
+}
+
+func (a *ACL) addrMatches(addr netip.Addr, prefix, set string) bool {
+	if prefix != "" {
+		p, err := netip.ParsePrefix(prefix)
+		if err != nil || !p.Contains(addr) {
+			return false
+		}
+	}
+	if set != "" {
+		if !slices.ContainsFunc(a.PrefixSets[set], func(s string) bool {
+			p, err := netip.ParsePrefix(s)
+			return err == nil && p.Contains(addr)
+		}) {
+			return false
+		}
+	}
+	return true
+}
+
+func (a *ACL) portMatches(port uint16, r PortRange, set string) bool {
+	if !r.Contains(port) {
+		return false
+	}
+	if set != "" {
+		return slices.ContainsFunc(a.PortSets[set], func(r PortRange) bool { return r.Contains(port) })
+	}
+	return true
+}
+
+// Matches reports whether p matches r, a rule of a.
+func (a *ACL) Matches(r *Rule, p *Packet) bool {
+	if r.EtherType != 0 && p.EtherType != r.EtherType {
+		return false
+	}
+	if !packetmatch.MACMatches(p.SrcMAC, r.SrcMAC, r.SrcMACMask) || !packetmatch.MACMatches(p.DstMAC, r.DstMAC, r.DstMACMask) {
+		return false
+	}
+	if !r.hasIP() && a.Type != oc.Acl_ACL_TYPE_ACL_IPV4 && a.Type != oc.Acl_ACL_TYPE_ACL_IPV6 {
+		return true
+	}
+
+	is6, err := a.family(r)
+	if err != nil || !p.Src.IsValid() || p.Src.Is6() != is6 {
+		return false
+	}
+	if !a.addrMatches(p.Src, r.Src, r.SrcSet) || !a.addrMatches(p.Dst, r.Dst, r.DstSet) {
+		return false
+	}
+	if r.Protocol != 0 && p.Protocol != r.Protocol {
+		return false
+	}
+	if len(r.DSCP) > 0 && !slices.Contains(r.DSCP, p.DSCP) {
+		return false
+	}
+	if r.HopLimit != nil && p.HopLimit != *r.HopLimit {
+		return false
+	}
+	if r.hasTransport() {
+		if !a.portMatches(p.SrcPort, r.SrcPort, r.SrcPortSet) || !a.portMatches(p.DstPort, r.DstPort, r.DstPortSet) {
+			return false
+		}
+		for _, f := range r.TCPFlags {
+			if !slices.Contains(p.TCPFlags, f) {
+				return false
+			}
+		}
+	}
+	if r.ICMPType != nil && p.ICMPType != *r.ICMPType {
+		return false
+	}
+	if r.ICMPCode != nil && p.ICMPCode != *r.ICMPCode {
+		return false
+	}
+	return true
+}
+
+// Evaluate returns the first rule of a that p matches, or nil if p only
+// matches the implicit deny at the end of the ACL.
+func (a *ACL) Evaluate(p *Packet) *Rule {
+	for _, r := range a.SortedRules() {
+		if a.Matches(r, p) {
+			return r
+		}
+	}
+	return nil
+}
+
+// Permits reports whether a forwards p.
+func (a *ACL) Permits(p *Packet) bool {
+	r := a.Evaluate(p)
+	return r != nil && r.Action == oc.Acl_FORWARDING_ACTION_ACCEPT
+}
+
+// Sample returns a packet that hits r, a rule of a.  Packets are tried from
+// the edges of the ranges and sets of r, so that rules partly shadowed by
+// earlier rules are still reached; it fails only if no tried packet gets
+// past the earlier rules.
+func (a *ACL) Sample(r *Rule, srcMAC, dstMAC string) (*Packet, error) {
+	if err := a.validateRule(r); err != nil {
+		return nil, fmt.Errorf("%v: %w", r, err)
+	}
+	var dims [][]func(*Packet)
+	add := func(opts ...func(*Packet)) { dims = append(dims, opts) }
+
+	add(macCandidates(r.SrcMAC, srcMAC, func(p *Packet, m string) { p.SrcMAC = m })...)
+	add(macCandidates(r.DstMAC, dstMAC, func(p *Packet, m string) { p.DstMAC = m })...)
+
+	ip := r.hasIP() || a.Type == oc.Acl_ACL_TYPE_ACL_IPV4 || a.Type == oc.Acl_ACL_TYPE_ACL_IPV6
+	if !ip {
+		ets := []uint16{r.EtherType}
+		if r.EtherType == 0 {
+			ets = []uint16{packetmatch.EtherTypeIPv4, packetmatch.EtherTypeIPv6}
+		}
+		// Give IP ethertypes an IP header so the DUT does not discard the
+		// packet as malformed before the ACL.
+		add(packetmatch.Values(ets, func(p *Packet, et uint16) {
+			p.EtherType = et
+			switch et {
+			case packetmatch.EtherTypeIPv4:
+				p.Src, p.Dst = packetmatch.DefaultAddr(false, 0), packetmatch.DefaultAddr(false, 1)
+			case packetmatch.EtherTypeIPv6:
+				p.Src, p.Dst = packetmatch.DefaultAddr(true, 0), packetmatch.DefaultAddr(true, 1)
+			default:
+				return
+			}
+			p.Protocol, p.HopLimit, p.SrcPort, p.DstPort = packetmatch.ProtocolUDP, 64, 49152, 49152
+		})...)
+	} else {
+		is6, err := a.family(r)
+		if err != nil {
+			return nil, fmt.Errorf("%v: %w", r, err)
+		}
+		add(func(p *Packet) {
+			p.EtherType = packetmatch.EtherTypeIPv4
+			if is6 {
+				p.EtherType = packetmatch.EtherTypeIPv6
+			}
+		})
+		add(a.addrCandidates(is6, r.Src, r.SrcSet, 1, func(p *Packet, addr netip.Addr) { p.Src = addr })...)
+		add(a.addrCandidates(is6, r.Dst, r.DstSet, 2, func(p *Packet, addr netip.Addr) { p.Dst = addr })...)
+
+		protocols := []uint8{r.Protocol}
+		if r.Protocol == 0 {
+			icmp := uint8(packetmatch.ProtocolICMP)
+			if is6 {
+				icmp = packetmatch.ProtocolICMPv6
+			}
+			protocols = []uint8{packetmatch.ProtocolUDP, packetmatch.ProtocolTCP, icmp}
+		}
+		add(packetmatch.Values(protocols, func(p *Packet, v uint8) { p.Protocol = v })...)
+		add(packetmatch.Values(orDefault(r.DSCP, 0, 10, 46), func(p *Packet, v uint8) { p.DSCP = v })...)
+		add(packetmatch.Values(optDefault(r.HopLimit, 64, 255, 1), func(p *Packet, v uint8) { p.HopLimit = v })...)
+		add(a.portCandidates(r.SrcPort, r.SrcPortSet, func(p *Packet, v uint16) { p.SrcPort = v })...)
+		add(a.portCandidates(r.DstPort, r.DstPortSet, func(p *Packet, v uint16) { p.DstPort = v })...)
+
+		var flagSets [][]oc.E_PacketMatchTypes_TCP_FLAGS
+		if len(r.TCPFlags) > 0 {
+			flagSets = [][]oc.E_PacketMatchTypes_TCP_FLAGS{r.TCPFlags}
+		} else {
+			flagSets = [][]oc.E_PacketMatchTypes_TCP_FLAGS{
+				{oc.PacketMatchTypes_TCP_FLAGS_TCP_SYN},
+				{oc.PacketMatchTypes_TCP_FLAGS_TCP_ACK},
+			}
+		}
+		add(packetmatch.Values(flagSets, func(p *Packet, v []oc.E_PacketMatchTypes_TCP_FLAGS) {
+			if p.Protocol == packetmatch.ProtocolTCP {
+				p.TCPFlags = v
+			} else {
+				p.TCPFlags = nil
+			}
+		})...)
+
+		echo := uint8(8)
+		if is6 {
+			echo = 128
+		}
+		add(packetmatch.Values(optDefault(r.ICMPType, echo, 0), func(p *Packet, v uint8) { p.ICMPType = v })...)
+		add(packetmatch.Values(optDefault(r.ICMPCode, 0), func(p *Packet, v uint8) { p.ICMPCode = v })...)
+	}
+
+	if p := packetmatch.Search(dims, func(p *Packet) bool { return a.Evaluate(p) == r }); p != nil {
+		return p, nil
+	}
+	return nil, fmt.Errorf("%v is shadowed by earlier rules", r)
+}
+
+func orDefault(vals []uint8, defaults ...uint8) []uint8 {
+	if len(vals) > 0 {
+		return vals
+	}
+	return defaults
+}
+
+func optDefault(v *uint8, defaults ...uint8) []uint8 {
+	if v != nil {
+		return []uint8{*v}
+	}
+	return defaults
+}
+
+func macCandidates(rule, def string, set func(*Packet, string)) []func(*Packet) {
+	if rule == "" {
+		return packetmatch.Values([]string{def}, set)
+	}
+	return packetmatch.Values([]string{rule}, set)
+}
+
+// addrCandidates returns setters of the addresses tried for an address
+// field matching prefix and set.
+func (a *ACL) addrCandidates(is6 bool, prefix, set string, offset uint64, assign func(*Packet, netip.Addr)) []func(*Packet) {
+	var strs []string
+	switch {
+	case prefix != "":
+		// Both must match; the prefix narrows the set.
+		strs = []string{prefix}
+	case set != "":
+		strs = a.PrefixSets[set]
+	}
+	var prefixes []netip.Prefix
+	for _, s := range strs {
+		if p, err := netip.ParsePrefix(s); err == nil {
+			prefixes = append(prefixes, p)
+		}
+	}
+	return packetmatch.Values(packetmatch.AddrCandidates(is6, offset, prefixes), assign)
+}
+
+func (a *ACL) portCandidates(r PortRange, set string, assign func(*Packet, uint16)) []func(*Packet) {
+	ranges := []PortRange{r}
+	if r.Any() && set != "" {
+		ranges = a.PortSets[set]
+	}
+	return packetmatch.Values(packetmatch.PortCandidates(ranges), assign)
+}
@@ -0,0 +1,138 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aclverify
+
+import (
+	"fmt"
+
+	"github.com/open-traffic-generator/snappi/gosnappi"
+	"github.com/openconfig/featureprofiles/internal/packetmatch"
+	"github.com/openconfig/ondatra/gnmi/oc"
+)
+
+// FlowSpec describes the flows AddFlows generates.  The DUT must route the
+// destinations of the generated packets to RxPort, e.g. with a default
+// route, for accepted flows to be received.
+type FlowSpec struct {
+	TxPort, RxPort string
+	// SrcMAC is the source MAC of rules that do not match on it, and DstMAC
+	// the destination MAC, normally that of the DUT port.
+	SrcMAC, DstMAC string
+	// Packets is the number of packets of each flow, sent at PPS packets
+	// per second.
+	Packets   uint32
+	PPS       uint64
+	FrameSize uint32
+}
+
+// RuleFlow is a flow sent at a rule.
+type RuleFlow struct {
+	Flow   string
+	Rule   *Rule
+	Packet *Packet
+}
+
+// AddFlows adds to top a flow of packets hitting each rule of a.
+func (a *ACL) AddFlows(top gosnappi.Config, spec FlowSpec) ([]RuleFlow, error) {
+	if err := a.Validate(); err != nil {
+		return nil, err
+	}
+	var flows []RuleFlow
+	for _, r := range a.SortedRules() {
+		p, err := a.Sample(r, spec.SrcMAC, spec.DstMAC)
+		if err != nil {
+			return nil, err
+		}
+		name := fmt.Sprintf("%s-%d", a.Name, r.Seq)
+		addFlow(top, name, p, spec)
+		flows = append(flows, RuleFlow{Flow: name, Rule: r, Packet: p})
+	}
+	return flows, nil
+}
+
+func addFlow(top gosnappi.Config, name string, p *Packet, spec FlowSpec) {
+	flow := top.Flows().Add().SetName(name)
+	flow.Metrics().SetEnable(true)
+	flow.TxRx().Port().SetTxName(spec.TxPort).SetRxNames([]string{spec.RxPort})
+	flow.Duration().FixedPackets().SetPackets(spec.Packets)
+	if spec.PPS > 0 {
+		flow.Rate().SetPps(spec.PPS)
+	}
+	if spec.FrameSize > 0 {
+		flow.Size().SetFixed(spec.FrameSize)
+	}
+
+	eth := flow.Packet().Add().Ethernet()
+	eth.Src().SetValue(p.SrcMAC)
+	eth.Dst().SetValue(p.DstMAC)
+	if !p.Src.IsValid() {
+		eth.EtherType().SetValue(uint32(p.EtherType))
+		return
+	}
+	if p.Src.Is4() {
+		ip := flow.Packet().Add().Ipv4()
+		ip.Src().SetValue(p.Src.String())
+		ip.Dst().SetValue(p.Dst.String())
+		ip.Priority().Dscp().Phb().SetValue(uint32(p.DSCP))
+		ip.TimeToLive().SetValue(uint32(p.HopLimit))
+		ip.Protocol().SetValue(uint32(p.Protocol))
+	} else {
+		ip := flow.Packet().Add().Ipv6()
+		ip.Src().SetValue(p.Src.String())
+		ip.Dst().SetValue(p.Dst.String())
+		ip.TrafficClass().SetValue(uint32(p.DSCP) << 2)
+		ip.HopLimit().SetValue(uint32(p.HopLimit))
+		ip.NextHeader().SetValue(uint32(p.Protocol))
+	}
+
+	switch p.Protocol {
+	case packetmatch.ProtocolTCP:
+		tcp := flow.Packet().Add().Tcp()
+		tcp.SrcPort().SetValue(uint32(p.SrcPort))
+		tcp.DstPort().SetValue(uint32(p.DstPort))
+		for _, f := range p.TCPFlags {
+			switch f {
+			case oc.PacketMatchTypes_TCP_FLAGS_TCP_SYN:
+				tcp.CtlSyn().SetValue(1)
+			case oc.PacketMatchTypes_TCP_FLAGS_TCP_ACK:
+				tcp.CtlAck().SetValue(1)
+			case oc.PacketMatchTypes_TCP_FLAGS_TCP_FIN:
+				tcp.CtlFin().SetValue(1)
+			case oc.PacketMatchTypes_TCP_FLAGS_TCP_RST:
+				tcp.CtlRst().SetValue(1)
+			case oc.PacketMatchTypes_TCP_FLAGS_TCP_PSH:
+				tcp.CtlPsh().SetValue(1)
+			case oc.PacketMatchTypes_TCP_FLAGS_TCP_URG:
+				tcp.CtlUrg().SetValue(1)
+			case oc.PacketMatchTypes_TCP_FLAGS_TCP_ECE:
+				tcp.EcnEcho().SetValue(1)
+			case oc.PacketMatchTypes_TCP_FLAGS_TCP_CWR:
+				tcp.EcnCwr().SetValue(1)
+			}
+		}
+	case packetmatch.ProtocolUDP:
+		udp := flow.Packet().Add().Udp()
+		udp.SrcPort().SetValue(uint32(p.SrcPort))
+		udp.DstPort().SetValue(uint32(p.DstPort))
+	case packetmatch.ProtocolICMP:
+		echo := flow.Packet().Add().Icmp().Echo()
+		echo.Type().SetValue(uint32(p.ICMPType))
+		echo.Code().SetValue(uint32(p.ICMPCode))
+	case packetmatch.ProtocolICMPv6:
+		echo := flow.Packet().Add().Icmpv6().Echo()
+		echo.Type().SetValue(uint32(p.ICMPType))
+		echo.Code().SetValue(uint32(p.ICMPCode))
+	}
+}
@@ -0,0 +1,334 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aclverify
+
+import (
+	"fmt"
+	"net/netip"
+	"slices"
+	"testing"
+
+	"github.com/openconfig/featureprofiles/internal/cfgplugins"
+	"github.com/openconfig/featureprofiles/internal/deviations"
+	"github.com/openconfig/featureprofiles/internal/packetmatch"
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/gnmi"
+	"github.com/openconfig/ondatra/gnmi/oc"
+	"github.com/openconfig/ygot/ygot"
+)
+
+// AclSet returns the OpenConfig ACL set of a.
+func (a *ACL) AclSet() (*oc.Acl_AclSet, error) {
+	if err := a.Validate(); err != nil {
+		return nil, err
+	}
+	set := &oc.Acl_AclSet{Name: ygot.String(a.Name), Type: a.Type}
+	for _, r := range a.Rules {
+		e := set.GetOrCreateAclEntry(r.Seq)
+		if r.Description != "" {
+			e.Description = ygot.String(r.Description)
+		}
+		e.GetOrCreateActions().ForwardingAction = r.Action
+		if r.Log {
+			e.GetOrCreateActions().LogAction = oc.Acl_LOG_ACTION_LOG_SYSLOG
+		}
+		if r.hasL2() {
+			l2 := e.GetOrCreateL2()
+			l2.SourceMac = optString(r.SrcMAC)
+			l2.SourceMacMask = optString(r.SrcMACMask)
+			l2.DestinationMac = optString(r.DstMAC)
+			l2.DestinationMacMask = optString(r.DstMACMask)
+			if r.EtherType != 0 {
+				l2.Ethertype = oc.UnionUint16(r.EtherType)
+			}
+		}
+		if r.hasIP() {
+			is6, _ := a.family(r)
+			if is6 {
+				ip := e.GetOrCreateIpv6()
+				ip.SourceAddress = optString(r.Src)
+				ip.DestinationAddress = optString(r.Dst)
+				ip.SourceAddressPrefixSet = optString(r.SrcSet)
+				ip.DestinationAddressPrefixSet = optString(r.DstSet)
+				if r.Protocol != 0 {
+					ip.Protocol = oc.UnionUint8(r.Protocol)
+				}
+				setDSCP(&ip.Dscp, &ip.DscpSet, r.DSCP)
+				ip.HopLimit = r.HopLimit
+				if r.ICMPType != nil || r.ICMPCode != nil {
+					icmp := ip.GetOrCreateIcmpv6()
+					if r.ICMPType != nil {
+						typ, ok := icmpv6Types[*r.ICMPType]
+						if !ok {
+							return nil, fmt.Errorf("%v: ICMPv6 type %d has no OpenConfig identity", r, *r.ICMPType)
+						}
+						icmp.Type = typ
+					}
+					if r.ICMPCode != nil {
+						icmp.Code = oc.E_Icmpv6Types_CODE(*r.ICMPCode)
+					}
+				}
+			} else {
+				ip := e.GetOrCreateIpv4()
+				ip.SourceAddress = optString(r.Src)
+				ip.DestinationAddress = optString(r.Dst)
+				ip.SourceAddressPrefixSet = optString(r.SrcSet)
+				ip.DestinationAddressPrefixSet = optString(r.DstSet)
+				if r.Protocol != 0 {
+					ip.Protocol = oc.UnionUint8(r.Protocol)
+				}
+				setDSCP(&ip.Dscp, &ip.DscpSet, r.DSCP)
+				ip.HopLimit = r.HopLimit
+				if r.ICMPType != nil || r.ICMPCode != nil {
+					icmp := ip.GetOrCreateIcmpv4()
+					if r.ICMPType != nil {
+						typ, ok := icmpv4Types[*r.ICMPType]
+						if !ok {
+							return nil, fmt.Errorf("%v: ICMP type %d has no OpenConfig identity", r, *r.ICMPType)
+						}
+						icmp.Type = typ
+					}
+					if r.ICMPCode != nil {
+						icmp.Code = oc.E_Icmpv4Types_CODE(*r.ICMPCode)
+					}
+				}
+			}
+		}
+		if r.hasTransport() {
+			tr := e.GetOrCreateTransport()
+			if !r.SrcPort.Any() {
+				tr.SourcePort = portUnion(r.SrcPort)
+			}
+			if !r.DstPort.Any() {
+				tr.DestinationPort = portUnion(r.DstPort)
+			}
+			tr.SourcePortSet = optString(r.SrcPortSet)
+			tr.DestinationPortSet = optString(r.DstPortSet)
+			if len(r.TCPFlags) > 0 {
+				tr.DetailMode = oc.Transport_DetailMode_EXPLICIT
+				tr.ExplicitDetailMatchMode = oc.Transport_ExplicitDetailMatchMode_ALL
+				tr.ExplicitTcpFlags = r.TCPFlags
+			}
+		}
+	}
+	return set, nil
+}
+
+// icmpv4Types and icmpv6Types map ICMP types to their OpenConfig identities.
+var (
+	icmpv4Types = map[uint8]oc.E_Icmpv4Types_TYPE{
+		0:  oc.Icmpv4Types_TYPE_ECHO_REPLY,
+		3:  oc.Icmpv4Types_TYPE_DST_UNREACHABLE,
+		5:  oc.Icmpv4Types_TYPE_REDIRECT,
+		8:  oc.Icmpv4Types_TYPE_ECHO,
+		9:  oc.Icmpv4Types_TYPE_ROUTER_ADVERTISEMENT,
+		10: oc.Icmpv4Types_TYPE_ROUTER_SOLICITATION,
+		11: oc.Icmpv4Types_TYPE_TIME_EXCEEDED,
+		12: oc.Icmpv4Types_TYPE_PARAM_PROBLEM,
+		13: oc.Icmpv4Types_TYPE_TIMESTAMP,
+		14: oc.Icmpv4Types_TYPE_TIMESTAMP_REPLY,
+		30: oc.Icmpv4Types_TYPE_TRACEROUTE,
+		40: oc.Icmpv4Types_TYPE_PHOTURIS,
+		42: oc.Icmpv4Types_TYPE_EXT_ECHO_REQUEST,
+		43: oc.Icmpv4Types_TYPE_EXT_ECHO_REPLY,
+	}
+	icmpv6Types = map[uint8]oc.E_Icmpv6Types_TYPE{
+		1:   oc.Icmpv6Types_TYPE_DESTINATION_UNREACHABLE,
+		2:   oc.Icmpv6Types_TYPE_PACKET_TOO_BIG,
+		3:   oc.Icmpv6Types_TYPE_TIME_EXCEEDED,
+		4:   oc.Icmpv6Types_TYPE_PARAMETER_PROBLEM,
+		128: oc.Icmpv6Types_TYPE_ECHO_REQUEST,
+		129: oc.Icmpv6Types_TYPE_ECHO_REPLY,
+		130: oc.Icmpv6Types_TYPE_MULTICAST_LISTENER_QUERY,
+		131: oc.Icmpv6Types_TYPE_MULTICAST_LISTENER_REPORT,
+		132: oc.Icmpv6Types_TYPE_MULTICAST_LISTENER_DONE,
+		133: oc.Icmpv6Types_TYPE_ROUTER_SOLICITATION,
+		134: oc.Icmpv6Types_TYPE_ROUTER_ADVERTISEMENT,
+		135: oc.Icmpv6Types_TYPE_NEIGHBOR_SOLICITATION,
+		136: oc.Icmpv6Types_TYPE_NEIGHBOR_ADVERTISEMENT,
+		137: oc.Icmpv6Types_TYPE_REDIRECT,
+		138: oc.Icmpv6Types_TYPE_RENUNBERING,
+		139: oc.Icmpv6Types_TYPE_NODE_INFORMATION_QUERY,
+		140: oc.Icmpv6Types_TYPE_NODE_INFORMATION_RESPONSE,
+		141: oc.Icmpv6Types_TYPE_INVERSE_NEIGHBOR_SOLICITATION,
+		142: oc.Icmpv6Types_TYPE_INVERSE_NEIGHBOR_ADVERTISEMENT,
+		143: oc.Icmpv6Types_TYPE_VERSION2_MULTICAST_LISTENER,
+		144: oc.Icmpv6Types_TYPE_HOME_AGENT_ADDRESS_DISCOVERY_REQUEST,
+		145: oc.Icmpv6Types_TYPE_HOME_AGENT_ADDRESS_DISCOVERY_REPLY,
+		146: oc.Icmpv6Types_TYPE_MOBILE_PREFIX_SOLICITATION,
+		147: oc.Icmpv6Types_TYPE_MOBILE_PREFIX_ADVERTISEMENT,
+		148: oc.Icmpv6Types_TYPE_CERTIFICATION_PATH_SOLICITATION,
+		149: oc.Icmpv6Types_TYPE_CERTIFICATION_PATH_ADVERTISEMENT,
+		151: oc.Icmpv6Types_TYPE_MULTICAST_ROUTER_ADVERTISEMENT,
+		152: oc.Icmpv6Types_TYPE_MULTICAST_ROUTER_SOLICITATION,
+		153: oc.Icmpv6Types_TYPE_MULTICAST_ROUTER_TERMINATION,
+		154: oc.Icmpv6Types_TYPE_FMIPV6,
+		155: oc.Icmpv6Types_TYPE_RPL_CONTROL,
+		156: oc.Icmpv6Types_TYPE_ILNPV6_LOCATOR_UPDATE,
+		157: oc.Icmpv6Types_TYPE_DUPLICATE_ADDRESS_REQUEST,
+		158: oc.Icmpv6Types_TYPE_DUPLICATE_ADDRESS_CONFIRMATION,
+		159: oc.Icmpv6Types_TYPE_MPL_CONTROL,
+		160: oc.Icmpv6Types_TYPE_EXT_ECHO_REQUEST,
+		161: oc.Icmpv6Types_TYPE_EXT_ECHO_REPLY,
+	}
+)
+
+// ndpRules permit neighbor discovery ahead of the implicit deny of an IPv6
+// ACL, at the sequences cfgplugins.ConfigureNDPRulesFromCLI uses.
+func ndpRules() []*Rule {
+	var rules []*Rule
+	for i, nd := range []struct {
+		typ  uint8
+		desc string
+	}{
+		{136, "neighbor-advertisement"},
+		{135, "neighbor-solicitation"},
+		{133, "router-solicitation"},
+		{134, "router-advertisement"},
+	} {
+		rules = append(rules, &Rule{
+			Seq:         uint32(cfgplugins.DefaultEntryID - 40 + 10*i),
+			Description: nd.desc,
+			Action:      oc.Acl_FORWARDING_ACTION_ACCEPT,
+			Src:         "::/0",
+			Dst:         "::/0",
+			Protocol:    packetmatch.ProtocolICMPv6,
+			ICMPType:    ygot.Uint8(nd.typ),
+		})
+	}
+	return rules
+}
+
+// withNDP returns a with ndpRules added if it is an IPv6 ACL.  It is an
+// error for a to use their sequences.
+func (a *ACL) withNDP() (*ACL, error) {
+	if a.Type != oc.Acl_ACL_TYPE_ACL_IPV6 {
+		return a, nil
+	}
+	nd := *a
+	nd.Rules = append(slices.Clone(a.Rules), ndpRules()...)
+	if err := nd.Validate(); err != nil {
+		return nil, fmt.Errorf("could not add neighbor discovery entries: %w", err)
+	}
+	return &nd, nil
+}
+
+func optString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return ygot.String(s)
+}
+
+func setDSCP(dscp **uint8, set *[]uint8, vals []uint8) {
+	switch len(vals) {
+	case 0:
+	case 1:
+		*dscp = ygot.Uint8(vals[0])
+	default:
+		*set = vals
+	}
+}
+
+// transportPort is a value of both the source and destination port leaves.
+type transportPort interface {
+	oc.Acl_AclSet_AclEntry_Transport_SourcePort_Union
+	oc.Acl_AclSet_AclEntry_Transport_DestinationPort_Union
+}
+
+func portUnion(r PortRange) transportPort {
+	if r.Lo == r.Hi {
+		return oc.UnionUint16(r.Lo)
+	}
+	return oc.UnionString(r.String())
+}
+
+// DefinedSets returns the prefix and port sets of a.
+func (a *ACL) DefinedSets() *oc.DefinedSets {
+	ds := &oc.DefinedSets{}
+	for name, prefixes := range a.PrefixSets {
+		if p, err := netip.ParsePrefix(prefixes[0]); err == nil && p.Addr().Is6() {
+			ds.GetOrCreateIpv6PrefixSet(name).Prefix = prefixes
+		} else {
+			ds.GetOrCreateIpv4PrefixSet(name).Prefix = prefixes
+		}
+	}
+	for name, ports := range a.PortSets {
+		ps := ds.GetOrCreatePortSet(name)
+		for _, r := range ports {
+			if r.Lo == r.Hi {
+				ps.Port = append(ps.Port, oc.UnionUint16(r.Lo))
+			} else {
+				ps.Port = append(ps.Port, oc.UnionString(r.String()))
+			}
+		}
+	}
+	return ds
+}
+
+// Configure adds to batch the defined sets and ACL set of a, replacing any
+// ACL set of the same name, and applies it to the ingress or egress of intf.
+// An IPv6 ACL also gets ndpRules, so that its implicit deny does not drop
+// neighbor discovery, unless dut needs them configured by ConfigureFromCLI.
+func Configure(t testing.TB, dut *ondatra.DUTDevice, batch *gnmi.SetBatch, a *ACL, intf string, ingress bool) {
+	t.Helper()
+	nd, err := a.withNDP()
+	if err != nil {
+		t.Fatalf("ACL %s: %v", a.Name, err)
+	}
+	if deviations.ACLIcmpTypeCodeConfigurationUnsupported(dut) {
+		nd = a
+	}
+	set, err := nd.AclSet()
+	if err != nil {
+		t.Fatalf("ACL %s: %v", a.Name, err)
+	}
+	ds := a.DefinedSets()
+	for name, s := range ds.Ipv4PrefixSet {
+		gnmi.BatchReplace(batch, gnmi.OC().DefinedSets().Ipv4PrefixSet(name).Config(), s)
+	}
+	for name, s := range ds.Ipv6PrefixSet {
+		gnmi.BatchReplace(batch, gnmi.OC().DefinedSets().Ipv6PrefixSet(name).Config(), s)
+	}
+	for name, s := range ds.PortSet {
+		gnmi.BatchReplace(batch, gnmi.OC().DefinedSets().PortSet(name).Config(), s)
+	}
+	gnmi.BatchReplace(batch, gnmi.OC().Acl().AclSet(a.Name, a.Type).Config(), set)
+
+	iface := &oc.Acl_Interface{Id: ygot.String(intf)}
+	iface.GetOrCreateInterfaceRef().Interface = ygot.String(intf)
+	iface.GetOrCreateInterfaceRef().Subinterface = ygot.Uint32(0)
+	if ingress {
+		iface.GetOrCreateIngressAclSet(a.Name, a.Type)
+	} else {
+		iface.GetOrCreateEgressAclSet(a.Name, a.Type)
+	}
+	gnmi.BatchUpdate(batch, gnmi.OC().Acl().Interface(intf).Config(), iface)
+}
+
+// ConfigureFromCLI configures with CLI what dut does not support in
+// OpenConfig: the per-entry counters GetMatchedPackets reads, and the
+// neighbor discovery entries of an IPv6 ACL.  It must follow the Set of the
+// batch Configure added a to.
+func ConfigureFromCLI(t *testing.T, dut *ondatra.DUTDevice, a *ACL) {
+	t.Helper()
+	params := cfgplugins.AclParams{Name: a.Name, ACLType: a.Type}
+	if deviations.ACLCountersEnableOCUnsupported(dut) {
+		cfgplugins.EnableACLCountersFromCLI(t, dut, params)
+	}
+	if a.Type == oc.Acl_ACL_TYPE_ACL_IPV6 && deviations.ACLIcmpTypeCodeConfigurationUnsupported(dut) {
+		cfgplugins.ConfigureNDPRulesFromCLI(t, dut, params)
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aclverify
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/openconfig/featureprofiles/internal/otgutils"
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/gnmi"
+	"github.com/openconfig/ondatra/gnmi/oc"
+)
+
+// lossTolerancePct is the loss accepted flows may see.
+const lossTolerancePct = 1
+
+// GetMatchedPackets returns the matched packets of each entry of a, as
+// counted on the ingress or egress of intf, or aggregated over interfaces
+// if intf is empty.
+func GetMatchedPackets(t testing.TB, dut *ondatra.DUTDevice, a *ACL, intf string, ingress bool) map[uint32]uint64 {
+	t.Helper()
+	matched := map[uint32]uint64{}
+	switch {
+	case intf == "":
+		for seq, e := range gnmi.Get(t, dut, gnmi.OC().Acl().AclSet(a.Name, a.Type).State()).AclEntry {
+			matched[seq] = e.GetMatchedPackets()
+		}
+	case ingress:
+		for seq, e := range gnmi.Get(t, dut, gnmi.OC().Acl().Interface(intf).IngressAclSet(a.Name, a.Type).State()).AclEntry {
+			matched[seq] = e.GetMatchedPackets()
+		}
+	default:
+		for seq, e := range gnmi.Get(t, dut, gnmi.OC().Acl().Interface(intf).EgressAclSet(a.Name, a.Type).State()).AclEntry {
+			matched[seq] = e.GetMatchedPackets()
+		}
+	}
+	return matched
+}
+
+// Check compares the counters of a run of flows with the rules they were
+// sent at.  Each entry must have matched at least the packets sent at it,
+// accepted flows must be received and dropped flows must not.
+func Check(flows []RuleFlow, counters map[string]otgutils.FlowCounters, before, after map[uint32]uint64) []error {
+	var errs []error
+	for _, f := range flows {
+		c := counters[f.Flow]
+		if c.TxPkts == 0 {
+			errs = append(errs, fmt.Errorf("flow %s: sent no packets", f.Flow))
+			continue
+		}
+		if got, ok := after[f.Rule.Seq]; !ok {
+			errs = append(errs, fmt.Errorf("%v: no matched-packets counter", f.Rule))
+		} else if got-before[f.Rule.Seq] < c.TxPkts {
+			errs = append(errs, fmt.Errorf("%v: matched %d packets, want >= %d sent by flow %s (%v)", f.Rule, got-before[f.Rule.Seq], c.TxPkts, f.Flow, f.Packet))
+		}
+		lossPct := float64(c.TxPkts-min(c.RxPkts, c.TxPkts)) * 100 / float64(c.TxPkts)
+		switch f.Rule.Action {
+		case oc.Acl_FORWARDING_ACTION_ACCEPT:
+			if lossPct > lossTolerancePct {
+				errs = append(errs, fmt.Errorf("%v accepts flow %s, but it lost %.2f%% of packets", f.Rule, f.Flow, lossPct))
+			}
+		default:
+			if c.RxPkts > 0 {
+				errs = append(errs, fmt.Errorf("%v drops flow %s, but %d packets were received", f.Rule, f.Flow, c.RxPkts))
+			}
+		}
+	}
+	return errs
+}
+
+// Verify reads the counters of flows from the ATE and of the entries of a
+// from the DUT, reporting as test errors where they differ from what the
+// rules predict.  before are the entry counters taken before traffic started.
+func Verify(t testing.TB, dut *ondatra.DUTDevice, ate *ondatra.ATEDevice, a *ACL, intf string, ingress bool, flows []RuleFlow, before map[uint32]uint64) {
+	t.Helper()
+	counters := map[string]otgutils.FlowCounters{}
+	for _, f := range flows {
+		counters[f.Flow] = otgutils.GetFlowCounters(t, ate.OTG(), f.Flow)
+	}
+	after := GetMatchedPackets(t, dut, a, intf, ingress)
+	for _, err := range Check(flows, counters, before, after) {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,280 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package allocator provides typed pools for the addresses and identifiers
+// that tests hand out to DUT and ATE ports: IPv4/IPv6 host addresses,
+// point-to-point subnets, MAC addresses, VLAN IDs, MPLS labels, ISIS system
+// IDs and router IDs.
+//
+// All pools are created from a Registry. The registry rejects pools whose
+// ranges overlap an existing pool of the same kind, and rejects reservations
+// that fall into a range owned by another pool, so that overlapping scale
+// configurations fail when the test is built rather than on the device.
+//
+// A registry created with NewSeededRegistry starts every pool at an offset
+// derived from the seed and the pool name, so that allocations are spread
+// across the range but remain identical across runs.
+package allocator
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"net/netip"
+	"sync"
+)
+
+var (
+	// ErrExhausted is returned when a pool has no free values left.
+	ErrExhausted = errors.New("pool exhausted")
+	// ErrCollision is returned when a pool or reservation overlaps a value
+	// owned by another pool.
+	ErrCollision = errors.New("allocation collision")
+)
+
+// Kind is the namespace of a pool. Pools of different kinds never collide.
+type Kind string
+
+const (
+	// KindIP is the namespace shared by all IPv4 and IPv6 pools.
+	KindIP Kind = "ip"
+	// KindMAC is the namespace of MAC address pools.
+	KindMAC Kind = "mac"
+	// KindVLAN is the namespace of VLAN ID pools.
+	KindVLAN Kind = "vlan"
+	// KindLabel is the namespace of MPLS label pools.
+	KindLabel Kind = "label"
+	// KindSystemID is the namespace of ISIS system ID pools.
+	KindSystemID Kind = "system-id"
+)
+
+// prefixClaim records the range of an IP pool.
+type prefixClaim struct {
+	owner  string
+	prefix netip.Prefix
+	// delegates is set for subnet pools; it holds the subnets that have been
+	// handed out and may be used as the range of a child pool.
+	delegates map[netip.Prefix]bool
+}
+
+// rangeClaim records the range of a non-IP pool.
+type rangeClaim struct {
+	owner  string
+	kind   Kind
+	lo, hi uint64
+}
+
+// Registry tracks every pool created from it and detects overlaps between
+// them. A Registry is safe for concurrent use.
+type Registry struct {
+	mu       sync.Mutex
+	seed     int64
+	seeded   bool
+	names    map[string]bool
+	prefixes []*prefixClaim
+	ranges   []*rangeClaim
+}
+
+// NewRegistry returns a registry whose pools allocate sequentially from the
+// start of their range.
+func NewRegistry() *Registry {
+	return &Registry{names: map[string]bool{}}
+}
+
+// NewSeededRegistry returns a registry whose pools start allocating at an
+// offset derived from seed and the pool name.
+func NewSeededRegistry(seed int64) *Registry {
+	r := NewRegistry()
+	r.seed = seed
+	r.seeded = true
+	return r
+}
+
+// startOffset returns the first offset a pool of the given size and name
+// allocates from.
+func (r *Registry) startOffset(name string, size uint64) uint64 {
+	if !r.seeded || size == 0 {
+		return 0
+	}
+	h := fnv.New64a()
+	fmt.Fprintf(h, "%d/%s", r.seed, name)
+	return h.Sum64() % size
+}
+
+// claimName reserves a unique pool name.
+func (r *Registry) claimName(name string) error {
+	if name == "" {
+		return fmt.Errorf("pool name must not be empty")
+	}
+	if r.names[name] {
+		return fmt.Errorf("pool %q already exists", name)
+	}
+	r.names[name] = true
+	return nil
+}
+
+// claimPrefix registers an IP pool over p. A pool may overlap another pool
+// only if p lies within a subnet that the other pool has handed out.
+func (r *Registry) claimPrefix(name string, p netip.Prefix, subnetPool bool) (*prefixClaim, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, c := range r.prefixes {
+		if !c.prefix.Overlaps(p) {
+			continue
+		}
+		if c.delegatedContains(p) {
+			continue
+		}
+		return nil, fmt.Errorf("%w: pool %q range %s overlaps pool %q range %s", ErrCollision, name, p, c.owner, c.prefix)
+	}
+	if err := r.claimName(name); err != nil {
+		return nil, err
+	}
+	c := &prefixClaim{owner: name, prefix: p}
+	if subnetPool {
+		c.delegates = map[netip.Prefix]bool{}
+	}
+	r.prefixes = append(r.prefixes, c)
+	return c, nil
+}
+
+// delegatedContains reports whether p lies within a subnet handed out by the
+// pool that owns c.
+func (c *prefixClaim) delegatedContains(p netip.Prefix) bool {
+	for d := range c.delegates {
+		if d.Bits() <= p.Bits() && d.Contains(p.Addr()) {
+			return true
+		}
+	}
+	return false
+}
+
+// delegate records that the subnet pool owning c handed out p.
+func (r *Registry) delegate(c *prefixClaim, p netip.Prefix, ok bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if ok {
+		c.delegates[p] = true
+	} else {
+		delete(c.delegates, p)
+	}
+}
+
+// Owner returns the name of the innermost IP pool whose range contains a,
+// or the empty string if no pool covers a.
+func (r *Registry) Owner(a netip.Addr) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	owner, bits := "", -1
+	for _, c := range r.prefixes {
+		if c.prefix.Contains(a) && c.prefix.Bits() > bits {
+			owner, bits = c.owner, c.prefix.Bits()
+		}
+	}
+	return owner
+}
+
+// claimRange registers a non-IP pool over [lo, hi].
+func (r *Registry) claimRange(name string, kind Kind, lo, hi uint64) (*rangeClaim, error) {
+	if lo > hi {
+		return nil, fmt.Errorf("pool %q: invalid range [%d, %d]", name, lo, hi)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, c := range r.ranges {
+		if c.kind == kind && lo <= c.hi && c.lo <= hi {
+			return nil, fmt.Errorf("%w: %s pool %q range [%d, %d] overlaps pool %q range [%d, %d]", ErrCollision, kind, name, lo, hi, c.owner, c.lo, c.hi)
+		}
+	}
+	if err := r.claimName(name); err != nil {
+		return nil, err
+	}
+	c := &rangeClaim{owner: name, kind: kind, lo: lo, hi: hi}
+	r.ranges = append(r.ranges, c)
+	return c, nil
+}
+
+// offsets is the bookkeeping shared by every pool: it hands out offsets in
+// [0, size) starting at start and wrapping around, skipping offsets in use.
+type offsets struct {
+	mu     sync.Mutex
+	size   uint64
+	start  uint64
+	cursor uint64
+	used   map[uint64]bool
+}
+
+func newOffsets(size, start uint64) *offsets {
+	return &offsets{size: size, start: start, used: map[uint64]bool{}}
+}
+
+// next returns the next free offset.
+func (o *offsets) next() (uint64, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	for tried := uint64(0); uint64(len(o.used)) < o.size && tried < o.size; tried++ {
+		off := o.start + o.cursor
+		if off < o.start || off >= o.size {
+			off -= o.size
+		}
+		if o.cursor++; o.cursor == o.size {
+			o.cursor = 0
+		}
+		if o.used[off] {
+			continue
+		}
+		o.used[off] = true
+		return off, nil
+	}
+	return 0, ErrExhausted
+}
+
+// reserve marks off as in use.
+func (o *offsets) reserve(off uint64) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if off >= o.size {
+		return fmt.Errorf("offset %d outside pool of size %d", off, o.size)
+	}
+	if o.used[off] {
+		return fmt.Errorf("%w: value already allocated", ErrCollision)
+	}
+	o.used[off] = true
+	return nil
+}
+
+// startAt makes next continue from off.
+func (o *offsets) startAt(off uint64) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if off >= o.size {
+		return fmt.Errorf("offset %d outside pool of size %d", off, o.size)
+	}
+	o.start, o.cursor = off, 0
+	return nil
+}
+
+// release marks off as free.
+func (o *offsets) release(off uint64) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	delete(o.used, off)
+}
+
+// inUse returns the number of allocated offsets.
+func (o *offsets) inUse() int {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return len(o.used)
+}
@@ -0,0 +1,358 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package allocator
+
+import (
+	"errors"
+	"net"
+	"net/netip"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func mustAddrs(t *testing.T, p *AddrPool, n int) []string {
+	t.Helper()
+	addrs, err := p.NextN(n)
+	if err != nil {
+		t.Fatalf("NextN(%d) failed: %v", n, err)
+	}
+	var s []string
+	for _, a := range addrs {
+		s = append(s, a.String())
+	}
+	return s
+}
+
+func TestAddrPool(t *testing.T) {
+	tests := []struct {
+		name   string
+		prefix string
+		count  int
+		want   []string
+	}{{
+		name:   "IPv4/29 skips network and broadcast",
+		prefix: "192.0.2.0/29",
+		count:  6,
+		want:   []string{"192.0.2.1", "192.0.2.2", "192.0.2.3", "192.0.2.4", "192.0.2.5", "192.0.2.6"},
+	}, {
+		name:   "IPv4/31",
+		prefix: "192.0.2.0/31",
+		count:  2,
+		want:   []string{"192.0.2.0", "192.0.2.1"},
+	}, {
+		name:   "unmasked prefix",
+		prefix: "198.18.0.252/22",
+		count:  2,
+		want:   []string{"198.18.0.1", "198.18.0.2"},
+	}, {
+		name:   "IPv6/64",
+		prefix: "2001:db8::/64",
+		count:  2,
+		want:   []string{"2001:db8::1", "2001:db8::2"},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := NewRegistry().NewAddrPool("p", tt.prefix)
+			if err != nil {
+				t.Fatalf("NewAddrPool(%q) failed: %v", tt.prefix, err)
+			}
+			if diff := cmp.Diff(tt.want, mustAddrs(t, p, tt.count)); diff != "" {
+				t.Errorf("NextN() returned diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestAddrPoolExhausted(t *testing.T) {
+	p, err := NewRegistry().NewAddrPool("p", "192.0.2.0/30")
+	if err != nil {
+		t.Fatalf("NewAddrPool() failed: %v", err)
+	}
+	mustAddrs(t, p, 2)
+	if _, err := p.Next(); !errors.Is(err, ErrExhausted) {
+		t.Errorf("Next() on full pool returned err %v, want %v", err, ErrExhausted)
+	}
+	if err := p.Release(netip.MustParseAddr("192.0.2.1")); err != nil {
+		t.Fatalf("Release() failed: %v", err)
+	}
+	if got := mustAddrs(t, p, 1); got[0] != "192.0.2.1" {
+		t.Errorf("Next() after Release() = %v, want 192.0.2.1", got[0])
+	}
+}
+
+func TestAddrPoolReserve(t *testing.T) {
+	p, err := NewRegistry().NewAddrPool("p", "192.0.2.0/29")
+	if err != nil {
+		t.Fatalf("NewAddrPool() failed: %v", err)
+	}
+	if err := p.Reserve(netip.MustParseAddr("192.0.2.1")); err != nil {
+		t.Fatalf("Reserve() failed: %v", err)
+	}
+	if err := p.Reserve(netip.MustParseAddr("192.0.2.1")); !errors.Is(err, ErrCollision) {
+		t.Errorf("second Reserve() returned err %v, want %v", err, ErrCollision)
+	}
+	if err := p.Reserve(netip.MustParseAddr("192.0.2.7")); err == nil {
+		t.Errorf("Reserve() of broadcast address succeeded, want error")
+	}
+	if err := p.Reserve(netip.MustParseAddr("198.51.100.1")); err == nil {
+		t.Errorf("Reserve() outside prefix succeeded, want error")
+	}
+	if diff := cmp.Diff([]string{"192.0.2.2", "192.0.2.3"}, mustAddrs(t, p, 2)); diff != "" {
+		t.Errorf("NextN() returned diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestSubnetPool(t *testing.T) {
+	tests := []struct {
+		name         string
+		parent       string
+		bits         int
+		wantSubnets  []string
+		wantLinkAddr [2]string
+	}{{
+		name:         "IPv4/30",
+		parent:       "192.0.2.0/24",
+		bits:         30,
+		wantSubnets:  []string{"192.0.2.0/30", "192.0.2.4/30", "192.0.2.8/30"},
+		wantLinkAddr: [2]string{"192.0.2.1", "192.0.2.2"},
+	}, {
+		name:         "IPv4/31",
+		parent:       "192.0.2.0/24",
+		bits:         31,
+		wantSubnets:  []string{"192.0.2.0/31", "192.0.2.2/31", "192.0.2.4/31"},
+		wantLinkAddr: [2]string{"192.0.2.0", "192.0.2.1"},
+	}, {
+		name:         "IPv6/127",
+		parent:       "2001:db8::/64",
+		bits:         127,
+		wantSubnets:  []string{"2001:db8::/127", "2001:db8::2/127", "2001:db8::4/127"},
+		wantLinkAddr: [2]string{"2001:db8::", "2001:db8::1"},
+	}, {
+		name:         "IPv6/64 from /32",
+		parent:       "2001:db8::/32",
+		bits:         64,
+		wantSubnets:  []string{"2001:db8::/64", "2001:db8:0:1::/64", "2001:db8:0:2::/64"},
+		wantLinkAddr: [2]string{"2001:db8::1", "2001:db8::2"},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := NewRegistry().NewSubnetPool("p", tt.parent, tt.bits)
+			if err != nil {
+				t.Fatalf("NewSubnetPool() failed: %v", err)
+			}
+			var got []string
+			for range tt.wantSubnets {
+				s, err := p.Next()
+				if err != nil {
+					t.Fatalf("Next() failed: %v", err)
+				}
+				got = append(got, s.String())
+			}
+			if diff := cmp.Diff(tt.wantSubnets, got); diff != "" {
+				t.Errorf("Next() returned diff (-want +got):\n%s", diff)
+			}
+			a, b, err := LinkAddrs(netip.MustParsePrefix(got[0]))
+			if err != nil {
+				t.Fatalf("LinkAddrs() failed: %v", err)
+			}
+			if diff := cmp.Diff(tt.wantLinkAddr, [2]string{a.String(), b.String()}); diff != "" {
+				t.Errorf("LinkAddrs() returned diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestStartAt(t *testing.T) {
+	r := NewSeededRegistry(1)
+	sp, err := r.NewSubnetPool("links", "192.0.2.0/28", 30)
+	if err != nil {
+		t.Fatalf("NewSubnetPool() failed: %v", err)
+	}
+	if err := sp.StartAt(netip.MustParsePrefix("192.0.2.8/30")); err != nil {
+		t.Fatalf("StartAt() failed: %v", err)
+	}
+	var got []string
+	for range 4 {
+		s, err := sp.Next()
+		if err != nil {
+			t.Fatalf("Next() failed: %v", err)
+		}
+		got = append(got, s.String())
+	}
+	// The pool wraps around once it reaches its end.
+	if diff := cmp.Diff([]string{"192.0.2.8/30", "192.0.2.12/30", "192.0.2.0/30", "192.0.2.4/30"}, got); diff != "" {
+		t.Errorf("Next() after StartAt() returned diff (-want +got):\n%s", diff)
+	}
+	if err := sp.StartAt(netip.MustParsePrefix("192.0.2.16/30")); err == nil {
+		t.Errorf("StartAt() outside the pool succeeded, want error")
+	}
+
+	ap, err := r.NewAddrPool("hosts", "198.51.100.0/24")
+	if err != nil {
+		t.Fatalf("NewAddrPool() failed: %v", err)
+	}
+	if err := ap.StartAt(netip.MustParseAddr("198.51.100.200")); err != nil {
+		t.Fatalf("StartAt() failed: %v", err)
+	}
+	if diff := cmp.Diff([]string{"198.51.100.200", "198.51.100.201"}, mustAddrs(t, ap, 2)); diff != "" {
+		t.Errorf("Next() after StartAt() returned diff (-want +got):\n%s", diff)
+	}
+
+	ids, err := r.NewIDPool("nh", Kind("nh"), 1, 100)
+	if err != nil {
+		t.Fatalf("NewIDPool() failed: %v", err)
+	}
+	if err := ids.StartAt(50); err != nil {
+		t.Fatalf("StartAt() failed: %v", err)
+	}
+	if id, err := ids.Next(); err != nil || id != 50 {
+		t.Errorf("Next() after StartAt(50) = %d, %v, want 50", id, err)
+	}
+	if err := ids.StartAt(0); err == nil {
+		t.Errorf("StartAt() below the pool succeeded, want error")
+	}
+}
+
+func TestAddrStep(t *testing.T) {
+	tests := []struct {
+		a, step string
+		n       uint64
+		want    string
+		wantOK  bool
+	}{
+		{a: "192.0.2.1", step: "0.0.0.4", n: 3, want: "192.0.2.13", wantOK: true},
+		{a: "200.1.0.1", step: "0.0.1.0", n: 255, want: "200.1.255.1", wantOK: true},
+		{a: "255.255.255.255", step: "0.0.0.1", n: 1, want: "0.0.0.0"},
+		{a: "2001:db8::1", step: "0:0:0:1::", n: 2, want: "2001:db8:0:2::1", wantOK: true},
+		{a: "2001:db8::1", step: "::1", n: 1 << 63, want: "2001:db8::8000:0:0:1", wantOK: true},
+		{a: "ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff", step: "::1", n: 1, want: "::"},
+		{a: "::", step: "8000::", n: 2, want: "::"},
+		{a: "192.0.2.1", step: "::1", n: 1, want: "invalid IP"},
+	}
+	for _, tt := range tests {
+		got, ok := AddrStep(netip.MustParseAddr(tt.a), netip.MustParseAddr(tt.step), tt.n)
+		if got.String() != tt.want || ok != tt.wantOK {
+			t.Errorf("AddrStep(%s, %s, %d) = %s, %t, want %s, %t", tt.a, tt.step, tt.n, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestCollisions(t *testing.T) {
+	r := NewRegistry()
+	sp, err := r.NewSubnetPool("links", "192.0.2.0/24", 30)
+	if err != nil {
+		t.Fatalf("NewSubnetPool() failed: %v", err)
+	}
+	if _, err := r.NewAddrPool("overlap", "192.0.2.128/25"); !errors.Is(err, ErrCollision) {
+		t.Errorf("NewAddrPool() overlapping a subnet pool returned err %v, want %v", err, ErrCollision)
+	}
+	if _, err := r.NewAddrPool("links", "198.51.100.0/24"); err == nil {
+		t.Errorf("NewAddrPool() with duplicate name succeeded, want error")
+	}
+	s, err := sp.Next()
+	if err != nil {
+		t.Fatalf("Next() failed: %v", err)
+	}
+	child, err := r.NewAddrPool("link0", s.String())
+	if err != nil {
+		t.Fatalf("NewAddrPool() on delegated subnet %s failed: %v", s, err)
+	}
+	if got := r.Owner(netip.MustParseAddr("192.0.2.1")); got != child.Name() {
+		t.Errorf("Owner(192.0.2.1) = %q, want %q", got, child.Name())
+	}
+	if _, err := r.NewAddrPool("link0-dup", s.String()); !errors.Is(err, ErrCollision) {
+		t.Errorf("second NewAddrPool() on %s returned err %v, want %v", s, err, ErrCollision)
+	}
+
+	if _, err := r.NewVLANPool("vlan-a", 100, 199); err != nil {
+		t.Fatalf("NewVLANPool() failed: %v", err)
+	}
+	if _, err := r.NewVLANPool("vlan-b", 150, 250); !errors.Is(err, ErrCollision) {
+		t.Errorf("overlapping NewVLANPool() returned err %v, want %v", err, ErrCollision)
+	}
+	if _, err := r.NewLabelPool("labels", 100, 199); err != nil {
+		t.Errorf("NewLabelPool() with range overlapping a VLAN pool failed: %v", err)
+	}
+	if _, err := r.NewLabelPool("reserved", 0, 15); err == nil {
+		t.Errorf("NewLabelPool() with reserved labels succeeded, want error")
+	}
+}
+
+func TestSeededRegistry(t *testing.T) {
+	alloc := func(seed int64) []string {
+		p, err := NewSeededRegistry(seed).NewAddrPool("p", "198.18.0.0/16")
+		if err != nil {
+			t.Fatalf("NewAddrPool() failed: %v", err)
+		}
+		return mustAddrs(t, p, 3)
+	}
+	a, b := alloc(1), alloc(1)
+	if diff := cmp.Diff(a, b); diff != "" {
+		t.Errorf("allocations with the same seed differ (-first +second):\n%s", diff)
+	}
+	if diff := cmp.Diff(a, alloc(2)); diff == "" {
+		t.Errorf("allocations with different seeds are identical: %v", a)
+	}
+}
+
+func TestMACAndSystemIDPools(t *testing.T) {
+	r := NewRegistry()
+	mp, err := r.NewMACPool("macs", "02:00:00:00:00:ff", 2)
+	if err != nil {
+		t.Fatalf("NewMACPool() failed: %v", err)
+	}
+	var macs []net.HardwareAddr
+	for i := 0; i < 2; i++ {
+		m, err := mp.Next()
+		if err != nil {
+			t.Fatalf("Next() failed: %v", err)
+		}
+		macs = append(macs, m)
+	}
+	if diff := cmp.Diff([]string{"02:00:00:00:00:ff", "02:00:00:00:01:00"}, []string{macs[0].String(), macs[1].String()}); diff != "" {
+		t.Errorf("MACPool.Next() returned diff (-want +got):\n%s", diff)
+	}
+	if _, err := mp.Next(); !errors.Is(err, ErrExhausted) {
+		t.Errorf("Next() on full MAC pool returned err %v, want %v", err, ErrExhausted)
+	}
+
+	step, _ := net.ParseMAC("00:00:00:00:01:00")
+	if m, ok := MACStep(macs[0], step, 2); !ok || m.String() != "02:00:00:00:02:ff" {
+		t.Errorf("MACStep(%s, %s, 2) = %s, %t, want 02:00:00:00:02:ff, true", macs[0], step, m, ok)
+	}
+	last, _ := net.ParseMAC("ff:ff:ff:ff:ff:ff")
+	if m, ok := MACStep(last, step, 1); ok || m.String() != "00:00:00:00:00:ff" {
+		t.Errorf("MACStep(%s, %s, 1) = %s, %t, want 00:00:00:00:00:ff, false", last, step, m, ok)
+	}
+
+	sp, err := r.NewSystemIDPool("sysids", "6400.0000.0001", 10)
+	if err != nil {
+		t.Fatalf("NewSystemIDPool() failed: %v", err)
+	}
+	if err := sp.Reserve(SystemID{0x64, 0, 0, 0, 0, 1}); err != nil {
+		t.Fatalf("Reserve() failed: %v", err)
+	}
+	id, err := sp.Next()
+	if err != nil {
+		t.Fatalf("Next() failed: %v", err)
+	}
+	if got, want := id.String(), "6400.0000.0002"; got != want {
+		t.Errorf("SystemID.String() = %q, want %q", got, want)
+	}
+	if got, want := id.Hex(), "640000000002"; got != want {
+		t.Errorf("SystemID.Hex() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,261 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package allocator
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/bits"
+	"net"
+	"strings"
+)
+
+const (
+	maxVLAN  = 4094
+	minLabel = 16
+	maxLabel = 1<<20 - 1
+	max48    = 1<<48 - 1
+)
+
+// IDPool hands out integer identifiers from a closed range [lo, hi], such as
+// VLAN IDs, MPLS labels or gRIBI next-hop IDs.
+type IDPool struct {
+	name string
+	lo   uint64
+	offs *offsets
+}
+
+// NewIDPool creates a pool of identifiers in [lo, hi] in the given kind's
+// namespace.
+func (r *Registry) NewIDPool(name string, kind Kind, lo, hi uint64) (*IDPool, error) {
+	c, err := r.claimRange(name, kind, lo, hi)
+	if err != nil {
+		return nil, err
+	}
+	size := rangeSize(64)
+	if hi-lo < size {
+		size = hi - lo + 1
+	}
+	return &IDPool{name: c.owner, lo: lo, offs: newOffsets(size, r.startOffset(name, size))}, nil
+}
+
+// NewVLANPool creates a pool of VLAN IDs in [lo, hi].
+func (r *Registry) NewVLANPool(name string, lo, hi uint16) (*IDPool, error) {
+	if lo < 1 || hi > maxVLAN {
+		return nil, fmt.Errorf("pool %q: VLAN range [%d, %d] outside [1, %d]", name, lo, hi, maxVLAN)
+	}
+	return r.NewIDPool(name, KindVLAN, uint64(lo), uint64(hi))
+}
+
+// NewLabelPool creates a pool of MPLS labels in [lo, hi]. Reserved labels
+// 0-15 cannot be allocated.
+func (r *Registry) NewLabelPool(name string, lo, hi uint32) (*IDPool, error) {
+	if lo < minLabel || hi > maxLabel {
+		return nil, fmt.Errorf("pool %q: label range [%d, %d] outside [%d, %d]", name, lo, hi, minLabel, maxLabel)
+	}
+	return r.NewIDPool(name, KindLabel, uint64(lo), uint64(hi))
+}
+
+// Name returns the name of the pool.
+func (p *IDPool) Name() string { return p.name }
+
+// InUse returns the number of identifiers currently allocated.
+func (p *IDPool) InUse() int { return p.offs.inUse() }
+
+// Next returns the next free identifier.
+func (p *IDPool) Next() (uint64, error) {
+	off, err := p.offs.next()
+	if err != nil {
+		return 0, fmt.Errorf("pool %q: %w", p.name, err)
+	}
+	return p.lo + off, nil
+}
+
+// StartAt makes Next continue from id, wrapping around to the start of the
+// pool once its end is reached. It overrides the start of a seeded registry.
+func (p *IDPool) StartAt(id uint64) error {
+	if id < p.lo {
+		return fmt.Errorf("pool %q: %d is below the pool range", p.name, id)
+	}
+	if err := p.offs.startAt(id - p.lo); err != nil {
+		return fmt.Errorf("pool %q: %d: %w", p.name, id, err)
+	}
+	return nil
+}
+
+// Reserve marks id as allocated so that Next never returns it.
+func (p *IDPool) Reserve(id uint64) error {
+	if id < p.lo {
+		return fmt.Errorf("pool %q: %d is below the pool range", p.name, id)
+	}
+	if err := p.offs.reserve(id - p.lo); err != nil {
+		return fmt.Errorf("pool %q: %d: %w", p.name, id, err)
+	}
+	return nil
+}
+
+// Release returns id to the pool.
+func (p *IDPool) Release(id uint64) {
+	if id >= p.lo {
+		p.offs.release(id - p.lo)
+	}
+}
+
+// MACPool hands out consecutive MAC addresses.
+type MACPool struct {
+	name string
+	ids  *IDPool
+}
+
+// NewMACPool creates a pool of count MAC addresses starting at start.
+func (r *Registry) NewMACPool(name, start string, count uint64) (*MACPool, error) {
+	mac, err := net.ParseMAC(start)
+	if err != nil || len(mac) != 6 {
+		return nil, fmt.Errorf("pool %q: invalid MAC %q", name, start)
+	}
+	lo := macToUint(mac)
+	if count == 0 || count-1 > max48-lo {
+		return nil, fmt.Errorf("pool %q: %d MACs from %s overflow 48 bits", name, count, start)
+	}
+	ids, err := r.NewIDPool(name, KindMAC, lo, lo+count-1)
+	if err != nil {
+		return nil, err
+	}
+	return &MACPool{name: name, ids: ids}, nil
+}
+
+// Name returns the name of the pool.
+func (p *MACPool) Name() string { return p.name }
+
+// InUse returns the number of MAC addresses currently allocated.
+func (p *MACPool) InUse() int { return p.ids.InUse() }
+
+// Next returns the next free MAC address.
+func (p *MACPool) Next() (net.HardwareAddr, error) {
+	v, err := p.ids.Next()
+	if err != nil {
+		return nil, err
+	}
+	return uintToMAC(v), nil
+}
+
+// Reserve marks mac as allocated so that Next never returns it.
+func (p *MACPool) Reserve(mac net.HardwareAddr) error {
+	if len(mac) != 6 {
+		return fmt.Errorf("pool %q: invalid MAC %v", p.name, mac)
+	}
+	return p.ids.Reserve(macToUint(mac))
+}
+
+// Release returns mac to the pool.
+func (p *MACPool) Release(mac net.HardwareAddr) {
+	if len(mac) == 6 {
+		p.ids.Release(macToUint(mac))
+	}
+}
+
+// MACStep returns mac + n*step, reading both as 48-bit numbers. The sum
+// wraps around, and ok is false if it did or if mac or step is not 6 bytes.
+func MACStep(mac, step net.HardwareAddr, n uint64) (sum net.HardwareAddr, ok bool) {
+	hi, lo := bits.Mul64(macToUint(step), n)
+	v, carry := bits.Add64(lo, macToUint(mac), 0)
+	return uintToMAC(v & max48), len(mac) == 6 && len(step) == 6 && hi == 0 && carry == 0 && v <= max48
+}
+
+func macToUint(mac net.HardwareAddr) uint64 {
+	var v uint64
+	for _, b := range mac {
+		v = v<<8 | uint64(b)
+	}
+	return v
+}
+
+func uintToMAC(v uint64) net.HardwareAddr {
+	mac := make(net.HardwareAddr, 6)
+	for i := 5; i >= 0; i-- {
+		mac[i] = byte(v)
+		v >>= 8
+	}
+	return mac
+}
+
+// SystemID is a 6-byte ISIS system ID.
+type SystemID [6]byte
+
+// String returns the system ID in dotted form, e.g. "6400.0000.0001", as used
+// in OpenConfig NET addresses.
+func (s SystemID) String() string {
+	h := hex.EncodeToString(s[:])
+	return h[0:4] + "." + h[4:8] + "." + h[8:12]
+}
+
+// Hex returns the system ID as 12 hex digits, e.g. "640000000001", as used
+// by OTG.
+func (s SystemID) Hex() string {
+	return hex.EncodeToString(s[:])
+}
+
+// ParseSystemID parses a system ID in dotted or plain hex form.
+func ParseSystemID(s string) (SystemID, error) {
+	var id SystemID
+	b, err := hex.DecodeString(strings.ReplaceAll(s, ".", ""))
+	if err != nil || len(b) != len(id) {
+		return id, fmt.Errorf("invalid ISIS system ID %q", s)
+	}
+	copy(id[:], b)
+	return id, nil
+}
+
+// SystemIDPool hands out consecutive ISIS system IDs.
+type SystemIDPool struct {
+	name string
+	ids  *IDPool
+}
+
+// NewSystemIDPool creates a pool of count system IDs starting at start.
+func (r *Registry) NewSystemIDPool(name, start string, count uint64) (*SystemIDPool, error) {
+	id, err := ParseSystemID(start)
+	if err != nil {
+		return nil, fmt.Errorf("pool %q: %v", name, err)
+	}
+	lo := macToUint(id[:])
+	if count == 0 || count-1 > max48-lo {
+		return nil, fmt.Errorf("pool %q: %d system IDs from %s overflow 48 bits", name, count, start)
+	}
+	ids, err := r.NewIDPool(name, KindSystemID, lo, lo+count-1)
+	if err != nil {
+		return nil, err
+	}
+	return &SystemIDPool{name: name, ids: ids}, nil
+}
+
+// Name returns the name of the pool.
+func (p *SystemIDPool) Name() string { return p.name }
+
+// Next returns the next free system ID.
+func (p *SystemIDPool) Next() (SystemID, error) {
+	var id SystemID
+	v, err := p.ids.Next()
+	if err != nil {
+		return id, err
+	}
+	copy(id[:], uintToMAC(v))
+	return id, nil
+}
+
+// Reserve marks id as allocated so that Next never returns it.
+func (p *SystemIDPool) Reserve(id SystemID) error {
+	return p.ids.Reserve(macToUint(id[:]))
+}
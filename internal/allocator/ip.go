@@ -0,0 +1,370 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package allocator
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"math/bits"
+	"net/netip"
+)
+
+// AddrPool hands out host addresses from an IPv4 or IPv6 prefix.
+//
+// For IPv4 prefixes shorter than /31 the network and broadcast addresses are
+// never allocated; for IPv6 prefixes shorter than /127 the subnet-router
+// anycast address is never allocated.
+type AddrPool struct {
+	name   string
+	prefix netip.Prefix
+	first  netip.Addr
+	offs   *offsets
+}
+
+// NewAddrPool creates a pool of host addresses from prefix, e.g.
+// "198.51.100.0/24" or "2001:db8::/64".
+func (r *Registry) NewAddrPool(name, prefix string) (*AddrPool, error) {
+	p, err := netip.ParsePrefix(prefix)
+	if err != nil {
+		return nil, fmt.Errorf("pool %q: %v", name, err)
+	}
+	p = p.Masked()
+	c, err := r.claimPrefix(name, p, false)
+	if err != nil {
+		return nil, err
+	}
+	first, size := hostRange(p)
+	return &AddrPool{
+		name:   c.owner,
+		prefix: p,
+		first:  first,
+		offs:   newOffsets(size, r.startOffset(name, size)),
+	}, nil
+}
+
+// NewRouterIDPool creates a pool of IPv4 router IDs from prefix.
+func (r *Registry) NewRouterIDPool(name, prefix string) (*AddrPool, error) {
+	p, err := netip.ParsePrefix(prefix)
+	if err != nil {
+		return nil, fmt.Errorf("pool %q: %v", name, err)
+	}
+	if !p.Addr().Is4() {
+		return nil, fmt.Errorf("pool %q: router IDs must be IPv4, got %s", name, prefix)
+	}
+	return r.NewAddrPool(name, prefix)
+}
+
+// hostRange returns the first allocatable address of p and the number of
+// allocatable addresses, capped at math.MaxUint64.
+func hostRange(p netip.Prefix) (netip.Addr, uint64) {
+	hostBits := p.Addr().BitLen() - p.Bits()
+	skip := 0
+	switch {
+	case p.Addr().Is4() && hostBits > 1:
+		skip = 2
+	case p.Addr().Is6() && hostBits > 1:
+		skip = 1
+	}
+	size := rangeSize(hostBits) - uint64(skip)
+	first := p.Addr()
+	if skip > 0 {
+		first = first.Next()
+	}
+	return first, size
+}
+
+// rangeSize returns 2^n capped at math.MaxUint64.
+func rangeSize(n int) uint64 {
+	if n >= 64 {
+		return math.MaxUint64
+	}
+	return 1 << n
+}
+
+// Name returns the name of the pool.
+func (p *AddrPool) Name() string { return p.name }
+
+// Prefix returns the prefix the pool allocates from.
+func (p *AddrPool) Prefix() netip.Prefix { return p.prefix }
+
+// InUse returns the number of addresses currently allocated.
+func (p *AddrPool) InUse() int { return p.offs.inUse() }
+
+// Next returns the next free address.
+func (p *AddrPool) Next() (netip.Addr, error) {
+	off, err := p.offs.next()
+	if err != nil {
+		return netip.Addr{}, fmt.Errorf("pool %q (%s): %w", p.name, p.prefix, err)
+	}
+	return addrAdd(p.first, off, 0), nil
+}
+
+// NextN returns the next n free addresses.
+func (p *AddrPool) NextN(n int) ([]netip.Addr, error) {
+	addrs := make([]netip.Addr, 0, n)
+	for i := 0; i < n; i++ {
+		a, err := p.Next()
+		if err != nil {
+			return nil, err
+		}
+		addrs = append(addrs, a)
+	}
+	return addrs, nil
+}
+
+// StartAt makes Next continue from a, wrapping around to the start of the
+// pool once its end is reached. It overrides the start of a seeded registry.
+func (p *AddrPool) StartAt(a netip.Addr) error {
+	off, err := p.offset(a)
+	if err != nil {
+		return err
+	}
+	return p.offs.startAt(off)
+}
+
+// Reserve marks a as allocated so that Next never returns it.
+func (p *AddrPool) Reserve(a netip.Addr) error {
+	off, err := p.offset(a)
+	if err != nil {
+		return err
+	}
+	if err := p.offs.reserve(off); err != nil {
+		return fmt.Errorf("pool %q: %s: %w", p.name, a, err)
+	}
+	return nil
+}
+
+// Release returns a to the pool.
+func (p *AddrPool) Release(a netip.Addr) error {
+	off, err := p.offset(a)
+	if err != nil {
+		return err
+	}
+	p.offs.release(off)
+	return nil
+}
+
+func (p *AddrPool) offset(a netip.Addr) (uint64, error) {
+	off, ok := addrDiff(p.first, a, 0)
+	if !ok || !p.prefix.Contains(a) || off >= p.offs.size {
+		return 0, fmt.Errorf("pool %q: %s is not an allocatable address of %s", p.name, a, p.prefix)
+	}
+	return off, nil
+}
+
+// SubnetPool carves fixed-length subnets, such as /30 or /31 point-to-point
+// links or /127 IPv6 links, out of a parent prefix. Subnets handed out by the
+// pool may be used as the prefix of a child AddrPool or SubnetPool.
+type SubnetPool struct {
+	reg    *Registry
+	claim  *prefixClaim
+	name   string
+	parent netip.Prefix
+	bits   int
+	offs   *offsets
+}
+
+// NewSubnetPool creates a pool of /bits subnets from parent.
+func (r *Registry) NewSubnetPool(name, parent string, bits int) (*SubnetPool, error) {
+	p, err := netip.ParsePrefix(parent)
+	if err != nil {
+		return nil, fmt.Errorf("pool %q: %v", name, err)
+	}
+	p = p.Masked()
+	if bits < p.Bits() || bits > p.Addr().BitLen() {
+		return nil, fmt.Errorf("pool %q: cannot carve /%d subnets from %s", name, bits, p)
+	}
+	c, err := r.claimPrefix(name, p, true)
+	if err != nil {
+		return nil, err
+	}
+	size := rangeSize(bits - p.Bits())
+	return &SubnetPool{
+		reg:    r,
+		claim:  c,
+		name:   name,
+		parent: p,
+		bits:   bits,
+		offs:   newOffsets(size, r.startOffset(name, size)),
+	}, nil
+}
+
+// Name returns the name of the pool.
+func (p *SubnetPool) Name() string { return p.name }
+
+// Parent returns the prefix the pool carves subnets from.
+func (p *SubnetPool) Parent() netip.Prefix { return p.parent }
+
+// InUse returns the number of subnets currently allocated.
+func (p *SubnetPool) InUse() int { return p.offs.inUse() }
+
+// Next returns the next free subnet.
+func (p *SubnetPool) Next() (netip.Prefix, error) {
+	off, err := p.offs.next()
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("pool %q (%s): %w", p.name, p.parent, err)
+	}
+	s := netip.PrefixFrom(addrAdd(p.parent.Addr(), off, p.shift()), p.bits)
+	p.reg.delegate(p.claim, s, true)
+	return s, nil
+}
+
+// StartAt makes Next continue from s, wrapping around to the start of the
+// pool once its end is reached. It overrides the start of a seeded registry.
+func (p *SubnetPool) StartAt(s netip.Prefix) error {
+	off, err := p.offset(s)
+	if err != nil {
+		return err
+	}
+	return p.offs.startAt(off)
+}
+
+// Reserve marks s as allocated so that Next never returns it.
+func (p *SubnetPool) Reserve(s netip.Prefix) error {
+	off, err := p.offset(s)
+	if err != nil {
+		return err
+	}
+	if err := p.offs.reserve(off); err != nil {
+		return fmt.Errorf("pool %q: %s: %w", p.name, s, err)
+	}
+	return nil
+}
+
+// Release returns s to the pool.
+func (p *SubnetPool) Release(s netip.Prefix) error {
+	off, err := p.offset(s)
+	if err != nil {
+		return err
+	}
+	p.offs.release(off)
+	p.reg.delegate(p.claim, s, false)
+	return nil
+}
+
+func (p *SubnetPool) shift() int {
+	return p.parent.Addr().BitLen() - p.bits
+}
+
+func (p *SubnetPool) offset(s netip.Prefix) (uint64, error) {
+	if s.Bits() != p.bits || s.Masked() != s || !p.parent.Contains(s.Addr()) {
+		return 0, fmt.Errorf("pool %q: %s is not a /%d subnet of %s", p.name, s, p.bits, p.parent)
+	}
+	off, ok := addrDiff(p.parent.Addr(), s.Addr(), p.shift())
+	if !ok || off >= p.offs.size {
+		return 0, fmt.Errorf("pool %q: %s is outside the pool range", p.name, s)
+	}
+	return off, nil
+}
+
+// LinkAddrs returns the two addresses conventionally used on either end of a
+// point-to-point subnet: the two addresses of a /31 or /127, and the first two
+// host addresses of any shorter prefix.
+func LinkAddrs(s netip.Prefix) (netip.Addr, netip.Addr, error) {
+	hostBits := s.Addr().BitLen() - s.Bits()
+	a := s.Masked().Addr()
+	switch {
+	case hostBits == 1:
+		return a, a.Next(), nil
+	case hostBits > 1:
+		return a.Next(), a.Next().Next(), nil
+	}
+	return netip.Addr{}, netip.Addr{}, fmt.Errorf("%s has no room for two link addresses", s)
+}
+
+// AddrStep returns a + n*step, where step is an address of the same family
+// read as a number, such as 0.0.1.0 or 0:0:0:1::. The sum wraps around the
+// address space, and ok is false if it did. ok is also false, and the sum
+// invalid, if a and step are of different families.
+func AddrStep(a, step netip.Addr, n uint64) (sum netip.Addr, ok bool) {
+	switch {
+	case a.Is4() && step.Is4():
+		a4, s4 := a.As4(), step.As4()
+		hi, lo := bits.Mul64(uint64(binary.BigEndian.Uint32(s4[:])), n)
+		v, carry := bits.Add64(lo, uint64(binary.BigEndian.Uint32(a4[:])), 0)
+		var b [4]byte
+		binary.BigEndian.PutUint32(b[:], uint32(v))
+		return netip.AddrFrom4(b), hi == 0 && carry == 0 && v <= math.MaxUint32
+	case a.Is6() && step.Is6():
+		ahi, alo := split(a)
+		shi, slo := split(step)
+		h1, l1 := bits.Mul64(slo, n)
+		h2, l2 := bits.Mul64(shi, n)
+		phi, c1 := bits.Add64(h1, l2, 0)
+		lo, c2 := bits.Add64(alo, l1, 0)
+		hi, c3 := bits.Add64(ahi, phi, c2)
+		return join(hi, lo, false), h2 == 0 && c1 == 0 && c3 == 0
+	}
+	return netip.Addr{}, false
+}
+
+// addrAdd returns a + (off << shift).
+func addrAdd(a netip.Addr, off uint64, shift int) netip.Addr {
+	hi, lo := split(a)
+	ohi, olo := shl(off, shift)
+	var carry uint64
+	lo, carry = bits.Add64(lo, olo, 0)
+	hi, _ = bits.Add64(hi, ohi, carry)
+	return join(hi, lo, a.Is4())
+}
+
+// addrDiff returns (b - a) >> shift, and false if b < a or the result does
+// not fit in 64 bits.
+func addrDiff(a, b netip.Addr, shift int) (uint64, bool) {
+	if a.BitLen() != b.BitLen() || b.Less(a) {
+		return 0, false
+	}
+	ahi, alo := split(a)
+	bhi, blo := split(b)
+	lo, borrow := bits.Sub64(blo, alo, 0)
+	hi, _ := bits.Sub64(bhi, ahi, borrow)
+	if shift >= 64 {
+		lo, hi = hi>>(shift-64), 0
+	} else if shift > 0 {
+		lo = lo>>shift | hi<<(64-shift)
+		hi >>= shift
+	}
+	return lo, hi == 0
+}
+
+func split(a netip.Addr) (uint64, uint64) {
+	b := a.As16()
+	return binary.BigEndian.Uint64(b[:8]), binary.BigEndian.Uint64(b[8:])
+}
+
+func join(hi, lo uint64, is4 bool) netip.Addr {
+	var b [16]byte
+	binary.BigEndian.PutUint64(b[:8], hi)
+	binary.BigEndian.PutUint64(b[8:], lo)
+	a := netip.AddrFrom16(b)
+	if is4 {
+		return a.Unmap()
+	}
+	return a
+}
+
+// shl returns the 128-bit value v << n as (hi, lo).
+func shl(v uint64, n int) (uint64, uint64) {
+	switch {
+	case n == 0:
+		return 0, v
+	case n >= 128:
+		return 0, 0
+	case n >= 64:
+		return v << (n - 64), 0
+	}
+	return v >> (64 - n), v << n
+}
@@ -0,0 +1,490 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package bgprib reads the BGP RIB state of a DUT, under
+// /network-instances/network-instance/protocols/protocol/bgp/rib, and checks
+// per-prefix attributes and best-path selection.
+//
+// The RIB is flattened into routes that carry their decoded attribute set, so
+// a policy test can assert what the DUT actually received, selected and
+// advertised:
+//
+//	rib := bgprib.Await(t, dut, deviations.DefaultNetworkInstance(dut), "BGP", time.Minute,
+//	  &bgprib.Expectation{
+//	    Table:       bgprib.AdjRIBInPost,
+//	    AFISAFI:     oc.BgpTypes_AFI_SAFI_TYPE_IPV4_UNICAST,
+//	    Neighbor:    "192.0.2.2",
+//	    Prefix:      "198.51.100.0/24",
+//	    LocalPref:   ygot.Uint32(200),
+//	    Communities: []string{"65000:100"},
+//	  },
+//	  &bgprib.Expectation{
+//	    Table:    bgprib.LocRIB,
+//	    AFISAFI:  oc.BgpTypes_AFI_SAFI_TYPE_IPV4_UNICAST,
+//	    Prefix:   "198.51.100.0/24",
+//	    BestFrom: "192.0.2.2",
+//	  })
+package bgprib
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/openconfig/ondatra/gnmi/oc"
+)
+
+// Table is one of the RIBs of a BGP speaker.
+type Table int
+
+const (
+	// LocRIB holds the routes selected by the DUT.
+	LocRIB Table = iota
+	// AdjRIBInPre holds the routes received from a neighbor, before import
+	// policy.
+	AdjRIBInPre
+	// AdjRIBInPost holds the routes received from a neighbor, after import
+	// policy.
+	AdjRIBInPost
+	// AdjRIBOutPre holds the routes to advertise to a neighbor, before export
+	// policy.
+	AdjRIBOutPre
+	// AdjRIBOutPost holds the routes advertised to a neighbor, after export
+	// policy.
+	AdjRIBOutPost
+)
+
+func (t Table) String() string {
+	switch t {
+	case LocRIB:
+		return "loc-rib"
+	case AdjRIBInPre:
+		return "adj-rib-in-pre"
+	case AdjRIBInPost:
+		return "adj-rib-in-post"
+	case AdjRIBOutPre:
+		return "adj-rib-out-pre"
+	case AdjRIBOutPost:
+		return "adj-rib-out-post"
+	}
+	return fmt.Sprintf("Table(%d)", int(t))
+}
+
+// Attributes is a decoded attribute set of a route.
+type Attributes struct {
+	Origin    oc.E_RibBgp_BgpOriginAttrType
+	ASPath    []ASSegment
+	NextHop   string
+	MED       *uint32
+	LocalPref *uint32
+	// Communities are standard communities as "AS:value", or the names of
+	// well-known communities such as "NO_EXPORT".
+	Communities []string
+	// ExtCommunities are extended communities in OpenConfig string form,
+	// such as "route-target:65000:100" or "link-bandwidth:65000:1000000".
+	ExtCommunities []string
+	// LargeCommunities are large communities as "global:local1:local2".
+	LargeCommunities []string
+	OriginatorID     string
+	ClusterList      []string
+	AtomicAggregate  bool
+	AIGP             *uint64
+}
+
+// ASSegment is a segment of an AS path.
+type ASSegment struct {
+	Type    oc.E_RibBgp_AsPathSegmentType
+	Members []uint32
+}
+
+// ASPathMembers returns the ASes of the path in order, flattening sets.
+func (a *Attributes) ASPathMembers() []uint32 {
+	var asns []uint32
+	for _, s := range a.ASPath {
+		asns = append(asns, s.Members...)
+	}
+	return asns
+}
+
+// ASPathString formats the AS path the way routers display it, with sets
+// in braces.
+func (a *Attributes) ASPathString() string {
+	var parts []string
+	for _, s := range a.ASPath {
+		var ms []string
+		for _, m := range s.Members {
+			ms = append(ms, strconv.FormatUint(uint64(m), 10))
+		}
+		switch s.Type {
+		case oc.RibBgp_AsPathSegmentType_AS_SET, oc.RibBgp_AsPathSegmentType_AS_CONFED_SET:
+			parts = append(parts, "{"+strings.Join(ms, " ")+"}")
+		default:
+			parts = append(parts, ms...)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// LinkBandwidth is a decoded link-bandwidth extended community.
+type LinkBandwidth struct {
+	AS uint32
+	// BitsPerSecond is the bandwidth.  The community itself carries bytes per
+	// second.
+	BitsPerSecond float64
+}
+
+// LinkBandwidths returns the link-bandwidth extended communities.
+func (a *Attributes) LinkBandwidths() []LinkBandwidth {
+	var lbs []LinkBandwidth
+	for _, c := range a.ExtCommunities {
+		if lb, ok := ParseLinkBandwidth(c); ok {
+			lbs = append(lbs, lb)
+		}
+	}
+	return lbs
+}
+
+// ParseLinkBandwidth parses "link-bandwidth:AS:bandwidth", where the
+// bandwidth is in bits per second with an optional K, M or G suffix.
+func ParseLinkBandwidth(s string) (LinkBandwidth, bool) {
+	parts := strings.Split(s, ":")
+	if len(parts) != 3 || parts[0] != "link-bandwidth" {
+		return LinkBandwidth{}, false
+	}
+	as, err := strconv.ParseUint(parts[1], 10, 32)
+	if err != nil {
+		return LinkBandwidth{}, false
+	}
+	bw, mult := parts[2], 1.0
+	switch {
+	case strings.HasSuffix(bw, "K"):
+		bw, mult = strings.TrimSuffix(bw, "K"), 1e3
+	case strings.HasSuffix(bw, "M"):
+		bw, mult = strings.TrimSuffix(bw, "M"), 1e6
+	case strings.HasSuffix(bw, "G"):
+		bw, mult = strings.TrimSuffix(bw, "G"), 1e9
+	}
+	v, err := strconv.ParseFloat(bw, 64)
+	if err != nil {
+		return LinkBandwidth{}, false
+	}
+	return LinkBandwidth{AS: uint32(as), BitsPerSecond: v * mult}, true
+}
+
+// Route is a path in one of the RIBs.
+type Route struct {
+	Table   Table
+	AFISAFI oc.E_BgpTypes_AFI_SAFI_TYPE
+	// Neighbor is the neighbor of an Adj-RIB, and empty for the Loc-RIB.
+	Neighbor string
+	Prefix   string
+	PathID   uint32
+	// Origin is the neighbor address or protocol a Loc-RIB route came from.
+	Origin        string
+	Valid         bool
+	InvalidReason oc.E_RibBgpTypes_INVALID_ROUTE_REASON
+	// BestPath is reported for Adj-RIB-In-Post routes.
+	BestPath bool
+	Attrs    *Attributes
+}
+
+func (r *Route) String() string {
+	s := fmt.Sprintf("%v %v %s", r.Table, r.AFISAFI, r.Prefix)
+	if r.Neighbor != "" {
+		s += " from " + r.Neighbor
+	}
+	if r.PathID != 0 {
+		s += fmt.Sprintf(" path-id %d", r.PathID)
+	}
+	return s
+}
+
+// RIB is the flattened BGP RIB of a network instance.
+type RIB struct {
+	Routes []*Route
+}
+
+// ocRoute is implemented by the route structs of all tables.
+type ocRoute interface {
+	GetPrefix() string
+	GetPathId() uint32
+	GetAttrIndex() uint64
+	GetCommunityIndex() uint64
+	GetExtCommunityIndex() uint64
+	GetValidRoute() bool
+	GetInvalidReason() oc.E_RibBgpTypes_INVALID_ROUTE_REASON
+}
+
+// New flattens an OpenConfig BGP RIB.
+func New(rib *oc.NetworkInstance_Protocol_Bgp_Rib) *RIB {
+	r := &RIB{}
+	if rib == nil {
+		return r
+	}
+	add := func(table Table, afisafi oc.E_BgpTypes_AFI_SAFI_TYPE, neighbor string, route ocRoute) {
+		rt := &Route{
+			Table:         table,
+			AFISAFI:       afisafi,
+			Neighbor:      neighbor,
+			Prefix:        route.GetPrefix(),
+			PathID:        route.GetPathId(),
+			Valid:         route.GetValidRoute(),
+			InvalidReason: route.GetInvalidReason(),
+			Attrs:         attributes(rib, route),
+		}
+		if bp, ok := route.(interface{ GetBestPath() bool }); ok {
+			rt.BestPath = bp.GetBestPath()
+		}
+		// Only Loc-RIB routes have an origin.
+		if o := reflect.ValueOf(route).Elem().FieldByName("Origin"); o.IsValid() && !o.IsNil() {
+			rt.Origin = unionString(o.Interface())
+		}
+		r.Routes = append(r.Routes, rt)
+	}
+	for afisafi, as := range rib.AfiSafi {
+		if v4 := as.GetIpv4Unicast(); v4 != nil {
+			if lr := v4.GetLocRib(); lr != nil {
+				addRoutes(add, LocRIB, afisafi, "", lr.Route)
+			}
+			for addr, n := range v4.Neighbor {
+				if t := n.GetAdjRibInPre(); t != nil {
+					addRoutes(add, AdjRIBInPre, afisafi, addr, t.Route)
+				}
+				if t := n.GetAdjRibInPost(); t != nil {
+					addRoutes(add, AdjRIBInPost, afisafi, addr, t.Route)
+				}
+				if t := n.GetAdjRibOutPre(); t != nil {
+					addRoutes(add, AdjRIBOutPre, afisafi, addr, t.Route)
+				}
+				if t := n.GetAdjRibOutPost(); t != nil {
+					addRoutes(add, AdjRIBOutPost, afisafi, addr, t.Route)
+				}
+			}
+		}
+		if v6 := as.GetIpv6Unicast(); v6 != nil {
+			if lr := v6.GetLocRib(); lr != nil {
+				addRoutes(add, LocRIB, afisafi, "", lr.Route)
+			}
+			for addr, n := range v6.Neighbor {
+				if t := n.GetAdjRibInPre(); t != nil {
+					addRoutes(add, AdjRIBInPre, afisafi, addr, t.Route)
+				}
+				if t := n.GetAdjRibInPost(); t != nil {
+					addRoutes(add, AdjRIBInPost, afisafi, addr, t.Route)
+				}
+				if t := n.GetAdjRibOutPre(); t != nil {
+					addRoutes(add, AdjRIBOutPre, afisafi, addr, t.Route)
+				}
+				if t := n.GetAdjRibOutPost(); t != nil {
+					addRoutes(add, AdjRIBOutPost, afisafi, addr, t.Route)
+				}
+			}
+		}
+	}
+	sort.Slice(r.Routes, func(i, j int) bool {
+		a, b := r.Routes[i], r.Routes[j]
+		switch {
+		case a.Table != b.Table:
+			return a.Table < b.Table
+		case a.AFISAFI != b.AFISAFI:
+			return a.AFISAFI < b.AFISAFI
+		case a.Neighbor != b.Neighbor:
+			return a.Neighbor < b.Neighbor
+		case a.Prefix != b.Prefix:
+			return a.Prefix < b.Prefix
+		case a.PathID != b.PathID:
+			return a.PathID < b.PathID
+		}
+		return a.Origin < b.Origin
+	})
+	return r
+}
+
+func addRoutes[K comparable, R ocRoute](add func(Table, oc.E_BgpTypes_AFI_SAFI_TYPE, string, ocRoute), table Table, afisafi oc.E_BgpTypes_AFI_SAFI_TYPE, neighbor string, routes map[K]R) {
+	for _, route := range routes {
+		add(table, afisafi, neighbor, route)
+	}
+}
+
+// unionString formats an OpenConfig union value.
+func unionString(v any) string {
+	switch v := v.(type) {
+	case oc.UnionString:
+		return string(v)
+	case oc.UnionUint32:
+		return fmt.Sprintf("%d:%d", uint32(v)>>16, uint32(v)&0xffff)
+	case oc.Binary:
+		return decodeExtCommunity(v)
+	case fmt.Stringer:
+		return v.String()
+	}
+	return fmt.Sprint(v)
+}
+
+// largeCommunityType is the BGP path attribute type of large communities,
+// which are only available as an unknown attribute.
+const largeCommunityType = 32
+
+func attributes(rib *oc.NetworkInstance_Protocol_Bgp_Rib, route ocRoute) *Attributes {
+	a := &Attributes{}
+	if set := rib.GetAttrSet(route.GetAttrIndex()); set != nil {
+		a.Origin = set.GetOrigin()
+		a.NextHop = set.GetNextHop()
+		a.MED = set.Med
+		a.LocalPref = set.LocalPref
+		a.OriginatorID = set.GetOriginatorId()
+		a.ClusterList = set.GetClusterList()
+		a.AtomicAggregate = set.GetAtomicAggregate()
+		a.AIGP = set.Aigp
+		// AS4 segments are only reported by speakers using 2-byte ASNs.
+		if len(set.As4Segment) > 0 {
+			for _, i := range sortedKeys(set.As4Segment) {
+				s := set.As4Segment[i]
+				a.ASPath = append(a.ASPath, ASSegment{Type: s.GetType(), Members: s.GetMember()})
+			}
+		} else {
+			for _, i := range sortedKeys(set.AsSegment) {
+				s := set.AsSegment[i]
+				a.ASPath = append(a.ASPath, ASSegment{Type: s.GetType(), Members: s.GetMember()})
+			}
+		}
+	}
+	if c := rib.GetCommunity(route.GetCommunityIndex()); c != nil {
+		for _, v := range c.GetCommunity() {
+			a.Communities = append(a.Communities, unionString(v))
+		}
+	}
+	if c := rib.GetExtCommunity(route.GetExtCommunityIndex()); c != nil {
+		for _, v := range c.GetExtCommunity() {
+			a.ExtCommunities = append(a.ExtCommunities, unionString(v))
+		}
+	}
+	if v, ok := unknownAttributes(route)[largeCommunityType]; ok {
+		for ; len(v) >= 12; v = v[12:] {
+			a.LargeCommunities = append(a.LargeCommunities, fmt.Sprintf("%d:%d:%d",
+				binary.BigEndian.Uint32(v[0:4]), binary.BigEndian.Uint32(v[4:8]), binary.BigEndian.Uint32(v[8:12])))
+		}
+	}
+	return a
+}
+
+func sortedKeys[V any](m map[uint32]V) []uint32 {
+	keys := make([]uint32, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	return keys
+}
+
+// unknownAttributes returns the values of a route's unknown attributes by
+// type.  Each table has its own UnknownAttribute struct, so they are read
+// by reflection.
+func unknownAttributes(route ocRoute) map[uint8][]byte {
+	m := reflect.ValueOf(route).Elem().FieldByName("UnknownAttribute")
+	if !m.IsValid() || m.Len() == 0 {
+		return nil
+	}
+	attrs := map[uint8][]byte{}
+	iter := m.MapRange()
+	for iter.Next() {
+		v := iter.Value().Elem().FieldByName("AttrValue")
+		attrs[uint8(iter.Key().Uint())] = v.Bytes()
+	}
+	return attrs
+}
+
+// decodeExtCommunity formats a binary extended community in OpenConfig
+// string form, or as hex if its type is not known.
+func decodeExtCommunity(b []byte) string {
+	if len(b) != 8 {
+		return fmt.Sprintf("%x", b)
+	}
+	typ, sub := b[0]&^0x40, b[1]
+	var kind string
+	switch sub {
+	case 0x02:
+		kind = "route-target"
+	case 0x03:
+		kind = "route-origin"
+	case 0x04:
+		if typ == 0x00 {
+			bw := math.Float32frombits(binary.BigEndian.Uint32(b[4:8]))
+			return fmt.Sprintf("link-bandwidth:%d:%s", binary.BigEndian.Uint16(b[2:4]), strconv.FormatFloat(float64(bw)*8, 'f', -1, 64))
+		}
+	}
+	if kind != "" {
+		switch typ {
+		case 0x00:
+			return fmt.Sprintf("%s:%d:%d", kind, binary.BigEndian.Uint16(b[2:4]), binary.BigEndian.Uint32(b[4:8]))
+		case 0x01:
+			return fmt.Sprintf("%s:%d.%d.%d.%d:%d", kind, b[2], b[3], b[4], b[5], binary.BigEndian.Uint16(b[6:8]))
+		case 0x02:
+			return fmt.Sprintf("%s:%d:%d", kind, binary.BigEndian.Uint32(b[2:6]), binary.BigEndian.Uint16(b[6:8]))
+		}
+	}
+	return fmt.Sprintf("%x", b)
+}
+
+// Find returns the routes of a table and AFI-SAFI for a prefix.  An empty
+// neighbor matches all neighbors.
+func (r *RIB) Find(table Table, afisafi oc.E_BgpTypes_AFI_SAFI_TYPE, neighbor, prefix string) []*Route {
+	var found []*Route
+	for _, rt := range r.Routes {
+		if rt.Table == table && rt.AFISAFI == afisafi && rt.Prefix == prefix && (neighbor == "" || rt.Neighbor == neighbor) {
+			found = append(found, rt)
+		}
+	}
+	return found
+}
+
+// Prefixes returns the distinct prefixes of a table and AFI-SAFI.  An empty
+// neighbor matches all neighbors.
+func (r *RIB) Prefixes(table Table, afisafi oc.E_BgpTypes_AFI_SAFI_TYPE, neighbor string) []string {
+	seen := map[string]bool{}
+	var prefixes []string
+	for _, rt := range r.Routes {
+		if rt.Table == table && rt.AFISAFI == afisafi && (neighbor == "" || rt.Neighbor == neighbor) && !seen[rt.Prefix] {
+			seen[rt.Prefix] = true
+			prefixes = append(prefixes, rt.Prefix)
+		}
+	}
+	sort.Strings(prefixes)
+	return prefixes
+}
+
+// BestPaths returns the neighbors whose path to a prefix the DUT selected.
+// Adj-RIB-In-Post best-path flags are used if reported, and otherwise the
+// origin of the Loc-RIB routes.
+func (r *RIB) BestPaths(afisafi oc.E_BgpTypes_AFI_SAFI_TYPE, prefix string) []string {
+	var best []string
+	for _, rt := range r.Find(AdjRIBInPost, afisafi, "", prefix) {
+		if rt.BestPath {
+			best = append(best, rt.Neighbor)
+		}
+	}
+	if len(best) > 0 {
+		return best
+	}
+	for _, rt := range r.Find(LocRIB, afisafi, "", prefix) {
+		if rt.Origin != "" {
+			best = append(best, rt.Origin)
+		}
+	}
+	return best
+}
@@ -0,0 +1,219 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bgprib
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/ondatra/gnmi/oc"
+	"github.com/openconfig/ygot/ygot"
+)
+
+const (
+	prefix = "198.51.100.0/24"
+	nbr1   = "192.0.2.2"
+	nbr2   = "192.0.2.6"
+	v4     = oc.BgpTypes_AFI_SAFI_TYPE_IPV4_UNICAST
+)
+
+// testRIB returns a RIB where prefix is received from two neighbors, with the
+// path from nbr2 preferred for its local preference.
+func testRIB(t *testing.T) *oc.NetworkInstance_Protocol_Bgp_Rib {
+	t.Helper()
+	rib := &oc.NetworkInstance_Protocol_Bgp_Rib{}
+
+	a1 := rib.GetOrCreateAttrSet(1)
+	a1.NextHop = ygot.String(nbr1)
+	a1.LocalPref = ygot.Uint32(100)
+	a1.Origin = oc.RibBgp_BgpOriginAttrType_IGP
+	seg := a1.GetOrCreateAsSegment(0)
+	seg.Type, seg.Member = oc.RibBgp_AsPathSegmentType_AS_SEQ, []uint32{64501, 64510}
+
+	a2 := rib.GetOrCreateAttrSet(2)
+	a2.NextHop = ygot.String(nbr2)
+	a2.LocalPref = ygot.Uint32(200)
+	a2.Med = ygot.Uint32(50)
+	a2.Origin = oc.RibBgp_BgpOriginAttrType_IGP
+	seg = a2.GetOrCreateAsSegment(0)
+	seg.Type, seg.Member = oc.RibBgp_AsPathSegmentType_AS_SEQ, []uint32{64502}
+	seg = a2.GetOrCreateAsSegment(1)
+	seg.Type, seg.Member = oc.RibBgp_AsPathSegmentType_AS_SET, []uint32{64511, 64512}
+
+	c := rib.GetOrCreateCommunity(1)
+	c.Community = []oc.NetworkInstance_Protocol_Bgp_Rib_Community_Community_Union{
+		oc.UnionString("65000:100"),
+		oc.UnionUint32(65000<<16 | 200),
+		oc.BgpTypes_BGP_WELL_KNOWN_STD_COMMUNITY_NO_EXPORT,
+	}
+	ec := rib.GetOrCreateExtCommunity(1)
+	ec.ExtCommunity = []oc.NetworkInstance_Protocol_Bgp_Rib_ExtCommunity_ExtCommunity_Union{
+		oc.UnionString("link-bandwidth:23456:1M"),
+		// route-target:65000:42
+		oc.Binary{0x00, 0x02, 0xfd, 0xe8, 0, 0, 0, 42},
+		// Non-transitive link-bandwidth of 125000 bytes per second from AS 64502.
+		oc.Binary{0x40, 0x04, 0xfb, 0xf6, 0x47, 0xf4, 0x24, 0x00},
+	}
+
+	n1 := rib.GetOrCreateAfiSafi(v4).GetOrCreateIpv4Unicast().GetOrCreateNeighbor(nbr1)
+	r := n1.GetOrCreateAdjRibInPre().GetOrCreateRoute(prefix, 0)
+	r.AttrIndex, r.CommunityIndex, r.ValidRoute = ygot.Uint64(1), ygot.Uint64(1), ygot.Bool(true)
+	rp := n1.GetOrCreateAdjRibInPost().GetOrCreateRoute(prefix, 0)
+	rp.AttrIndex, rp.CommunityIndex, rp.ValidRoute, rp.BestPath = ygot.Uint64(1), ygot.Uint64(1), ygot.Bool(true), ygot.Bool(false)
+
+	n2 := rib.GetOrCreateAfiSafi(v4).GetOrCreateIpv4Unicast().GetOrCreateNeighbor(nbr2)
+	rp2 := n2.GetOrCreateAdjRibInPost().GetOrCreateRoute(prefix, 0)
+	rp2.AttrIndex, rp2.ExtCommunityIndex, rp2.ValidRoute, rp2.BestPath = ygot.Uint64(2), ygot.Uint64(1), ygot.Bool(true), ygot.Bool(true)
+	ua := rp2.GetOrCreateUnknownAttribute(largeCommunityType)
+	ua.AttrValue = oc.Binary{0, 0, 0xfd, 0xe8, 0, 0, 0, 1, 0, 0, 0, 2}
+
+	lr, err := rib.GetOrCreateAfiSafi(v4).GetOrCreateIpv4Unicast().GetOrCreateLocRib().NewRoute(prefix, oc.UnionString(nbr2), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lr.AttrIndex, lr.ValidRoute = ygot.Uint64(2), ygot.Bool(true)
+	return rib
+}
+
+func TestNew(t *testing.T) {
+	rib := New(testRIB(t))
+	var got []string
+	for _, r := range rib.Routes {
+		got = append(got, r.String())
+	}
+	want := []string{
+		"loc-rib IPV4_UNICAST 198.51.100.0/24",
+		"adj-rib-in-pre IPV4_UNICAST 198.51.100.0/24 from 192.0.2.2",
+		"adj-rib-in-post IPV4_UNICAST 198.51.100.0/24 from 192.0.2.2",
+		"adj-rib-in-post IPV4_UNICAST 198.51.100.0/24 from 192.0.2.6",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("New() routes differ (-want +got):\n%s", diff)
+	}
+
+	if got := rib.Routes[0].Origin; got != nbr2 {
+		t.Errorf("Loc-RIB origin = %q, want %q", got, nbr2)
+	}
+	pre := rib.Routes[1].Attrs
+	if diff := cmp.Diff([]string{"65000:100", "65000:200", "NO_EXPORT"}, pre.Communities); diff != "" {
+		t.Errorf("Communities differ (-want +got):\n%s", diff)
+	}
+	post := rib.Routes[3].Attrs
+	if got, want := post.ASPathString(), "64502 {64511 64512}"; got != want {
+		t.Errorf("ASPathString() = %q, want %q", got, want)
+	}
+	wantExt := []string{"link-bandwidth:23456:1M", "route-target:65000:42", "link-bandwidth:64502:1000000"}
+	if diff := cmp.Diff(wantExt, post.ExtCommunities); diff != "" {
+		t.Errorf("ExtCommunities differ (-want +got):\n%s", diff)
+	}
+	wantLB := []LinkBandwidth{{AS: 23456, BitsPerSecond: 1e6}, {AS: 64502, BitsPerSecond: 1e6}}
+	if diff := cmp.Diff(wantLB, post.LinkBandwidths()); diff != "" {
+		t.Errorf("LinkBandwidths() differ (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"65000:1:2"}, post.LargeCommunities); diff != "" {
+		t.Errorf("LargeCommunities differ (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{nbr2}, rib.BestPaths(v4, prefix)); diff != "" {
+		t.Errorf("BestPaths() differ (-want +got):\n%s", diff)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	rib := New(testRIB(t))
+	tests := []struct {
+		desc    string
+		exp     *Expectation
+		wantErr string
+	}{{
+		desc: "pre-policy attributes",
+		exp: &Expectation{
+			Table: AdjRIBInPre, AFISAFI: v4, Neighbor: nbr1, Prefix: prefix,
+			NextHop: nbr1, LocalPref: ygot.Uint32(100), ASPath: []uint32{64501, 64510},
+			Origin: oc.RibBgp_BgpOriginAttrType_IGP, Communities: []string{"NO_EXPORT"},
+		},
+	}, {
+		desc: "post-policy attributes from any neighbor",
+		exp: &Expectation{
+			Table: AdjRIBInPost, AFISAFI: v4, Prefix: prefix,
+			MED: ygot.Uint32(50), LargeCommunities: []string{"65000:1:2"},
+			LinkBandwidth: &LinkBandwidth{AS: 64502, BitsPerSecond: 1e6},
+		},
+	}, {
+		desc: "best path",
+		exp:  &Expectation{Table: LocRIB, AFISAFI: v4, Prefix: prefix, BestFrom: nbr2, LocalPref: ygot.Uint32(200)},
+	}, {
+		desc:    "wrong best path",
+		exp:     &Expectation{Table: LocRIB, AFISAFI: v4, Prefix: prefix, BestFrom: nbr1},
+		wantErr: "best path from [192.0.2.6], want 192.0.2.2",
+	}, {
+		desc:    "wrong local-pref",
+		exp:     &Expectation{Table: AdjRIBInPost, AFISAFI: v4, Neighbor: nbr1, Prefix: prefix, LocalPref: ygot.Uint32(300)},
+		wantErr: "local-pref = 100, want 300",
+	}, {
+		desc:    "removed community still present",
+		exp:     &Expectation{Table: AdjRIBInPost, AFISAFI: v4, Neighbor: nbr1, Prefix: prefix, NoCommunities: []string{"65000:200"}},
+		wantErr: `unexpected community "65000:200"`,
+	}, {
+		desc: "absent",
+		exp:  &Expectation{Table: AdjRIBInPost, AFISAFI: v4, Neighbor: nbr1, Prefix: "203.0.113.0/24", Absent: true},
+	}, {
+		desc:    "not absent",
+		exp:     &Expectation{Table: AdjRIBInPre, AFISAFI: v4, Prefix: prefix, Absent: true},
+		wantErr: "got 1 routes, want none",
+	}, {
+		desc: "valid",
+		exp:  &Expectation{Table: AdjRIBInPre, AFISAFI: v4, Neighbor: nbr1, Prefix: prefix, Valid: ygot.Bool(true)},
+	}, {
+		desc:    "not invalid",
+		exp:     &Expectation{Table: AdjRIBInPre, AFISAFI: v4, Neighbor: nbr1, Prefix: prefix, Valid: ygot.Bool(false)},
+		wantErr: "valid = true",
+	}, {
+		desc:    "missing",
+		exp:     &Expectation{Table: AdjRIBOutPost, AFISAFI: v4, Neighbor: nbr1, Prefix: prefix},
+		wantErr: "route not found",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			err := rib.Check(tt.exp)
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Errorf("Check() failed: %v", err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Errorf("Check() got error %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCheckInvalidRoute(t *testing.T) {
+	r := testRIB(t)
+	route := r.GetAfiSafi(v4).GetIpv4Unicast().GetNeighbor(nbr1).GetAdjRibInPre().GetRoute(prefix, 0)
+	route.ValidRoute, route.InvalidReason = ygot.Bool(false), oc.RibBgpTypes_INVALID_ROUTE_REASON_INVALID_AS_LOOP
+	rib := New(r)
+
+	present := &Expectation{Table: AdjRIBInPre, AFISAFI: v4, Neighbor: nbr1, Prefix: prefix}
+	if err := rib.Check(present); err != nil {
+		t.Errorf("Check() of presence failed on an invalid route: %v", err)
+	}
+	invalid := &Expectation{Table: AdjRIBInPre, AFISAFI: v4, Neighbor: nbr1, Prefix: prefix, Valid: ygot.Bool(false)}
+	if err := rib.Check(invalid); err != nil {
+		t.Errorf("Check() of an invalid route failed: %v", err)
+	}
+	valid := &Expectation{Table: AdjRIBInPre, AFISAFI: v4, Neighbor: nbr1, Prefix: prefix, Valid: ygot.Bool(true)}
+	if err := rib.Check(valid); err == nil || !strings.Contains(err.Error(), "valid = false (INVALID_AS_LOOP), want true") {
+		t.Errorf("Check() got error %v, want valid = false (INVALID_AS_LOOP), want true", err)
+	}
+}
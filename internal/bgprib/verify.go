@@ -0,0 +1,213 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bgprib
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/gnmi"
+	"github.com/openconfig/ondatra/gnmi/oc"
+	"github.com/openconfig/ondatra/gnmi/oc/netinstbgp"
+	"github.com/openconfig/ygnmi/ygnmi"
+)
+
+// Expectation describes a route that must, or must not, be in a RIB.  Unset
+// fields are not checked.
+type Expectation struct {
+	Table   Table
+	AFISAFI oc.E_BgpTypes_AFI_SAFI_TYPE
+	// Neighbor selects the Adj-RIB of a neighbor.  It must be empty for the
+	// Loc-RIB, and an empty Neighbor matches routes from any neighbor.
+	Neighbor string
+	Prefix   string
+
+	// Absent expects no route for the prefix, e.g. because policy rejected it.
+	Absent bool
+	// Valid, when set, is whether the route must be valid, e.g. false to
+	// expect a route that is present but not valid.
+	Valid *bool
+
+	NextHop   string
+	MED       *uint32
+	LocalPref *uint32
+	Origin    oc.E_RibBgp_BgpOriginAttrType
+	// ASPath is the expected AS path, with sets flattened.
+	ASPath []uint32
+	// Communities, ExtCommunities and LargeCommunities must all be present;
+	// other communities are allowed.
+	Communities      []string
+	ExtCommunities   []string
+	LargeCommunities []string
+	// NoCommunities must not be present, e.g. after a policy removes them.
+	NoCommunities []string
+	// LinkBandwidth is the expected link-bandwidth extended community.
+	LinkBandwidth *LinkBandwidth
+	// BestFrom is the neighbor whose path must be selected as best.
+	BestFrom string
+}
+
+func (e *Expectation) String() string {
+	s := fmt.Sprintf("%v %v %s", e.Table, e.AFISAFI, e.Prefix)
+	if e.Neighbor != "" {
+		s += " from " + e.Neighbor
+	}
+	return s
+}
+
+// check returns why none of routes meets e.
+func (e *Expectation) check(routes []*Route, rib *RIB) error {
+	if e.Absent {
+		if len(routes) > 0 {
+			return fmt.Errorf("%v: got %d routes, want none", e, len(routes))
+		}
+		return nil
+	}
+	if len(routes) == 0 {
+		return fmt.Errorf("%v: route not found", e)
+	}
+	var errs []error
+	for _, rt := range routes {
+		err := e.checkRoute(rt)
+		if err == nil {
+			errs = nil
+			break
+		}
+		errs = append(errs, err)
+	}
+	if e.BestFrom != "" {
+		if best := rib.BestPaths(e.AFISAFI, e.Prefix); !slices.Contains(best, e.BestFrom) {
+			errs = append(errs, fmt.Errorf("%v: best path from %v, want %s", e, best, e.BestFrom))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (e *Expectation) checkRoute(rt *Route) error {
+	var errs []error
+	addf := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf("%v: "+format, append([]any{rt}, args...)...))
+	}
+	if e.Valid != nil && rt.Valid != *e.Valid {
+		addf("valid = %t (%v), want %t", rt.Valid, rt.InvalidReason, *e.Valid)
+	}
+	a := rt.Attrs
+	if e.NextHop != "" && a.NextHop != e.NextHop {
+		addf("next-hop = %q, want %q", a.NextHop, e.NextHop)
+	}
+	if e.MED != nil && (a.MED == nil || *a.MED != *e.MED) {
+		addf("MED = %v, want %d", ptrString(a.MED), *e.MED)
+	}
+	if e.LocalPref != nil && (a.LocalPref == nil || *a.LocalPref != *e.LocalPref) {
+		addf("local-pref = %v, want %d", ptrString(a.LocalPref), *e.LocalPref)
+	}
+	if e.Origin != oc.RibBgp_BgpOriginAttrType_UNSET && a.Origin != e.Origin {
+		addf("origin = %v, want %v", a.Origin, e.Origin)
+	}
+	if e.ASPath != nil && !slices.Equal(a.ASPathMembers(), e.ASPath) {
+		addf("AS path = [%s], want %v", a.ASPathString(), e.ASPath)
+	}
+	for _, c := range [][2][]string{
+		{e.Communities, a.Communities},
+		{e.ExtCommunities, a.ExtCommunities},
+		{e.LargeCommunities, a.LargeCommunities},
+	} {
+		for _, want := range c[0] {
+			if !slices.Contains(c[1], want) {
+				addf("missing community %q in %v", want, c[1])
+			}
+		}
+	}
+	for _, c := range e.NoCommunities {
+		if slices.Contains(a.Communities, c) || slices.Contains(a.ExtCommunities, c) || slices.Contains(a.LargeCommunities, c) {
+			addf("unexpected community %q", c)
+		}
+	}
+	if e.LinkBandwidth != nil && !slices.Contains(a.LinkBandwidths(), *e.LinkBandwidth) {
+		addf("link-bandwidth = %v, want %v", a.LinkBandwidths(), *e.LinkBandwidth)
+	}
+	return errors.Join(errs...)
+}
+
+func ptrString[T any](v *T) string {
+	if v == nil {
+		return "unset"
+	}
+	return fmt.Sprint(*v)
+}
+
+// Check returns the expectations the RIB does not meet.
+func (r *RIB) Check(exps ...*Expectation) error {
+	var errs []error
+	for _, e := range exps {
+		if err := e.check(r.Find(e.Table, e.AFISAFI, e.Neighbor, e.Prefix), r); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// path returns the RIB path of the BGP protocol named bgpName of a network
+// instance.
+func path(ni, bgpName string) *netinstbgp.NetworkInstance_Protocol_Bgp_RibPath {
+	return gnmi.OC().NetworkInstance(ni).Protocol(oc.PolicyTypes_INSTALL_PROTOCOL_TYPE_BGP, bgpName).Bgp().Rib()
+}
+
+// Get returns the RIB of the BGP protocol named bgpName of a network
+// instance.
+func Get(t testing.TB, dut *ondatra.DUTDevice, ni, bgpName string) *RIB {
+	t.Helper()
+	return New(gnmi.Get(t, dut, path(ni, bgpName).State()))
+}
+
+// Verify checks expectations against the current RIB of the BGP protocol
+// named bgpName of a network instance, reporting unmet ones as test errors.
+func Verify(t testing.TB, dut *ondatra.DUTDevice, ni, bgpName string, exps ...*Expectation) *RIB {
+	t.Helper()
+	rib := Get(t, dut, ni, bgpName)
+	if err := rib.Check(exps...); err != nil {
+		t.Errorf("BGP RIB of %s on %s:\n%v", ni, dut.Name(), err)
+	}
+	return rib
+}
+
+// Await waits up to timeout for the RIB of the BGP protocol named bgpName of
+// a network instance to meet expectations, reporting unmet ones as test
+// errors.  It returns the last RIB received.
+func Await(t testing.TB, dut *ondatra.DUTDevice, ni, bgpName string, timeout time.Duration, exps ...*Expectation) *RIB {
+	t.Helper()
+	rib := &RIB{}
+	var err error
+	gnmi.Watch(t, dut, path(ni, bgpName).State(), timeout, func(v *ygnmi.Value[*oc.NetworkInstance_Protocol_Bgp_Rib]) bool {
+		val, ok := v.Val()
+		if !ok {
+			return false
+		}
+		rib = New(val)
+		err = rib.Check(exps...)
+		return err == nil
+	}).Await(t)
+	if len(rib.Routes) == 0 && err == nil {
+		err = (&RIB{}).Check(exps...)
+	}
+	if err != nil {
+		t.Errorf("BGP RIB of %s on %s after %v:\n%v", ni, dut.Name(), timeout, err)
+	}
+	return rib
+}
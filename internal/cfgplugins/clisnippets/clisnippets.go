@@ -0,0 +1,346 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package clisnippets is a registry of vendor CLI snippets used by cfgplugins
+// where OpenConfig is not (yet) sufficient.
+//
+// Snippets are text/template files under snippets/<vendor>/ and are embedded
+// in the binary.  Each file starts with a YAML header between "---" lines
+// describing when the snippet applies:
+//
+//	---
+//	feature: qos/two-rate-three-color-policer
+//	vendor: ARISTA
+//	model: "(?i)^7280"        # Optional regexp on the hardware model.
+//	version: "^4\\.3[0-3]\\." # Optional regexp on the software version.
+//	deviation: qos_two_rate_three_color_policer_oc_unsupported
+//	params: [SchedulerName, ClassName]
+//	description: Two-rate three-color policer.
+//	---
+//	policy-map type quality-of-service {{.SchedulerName}}
+//	   class {{.ClassName}}
+//
+// When several snippets of a feature match a DUT, the one with the most
+// constraints (model and version) is used.  The params listed in the header
+// must all be given when rendering, and no others.
+//
+// Every rendered snippet is recorded, so a test can report exactly which CLI
+// workarounds it relied on:
+//
+//	for _, u := range clisnippets.Default().Used() {
+//	  t.Log(u)
+//	}
+package clisnippets
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"text/template"
+
+	"github.com/openconfig/ondatra"
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed snippets
+var embedded embed.FS
+
+// Snippet is a CLI template and the conditions under which it applies.
+type Snippet struct {
+	// Feature names what the snippet configures, such as
+	// "hardware-init/policy-forwarding".
+	Feature string
+	// Vendor is the ondatra vendor name, such as "ARISTA".
+	Vendor string
+	// Model and Version restrict the snippet to matching hardware models and
+	// software versions.  A nil regexp matches anything.
+	Model   *regexp.Regexp
+	Version *regexp.Regexp
+	// Deviation is the deviation the snippet works around, if any.
+	Deviation   string
+	Description string
+	// Params are the template parameters the snippet requires.
+	Params []string
+	// File is the path of the snippet within the registry.
+	File string
+
+	tmpl *template.Template
+}
+
+type header struct {
+	Feature     string   `yaml:"feature"`
+	Vendor      string   `yaml:"vendor"`
+	Model       string   `yaml:"model"`
+	Version     string   `yaml:"version"`
+	Deviation   string   `yaml:"deviation"`
+	Description string   `yaml:"description"`
+	Params      []string `yaml:"params"`
+}
+
+// specificity orders matching snippets, preferring constrained ones.
+func (s *Snippet) specificity() int {
+	n := 0
+	if s.Model != nil {
+		n++
+	}
+	if s.Version != nil {
+		n++
+	}
+	return n
+}
+
+// Matches reports whether the snippet applies to a device.
+func (s *Snippet) Matches(vendor, model, version string) bool {
+	return strings.EqualFold(s.Vendor, vendor) &&
+		(s.Model == nil || s.Model.MatchString(model)) &&
+		(s.Version == nil || s.Version.MatchString(version))
+}
+
+// Render executes the snippet with params, which must hold exactly the
+// parameters the snippet declares.
+func (s *Snippet) Render(params map[string]any) (string, error) {
+	var missing, unknown []string
+	for _, p := range s.Params {
+		if _, ok := params[p]; !ok {
+			missing = append(missing, p)
+		}
+	}
+	for p := range params {
+		if !slices.Contains(s.Params, p) {
+			unknown = append(unknown, p)
+		}
+	}
+	sort.Strings(unknown)
+	switch {
+	case len(missing) > 0:
+		return "", fmt.Errorf("snippet %s: missing params %v", s.File, missing)
+	case len(unknown) > 0:
+		return "", fmt.Errorf("snippet %s: unknown params %v", s.File, unknown)
+	}
+	if params == nil {
+		params = map[string]any{}
+	}
+	var buf bytes.Buffer
+	if err := s.tmpl.Execute(&buf, params); err != nil {
+		return "", fmt.Errorf("snippet %s: %w", s.File, err)
+	}
+	return buf.String(), nil
+}
+
+// Use records a snippet that was rendered for a device.
+type Use struct {
+	Test    string
+	Feature string
+	File    string
+	Vendor  string
+	Model   string
+	Version string
+}
+
+func (u Use) String() string {
+	s := fmt.Sprintf("%s: %s for %s %s %s", u.Feature, u.File, u.Vendor, u.Model, u.Version)
+	if u.Test != "" {
+		s = u.Test + ": " + s
+	}
+	return s
+}
+
+// Registry is a set of snippets.
+type Registry struct {
+	snippets []*Snippet
+
+	mu   sync.Mutex
+	used []Use
+}
+
+// Load reads all *.tmpl files in fsys into a registry.
+func Load(fsys fs.FS) (*Registry, error) {
+	r := &Registry{}
+	err := fs.WalkDir(fsys, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || path.Ext(name) != ".tmpl" {
+			return err
+		}
+		b, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return err
+		}
+		s, err := parse(name, b)
+		if err != nil {
+			return err
+		}
+		r.snippets = append(r.snippets, s)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func parse(name string, b []byte) (*Snippet, error) {
+	const delim = "---\n"
+	rest, ok := bytes.CutPrefix(b, []byte(delim))
+	if !ok {
+		return nil, fmt.Errorf("snippet %s: missing %q header", name, strings.TrimSpace(delim))
+	}
+	hdr, body, ok := bytes.Cut(rest, []byte("\n"+delim))
+	if !ok {
+		return nil, fmt.Errorf("snippet %s: unterminated header", name)
+	}
+	var h header
+	if err := yaml.Unmarshal(hdr, &h); err != nil {
+		return nil, fmt.Errorf("snippet %s: %w", name, err)
+	}
+	if h.Feature == "" || h.Vendor == "" {
+		return nil, fmt.Errorf("snippet %s: feature and vendor are required", name)
+	}
+	s := &Snippet{
+		Feature:     h.Feature,
+		Vendor:      h.Vendor,
+		Deviation:   h.Deviation,
+		Description: h.Description,
+		Params:      h.Params,
+		File:        name,
+	}
+	var err error
+	if h.Model != "" {
+		if s.Model, err = regexp.Compile(h.Model); err != nil {
+			return nil, fmt.Errorf("snippet %s: model: %w", name, err)
+		}
+	}
+	if h.Version != "" {
+		if s.Version, err = regexp.Compile(h.Version); err != nil {
+			return nil, fmt.Errorf("snippet %s: version: %w", name, err)
+		}
+	}
+	if s.tmpl, err = template.New(name).Option("missingkey=error").Parse(string(body)); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+var (
+	defaultOnce     sync.Once
+	defaultRegistry *Registry
+)
+
+// Default returns the registry of snippets embedded in this package.
+func Default() *Registry {
+	defaultOnce.Do(func() {
+		sub, err := fs.Sub(embedded, "snippets")
+		if err != nil {
+			panic(err)
+		}
+		if defaultRegistry, err = Load(sub); err != nil {
+			panic(fmt.Sprintf("invalid embedded CLI snippets: %v", err))
+		}
+	})
+	return defaultRegistry
+}
+
+// Snippets returns all snippets, sorted by feature, vendor and file.
+func (r *Registry) Snippets() []*Snippet {
+	ss := slices.Clone(r.snippets)
+	sort.Slice(ss, func(i, j int) bool {
+		a, b := ss[i], ss[j]
+		if a.Feature != b.Feature {
+			return a.Feature < b.Feature
+		}
+		if a.Vendor != b.Vendor {
+			return a.Vendor < b.Vendor
+		}
+		return a.File < b.File
+	})
+	return ss
+}
+
+// Lookup returns the most specific snippet of a feature for a device, or
+// nil if there is none.  It is an error for several snippets to match with
+// the same specificity.
+func (r *Registry) Lookup(feature, vendor, model, version string) (*Snippet, error) {
+	var best []*Snippet
+	for _, s := range r.snippets {
+		if s.Feature != feature || !s.Matches(vendor, model, version) {
+			continue
+		}
+		switch {
+		case len(best) == 0 || s.specificity() > best[0].specificity():
+			best = []*Snippet{s}
+		case s.specificity() == best[0].specificity():
+			best = append(best, s)
+		}
+	}
+	switch len(best) {
+	case 0:
+		return nil, nil
+	case 1:
+		return best[0], nil
+	}
+	var files []string
+	for _, s := range best {
+		files = append(files, s.File)
+	}
+	sort.Strings(files)
+	return nil, fmt.Errorf("feature %q for %s %s %s matches several snippets: %v", feature, vendor, model, version, files)
+}
+
+// Render looks up and renders a snippet of a feature for a device, recording
+// its use.  It returns "" and no error if there is no snippet for the device.
+func (r *Registry) Render(test, feature, vendor, model, version string, params map[string]any) (string, error) {
+	s, err := r.Lookup(feature, vendor, model, version)
+	if err != nil || s == nil {
+		return "", err
+	}
+	out, err := s.Render(params)
+	if err != nil {
+		return "", err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.used = append(r.used, Use{
+		Test:    test,
+		Feature: feature,
+		File:    s.File,
+		Vendor:  vendor,
+		Model:   model,
+		Version: version,
+	})
+	return out, nil
+}
+
+// For renders the snippet of a feature for dut, failing the test on error.
+// It returns "" if there is no snippet for the DUT.
+func (r *Registry) For(t testing.TB, dut *ondatra.DUTDevice, feature string, params map[string]any) string {
+	t.Helper()
+	out, err := r.Render(t.Name(), feature, dut.Vendor().String(), dut.Model(), dut.Version(), params)
+	if err != nil {
+		t.Fatalf("CLI snippet for %s on %s: %v", feature, dut.Name(), err)
+	}
+	return out
+}
+
+// Used returns the snippets rendered so far, in order.
+func (r *Registry) Used() []Use {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Clone(r.used)
+}
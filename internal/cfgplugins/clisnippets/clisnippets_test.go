@@ -0,0 +1,137 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clisnippets
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestDefault(t *testing.T) {
+	r := Default()
+	if len(r.Snippets()) == 0 {
+		t.Fatal("Default() has no snippets")
+	}
+	for _, s := range r.Snippets() {
+		params := map[string]any{}
+		for _, p := range s.Params {
+			params[p] = "x"
+		}
+		got, err := s.Render(params)
+		if err != nil {
+			t.Errorf("Render(%s) failed: %v", s.File, err)
+		}
+		if strings.TrimSpace(got) == "" {
+			t.Errorf("Render(%s) is empty", s.File)
+		}
+	}
+}
+
+func TestLookupAndRender(t *testing.T) {
+	fsys := fstest.MapFS{
+		"arista/generic.tmpl": {Data: []byte("---\nfeature: f\nvendor: ARISTA\nparams: [Name]\n---\ngeneric {{.Name}}\n")},
+		"arista/model.tmpl":   {Data: []byte("---\nfeature: f\nvendor: ARISTA\nmodel: \"^7280\"\nparams: [Name]\n---\nmodel {{.Name}}\n")},
+		"arista/both.tmpl":    {Data: []byte("---\nfeature: f\nvendor: ARISTA\nmodel: \"^7280\"\nversion: \"^4\\\\.3\"\nparams: [Name]\n---\nboth {{.Name}}\n")},
+		"arista/v1.tmpl":      {Data: []byte("---\nfeature: g\nvendor: ARISTA\nversion: \"^1\"\n---\nv1\n")},
+		"arista/v2.tmpl":      {Data: []byte("---\nfeature: g\nvendor: ARISTA\nversion: \"^1\\\\.2\"\n---\nv2\n")},
+		"README.md":           {Data: []byte("not a snippet")},
+	}
+	r, err := Load(fsys)
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+	params := map[string]any{"Name": "n"}
+
+	tests := []struct {
+		desc, feature, vendor, model, version string
+		want                                  string
+		wantErr                               bool
+	}{
+		{desc: "generic", feature: "f", vendor: "ARISTA", model: "7050", version: "4.30", want: "generic n\n"},
+		{desc: "model", feature: "f", vendor: "arista", model: "7280R3", version: "4.29", want: "model n\n"},
+		{desc: "model and version", feature: "f", vendor: "ARISTA", model: "7280R3", version: "4.31", want: "both n\n"},
+		{desc: "other vendor", feature: "f", vendor: "NOKIA", model: "7280R3", version: "4.31"},
+		{desc: "unknown feature", feature: "h", vendor: "ARISTA"},
+		{desc: "ambiguous", feature: "g", vendor: "ARISTA", version: "1.2", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			p := params
+			if tt.feature == "g" {
+				p = nil
+			}
+			got, err := r.Render("test", tt.feature, tt.vendor, tt.model, tt.version, p)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Render() got err %v, want error %t", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Render() got %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	used := r.Used()
+	if len(used) != 3 {
+		t.Fatalf("Used() got %d uses, want 3: %v", len(used), used)
+	}
+	if u := used[2]; u.File != "arista/both.tmpl" || u.Test != "test" || u.Feature != "f" {
+		t.Errorf("Used()[2] = %v, want arista/both.tmpl for feature f in test", u)
+	}
+}
+
+func TestRenderParams(t *testing.T) {
+	r, err := Load(fstest.MapFS{
+		"s.tmpl": {Data: []byte("---\nfeature: f\nvendor: ARISTA\nparams: [A, B]\n---\n{{.A}} {{.B}}\n")},
+	})
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+	s := r.Snippets()[0]
+	for _, tt := range []struct {
+		desc    string
+		params  map[string]any
+		wantErr string
+	}{
+		{desc: "ok", params: map[string]any{"A": 1, "B": "b"}},
+		{desc: "missing", params: map[string]any{"A": 1}, wantErr: "missing params [B]"},
+		{desc: "unknown", params: map[string]any{"A": 1, "B": 2, "C": 3}, wantErr: "unknown params [C]"},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			_, err := s.Render(tt.params)
+			if tt.wantErr == "" && err != nil || tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Errorf("Render() got err %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	for _, tt := range []struct {
+		desc, data string
+	}{
+		{desc: "no header", data: "show version\n"},
+		{desc: "unterminated header", data: "---\nfeature: f\nvendor: ARISTA\n"},
+		{desc: "missing vendor", data: "---\nfeature: f\n---\nx\n"},
+		{desc: "bad model", data: "---\nfeature: f\nvendor: ARISTA\nmodel: \"(\"\n---\nx\n"},
+		{desc: "bad template", data: "---\nfeature: f\nvendor: ARISTA\n---\n{{.A\n"},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			if _, err := Load(fstest.MapFS{"s.tmpl": {Data: []byte(tt.data)}}); err == nil {
+				t.Errorf("Load() succeeded, want error")
+			}
+		})
+	}
+}
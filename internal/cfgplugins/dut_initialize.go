@@ -21,6 +21,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/openconfig/featureprofiles/internal/cfgplugins/clisnippets"
 	"github.com/openconfig/featureprofiles/internal/deviations"
 	"github.com/openconfig/featureprofiles/internal/helpers"
 	gpb "github.com/openconfig/gnmi/proto/gnmi"
@@ -51,1352 +52,26 @@ const (
 	FeatureHierarchicalFIB
 	FeatureSecondaryDefaultLookup
 	FeatureAnpf
-
-	aristaTcamProfileMplsTracking = `
-hardware counter feature traffic-policy in
-!
-hardware tcam
-  profile ancx
-    feature acl port ip
-        sequence 45
-        key size limit 160
-        key field dscp dst-ip ip-frag ip-protocol l4-dst-port l4-ops l4-src-port src-ip tcp-control ttl
-        action count drop mirror
-        packet ipv4 forwarding bridged
-        packet ipv4 forwarding routed
-        packet ipv4 forwarding routed multicast
-        packet ipv4 mpls ipv4 forwarding mpls decap
-        packet ipv4 mpls ipv6 forwarding mpls decap
-        packet ipv4 non-vxlan forwarding routed decap
-        packet ipv4 vxlan eth ipv4 forwarding routed decap
-        packet ipv4 vxlan forwarding bridged decap
-    feature acl port ip egress mpls-tunnelled-match
-        sequence 95
-    feature acl port ipv6
-        sequence 25
-        key field dst-ipv6 ipv6-next-header ipv6-traffic-class l4-dst-port l4-ops-3b l4-src-port src-ipv6-high src-ipv6-low tcp-control
-        action count drop mirror
-        packet ipv6 forwarding bridged
-        packet ipv6 forwarding routed
-        packet ipv6 forwarding routed multicast
-        packet ipv6 ipv6 forwarding routed decap
-    feature acl port ipv6 egress
-        sequence 105
-        key field dst-ipv6 ipv6-next-header ipv6-traffic-class l4-dst-port l4-src-port src-ipv6-high src-ipv6-low tcp-control
-        action count drop mirror
-        packet ipv6 forwarding bridged
-        packet ipv6 forwarding routed
-    feature acl port mac
-        sequence 55
-        key size limit 160
-        key field dst-mac ether-type src-mac
-        action count drop mirror
-        packet ipv4 forwarding bridged
-        packet ipv4 forwarding routed
-        packet ipv4 forwarding routed multicast
-        packet ipv4 mpls ipv4 forwarding mpls decap
-        packet ipv4 mpls ipv6 forwarding mpls decap
-        packet ipv4 non-vxlan forwarding routed decap
-        packet ipv4 vxlan forwarding bridged decap
-        packet ipv6 forwarding bridged
-        packet ipv6 forwarding routed
-        packet ipv6 forwarding routed decap
-        packet ipv6 forwarding routed multicast
-        packet ipv6 ipv6 forwarding routed decap
-        packet mpls forwarding bridged decap
-        packet mpls ipv4 forwarding mpls
-        packet mpls ipv6 forwarding mpls
-        packet mpls non-ip forwarding mpls
-        packet non-ip forwarding bridged
-    feature acl vlan ipv6 egress
-        sequence 20
-        key field dst-ipv6 ipv6-next-header ipv6-traffic-class l4-dst-port l4-src-port src-ipv6-high src-ipv6-low tcp-control
-        action count drop mirror
-        packet ipv6 forwarding bridged
-        packet ipv6 forwarding routed
-    feature counter lfib
-        sequence 85
-    feature forwarding-destination mpls
-        sequence 100
-    feature mirror ip
-        sequence 80
-        key size limit 160
-        key field dscp dst-ip ip-frag ip-protocol l4-dst-port l4-ops l4-src-port src-ip tcp-control
-        action count mirror set-policer
-        packet ipv4 forwarding bridged
-        packet ipv4 forwarding routed
-        packet ipv4 forwarding routed multicast
-        packet ipv4 non-vxlan forwarding routed decap
-    feature mpls
-        sequence 5
-        key size limit 160
-        action drop redirect set-ecn
-        packet ipv4 mpls ipv4 forwarding mpls decap
-        packet ipv4 mpls ipv6 forwarding mpls decap
-        packet mpls ipv4 forwarding mpls
-        packet mpls ipv6 forwarding mpls
-        packet mpls non-ip forwarding mpls
-    feature mpls pop ingress
-        sequence 90
-    feature pbr mpls
-        sequence 65
-        key size limit 160
-        key field mpls-inner-ip-tos
-        action count drop redirect
-        packet mpls ipv4 forwarding mpls
-        packet mpls ipv6 forwarding mpls
-        packet mpls non-ip forwarding mpls
-    feature qos ip
-        sequence 75
-        key size limit 160
-        key field dscp dst-ip ip-frag ip-protocol l4-dst-port l4-ops l4-src-port src-ip tcp-control
-        action set-dscp set-policer set-tc
-        packet ipv4 forwarding routed
-        packet ipv4 forwarding routed multicast
-        packet ipv4 mpls ipv4 forwarding mpls decap
-        packet ipv4 mpls ipv6 forwarding mpls decap
-        packet ipv4 non-vxlan forwarding routed decap
-    feature qos ipv6
-        sequence 70
-        key field dst-ipv6 ipv6-next-header ipv6-traffic-class l4-dst-port l4-src-port src-ipv6-high src-ipv6-low
-        action set-dscp set-policer set-tc
-        packet ipv6 forwarding routed
-    feature traffic-policy port ipv4
-        sequence 45
-        key size limit 160
-        key field dscp dst-ip-label ip-frag ip-fragment-offset ip-length ip-protocol l4-dst-port-label l4-src-port-label src-ip-label tcp-control ttl
-        action count drop redirect set-dscp set-tc
-        packet ipv4 forwarding routed
-    feature traffic-policy port ipv4 egress
-        key size limit 160
-        key field dscp dst-ip-label ip-frag ip-protocol l4-dst-port-label l4-src-port-label src-ip-label
-        action count drop
-        packet ipv4 forwarding routed
-    feature traffic-policy port ipv6
-        sequence 25
-        key size limit 160
-        key field dst-ipv6-label hop-limit ipv6-length ipv6-next-header ipv6-traffic-class l4-dst-port-label l4-src-port-label src-ipv6-label tcp-control
-        action count drop redirect set-dscp set-tc
-        packet ipv6 forwarding routed
-    feature traffic-policy port ipv6 egress
-        key size limit 160
-        key field dscp dst-ipv6-label ipv6-next-header l4-dst-port-label l4-src-port-label src-ipv6-label
-        action count drop
-        packet ipv6 forwarding routed
-    feature tunnel vxlan
-        sequence 50
-        key size limit 160
-        packet ipv4 vxlan eth ipv4 forwarding routed decap
-        packet ipv4 vxlan forwarding bridged decap
-  system profile ancx
-!
-`
-
-	aristaTcamProfileVrfSelectionExtended = `
-hardware tcam
-   profile vrf-selection-with-ip6-sip
-      feature acl port ip
-         sequence 45
-         key size limit 160
-         key field dscp dst-ip ip-frag ip-protocol l4-dst-port l4-ops l4-src-port src-ip tcp-control ttl
-         action count drop mirror
-         packet ipv4 forwarding bridged
-         packet ipv4 forwarding routed
-         packet ipv4 forwarding routed multicast
-         packet ipv4 mpls ipv4 forwarding mpls decap
-         packet ipv4 mpls ipv6 forwarding mpls decap
-         packet ipv4 non-vxlan forwarding routed decap
-         packet ipv4 vxlan eth ipv4 forwarding routed decap
-         packet ipv4 vxlan forwarding bridged decap
-      feature acl port ip egress mpls-tunnelled-match
-         sequence 95
-      feature acl port ipv6
-         sequence 25
-         key field dst-ipv6 ipv6-next-header ipv6-traffic-class l4-dst-port l4-ops-3b l4-src-port src-ipv6-high src-ipv6-low tcp-control
-         action count drop mirror
-         packet ipv6 forwarding bridged
-         packet ipv6 forwarding routed
-         packet ipv6 forwarding routed multicast
-         packet ipv6 ipv6 forwarding routed decap
-      feature acl port ipv6 egress
-         sequence 105
-         key field dst-ipv6 ipv6-next-header ipv6-traffic-class l4-dst-port l4-src-port src-ipv6-high src-ipv6-low tcp-control
-         action count drop mirror
-         packet ipv6 forwarding bridged
-         packet ipv6 forwarding routed
-      feature acl port mac
-         sequence 55
-         key size limit 160
-         key field dst-mac ether-type src-mac
-         action count drop mirror
-         packet ipv4 forwarding bridged
-         packet ipv4 forwarding routed
-         packet ipv4 forwarding routed multicast
-         packet ipv4 mpls ipv4 forwarding mpls decap
-         packet ipv4 mpls ipv6 forwarding mpls decap
-         packet ipv4 non-vxlan forwarding routed decap
-         packet ipv4 vxlan forwarding bridged decap
-         packet ipv6 forwarding bridged
-         packet ipv6 forwarding routed
-         packet ipv6 forwarding routed decap
-         packet ipv6 forwarding routed multicast
-         packet ipv6 ipv6 forwarding routed decap
-         packet mpls forwarding bridged decap
-         packet mpls ipv4 forwarding mpls
-         packet mpls ipv6 forwarding mpls
-         packet mpls non-ip forwarding mpls
-         packet non-ip forwarding bridged
-      feature acl subintf ip
-         sequence 40
-         key size limit 160
-         key field dscp dst-ip ip-frag ip-protocol l4-dst-port l4-ops-18b l4-src-port src-ip tcp-control ttl
-         action count drop
-         packet ipv4 forwarding routed
-      feature acl subintf ipv6
-         sequence 15
-         key field dst-ipv6 ipv6-next-header l4-dst-port l4-src-port src-ipv6-high src-ipv6-low tcp-control
-         action count drop
-         packet ipv6 forwarding routed
-      feature acl vlan ip
-         sequence 35
-         key size limit 160
-         key field dscp dst-ip ip-frag ip-protocol l4-dst-port l4-ops-18b l4-src-port src-ip tcp-control ttl
-         action count drop
-         packet ipv4 forwarding routed
-         packet ipv4 mpls ipv4 forwarding mpls decap
-         packet ipv4 mpls ipv6 forwarding mpls decap
-         packet ipv4 non-vxlan forwarding routed decap
-         packet ipv4 vxlan eth ipv4 forwarding routed decap
-      feature acl vlan ipv6
-         sequence 10
-         key field dst-ipv6 ipv6-next-header l4-dst-port l4-src-port src-ipv6-high src-ipv6-low tcp-control
-         action count drop
-         packet ipv6 forwarding routed
-         packet ipv6 ipv6 forwarding routed decap
-      feature acl vlan ipv6 egress
-         sequence 20
-         key field dst-ipv6 ipv6-next-header ipv6-traffic-class l4-dst-port l4-src-port src-ipv6-high src-ipv6-low tcp-control
-         action count drop mirror
-         packet ipv6 forwarding bridged
-         packet ipv6 forwarding routed
-      feature counter lfib
-         sequence 85
-      feature forwarding-destination mpls
-         sequence 100
-      feature mirror ip
-         sequence 80
-         key size limit 160
-         key field dscp dst-ip ip-frag ip-protocol l4-dst-port l4-ops l4-src-port src-ip tcp-control
-         action count mirror set-policer
-         packet ipv4 forwarding bridged
-         packet ipv4 forwarding routed
-         packet ipv4 forwarding routed multicast
-         packet ipv4 non-vxlan forwarding routed decap
-      feature mpls
-         sequence 5
-         key size limit 160
-         action drop redirect set-ecn
-         packet ipv4 mpls ipv4 forwarding mpls decap
-         packet ipv4 mpls ipv6 forwarding mpls decap
-         packet mpls ipv4 forwarding mpls
-         packet mpls ipv6 forwarding mpls
-         packet mpls non-ip forwarding mpls
-      feature mpls pop ingress
-         sequence 90
-      feature pbr ip
-         sequence 60
-         key size limit 160
-         key field dscp dst-ip ip-frag ip-protocol l4-dst-port l4-ops-18b l4-src-port src-ip tcp-control
-         action count redirect
-         packet ipv4 forwarding routed
-         packet ipv4 mpls ipv4 forwarding mpls decap
-         packet ipv4 mpls ipv6 forwarding mpls decap
-         packet ipv4 non-vxlan forwarding routed decap
-         packet ipv4 vxlan forwarding bridged decap
-      feature pbr ipv6
-         sequence 30
-         key field dst-ipv6 ipv6-next-header l4-dst-port l4-src-port src-ipv6-high src-ipv6-low tcp-control
-         action count redirect
-         packet ipv6 forwarding routed
-      feature pbr mpls
-         sequence 65
-         key size limit 160
-         key field mpls-inner-ip-tos
-         action count drop redirect
-         packet mpls ipv4 forwarding mpls
-         packet mpls ipv6 forwarding mpls
-         packet mpls non-ip forwarding mpls
-      feature qos ip
-         sequence 75
-         key size limit 160
-         key field dscp dst-ip ip-frag ip-protocol l4-dst-port l4-ops l4-src-port src-ip tcp-control
-         action set-dscp set-policer set-tc
-         packet ipv4 forwarding routed
-         packet ipv4 forwarding routed multicast
-         packet ipv4 mpls ipv4 forwarding mpls decap
-         packet ipv4 mpls ipv6 forwarding mpls decap
-         packet ipv4 non-vxlan forwarding routed decap
-      feature qos ipv6
-         sequence 70
-         key field dst-ipv6 ipv6-next-header ipv6-traffic-class l4-dst-port l4-src-port src-ipv6-high src-ipv6-low
-         action set-dscp set-policer set-tc
-         packet ipv6 forwarding routed
-      feature tunnel vxlan
-         sequence 50
-         key size limit 160
-         packet ipv4 vxlan eth ipv4 forwarding routed decap
-         packet ipv4 vxlan forwarding bridged decap
-      feature vrf selection
-         port qualifier size 8 bits
-      feature vrf selection extended
-	  !
-	system profile vrf-selection-with-ip6-sip
-`
-
-	nokiaSecondaryDefaultLookup = `
-system datapath secondary-default-lookup admin-state enable
-`
-
-	aristaOptimizeFIBAndCounters = `
-   ip hardware fib next-hop weight-deviation 2.0
-   ip hardware fib programmed error action preserved
-   hardware fec programmed all
-   no hardware counter feature acl out ipv4
-   no hardware counter feature acl in
-   hardware counter feature ip-in-ip tunnel
-   hardware counter feature ip out layer3
-   hardware counter feature ip in layer3
-   hardware counter feature route ipv4
-   `
-
-	aristaTcamProfilePolicyForwarding = `
-hardware tcam
-  	profile tcam-policy-forwarding
-      feature traffic-policy port ipv4
-         port qualifier size 12 bits
-         sequence 45
-         key size limit 160
-         key field dscp dst-ip-label ip-frag ip-fragment-offset ip-length ip-protocol l4-dst-port-label l4-src-port-label src-ip-label tcp-control ttl
-         action count drop redirect set-dscp set-tc set-ttl
-         packet ipv4 forwarding routed
-      !
-      feature traffic-policy port ipv6
-         port qualifier size 12 bits
-         sequence 25
-         key size limit 160
-         key field dst-ipv6-label hop-limit ipv6-length ipv6-next-header ipv6-traffic-class l4-dst-port-label l4-src-port-label src-ipv6-label tcp-control
-         action count drop redirect set-dscp set-tc set-ttl
-         packet ipv6 forwarding routed
-      !
-   system profile tcam-policy-forwarding
-    !
-    hardware counter feature gre tunnel interface out
-    !
-    hardware counter feature traffic-policy in
-    !
-    hardware counter feature traffic-policy out
-    !
-    hardware counter feature route ipv4
-    !
-    hardware counter feature nexthop
-    !
-    `
-
-	aristaEnableAFTSummaries = `
-   management api models
-      !
-      provider aft
-         ipv4-unicast
-         ipv6-unicast
-         route-summary
-   agent OpenConfig terminate
-   `
-
-	aristaNGPRTcamProfile = `
-   hardware tcam
-   profile ngpr
-      feature acl port mac
-         sequence 55
-         key size limit 160
-         key field dst-mac ether-type src-mac
-         action count drop mirror
-         packet ipv4 forwarding bridged
-         packet ipv4 forwarding routed
-         packet ipv4 forwarding routed multicast
-         packet ipv4 mpls ipv4 forwarding mpls decap
-         packet ipv4 mpls ipv6 forwarding mpls decap
-         packet ipv4 non-vxlan forwarding routed decap
-         packet ipv4 vxlan forwarding bridged decap
-         packet ipv6 forwarding bridged
-         packet ipv6 forwarding routed
-         packet ipv6 forwarding routed decap
-         packet ipv6 forwarding routed multicast
-         packet ipv6 ipv6 forwarding routed decap
-         packet mpls forwarding bridged decap
-         packet mpls ipv4 forwarding mpls
-         packet mpls ipv6 forwarding mpls
-         packet mpls non-ip forwarding mpls
-         packet non-ip forwarding bridged
-      !
-      feature forwarding-destination mpls
-         sequence 100
-      !
-      feature mirror ip
-         sequence 80
-         key size limit 160
-         key field dscp dst-ip ip-frag ip-protocol l4-dst-port l4-ops l4-src-port src-ip tcp-control
-         action count mirror set-policer
-         packet ipv4 forwarding bridged
-         packet ipv4 forwarding routed
-         packet ipv4 forwarding routed multicast
-         packet ipv4 non-vxlan forwarding routed decap
-      !
-      feature mpls
-         sequence 5
-         key size limit 160
-         action drop redirect set-ecn
-         packet ipv4 mpls ipv4 forwarding mpls decap
-         packet ipv4 mpls ipv6 forwarding mpls decap
-         packet mpls ipv4 forwarding mpls
-         packet mpls ipv6 forwarding mpls
-         packet mpls non-ip forwarding mpls
-      !
-      feature mpls pop ingress
-      !
-      feature pbr mpls
-         sequence 65
-         key size limit 160
-         key field mpls-inner-ip-tos
-         action count drop redirect
-         packet mpls ipv4 forwarding mpls
-         packet mpls ipv6 forwarding mpls
-         packet mpls non-ip forwarding mpls
-      !
-      feature qos ip
-         sequence 75
-         key size limit 160
-         key field dscp dst-ip ip-frag ip-protocol l4-dst-port l4-ops l4-src-port src-ip tcp-control
-         action count set-dscp set-tc set-unshared-policer
-         packet ipv4 forwarding routed
-         packet ipv4 forwarding routed multicast
-         packet ipv4 gue mpls ipv4 forwarding routed decap
-         packet ipv4 gue mpls ipv6 forwarding routed decap
-         packet ipv4 mpls ipv4 forwarding mpls decap
-         packet ipv4 mpls ipv6 forwarding mpls decap
-         packet ipv4 non-vxlan forwarding routed decap
-      !
-      feature qos ipv6
-         sequence 70
-         key size limit 160
-         key field ipv6-traffic-class
-         action count set-dscp set-tc set-unshared-policer
-         packet ipv6 forwarding routed
-         packet ipv6 gue mpls ipv4 forwarding routed decap
-         packet ipv6 gue mpls ipv6 forwarding routed decap
-      !
-      feature qos mac
-         key size limit 160
-         key field ether-type forwarding-type ipv6-traffic-class mpls-traffic-class udf-32b-1 udf-32b-2 vlan
-         action count set-dscp set-tc set-unshared-policer
-         packet ipv6 forwarding bridged
-         packet ipv6 forwarding routed
-         packet mpls forwarding bridged decap
-         packet mpls ipv4 forwarding mpls
-         packet mpls ipv6 forwarding mpls
-         packet mpls non-ip forwarding mpls
-         packet non-ip forwarding bridged
-      !
-      feature traffic-policy cpu ipv4
-         sequence 1
-         key size limit 160
-         key field dst-ip ip-frag ip-protocol l4-dst-port l4-src-port src-ip tcp-control
-         action count set-drop-precedence set-policer
-      !
-      feature traffic-policy cpu ipv6
-         sequence 2
-         key field dst-ipv6 ipv6-next-header l4-dst-port l4-src-port src-ipv6-high src-ipv6-low tcp-control
-         action count set-drop-precedence set-policer
-      !
-      feature traffic-policy port ipv4
-         sequence 45
-         key size limit 160
-         key field dscp dst-ip-label icmp-type-code ip-frag ip-fragment-offset ip-length ip-protocol l4-dst-port-label l4-src-port-label src-ip-label tcp-control ttl
-         action count drop redirect set-dscp set-tc set-unshared-policer
-         packet ipv4 forwarding routed
-      !
-      feature traffic-policy port ipv4 egress
-         key size limit 160
-         key field dscp dst-ip-label ip-frag ip-protocol l4-dst-port-label l4-src-port-label src-ip-label tcp-control
-         action count drop redirect set-tc
-         packet ipv4 forwarding routed
-         packet mpls ipv4 forwarding mpls
-      !
-      feature traffic-policy port ipv6
-         sequence 25
-         key size limit 160
-         key field dst-ipv6-label dst-port icmp-type-code ipv6-length ipv6-next-header ipv6-traffic-class l4-dst-port-label l4-src-port-label src-ipv6-label tcp-control hop-limit
-         action count drop redirect set-dscp set-tc set-unshared-policer
-         packet ipv6 forwarding routed
-      !
-      feature traffic-policy port ipv6 egress
-         key size limit 160
-         key field dscp dst-ipv6-label ipv6-next-header l4-dst-port-label l4-src-port-label src-ipv6-label tcp-control
-         action count drop redirect set-tc
-         packet ipv6 forwarding routed
-         packet mpls ipv6 forwarding mpls
-      !
-      feature tunnel vxlan
-         sequence 50
-         key size limit 160
-         packet ipv4 vxlan eth ipv4 forwarding routed decap
-         packet ipv4 vxlan forwarding bridged decap
-   !
-   system profile ngpr
-   !
-   !
-   hardware counter feature gre tunnel interface out
-   !
-   hardware counter feature traffic-policy in
-   !
-   hardware counter feature traffic-policy out
-   !
-   hardware counter feature route ipv4
-   !
-   hardware counter feature nexthop
-   `
-
-	aristaTcamProfilePreserveTTL = `
-      hardware tcam
-      profile customProfile
-         system-rule overriding-action redirect
-         !
-         feature cfm
-            packet ipv4 forwarding bridged
-            packet ipv6 forwarding bridged
-            packet non-ip forwarding bridged
-         !
-         feature flow tracking sampled ipv4
-            key size limit 160
-            key field dst-ip ip-frag ip-protocol l4-dst-port l4-src-port src-ip vlan vrf
-            action count sample
-            packet ipv4 forwarding bridged
-            packet ipv4 forwarding routed
-            packet ipv4 forwarding routed multicast
-         !
-         feature l2-protocol forwarding
-            key size limit 160
-            key field dst-mac vlan-tag-format
-            action redirect-to-cpu
-            packet non-ip forwarding bridged
-         !
-         feature mirror ip
-            key size limit 160
-            key field dscp dst-ip ip-frag ip-protocol l4-dst-port l4-ops l4-src-port src-ip tcp-control
-            action count mirror
-            packet ipv4 forwarding bridged
-            packet ipv4 forwarding routed
-            packet ipv4 forwarding routed multicast
-            packet ipv4 non-vxlan forwarding routed decap
-         !
-         feature mpls
-            key size limit 160
-            action drop redirect set-ecn
-            packet ipv4 mpls ipv4 forwarding mpls decap
-            packet ipv4 mpls ipv6 forwarding mpls decap
-            packet mpls ipv4 forwarding mpls
-            packet mpls ipv6 forwarding mpls
-            packet mpls non-ip forwarding mpls
-         !
-         feature mpls pop ingress
-         !
-         feature pbr ip
-            key size limit 160
-            key field dscp dst-ip ip-frag ip-protocol l4-dst-port l4-ops-18b l4-src-port src-ip tcp-control
-            action count redirect
-            packet ipv4 forwarding routed
-            packet ipv4 mpls ipv4 forwarding mpls decap
-            packet ipv4 mpls ipv6 forwarding mpls decap
-            packet ipv4 non-vxlan forwarding routed decap
-            packet ipv4 vxlan forwarding bridged decap
-         !
-         feature pbr ipv6
-            key field dst-ipv6 ipv6-next-header l4-dst-port l4-src-port src-ipv6-high src-ipv6-low tcp-control
-            action count redirect
-            packet ipv6 forwarding routed
-         !
-         feature pbr mpls
-            key size limit 160
-            key field mpls-inner-ip-tos
-            action count drop redirect
-            packet mpls ipv4 forwarding mpls
-            packet mpls ipv6 forwarding mpls
-            packet mpls non-ip forwarding mpls
-         !
-         feature qos ip
-            sequence 90
-            key field dscp dst-ip forwarding-type ip-frag ip-protocol l4-dst-port l4-ops-7b l4-src-port outer-vlan-id src-ip tcp-control vlan-tag-format
-            action count set-drop-precedence set-dscp set-policer set-tc
-            packet ipv4 forwarding bridged
-            packet ipv4 forwarding routed
-            packet ipv4 forwarding routed multicast
-            packet ipv4 mpls ipv4 forwarding mpls decap
-            packet ipv4 mpls ipv6 forwarding mpls decap
-            packet ipv4 non-vxlan forwarding routed decap
-            packet ipv4 vxlan forwarding bridged decap
-         !
-         feature qos ipv6
-            key field dst-ipv6 ipv6-next-header ipv6-traffic-class l4-dst-port l4-src-port src-ipv6-high src-ipv6-low
-            action count set-drop-precedence set-dscp set-policer set-tc
-            packet ipv6 forwarding routed
-         !
-         feature qos mac
-            key size limit 160
-            key field forwarding-type ipv6-traffic-class mpls-traffic-class vlan
-            action count set-policer set-tc
-            packet ipv6 forwarding bridged
-            packet mpls forwarding bridged decap
-            packet mpls ipv4 forwarding mpls
-            packet mpls ipv6 forwarding mpls
-            packet mpls non-ip forwarding mpls
-            packet non-ip forwarding bridged
-         !
-         feature traffic-policy port ipv4
-            port qualifier size 12 bits
-            key field dscp dst-ip-label dst-mac ip-frag ip-fragment-offset ip-length ip-protocol l4-dst-port l4-src-port src-ip-label src-mac tcp-control ttl
-            action count drop redirect set-dscp set-tc set-ttl
-            packet ipv4 forwarding bridged
-            packet ipv4 forwarding routed
-            packet ipv4 mpls ipv4 forwarding mpls decap
-            packet ipv4 non-vxlan forwarding routed decap
-            packet mpls ipv4 forwarding bridged
-            packet mpls ipv4 forwarding mpls
-            packet mpls ipv4 forwarding routed decap
-         !
-         feature traffic-policy port ipv6
-            port qualifier size 12 bits
-            key field dst-ipv6-label dst-mac hop-limit ipv6-length ipv6-next-header ipv6-traffic-class l4-dst-port l4-src-port src-ipv6-label src-mac tcp-control
-            action count drop redirect set-dscp set-tc set-ttl
-            packet ipv4 mpls ipv6 forwarding mpls decap
-            packet ipv6 forwarding bridged
-            packet ipv6 forwarding routed
-            packet ipv6 forwarding routed decap
-            packet mpls ipv6 forwarding bridged
-            packet mpls ipv6 forwarding mpls
-            packet mpls ipv6 forwarding routed decap
-         !
-         feature tunnel vxlan
-            key size limit 160
-            packet ipv4 vxlan eth ipv4 forwarding routed decap
-            packet ipv4 vxlan forwarding bridged decap
-      system profile customProfile
-   !
-   `
-	aristaQOSTcamIn = `
-   hardware counter feature qos in
-   !
-   `
-
-	aristaTCAMACLCounters = `
-   hardware tcam
-   profile aclCounters
-      feature acl port ip
-         sequence 45
-         key size limit 160
-         key field dscp dst-ip ip-frag ip-protocol l4-dst-port l4-ops l4-src-port src-ip tcp-control ttl
-         action count drop mirror snoop
-         packet ipv4 forwarding bridged
-         packet ipv4 forwarding routed
-         packet ipv4 forwarding routed multicast
-         packet ipv4 mpls ipv4 forwarding mpls decap
-         packet ipv4 mpls ipv6 forwarding mpls decap
-         packet ipv4 non-vxlan forwarding routed decap
-         packet ipv4 vxlan eth ipv4 forwarding routed decap
-         packet ipv4 vxlan forwarding bridged decap
-      feature acl port ip egress mpls-tunnelled-match
-         sequence 95
-      feature acl port ipv6
-         sequence 25
-         key field dst-ipv6 ipv6-next-header ipv6-traffic-class l4-dst-port l4-ops-3b l4-src-port src-ipv6-high src-ipv6-low tcp-control
-         action count drop mirror snoop
-         packet ipv6 forwarding bridged
-         packet ipv6 forwarding routed
-         packet ipv6 forwarding routed multicast
-         packet ipv6 ipv6 forwarding routed decap
-      feature acl port ip egress
-        sequence 125
-        key field dscp dst-ip ip-frag ip-protocol l4-dst-port l4-ops l4-src-port src-ip tcp-control
-        action count drop mirror snoop
-        packet ipv4 forwarding bridged
-        packet ipv4 forwarding routed
-      feature acl port ipv6 egress
-         sequence 105
-         key field dst-ipv6 ipv6-next-header ipv6-traffic-class l4-dst-port l4-src-port src-ipv6-high src-ipv6-low tcp-control
-         action count drop mirror snoop
-         packet ipv6 forwarding bridged
-         packet ipv6 forwarding routed
-      feature acl port mac
-         sequence 55
-         key size limit 160
-         key field dst-mac ether-type src-mac
-         action count drop mirror snoop
-         packet ipv4 forwarding bridged
-         packet ipv4 forwarding routed
-         packet ipv4 forwarding routed multicast
-         packet ipv4 mpls ipv4 forwarding mpls decap
-         packet ipv4 mpls ipv6 forwarding mpls decap
-         packet ipv4 non-vxlan forwarding routed decap
-         packet ipv4 vxlan forwarding bridged decap
-         packet ipv6 forwarding bridged
-         packet ipv6 forwarding routed
-         packet ipv6 forwarding routed decap
-         packet ipv6 forwarding routed multicast
-         packet ipv6 ipv6 forwarding routed decap
-         packet mpls forwarding bridged decap
-         packet mpls ipv4 forwarding mpls
-         packet mpls ipv6 forwarding mpls
-         packet mpls non-ip forwarding mpls
-         packet non-ip forwarding bridged
-      feature acl subintf ip
-         sequence 40
-         key size limit 160
-         key field dscp dst-ip ip-frag ip-protocol l4-dst-port l4-ops-18b l4-src-port src-ip tcp-control ttl
-         action count drop
-         packet ipv4 forwarding routed
-      feature acl subintf ipv6
-         sequence 15
-         key field dst-ipv6 ipv6-next-header l4-dst-port l4-src-port src-ipv6-high src-ipv6-low tcp-control
-         action count drop
-         packet ipv6 forwarding routed
-      feature acl vlan ip
-         sequence 35
-         key size limit 160
-         key field dscp dst-ip ip-frag ip-protocol l4-dst-port l4-ops-18b l4-src-port src-ip tcp-control ttl
-         action count drop
-         packet ipv4 forwarding routed
-         packet ipv4 mpls ipv4 forwarding mpls decap
-         packet ipv4 mpls ipv6 forwarding mpls decap
-         packet ipv4 non-vxlan forwarding routed decap
-         packet ipv4 vxlan eth ipv4 forwarding routed decap
-      feature acl vlan ipv6
-         sequence 10
-         key field dst-ipv6 ipv6-next-header l4-dst-port l4-src-port src-ipv6-high src-ipv6-low tcp-control
-         action count drop
-         packet ipv6 forwarding routed
-         packet ipv6 ipv6 forwarding routed decap
-      feature acl vlan ipv6 egress
-         sequence 20
-         key field dst-ipv6 ipv6-next-header ipv6-traffic-class l4-dst-port l4-src-port src-ipv6-high src-ipv6-low tcp-control
-         action count drop mirror
-         packet ipv6 forwarding bridged
-         packet ipv6 forwarding routed
-      feature counter lfib
-         sequence 85
-      feature forwarding-destination mpls
-         sequence 100
-      feature mirror ip
-         sequence 80
-         key size limit 160
-         key field dscp dst-ip ip-frag ip-protocol l4-dst-port l4-ops l4-src-port src-ip tcp-control
-         action count mirror set-policer
-         packet ipv4 forwarding bridged
-         packet ipv4 forwarding routed
-         packet ipv4 forwarding routed multicast
-         packet ipv4 non-vxlan forwarding routed decap
-      feature mpls
-         sequence 5
-         key size limit 160
-         action drop redirect set-ecn
-         packet ipv4 mpls ipv4 forwarding mpls decap
-         packet ipv4 mpls ipv6 forwarding mpls decap
-         packet mpls ipv4 forwarding mpls
-         packet mpls ipv6 forwarding mpls
-         packet mpls non-ip forwarding mpls
-      feature mpls pop ingress
-         sequence 90
-      feature pbr ip
-         sequence 60
-         key size limit 160
-         key field dscp dst-ip ip-frag ip-protocol l4-dst-port l4-ops-18b l4-src-port src-ip tcp-control
-         action count redirect
-         packet ipv4 forwarding routed
-         packet ipv4 mpls ipv4 forwarding mpls decap
-         packet ipv4 mpls ipv6 forwarding mpls decap
-         packet ipv4 non-vxlan forwarding routed decap
-         packet ipv4 vxlan forwarding bridged decap
-      feature pbr ipv6
-         sequence 30
-         key field dst-ipv6 ipv6-next-header l4-dst-port l4-src-port src-ipv6-high src-ipv6-low tcp-control
-         action count redirect
-         packet ipv6 forwarding routed
-      feature pbr mpls
-         sequence 65
-         key size limit 160
-         key field mpls-inner-ip-tos
-         action count drop redirect
-         packet mpls ipv4 forwarding mpls
-         packet mpls ipv6 forwarding mpls
-         packet mpls non-ip forwarding mpls
-      feature qos ip
-         sequence 75
-         key size limit 160
-         key field dscp dst-ip ip-frag ip-protocol l4-dst-port l4-ops l4-src-port src-ip tcp-control
-         action set-dscp set-policer set-tc
-         packet ipv4 forwarding routed
-         packet ipv4 forwarding routed multicast
-         packet ipv4 mpls ipv4 forwarding mpls decap
-         packet ipv4 mpls ipv6 forwarding mpls decap
-         packet ipv4 non-vxlan forwarding routed decap
-      feature qos ipv6
-         sequence 70
-         key field dst-ipv6 ipv6-next-header ipv6-traffic-class l4-dst-port l4-src-port src-ipv6-high src-ipv6-low
-         action set-dscp set-policer set-tc
-         packet ipv6 forwarding routed
-      feature tunnel vxlan
-         sequence 50
-         key size limit 160
-         packet ipv4 vxlan eth ipv4 forwarding routed decap
-         packet ipv4 vxlan forwarding bridged decap
-	  !
-	system profile aclCounters
-   !
-    hardware counter feature acl in units packets
-   !
-   hardware counter feature acl out ipv4 units packets
-   !
-   hardware counter feature acl out ipv6 units packets 
-   !
-   `
-
-	aristaIngressARP = `
-   hardware tcam
-   profile ingress-arp
-      feature acl port mac
-         sequence 55
-         key size limit 160
-         key field dst-mac ether-type src-mac
-         action count drop mirror
-         packet ipv4 forwarding bridged
-         packet ipv4 forwarding routed
-         packet ipv4 forwarding routed multicast
-         packet ipv4 mpls ipv4 forwarding mpls decap
-         packet ipv4 mpls ipv6 forwarding mpls decap
-         packet ipv4 non-vxlan forwarding routed decap
-         packet ipv4 vxlan forwarding bridged decap
-         packet ipv6 forwarding bridged
-         packet ipv6 forwarding routed
-         packet ipv6 forwarding routed decap
-         packet ipv6 forwarding routed multicast
-         packet ipv6 ipv6 forwarding routed decap
-         packet mpls forwarding bridged decap
-         packet mpls ipv4 forwarding mpls
-         packet mpls ipv6 forwarding mpls
-         packet mpls non-ip forwarding mpls
-         packet non-ip forwarding bridged
-      !
-      feature forwarding-destination mpls
-         sequence 100
-      !
-      feature mirror ip
-         sequence 80
-         key size limit 160
-         key field dscp dst-ip ip-frag ip-protocol l4-dst-port l4-ops l4-src-port src-ip tcp-control
-         action count mirror set-policer
-         packet ipv4 forwarding bridged
-         packet ipv4 forwarding routed
-         packet ipv4 forwarding routed multicast
-         packet ipv4 non-vxlan forwarding routed decap
-      !
-      feature mpls
-         sequence 5
-         key size limit 160
-         action drop redirect set-ecn
-         packet ipv4 mpls ipv4 forwarding mpls decap
-         packet ipv4 mpls ipv6 forwarding mpls decap
-         packet mpls ipv4 forwarding mpls
-         packet mpls ipv6 forwarding mpls
-         packet mpls non-ip forwarding mpls
-      !
-      feature mpls pop ingress
-      !
-      feature pbr mpls
-         sequence 65
-         key size limit 160
-         key field mpls-inner-ip-tos
-         action count drop redirect
-         packet mpls ipv4 forwarding mpls
-         packet mpls ipv6 forwarding mpls
-         packet mpls non-ip forwarding mpls
-      !
-      feature qos ip
-         sequence 75
-         key size limit 160
-         key field dscp dst-ip ip-frag ip-protocol l4-dst-port l4-ops l4-src-port src-ip tcp-control
-         action count set-dscp set-tc set-unshared-policer
-         packet ipv4 forwarding routed
-         packet ipv4 forwarding routed multicast
-         packet ipv4 mpls ipv4 forwarding mpls decap
-         packet ipv4 mpls ipv6 forwarding mpls decap
-         packet ipv4 non-vxlan forwarding routed decap
-      !
-      feature qos ipv6
-         sequence 70
-         key size limit 160
-         key field ipv6-traffic-class
-         action count set-dscp set-tc set-unshared-policer
-         packet ipv6 forwarding routed
-      !
-      feature qos mac
-         key size limit 160
-         key field ether-type forwarding-type ipv6-traffic-class mpls-traffic-class udf-32b-1 udf-32b-2 vlan
-         action count set-dscp set-tc set-unshared-policer
-         packet ipv6 forwarding bridged
-         packet ipv6 forwarding routed
-         packet mpls forwarding bridged decap
-         packet mpls ipv4 forwarding mpls
-         packet mpls ipv6 forwarding mpls
-         packet mpls non-ip forwarding mpls
-         packet non-ip forwarding bridged
-      !
-      feature traffic-policy cpu ipv4
-         sequence 1
-         key size limit 160
-         key field dst-ip ip-frag ip-protocol l4-dst-port l4-src-port src-ip tcp-control
-         action count set-drop-precedence set-policer
-      !
-      feature traffic-policy cpu ipv6
-         sequence 2
-         key field dst-ipv6 ipv6-next-header l4-dst-port l4-src-port src-ipv6-high src-ipv6-low tcp-control
-         action count set-drop-precedence set-policer
-      !
-      feature traffic-policy port ipv4
-         sequence 45
-         key size limit 160
-         key field dscp dst-ip-label icmp-type-code ip-frag ip-fragment-offset ip-length ip-protocol l4-dst-port-label l4-src-port-label src-ip-label tcp-control ttl
-         action count drop redirect set-dscp set-tc set-unshared-policer
-         packet ipv4 forwarding routed
-      !
-      feature traffic-policy port ipv4 egress
-         key size limit 160
-         key field dscp dst-ip-label ip-frag ip-protocol l4-dst-port-label l4-src-port-label src-ip-label tcp-control
-         action count drop redirect set-tc
-         packet ipv4 forwarding routed
-         packet mpls ipv4 forwarding mpls
-      !
-      feature traffic-policy port ipv6
-         sequence 25
-         key size limit 160
-         key field dst-ipv6-label icmp-type-code ipv6-length ipv6-next-header ipv6-traffic-class l4-dst-port-label l4-src-port-label src-ipv6-label tcp-control
-         action count drop redirect set-dscp set-tc set-unshared-policer
-         packet ipv6 forwarding routed
-      !
-      feature traffic-policy port ipv6 egress
-         key size limit 160
-         key field dscp dst-ipv6-label ipv6-next-header l4-dst-port-label l4-src-port-label src-ipv6-label tcp-control
-         action count drop redirect set-tc
-         packet ipv6 forwarding routed
-         packet mpls ipv6 forwarding mpls
-      !
-      feature tunnel vxlan
-         sequence 50
-         key size limit 160
-         packet ipv4 vxlan eth ipv4 forwarding routed decap
-         packet ipv4 vxlan forwarding bridged decap
-   system profile ingress-arp
-   !
-   `
-
-	aristaAnPF = `
-   management api models
-   provider smash
-      path flexCounters/counterTable/Nexthop 
-      path routing/nexthopgroup/entrystatus
-      path tunnel/nexthop
-      
-   hardware tcam
-  
-   !
-   profile anPF-Customer-tcam
-      system-rule overriding-action redirect
-      !
-      feature acl vlan ipv6 egress
-         key field forwarding-type
-         action count
-         packet ipv6 forwarding bridged
-         packet ipv6 forwarding routed
-      !
-      feature cfm
-         packet ipv4 forwarding bridged
-         packet ipv6 forwarding bridged
-         packet non-ip forwarding bridged
-      !
-      feature flow tracking sampled ipv4
-         key size limit 160
-         key field dst-ip ip-frag ip-protocol l4-dst-port l4-src-port src-ip vlan vrf
-         action count sample
-         packet ipv4 forwarding bridged
-         packet ipv4 forwarding routed
-         packet ipv4 forwarding routed multicast
-      !
-      feature interface-policing
-         action count police-interface
-         packet ipv4 forwarding routed
-         packet ipv6 forwarding routed
-      !
-      feature l2-protocol forwarding
-         key size limit 160
-         key field dst-mac vlan-tag-format
-         action redirect-to-cpu
-         packet non-ip forwarding bridged
-      !
-      feature mirror ip
-         key size limit 160
-         key field dscp dst-ip ip-frag ip-protocol l4-dst-port l4-ops l4-src-port src-ip tcp-control
-         action count mirror
-         packet ipv4 forwarding bridged
-         packet ipv4 forwarding routed
-         packet ipv4 forwarding routed multicast
-         packet ipv4 non-vxlan forwarding routed decap
-      !
-      feature mpls
-         key size limit 160
-         action drop redirect set-ecn
-         packet ipv4 mpls ipv4 forwarding mpls decap
-         packet ipv4 mpls ipv6 forwarding mpls decap
-         packet mpls ipv4 forwarding mpls
-         packet mpls ipv6 forwarding mpls
-         packet mpls non-ip forwarding mpls
-      !
-      feature mpls pop ingress
-      !
-      feature mpls pop ingress multicast
-         packet mpls ipv4 forwarding mpls php
-         packet mpls ipv6 forwarding mpls php
-      !
-      feature qos ip
-         sequence 90
-         port qualifier size 2 bits
-         key field dscp dst-ip forwarding-type ip-frag ip-protocol l4-dst-port l4-ops-7b l4-src-port outer-vlan-id src-ip tcp-control vlan-tag-format
-         action count set-drop-precedence set-dscp set-policer set-tc
-         packet ipv4 forwarding bridged
-         packet ipv4 forwarding routed
-         packet ipv4 forwarding routed multicast
-         packet ipv4 mpls ipv4 forwarding mpls decap
-         packet ipv4 mpls ipv6 forwarding mpls decap
-         packet ipv4 non-vxlan forwarding routed decap
-      !
-      feature qos ipv6
-         port qualifier size 2 bits
-         key field dst-ipv6 ipv6-next-header ipv6-traffic-class l4-dst-port l4-src-port src-ipv6-high src-ipv6-low
-         action count set-drop-precedence set-dscp set-policer set-tc
-         packet ipv6 forwarding routed
-      !
-      feature qos mac
-         key size limit 160
-         port qualifier size 2 bits
-         key field forwarding-type ipv6-traffic-class mpls-traffic-class vlan
-         action count set-dscp set-policer set-tc
-         packet ipv6 forwarding bridged
-         packet mpls forwarding bridged decap
-         packet mpls ipv4 forwarding mpls
-         packet mpls ipv6 forwarding mpls
-         packet mpls non-ip forwarding mpls
-         packet non-ip forwarding bridged
-      !
-      feature traffic-policy port ipv4
-         port qualifier size 12 bits
-         key field dscp dst-ip-label dst-mac ip-frag ip-fragment-offset ip-length ip-protocol ipv4-mc l4-dst-port l4-src-port src-ip-label src-mac tcp-control ttl
-         action copy-ttl count drop redirect set-dscp set-fwd-layer-index set-tc set-ttl
-         packet ipv4 forwarding bridged
-         packet ipv4 forwarding routed
-         packet ipv4 mpls ipv4 forwarding mpls decap
-         packet ipv4 non-vxlan forwarding routed decap
-         packet mpls ipv4 forwarding bridged
-         packet mpls ipv4 forwarding mpls
-         packet mpls ipv4 forwarding routed decap
-      !
-      feature traffic-policy port ipv6
-         port qualifier size 12 bits
-         key field dst-ipv6-label dst-mac hop-limit ipv6-length ipv6-mc ipv6-next-header ipv6-traffic-class l4-dst-port l4-src-port src-ipv6-label src-mac tcp-control
-         action copy-ttl count drop redirect set-dscp set-fwd-layer-index set-tc set-ttl
-         packet ipv4 mpls ipv6 forwarding mpls decap
-         packet ipv6 forwarding bridged
-         packet ipv6 forwarding routed
-         packet ipv6 forwarding routed decap
-         packet mpls ipv6 forwarding bridged
-         packet mpls ipv6 forwarding mpls
-         packet mpls ipv6 forwarding routed decap
-   !
-   profile anPF-Final
-      system-rule overriding-action redirect
-      !
-      feature acl vlan ipv6 egress
-         key field forwarding-type
-         action count
-         packet ipv6 forwarding bridged
-         packet ipv6 forwarding routed
-      !
-      feature cfm
-         packet ipv4 forwarding bridged
-         packet ipv6 forwarding bridged
-         packet non-ip forwarding bridged
-      !
-      feature flow tracking sampled ipv4
-         key size limit 160
-         key field dst-ip ip-frag ip-protocol l4-dst-port l4-src-port src-ip vlan vrf
-         action count sample
-         packet ipv4 forwarding bridged
-         packet ipv4 forwarding routed
-         packet ipv4 forwarding routed multicast
-      !
-      feature interface-policing
-         action count police-interface
-         packet ipv4 forwarding routed
-         packet ipv6 forwarding routed
-      !
-      feature l2-protocol forwarding
-         key size limit 160
-         key field dst-mac vlan-tag-format
-         action redirect-to-cpu
-         packet non-ip forwarding bridged
-      !
-      feature mirror ip
-         key size limit 160
-         key field dscp dst-ip ip-frag ip-protocol l4-dst-port l4-ops l4-src-port src-ip tcp-control
-         action count mirror
-         packet ipv4 forwarding bridged
-         packet ipv4 forwarding routed
-         packet ipv4 forwarding routed multicast
-         packet ipv4 non-vxlan forwarding routed decap
-      !
-      feature mpls
-         key size limit 160
-         action drop redirect set-ecn
-         packet ipv4 mpls ipv4 forwarding mpls decap
-         packet ipv4 mpls ipv6 forwarding mpls decap
-         packet mpls ipv4 forwarding mpls
-         packet mpls ipv6 forwarding mpls
-         packet mpls non-ip forwarding mpls
-      !
-      feature mpls pop ingress
-      !
-      feature mpls pop ingress multicast
-         packet mpls ipv4 forwarding mpls php
-         packet mpls ipv6 forwarding mpls php
-      !
-      feature qos ip
-         sequence 90
-         port qualifier size 6 bits
-         key field dscp dst-ip forwarding-type ip-frag ip-protocol l4-dst-port l4-ops-7b l4-src-port outer-vlan-id src-ip tcp-control vlan-tag-format
-         action count set-drop-precedence set-dscp set-policer set-tc
-         packet ipv4 forwarding bridged
-         packet ipv4 forwarding routed
-         packet ipv4 forwarding routed multicast
-         packet ipv4 mpls ipv4 forwarding mpls decap
-         packet ipv4 mpls ipv6 forwarding mpls decap
-         packet ipv4 non-vxlan forwarding routed decap
-      !
-      feature qos ipv6
-         port qualifier size 6 bits
-         key field dst-ipv6 ipv6-next-header ipv6-traffic-class l4-dst-port l4-src-port src-ipv6-high src-ipv6-low
-         action count set-drop-precedence set-dscp set-policer set-tc
-         packet ipv6 forwarding routed
-      !
-      feature qos mac
-         key size limit 160
-         port qualifier size 6 bits
-         key field forwarding-type ipv6-traffic-class mpls-traffic-class vlan
-         action count set-dscp set-policer set-tc
-         packet ipv6 forwarding bridged
-         packet mpls forwarding bridged decap
-         packet mpls ipv4 forwarding mpls
-         packet mpls ipv6 forwarding mpls
-         packet mpls non-ip forwarding mpls
-         packet non-ip forwarding bridged
-      !
-      feature traffic-policy port ipv4
-         port qualifier size 12 bits
-         key field dscp dst-ip dst-mac ether-type ip-frag ip-fragment-offset ip-length ip-protocol ip-type ipv4-mc l4-dst-port l4-src-port src-ip src-mac tcp-control ttl
-         action copy-ttl count drop drop-pseudowire redirect set-fwd-layer-index set-policer set-ttl
-         packet ipv4 forwarding bridged
-         packet ipv4 forwarding routed
-         packet ipv4 mpls ipv4 forwarding mpls decap
-         packet ipv4 non-vxlan forwarding routed decap
-         packet mpls ipv4 forwarding bridged
-         packet mpls ipv4 forwarding mpls
-         packet mpls ipv4 forwarding routed decap
-         packet non-ip forwarding bridged
-      !
-      feature traffic-policy port ipv6
-         port qualifier size 12 bits
-         key field dst-mac hop-limit ipv6-length ipv6-mc ipv6-next-header ipv6-traffic-class l4-dst-port l4-src-port src-mac tcp-control
-         action copy-ttl count drop drop-pseudowire redirect set-fwd-layer-index set-policer set-ttl
-         packet ipv4 mpls ipv6 forwarding mpls decap
-         packet ipv6 forwarding bridged
-         packet ipv6 forwarding routed
-         packet ipv6 forwarding routed decap
-         packet mpls ipv6 forwarding bridged
-         packet mpls ipv6 forwarding mpls
-         packet mpls ipv6 forwarding routed decap
-   !
-   profile anPF-final
-   !
-   system profile anPF-Customer-tcam
-   `
-	aristaAnpfTcamProfile = `
-hardware tcam
-   profile anPF
-      system-rule overriding-action redirect
-      !
-      feature cfm
-         packet ipv4 forwarding bridged
-         packet ipv6 forwarding bridged
-         packet non-ip forwarding bridged
-      !
-      feature flow tracking sampled ipv4
-         key size limit 160
-         key field dst-ip ip-frag ip-protocol l4-dst-port l4-src-port src-ip vlan vrf
-         action count sample
-         packet ipv4 forwarding bridged
-         packet ipv4 forwarding routed
-         packet ipv4 forwarding routed multicast
-      !
-      feature l2-protocol forwarding
-         key size limit 160
-         key field dst-mac vlan-tag-format
-         action redirect-to-cpu
-         packet non-ip forwarding bridged
-      !
-      feature mirror ip
-         key size limit 160
-         key field dscp dst-ip ip-frag ip-protocol l4-dst-port l4-ops l4-src-port src-ip tcp-control
-         action count mirror
-         packet ipv4 forwarding bridged
-         packet ipv4 forwarding routed
-         packet ipv4 forwarding routed multicast
-         packet ipv4 non-vxlan forwarding routed decap
-      !
-      feature mpls
-         key size limit 160
-         action drop redirect set-ecn
-         packet ipv4 mpls ipv4 forwarding mpls decap
-         packet ipv4 mpls ipv6 forwarding mpls decap
-         packet mpls ipv4 forwarding mpls
-         packet mpls ipv6 forwarding mpls
-         packet mpls non-ip forwarding mpls
-      !
-      feature mpls pop ingress
-      !
-      feature pbr ip
-         key size limit 160
-         key field dscp dst-ip ip-frag ip-protocol l4-dst-port l4-ops-18b l4-src-port src-ip tcp-control
-         action count redirect
-         packet ipv4 forwarding routed
-         packet ipv4 mpls ipv4 forwarding mpls decap
-         packet ipv4 mpls ipv6 forwarding mpls decap
-         packet ipv4 non-vxlan forwarding routed decap
-         packet ipv4 vxlan forwarding bridged decap
-      !
-      feature pbr ipv6
-         key field dst-ipv6 ipv6-next-header l4-dst-port l4-src-port src-ipv6-high src-ipv6-low tcp-control
-         action count redirect
-         packet ipv6 forwarding routed
-      !
-      feature pbr mpls
-         key size limit 160
-         key field mpls-inner-ip-tos
-         action count drop redirect
-         packet mpls ipv4 forwarding mpls
-         packet mpls ipv6 forwarding mpls
-         packet mpls non-ip forwarding mpls
-      !
-      feature qos ip
-         sequence 90
-         key field dscp dst-ip forwarding-type ip-frag ip-protocol l4-dst-port l4-ops-7b l4-src-port outer-vlan-id src-ip tcp-control vlan-tag-format
-         action count set-drop-precedence set-dscp set-policer set-tc
-         packet ipv4 forwarding bridged
-         packet ipv4 forwarding routed
-         packet ipv4 forwarding routed multicast
-         packet ipv4 mpls ipv4 forwarding mpls decap
-         packet ipv4 mpls ipv6 forwarding mpls decap
-         packet ipv4 non-vxlan forwarding routed decap
-         packet ipv4 vxlan forwarding bridged decap
-      !
-      feature qos ipv6
-         key field dst-ipv6 ipv6-next-header ipv6-traffic-class l4-dst-port l4-src-port src-ipv6-high src-ipv6-low
-         action count set-drop-precedence set-dscp set-policer set-tc
-         packet ipv6 forwarding routed
-      !
-      feature qos mac
-         key size limit 160
-         key field forwarding-type ipv6-traffic-class mpls-traffic-class vlan
-         action count set-policer set-tc
-         packet ipv6 forwarding bridged
-         packet mpls forwarding bridged decap
-         packet mpls ipv4 forwarding mpls
-         packet mpls ipv6 forwarding mpls
-         packet mpls non-ip forwarding mpls
-         packet non-ip forwarding bridged
-      !
-      feature traffic-policy port ipv4
-         port qualifier size 12 bits
-         key field dscp dst-ip-label dst-mac ip-frag ip-fragment-offset ip-length ip-protocol l4-dst-port l4-src-port src-ip-label src-mac tcp-control ttl
-         action count drop redirect set-dscp set-tc set-ttl
-         packet ipv4 forwarding bridged
-         packet ipv4 forwarding routed
-         packet ipv4 mpls ipv4 forwarding mpls decap
-         packet ipv4 non-vxlan forwarding routed decap
-         packet mpls ipv4 forwarding bridged
-         packet mpls ipv4 forwarding mpls
-         packet mpls ipv4 forwarding routed decap
-      !
-      feature traffic-policy port ipv6
-         port qualifier size 12 bits
-         key field dst-ipv6-label dst-mac hop-limit ipv6-length ipv6-next-header ipv6-traffic-class l4-dst-port l4-src-port src-ipv6-label src-mac tcp-control
-         action count drop redirect set-dscp set-tc set-ttl
-         packet ipv4 mpls ipv6 forwarding mpls decap
-         packet ipv6 forwarding bridged
-         packet ipv6 forwarding routed
-         packet ipv6 forwarding routed decap
-         packet mpls ipv6 forwarding bridged
-         packet mpls ipv6 forwarding mpls
-         packet mpls ipv6 forwarding routed decap
-      !
-      feature tunnel vxlan
-         key size limit 160
-         packet ipv4 vxlan eth ipv4 forwarding routed decap
-         packet ipv4 vxlan forwarding bridged decap
-   !
-   system profile anPF
-   `
 )
 
-const aristaHierarchicalFIB = `
-router general
-   rib fib fec hierarchical resolution
-!
-   `
-
-var (
-	aristaTcamProfileMap = map[FeatureType]string{
-		FeatureMplsTracking:           aristaTcamProfileMplsTracking,
-		FeatureVrfSelectionExtended:   aristaTcamProfileVrfSelectionExtended,
-		FeaturePolicyForwarding:       aristaTcamProfilePolicyForwarding,
-		FeatureEnableAFTSummaries:     aristaEnableAFTSummaries,
-		FeatureNGPR:                   aristaNGPRTcamProfile,
-		FeatureTTLPolicyForwarding:    aristaTcamProfilePreserveTTL,
-		FeatureQOSIn:                  aristaQOSTcamIn,
-		FeatureACLCounters:            aristaTCAMACLCounters,
-		FeatureAnPF:                   aristaAnPF,
-		FeatureIngressARP:             aristaIngressARP,
-		FeatureOptimizeFIBAndCounters: aristaOptimizeFIBAndCounters,
-		FeatureAnpf:                   aristaAnpfTcamProfile,
-		FeatureHierarchicalFIB:        aristaHierarchicalFIB,
-	}
-
-	nokiaHardwareInitMap = map[FeatureType]string{
-		FeatureSecondaryDefaultLookup: nokiaSecondaryDefaultLookup,
-	}
-)
+// hardwareInitSnippets maps features to their hardware init CLI snippets in
+// clisnippets.
+var hardwareInitSnippets = map[FeatureType]string{
+	FeatureMplsTracking:           "hardware-init/mpls-tracking",
+	FeatureVrfSelectionExtended:   "hardware-init/vrf-selection-extended",
+	FeaturePolicyForwarding:       "hardware-init/policy-forwarding",
+	FeatureEnableAFTSummaries:     "hardware-init/aft-summaries",
+	FeatureNGPR:                   "hardware-init/ngpr",
+	FeatureTTLPolicyForwarding:    "hardware-init/ttl-policy-forwarding",
+	FeatureQOSIn:                  "hardware-init/qos-in",
+	FeatureACLCounters:            "hardware-init/acl-counters",
+	FeatureAnPF:                   "hardware-init/anpf",
+	FeatureIngressARP:             "hardware-init/ingress-arp",
+	FeatureOptimizeFIBAndCounters: "hardware-init/optimize-fib-and-counters",
+	FeatureHierarchicalFIB:        "hardware-init/hierarchical-fib",
+	FeatureSecondaryDefaultLookup: "hardware-init/secondary-default-lookup",
+	FeatureAnpf:                   "hardware-init/anpf-tcam",
+}
 
 func buildCliSetRequest(config string) *gpb.SetRequest {
 	gpbSetRequest := &gpb.SetRequest{
@@ -1417,18 +92,18 @@ func buildCliSetRequest(config string) *gpb.SetRequest {
 	return gpbSetRequest
 }
 
+// NewDUTHardwareInit returns the hardware init CLI for a feature on dut, or
+// "" if the DUT needs none.  The CLI comes from the clisnippets registry.
 func NewDUTHardwareInit(t *testing.T, dut *ondatra.DUTDevice, feature FeatureType) string {
-	switch dut.Vendor() {
-	case ondatra.ARISTA:
-		if strings.ToLower(dut.Model()) == "ceos" {
-			return ""
-		}
-		return aristaTcamProfileMap[feature]
-	case ondatra.NOKIA:
-		return nokiaHardwareInitMap[feature]
-	default:
+	t.Helper()
+	if dut.Vendor() == ondatra.ARISTA && strings.ToLower(dut.Model()) == "ceos" {
+		return ""
+	}
+	name, ok := hardwareInitSnippets[feature]
+	if !ok {
 		return ""
 	}
+	return clisnippets.Default().For(t, dut, name, nil)
 }
 
 func PushDUTHardwareInitConfig(t *testing.T, dut *ondatra.DUTDevice, hardwareInitConf string) {
@@ -1497,9 +172,7 @@ func EnableHardwareCounters(t *testing.T, dut *ondatra.DUTDevice, feature string
 	t.Helper()
 	switch dut.Vendor() {
 	case ondatra.ARISTA:
-		counterCli := fmt.Sprintf(`
-         hardware counter feature %s
-         clear counters`, feature)
+		counterCli := clisnippets.Default().For(t, dut, "hardware-counters", map[string]any{"Feature": feature})
 		helpers.GnmiCLIConfig(t, dut, counterCli)
 	default:
 		t.Fatalf("Unsupported vendor: %v", dut.Vendor())
@@ -1582,7 +255,7 @@ func ConfigureAnpfHardwareTcam(t *testing.T, dut *ondatra.DUTDevice) {
 	t.Helper()
 	switch dut.Vendor() {
 	case ondatra.ARISTA:
-		helpers.GnmiCLIConfig(t, dut, aristaAnpfTcamProfile)
+		helpers.GnmiCLIConfig(t, dut, clisnippets.Default().For(t, dut, hardwareInitSnippets[FeatureAnpf], nil))
 	default:
 		t.Fatalf("Unsupported vendor: %v", dut.Vendor())
 	}
@@ -0,0 +1,572 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cfgplugins
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/openconfig/ondatra"
+)
+
+// HardwareProfileConfig holds the hardware init features a test needs.
+type HardwareProfileConfig struct {
+	// Features are merged in order: settings of a TCAM feature, such as its
+	// sequence, are taken from the first feature defining them.
+	Features []FeatureType
+	// Reload reboots the DUT with RebootChassis after a changed TCAM profile
+	// is pushed, for platforms that only program a new profile on boot.
+	Reload bool
+}
+
+// HardwareProfile is the hardware init CLI composed from several features.
+type HardwareProfile struct {
+	// Global is the configuration outside "hardware tcam".
+	Global string
+	// TCAM is the merged "hardware tcam" block, or "" if no feature needs one.
+	TCAM string
+	// TCAMName is the name of the TCAM profile selected by TCAM.
+	TCAMName string
+
+	cli  string
+	tcam *tcamProfile
+}
+
+// CLI returns the full hardware init configuration, with the configuration
+// the features have before "hardware tcam" first.
+func (p *HardwareProfile) CLI() string {
+	return p.cli
+}
+
+// tcamFeature is a "feature" block of a TCAM profile.
+type tcamFeature struct {
+	header string
+	lines  []string
+}
+
+// tcamProfile is an Arista TCAM profile, with whitespace and "!" separators
+// removed.
+type tcamProfile struct {
+	name string
+	// source is the rest of the profile header, such as "copy default".
+	source   string
+	rules    []string
+	features []*tcamFeature
+}
+
+func (p *tcamProfile) feature(header string) *tcamFeature {
+	for _, f := range p.features {
+		if f.header == header {
+			return f
+		}
+	}
+	return nil
+}
+
+// equal reports whether two profiles program the same features, ignoring
+// the order of lines.
+func (p *tcamProfile) equal(o *tcamProfile) bool {
+	if p.name != o.name || p.source != o.source || !sameLines(p.rules, o.rules) || len(p.features) != len(o.features) {
+		return false
+	}
+	for _, f := range p.features {
+		of := o.feature(f.header)
+		if of == nil || !sameLines(f.lines, of.lines) {
+			return false
+		}
+	}
+	return true
+}
+
+func (p *tcamProfile) body() string {
+	var b strings.Builder
+	for _, r := range p.rules {
+		fmt.Fprintf(&b, "      %s\n", r)
+	}
+	for _, f := range p.features {
+		fmt.Fprintf(&b, "      %s\n", f.header)
+		for _, l := range f.lines {
+			fmt.Fprintf(&b, "         %s\n", l)
+		}
+		b.WriteString("      !\n")
+	}
+	return b.String()
+}
+
+func (p *tcamProfile) String() string {
+	header := "profile " + p.name
+	if p.source != "" {
+		header += " " + p.source
+	}
+	return fmt.Sprintf("   %s\n%s   !\n", header, p.body())
+}
+
+// tcamConfig is the "hardware tcam" block of Arista hardware init CLI.
+type tcamConfig struct {
+	// settings are the lines of the block outside its profiles.
+	settings []string
+	profiles []*tcamProfile
+	// active is the name given by "system profile", "" if there is none.
+	active string
+}
+
+func (c *tcamConfig) profile(name string) *tcamProfile {
+	for _, p := range c.profiles {
+		if p.name == name {
+			return p
+		}
+	}
+	return nil
+}
+
+func (c *tcamConfig) String() string {
+	var b strings.Builder
+	b.WriteString("hardware tcam\n")
+	for _, s := range c.settings {
+		fmt.Fprintf(&b, "   %s\n", s)
+	}
+	for _, p := range c.profiles {
+		b.WriteString(p.String())
+	}
+	if c.active != "" {
+		fmt.Fprintf(&b, "   system profile %s\n", c.active)
+	}
+	b.WriteString("!\n")
+	return b.String()
+}
+
+// hardwareInit is hardware init CLI split around its "hardware tcam" block.
+type hardwareInit struct {
+	// before and after are the lines outside "hardware tcam", with their
+	// indentation.
+	before, after []string
+	tcam          *tcamConfig
+}
+
+func sameLines(a, b []string) bool {
+	return containsLines(a, b) && containsLines(b, a)
+}
+
+// containsLines reports whether every line of b is in a.
+func containsLines(a, b []string) bool {
+	for _, l := range b {
+		if !slices.Contains(a, l) {
+			return false
+		}
+	}
+	return true
+}
+
+// cliLines returns the non-empty lines of cli, with their indentation.
+func cliLines(cli string) []string {
+	var lines []string
+	for _, l := range strings.Split(cli, "\n") {
+		if l = strings.TrimRight(l, " \t"); strings.TrimSpace(l) != "" {
+			lines = append(lines, l)
+		}
+	}
+	return lines
+}
+
+// normalizeLines returns lines with their whitespace collapsed, without
+// empty and "!" lines.
+func normalizeLines(lines []string) []string {
+	var out []string
+	for _, l := range lines {
+		if l = strings.Join(strings.Fields(l), " "); l != "" && l != "!" {
+			out = append(out, l)
+		}
+	}
+	return out
+}
+
+// parseHardwareInit splits Arista hardware init CLI around its "hardware
+// tcam" block, which ends with "system profile".  Indentation in the
+// snippets is not consistent, so the block is parsed by keyword.
+func parseHardwareInit(cli string) *hardwareInit {
+	var (
+		h       = &hardwareInit{}
+		done    bool
+		profile *tcamProfile
+		feature *tcamFeature
+	)
+	for _, raw := range cliLines(cli) {
+		line := strings.Join(strings.Fields(raw), " ")
+		switch {
+		case h.tcam == nil && line == "hardware tcam":
+			h.tcam = &tcamConfig{}
+		case h.tcam == nil:
+			h.before = append(h.before, raw)
+		case done:
+			h.after = append(h.after, raw)
+		case line == "!":
+		case strings.HasPrefix(line, "system profile "):
+			h.tcam.active = strings.TrimPrefix(line, "system profile ")
+			done = true
+		case strings.HasPrefix(line, "profile "):
+			name, source, _ := strings.Cut(strings.TrimPrefix(line, "profile "), " ")
+			profile, feature = &tcamProfile{name: name, source: source}, nil
+			h.tcam.profiles = append(h.tcam.profiles, profile)
+		case profile == nil:
+			h.tcam.settings = append(h.tcam.settings, line)
+		case strings.HasPrefix(line, "feature "):
+			feature = &tcamFeature{header: line}
+			profile.features = append(profile.features, feature)
+		case feature != nil:
+			feature.lines = append(feature.lines, line)
+		default:
+			profile.rules = append(profile.rules, line)
+		}
+	}
+	return h
+}
+
+// lineSetting returns the setting a TCAM feature line configures: "action",
+// or the line up to its first number, such as "sequence" or "port qualifier
+// size".
+func lineSetting(line string) string {
+	if strings.HasPrefix(line, "action ") {
+		return "action"
+	}
+	words := strings.Fields(line)
+	for i, w := range words {
+		if w[0] >= '0' && w[0] <= '9' {
+			return strings.Join(words[:i], " ")
+		}
+	}
+	return line
+}
+
+func isKeyLine(line string) bool {
+	return strings.HasPrefix(line, "key field ") || strings.HasPrefix(line, "key size limit ")
+}
+
+// keyFields returns the words of the "key field" line of a TCAM feature.
+func keyFields(lines []string) []string {
+	for _, l := range lines {
+		if fields, ok := strings.CutPrefix(l, "key field "); ok {
+			return strings.Fields(fields)
+		}
+	}
+	return nil
+}
+
+// replaceKey returns lines with their key lines replaced by those of key.
+func replaceKey(lines, key []string) []string {
+	var (
+		out  []string
+		keys = slices.DeleteFunc(slices.Clone(key), func(l string) bool { return !isKeyLine(l) })
+	)
+	for _, l := range lines {
+		if !isKeyLine(l) {
+			out = append(out, l)
+			continue
+		}
+		out, keys = append(out, keys...), nil
+	}
+	return append(out, keys...)
+}
+
+// mergeFeatureLines merges the lines of two definitions of a TCAM feature,
+// where a comes from a feature earlier in HardwareProfileConfig.Features
+// than b.  "packet" lines and the words of "action" lines are merged.  The
+// key, given by "key field" and "key size limit", is that of the definition
+// whose fields include the other's, so that it fits in a key size the DUT
+// was configured with; fields that neither includes are a conflict.  Other
+// settings, such as "sequence", keep the value of a.
+func mergeFeatureLines(a, b []string) ([]string, error) {
+	keyA, keyB := keyFields(a), keyFields(b)
+	merged := slices.Clone(a)
+	switch {
+	case containsLines(keyA, keyB):
+	case containsLines(keyB, keyA):
+		merged = replaceKey(merged, b)
+	default:
+		return nil, fmt.Errorf("key fields %q and %q do not fit in one key", strings.Join(keyA, " "), strings.Join(keyB, " "))
+	}
+	for _, l := range b {
+		if slices.Contains(merged, l) || isKeyLine(l) {
+			continue
+		}
+		if strings.HasPrefix(l, "packet ") {
+			merged = append(merged, l)
+			continue
+		}
+		setting := lineSetting(l)
+		i := slices.IndexFunc(merged, func(m string) bool {
+			return !strings.HasPrefix(m, "packet ") && lineSetting(m) == setting
+		})
+		switch {
+		case i < 0:
+			merged = append(merged, l)
+		case setting == "action":
+			words := strings.Fields(merged[i])
+			for _, w := range strings.Fields(l)[1:] {
+				if !slices.Contains(words, w) {
+					words = append(words, w)
+				}
+			}
+			merged[i] = strings.Join(words, " ")
+		}
+	}
+	return merged, nil
+}
+
+// mergeTCAMProfiles merges profiles into one, merging the lines of a feature
+// requested by several profiles with mergeFeatureLines in the order of
+// profiles.
+func mergeTCAMProfiles(profiles []*tcamProfile) (*tcamProfile, error) {
+	switch len(profiles) {
+	case 0:
+		return nil, nil
+	case 1:
+		return profiles[0], nil
+	}
+	merged := &tcamProfile{source: profiles[0].source}
+	var (
+		errs  []error
+		owner = map[string]string{}
+	)
+	for _, p := range profiles {
+		if p.source != merged.source {
+			errs = append(errs, fmt.Errorf("TCAM profile %s %q differs from %s %q", p.name, p.source, profiles[0].name, merged.source))
+		}
+		for _, r := range p.rules {
+			if !slices.Contains(merged.rules, r) {
+				merged.rules = append(merged.rules, r)
+			}
+		}
+		for _, f := range p.features {
+			mf := merged.feature(f.header)
+			if mf == nil {
+				merged.features = append(merged.features, &tcamFeature{header: f.header, lines: slices.Clone(f.lines)})
+				owner[f.header] = p.name
+				continue
+			}
+			lines, err := mergeFeatureLines(mf.lines, f.lines)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("TCAM %q differs between profiles %s and %s: %w", f.header, owner[f.header], p.name, err))
+				continue
+			}
+			mf.lines = lines
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	merged.name = fmt.Sprintf("fp-%x", sha256.Sum256([]byte(merged.body())))[:11]
+	return merged, nil
+}
+
+// mergeTCAMConfigs merges "hardware tcam" blocks into one, whose active
+// profile merges their active profiles with mergeTCAMProfiles.  Settings
+// and other profiles are kept once.
+func mergeTCAMConfigs(configs []*tcamConfig) (*tcamConfig, error) {
+	switch len(configs) {
+	case 0:
+		return nil, nil
+	case 1:
+		return configs[0], nil
+	}
+	var (
+		merged = &tcamConfig{}
+		active []*tcamProfile
+		others []*tcamProfile
+		errs   []error
+	)
+	for _, c := range configs {
+		for _, s := range c.settings {
+			if !slices.Contains(merged.settings, s) {
+				merged.settings = append(merged.settings, s)
+			}
+		}
+		for _, p := range c.profiles {
+			if p.name == c.active {
+				active = append(active, p)
+				continue
+			}
+			i := slices.IndexFunc(others, func(o *tcamProfile) bool { return o.name == p.name })
+			switch {
+			case i < 0:
+				others = append(others, p)
+			case !others[i].equal(p):
+				errs = append(errs, fmt.Errorf("TCAM profile %s is defined differently", p.name))
+			}
+		}
+		if c.active != "" && c.profile(c.active) == nil {
+			errs = append(errs, fmt.Errorf("system profile %s is not defined with the TCAM profiles", c.active))
+		}
+	}
+	profile, err := mergeTCAMProfiles(active)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	if profile != nil {
+		if slices.ContainsFunc(others, func(o *tcamProfile) bool { return o.name == profile.name }) {
+			return nil, fmt.Errorf("TCAM profile %s is both active and inactive", profile.name)
+		}
+		merged.profiles, merged.active = append(merged.profiles, profile), profile.name
+	}
+	merged.profiles = append(merged.profiles, others...)
+	return merged, nil
+}
+
+// hardwareInitBlock is the CLI of one feature outside the TCAM profile.
+type hardwareInitBlock struct {
+	feature FeatureType
+	lines   []string
+}
+
+// globalConflicts returns the lines that one feature negates with "no" and
+// another configures.
+func globalConflicts(blocks []hardwareInitBlock) []error {
+	var errs []error
+	for _, a := range blocks {
+		for _, neg := range a.lines {
+			cmd, ok := strings.CutPrefix(neg, "no ")
+			if !ok {
+				continue
+			}
+			for _, b := range blocks {
+				if b.feature == a.feature {
+					continue
+				}
+				for _, l := range b.lines {
+					if l == cmd || strings.HasPrefix(l, cmd+" ") {
+						errs = append(errs, fmt.Errorf("%q of %s conflicts with %q of %s", neg, hardwareInitSnippets[a.feature], l, hardwareInitSnippets[b.feature]))
+					}
+				}
+			}
+		}
+	}
+	return errs
+}
+
+// composeHardwareProfile merges the hardware init CLI of features, given
+// by snippets, for a vendor.  Only Arista TCAM profiles are merged; other
+// vendors' snippets are concatenated.  The CLI features have before and
+// after "hardware tcam" stays on that side of the merged block, and a block
+// of CLI shared by several features is kept once.
+func composeHardwareProfile(vendor ondatra.Vendor, features []FeatureType, snippets map[FeatureType]string) (*HardwareProfile, error) {
+	var (
+		before, after []string
+		seen          []string
+		tcams         []*tcamConfig
+		blocks        []hardwareInitBlock
+	)
+	add := func(out *[]string, lines []string) {
+		block := strings.Join(normalizeLines(lines), "\n")
+		if block == "" || slices.Contains(seen, block) {
+			return
+		}
+		seen = append(seen, block)
+		*out = append(*out, lines...)
+	}
+	for _, f := range features {
+		cli := snippets[f]
+		if strings.TrimSpace(cli) == "" {
+			continue
+		}
+		h := &hardwareInit{before: cliLines(cli)}
+		if vendor == ondatra.ARISTA {
+			h = parseHardwareInit(cli)
+		}
+		if h.tcam != nil {
+			tcams = append(tcams, h.tcam)
+		}
+		blocks = append(blocks, hardwareInitBlock{feature: f, lines: normalizeLines(append(slices.Clone(h.before), h.after...))})
+		add(&before, h.before)
+		add(&after, h.after)
+	}
+	errs := globalConflicts(blocks)
+	tcam, err := mergeTCAMConfigs(tcams)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	var global strings.Builder
+	for _, l := range append(before, after...) {
+		global.WriteString(l + "\n")
+	}
+	p := &HardwareProfile{Global: global.String()}
+	if tcam != nil {
+		p.TCAM, p.TCAMName, p.tcam = tcam.String(), tcam.active, tcam.profile(tcam.active)
+	}
+	var cli strings.Builder
+	for _, l := range before {
+		cli.WriteString(l + "\n")
+	}
+	cli.WriteString(p.TCAM)
+	for _, l := range after {
+		cli.WriteString(l + "\n")
+	}
+	p.cli = cli.String()
+	return p, nil
+}
+
+// NewDUTHardwareProfile composes the hardware init CLI of several features
+// into one profile for dut, merging their TCAM profiles.  It fails the test
+// if the features conflict.
+func NewDUTHardwareProfile(t *testing.T, dut *ondatra.DUTDevice, cfg HardwareProfileConfig) *HardwareProfile {
+	t.Helper()
+	snippets := map[FeatureType]string{}
+	for _, f := range cfg.Features {
+		snippets[f] = NewDUTHardwareInit(t, dut, f)
+	}
+	p, err := composeHardwareProfile(dut.Vendor(), cfg.Features, snippets)
+	if err != nil {
+		t.Fatalf("Conflicting hardware init features %v on %s: %v", cfg.Features, dut.Name(), err)
+	}
+	return p
+}
+
+// runningTCAMProfile returns the active TCAM profile of an Arista DUT.
+func runningTCAMProfile(t *testing.T, dut *ondatra.DUTDevice) *tcamProfile {
+	h := parseHardwareInit(runCliCommand(t, dut, "show running-config section hardware tcam"))
+	if h.tcam == nil {
+		return nil
+	}
+	return h.tcam.profile(h.tcam.active)
+}
+
+// PushDUTHardwareProfile composes and pushes the hardware init CLI of
+// cfg.Features.  The TCAM profile is only pushed if it differs from the
+// running one, in which case the DUT is rebooted if cfg.Reload is set.
+func PushDUTHardwareProfile(t *testing.T, dut *ondatra.DUTDevice, cfg HardwareProfileConfig) {
+	t.Helper()
+	p := NewDUTHardwareProfile(t, dut, cfg)
+	cli := p.CLI()
+	tcamChanged := p.tcam != nil
+	if tcamChanged && dut.Vendor() == ondatra.ARISTA {
+		if running := runningTCAMProfile(t, dut); running != nil && running.equal(p.tcam) {
+			t.Logf("TCAM profile %s is already active on %s", p.TCAMName, dut.Name())
+			cli, tcamChanged = p.Global, false
+		}
+	}
+	PushDUTHardwareInitConfig(t, dut, cli)
+	if tcamChanged && cfg.Reload {
+		t.Logf("Rebooting %s to apply TCAM profile %s", dut.Name(), p.TCAMName)
+		RebootChassis(t, dut)
+	}
+}
@@ -0,0 +1,382 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cfgplugins
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/featureprofiles/internal/cfgplugins/clisnippets"
+	"github.com/openconfig/ondatra"
+)
+
+func TestParseHardwareInit(t *testing.T) {
+	cli := `
+hardware counter feature traffic-policy in
+!
+   hardware tcam
+   system rewrite ingress
+   profile one copy default
+      feature acl port ip
+         sequence 45
+         action count drop
+         packet ipv4 forwarding routed
+      !
+      feature mpls
+         sequence 5
+   !
+   profile two
+      system-rule overriding-action redirect
+      feature mpls
+         sequence 10
+   !
+   system profile one
+!
+hardware counter feature acl in units packets
+`
+	got := parseHardwareInit(cli)
+	want := &hardwareInit{
+		before: []string{"hardware counter feature traffic-policy in", "!"},
+		tcam: &tcamConfig{
+			settings: []string{"system rewrite ingress"},
+			profiles: []*tcamProfile{{
+				name:   "one",
+				source: "copy default",
+				features: []*tcamFeature{
+					{header: "feature acl port ip", lines: []string{"sequence 45", "action count drop", "packet ipv4 forwarding routed"}},
+					{header: "feature mpls", lines: []string{"sequence 5"}},
+				},
+			}, {
+				name:     "two",
+				rules:    []string{"system-rule overriding-action redirect"},
+				features: []*tcamFeature{{header: "feature mpls", lines: []string{"sequence 10"}}},
+			}},
+			active: "one",
+		},
+		after: []string{"!", "hardware counter feature acl in units packets"},
+	}
+	if diff := cmp.Diff(want, got, cmp.AllowUnexported(hardwareInit{}, tcamConfig{}, tcamProfile{}, tcamFeature{})); diff != "" {
+		t.Errorf("parseHardwareInit() diff (-want +got):\n%s", diff)
+	}
+	if wantPrefix := "hardware tcam\n   system rewrite ingress\n   profile one copy default\n      feature acl port ip\n"; !strings.HasPrefix(got.tcam.String(), wantPrefix) {
+		t.Errorf("tcamConfig.String() = %q, want prefix %q", got.tcam.String(), wantPrefix)
+	}
+}
+
+// hardwareInitCLI renders the hardware init snippet of feature for vendor,
+// or returns "" if there is none.
+func hardwareInitCLI(t *testing.T, feature FeatureType, vendor ondatra.Vendor) string {
+	t.Helper()
+	cli, err := clisnippets.Default().Render(t.Name(), hardwareInitSnippets[feature], vendor.String(), "", "", nil)
+	if err != nil {
+		t.Fatalf("Render(%s) failed: %v", hardwareInitSnippets[feature], err)
+	}
+	return cli
+}
+
+func TestHardwareInitRoundTrip(t *testing.T) {
+	for f, name := range hardwareInitSnippets {
+		for _, vendor := range []ondatra.Vendor{ondatra.ARISTA, ondatra.CISCO, ondatra.JUNIPER, ondatra.NOKIA} {
+			cli := hardwareInitCLI(t, f, vendor)
+			if cli == "" {
+				continue
+			}
+			t.Run(fmt.Sprintf("%s/%v", name, vendor), func(t *testing.T) {
+				p, err := composeHardwareProfile(vendor, []FeatureType{f}, map[FeatureType]string{f: cli})
+				if err != nil {
+					t.Fatalf("composeHardwareProfile() failed: %v", err)
+				}
+				if diff := cmp.Diff(normalizeLines(cliLines(cli)), normalizeLines(cliLines(p.CLI()))); diff != "" {
+					t.Errorf("composeHardwareProfile() CLI differs from the snippet (-want +got):\n%s", diff)
+				}
+			})
+		}
+	}
+}
+
+func TestMergeFeatureLines(t *testing.T) {
+	tests := []struct {
+		desc    string
+		a, b    []string
+		want    []string
+		wantErr string
+	}{{
+		desc: "identical",
+		a:    []string{"sequence 45", "key size limit 160", "action count drop"},
+		b:    []string{"sequence 45", "key size limit 160", "action count drop"},
+		want: []string{"sequence 45", "key size limit 160", "action count drop"},
+	}, {
+		desc: "subset",
+		a:    []string{"sequence 45", "packet ipv4 forwarding routed", "packet ipv4 forwarding bridged"},
+		b:    []string{"packet ipv4 forwarding bridged"},
+		want: []string{"sequence 45", "packet ipv4 forwarding routed", "packet ipv4 forwarding bridged"},
+	}, {
+		desc: "packet lines",
+		a:    []string{"sequence 45", "packet ipv4 forwarding routed"},
+		b:    []string{"sequence 45", "packet ipv6 forwarding routed"},
+		want: []string{"sequence 45", "packet ipv4 forwarding routed", "packet ipv6 forwarding routed"},
+	}, {
+		desc: "actions",
+		a:    []string{"action count drop mirror"},
+		b:    []string{"action count drop mirror snoop"},
+		want: []string{"action count drop mirror snoop"},
+	}, {
+		desc: "key of first",
+		a:    []string{"key size limit 160", "key field dscp dst-ip src-ip", "action count"},
+		b:    []string{"key field dst-ip src-ip", "action drop"},
+		want: []string{"key size limit 160", "key field dscp dst-ip src-ip", "action count drop"},
+	}, {
+		desc: "key of second",
+		a:    []string{"sequence 45", "key size limit 160", "key field dst-ip", "action count"},
+		b:    []string{"key field dst-ip src-ip", "action count"},
+		want: []string{"sequence 45", "key field dst-ip src-ip", "action count"},
+	}, {
+		desc: "key of second with size limit",
+		a:    []string{"sequence 45", "action count"},
+		b:    []string{"key size limit 160", "key field dst-ip"},
+		want: []string{"sequence 45", "action count", "key size limit 160", "key field dst-ip"},
+	}, {
+		desc: "new setting",
+		a:    []string{"action count"},
+		b:    []string{"sequence 95", "port qualifier size 12 bits"},
+		want: []string{"action count", "sequence 95", "port qualifier size 12 bits"},
+	}, {
+		desc: "settings of first",
+		a:    []string{"sequence 90", "port qualifier size 2 bits", "action count"},
+		b:    []string{"sequence 75", "port qualifier size 6 bits", "action count"},
+		want: []string{"sequence 90", "port qualifier size 2 bits", "action count"},
+	}, {
+		desc:    "key conflict",
+		a:       []string{"key size limit 160", "key field dscp dst-ip"},
+		b:       []string{"key size limit 160", "key field dst-ip src-ip"},
+		wantErr: `key fields "dscp dst-ip" and "dst-ip src-ip" do not fit in one key`,
+	}}
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := mergeFeatureLines(tc.a, tc.b)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("mergeFeatureLines() error = %v, want error containing %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("mergeFeatureLines() failed: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("mergeFeatureLines() diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestComposeHardwareProfile(t *testing.T) {
+	const (
+		aclIP = `
+hardware tcam
+   profile a copy default
+      feature acl port ip
+         sequence 45
+         key field dscp dst-ip
+         action count drop mirror
+         packet ipv4 forwarding routed
+   system profile a
+`
+		aclIPSnoop = `
+hardware counter feature traffic-policy in
+hardware tcam
+   system rewrite ingress
+   profile b copy default
+      feature acl port ip
+         sequence 50
+         key field dst-ip
+         action count drop mirror snoop
+         packet ipv4 forwarding bridged
+      feature mpls
+         sequence 5
+   !
+   profile spare
+      feature mpls
+         sequence 5
+   system profile b
+hardware counter feature acl in units packets
+`
+		aclIPOtherKey = `
+hardware tcam
+   profile c copy default
+      feature acl port ip
+         key field src-ip
+   system profile c
+`
+		otherSource = `
+hardware tcam
+   profile d
+      feature mpls
+         sequence 5
+   system profile d
+`
+		noCounter = "no hardware counter feature acl in\n"
+	)
+	tests := []struct {
+		desc       string
+		vendor     ondatra.Vendor
+		snippets   []string
+		wantGlobal string
+		wantTCAM   []string
+		wantErr    string
+	}{{
+		desc:     "single profile",
+		vendor:   ondatra.ARISTA,
+		snippets: []string{aclIP},
+		wantTCAM: []string{"   profile a copy default\n", "action count drop mirror", "   system profile a\n"},
+	}, {
+		desc:       "merged profiles",
+		vendor:     ondatra.ARISTA,
+		snippets:   []string{aclIP, aclIPSnoop, aclIP},
+		wantGlobal: "hardware counter feature traffic-policy in\nhardware counter feature acl in units packets\n",
+		wantTCAM: []string{
+			"hardware tcam\n   system rewrite ingress\n   profile fp-",
+			" copy default\n",
+			"      feature acl port ip\n",
+			"         sequence 45\n",
+			"         key field dscp dst-ip\n",
+			"         action count drop mirror snoop\n",
+			"         packet ipv4 forwarding routed\n",
+			"         packet ipv4 forwarding bridged\n",
+			"      feature mpls\n",
+			"   profile spare\n",
+		},
+	}, {
+		desc:     "key conflict",
+		vendor:   ondatra.ARISTA,
+		snippets: []string{aclIP, aclIPOtherKey},
+		wantErr:  `TCAM "feature acl port ip" differs between profiles a and c: key fields "dscp dst-ip" and "src-ip" do not fit in one key`,
+	}, {
+		desc:     "source conflict",
+		vendor:   ondatra.ARISTA,
+		snippets: []string{aclIP, otherSource},
+		wantErr:  `TCAM profile d "" differs from a "copy default"`,
+	}, {
+		desc:     "global conflict",
+		vendor:   ondatra.ARISTA,
+		snippets: []string{aclIPSnoop, noCounter},
+		wantErr:  `"no hardware counter feature acl in"`,
+	}, {
+		desc:       "other vendor",
+		vendor:     ondatra.JUNIPER,
+		snippets:   []string{"set a\n", "set b\n  set c\n", "set a\n"},
+		wantGlobal: "set a\nset b\n  set c\n",
+	}}
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			var features []FeatureType
+			snippets := map[FeatureType]string{}
+			for i, s := range tc.snippets {
+				features = append(features, FeatureType(i))
+				snippets[FeatureType(i)] = s
+			}
+			p, err := composeHardwareProfile(tc.vendor, features, snippets)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("composeHardwareProfile() error = %v, want error containing %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("composeHardwareProfile() failed: %v", err)
+			}
+			if p.Global != tc.wantGlobal {
+				t.Errorf("composeHardwareProfile() global = %q, want %q", p.Global, tc.wantGlobal)
+			}
+			for _, w := range tc.wantTCAM {
+				if !strings.Contains(p.TCAM, w) {
+					t.Errorf("composeHardwareProfile() TCAM does not contain %q:\n%s", w, p.TCAM)
+				}
+			}
+			if !strings.Contains(p.CLI(), p.TCAM) {
+				t.Errorf("composeHardwareProfile() CLI %q does not contain TCAM %q", p.CLI(), p.TCAM)
+			}
+		})
+	}
+}
+
+// TestComposeHardwareProfilePairs composes every pair of Arista hardware
+// init features.  A pair either merges into a profile keeping the key of one
+// of its definitions of each TCAM feature and the settings of the first, or
+// fails for keys that do not fit together or conflicting global CLI.
+func TestComposeHardwareProfilePairs(t *testing.T) {
+	snippets := map[FeatureType]string{}
+	var features []FeatureType
+	for f := range hardwareInitSnippets {
+		if cli := hardwareInitCLI(t, f, ondatra.ARISTA); cli != "" {
+			snippets[f] = cli
+			features = append(features, f)
+		}
+	}
+	slices.Sort(features)
+	composed := map[[2]FeatureType]bool{}
+	for _, a := range features {
+		for _, b := range features {
+			if a == b {
+				continue
+			}
+			t.Run(hardwareInitSnippets[a]+"+"+hardwareInitSnippets[b], func(t *testing.T) {
+				p, err := composeHardwareProfile(ondatra.ARISTA, []FeatureType{a, b}, snippets)
+				if err != nil {
+					for _, e := range strings.Split(err.Error(), "\n") {
+						if !strings.Contains(e, "do not fit in one key") && !strings.Contains(e, "conflicts with") {
+							t.Errorf("composeHardwareProfile() failed: %s", e)
+						}
+					}
+					return
+				}
+				composed[[2]FeatureType{a, b}] = true
+				if got := parseHardwareInit(p.CLI()); got.tcam == nil && p.tcam != nil || p.tcam != nil && !got.tcam.profile(got.tcam.active).equal(p.tcam) {
+					t.Errorf("Composed CLI does not parse back to its TCAM profile:\n%s", p.CLI())
+				}
+				ha, hb := parseHardwareInit(snippets[a]), parseHardwareInit(snippets[b])
+				if ha.tcam == nil || hb.tcam == nil {
+					return
+				}
+				pa, pb := ha.tcam.profile(ha.tcam.active), hb.tcam.profile(hb.tcam.active)
+				for _, f := range p.tcam.features {
+					fa, fb := pa.feature(f.header), pb.feature(f.header)
+					if fa == nil || fb == nil {
+						continue
+					}
+					if key := keyFields(f.lines); !slices.Equal(key, keyFields(fa.lines)) && !slices.Equal(key, keyFields(fb.lines)) {
+						t.Errorf("%q key fields %q are not those of either feature", f.header, key)
+					}
+					for _, l := range fa.lines {
+						if strings.HasPrefix(l, "sequence ") && !slices.Contains(f.lines, l) {
+							t.Errorf("%q lines %q, want %q of the first feature", f.header, f.lines, l)
+						}
+					}
+				}
+			})
+		}
+	}
+	for _, pair := range [][2]FeatureType{
+		{FeatureVrfSelectionExtended, FeaturePolicyForwarding},
+		{FeatureMplsTracking, FeatureACLCounters},
+	} {
+		if !composed[pair] {
+			t.Errorf("composeHardwareProfile(%s, %s) failed, want a merged profile", hardwareInitSnippets[pair[0]], hardwareInitSnippets[pair[1]])
+		}
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/openconfig/featureprofiles/internal/cfgplugins/clisnippets"
 	"github.com/openconfig/featureprofiles/internal/deviations"
 	"github.com/openconfig/featureprofiles/internal/fptest"
 	"github.com/openconfig/featureprofiles/internal/helpers"
@@ -340,7 +341,7 @@ func MPLSStaticLSPConfig(t *testing.T, dut *ondatra.DUTDevice, ni *oc.NetworkIns
 				}
 				helpers.GnmiCLIConfig(t, dut, mplsStaticLspConfigV6)
 			} else {
-				helpers.GnmiCLIConfig(t, dut, staticLSPArista)
+				helpers.GnmiCLIConfig(t, dut, clisnippets.Default().For(t, dut, "mpls/static-lsp", nil))
 			}
 		default:
 			t.Logf("Unsupported vendor %s for native command support for deviation 'mpls static lsp'", dut.Vendor())
@@ -395,14 +396,10 @@ func NewMPLSSRBasic(t *testing.T, batch *gnmi.SetBatch, dut *ondatra.DUTDevice,
 	}
 }
 
-// LabelRangeOCConfig configures MPLS label ranges on the DUT using OpenConfig.
-func LabelRangeOCConfig(t *testing.T, dut *ondatra.DUTDevice) {
-	t.Helper()
-	d := &oc.Root{}
-	ni := d.GetOrCreateNetworkInstance(deviations.DefaultNetworkInstance(dut))
-	mplsObj := ni.GetOrCreateMpls().GetOrCreateGlobal()
-	// Map of local-id → [lowerBound, upperBound]
-	labelRanges := map[string][2]uint32{
+// LabelRanges returns the local IDs of the reserved label blocks configured
+// by LabelRangeOCConfig mapped to their [lowerBound, upperBound].
+func LabelRanges() map[string][2]uint32 {
+	return map[string][2]uint32{
 		"bgp-sr":                  {16, 0},
 		"dynamic":                 {16, 0},
 		"isis-sr":                 {16, 0},
@@ -412,6 +409,15 @@ func LabelRangeOCConfig(t *testing.T, dut *ondatra.DUTDevice) {
 		"srlb":                    {16, 0},
 		"static":                  {16, 1048560},
 	}
+}
+
+// LabelRangeOCConfig configures MPLS label ranges on the DUT using OpenConfig.
+func LabelRangeOCConfig(t *testing.T, dut *ondatra.DUTDevice) {
+	t.Helper()
+	d := &oc.Root{}
+	ni := d.GetOrCreateNetworkInstance(deviations.DefaultNetworkInstance(dut))
+	mplsObj := ni.GetOrCreateMpls().GetOrCreateGlobal()
+	labelRanges := LabelRanges()
 	t.Logf("Mpls Object %v, label range %v", mplsObj, labelRanges)
 	for localID, bounds := range labelRanges {
 		rlb := mplsObj.GetOrCreateReservedLabelBlock(localID)
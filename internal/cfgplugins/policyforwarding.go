@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/openconfig/featureprofiles/internal/attrs"
+	"github.com/openconfig/featureprofiles/internal/cfgplugins/clisnippets"
 	"github.com/openconfig/featureprofiles/internal/deviations"
 	"github.com/openconfig/featureprofiles/internal/helpers"
 	"github.com/openconfig/ondatra"
@@ -111,196 +112,6 @@ type ACLTrafficPolicyParams struct {
 	Action       string
 }
 
-var (
-
-	// PolicyForwardingConfigv4Arista configuration for policy-forwarding for ipv4.
-	PolicyForwardingConfigv4Arista = `
-Traffic-policies
-   traffic-policy tp_cloud_id_3_20
-      match bgpsetttlv4 ipv4
-         ttl 1
-         actions
-            redirect next-hop group 1V4_vlan_3_20 ttl 1
-            set traffic class 3
-      match icmpechov4 ipv4
-         destination prefix 169.254.0.11/32
-         protocol icmp type echo-reply code all
-      match ipv4-all-default ipv4
-         actions
-            redirect next-hop group 1V4_vlan_3_20
-            set traffic class 3
-      match ipv6-all-default ipv6
-   !
-     `
-	// PolicyForwardingConfigv6Arista configuration for policy-forwarding for ipv6.
-	PolicyForwardingConfigv6Arista = `
-Traffic-policies
-    traffic-policy tp_cloud_id_3_21
-    match bgpsetttlv6 ipv6
-       ttl 1
-       !
-       actions
-          count
-          redirect next-hop group 1V6_vlan_3_21 ttl 1
-          set traffic class 3
-    !
-    match icmpv6 ipv6
-       destination prefix 2600:2d00:0:1:8000:10:0:ca33/128
-       protocol icmpv6 type echo-reply neighbor-advertisement code all
-       !
-       
-    !
-    match ipv4-all-default ipv4
-    !
-    match ipv6-all-default ipv6
-       actions
-          count
-          redirect next-hop group 1V6_vlan_3_21
-          set traffic class 3
- !
-    `
-	// PolicyForwardingConfigDualStackArista configuration for policy-forwarding for the dualstack.
-	PolicyForwardingConfigDualStackArista = `
-   Traffic-policies
-    traffic-policy tp_cloud_id_3_22
-    match bgpsetttlv6 ipv6
-       ttl 1
-       !
-       actions
-          count
-          redirect next-hop group 1V6_vlan_3_22 ttl 1
-          set traffic class 3
-    !
-    match icmpv6 ipv6
-       destination prefix 2600:2d00:0:1:7000:10:0:ca33/128
-       protocol icmpv6 type echo-reply neighbor-advertisement code all
-       !
-       actions
-          count
-    !
-    match bgpsetttlv4 ipv4
-       ttl 1
-       !
-       actions
-          count
-          redirect next-hop group 1V4_vlan_3_22 ttl 1
-          set traffic class 3
-    !
-    match icmpechov4 ipv4
-       destination prefix 169.254.0.27/32
-       protocol icmp type echo-reply code all
-       !
-       actions
-          count
-    !
-    match ipv4-all-default ipv4
-       actions
-          count
-          redirect next-hop group 1V4_vlan_3_22
-          set traffic class 3
-    !
-    match ipv6-all-default ipv6
-       actions
-          count
-          redirect next-hop group 1V6_vlan_3_22
-          set traffic class 3
- !`
-	// PolicyForwardingConfigMulticloudAristav4 configuration for policy-forwarding for multicloud ipv4.
-	PolicyForwardingConfigMulticloudAristav4 = `
- Traffic-policies
- counter interface per-interface ingress
- !
- traffic-policy tp_cloud_id_3_23
-		match icmpechov4 ipv4
-			 destination prefix 169.254.0.33/32
-			 protocol icmp type echo-reply code all
-			 !
-			 actions
-					count
-		!
-		match bgpsetttlv4 ipv4
-			 ttl 1
-			 !
-			 actions
-					count
-					redirect next-hop group 1V4_vlan_3_23 ttl 1
-					set traffic class 3
-		!
-		match ipv4-all-default ipv4
-			 actions
-					count
-					redirect next-hop group 1V4_vlan_3_23
-					set traffic class 3
-		!
-		match ipv6-all-default ipv6
- !
-`
-	qosconfigArista = `
- qos map dscp 0 1 2 3 4 5 6 7 to traffic-class 0
- qos map dscp 8 9 10 11 12 13 14 15 to traffic-class 1
- qos map dscp 40 41 42 43 44 45 46 47 to traffic-class 4
- qos map dscp 48 49 50 51 52 53 54 55 to traffic-class 7
-!
- policy-map type quality-of-service af3
-   class class-default
-      set traffic-class 3
-!
-`
-
-	mplsLabelRangeArista = `
-mpls label range bgp-sr 16 0
-mpls label range dynamic 16 0
-mpls label range isis-sr 16 0
-mpls label range l2evpn 16 0
-mpls label range l2evpn ethernet-segment 16 0
-mpls label range ospf-sr 16 0
-mpls label range srlb 16 0
-mpls label range static 16 1048560
-!
-`
-	decapGroupGREArista = `
-ip decap-group gre-decap
-  tunnel type gre
-  tunnel decap-ip 11.0.0.0/8
-  tunnel overlay mpls qos map mpls-traffic-class to traffic-class
-!`
-
-	decapGroupGUEArista = `
-!
-ip decap-group type udp destination port 6635 payload mpls
-!
-ip decap-group gre-decap
-  tunnel type udp
-  tunnel decap-ip 11.0.0.0/8
-  tunnel overlay mpls qos map mpls-traffic-class to traffic-class
-!`
-
-	staticLSPArista = `
-mpls static top-label 99991 169.254.0.12 pop payload-type ipv4 access-list bypass
-mpls static top-label 99992 2600:2d00:0:1:8000:10:0:ca32 pop payload-type ipv6 access-list bypass
-mpls static top-label 99993 169.254.0.26 pop payload-type ipv4 access-list bypass
-mpls static top-label 99994 2600:2d00:0:1:7000:10:0:ca32 pop payload-type ipv6 access-list bypass
-`
-
-	decapGroupGREAristaMPLSTemplate = `
-ip decap-group %s
-  tunnel type gre
-  tunnel decap-ip %s
-  tunnel decap-interface %s
-  tunnel overlay mpls qos map mpls-traffic-class to traffic-class
-!`
-
-	interfaceTrafficPolicyAristaTemplate = `
-interface %s
-traffic-policy input %s
-!`
-
-	interfaceTrafficPolicyAristaCloud = `
-interface %s.%d
-traffic-policy input tp_cloud_id_3_%d
-!`
-)
-
 // InterfacelocalProxyConfig configures the interface local-proxy-arp.
 func InterfacelocalProxyConfig(t *testing.T, dut *ondatra.DUTDevice, a *attrs.Attributes, aggID string) {
 	if deviations.LocalProxyOCUnsupported(dut) {
@@ -421,7 +232,10 @@ func InterfaceQosClassificationConfig(t *testing.T, dut *ondatra.DUTDevice, a *a
 	if deviations.QosClassificationOCUnsupported(dut) {
 		switch dut.Vendor() {
 		case ondatra.ARISTA:
-			helpers.GnmiCLIConfig(t, dut, fmt.Sprintf("interface %s.%d \n service-policy type qos input af3 \n", aggID, a.Subinterface))
+			helpers.GnmiCLIConfig(t, dut, clisnippets.Default().For(t, dut, "qos/interface-service-policy", map[string]any{
+				"Interface":  fmt.Sprintf("%s.%d", aggID, a.Subinterface),
+				"PolicyName": "af3",
+			}))
 		default:
 			t.Logf("Unsupported vendor %s for native command support for deviation 'qos classification'", dut.Vendor())
 		}
@@ -438,13 +252,11 @@ func InterfacePolicyForwardingConfig(t *testing.T, dut *ondatra.DUTDevice, a *at
 		switch dut.Vendor() {
 		case ondatra.ARISTA: // Currently supports Arista devices for CLI deviations.
 			// Format and apply the CLI command for traffic policy input.
-			var cliConfig string
-			if params.Dynamic && a == nil && aggID == "" && params.AppliedPolicyName != "" && params.InterfaceID != "" {
-				cliConfig = fmt.Sprintf(interfaceTrafficPolicyAristaTemplate, params.InterfaceID, params.AppliedPolicyName)
-			} else {
-				cliConfig = fmt.Sprintf(interfaceTrafficPolicyAristaCloud, aggID, a.Subinterface, a.Subinterface)
+			intf, policy := params.InterfaceID, params.AppliedPolicyName
+			if !(params.Dynamic && a == nil && aggID == "" && policy != "" && intf != "") {
+				intf, policy = fmt.Sprintf("%s.%d", aggID, a.Subinterface), fmt.Sprintf("tp_cloud_id_3_%d", a.Subinterface)
 			}
-			helpers.GnmiCLIConfig(t, dut, cliConfig)
+			helpers.GnmiCLIConfig(t, dut, clisnippets.Default().For(t, dut, "policy-forwarding/interface", map[string]any{"Interface": intf, "PolicyName": policy}))
 		default:
 			// Log a message if the vendor is not supported for this specific CLI deviation.
 			t.Logf("Unsupported vendor %s for native command support for deviation 'policy-forwarding config'", dut.Vendor())
@@ -479,7 +291,7 @@ func QosClassificationConfig(t *testing.T, dut *ondatra.DUTDevice) {
 	if deviations.QosClassificationOCUnsupported(dut) {
 		switch dut.Vendor() {
 		case ondatra.ARISTA:
-			helpers.GnmiCLIConfig(t, dut, qosconfigArista)
+			helpers.GnmiCLIConfig(t, dut, clisnippets.Default().For(t, dut, "qos/classification", nil))
 		default:
 			t.Logf("Unsupported vendor %s for native command support for deviation 'qos classification'", dut.Vendor())
 		}
@@ -493,7 +305,7 @@ func LabelRangeConfig(t *testing.T, dut *ondatra.DUTDevice) {
 	if deviations.MplsLabelClassificationOCUnsupported(dut) {
 		switch dut.Vendor() {
 		case ondatra.ARISTA:
-			helpers.GnmiCLIConfig(t, dut, mplsLabelRangeArista)
+			helpers.GnmiCLIConfig(t, dut, clisnippets.Default().For(t, dut, "mpls/label-range", nil))
 		default:
 			t.Logf("Unsupported vendor %s for native command support for deviation 'mpls label range'", dut.Vendor())
 		}
@@ -512,14 +324,9 @@ func PolicyForwardingConfig(t *testing.T, dut *ondatra.DUTDevice, traffictype st
 		switch dut.Vendor() {
 		case ondatra.ARISTA: // Currently supports Arista devices for CLI deviations.
 			// Select and apply the appropriate CLI snippet based on 'traffictype'.
-			if traffictype == "v4" {
-				helpers.GnmiCLIConfig(t, dut, PolicyForwardingConfigv4Arista)
-			} else if traffictype == "v6" {
-				helpers.GnmiCLIConfig(t, dut, PolicyForwardingConfigv6Arista)
-			} else if traffictype == "dualstack" {
-				helpers.GnmiCLIConfig(t, dut, PolicyForwardingConfigDualStackArista)
-			} else if traffictype == "multicloudv4" {
-				helpers.GnmiCLIConfig(t, dut, PolicyForwardingConfigMulticloudAristav4)
+			switch traffictype {
+			case "v4", "v6", "dualstack", "multicloudv4":
+				helpers.GnmiCLIConfig(t, dut, clisnippets.Default().For(t, dut, "policy-forwarding/cloud/"+traffictype, nil))
 			}
 		default:
 			// Log a message if the vendor is not supported for this specific CLI deviation.
@@ -540,62 +347,22 @@ func NewPolicyForwardingMatchAndSetTTL(t *testing.T, dut *ondatra.DUTDevice, pf
 		switch dut.Vendor() {
 		case ondatra.ARISTA:
 			if params.RemovePolicy {
-				removeCmd := fmt.Sprintf(`
-				traffic-policies
-				  no traffic-policy %s
-				`, params.PolicyName)
+				removeCmd := clisnippets.Default().For(t, dut, "policy-forwarding/remove", map[string]any{"PolicyName": params.PolicyName})
 				helpers.GnmiCLIConfig(t, dut, removeCmd)
 				return
-			} else {
-				switch params.IPType {
-				case "ipv4":
-					policyForwardingConfigv4Vrf := fmt.Sprintf(`
-						traffic-policies
-						traffic-policy %[1]s
-							match rewritettlv4 ipv4
-							ttl %[2]d
-							!
-							actions
-								count
-								redirect next-hop group %[3]s ttl %[4]d
-							!
-							interface %[5]s
-							traffic-policy input %[1]s
-						!`,
-						params.PolicyName,
-						params.MatchTTL,
-						params.ActionNHGName,
-						params.ActionSetTTL,
-						params.InterfaceName,
-					)
-					helpers.GnmiCLIConfig(t, dut, policyForwardingConfigv4Vrf)
-
-				case "ipv6":
-					policyForwardingConfigv6Vrf := fmt.Sprintf(`
-						traffic-policies
-						no traffic-policy %[1]s
-						traffic-policy %[1]s
-							match rewritettlv6 ipv6
-							ttl %[2]d
-							!
-							actions
-								count
-								redirect next-hop group %[3]s ttl %[4]d
-							!
-							interface %[5]s
-							traffic-policy input %[1]s
-						!`,
-						params.PolicyName,
-						params.MatchTTL,
-						params.ActionNHGName,
-						params.ActionSetTTL,
-						params.InterfaceName,
-					)
-					helpers.GnmiCLIConfig(t, dut, policyForwardingConfigv6Vrf)
-
-				default:
-					t.Logf("Unsupported traffictype %s for TTL policy", params.IPType)
-				}
+			}
+			switch params.IPType {
+			case "ipv4", "ipv6":
+				cliConfig := clisnippets.Default().For(t, dut, "policy-forwarding/match-and-set-ttl/"+params.IPType, map[string]any{
+					"PolicyName":    params.PolicyName,
+					"MatchTTL":      params.MatchTTL,
+					"ActionNHGName": params.ActionNHGName,
+					"ActionSetTTL":  params.ActionSetTTL,
+					"InterfaceName": params.InterfaceName,
+				})
+				helpers.GnmiCLIConfig(t, dut, cliConfig)
+			default:
+				t.Logf("Unsupported traffictype %s for TTL policy", params.IPType)
 			}
 		default:
 			t.Logf("Unsupported vendor %s for native command support for deviation 'policy-forwarding config'", dut.Vendor())
@@ -858,7 +625,7 @@ func DecapGroupConfigGre(t *testing.T, dut *ondatra.DUTDevice, pf *oc.NetworkIns
 				t.Logf("Going into decap")
 				aristaGreDecapCLIConfig(t, dut, ocPFParams)
 			} else {
-				helpers.GnmiCLIConfig(t, dut, decapGroupGREArista)
+				helpers.GnmiCLIConfig(t, dut, clisnippets.Default().For(t, dut, "policy-forwarding/decap-group/gre-default", nil))
 			}
 		default:
 			t.Logf("Unsupported vendor %s for native command support for deviation 'decap-group config'", dut.Vendor())
@@ -876,7 +643,7 @@ func DecapGroupConfigGue(t *testing.T, dut *ondatra.DUTDevice, pf *oc.NetworkIns
 			if ocPFParams.Dynamic {
 				aristaGueDecapCLIConfig(t, dut, ocPFParams)
 			} else {
-				helpers.GnmiCLIConfig(t, dut, decapGroupGUEArista)
+				helpers.GnmiCLIConfig(t, dut, clisnippets.Default().For(t, dut, "policy-forwarding/decap-group/gue-default", nil))
 			}
 		default:
 			t.Logf("Unsupported vendor %s for native command support for deviation 'decap-group config'", dut.Vendor())
@@ -910,32 +677,23 @@ func aristaGueDecapCLIConfig(t *testing.T, dut *ondatra.DUTDevice, params OcPoli
 		decapProto = params.IPType
 	}
 
-	cliConfig := fmt.Sprintf(`
-		                    ip decap-group type udp destination port %v payload %s
-							ip decap-group %s
-							tunnel type UDP
-							tunnel decap-ip %s
-							`, params.GUEPort, decapProto, params.AppliedPolicyName, params.TunnelIP)
-	if params.InterfaceID != "" {
-		cliConfig += fmt.Sprintf("tunnel decap-interface %s", params.InterfaceID)
-	}
-	helpers.GnmiCLIConfig(t, dut, cliConfig)
+	helpers.GnmiCLIConfig(t, dut, clisnippets.Default().For(t, dut, "policy-forwarding/decap-group/gue", map[string]any{
+		"Port":        params.GUEPort,
+		"Protocol":    decapProto,
+		"Name":        params.AppliedPolicyName,
+		"TunnelIP":    params.TunnelIP,
+		"InterfaceID": params.InterfaceID,
+	}))
 }
 
 // aristaGreDecapCLIConfig configures GREDEcapConfig for Arista
 func aristaGreDecapCLIConfig(t *testing.T, dut *ondatra.DUTDevice, params OcPolicyForwardingParams) {
-	var cliConfig string
-	if params.HasMPLS {
-		cliConfig = fmt.Sprintf(decapGroupGREAristaMPLSTemplate, params.AppliedPolicyName, params.TunnelIP, params.InterfaceID)
-	} else {
-		cliConfig = fmt.Sprintf(`
-			ip decap-group %s
-			 tunnel type gre
-			 tunnel decap-ip %s
-			`, params.AppliedPolicyName, params.TunnelIP)
-	}
-	helpers.GnmiCLIConfig(t, dut, cliConfig)
-
+	helpers.GnmiCLIConfig(t, dut, clisnippets.Default().For(t, dut, "policy-forwarding/decap-group/gre", map[string]any{
+		"Name":        params.AppliedPolicyName,
+		"TunnelIP":    params.TunnelIP,
+		"InterfaceID": params.InterfaceID,
+		"MPLS":        params.HasMPLS,
+	}))
 }
 
 // QosClassificationConfig configures the interface qos classification.
@@ -0,0 +1,318 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cfgplugins
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/featureprofiles/internal/cfgplugins/clisnippets"
+	"github.com/openconfig/ondatra"
+)
+
+// TestPolicyForwardingAndQosSnippets checks that the policy-forwarding, MPLS
+// and QoS snippets render the CLI these plugins used to push inline, up to
+// indentation and "!" comment lines.
+func TestPolicyForwardingAndQosSnippets(t *testing.T) {
+	tests := []struct {
+		desc    string
+		feature string
+		params  map[string]any
+		want    string
+	}{
+		{
+			desc:    "cloud v4",
+			feature: "policy-forwarding/cloud/v4",
+			want: `Traffic-policies
+   traffic-policy tp_cloud_id_3_20
+      match bgpsetttlv4 ipv4
+         ttl 1
+         actions
+            redirect next-hop group 1V4_vlan_3_20 ttl 1
+            set traffic class 3
+      match icmpechov4 ipv4
+         destination prefix 169.254.0.11/32
+         protocol icmp type echo-reply code all
+      match ipv4-all-default ipv4
+         actions
+            redirect next-hop group 1V4_vlan_3_20
+            set traffic class 3
+      match ipv6-all-default ipv6
+   !
+`,
+		},
+		{
+			desc:    "cloud v6",
+			feature: "policy-forwarding/cloud/v6",
+			want: `Traffic-policies
+    traffic-policy tp_cloud_id_3_21
+    match bgpsetttlv6 ipv6
+       ttl 1
+       !
+       actions
+          count
+          redirect next-hop group 1V6_vlan_3_21 ttl 1
+          set traffic class 3
+    !
+    match icmpv6 ipv6
+       destination prefix 2600:2d00:0:1:8000:10:0:ca33/128
+       protocol icmpv6 type echo-reply neighbor-advertisement code all
+       !
+
+    !
+    match ipv4-all-default ipv4
+    !
+    match ipv6-all-default ipv6
+       actions
+          count
+          redirect next-hop group 1V6_vlan_3_21
+          set traffic class 3
+ !
+`,
+		},
+		{
+			desc:    "cloud dualstack",
+			feature: "policy-forwarding/cloud/dualstack",
+			want: `   Traffic-policies
+    traffic-policy tp_cloud_id_3_22
+    match bgpsetttlv6 ipv6
+       ttl 1
+       !
+       actions
+          count
+          redirect next-hop group 1V6_vlan_3_22 ttl 1
+          set traffic class 3
+    !
+    match icmpv6 ipv6
+       destination prefix 2600:2d00:0:1:7000:10:0:ca33/128
+       protocol icmpv6 type echo-reply neighbor-advertisement code all
+       !
+       actions
+          count
+    !
+    match bgpsetttlv4 ipv4
+       ttl 1
+       !
+       actions
+          count
+          redirect next-hop group 1V4_vlan_3_22 ttl 1
+          set traffic class 3
+    !
+    match icmpechov4 ipv4
+       destination prefix 169.254.0.27/32
+       protocol icmp type echo-reply code all
+       !
+       actions
+          count
+    !
+    match ipv4-all-default ipv4
+       actions
+          count
+          redirect next-hop group 1V4_vlan_3_22
+          set traffic class 3
+    !
+    match ipv6-all-default ipv6
+       actions
+          count
+          redirect next-hop group 1V6_vlan_3_22
+          set traffic class 3
+ !`,
+		},
+		{
+			desc:    "cloud multicloudv4",
+			feature: "policy-forwarding/cloud/multicloudv4",
+			want: ` Traffic-policies
+ counter interface per-interface ingress
+ !
+ traffic-policy tp_cloud_id_3_23
+		match icmpechov4 ipv4
+			 destination prefix 169.254.0.33/32
+			 protocol icmp type echo-reply code all
+			 !
+			 actions
+					count
+		!
+		match bgpsetttlv4 ipv4
+			 ttl 1
+			 !
+			 actions
+					count
+					redirect next-hop group 1V4_vlan_3_23 ttl 1
+					set traffic class 3
+		!
+		match ipv4-all-default ipv4
+			 actions
+					count
+					redirect next-hop group 1V4_vlan_3_23
+					set traffic class 3
+		!
+		match ipv6-all-default ipv6
+ !
+`,
+		},
+		{
+			desc:    "qos classification",
+			feature: "qos/classification",
+			want: ` qos map dscp 0 1 2 3 4 5 6 7 to traffic-class 0
+ qos map dscp 8 9 10 11 12 13 14 15 to traffic-class 1
+ qos map dscp 40 41 42 43 44 45 46 47 to traffic-class 4
+ qos map dscp 48 49 50 51 52 53 54 55 to traffic-class 7
+!
+ policy-map type quality-of-service af3
+   class class-default
+      set traffic-class 3
+!
+`,
+		},
+		{
+			desc:    "mpls label range",
+			feature: "mpls/label-range",
+			want: `mpls label range bgp-sr 16 0
+mpls label range dynamic 16 0
+mpls label range isis-sr 16 0
+mpls label range l2evpn 16 0
+mpls label range l2evpn ethernet-segment 16 0
+mpls label range ospf-sr 16 0
+mpls label range srlb 16 0
+mpls label range static 16 1048560
+!
+`,
+		},
+		{
+			desc:    "mpls static lsp",
+			feature: "mpls/static-lsp",
+			want: `mpls static top-label 99991 169.254.0.12 pop payload-type ipv4 access-list bypass
+mpls static top-label 99992 2600:2d00:0:1:8000:10:0:ca32 pop payload-type ipv6 access-list bypass
+mpls static top-label 99993 169.254.0.26 pop payload-type ipv4 access-list bypass
+mpls static top-label 99994 2600:2d00:0:1:7000:10:0:ca32 pop payload-type ipv6 access-list bypass
+`,
+		},
+		{
+			desc:    "decap group gre default",
+			feature: "policy-forwarding/decap-group/gre-default",
+			want: `ip decap-group gre-decap
+  tunnel type gre
+  tunnel decap-ip 11.0.0.0/8
+  tunnel overlay mpls qos map mpls-traffic-class to traffic-class
+!`,
+		},
+		{
+			desc:    "decap group gue default",
+			feature: "policy-forwarding/decap-group/gue-default",
+			want: `!
+ip decap-group type udp destination port 6635 payload mpls
+!
+ip decap-group gre-decap
+  tunnel type udp
+  tunnel decap-ip 11.0.0.0/8
+  tunnel overlay mpls qos map mpls-traffic-class to traffic-class
+!`,
+		},
+		{
+			desc:    "decap group gre mpls",
+			feature: "policy-forwarding/decap-group/gre",
+			params:  map[string]any{"Name": "decap-mpls", "TunnelIP": "192.0.2.0/24", "InterfaceID": "Ethernet1", "MPLS": true},
+			want: `
+ip decap-group decap-mpls
+  tunnel type gre
+  tunnel decap-ip 192.0.2.0/24
+  tunnel decap-interface Ethernet1
+  tunnel overlay mpls qos map mpls-traffic-class to traffic-class
+!`,
+		},
+		{
+			desc:    "decap group gre",
+			feature: "policy-forwarding/decap-group/gre",
+			params:  map[string]any{"Name": "decap", "TunnelIP": "192.0.2.0/24", "InterfaceID": "Ethernet1", "MPLS": false},
+			want: `
+			ip decap-group decap
+			 tunnel type gre
+			 tunnel decap-ip 192.0.2.0/24
+`,
+		},
+		{
+			desc:    "decap group gue",
+			feature: "policy-forwarding/decap-group/gue",
+			params:  map[string]any{"Port": 6080, "Protocol": "ipv4", "Name": "decap", "TunnelIP": "192.0.2.0/24", "InterfaceID": ""},
+			want: `
+		                    ip decap-group type udp destination port 6080 payload ipv4
+							ip decap-group decap
+							tunnel type UDP
+							tunnel decap-ip 192.0.2.0/24
+`,
+		},
+		{
+			desc:    "decap group gue interface",
+			feature: "policy-forwarding/decap-group/gue",
+			params:  map[string]any{"Port": 6080, "Protocol": "ipv4", "Name": "decap", "TunnelIP": "192.0.2.0/24", "InterfaceID": "Ethernet1"},
+			want: `
+		                    ip decap-group type udp destination port 6080 payload ipv4
+							ip decap-group decap
+							tunnel type UDP
+							tunnel decap-ip 192.0.2.0/24
+							tunnel decap-interface Ethernet1`,
+		},
+		{
+			desc:    "interface traffic policy",
+			feature: "policy-forwarding/interface",
+			params:  map[string]any{"Interface": "Port-Channel1.20", "PolicyName": "tp_cloud_id_3_20"},
+			want: `
+interface Port-Channel1.20
+traffic-policy input tp_cloud_id_3_20
+!`,
+		},
+		{
+			desc:    "qos interface service policy",
+			feature: "qos/interface-service-policy",
+			params:  map[string]any{"Interface": "Ethernet1", "PolicyName": "scheduler"},
+			want: `
+        interface Ethernet1
+        service-policy type qos input scheduler
+        !
+`,
+		},
+		{
+			desc:    "qos classification service policy",
+			feature: "qos/interface-service-policy",
+			params:  map[string]any{"Interface": "Port-Channel1.20", "PolicyName": "af3"},
+			want: `interface Port-Channel1.20
+ service-policy type qos input af3
+`,
+		},
+		{
+			desc:    "one rate two color policer",
+			feature: "qos/one-rate-two-color-policer",
+			params:  map[string]any{"SchedulerName": "scheduler", "ClassName": "class", "QueueID": 3, "CirValue": uint64(1000000000), "BurstSize": uint32(100000)},
+			want: `
+			policy-map type quality-of-service scheduler
+			class class
+			set traffic-class 3
+			police cir 1000000000 bps bc 100000 bytes
+			!
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := clisnippets.Default().Render(t.Name(), tt.feature, ondatra.ARISTA.String(), "", "", tt.params)
+			if err != nil {
+				t.Fatalf("Render(%q) failed: %v", tt.feature, err)
+			}
+			if diff := cmp.Diff(normalizeLines(cliLines(tt.want)), normalizeLines(cliLines(got))); diff != "" {
+				t.Errorf("Render(%q) diff (-want +got):\n%s", tt.feature, diff)
+			}
+		})
+	}
+}
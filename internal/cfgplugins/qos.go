@@ -20,6 +20,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/openconfig/featureprofiles/internal/cfgplugins/clisnippets"
 	"github.com/openconfig/featureprofiles/internal/deviations"
 	"github.com/openconfig/featureprofiles/internal/helpers"
 	"github.com/openconfig/featureprofiles/internal/qoscfg"
@@ -288,7 +289,10 @@ func CreateQueues(t *testing.T, dut *ondatra.DUTDevice, qos *oc.Qos, queues []st
 	}
 }
 
-func configureTwoRateThreeColorSchedulerFromOC(batch *gnmi.SetBatch, params *SchedulerParams) {
+// TwoRateThreeColorSchedulerPolicy returns the OpenConfig scheduler policy
+// of a two-rate three-color policer that forwards exceeding traffic and
+// drops violating traffic.
+func TwoRateThreeColorSchedulerPolicy(params *SchedulerParams) *oc.Qos {
 	qos := &oc.Qos{}
 	sp := qos.GetOrCreateSchedulerPolicy(params.SchedulerName)
 	sp.Name = ygot.String(params.SchedulerName)
@@ -303,22 +307,27 @@ func configureTwoRateThreeColorSchedulerFromOC(batch *gnmi.SetBatch, params *Sch
 	trtc.Pir = ygot.Uint64(params.PirValue)
 	trtc.Bc = ygot.Uint32(params.BurstSize)
 	trtc.Be = ygot.Uint32(params.BurstSize)
-	trtc.GetOrCreateExceedAction().Drop = ygot.Bool(true)
+	trtc.GetOrCreateExceedAction().Drop = ygot.Bool(false)
 	trtc.GetOrCreateViolateAction().Drop = ygot.Bool(true)
+	return qos
+}
+
+func configureTwoRateThreeColorSchedulerFromOC(batch *gnmi.SetBatch, params *SchedulerParams) {
 	qosPath := gnmi.OC().Qos().Config()
-	gnmi.BatchUpdate(batch, qosPath, qos)
+	gnmi.BatchUpdate(batch, qosPath, TwoRateThreeColorSchedulerPolicy(params))
 }
 
 func configureTwoRateThreeColorSchedulerFromCLI(t *testing.T, dut *ondatra.DUTDevice, params *SchedulerParams) {
 	switch dut.Vendor() {
 	case ondatra.ARISTA:
-		cliConfig := fmt.Sprintf(`
-        policy-map type quality-of-service %s
-        class %s
-        set traffic-class %d 
-        police rate %d bps burst-size %d bytes rate %d bps burst-size %d bytes
-        !
-        `, params.SchedulerName, params.ClassName, params.QueueID, params.CirValue, params.BurstSize, params.PirValue, params.BurstSize)
+		cliConfig := clisnippets.Default().For(t, dut, "qos/two-rate-three-color-policer", map[string]any{
+			"SchedulerName": params.SchedulerName,
+			"ClassName":     params.ClassName,
+			"QueueID":       params.QueueID,
+			"CirValue":      params.CirValue,
+			"PirValue":      params.PirValue,
+			"BurstSize":     params.BurstSize,
+		})
 		helpers.GnmiCLIConfig(t, dut, cliConfig)
 	default:
 		t.Errorf("Unsupported CLI command for dut %v %s", dut.Vendor(), dut.Name())
@@ -358,11 +367,10 @@ func applyQosPolicyOnInterfaceFromOC(batch *gnmi.SetBatch, params *SchedulerPara
 func applyQosPolicyOnInterfaceFromCLI(t *testing.T, dut *ondatra.DUTDevice, params *SchedulerParams) {
 	switch dut.Vendor() {
 	case ondatra.ARISTA:
-		cliConfig := fmt.Sprintf(`
-        interface %s
-        service-policy type qos input %s
-        !
-        `, params.InterfaceName, params.SchedulerName)
+		cliConfig := clisnippets.Default().For(t, dut, "qos/interface-service-policy", map[string]any{
+			"Interface":  params.InterfaceName,
+			"PolicyName": params.SchedulerName,
+		})
 		helpers.GnmiCLIConfig(t, dut, cliConfig)
 	default:
 		t.Errorf("Unsupported CLI command for dut %v %s", dut.Vendor(), dut.Name())
@@ -525,13 +533,13 @@ func configureOneRateTwoColorSchedulerFromCLI(t *testing.T, dut *ondatra.DUTDevi
 	if deviations.QosTwoRateThreeColorPolicerOCUnsupported(dut) {
 		switch dut.Vendor() {
 		case ondatra.ARISTA:
-			cliConfig := fmt.Sprintf(`
-			policy-map type quality-of-service %s
-			class %s
-			set traffic-class %d 
-			police cir %d bps bc %d bytes
-			!
-			`, params.SchedulerName, params.ClassName, params.QueueID, params.CirValue, params.BurstSize)
+			cliConfig := clisnippets.Default().For(t, dut, "qos/one-rate-two-color-policer", map[string]any{
+				"SchedulerName": params.SchedulerName,
+				"ClassName":     params.ClassName,
+				"QueueID":       params.QueueID,
+				"CirValue":      params.CirValue,
+				"BurstSize":     params.BurstSize,
+			})
 			helpers.GnmiCLIConfig(t, dut, cliConfig)
 		default:
 			t.Errorf("Unsupported CLI command for dut %v %s", dut.Vendor(), dut.Name())
@@ -0,0 +1,397 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/openconfig/ygnmi/ygnmi"
+	"github.com/openconfig/ygot/ygot"
+)
+
+// pollInterval is how often validators that cannot watch a single path
+// re-evaluate their condition while awaiting.
+const pollInterval = time.Second
+
+// number is the set of types that numeric validators accept.
+type number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 |
+		~float32 | ~float64
+}
+
+// poll calls check until it returns nil or ctx is done. check is always
+// called at least once.
+func poll(ctx context.Context, check func() error) error {
+	err := check()
+	for err != nil {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w (%v)", err, ctx.Err())
+		case <-time.After(pollInterval):
+		}
+		err = check()
+	}
+	return nil
+}
+
+// awaitFor and awaitUntil implement AwaitFor and AwaitUntil on top of Check
+// and Await for the validators in this file.
+func awaitFor(vd Validator, timeout time.Duration, client *ygnmi.Client) error {
+	if timeout <= 0 {
+		return vd.Check(client)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return vd.Await(ctx, client)
+}
+
+func awaitUntil(vd Validator, deadline time.Time, client *ygnmi.Client) error {
+	if deadline.Before(time.Now()) {
+		return vd.Check(client)
+	}
+	ctx, cancel := context.WithDeadline(context.Background(), deadline)
+	defer cancel()
+	return vd.Await(ctx, client)
+}
+
+// valuePath formats the path of a value returned by a wildcard query.
+func valuePath[T any](v *ygnmi.Value[T]) string {
+	if v == nil || v.Path == nil {
+		return "<unknown path>"
+	}
+	s, err := ygot.PathToString(v.Path)
+	if err != nil {
+		return fmt.Sprintf("<Unprintable path: %v>", err)
+	}
+	return s
+}
+
+// wildcardValidation is the Validator implementation for wildcard queries.
+type wildcardValidation[T any] struct {
+	query        ygnmi.WildcardQuery[T]
+	validationFn func([]*ygnmi.Value[T]) error
+}
+
+var _ Validator = (*wildcardValidation[any])(nil)
+
+// Path returns a string representation of the path being validated.
+func (vd *wildcardValidation[T]) Path() string {
+	return FormatPath(vd.query.PathStruct())
+}
+
+// RelPath returns a string representation of the path being validated,
+// relative to some base.
+func (vd *wildcardValidation[T]) RelPath(base ygnmi.PathStruct) string {
+	return FormatRelativePath(base, vd.query.PathStruct())
+}
+
+// Check fetches every value matching the query and validates them together.
+func (vd *wildcardValidation[T]) Check(client *ygnmi.Client) error {
+	vals, err := ygnmi.LookupAll(context.Background(), client, vd.query)
+	if err != nil {
+		return fmt.Errorf("%s: %w", vd.Path(), err)
+	}
+	if err := vd.validationFn(vals); err != nil {
+		return fmt.Errorf("%s: %w", vd.Path(), err)
+	}
+	return nil
+}
+
+// Await re-fetches all values matching the query until they pass validation
+// or ctx is done. A wildcard subscription delivers values one at a time, so
+// the whole set is re-fetched rather than watched, to avoid passing on a
+// partial set.
+func (vd *wildcardValidation[T]) Await(ctx context.Context, client *ygnmi.Client) error {
+	return poll(ctx, func() error { return vd.Check(client) })
+}
+
+// AwaitFor calls Await with a context with deadline now + timeout. If timeout
+// is <= 0, this is equivalent to Check().
+func (vd *wildcardValidation[T]) AwaitFor(timeout time.Duration, client *ygnmi.Client) error {
+	return awaitFor(vd, timeout, client)
+}
+
+// AwaitUntil calls Await with a context with the given deadline. If deadline
+// is in the past, this is equivalent to Check().
+func (vd *wildcardValidation[T]) AwaitUntil(deadline time.Time, client *ygnmi.Client) error {
+	return awaitUntil(vd, deadline, client)
+}
+
+// ValidateAll expects validationFn to return no error on the set of values
+// matching a wildcard query.
+func ValidateAll[T any, QT ygnmi.WildcardQuery[T]](query QT, validationFn func([]*ygnmi.Value[T]) error) Validator {
+	return &wildcardValidation[T]{query, validationFn}
+}
+
+// AllPredicate expects the wildcard query to match at least one value and
+// the predicate to return true on every one of them. Failing values are
+// reported with their paths, e.g.
+//
+//	"/interfaces/interface[name=*]/state/oper-status: got DOWN at
+//	/interfaces/interface[name=Ethernet2]/state/oper-status, want UP".
+func AllPredicate[T any, QT ygnmi.WildcardQuery[T]](query QT, wantMsg string, predicate func(T) bool) Validator {
+	return ValidateAll(query, func(vals []*ygnmi.Value[T]) error {
+		var bad []string
+		present := 0
+		for _, v := range vals {
+			got, ok := v.Val()
+			if !ok {
+				continue
+			}
+			present++
+			if !predicate(got) {
+				bad = append(bad, fmt.Sprintf("%s at %s", FormatValue(v), valuePath(v)))
+			}
+		}
+		if present == 0 {
+			return fmt.Errorf("got no values, %s", wantMsg)
+		}
+		if len(bad) > 0 {
+			return fmt.Errorf("got %s, %s", strings.Join(bad, "; "), wantMsg)
+		}
+		return nil
+	})
+}
+
+// AllEqual expects the wildcard query to match at least one value and every
+// value to be want.
+func AllEqual[T any, QT ygnmi.WildcardQuery[T]](query QT, want T) Validator {
+	return AllPredicate(query, fmt.Sprintf("want %#v", want), func(got T) bool {
+		return reflect.DeepEqual(got, want)
+	})
+}
+
+// Count expects the wildcard query to match exactly want present values.
+func Count[T any, QT ygnmi.WildcardQuery[T]](query QT, want int) Validator {
+	return ValidateAll(query, func(vals []*ygnmi.Value[T]) error {
+		got := 0
+		for _, v := range vals {
+			if v.IsPresent() {
+				got++
+			}
+		}
+		if got != want {
+			return fmt.Errorf("got %d values, want %d", got, want)
+		}
+		return nil
+	})
+}
+
+// Within expects the query's value to be within tolerance of want, which is
+// useful for counters that are only approximately predictable.
+func Within[T number, QT ygnmi.SingletonQuery[T]](query QT, want, tolerance T) Validator {
+	wantMsg := fmt.Sprintf("want %v ± %v", want, tolerance)
+	return Predicate(query, wantMsg, func(got T) bool {
+		return math.Abs(float64(got)-float64(want)) <= float64(tolerance)
+	})
+}
+
+// WithinPercent expects the query's value to be within pct percent of want.
+func WithinPercent[T number, QT ygnmi.SingletonQuery[T]](query QT, want T, pct float64) Validator {
+	wantMsg := fmt.Sprintf("want %v ± %v%%", want, pct)
+	return Predicate(query, wantMsg, func(got T) bool {
+		return math.Abs(float64(got)-float64(want)) <= math.Abs(float64(want))*pct/100
+	})
+}
+
+// combined is the Validator implementation for All, Any and Not.
+type combined struct {
+	op  string
+	vds []Validator
+	// eval combines the errors returned by each validator into one.
+	eval func(errs []error) error
+}
+
+var _ Validator = (*combined)(nil)
+
+func (c *combined) join(path func(Validator) string) string {
+	var paths []string
+	for _, vd := range c.vds {
+		paths = append(paths, path(vd))
+	}
+	if c.op == "not" {
+		return "not(" + strings.Join(paths, "") + ")"
+	}
+	return c.op + "(" + strings.Join(paths, ", ") + ")"
+}
+
+// Path returns the paths of the combined validators.
+func (c *combined) Path() string {
+	return c.join(Validator.Path)
+}
+
+// RelPath returns the paths of the combined validators relative to base.
+func (c *combined) RelPath(base ygnmi.PathStruct) string {
+	return c.join(func(vd Validator) string { return vd.RelPath(base) })
+}
+
+// Check runs Check on every combined validator and combines the results.
+func (c *combined) Check(client *ygnmi.Client) error {
+	errs := make([]error, len(c.vds))
+	for i, vd := range c.vds {
+		errs[i] = vd.Check(client)
+	}
+	return c.eval(errs)
+}
+
+// Await waits for the combination to pass. For All, each validator is awaited
+// in turn with the same ctx, so the total wait is bounded by ctx; Any and Not
+// re-check on a poll interval.
+func (c *combined) Await(ctx context.Context, client *ygnmi.Client) error {
+	if c.op != "all" {
+		return poll(ctx, func() error { return c.Check(client) })
+	}
+	errs := make([]error, len(c.vds))
+	for i, vd := range c.vds {
+		errs[i] = vd.Await(ctx, client)
+	}
+	return c.eval(errs)
+}
+
+// AwaitFor calls Await with a context with deadline now + timeout. If timeout
+// is <= 0, this is equivalent to Check().
+func (c *combined) AwaitFor(timeout time.Duration, client *ygnmi.Client) error {
+	return awaitFor(c, timeout, client)
+}
+
+// AwaitUntil calls Await with a context with the given deadline. If deadline
+// is in the past, this is equivalent to Check().
+func (c *combined) AwaitUntil(deadline time.Time, client *ygnmi.Client) error {
+	return awaitUntil(c, deadline, client)
+}
+
+// All expects every one of vds to pass. The error lists every failure.
+func All(vds ...Validator) Validator {
+	return &combined{op: "all", vds: vds, eval: func(errs []error) error { return errors.Join(errs...) }}
+}
+
+// Any expects at least one of vds to pass.
+func Any(vds ...Validator) Validator {
+	return &combined{op: "any", vds: vds, eval: func(errs []error) error {
+		for _, err := range errs {
+			if err == nil {
+				return nil
+			}
+		}
+		return fmt.Errorf("none of %d validators passed: %w", len(errs), errors.Join(errs...))
+	}}
+}
+
+// Not expects vd to fail. Note that vd also fails if its value cannot be
+// fetched at all.
+func Not(vd Validator) Validator {
+	return &combined{op: "not", vds: []Validator{vd}, eval: func(errs []error) error {
+		if errs[0] == nil {
+			return fmt.Errorf("%s: validation passed, want failure", vd.Path())
+		}
+		return nil
+	}}
+}
+
+// liveValidator is implemented by validators that must sample the device
+// over time and so cannot be evaluated against a Batch snapshot.
+type liveValidator interface {
+	live()
+}
+
+// monotonic is the Validator implementation for MonotonicIncrease.
+type monotonic[T number] struct {
+	query    ygnmi.SingletonQuery[T]
+	samples  int
+	interval time.Duration
+}
+
+var _ Validator = (*monotonic[uint64])(nil)
+
+func (m *monotonic[T]) live() {}
+
+// Path returns a string representation of the path being validated.
+func (m *monotonic[T]) Path() string {
+	return FormatPath(m.query.PathStruct())
+}
+
+// RelPath returns a string representation of the path being validated,
+// relative to some base.
+func (m *monotonic[T]) RelPath(base ygnmi.PathStruct) string {
+	return FormatRelativePath(base, m.query.PathStruct())
+}
+
+// sample fetches m.samples values m.interval apart and checks that each is
+// greater than the one before it.
+func (m *monotonic[T]) sample(ctx context.Context, client *ygnmi.Client) error {
+	var prev T
+	for i := 0; i < m.samples; i++ {
+		if i > 0 {
+			select {
+			case <-ctx.Done():
+				return fmt.Errorf("%s: %w after %d samples", m.Path(), ctx.Err(), i)
+			case <-time.After(m.interval):
+			}
+		}
+		v, err := ygnmi.Lookup(ctx, client, m.query)
+		if err != nil {
+			return fmt.Errorf("%s: %w", m.Path(), err)
+		}
+		got, ok := v.Val()
+		if !ok {
+			return fmt.Errorf("%s: got no value at sample %d, want a value increasing over %d samples", m.Path(), i+1, m.samples)
+		}
+		if i > 0 && got <= prev {
+			return fmt.Errorf("%s: got %v after %v at sample %d, want a value increasing over %d samples %v apart", m.Path(), got, prev, i+1, m.samples, m.interval)
+		}
+		prev = got
+	}
+	return nil
+}
+
+// Check samples the query and returns an error unless every sample is larger
+// than the previous one.
+func (m *monotonic[T]) Check(client *ygnmi.Client) error {
+	return m.sample(context.Background(), client)
+}
+
+// Await repeats the sampling until it passes or ctx is done.
+func (m *monotonic[T]) Await(ctx context.Context, client *ygnmi.Client) error {
+	return poll(ctx, func() error { return m.sample(ctx, client) })
+}
+
+// AwaitFor calls Await with a context with deadline now + timeout. If timeout
+// is <= 0, this is equivalent to Check().
+func (m *monotonic[T]) AwaitFor(timeout time.Duration, client *ygnmi.Client) error {
+	return awaitFor(m, timeout, client)
+}
+
+// AwaitUntil calls Await with a context with the given deadline. If deadline
+// is in the past, this is equivalent to Check().
+func (m *monotonic[T]) AwaitUntil(deadline time.Time, client *ygnmi.Client) error {
+	return awaitUntil(m, deadline, client)
+}
+
+// MonotonicIncrease expects the query, typically a counter, to strictly
+// increase across samples values fetched interval apart. Check blocks for
+// (samples-1)*interval.
+func MonotonicIncrease[T number, QT ygnmi.SingletonQuery[T]](query QT, samples int, interval time.Duration) Validator {
+	if samples < 2 {
+		samples = 2
+	}
+	return &monotonic[T]{query: query, samples: samples, interval: interval}
+}
@@ -0,0 +1,329 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package check_test
+
+import (
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/openconfig/featureprofiles/internal/check"
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+	"github.com/openconfig/ygnmi/exampleoc/exampleocpath"
+	"github.com/openconfig/ygnmi/ygnmi"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// valueServer is a gNMI server that answers every subscription with the
+// single-key values returned by its values function, which is called once per
+// subscription.
+type valueServer struct {
+	gpb.UnimplementedGNMIServer
+
+	mu     sync.Mutex
+	values func() map[string]int64
+}
+
+func (s *valueServer) set(values func() map[string]int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.values = values
+}
+
+func (s *valueServer) Subscribe(stream gpb.GNMI_SubscribeServer) error {
+	if _, err := stream.Recv(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	values := s.values()
+	s.mu.Unlock()
+	for key, v := range values {
+		p, _, err := ygnmi.ResolvePath(exampleocpath.Root().Model().SingleKey(key).Value().State().PathStruct())
+		if err != nil {
+			return err
+		}
+		if err := stream.Send(&gpb.SubscribeResponse{
+			Response: &gpb.SubscribeResponse_Update{
+				Update: &gpb.Notification{
+					Timestamp: time.Now().UnixNano(),
+					Update: []*gpb.Update{{
+						Path: p,
+						Val:  &gpb.TypedValue{Value: &gpb.TypedValue_IntVal{IntVal: v}},
+					}},
+				},
+			},
+		}); err != nil {
+			return err
+		}
+	}
+	return stream.Send(&gpb.SubscribeResponse{
+		Response: &gpb.SubscribeResponse_SyncResponse{SyncResponse: true},
+	})
+}
+
+func mustNewValueServer(t *testing.T) (*valueServer, *ygnmi.Client) {
+	t.Helper()
+	lis, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+	s := &valueServer{values: func() map[string]int64 { return nil }}
+	srv := grpc.NewServer()
+	gpb.RegisterGNMIServer(srv, s)
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+	conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("NewClient(%s): %v", lis.Addr(), err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	c, err := ygnmi.NewClient(gpb.NewGNMIClient(conn))
+	if err != nil {
+		t.Fatalf("ygnmi.NewClient: %v", err)
+	}
+	return s, c
+}
+
+func fixed(values map[string]int64) func() map[string]int64 {
+	return func() map[string]int64 { return values }
+}
+
+func TestWildcard(t *testing.T) {
+	s, c := mustNewValueServer(t)
+	query := exampleocpath.Root().Model().SingleKeyAny().Value().State()
+	testCases := []struct {
+		desc        string
+		validator   check.Validator
+		values      map[string]int64
+		errIncludes []string
+	}{{
+		desc:      "AllEqual/Correct",
+		validator: check.AllEqual(query, 1),
+		values:    map[string]int64{"a": 1, "b": 1},
+	}, {
+		desc:        "AllEqual/Incorrect",
+		validator:   check.AllEqual(query, 1),
+		values:      map[string]int64{"a": 1, "b": 2},
+		errIncludes: []string{"/model/a/single-key[key=*]/state/value", "single-key[key=b]", "want 1"},
+	}, {
+		desc:        "AllEqual/Missing",
+		validator:   check.AllEqual(query, 1),
+		errIncludes: []string{"got no values", "want 1"},
+	}, {
+		desc:      "AllPredicate/Correct",
+		validator: check.AllPredicate(query, "want positive", func(v int64) bool { return v > 0 }),
+		values:    map[string]int64{"a": 1, "b": 2},
+	}, {
+		desc:        "AllPredicate/Incorrect",
+		validator:   check.AllPredicate(query, "want positive", func(v int64) bool { return v > 0 }),
+		values:      map[string]int64{"a": -1, "b": 2, "c": 0},
+		errIncludes: []string{"single-key[key=a]", "single-key[key=c]", "want positive"},
+	}, {
+		desc:      "Count/Correct",
+		validator: check.Count(query, 3),
+		values:    map[string]int64{"a": 1, "b": 2, "c": 3},
+	}, {
+		desc:        "Count/Incorrect",
+		validator:   check.Count(query, 3),
+		values:      map[string]int64{"a": 1, "b": 2},
+		errIncludes: []string{"got 2 values, want 3"},
+	}, {
+		desc:      "Count/None",
+		validator: check.Count(query, 0),
+	}}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			s.set(fixed(tc.values))
+			gotErr := tc.validator.Check(c)
+			if len(tc.errIncludes) > 0 {
+				if err := errContainsAll(gotErr, tc.errIncludes); err != nil {
+					t.Error(err)
+				}
+			} else if gotErr != nil {
+				t.Errorf("Unexpected error: %v", gotErr)
+			}
+		})
+	}
+}
+
+func TestWildcardPath(t *testing.T) {
+	vd := check.AllEqual(exampleocpath.Root().Model().SingleKeyAny().Value().State(), 1)
+	if got, want := vd.Path(), "/model/a/single-key[key=*]/state/value"; got != want {
+		t.Errorf("vd.Path(): got %#v, want %#v", got, want)
+	}
+}
+
+func TestTolerance(t *testing.T) {
+	s, c := mustNewValueServer(t)
+	query := exampleocpath.Root().Model().SingleKey("a").Value().State()
+	path := "/model/a/single-key[key=a]/state/value"
+	testCases := []struct {
+		desc        string
+		validator   check.Validator
+		value       int64
+		errIncludes []string
+	}{{
+		desc:      "Within/Exact",
+		validator: check.Within(query, 100, 5),
+		value:     100,
+	}, {
+		desc:      "Within/UpperBound",
+		validator: check.Within(query, 100, 5),
+		value:     105,
+	}, {
+		desc:      "Within/LowerBound",
+		validator: check.Within(query, 100, 5),
+		value:     95,
+	}, {
+		desc:        "Within/AboveUpperBound",
+		validator:   check.Within(query, 100, 5),
+		value:       106,
+		errIncludes: []string{path, "106", "want 100 ± 5"},
+	}, {
+		desc:        "Within/BelowLowerBound",
+		validator:   check.Within(query, 100, 5),
+		value:       94,
+		errIncludes: []string{path, "94", "want 100 ± 5"},
+	}, {
+		desc:      "Within/ZeroTolerance",
+		validator: check.Within(query, 100, 0),
+		value:     100,
+	}, {
+		desc:        "Within/ZeroToleranceIncorrect",
+		validator:   check.Within(query, 100, 0),
+		value:       101,
+		errIncludes: []string{path, "want 100 ± 0"},
+	}, {
+		desc:      "WithinPercent/UpperBound",
+		validator: check.WithinPercent(query, 200, 10),
+		value:     220,
+	}, {
+		desc:      "WithinPercent/LowerBound",
+		validator: check.WithinPercent(query, 200, 10),
+		value:     180,
+	}, {
+		desc:        "WithinPercent/AboveUpperBound",
+		validator:   check.WithinPercent(query, 200, 10),
+		value:       221,
+		errIncludes: []string{path, "221", "want 200 ± 10%"},
+	}, {
+		desc:        "WithinPercent/BelowLowerBound",
+		validator:   check.WithinPercent(query, 200, 10),
+		value:       179,
+		errIncludes: []string{path, "179", "want 200 ± 10%"},
+	}, {
+		desc:      "WithinPercent/NegativeWant",
+		validator: check.WithinPercent(query, -200, 10),
+		value:     -220,
+	}, {
+		desc:        "WithinPercent/ZeroWant",
+		validator:   check.WithinPercent(query, 0, 10),
+		value:       1,
+		errIncludes: []string{path, "want 0 ± 10%"},
+	}}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			s.set(fixed(map[string]int64{"a": tc.value}))
+			gotErr := tc.validator.Check(c)
+			if len(tc.errIncludes) > 0 {
+				if err := errContainsAll(gotErr, tc.errIncludes); err != nil {
+					t.Error(err)
+				}
+			} else if gotErr != nil {
+				t.Errorf("Unexpected error: %v", gotErr)
+			}
+		})
+	}
+	t.Run("Within/Missing", func(t *testing.T) {
+		s.set(fixed(nil))
+		if err := errContainsAll(check.Within(query, 100, 5).Check(c), []string{path, "no value"}); err != nil {
+			t.Error(err)
+		}
+	})
+}
+
+// sequence returns a values function that returns successive values of seq
+// for key "a", repeating the last one once seq is exhausted.
+func sequence(seq ...int64) func() map[string]int64 {
+	i := 0
+	return func() map[string]int64 {
+		v := seq[min(i, len(seq)-1)]
+		i++
+		return map[string]int64{"a": v}
+	}
+}
+
+func TestMonotonicIncrease(t *testing.T) {
+	s, c := mustNewValueServer(t)
+	query := exampleocpath.Root().Model().SingleKey("a").Value().State()
+	path := "/model/a/single-key[key=a]/state/value"
+	testCases := []struct {
+		desc        string
+		samples     int
+		values      func() map[string]int64
+		errIncludes []string
+	}{{
+		desc:    "Increasing",
+		samples: 3,
+		values:  sequence(1, 2, 3),
+	}, {
+		desc:        "Flat",
+		samples:     3,
+		values:      sequence(1, 2, 2),
+		errIncludes: []string{path, "got 2 after 2 at sample 3"},
+	}, {
+		desc:        "Decreasing",
+		samples:     2,
+		values:      sequence(5, 4),
+		errIncludes: []string{path, "got 4 after 5 at sample 2"},
+	}, {
+		desc:        "TooFewSamples",
+		samples:     1,
+		values:      sequence(1),
+		errIncludes: []string{path, "got 1 after 1 at sample 2", "2 samples"},
+	}, {
+		desc:        "Missing",
+		samples:     2,
+		values:      fixed(nil),
+		errIncludes: []string{path, "no value at sample 1"},
+	}}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			s.set(tc.values)
+			gotErr := check.MonotonicIncrease(query, tc.samples, 10*time.Millisecond).Check(c)
+			if len(tc.errIncludes) > 0 {
+				if err := errContainsAll(gotErr, tc.errIncludes); err != nil {
+					t.Error(err)
+				}
+			} else if gotErr != nil {
+				t.Errorf("Unexpected error: %v", gotErr)
+			}
+		})
+	}
+	t.Run("Await", func(t *testing.T) {
+		s.set(sequence(1, 1, 2, 3))
+		if err := check.MonotonicIncrease(query, 2, 10*time.Millisecond).AwaitFor(5*time.Second, c); err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	})
+	t.Run("Await/Deadline", func(t *testing.T) {
+		s.set(fixed(map[string]int64{"a": 1}))
+		err := check.MonotonicIncrease(query, 2, 10*time.Millisecond).AwaitFor(500*time.Millisecond, c)
+		if err := errContainsAll(err, []string{path, "deadline"}); err != nil {
+			t.Error(err)
+		}
+	})
+}
@@ -0,0 +1,415 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+	"github.com/openconfig/ygnmi/ygnmi"
+	"github.com/openconfig/ygot/ygot"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// Batch evaluates many validators against one gNMI snapshot instead of
+// issuing one subscription per validator.
+//
+// A Batch first runs every validator against a recording client to learn
+// which paths it subscribes to, then issues a single ONCE subscription for
+// the union of those paths, and finally runs every validator against the
+// resulting snapshot. Validators that sample over time, such as
+// MonotonicIncrease, are run directly against the device.
+type Batch struct {
+	vds []Validator
+}
+
+// NewBatch returns a Batch of the given validators.
+func NewBatch(vds ...Validator) *Batch {
+	return &Batch{vds: vds}
+}
+
+// Add adds validators to the batch.
+func (b *Batch) Add(vds ...Validator) {
+	b.vds = append(b.vds, vds...)
+}
+
+// Result is the outcome of one validator in a Batch.
+type Result struct {
+	Path string
+	Err  error
+}
+
+// Report is the consolidated outcome of a Batch.
+type Report struct {
+	// Results has one entry per validator, in the order they were added.
+	Results []Result
+	// Paths is the number of paths in the snapshot subscription.
+	Paths int
+	// Notifications is the number of notifications in the snapshot.
+	Notifications int
+	// Attempts is the number of snapshots taken.
+	Attempts int
+}
+
+// Failed returns the results of validators that did not pass.
+func (r *Report) Failed() []Result {
+	var failed []Result
+	for _, res := range r.Results {
+		if res.Err != nil {
+			failed = append(failed, res)
+		}
+	}
+	return failed
+}
+
+// Err returns the errors of all failed validators joined together, or nil if
+// every validator passed.
+func (r *Report) Err() error {
+	var errs []error
+	for _, res := range r.Failed() {
+		errs = append(errs, res.Err)
+	}
+	return errors.Join(errs...)
+}
+
+// String formats the report as one line per validator.
+func (r *Report) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%d/%d validators passed (%d paths, %d notifications, %d snapshots)\n",
+		len(r.Results)-len(r.Failed()), len(r.Results), r.Paths, r.Notifications, r.Attempts)
+	for _, res := range r.Results {
+		if res.Err != nil {
+			fmt.Fprintf(&sb, "FAIL %s: %v\n", res.Path, res.Err)
+		} else {
+			fmt.Fprintf(&sb, "PASS %s\n", res.Path)
+		}
+	}
+	return sb.String()
+}
+
+// isLive reports whether vd, or any validator it combines, must be run
+// against the device rather than a snapshot.
+func isLive(vd Validator) bool {
+	if _, ok := vd.(liveValidator); ok {
+		return true
+	}
+	if c, ok := vd.(*combined); ok {
+		for _, child := range c.vds {
+			if isLive(child) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Check takes one snapshot of all paths needed by the batch and evaluates
+// every validator against it. opts are used to create the ygnmi clients the
+// validators run against and should match those used elsewhere in the test,
+// e.g. ygnmi.WithTarget. The returned error is only set if the snapshot could
+// not be taken; validation failures are reported in the Report.
+func (b *Batch) Check(ctx context.Context, client gpb.GNMIClient, opts ...ygnmi.ClientOption) (*Report, error) {
+	return b.run(ctx, client, opts, false)
+}
+
+// AwaitUntil repeatedly snapshots and evaluates the validators that have not
+// yet passed, until all pass or the deadline is reached. At least one
+// snapshot is always taken.
+func (b *Batch) AwaitUntil(deadline time.Time, client gpb.GNMIClient, opts ...ygnmi.ClientOption) (*Report, error) {
+	ctx, cancel := context.WithDeadline(context.Background(), deadline)
+	defer cancel()
+	return b.run(ctx, client, opts, true)
+}
+
+func (b *Batch) run(ctx context.Context, client gpb.GNMIClient, opts []ygnmi.ClientOption, await bool) (*Report, error) {
+	report := &Report{Results: make([]Result, len(b.vds))}
+	pending := make([]int, len(b.vds))
+	for i, vd := range b.vds {
+		report.Results[i].Path = vd.Path()
+		pending[i] = i
+	}
+	liveClient, err := ygnmi.NewClient(client, opts...)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		report.Attempts++
+		snap, err := takeSnapshot(ctx, client, b.subset(pending), opts)
+		if err != nil {
+			return report, err
+		}
+		report.Paths, report.Notifications = len(snap.paths), len(snap.notifs)
+		snapClient, err := ygnmi.NewClient(snap, opts...)
+		if err != nil {
+			return nil, err
+		}
+		var failed []int
+		for _, i := range pending {
+			c := snapClient
+			if isLive(b.vds[i]) {
+				c = liveClient
+			}
+			if report.Results[i].Err = b.vds[i].Check(c); report.Results[i].Err != nil {
+				failed = append(failed, i)
+			}
+		}
+		pending = failed
+		if !await || len(pending) == 0 {
+			return report, nil
+		}
+		select {
+		case <-ctx.Done():
+			return report, nil
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+func (b *Batch) subset(idx []int) []Validator {
+	vds := make([]Validator, 0, len(idx))
+	for _, i := range idx {
+		vds = append(vds, b.vds[i])
+	}
+	return vds
+}
+
+// takeSnapshot learns the paths vds subscribe to and fetches them from client
+// in a single ONCE subscription.
+func takeSnapshot(ctx context.Context, client gpb.GNMIClient, vds []Validator, opts []ygnmi.ClientOption) (*snapshot, error) {
+	rec := &snapshot{}
+	recClient, err := ygnmi.NewClient(rec, opts...)
+	if err != nil {
+		return nil, err
+	}
+	for _, vd := range vds {
+		if !isLive(vd) {
+			// The recording client returns no data, so these checks are
+			// expected to fail; only the subscription paths matter.
+			_ = vd.Check(recClient)
+		}
+	}
+	snap := &snapshot{paths: rec.paths}
+	if len(rec.paths) == 0 {
+		return snap, nil
+	}
+	subs := make([]*gpb.Subscription, 0, len(rec.paths))
+	for _, p := range rec.paths {
+		subs = append(subs, &gpb.Subscription{Path: p})
+	}
+	req := &gpb.SubscribeRequest{
+		Request: &gpb.SubscribeRequest_Subscribe{
+			Subscribe: &gpb.SubscriptionList{
+				Prefix:       &gpb.Path{Target: rec.target},
+				Subscription: subs,
+				Mode:         gpb.SubscriptionList_ONCE,
+				Encoding:     rec.encoding,
+			},
+		},
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	sub, err := client.Subscribe(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("snapshot subscribe failed: %w", err)
+	}
+	if err := sub.Send(req); err != nil {
+		return nil, fmt.Errorf("snapshot subscribe send failed: %w", err)
+	}
+	for {
+		resp, err := sub.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("snapshot subscribe receive failed: %w", err)
+		}
+		if resp.GetSyncResponse() {
+			break
+		}
+		if n := resp.GetUpdate(); n != nil {
+			snap.notifs = append(snap.notifs, n)
+		}
+	}
+	return snap, nil
+}
+
+// snapshot is a gpb.GNMIClient that answers ONCE subscriptions from a fixed
+// set of notifications and records the paths it was asked for.
+type snapshot struct {
+	mu       sync.Mutex
+	notifs   []*gpb.Notification
+	paths    []*gpb.Path
+	seen     map[string]bool
+	target   string
+	encoding gpb.Encoding
+}
+
+var _ gpb.GNMIClient = (*snapshot)(nil)
+
+// Capabilities is not supported by a snapshot.
+func (s *snapshot) Capabilities(context.Context, *gpb.CapabilityRequest, ...grpc.CallOption) (*gpb.CapabilityResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "Capabilities is not supported on a check.Batch snapshot")
+}
+
+// Get is not supported by a snapshot.
+func (s *snapshot) Get(context.Context, *gpb.GetRequest, ...grpc.CallOption) (*gpb.GetResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "Get is not supported on a check.Batch snapshot")
+}
+
+// Set is not supported by a snapshot.
+func (s *snapshot) Set(context.Context, *gpb.SetRequest, ...grpc.CallOption) (*gpb.SetResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "Set is not supported on a check.Batch snapshot")
+}
+
+// Subscribe returns a stream that replays the matching notifications.
+func (s *snapshot) Subscribe(ctx context.Context, _ ...grpc.CallOption) (gpb.GNMI_SubscribeClient, error) {
+	return &snapshotStream{ctx: ctx, snap: s}, nil
+}
+
+// record adds the paths of req to the set of paths seen by s.
+func (s *snapshot) record(req *gpb.SubscribeRequest) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.seen == nil {
+		s.seen = map[string]bool{}
+	}
+	list := req.GetSubscribe()
+	s.target = list.GetPrefix().GetTarget()
+	s.encoding = list.GetEncoding()
+	for _, sub := range list.GetSubscription() {
+		p := joinPaths(list.GetPrefix(), sub.GetPath())
+		key, err := ygot.PathToString(p)
+		if err != nil || s.seen[key] {
+			continue
+		}
+		s.seen[key] = true
+		s.paths = append(s.paths, p)
+	}
+}
+
+// matching returns the responses that answer req from the snapshot.
+func (s *snapshot) matching(req *gpb.SubscribeRequest) []*gpb.SubscribeResponse {
+	list := req.GetSubscribe()
+	var want []*gpb.Path
+	for _, sub := range list.GetSubscription() {
+		want = append(want, joinPaths(list.GetPrefix(), sub.GetPath()))
+	}
+	var resps []*gpb.SubscribeResponse
+	for _, n := range s.notifs {
+		var upds []*gpb.Update
+		for _, u := range n.GetUpdate() {
+			leaf := joinPaths(n.GetPrefix(), u.GetPath())
+			for _, w := range want {
+				if pathMatches(w.GetElem(), leaf.GetElem()) {
+					upds = append(upds, u)
+					break
+				}
+			}
+		}
+		if len(upds) == 0 {
+			continue
+		}
+		m := proto.Clone(n).(*gpb.Notification)
+		m.Update, m.Delete = upds, nil
+		resps = append(resps, &gpb.SubscribeResponse{Response: &gpb.SubscribeResponse_Update{Update: m}})
+	}
+	return append(resps, &gpb.SubscribeResponse{Response: &gpb.SubscribeResponse_SyncResponse{SyncResponse: true}})
+}
+
+// joinPaths returns the elements of prefix followed by those of p, with the
+// origin of whichever sets one.
+func joinPaths(prefix, p *gpb.Path) *gpb.Path {
+	origin := p.GetOrigin()
+	if origin == "" {
+		origin = prefix.GetOrigin()
+	}
+	elems := append(append([]*gpb.PathElem{}, prefix.GetElem()...), p.GetElem()...)
+	return &gpb.Path{Origin: origin, Elem: elems}
+}
+
+// pathMatches reports whether leaf is at or below the possibly wildcarded
+// path want. A missing key or a key value of "*" in want matches any value.
+func pathMatches(want, leaf []*gpb.PathElem) bool {
+	for i, w := range want {
+		if w.GetName() == "..." {
+			return true
+		}
+		if i >= len(leaf) {
+			return false
+		}
+		l := leaf[i]
+		if w.GetName() != "*" && w.GetName() != l.GetName() {
+			return false
+		}
+		for k, v := range w.GetKey() {
+			if v != "*" && l.GetKey()[k] != v {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// snapshotStream is the gpb.GNMI_SubscribeClient returned by a snapshot. Like
+// ygnmi's Get-backed subscriber, it embeds the interface and only implements
+// the methods used by ygnmi.
+type snapshotStream struct {
+	gpb.GNMI_SubscribeClient
+	ctx   context.Context
+	snap  *snapshot
+	resps []*gpb.SubscribeResponse
+}
+
+// Send records the request and queues the matching responses.
+func (ss *snapshotStream) Send(req *gpb.SubscribeRequest) error {
+	ss.snap.record(req)
+	ss.resps = ss.snap.matching(req)
+	return nil
+}
+
+// Recv returns the queued responses, then io.EOF.
+func (ss *snapshotStream) Recv() (*gpb.SubscribeResponse, error) {
+	if err := ss.ctx.Err(); err != nil {
+		return nil, err
+	}
+	if len(ss.resps) == 0 {
+		return nil, io.EOF
+	}
+	resp := ss.resps[0]
+	ss.resps = ss.resps[1:]
+	return resp, nil
+}
+
+// CloseSend is a no-op.
+func (ss *snapshotStream) CloseSend() error { return nil }
+
+// Header returns no metadata.
+func (ss *snapshotStream) Header() (metadata.MD, error) { return nil, nil }
+
+// Trailer returns no metadata.
+func (ss *snapshotStream) Trailer() metadata.MD { return nil }
+
+// Context returns the stream context.
+func (ss *snapshotStream) Context() context.Context { return ss.ctx }
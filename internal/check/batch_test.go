@@ -0,0 +1,127 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package check_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/openconfig/featureprofiles/internal/check"
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+func TestCombinators(t *testing.T) {
+	fakeGNMI, c := mustNewFakeGNMI(context.Background(), t)
+	defer fakeGNMI.Close()
+	query := childTwo.State()
+	testCases := []struct {
+		desc        string
+		validator   check.Validator
+		errIncludes []string
+	}{{
+		desc:      "All/Correct",
+		validator: check.All(check.Equal(query, "correct"), check.Present[string](query)),
+	}, {
+		desc:        "All/Incorrect",
+		validator:   check.All(check.Equal(query, "correct"), check.Equal(query, "other")),
+		errIncludes: []string{childTwoStatePath, "other"},
+	}, {
+		desc:      "Any/Correct",
+		validator: check.Any(check.Equal(query, "other"), check.Equal(query, "correct")),
+	}, {
+		desc:        "Any/Incorrect",
+		validator:   check.Any(check.Equal(query, "other"), check.NotPresent[string](query)),
+		errIncludes: []string{"none of 2", "other", "no value"},
+	}, {
+		desc:      "Not/Correct",
+		validator: check.Not(check.Equal(query, "other")),
+	}, {
+		desc:        "Not/Incorrect",
+		validator:   check.Not(check.Equal(query, "correct")),
+		errIncludes: []string{childTwoStatePath, "want failure"},
+	}}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			fakeGNMI.stubChildTwo(update{"correct", 0})
+			gotErr := tc.validator.Check(c)
+			if len(tc.errIncludes) > 0 {
+				if err := errContainsAll(gotErr, tc.errIncludes); err != nil {
+					t.Error(err)
+				}
+			} else if gotErr != nil {
+				t.Errorf("Unexpected error: %v", gotErr)
+			}
+		})
+	}
+}
+
+func TestCombinatorPath(t *testing.T) {
+	query := childTwo.State()
+	vd := check.All(check.Equal(query, "a"), check.Not(check.Present[string](query)))
+	if got, want := vd.Path(), "all(/parent/child/state/two, not(/parent/child/state/two))"; got != want {
+		t.Errorf("vd.Path(): got %#v, want %#v", got, want)
+	}
+}
+
+func TestBatch(t *testing.T) {
+	fakeGNMI, _ := mustNewFakeGNMI(context.Background(), t)
+	defer fakeGNMI.Close()
+	conn, err := grpc.NewClient(fakeGNMI.Agent.Address(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("NewClient(%s): %v", fakeGNMI.Agent.Address(), err)
+	}
+	defer conn.Close()
+	raw := gpb.NewGNMIClient(conn)
+	fakeGNMI.stubChildTwo(update{"correct", 0})
+
+	query := childTwo.State()
+	batch := check.NewBatch(
+		check.Equal(query, "correct"),
+		check.Present[string](query),
+		check.Equal(query, "wrong"),
+	)
+	batch.Add(check.Any(check.Equal(query, "wrong"), check.NotEqual(query, "wrong")))
+
+	report, err := batch.Check(context.Background(), raw)
+	if err != nil {
+		t.Fatalf("Batch.Check() failed: %v", err)
+	}
+	if got, want := len(report.Results), 4; got != want {
+		t.Fatalf("Batch.Check() returned %d results, want %d", got, want)
+	}
+	if got, want := report.Paths, 1; got != want {
+		t.Errorf("Batch.Check() subscribed to %d paths, want %d", got, want)
+	}
+	failed := report.Failed()
+	if len(failed) != 1 {
+		t.Fatalf("Batch.Check() failed validators: got %v, want only Equal(wrong)\n%s", failed, report)
+	}
+	if err := errContainsAll(failed[0].Err, []string{childTwoStatePath, "wrong", "correct"}); err != nil {
+		t.Error(err)
+	}
+	if report.Err() == nil {
+		t.Errorf("Report.Err() = nil, want error")
+	}
+
+	report, err = check.NewBatch(check.Equal(query, "correct")).AwaitUntil(time.Now().Add(time.Second), raw)
+	if err != nil {
+		t.Fatalf("Batch.AwaitUntil() failed: %v", err)
+	}
+	if err := report.Err(); err != nil {
+		t.Errorf("Batch.AwaitUntil() returned failures: %v", err)
+	}
+}
@@ -65,6 +65,20 @@ check also provides a number of shorthands for common cases:
     bool) checks that the value at query is present and satisfies the given
     predicate function; wantMsg is used in the resulting error if it fails.
 
+Wildcard queries are validated as a set:
+
+  - check.ValidateAll(query, validationFn func([]*Value[T]) error) runs
+    validationFn on every value matching the query.
+  - check.AllEqual(query, want) and check.AllPredicate(query, wantMsg,
+    predicate) check every matching value and report failures by path.
+  - check.Count(query, n) checks that exactly n values match.
+
+Numeric values such as counters can be checked approximately with
+check.Within(query, want, tolerance) and check.WithinPercent(query, want, pct),
+and check.MonotonicIncrease(query, samples, interval) samples a value over time.
+
+Validators can be combined with check.All, check.Any and check.Not.
+
 These helpers all have prewritten validation functions that return sensible
 errors of the form "<path>: <got>, <want>", such as:
 
@@ -130,6 +144,19 @@ run because Await(ctx, client) aborts if the ctx has already expired, whereas
 AwaitUntil and AwaitFor will both be equivalent to Check if given a 0 or
 negative timeout or a deadline in the past.
 
+# Batches
+
+When many validators check one snapshot of state, a Batch fetches all of their
+paths with a single subscription and returns a consolidated Report:
+
+	report, err := check.NewBatch(validators...).AwaitUntil(deadline, dut.RawAPIs().GNMI(t), ygnmi.WithTarget(dut.ID()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, res := range report.Failed() {
+		t.Error(res.Err)
+	}
+
 # Error Messages
 
 The error messages generated by failing checks will include the path, the value
@@ -0,0 +1,427 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collectors
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/netip"
+	"time"
+)
+
+// BMPType is a BMP message type from RFC 7854.
+type BMPType uint8
+
+// BMP message types.
+const (
+	BMPRouteMonitoring  BMPType = 0
+	BMPStatisticsReport BMPType = 1
+	BMPPeerDown         BMPType = 2
+	BMPPeerUp           BMPType = 3
+	BMPInitiation       BMPType = 4
+	BMPTermination      BMPType = 5
+	BMPRouteMirroring   BMPType = 6
+)
+
+func (t BMPType) String() string {
+	switch t {
+	case BMPRouteMonitoring:
+		return "RouteMonitoring"
+	case BMPStatisticsReport:
+		return "StatisticsReport"
+	case BMPPeerDown:
+		return "PeerDown"
+	case BMPPeerUp:
+		return "PeerUp"
+	case BMPInitiation:
+		return "Initiation"
+	case BMPTermination:
+		return "Termination"
+	case BMPRouteMirroring:
+		return "RouteMirroring"
+	}
+	return fmt.Sprintf("BMPType(%d)", uint8(t))
+}
+
+// BMP per-peer header flags.
+const (
+	BMPPeerFlagIPv6       = 0x80
+	BMPPeerFlagPostPolicy = 0x40
+	BMPPeerFlag2ByteAS    = 0x20
+	BMPPeerFlagAdjRIBOut  = 0x10
+)
+
+// BMP information TLV types used in Initiation and Termination messages.
+const (
+	BMPInfoString   = 0
+	BMPInfoSysDescr = 1
+	BMPInfoSysName  = 2
+)
+
+const (
+	bmpVersion        = 3
+	bmpCommonHdrLen   = 6
+	bmpPerPeerHdrLen  = 42
+	bgpHeaderLen      = 19
+	bgpUpdateType     = 2
+	bgpAttrMPReach    = 14
+	bgpAttrMPUnreach  = 15
+	bgpAttrFlagExtLen = 0x10
+	afiIPv4           = 1
+	afiIPv6           = 2
+)
+
+// BMPPeer is the BMP per-peer header.
+type BMPPeer struct {
+	Type          uint8
+	Flags         uint8
+	Distinguisher uint64
+	Address       netip.Addr
+	AS            uint32
+	BGPID         netip.Addr
+	Timestamp     time.Time
+}
+
+// PostPolicy reports whether the message carries post-policy Adj-RIB-In.
+func (p *BMPPeer) PostPolicy() bool {
+	return p.Flags&BMPPeerFlagPostPolicy != 0
+}
+
+// BMPMessage is a parsed BMP v3 message.
+type BMPMessage struct {
+	Type BMPType
+	// Peer is the per-peer header, or nil for Initiation and Termination.
+	Peer *BMPPeer
+	// Info are the information TLVs of Initiation and Termination messages,
+	// keyed by type.  Repeated TLVs are joined with newlines.
+	Info map[uint16]string
+	// Stats are the Statistics Report counters, keyed by stat type.
+	Stats map[uint16]uint64
+	// Announced and Withdrawn are the unicast prefixes of a Route
+	// Monitoring message's BGP UPDATE.
+	Announced []netip.Prefix
+	Withdrawn []netip.Prefix
+	// PeerDownReason is the reason code of a Peer Down message.
+	PeerDownReason uint8
+	// Body is the message after the per-peer header.
+	Body []byte
+	// Source is the address of the exporter, if known.
+	Source   net.IP
+	Received time.Time
+}
+
+// ReadBMP reads one BMP message from r.
+func ReadBMP(r io.Reader) (*BMPMessage, error) {
+	hdr := make([]byte, bmpCommonHdrLen)
+	if _, err := io.ReadFull(r, hdr); err != nil {
+		return nil, err
+	}
+	if hdr[0] != bmpVersion {
+		return nil, fmt.Errorf("unsupported BMP version %d", hdr[0])
+	}
+	n := binary.BigEndian.Uint32(hdr[1:5])
+	if n < bmpCommonHdrLen || n > 1<<24 {
+		return nil, fmt.Errorf("invalid BMP message length %d", n)
+	}
+	msg := make([]byte, n)
+	copy(msg, hdr)
+	if _, err := io.ReadFull(r, msg[bmpCommonHdrLen:]); err != nil {
+		return nil, err
+	}
+	return ParseBMP(msg)
+}
+
+// ParseBMP parses one complete BMP message, including its common header.
+func ParseBMP(b []byte) (*BMPMessage, error) {
+	if len(b) < bmpCommonHdrLen {
+		return nil, errors.New("BMP message too short")
+	}
+	if b[0] != bmpVersion {
+		return nil, fmt.Errorf("unsupported BMP version %d", b[0])
+	}
+	if n := binary.BigEndian.Uint32(b[1:5]); int(n) != len(b) {
+		return nil, fmt.Errorf("BMP message length %d, have %d bytes", n, len(b))
+	}
+	m := &BMPMessage{Type: BMPType(b[5])}
+	body := b[bmpCommonHdrLen:]
+
+	switch m.Type {
+	case BMPInitiation, BMPTermination:
+		info, err := parseBMPInfo(body)
+		if err != nil {
+			return nil, fmt.Errorf("%v: %w", m.Type, err)
+		}
+		m.Info, m.Body = info, body
+		return m, nil
+	}
+
+	if len(body) < bmpPerPeerHdrLen {
+		return nil, fmt.Errorf("%v: per-peer header too short", m.Type)
+	}
+	m.Peer = parseBMPPeer(body[:bmpPerPeerHdrLen])
+	m.Body = body[bmpPerPeerHdrLen:]
+	var err error
+	switch m.Type {
+	case BMPRouteMonitoring:
+		m.Announced, m.Withdrawn, err = parseBGPUpdate(m.Body)
+	case BMPStatisticsReport:
+		m.Stats, err = parseBMPStats(m.Body)
+	case BMPPeerDown:
+		if len(m.Body) == 0 {
+			err = errors.New("missing reason")
+		} else {
+			m.PeerDownReason = m.Body[0]
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%v: %w", m.Type, err)
+	}
+	return m, nil
+}
+
+func parseBMPPeer(b []byte) *BMPPeer {
+	p := &BMPPeer{
+		Type:          b[0],
+		Flags:         b[1],
+		Distinguisher: binary.BigEndian.Uint64(b[2:10]),
+		AS:            binary.BigEndian.Uint32(b[26:30]),
+		BGPID:         netip.AddrFrom4([4]byte(b[30:34])),
+		Timestamp:     time.Unix(int64(binary.BigEndian.Uint32(b[34:38])), int64(binary.BigEndian.Uint32(b[38:42]))*1000),
+	}
+	if p.Flags&BMPPeerFlagIPv6 != 0 {
+		p.Address = netip.AddrFrom16([16]byte(b[10:26]))
+	} else {
+		p.Address = netip.AddrFrom4([4]byte(b[22:26]))
+	}
+	return p
+}
+
+func parseBMPInfo(b []byte) (map[uint16]string, error) {
+	info := map[uint16]string{}
+	for len(b) > 0 {
+		if len(b) < 4 {
+			return nil, errors.New("truncated information TLV")
+		}
+		t, n := binary.BigEndian.Uint16(b[0:2]), int(binary.BigEndian.Uint16(b[2:4]))
+		if len(b) < 4+n {
+			return nil, errors.New("truncated information TLV")
+		}
+		if prev, ok := info[t]; ok {
+			info[t] = prev + "\n" + string(b[4:4+n])
+		} else {
+			info[t] = string(b[4 : 4+n])
+		}
+		b = b[4+n:]
+	}
+	return info, nil
+}
+
+func parseBMPStats(b []byte) (map[uint16]uint64, error) {
+	if len(b) < 4 {
+		return nil, errors.New("missing stats count")
+	}
+	count := binary.BigEndian.Uint32(b[:4])
+	b = b[4:]
+	stats := map[uint16]uint64{}
+	for i := uint32(0); i < count; i++ {
+		if len(b) < 4 {
+			return nil, errors.New("truncated stat TLV")
+		}
+		t, n := binary.BigEndian.Uint16(b[0:2]), int(binary.BigEndian.Uint16(b[2:4]))
+		if len(b) < 4+n {
+			return nil, errors.New("truncated stat TLV")
+		}
+		switch n {
+		case 4:
+			stats[t] = uint64(binary.BigEndian.Uint32(b[4:8]))
+		case 8:
+			stats[t] = binary.BigEndian.Uint64(b[4:12])
+		}
+		b = b[4+n:]
+	}
+	return stats, nil
+}
+
+// parseBGPUpdate returns the unicast prefixes announced and withdrawn by a
+// BGP UPDATE, from both the IPv4 NLRI fields and MP_REACH/MP_UNREACH.
+func parseBGPUpdate(b []byte) (announced, withdrawn []netip.Prefix, err error) {
+	if len(b) < bgpHeaderLen {
+		return nil, nil, errors.New("BGP message too short")
+	}
+	n := int(binary.BigEndian.Uint16(b[16:18]))
+	if n < bgpHeaderLen || n > len(b) {
+		return nil, nil, fmt.Errorf("invalid BGP message length %d", n)
+	}
+	if b[18] != bgpUpdateType {
+		return nil, nil, fmt.Errorf("BGP message type %d, want UPDATE", b[18])
+	}
+	b = b[bgpHeaderLen:n]
+
+	if len(b) < 2 {
+		return nil, nil, errors.New("truncated withdrawn routes")
+	}
+	wlen := int(binary.BigEndian.Uint16(b[:2]))
+	if len(b) < 2+wlen+2 {
+		return nil, nil, errors.New("truncated withdrawn routes")
+	}
+	if withdrawn, err = parsePrefixes(b[2:2+wlen], afiIPv4); err != nil {
+		return nil, nil, err
+	}
+	b = b[2+wlen:]
+	alen := int(binary.BigEndian.Uint16(b[:2]))
+	if len(b) < 2+alen {
+		return nil, nil, errors.New("truncated path attributes")
+	}
+	attrs, nlri := b[2:2+alen], b[2+alen:]
+	if announced, err = parsePrefixes(nlri, afiIPv4); err != nil {
+		return nil, nil, err
+	}
+
+	for len(attrs) > 0 {
+		if len(attrs) < 3 {
+			return nil, nil, errors.New("truncated path attribute")
+		}
+		flags, code := attrs[0], attrs[1]
+		hlen, vlen := 3, int(attrs[2])
+		if flags&bgpAttrFlagExtLen != 0 {
+			if len(attrs) < 4 {
+				return nil, nil, errors.New("truncated path attribute")
+			}
+			hlen, vlen = 4, int(binary.BigEndian.Uint16(attrs[2:4]))
+		}
+		if len(attrs) < hlen+vlen {
+			return nil, nil, errors.New("truncated path attribute")
+		}
+		val := attrs[hlen : hlen+vlen]
+		attrs = attrs[hlen+vlen:]
+		switch code {
+		case bgpAttrMPReach:
+			// AFI(2) SAFI(1) NH-len(1) NH reserved(1) NLRI.
+			if len(val) < 5 || len(val) < 5+int(val[3]) {
+				return nil, nil, errors.New("truncated MP_REACH_NLRI")
+			}
+			if val[2] != 1 {
+				continue
+			}
+			ps, err := parsePrefixes(val[5+int(val[3]):], binary.BigEndian.Uint16(val[:2]))
+			if err != nil {
+				return nil, nil, err
+			}
+			announced = append(announced, ps...)
+		case bgpAttrMPUnreach:
+			if len(val) < 3 {
+				return nil, nil, errors.New("truncated MP_UNREACH_NLRI")
+			}
+			if val[2] != 1 {
+				continue
+			}
+			ps, err := parsePrefixes(val[3:], binary.BigEndian.Uint16(val[:2]))
+			if err != nil {
+				return nil, nil, err
+			}
+			withdrawn = append(withdrawn, ps...)
+		}
+	}
+	return announced, withdrawn, nil
+}
+
+func parsePrefixes(b []byte, afi uint16) ([]netip.Prefix, error) {
+	size := 4
+	if afi == afiIPv6 {
+		size = 16
+	} else if afi != afiIPv4 {
+		return nil, nil
+	}
+	var ps []netip.Prefix
+	for len(b) > 0 {
+		bits := int(b[0])
+		n := (bits + 7) / 8
+		if bits > size*8 || len(b) < 1+n {
+			return nil, fmt.Errorf("invalid NLRI prefix length %d", bits)
+		}
+		addr := make([]byte, size)
+		copy(addr, b[1:1+n])
+		a, _ := netip.AddrFromSlice(addr)
+		ps = append(ps, netip.PrefixFrom(a, bits))
+		b = b[1+n:]
+	}
+	return ps, nil
+}
+
+// BMPCollector is a BMP station that accepts connections from routers.
+type BMPCollector struct {
+	// Messages are the messages received.
+	Messages *Store[*BMPMessage]
+	// Errors are the connection and parsing errors.
+	Errors *Store[error]
+
+	l net.Listener
+}
+
+// ListenBMP starts a BMP station on a TCP address, such as ":11019".
+func ListenBMP(addr string) (*BMPCollector, error) {
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	c := &BMPCollector{Messages: NewStore[*BMPMessage](), Errors: NewStore[error](), l: l}
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go c.serve(conn)
+		}
+	}()
+	return c, nil
+}
+
+func (c *BMPCollector) serve(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	for {
+		m, err := ReadBMP(r)
+		if err != nil {
+			if !errors.Is(err, io.EOF) && !errors.Is(err, net.ErrClosed) {
+				c.Errors.Add(fmt.Errorf("BMP session from %v: %w", conn.RemoteAddr(), err))
+			}
+			return
+		}
+		m.Source = addrIP(conn.RemoteAddr())
+		m.Received = time.Now()
+		c.Messages.Add(m)
+	}
+}
+
+// Addr returns the address the station listens on.
+func (c *BMPCollector) Addr() net.Addr {
+	return c.l.Addr()
+}
+
+// Close stops accepting connections.
+func (c *BMPCollector) Close() error {
+	return c.l.Close()
+}
+
+// BMPOfType returns a match function for messages of type t, for use with
+// Store.Await and Store.Find.
+func BMPOfType(t BMPType) func(*BMPMessage) bool {
+	return func(m *BMPMessage) bool { return m.Type == t }
+}
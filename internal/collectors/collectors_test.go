@@ -0,0 +1,370 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collectors
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"net/netip"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/google/gopacket/pcapgo"
+)
+
+func TestParseSyslog(t *testing.T) {
+	tests := []struct {
+		desc string
+		msg  string
+		want *SyslogMessage
+	}{{
+		desc: "RFC 5424",
+		msg:  `<187>1 2026-10-19T08:00:00.123Z dut1 sshd 1234 ID47 [exampleSDID@32473 iut="3" eventSource="App]lication"] interface down`,
+		want: &SyslogMessage{
+			Format:         RFC5424,
+			Facility:       FacilityLocal7,
+			Severity:       SeverityError,
+			Timestamp:      time.Date(2026, 10, 19, 8, 0, 0, 123000000, time.UTC),
+			Hostname:       "dut1",
+			AppName:        "sshd",
+			ProcID:         "1234",
+			MsgID:          "ID47",
+			StructuredData: `[exampleSDID@32473 iut="3" eventSource="App]lication"]`,
+			Message:        "interface down",
+		},
+	}, {
+		desc: "RFC 5424 with nil values",
+		msg:  "<14>1 - - - - - - hello",
+		want: &SyslogMessage{Format: RFC5424, Facility: FacilityUser, Severity: SeverityInformational, StructuredData: "-", Message: "hello"},
+	}, {
+		desc: "RFC 3164",
+		msg:  "<34>Oct 11 22:14:15 dut2 su[42]: 'su root' failed",
+		want: &SyslogMessage{
+			Format:   RFC3164,
+			Facility: FacilityAuth,
+			Severity: SeverityCritical,
+			Hostname: "dut2",
+			AppName:  "su",
+			ProcID:   "42",
+			Message:  "'su root' failed",
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := ParseSyslog([]byte(tt.msg))
+			if err != nil {
+				t.Fatalf("ParseSyslog() failed: %v", err)
+			}
+			opts := cmp.FilterPath(func(p cmp.Path) bool {
+				name := p.Last().String()
+				return name == ".Raw" || tt.want.Format == RFC3164 && name == ".Timestamp"
+			}, cmp.Ignore())
+			if diff := cmp.Diff(tt.want, got, opts); diff != "" {
+				t.Errorf("ParseSyslog() differs (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	if _, err := ParseSyslog([]byte("no priority")); err == nil {
+		t.Errorf("ParseSyslog() without priority succeeded, want error")
+	}
+}
+
+func TestSyslogCollectors(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	filter := SyslogFilter{Host: "dut", Contains: "BGP"}.
+		WithFacility(FacilityLocal7).
+		WithMinSeverity(SeverityWarning)
+
+	t.Run("UDP", func(t *testing.T) {
+		c, err := ListenSyslogUDP("127.0.0.1:0")
+		if err != nil {
+			t.Fatalf("ListenSyslogUDP() failed: %v", err)
+		}
+		defer c.Close()
+		conn, err := net.Dial("udp", c.Addr().String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer conn.Close()
+		for _, msg := range []string{
+			"<191>1 - dut app - - - BGP debug",      // local7.debug
+			"<188>1 - dut app - - - BGP peer reset", // local7.warning
+		} {
+			if _, err := conn.Write([]byte(msg)); err != nil {
+				t.Fatal(err)
+			}
+		}
+		got, err := c.Messages.Await(ctx, filter.Match)
+		if err != nil {
+			t.Fatalf("Await() failed: %v", err)
+		}
+		if got.Message != "BGP peer reset" {
+			t.Errorf("Await() got %q, want %q", got.Message, "BGP peer reset")
+		}
+	})
+
+	t.Run("TCP", func(t *testing.T) {
+		c, err := ListenSyslogTCP("127.0.0.1:0")
+		if err != nil {
+			t.Fatalf("ListenSyslogTCP() failed: %v", err)
+		}
+		defer c.Close()
+		conn, err := net.Dial("tcp", c.Addr().String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer conn.Close()
+		octet := "<186>1 - dut app - - - BGP octet\ncounted"
+		fmt.Fprintf(conn, "%d %s<186>1 - dut app - - - BGP newline\n", len(octet), octet)
+		got, err := c.Messages.AwaitN(ctx, 2, filter.Match)
+		if err != nil {
+			t.Fatalf("AwaitN() failed: %v (errors: %v)", err, c.Errors.All())
+		}
+		if got[0].Message != "BGP octet\ncounted" || got[1].Message != "BGP newline" {
+			t.Errorf("AwaitN() got %q and %q", got[0].Message, got[1].Message)
+		}
+	})
+
+	t.Run("TCP oversized frame", func(t *testing.T) {
+		c, err := ListenSyslogTCP("127.0.0.1:0")
+		if err != nil {
+			t.Fatalf("ListenSyslogTCP() failed: %v", err)
+		}
+		defer c.Close()
+		conn, err := net.Dial("tcp", c.Addr().String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer conn.Close()
+		fmt.Fprint(conn, "99999999999 <186>1 - dut app - - - BGP oversized\n")
+		got, err := c.Errors.Await(ctx, func(error) bool { return true })
+		if err != nil {
+			t.Fatalf("Await() failed: %v", err)
+		}
+		if !strings.Contains(got.Error(), "invalid syslog frame length") {
+			t.Errorf("Await() got error %v, want invalid syslog frame length", got)
+		}
+		if n := len(c.Messages.All()); n != 0 {
+			t.Errorf("Collector stored %d messages after an oversized frame, want 0", n)
+		}
+	})
+}
+
+func bmpMessage(typ BMPType, body []byte) []byte {
+	b := []byte{bmpVersion, 0, 0, 0, 0, byte(typ)}
+	binary.BigEndian.PutUint32(b[1:5], uint32(len(b)+len(body)))
+	return append(b, body...)
+}
+
+func bmpPeerHeader(flags uint8, addr netip.Addr, as uint32) []byte {
+	b := make([]byte, bmpPerPeerHdrLen)
+	b[1] = flags
+	a16 := addr.As16()
+	copy(b[10:26], a16[:])
+	binary.BigEndian.PutUint32(b[26:30], as)
+	copy(b[30:34], []byte{192, 0, 2, 1})
+	return b
+}
+
+// bgpUpdate returns a BGP UPDATE announcing 198.51.100.0/24 and withdrawing
+// 2001:db8::/32 with MP_UNREACH_NLRI.
+func bgpUpdate() []byte {
+	attrs := []byte{0x80, bgpAttrMPUnreach, 8, 0, afiIPv6, 1, 32, 0x20, 0x01, 0x0d, 0xb8}
+	nlri := []byte{24, 198, 51, 100}
+	body := binary.BigEndian.AppendUint16(nil, 0)
+	body = binary.BigEndian.AppendUint16(body, uint16(len(attrs)))
+	body = append(append(body, attrs...), nlri...)
+	hdr := bytes.Repeat([]byte{0xff}, 16)
+	hdr = binary.BigEndian.AppendUint16(hdr, uint16(bgpHeaderLen+len(body)))
+	hdr = append(hdr, bgpUpdateType)
+	return append(hdr, body...)
+}
+
+func TestBMPCollector(t *testing.T) {
+	c, err := ListenBMP("127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("ListenBMP() failed: %v", err)
+	}
+	defer c.Close()
+	conn, err := net.Dial("tcp", c.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	peer := bmpPeerHeader(BMPPeerFlagPostPolicy, netip.MustParseAddr("192.0.2.2"), 64500)
+	stats := binary.BigEndian.AppendUint32(nil, 1)
+	stats = append(stats, 0, 7, 0, 8)
+	stats = binary.BigEndian.AppendUint64(stats, 42)
+	for _, m := range [][]byte{
+		bmpMessage(BMPInitiation, []byte{0, BMPInfoSysName, 0, 3, 'd', 'u', 't'}),
+		bmpMessage(BMPRouteMonitoring, append(peer, bgpUpdate()...)),
+		bmpMessage(BMPStatisticsReport, append(peer, stats...)),
+	} {
+		if _, err := conn.Write(m); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	msgs, err := c.Messages.AwaitN(ctx, 3, func(*BMPMessage) bool { return true })
+	if err != nil {
+		t.Fatalf("AwaitN() failed: %v (errors: %v)", err, c.Errors.All())
+	}
+	if got := msgs[0].Info[BMPInfoSysName]; got != "dut" {
+		t.Errorf("Initiation sysName = %q, want dut", got)
+	}
+	rm := msgs[1]
+	if !rm.Peer.PostPolicy() || rm.Peer.AS != 64500 || rm.Peer.Address != netip.MustParseAddr("192.0.2.2") {
+		t.Errorf("Route Monitoring peer = %+v, want post-policy AS 64500 from 192.0.2.2", rm.Peer)
+	}
+	wantAnnounced := []netip.Prefix{netip.MustParsePrefix("198.51.100.0/24")}
+	wantWithdrawn := []netip.Prefix{netip.MustParsePrefix("2001:db8::/32")}
+	if !cmp.Equal(rm.Announced, wantAnnounced, cmp.Comparer(func(a, b netip.Prefix) bool { return a == b })) ||
+		!cmp.Equal(rm.Withdrawn, wantWithdrawn, cmp.Comparer(func(a, b netip.Prefix) bool { return a == b })) {
+		t.Errorf("Route Monitoring prefixes = %v/%v, want %v/%v", rm.Announced, rm.Withdrawn, wantAnnounced, wantWithdrawn)
+	}
+	if got := msgs[2].Stats[7]; got != 42 {
+		t.Errorf("Statistics Report stat 7 = %d, want 42", got)
+	}
+}
+
+// ipfixMessage returns an IPFIX message with a template for source address,
+// destination address and packet count, and one data record using it.
+func ipfixMessage() []byte {
+	tmpl := []byte{0x01, 0x00, 0, 3, 0, byte(IESourceIPv4Address), 0, 4, 0, byte(IEDestinationIPv4Address), 0, 4, 0, byte(IEPacketDeltaCount), 0, 8}
+	data := append([]byte{198, 51, 100, 1, 203, 0, 113, 1}, binary.BigEndian.AppendUint64(nil, 1000)...)
+	set := func(id uint16, body []byte) []byte {
+		b := binary.BigEndian.AppendUint16(nil, id)
+		b = binary.BigEndian.AppendUint16(b, uint16(4+len(body)))
+		return append(b, body...)
+	}
+	sets := append(set(ipfixTemplateSet, tmpl), set(256, data)...)
+	hdr := binary.BigEndian.AppendUint16(nil, ipfixVersion)
+	hdr = binary.BigEndian.AppendUint16(hdr, uint16(ipfixHeaderLen+len(sets)))
+	hdr = binary.BigEndian.AppendUint32(hdr, 1760860800)
+	hdr = binary.BigEndian.AppendUint32(hdr, 1)
+	hdr = binary.BigEndian.AppendUint32(hdr, 7)
+	return append(hdr, sets...)
+}
+
+func checkIPFIXRecord(t *testing.T, msgs []*IPFIXMessage) {
+	t.Helper()
+	recs := IPFIXRecords(msgs)
+	if len(recs) != 1 {
+		t.Fatalf("got %d IPFIX records, want 1", len(recs))
+	}
+	if src, _ := recs[0].Addr(IESourceIPv4Address); src != netip.MustParseAddr("198.51.100.1") {
+		t.Errorf("sourceIPv4Address = %v, want 198.51.100.1", src)
+	}
+	if n, _ := recs[0].Uint(IEPacketDeltaCount); n != 1000 {
+		t.Errorf("packetDeltaCount = %d, want 1000", n)
+	}
+}
+
+func TestIPFIXCollector(t *testing.T) {
+	c, err := ListenIPFIX("127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("ListenIPFIX() failed: %v", err)
+	}
+	defer c.Close()
+	conn, err := net.Dial("udp", c.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	if _, err := conn.Write(ipfixMessage()); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	msg, err := c.Messages.Await(ctx, func(m *IPFIXMessage) bool { return len(m.Records) > 0 })
+	if err != nil {
+		t.Fatalf("Await() failed: %v (errors: %v)", err, c.Errors.All())
+	}
+	if msg.ObservationDomainID != 7 {
+		t.Errorf("ObservationDomainID = %d, want 7", msg.ObservationDomainID)
+	}
+	checkIPFIXRecord(t, []*IPFIXMessage{msg})
+}
+
+func TestFromPCAP(t *testing.T) {
+	var capture bytes.Buffer
+	w := pcapgo.NewWriter(&capture)
+	if err := w.WriteFileHeader(65536, layers.LinkTypeEthernet); err != nil {
+		t.Fatal(err)
+	}
+	src, dst := net.ParseIP("192.0.2.1").To4(), net.ParseIP("192.0.2.2").To4()
+	write := func(transport gopacket.SerializableLayer, payload []byte) {
+		t.Helper()
+		ip := &layers.IPv4{Version: 4, TTL: 64, SrcIP: src, DstIP: dst}
+		switch l := transport.(type) {
+		case *layers.UDP:
+			ip.Protocol = layers.IPProtocolUDP
+			l.SetNetworkLayerForChecksum(ip)
+		case *layers.TCP:
+			ip.Protocol = layers.IPProtocolTCP
+			l.SetNetworkLayerForChecksum(ip)
+		}
+		buf := gopacket.NewSerializeBuffer()
+		eth := &layers.Ethernet{SrcMAC: net.HardwareAddr{2, 0, 0, 0, 0, 1}, DstMAC: net.HardwareAddr{2, 0, 0, 0, 0, 2}, EthernetType: layers.EthernetTypeIPv4}
+		if err := gopacket.SerializeLayers(buf, gopacket.SerializeOptions{FixLengths: true, ComputeChecksums: true}, eth, ip, transport, gopacket.Payload(payload)); err != nil {
+			t.Fatal(err)
+		}
+		if err := w.WritePacket(gopacket.CaptureInfo{Timestamp: time.Now(), CaptureLength: len(buf.Bytes()), Length: len(buf.Bytes())}, buf.Bytes()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write(&layers.UDP{SrcPort: 50000, DstPort: SyslogPort}, []byte("<187>1 - dut app - - - over UDP"))
+	write(&layers.UDP{SrcPort: 50001, DstPort: IPFIXPort}, ipfixMessage())
+	// A BMP session split across segments, with a retransmission.
+	bmp := bmpMessage(BMPInitiation, []byte{0, BMPInfoSysName, 0, 3, 'd', 'u', 't'})
+	write(&layers.TCP{SrcPort: 50002, DstPort: BMPPort, Seq: 1000, PSH: true, ACK: true, Window: 1024}, bmp[:5])
+	write(&layers.TCP{SrcPort: 50002, DstPort: BMPPort, Seq: 1000, PSH: true, ACK: true, Window: 1024}, bmp[:5])
+	write(&layers.TCP{SrcPort: 50002, DstPort: BMPPort, Seq: 1005, PSH: true, ACK: true, Window: 1024}, bmp[5:])
+
+	logs, err := SyslogFromPCAP(capture.Bytes())
+	if err != nil {
+		t.Fatalf("SyslogFromPCAP() failed: %v", err)
+	}
+	if len(logs) != 1 || logs[0].Message != "over UDP" || !logs[0].Source.Equal(src) {
+		t.Errorf("SyslogFromPCAP() = %v, want one message from %v", logs, src)
+	}
+
+	msgs, err := IPFIXFromPCAP(capture.Bytes())
+	if err != nil {
+		t.Fatalf("IPFIXFromPCAP() failed: %v", err)
+	}
+	checkIPFIXRecord(t, msgs)
+
+	bmps, err := BMPFromPCAP(capture.Bytes())
+	if err != nil {
+		t.Fatalf("BMPFromPCAP() failed: %v", err)
+	}
+	if len(bmps) != 1 || bmps[0].Info[BMPInfoSysName] != "dut" {
+		t.Errorf("BMPFromPCAP() = %v, want one Initiation from dut", bmps)
+	}
+}
@@ -0,0 +1,330 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collectors
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"sync"
+	"time"
+)
+
+// Common IPFIX information elements from the IANA registry.
+const (
+	IEOctetDeltaCount          uint16 = 1
+	IEPacketDeltaCount         uint16 = 2
+	IEProtocolIdentifier       uint16 = 4
+	IEIPClassOfService         uint16 = 5
+	IESourceTransportPort      uint16 = 7
+	IESourceIPv4Address        uint16 = 8
+	IEIngressInterface         uint16 = 10
+	IEDestinationTransportPort uint16 = 11
+	IEDestinationIPv4Address   uint16 = 12
+	IEEgressInterface          uint16 = 14
+	IESourceIPv6Address        uint16 = 27
+	IEDestinationIPv6Address   uint16 = 28
+	IEObservationDomainID      uint16 = 149
+	IEFlowStartMilliseconds    uint16 = 152
+	IEFlowEndMilliseconds      uint16 = 153
+	IESelectorAlgorithm        uint16 = 304
+	IESamplingPacketInterval   uint16 = 305
+	IESamplingPacketSpace      uint16 = 306
+)
+
+const (
+	ipfixVersion       = 10
+	ipfixHeaderLen     = 16
+	ipfixTemplateSet   = 2
+	ipfixOptionsSet    = 3
+	ipfixMinDataSetID  = 256
+	ipfixVarLen        = 65535
+	ipfixEnterpriseBit = 0x8000
+)
+
+// IPFIXField is a field specifier of a template.
+type IPFIXField struct {
+	ID           uint16
+	Length       uint16
+	EnterpriseID uint32
+}
+
+// IPFIXTemplate is a template or options template.
+type IPFIXTemplate struct {
+	ID         uint16
+	ScopeCount int
+	Fields     []IPFIXField
+}
+
+// IPFIXRecord is a data record decoded with its template.  Values are keyed
+// by information element; enterprise-specific elements are not included.
+type IPFIXRecord struct {
+	TemplateID uint16
+	// Options is set for records described by an options template.
+	Options bool
+	Values  map[uint16][]byte
+}
+
+// Uint returns the value of an unsigned integer information element.
+func (r *IPFIXRecord) Uint(ie uint16) (uint64, bool) {
+	v, ok := r.Values[ie]
+	if !ok || len(v) == 0 || len(v) > 8 {
+		return 0, false
+	}
+	var n uint64
+	for _, b := range v {
+		n = n<<8 | uint64(b)
+	}
+	return n, true
+}
+
+// Addr returns the value of an IPv4 or IPv6 address information element.
+func (r *IPFIXRecord) Addr(ie uint16) (netip.Addr, bool) {
+	return netip.AddrFromSlice(r.Values[ie])
+}
+
+// IPFIXMessage is a decoded IPFIX message.
+type IPFIXMessage struct {
+	ExportTime          time.Time
+	SequenceNumber      uint32
+	ObservationDomainID uint32
+	// Templates are the templates defined by this message.
+	Templates []*IPFIXTemplate
+	// Records are the data records that could be decoded.
+	Records []*IPFIXRecord
+	// Unknown counts data sets whose template has not been received.
+	Unknown int
+	// Source is the address of the exporter, if known.
+	Source   net.IP
+	Received time.Time
+}
+
+// IPFIXDecoder decodes IPFIX messages, remembering templates per exporter
+// and observation domain.
+type IPFIXDecoder struct {
+	mu        sync.Mutex
+	templates map[string]*IPFIXTemplate
+}
+
+// NewIPFIXDecoder returns a decoder with no templates.
+func NewIPFIXDecoder() *IPFIXDecoder {
+	return &IPFIXDecoder{templates: map[string]*IPFIXTemplate{}}
+}
+
+func templateKey(source net.IP, domain uint32, id uint16) string {
+	return fmt.Sprintf("%s/%d/%d", source, domain, id)
+}
+
+// Decode decodes one IPFIX message from source, which may be nil.
+func (d *IPFIXDecoder) Decode(b []byte, source net.IP) (*IPFIXMessage, error) {
+	if len(b) < ipfixHeaderLen {
+		return nil, errors.New("IPFIX message too short")
+	}
+	if v := binary.BigEndian.Uint16(b[0:2]); v != ipfixVersion {
+		return nil, fmt.Errorf("unsupported IPFIX version %d", v)
+	}
+	n := int(binary.BigEndian.Uint16(b[2:4]))
+	if n < ipfixHeaderLen || n > len(b) {
+		return nil, fmt.Errorf("invalid IPFIX message length %d", n)
+	}
+	m := &IPFIXMessage{
+		ExportTime:          time.Unix(int64(binary.BigEndian.Uint32(b[4:8])), 0),
+		SequenceNumber:      binary.BigEndian.Uint32(b[8:12]),
+		ObservationDomainID: binary.BigEndian.Uint32(b[12:16]),
+		Source:              source,
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	sets := b[ipfixHeaderLen:n]
+	for len(sets) > 0 {
+		if len(sets) < 4 {
+			return nil, errors.New("truncated IPFIX set header")
+		}
+		id, slen := binary.BigEndian.Uint16(sets[0:2]), int(binary.BigEndian.Uint16(sets[2:4]))
+		if slen < 4 || slen > len(sets) {
+			return nil, fmt.Errorf("invalid IPFIX set length %d", slen)
+		}
+		body := sets[4:slen]
+		sets = sets[slen:]
+		switch {
+		case id == ipfixTemplateSet || id == ipfixOptionsSet:
+			ts, err := parseTemplates(body, id == ipfixOptionsSet)
+			if err != nil {
+				return nil, err
+			}
+			for _, t := range ts {
+				d.templates[templateKey(source, m.ObservationDomainID, t.ID)] = t
+			}
+			m.Templates = append(m.Templates, ts...)
+		case id >= ipfixMinDataSetID:
+			t, ok := d.templates[templateKey(source, m.ObservationDomainID, id)]
+			if !ok {
+				m.Unknown++
+				continue
+			}
+			recs, err := parseRecords(body, t)
+			if err != nil {
+				return nil, fmt.Errorf("data set %d: %w", id, err)
+			}
+			m.Records = append(m.Records, recs...)
+		}
+	}
+	return m, nil
+}
+
+func parseTemplates(b []byte, options bool) ([]*IPFIXTemplate, error) {
+	var ts []*IPFIXTemplate
+	// Sets may be padded with zeros to a 4-byte boundary.
+	for len(b) >= 4 {
+		t := &IPFIXTemplate{ID: binary.BigEndian.Uint16(b[0:2])}
+		count := int(binary.BigEndian.Uint16(b[2:4]))
+		b = b[4:]
+		if options {
+			if len(b) < 2 {
+				return nil, errors.New("truncated options template")
+			}
+			t.ScopeCount = int(binary.BigEndian.Uint16(b[0:2]))
+			b = b[2:]
+		}
+		if t.ID < ipfixMinDataSetID {
+			break
+		}
+		for i := 0; i < count; i++ {
+			if len(b) < 4 {
+				return nil, fmt.Errorf("template %d: truncated field specifier", t.ID)
+			}
+			f := IPFIXField{ID: binary.BigEndian.Uint16(b[0:2]), Length: binary.BigEndian.Uint16(b[2:4])}
+			b = b[4:]
+			if f.ID&ipfixEnterpriseBit != 0 {
+				if len(b) < 4 {
+					return nil, fmt.Errorf("template %d: truncated enterprise number", t.ID)
+				}
+				f.ID &^= ipfixEnterpriseBit
+				f.EnterpriseID = binary.BigEndian.Uint32(b[0:4])
+				b = b[4:]
+			}
+			t.Fields = append(t.Fields, f)
+		}
+		ts = append(ts, t)
+	}
+	return ts, nil
+}
+
+func parseRecords(b []byte, t *IPFIXTemplate) ([]*IPFIXRecord, error) {
+	minLen := 0
+	for _, f := range t.Fields {
+		if f.Length == ipfixVarLen {
+			minLen++
+		} else {
+			minLen += int(f.Length)
+		}
+	}
+	if minLen == 0 {
+		return nil, nil
+	}
+	var recs []*IPFIXRecord
+	// Padding is shorter than the shortest record.
+	for len(b) >= minLen {
+		r := &IPFIXRecord{TemplateID: t.ID, Options: t.ScopeCount > 0, Values: map[uint16][]byte{}}
+		for _, f := range t.Fields {
+			n := int(f.Length)
+			if f.Length == ipfixVarLen {
+				if len(b) < 1 {
+					return nil, errors.New("truncated variable-length field")
+				}
+				n, b = int(b[0]), b[1:]
+				if n == 255 {
+					if len(b) < 2 {
+						return nil, errors.New("truncated variable-length field")
+					}
+					n, b = int(binary.BigEndian.Uint16(b[0:2])), b[2:]
+				}
+			}
+			if len(b) < n {
+				return nil, fmt.Errorf("truncated field %d", f.ID)
+			}
+			if f.EnterpriseID == 0 {
+				r.Values[f.ID] = b[:n]
+			}
+			b = b[n:]
+		}
+		recs = append(recs, r)
+	}
+	return recs, nil
+}
+
+// IPFIXCollector receives IPFIX messages over UDP.
+type IPFIXCollector struct {
+	// Messages are the messages received.
+	Messages *Store[*IPFIXMessage]
+	// Errors are the messages that could not be decoded.
+	Errors *Store[error]
+
+	conn    net.PacketConn
+	decoder *IPFIXDecoder
+}
+
+// ListenIPFIX starts an IPFIX collector on a UDP address, such as ":4739".
+func ListenIPFIX(addr string) (*IPFIXCollector, error) {
+	conn, err := net.ListenPacket("udp", addr)
+	if err != nil {
+		return nil, err
+	}
+	c := &IPFIXCollector{
+		Messages: NewStore[*IPFIXMessage](),
+		Errors:   NewStore[error](),
+		conn:     conn,
+		decoder:  NewIPFIXDecoder(),
+	}
+	go func() {
+		buf := make([]byte, 65535)
+		for {
+			n, from, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			m, err := c.decoder.Decode(bytes.Clone(buf[:n]), addrIP(from))
+			if err != nil {
+				c.Errors.Add(fmt.Errorf("message from %v: %w", from, err))
+				continue
+			}
+			m.Received = time.Now()
+			c.Messages.Add(m)
+		}
+	}()
+	return c, nil
+}
+
+// Addr returns the address the collector listens on.
+func (c *IPFIXCollector) Addr() net.Addr {
+	return c.conn.LocalAddr()
+}
+
+// Close stops the collector.
+func (c *IPFIXCollector) Close() error {
+	return c.conn.Close()
+}
+
+// IPFIXRecords returns the data records of all messages.
+func IPFIXRecords(msgs []*IPFIXMessage) []*IPFIXRecord {
+	var recs []*IPFIXRecord
+	for _, m := range msgs {
+		recs = append(recs, m.Records...)
+	}
+	return recs
+}
@@ -0,0 +1,228 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collectors
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/google/gopacket/pcapgo"
+)
+
+// Well-known collector ports, used by the FromPCAP functions when no ports
+// are given.
+const (
+	SyslogPort    = 514
+	SyslogTCPPort = 601
+	BMPPort       = 11019
+	IPFIXPort     = 4739
+)
+
+// capturedPacket is a transport payload from a capture.
+type capturedPacket struct {
+	time    time.Time
+	src     net.IP
+	dst     net.IP
+	srcPort uint16
+	dstPort uint16
+	tcp     *layers.TCP
+	payload []byte
+}
+
+// readCapture returns the UDP or TCP payloads of the packets in a pcap or
+// pcapng capture whose destination port is one of ports.
+func readCapture(capture []byte, ports []uint16) ([]*capturedPacket, error) {
+	var (
+		src      gopacket.PacketDataSource
+		linkType layers.LinkType
+	)
+	if r, err := pcapgo.NewReader(bytes.NewReader(capture)); err == nil {
+		src, linkType = r, r.LinkType()
+	} else if ng, ngErr := pcapgo.NewNgReader(bytes.NewReader(capture), pcapgo.DefaultNgReaderOptions); ngErr == nil {
+		src, linkType = ng, ng.LinkType()
+	} else {
+		return nil, fmt.Errorf("capture is neither pcap (%v) nor pcapng (%v)", err, ngErr)
+	}
+	var pkts []*capturedPacket
+	for i := 0; ; i++ {
+		data, ci, err := src.ReadPacketData()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("packet %d: %w", i, err)
+		}
+		pkt := gopacket.NewPacket(data, linkType, gopacket.Default)
+		cp := &capturedPacket{time: ci.Timestamp}
+		switch ip := pkt.NetworkLayer().(type) {
+		case *layers.IPv4:
+			cp.src, cp.dst = ip.SrcIP, ip.DstIP
+		case *layers.IPv6:
+			cp.src, cp.dst = ip.SrcIP, ip.DstIP
+		default:
+			continue
+		}
+		switch l := pkt.TransportLayer().(type) {
+		case *layers.UDP:
+			cp.srcPort, cp.dstPort, cp.payload = uint16(l.SrcPort), uint16(l.DstPort), l.Payload
+		case *layers.TCP:
+			cp.srcPort, cp.dstPort, cp.payload, cp.tcp = uint16(l.SrcPort), uint16(l.DstPort), l.Payload, l
+		default:
+			continue
+		}
+		if slices.Contains(ports, cp.dstPort) {
+			pkts = append(pkts, cp)
+		}
+	}
+	return pkts, nil
+}
+
+// tcpStreams reassembles the TCP payloads of each connection in capture
+// order, dropping retransmitted segments.
+func tcpStreams(pkts []*capturedPacket) []*capturedPacket {
+	type stream struct {
+		first *capturedPacket
+		segs  map[uint32][]byte
+	}
+	streams := map[string]*stream{}
+	var keys []string
+	for _, p := range pkts {
+		if p.tcp == nil || len(p.payload) == 0 {
+			continue
+		}
+		key := fmt.Sprintf("%s:%d-%s:%d", p.src, p.srcPort, p.dst, p.dstPort)
+		s, ok := streams[key]
+		if !ok {
+			s = &stream{first: p, segs: map[uint32][]byte{}}
+			streams[key] = s
+			keys = append(keys, key)
+		}
+		if _, dup := s.segs[p.tcp.Seq]; !dup {
+			s.segs[p.tcp.Seq] = p.payload
+		}
+	}
+	var out []*capturedPacket
+	for _, key := range keys {
+		s := streams[key]
+		seqs := make([]uint32, 0, len(s.segs))
+		for seq := range s.segs {
+			seqs = append(seqs, seq)
+		}
+		// Sequence numbers relative to the first segment handle wraparound.
+		base := s.first.tcp.Seq
+		sort.Slice(seqs, func(i, j int) bool { return seqs[i]-base < seqs[j]-base })
+		var buf bytes.Buffer
+		var end uint32 // Relative to base.
+		for _, seq := range seqs {
+			seg, off := s.segs[seq], seq-base
+			if off+uint32(len(seg)) <= end {
+				continue // Retransmitted.
+			}
+			if off < end {
+				seg = seg[end-off:]
+			}
+			buf.Write(seg)
+			end = off + uint32(len(s.segs[seq]))
+		}
+		c := *s.first
+		c.payload = buf.Bytes()
+		out = append(out, &c)
+	}
+	return out
+}
+
+// SyslogFromPCAP decodes the syslog messages in a capture, sent over UDP or
+// TCP to one of ports, which defaults to SyslogPort and SyslogTCPPort.
+func SyslogFromPCAP(capture []byte, ports ...uint16) ([]*SyslogMessage, error) {
+	if len(ports) == 0 {
+		ports = []uint16{SyslogPort, SyslogTCPPort}
+	}
+	pkts, err := readCapture(capture, ports)
+	if err != nil {
+		return nil, err
+	}
+	c := newSyslogCollector(nil, nil)
+	for _, p := range pkts {
+		if p.tcp == nil {
+			c.add(p.payload, &net.UDPAddr{IP: p.src, Port: int(p.srcPort)})
+		}
+	}
+	for _, s := range tcpStreams(pkts) {
+		c.readStream(bytes.NewReader(s.payload), &net.TCPAddr{IP: s.src, Port: int(s.srcPort)})
+	}
+	if errs := c.Errors.All(); len(errs) > 0 {
+		return c.Messages.All(), errors.Join(errs...)
+	}
+	return c.Messages.All(), nil
+}
+
+// BMPFromPCAP decodes the BMP messages in a capture of sessions to one of
+// ports, which defaults to BMPPort.
+func BMPFromPCAP(capture []byte, ports ...uint16) ([]*BMPMessage, error) {
+	if len(ports) == 0 {
+		ports = []uint16{BMPPort}
+	}
+	pkts, err := readCapture(capture, ports)
+	if err != nil {
+		return nil, err
+	}
+	var msgs []*BMPMessage
+	for _, s := range tcpStreams(pkts) {
+		r := bytes.NewReader(s.payload)
+		for r.Len() > 0 {
+			m, err := ReadBMP(r)
+			if err != nil {
+				return msgs, fmt.Errorf("BMP session from %v: %w", s.src, err)
+			}
+			m.Source, m.Received = s.src, s.time
+			msgs = append(msgs, m)
+		}
+	}
+	return msgs, nil
+}
+
+// IPFIXFromPCAP decodes the IPFIX messages in a capture, sent over UDP to
+// one of ports, which defaults to IPFIXPort.
+func IPFIXFromPCAP(capture []byte, ports ...uint16) ([]*IPFIXMessage, error) {
+	if len(ports) == 0 {
+		ports = []uint16{IPFIXPort}
+	}
+	pkts, err := readCapture(capture, ports)
+	if err != nil {
+		return nil, err
+	}
+	d := NewIPFIXDecoder()
+	var msgs []*IPFIXMessage
+	for _, p := range pkts {
+		if p.tcp != nil {
+			continue
+		}
+		m, err := d.Decode(p.payload, p.src)
+		if err != nil {
+			return msgs, fmt.Errorf("message from %v: %w", p.src, err)
+		}
+		m.Received = p.time
+		msgs = append(msgs, m)
+	}
+	return msgs, nil
+}
@@ -0,0 +1,133 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package collectors runs in-process syslog, BMP and IPFIX collectors for
+// management-plane export tests, and decodes the same messages from pcap
+// captures.
+//
+// Each collector keeps what it receives in a Store that can be queried and
+// awaited:
+//
+//	c, err := collectors.ListenSyslogUDP(":514")
+//	...
+//	defer c.Close()
+//	filter := collectors.SyslogFilter{Host: "dut"}.
+//	  WithFacility(collectors.FacilityLocal7).
+//	  WithMinSeverity(collectors.SeverityCritical)
+//	msg, err := c.Messages.Await(ctx, filter.Match)
+//
+// The collectors only need a reachable address, so they work when the test
+// runs as a process in a KNE or container topology.  Where the DUT can only
+// reach an ATE port, capture on the port and use SyslogFromPCAP,
+// BMPFromPCAP or IPFIXFromPCAP instead.
+package collectors
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// Store is a concurrency-safe, append-only list of received items.
+type Store[T any] struct {
+	mu      sync.Mutex
+	items   []T
+	changed chan struct{}
+}
+
+// NewStore returns an empty store.
+func NewStore[T any]() *Store[T] {
+	return &Store[T]{changed: make(chan struct{})}
+}
+
+// Add appends items and wakes up waiters.
+func (s *Store[T]) Add(items ...T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.items = append(s.items, items...)
+	close(s.changed)
+	s.changed = make(chan struct{})
+}
+
+// All returns a copy of the items received so far.
+func (s *Store[T]) All() []T {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.items)
+}
+
+// Len returns the number of items received so far.
+func (s *Store[T]) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.items)
+}
+
+// Find returns the items matching match.
+func (s *Store[T]) Find(match func(T) bool) []T {
+	var found []T
+	for _, item := range s.All() {
+		if match(item) {
+			found = append(found, item)
+		}
+	}
+	return found
+}
+
+// Reset discards all items.
+func (s *Store[T]) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.items = nil
+}
+
+// Await returns the first item matching match, waiting for one to arrive
+// until ctx is done.
+func (s *Store[T]) Await(ctx context.Context, match func(T) bool) (T, error) {
+	found, err := s.AwaitN(ctx, 1, match)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return found[0], nil
+}
+
+// AwaitN waits until at least n items match and returns all matching items.
+func (s *Store[T]) AwaitN(ctx context.Context, n int, match func(T) bool) ([]T, error) {
+	seen := 0
+	var found []T
+	for {
+		s.mu.Lock()
+		if seen > len(s.items) { // Reset was called.
+			seen = 0
+		}
+		items, changed := s.items[seen:], s.changed
+		seen = len(s.items)
+		s.mu.Unlock()
+		for _, item := range items {
+			if match(item) {
+				found = append(found, item)
+			}
+		}
+		if len(found) >= n {
+			return found, nil
+		}
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return found, fmt.Errorf("got %d of %d matching items: %w", len(found), n, ctx.Err())
+		}
+	}
+}
@@ -0,0 +1,423 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collectors
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Facility is a syslog facility code.
+type Facility int
+
+// Syslog facilities.
+const (
+	FacilityKernel Facility = iota
+	FacilityUser
+	FacilityMail
+	FacilityDaemon
+	FacilityAuth
+	FacilitySyslog
+	FacilityLPR
+	FacilityNews
+	FacilityUUCP
+	FacilityCron
+	FacilityAuthPriv
+	FacilityFTP
+	FacilityNTP
+	FacilityAudit
+	FacilityAlert
+	FacilityClock
+	FacilityLocal0
+	FacilityLocal1
+	FacilityLocal2
+	FacilityLocal3
+	FacilityLocal4
+	FacilityLocal5
+	FacilityLocal6
+	FacilityLocal7
+)
+
+// Severity is a syslog severity.  Lower values are more severe.
+type Severity int
+
+// Syslog severities.
+const (
+	SeverityEmergency Severity = iota
+	SeverityAlert
+	SeverityCritical
+	SeverityError
+	SeverityWarning
+	SeverityNotice
+	SeverityInformational
+	SeverityDebug
+)
+
+// AtLeast reports whether s is at least as severe as min.
+func (s Severity) AtLeast(min Severity) bool {
+	return s <= min
+}
+
+// SyslogFormat is the format a syslog message was parsed as.
+type SyslogFormat string
+
+// Syslog formats.
+const (
+	RFC5424 SyslogFormat = "RFC5424"
+	RFC3164 SyslogFormat = "RFC3164"
+)
+
+// SyslogMessage is a parsed syslog message.
+type SyslogMessage struct {
+	Format    SyslogFormat
+	Facility  Facility
+	Severity  Severity
+	Timestamp time.Time
+	Hostname  string
+	AppName   string
+	ProcID    string
+	MsgID     string
+	// StructuredData is the unparsed RFC 5424 structured data, or "-".
+	StructuredData string
+	Message        string
+	// Source is the address the message was received from, if known.
+	Source   net.IP
+	Received time.Time
+	Raw      []byte
+}
+
+func (m *SyslogMessage) String() string {
+	return fmt.Sprintf("<%d.%d> %s %s %s: %s", m.Facility, m.Severity, m.Timestamp.Format(time.RFC3339), m.Hostname, m.AppName, m.Message)
+}
+
+// ParseSyslog parses an RFC 5424 or RFC 3164 message.  RFC 3164 is a
+// best-effort format, so only the priority is required.
+func ParseSyslog(b []byte) (*SyslogMessage, error) {
+	s := strings.TrimRight(string(b), "\r\n\x00")
+	if !strings.HasPrefix(s, "<") {
+		return nil, errors.New("syslog message does not start with a priority")
+	}
+	end := strings.IndexByte(s, '>')
+	if end < 2 || end > 4 {
+		return nil, fmt.Errorf("invalid syslog priority in %q", s)
+	}
+	pri, err := strconv.Atoi(s[1:end])
+	if err != nil || pri > 191 {
+		return nil, fmt.Errorf("invalid syslog priority %q", s[1:end])
+	}
+	m := &SyslogMessage{
+		Facility: Facility(pri / 8),
+		Severity: Severity(pri % 8),
+		Raw:      b,
+	}
+	rest := s[end+1:]
+	if strings.HasPrefix(rest, "1 ") {
+		return m, parse5424(m, rest[2:])
+	}
+	parse3164(m, rest)
+	return m, nil
+}
+
+// nilValue is the RFC 5424 NILVALUE.
+const nilValue = "-"
+
+func parse5424(m *SyslogMessage, s string) error {
+	m.Format = RFC5424
+	fields := strings.SplitN(s, " ", 6)
+	if len(fields) < 6 {
+		return fmt.Errorf("RFC 5424 message has %d header fields, want 6", len(fields))
+	}
+	if fields[0] != nilValue {
+		ts, err := time.Parse(time.RFC3339Nano, fields[0])
+		if err != nil {
+			return fmt.Errorf("invalid RFC 5424 timestamp: %w", err)
+		}
+		m.Timestamp = ts
+	}
+	unnil := func(f string) string {
+		if f == nilValue {
+			return ""
+		}
+		return f
+	}
+	m.Hostname, m.AppName, m.ProcID, m.MsgID = unnil(fields[1]), unnil(fields[2]), unnil(fields[3]), unnil(fields[4])
+
+	rest := fields[5]
+	switch {
+	case strings.HasPrefix(rest, nilValue):
+		m.StructuredData, rest = nilValue, rest[1:]
+	case strings.HasPrefix(rest, "["):
+		n, err := structuredDataLen(rest)
+		if err != nil {
+			return err
+		}
+		m.StructuredData, rest = rest[:n], rest[n:]
+	default:
+		return fmt.Errorf("invalid RFC 5424 structured data %q", rest)
+	}
+	m.Message = strings.TrimPrefix(strings.TrimPrefix(rest, " "), "\ufeff")
+	return nil
+}
+
+// structuredDataLen returns the length of the SD-ELEMENTs at the start of s.
+func structuredDataLen(s string) (int, error) {
+	i := 0
+	for i < len(s) && s[i] == '[' {
+		end := sdElementEnd(s[i:])
+		if end < 0 {
+			return 0, errors.New("unterminated RFC 5424 structured data")
+		}
+		i += end + 1
+	}
+	return i, nil
+}
+
+// sdElementEnd returns the index of the "]" closing the SD-ELEMENT at the
+// start of s, or -1.
+func sdElementEnd(s string) int {
+	inQuote := false
+	for i := 1; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '\\' && inQuote:
+			i++
+		case c == '"':
+			inQuote = !inQuote
+		case c == ']' && !inQuote:
+			return i
+		}
+	}
+	return -1
+}
+
+func parse3164(m *SyslogMessage, s string) {
+	m.Format = RFC3164
+	// TIMESTAMP is "Mmm dd hh:mm:ss" and has no year.
+	if len(s) >= 16 && s[15] == ' ' {
+		if ts, err := time.Parse(time.Stamp, s[:15]); err == nil {
+			now := time.Now()
+			m.Timestamp = ts.AddDate(now.Year(), 0, 0)
+			s = s[16:]
+			if host, rest, ok := strings.Cut(s, " "); ok {
+				m.Hostname, s = host, rest
+			}
+		}
+	}
+	// TAG is an alphanumeric name, optionally followed by "[pid]", then ":".
+	if tag, rest, ok := strings.Cut(s, ":"); ok && !strings.ContainsAny(tag, " \t") && tag != "" {
+		if i := strings.IndexByte(tag, '['); i > 0 && strings.HasSuffix(tag, "]") {
+			m.ProcID = tag[i+1 : len(tag)-1]
+			tag = tag[:i]
+		}
+		m.AppName, s = tag, strings.TrimPrefix(rest, " ")
+	}
+	m.Message = s
+}
+
+// SyslogFilter matches syslog messages.  Zero fields match any message;
+// use WithFacility and WithMinSeverity to also match on priority.
+type SyslogFilter struct {
+	// Host matches the hostname or the source address.
+	Host     string
+	AppName  string
+	Contains string
+
+	facility    *Facility
+	minSeverity *Severity
+}
+
+// WithFacility returns a copy of f that only matches messages with facility
+// fac.
+func (f SyslogFilter) WithFacility(fac Facility) SyslogFilter {
+	f.facility = &fac
+	return f
+}
+
+// WithMinSeverity returns a copy of f that only matches messages at least as
+// severe as sev.
+func (f SyslogFilter) WithMinSeverity(sev Severity) SyslogFilter {
+	f.minSeverity = &sev
+	return f
+}
+
+// Match reports whether m matches the filter.
+func (f SyslogFilter) Match(m *SyslogMessage) bool {
+	switch {
+	case f.facility != nil && m.Facility != *f.facility:
+		return false
+	case f.minSeverity != nil && !m.Severity.AtLeast(*f.minSeverity):
+		return false
+	case f.Host != "" && m.Hostname != f.Host && (m.Source == nil || m.Source.String() != f.Host):
+		return false
+	case f.AppName != "" && m.AppName != f.AppName:
+		return false
+	case f.Contains != "" && !strings.Contains(m.Message, f.Contains):
+		return false
+	}
+	return true
+}
+
+// SyslogCollector receives syslog messages.
+type SyslogCollector struct {
+	// Messages are the messages received.
+	Messages *Store[*SyslogMessage]
+	// Errors are the messages that could not be parsed.
+	Errors *Store[error]
+
+	addr   net.Addr
+	closer io.Closer
+}
+
+// ListenSyslogUDP starts a syslog collector on a UDP address, such as ":514".
+func ListenSyslogUDP(addr string) (*SyslogCollector, error) {
+	conn, err := net.ListenPacket("udp", addr)
+	if err != nil {
+		return nil, err
+	}
+	c := newSyslogCollector(conn.LocalAddr(), conn)
+	go func() {
+		buf := make([]byte, 65535)
+		for {
+			n, from, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			c.add(bytes.Clone(buf[:n]), from)
+		}
+	}()
+	return c, nil
+}
+
+// ListenSyslogTCP starts a syslog collector on a TCP address, such as
+// ":601".  Both octet-counted and newline-delimited framing are accepted.
+func ListenSyslogTCP(addr string) (*SyslogCollector, error) {
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	return serveSyslogStream(l), nil
+}
+
+// ListenSyslogTLS starts a syslog collector on a TLS address, such as
+// ":6514", as described in RFC 5425.
+func ListenSyslogTLS(addr string, config *tls.Config) (*SyslogCollector, error) {
+	l, err := tls.Listen("tcp", addr, config)
+	if err != nil {
+		return nil, err
+	}
+	return serveSyslogStream(l), nil
+}
+
+func newSyslogCollector(addr net.Addr, closer io.Closer) *SyslogCollector {
+	return &SyslogCollector{
+		Messages: NewStore[*SyslogMessage](),
+		Errors:   NewStore[error](),
+		addr:     addr,
+		closer:   closer,
+	}
+}
+
+func serveSyslogStream(l net.Listener) *SyslogCollector {
+	c := newSyslogCollector(l.Addr(), l)
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				c.readStream(conn, conn.RemoteAddr())
+			}()
+		}
+	}()
+	return c
+}
+
+// maxSyslogFrameLen is the largest octet-counted frame readStream accepts.
+const maxSyslogFrameLen = 1 << 20
+
+// readStream reads RFC 6587 framed messages until the connection closes or
+// a frame has an invalid length.
+func (c *SyslogCollector) readStream(stream io.Reader, from net.Addr) {
+	r := bufio.NewReader(stream)
+	for {
+		first, err := r.Peek(1)
+		if err != nil {
+			return
+		}
+		var msg []byte
+		if first[0] >= '1' && first[0] <= '9' {
+			lenStr, err := r.ReadString(' ')
+			if err != nil {
+				return
+			}
+			n, err := strconv.Atoi(strings.TrimSpace(lenStr))
+			if err != nil || n > maxSyslogFrameLen {
+				c.Errors.Add(fmt.Errorf("invalid syslog frame length %q from %v", lenStr, from))
+				return
+			}
+			msg = make([]byte, n)
+			if _, err := io.ReadFull(r, msg); err != nil {
+				return
+			}
+		} else {
+			msg, err = r.ReadBytes('\n')
+			if err != nil && len(msg) == 0 {
+				return
+			}
+		}
+		c.add(msg, from)
+	}
+}
+
+func (c *SyslogCollector) add(b []byte, from net.Addr) {
+	m, err := ParseSyslog(b)
+	if err != nil {
+		c.Errors.Add(fmt.Errorf("message from %v: %w", from, err))
+		return
+	}
+	m.Source = addrIP(from)
+	m.Received = time.Now()
+	c.Messages.Add(m)
+}
+
+// Addr returns the address the collector listens on.
+func (c *SyslogCollector) Addr() net.Addr {
+	return c.addr
+}
+
+// Close stops the collector.  Open TCP connections are served until the
+// exporter closes them.
+func (c *SyslogCollector) Close() error {
+	return c.closer.Close()
+}
+
+func addrIP(a net.Addr) net.IP {
+	switch a := a.(type) {
+	case *net.UDPAddr:
+		return a.IP
+	case *net.TCPAddr:
+		return a.IP
+	}
+	return nil
+}
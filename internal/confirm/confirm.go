@@ -12,7 +12,13 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-// Package confirm provides experimental assertion helpers.
+// Package confirm provides assertion helpers that compare intended
+// configuration with the state reported by a device.
+//
+// Verifier is the supported entry point: it takes the oc.Root a test pushed,
+// fetches the corresponding /state leaves and reports every leaf whose state
+// does not reflect the intended config. State compares two structs that the
+// caller has already fetched.
 package confirm
 
 import (
@@ -113,8 +119,7 @@ func ExtractChanges(diff *gnmipb.Notification, want, got ygot.ValidatedGoStruct)
 
 // State checks that every set value in want is present in got. Extra fields in got will be ignored
 // (typically the state contains many more keys than just the ones we're setting).
-//
-// DEPRECATED: experimental function
+// To verify a whole pushed configuration against the device, use Verifier.
 func State(t testing.TB, want, got ygot.ValidatedGoStruct) {
 	t.Helper()
 	diff, err := ygot.Diff(want, got, &ygot.IgnoreAdditions{})
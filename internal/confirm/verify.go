@@ -0,0 +1,415 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package confirm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/netip"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/openconfig/featureprofiles/internal/fptest"
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/gnmi/oc"
+	"github.com/openconfig/ygot/ygot"
+)
+
+// Status is the outcome of comparing one intended leaf with device state.
+type Status string
+
+const (
+	// StatusMatch means the state leaf has the intended value.
+	StatusMatch Status = "match"
+	// StatusMismatch means the state leaf has a different value.
+	StatusMismatch Status = "mismatch"
+	// StatusMissing means the device did not report the state leaf.
+	StatusMissing Status = "missing"
+	// StatusIgnored means the leaf matched an ignore rule.
+	StatusIgnored Status = "ignored"
+)
+
+// Entry is the comparison result for one intended leaf.
+type Entry struct {
+	ConfigPath string `json:"config_path"`
+	StatePath  string `json:"state_path"`
+	Want       string `json:"want"`
+	Got        string `json:"got,omitempty"`
+	Status     Status `json:"status"`
+	Reason     string `json:"reason,omitempty"`
+}
+
+// Report is the result of verifying an intended configuration against state.
+type Report struct {
+	Entries []*Entry `json:"entries"`
+}
+
+// Failures returns the entries that are mismatched or missing.
+func (r *Report) Failures() []*Entry {
+	var failed []*Entry
+	for _, e := range r.Entries {
+		if e.Status == StatusMismatch || e.Status == StatusMissing {
+			failed = append(failed, e)
+		}
+	}
+	return failed
+}
+
+// Summary returns counts of entries per status.
+func (r *Report) Summary() string {
+	counts := map[Status]int{}
+	for _, e := range r.Entries {
+		counts[e.Status]++
+	}
+	return fmt.Sprintf("%d leaves: %d match, %d mismatch, %d missing, %d ignored",
+		len(r.Entries), counts[StatusMatch], counts[StatusMismatch], counts[StatusMissing], counts[StatusIgnored])
+}
+
+// JSON renders the report as indented JSON.
+func (r *Report) JSON() ([]byte, error) {
+	return json.MarshalIndent(r, "", "  ")
+}
+
+// rule is a path pattern used by ignore and tolerance rules.
+type rule struct {
+	pattern *gnmipb.Path
+	reason  string
+	delta   float64
+}
+
+// Verifier compares an intended configuration with the corresponding state
+// reported by a device. The zero value compares every leaf exactly.
+type Verifier struct {
+	ignores    []rule
+	tolerances []rule
+}
+
+// NewVerifier returns a Verifier with no ignore or tolerance rules.
+func NewVerifier() *Verifier {
+	return &Verifier{}
+}
+
+// parseRule parses a path pattern such as "/interfaces/interface/config/mtu"
+// or "/network-instances/network-instance[name=*]/protocols". Patterns may
+// use config or state paths and match every leaf at or below them.
+func parseRule(path string) rule {
+	p, err := ygot.StringToStructuredPath(path)
+	if err != nil {
+		p = &gnmipb.Path{}
+		for _, name := range strings.Split(strings.Trim(path, "/"), "/") {
+			p.Elem = append(p.Elem, &gnmipb.PathElem{Name: name})
+		}
+	}
+	for _, e := range p.GetElem() {
+		if e.GetName() == "config" {
+			e.Name = "state"
+		}
+	}
+	return rule{pattern: p}
+}
+
+// Ignore skips every leaf at or below path.
+func (v *Verifier) Ignore(path, reason string) *Verifier {
+	r := parseRule(path)
+	r.reason = reason
+	v.ignores = append(v.ignores, r)
+	return v
+}
+
+// IgnoreIf skips every leaf at or below path when cond is true. It is
+// intended to be keyed by a deviation, e.g.
+//
+//	v.IgnoreIf(deviations.IPv4MissingEnabled(dut), "/interfaces/interface/subinterfaces/subinterface/ipv4/config/enabled", "IPv4MissingEnabled")
+func (v *Verifier) IgnoreIf(cond bool, path, reason string) *Verifier {
+	if cond {
+		v.Ignore(path, reason)
+	}
+	return v
+}
+
+// Tolerate accepts numeric leaves at or below path whose state value is
+// within delta of the intended value.
+func (v *Verifier) Tolerate(path string, delta float64) *Verifier {
+	r := parseRule(path)
+	r.delta = delta
+	v.tolerances = append(v.tolerances, r)
+	return v
+}
+
+// matches reports whether leaf is at or below the rule's pattern.
+func (r rule) matches(leaf *gnmipb.Path) bool {
+	pe, le := r.pattern.GetElem(), leaf.GetElem()
+	if len(pe) > len(le) {
+		return false
+	}
+	for i, p := range pe {
+		if p.GetName() != "*" && p.GetName() != le[i].GetName() {
+			return false
+		}
+		for k, want := range p.GetKey() {
+			if want != "*" && le[i].GetKey()[k] != want {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// intendedLeaves returns the state paths and values of every config leaf set
+// in want, sorted by path.
+func intendedLeaves(want *oc.Root) ([]*gnmipb.Update, error) {
+	notifs, err := ygot.TogNMINotifications(want, 0, ygot.GNMINotificationsConfig{UsePathElem: true})
+	if err != nil {
+		return nil, fmt.Errorf("cannot render intended config: %w", err)
+	}
+	var leaves []*gnmipb.Update
+	for _, n := range notifs {
+		for _, u := range n.GetUpdate() {
+			p := &gnmipb.Path{Elem: append(append([]*gnmipb.PathElem{}, n.GetPrefix().GetElem()...), u.GetPath().GetElem()...)}
+			// Only leaves in a state container have a config counterpart;
+			// list keys outside it are covered by their config/state leaf.
+			if len(p.Elem) < 2 || p.Elem[len(p.Elem)-2].GetName() != "state" {
+				continue
+			}
+			leaves = append(leaves, &gnmipb.Update{Path: p, Val: u.GetVal()})
+		}
+	}
+	sort.Slice(leaves, func(i, j int) bool { return PathLabel(leaves[i].Path) < PathLabel(leaves[j].Path) })
+	return leaves, nil
+}
+
+// configPath returns the config path corresponding to the state leaf p.
+func configPath(p *gnmipb.Path) *gnmipb.Path {
+	c := &gnmipb.Path{}
+	for i, e := range p.GetElem() {
+		ce := &gnmipb.PathElem{Name: e.GetName(), Key: e.GetKey()}
+		if i == len(p.GetElem())-2 && ce.Name == "state" {
+			ce.Name = "config"
+		}
+		c.Elem = append(c.Elem, ce)
+	}
+	return c
+}
+
+// Compare compares every config leaf set in want with the state leaves in
+// got, which is typically the result of a ONCE subscription.
+func (v *Verifier) Compare(want *oc.Root, got []*gnmipb.Notification) (*Report, error) {
+	leaves, err := intendedLeaves(want)
+	if err != nil {
+		return nil, err
+	}
+	state := map[string]*gnmipb.TypedValue{}
+	for _, n := range got {
+		for _, u := range n.GetUpdate() {
+			p := &gnmipb.Path{Elem: append(append([]*gnmipb.PathElem{}, n.GetPrefix().GetElem()...), u.GetPath().GetElem()...)}
+			state[PathLabel(p)] = u.GetVal()
+		}
+	}
+	report := &Report{}
+	for _, leaf := range leaves {
+		e := &Entry{
+			ConfigPath: PathLabel(configPath(leaf.Path)),
+			StatePath:  PathLabel(leaf.Path),
+			Want:       formatTypedValue(leaf.Val),
+		}
+		report.Entries = append(report.Entries, e)
+		if r, ok := v.firstMatch(v.ignores, leaf.Path); ok {
+			e.Status, e.Reason = StatusIgnored, r.reason
+			continue
+		}
+		gotVal, ok := state[e.StatePath]
+		if !ok {
+			e.Status = StatusMissing
+			continue
+		}
+		e.Got = formatTypedValue(gotVal)
+		delta := 0.0
+		if r, ok := v.firstMatch(v.tolerances, leaf.Path); ok {
+			delta = r.delta
+		}
+		if valuesEqual(leaf.Val, gotVal, delta) {
+			e.Status = StatusMatch
+		} else {
+			e.Status = StatusMismatch
+		}
+	}
+	return report, nil
+}
+
+func (v *Verifier) firstMatch(rules []rule, p *gnmipb.Path) (rule, bool) {
+	for _, r := range rules {
+		if r.matches(p) {
+			return r, true
+		}
+	}
+	return rule{}, false
+}
+
+// Fetch subscribes once to the state containers of every config leaf set in
+// want and returns the notifications received.
+func Fetch(ctx context.Context, client gnmipb.GNMIClient, target string, want *oc.Root) ([]*gnmipb.Notification, error) {
+	leaves, err := intendedLeaves(want)
+	if err != nil {
+		return nil, err
+	}
+	seen := map[string]bool{}
+	var subs []*gnmipb.Subscription
+	for _, leaf := range leaves {
+		parent := &gnmipb.Path{Elem: leaf.Path.GetElem()[:len(leaf.Path.GetElem())-1]}
+		if label := PathLabel(parent); !seen[label] {
+			seen[label] = true
+			subs = append(subs, &gnmipb.Subscription{Path: parent})
+		}
+	}
+	if len(subs) == 0 {
+		return nil, nil
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	sub, err := client.Subscribe(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := sub.Send(&gnmipb.SubscribeRequest{
+		Request: &gnmipb.SubscribeRequest_Subscribe{
+			Subscribe: &gnmipb.SubscriptionList{
+				Prefix:       &gnmipb.Path{Origin: "openconfig", Target: target},
+				Subscription: subs,
+				Mode:         gnmipb.SubscriptionList_ONCE,
+				Encoding:     gnmipb.Encoding_PROTO,
+			},
+		},
+	}); err != nil {
+		return nil, err
+	}
+	var notifs []*gnmipb.Notification
+	for {
+		resp, err := sub.Recv()
+		if errors.Is(err, io.EOF) || resp.GetSyncResponse() {
+			return notifs, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if n := resp.GetUpdate(); n != nil {
+			notifs = append(notifs, n)
+		}
+	}
+}
+
+// Verify checks that every config leaf set in want is reflected in the DUT's
+// state. It fetches the state with a single subscription, reports each
+// mismatched or missing leaf as a test error, writes the full report as JSON
+// to the test outputs directory, and returns it.
+func (v *Verifier) Verify(t testing.TB, dut *ondatra.DUTDevice, want *oc.Root) *Report {
+	t.Helper()
+	got, err := Fetch(context.Background(), dut.RawAPIs().GNMI(t), dut.Name(), want)
+	if err != nil {
+		t.Fatalf("Cannot fetch state from %s: %v", dut.Name(), err)
+	}
+	report, err := v.Compare(want, got)
+	if err != nil {
+		t.Fatalf("Cannot compare intended config with state: %v", err)
+	}
+	if js, err := report.JSON(); err != nil {
+		t.Errorf("Cannot render verify report: %v", err)
+	} else if _, err := fptest.WriteOutput("confirm_"+t.Name(), ".json", string(js)); err != nil {
+		t.Errorf("Cannot write verify report: %v", err)
+	}
+	t.Logf("Intent vs state for %s: %s", dut.Name(), report.Summary())
+	for _, e := range report.Failures() {
+		if e.Status == StatusMissing {
+			t.Errorf("%s: got no state at %s, want %s", e.ConfigPath, e.StatePath, e.Want)
+		} else {
+			t.Errorf("%s: got %s, want %s", e.ConfigPath, e.Got, e.Want)
+		}
+	}
+	return report
+}
+
+// identityRE matches identityref values qualified with their module name.
+var identityRE = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_.-]*:([A-Za-z][A-Za-z0-9_-]*)$`)
+
+// scalar returns a normalized string form of tv, and its numeric value if it
+// has one.
+func scalar(tv *gnmipb.TypedValue) (string, float64, bool) {
+	switch v := tv.GetValue().(type) {
+	case *gnmipb.TypedValue_StringVal:
+		s := v.StringVal
+		if m := identityRE.FindStringSubmatch(s); m != nil {
+			return m[1], 0, false
+		}
+		if a, err := netip.ParseAddr(s); err == nil {
+			return a.String(), 0, false
+		}
+		if p, err := netip.ParsePrefix(s); err == nil {
+			return p.String(), 0, false
+		}
+		return s, 0, false
+	case *gnmipb.TypedValue_IntVal:
+		return strconv.FormatInt(v.IntVal, 10), float64(v.IntVal), true
+	case *gnmipb.TypedValue_UintVal:
+		return strconv.FormatUint(v.UintVal, 10), float64(v.UintVal), true
+	case *gnmipb.TypedValue_DoubleVal:
+		return strconv.FormatFloat(v.DoubleVal, 'g', -1, 64), v.DoubleVal, true
+	case *gnmipb.TypedValue_FloatVal:
+		return strconv.FormatFloat(float64(v.FloatVal), 'g', -1, 32), float64(v.FloatVal), true
+	case *gnmipb.TypedValue_DecimalVal:
+		f := float64(v.DecimalVal.GetDigits()) / math.Pow10(int(v.DecimalVal.GetPrecision()))
+		return strconv.FormatFloat(f, 'g', -1, 64), f, true
+	case *gnmipb.TypedValue_BoolVal:
+		return strconv.FormatBool(v.BoolVal), 0, false
+	case *gnmipb.TypedValue_LeaflistVal:
+		var elems []string
+		for _, e := range v.LeaflistVal.GetElement() {
+			s, _, _ := scalar(e)
+			elems = append(elems, s)
+		}
+		sort.Strings(elems)
+		return "[" + strings.Join(elems, ", ") + "]", 0, false
+	}
+	return tv.String(), 0, false
+}
+
+func formatTypedValue(tv *gnmipb.TypedValue) string {
+	s, _, _ := scalar(tv)
+	return s
+}
+
+// valuesEqual compares two leaf values after normalizing encoding
+// differences: module prefixes on identities, integer signedness, address
+// formatting and leaf-list order. Numeric values may differ by up to delta.
+func valuesEqual(want, got *gnmipb.TypedValue, delta float64) bool {
+	ws, wn, wNum := scalar(want)
+	gs, gn, gNum := scalar(got)
+	if wNum && gNum {
+		if delta > 0 {
+			return math.Abs(wn-gn) <= delta
+		}
+		if ws == gs {
+			return true
+		}
+		return wn == gn
+	}
+	return ws == gs
+}
@@ -0,0 +1,131 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package confirm
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+	"github.com/openconfig/ondatra/gnmi/oc"
+	"github.com/openconfig/ygot/ygot"
+)
+
+func stateUpdate(t *testing.T, path string, val *gnmipb.TypedValue) *gnmipb.Update {
+	t.Helper()
+	p, err := ygot.StringToStructuredPath(path)
+	if err != nil {
+		t.Fatalf("StringToStructuredPath(%q) failed: %v", path, err)
+	}
+	return &gnmipb.Update{Path: p, Val: val}
+}
+
+func TestCompare(t *testing.T) {
+	want := &oc.Root{}
+	intf := want.GetOrCreateInterface("Ethernet1")
+	intf.Description = ygot.String("uplink")
+	intf.Mtu = ygot.Uint16(9000)
+	intf.Type = oc.IETFInterfaces_InterfaceType_ethernetCsmacd
+	intf.Enabled = ygot.Bool(true)
+	ipv6 := intf.GetOrCreateSubinterface(0).GetOrCreateIpv6()
+	ipv6.GetOrCreateAddress("2001:DB8::1").PrefixLength = ygot.Uint8(64)
+
+	got := []*gnmipb.Notification{{
+		Update: []*gnmipb.Update{
+			stateUpdate(t, "/interfaces/interface[name=Ethernet1]/state/description", &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: "downlink"}}),
+			stateUpdate(t, "/interfaces/interface[name=Ethernet1]/state/mtu", &gnmipb.TypedValue{Value: &gnmipb.TypedValue_IntVal{IntVal: 8998}}),
+			stateUpdate(t, "/interfaces/interface[name=Ethernet1]/state/type", &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: "iana-if-type:ethernetCsmacd"}}),
+			stateUpdate(t, "/interfaces/interface[name=Ethernet1]/subinterfaces/subinterface[index=0]/ipv6/addresses/address[ip=2001:DB8::1]/state/prefix-length", &gnmipb.TypedValue{Value: &gnmipb.TypedValue_UintVal{UintVal: 64}}),
+		},
+	}}
+
+	v := NewVerifier().
+		Tolerate("/interfaces/interface/config/mtu", 2).
+		IgnoreIf(true, "/interfaces/interface/subinterfaces", "deviation").
+		IgnoreIf(false, "/interfaces/interface/config/description", "not applied")
+	report, err := v.Compare(want, got)
+	if err != nil {
+		t.Fatalf("Compare() failed: %v", err)
+	}
+	gotStatus := map[string]Status{}
+	for _, e := range report.Entries {
+		gotStatus[e.ConfigPath] = e.Status
+	}
+	wantStatus := map[string]Status{
+		"/interfaces/interface[name=Ethernet1]/config/description":                                                                              StatusMismatch,
+		"/interfaces/interface[name=Ethernet1]/config/enabled":                                                                                  StatusMissing,
+		"/interfaces/interface[name=Ethernet1]/config/mtu":                                                                                      StatusMatch,
+		"/interfaces/interface[name=Ethernet1]/config/name":                                                                                     StatusMissing,
+		"/interfaces/interface[name=Ethernet1]/config/type":                                                                                     StatusMatch,
+		"/interfaces/interface[name=Ethernet1]/subinterfaces/subinterface[index=0]/config/index":                                                StatusIgnored,
+		"/interfaces/interface[name=Ethernet1]/subinterfaces/subinterface[index=0]/ipv6/addresses/address[ip=2001:DB8::1]/config/ip":            StatusIgnored,
+		"/interfaces/interface[name=Ethernet1]/subinterfaces/subinterface[index=0]/ipv6/addresses/address[ip=2001:DB8::1]/config/prefix-length": StatusIgnored,
+	}
+	if diff := cmp.Diff(wantStatus, gotStatus); diff != "" {
+		t.Errorf("Compare() statuses differ (-want +got):\n%s", diff)
+	}
+	if got, want := len(report.Failures()), 3; got != want {
+		t.Errorf("Failures() returned %d entries, want %d", got, want)
+	}
+}
+
+func TestValuesEqual(t *testing.T) {
+	str := func(s string) *gnmipb.TypedValue {
+		return &gnmipb.TypedValue{Value: &gnmipb.TypedValue_StringVal{StringVal: s}}
+	}
+	tests := []struct {
+		desc      string
+		want, got *gnmipb.TypedValue
+		delta     float64
+		wantEqual bool
+	}{{
+		desc:      "identity with module prefix",
+		want:      str("openconfig-policy-types:BGP"),
+		got:       str("BGP"),
+		wantEqual: true,
+	}, {
+		desc:      "IPv6 case",
+		want:      str("2001:DB8::1"),
+		got:       str("2001:db8::1"),
+		wantEqual: true,
+	}, {
+		desc:      "int and uint",
+		want:      &gnmipb.TypedValue{Value: &gnmipb.TypedValue_UintVal{UintVal: 5}},
+		got:       &gnmipb.TypedValue{Value: &gnmipb.TypedValue_IntVal{IntVal: 5}},
+		wantEqual: true,
+	}, {
+		desc:      "outside tolerance",
+		want:      &gnmipb.TypedValue{Value: &gnmipb.TypedValue_UintVal{UintVal: 100}},
+		got:       &gnmipb.TypedValue{Value: &gnmipb.TypedValue_UintVal{UintVal: 90}},
+		delta:     5,
+		wantEqual: false,
+	}, {
+		desc: "leaf-list order",
+		want: &gnmipb.TypedValue{Value: &gnmipb.TypedValue_LeaflistVal{LeaflistVal: &gnmipb.ScalarArray{
+			Element: []*gnmipb.TypedValue{str("a"), str("b")},
+		}}},
+		got: &gnmipb.TypedValue{Value: &gnmipb.TypedValue_LeaflistVal{LeaflistVal: &gnmipb.ScalarArray{
+			Element: []*gnmipb.TypedValue{str("b"), str("a")},
+		}}},
+		wantEqual: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if got := valuesEqual(tt.want, tt.got, tt.delta); got != tt.wantEqual {
+				t.Errorf("valuesEqual(%v, %v, %v) = %v, want %v", tt.want, tt.got, tt.delta, got, tt.wantEqual)
+			}
+		})
+	}
+}
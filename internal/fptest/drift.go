@@ -0,0 +1,291 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fptest
+
+import (
+	"flag"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/gnmi/oc"
+	"github.com/openconfig/ygot/ygot"
+	"google.golang.org/protobuf/encoding/prototext"
+)
+
+var checkConfigDrift = flag.Bool("check_config_drift", false, "Compare the DUT config at the end of each test that calls fptest.CheckConfigDrift with the config at its start, and fail on changes outside the allowed paths.")
+
+// DriftKind describes how a config leaf changed.
+type DriftKind string
+
+const (
+	// DriftAdded is a leaf that was not present before the test.
+	DriftAdded DriftKind = "added"
+	// DriftRemoved is a leaf that was present before the test but not after.
+	DriftRemoved DriftKind = "removed"
+	// DriftChanged is a leaf whose value changed during the test.
+	DriftChanged DriftKind = "changed"
+)
+
+// Drift is a config leaf that differs between two snapshots.
+type Drift struct {
+	Path     string
+	Kind     DriftKind
+	Category string
+	// Entity is the list entry owning the leaf, e.g. the VRF, policy
+	// definition, ACL set or static route, which is usually what leaked.
+	Entity string
+	Before string
+	After  string
+}
+
+func (d *Drift) String() string {
+	switch d.Kind {
+	case DriftAdded:
+		return fmt.Sprintf("%s %s = %s", d.Kind, d.Path, d.After)
+	case DriftRemoved:
+		return fmt.Sprintf("%s %s (was %s)", d.Kind, d.Path, d.Before)
+	}
+	return fmt.Sprintf("%s %s: %s -> %s", d.Kind, d.Path, d.Before, d.After)
+}
+
+// CheckConfigDrift snapshots the DUT config and, when t finishes, verifies
+// that the config only changed under the allowed paths.  It should be called
+// at the start of a top-level test:
+//
+//	func TestFoo(t *testing.T) {
+//	  dut := ondatra.DUT(t, "dut")
+//	  fptest.CheckConfigDrift(t, dut, "/network-instances/network-instance[name=DEFAULT]/protocols", "/interfaces")
+//	  ...
+//	}
+//
+// Allowed paths are config paths whose list keys may be omitted or "*" to
+// match any entry.  Any other added, removed or changed leaf is reported as
+// a leftover, grouped by the entity that leaked, and the full list is written
+// to --outputs_dir.  The check is a no-op unless --check_config_drift is set.
+func CheckConfigDrift(t testing.TB, dut *ondatra.DUTDevice, allowed ...string) {
+	t.Helper()
+	if !*checkConfigDrift {
+		return
+	}
+	// GetDeviceConfig prunes the fields devices report differently from what
+	// was pushed, which would otherwise show up as drift.
+	before := GetDeviceConfig(t, dut)
+	t.Cleanup(func() {
+		after := GetDeviceConfig(t, dut)
+		drifts, err := ConfigDrift(before, after, allowed...)
+		if err != nil {
+			t.Errorf("Cannot compare %s config: %v", dut.Name(), err)
+			return
+		}
+		if len(drifts) == 0 {
+			return
+		}
+		var sb strings.Builder
+		for _, d := range drifts {
+			fmt.Fprintln(&sb, d)
+		}
+		if _, err := WriteOutput("config_drift_"+t.Name(), ".txt", sb.String()); err != nil {
+			t.Errorf("Cannot write %s config drift: %v", dut.Name(), err)
+		}
+		for _, entity := range driftEntities(drifts) {
+			t.Errorf("%s config drift: %s", dut.Name(), entity)
+		}
+	})
+}
+
+// ConfigDrift returns the config leaves that differ between before and after
+// and are not under one of the allowed paths, sorted by path.
+func ConfigDrift(before, after *oc.Root, allowed ...string) ([]*Drift, error) {
+	var patterns []*gpb.Path
+	for _, a := range allowed {
+		p, err := ygot.StringToStructuredPath(a)
+		if err != nil {
+			return nil, fmt.Errorf("invalid allowed path %q: %w", a, err)
+		}
+		patterns = append(patterns, p)
+	}
+	beforeLeaves, err := configLeaves(before)
+	if err != nil {
+		return nil, err
+	}
+	afterLeaves, err := configLeaves(after)
+	if err != nil {
+		return nil, err
+	}
+
+	var drifts []*Drift
+	add := func(l *leaf, kind DriftKind, before, after string) {
+		for _, pat := range patterns {
+			if pathHasPrefix(l.path, pat) {
+				return
+			}
+		}
+		category, entity := driftEntity(l.path)
+		drifts = append(drifts, &Drift{
+			Path:     l.name,
+			Kind:     kind,
+			Category: category,
+			Entity:   entity,
+			Before:   before,
+			After:    after,
+		})
+	}
+	for name, b := range beforeLeaves {
+		a, ok := afterLeaves[name]
+		switch {
+		case !ok:
+			add(b, DriftRemoved, b.value, "")
+		case a.value != b.value:
+			add(b, DriftChanged, b.value, a.value)
+		}
+	}
+	for name, a := range afterLeaves {
+		if _, ok := beforeLeaves[name]; !ok {
+			add(a, DriftAdded, "", a.value)
+		}
+	}
+	sort.Slice(drifts, func(i, j int) bool { return drifts[i].Path < drifts[j].Path })
+	return drifts, nil
+}
+
+type leaf struct {
+	name  string
+	path  *gpb.Path
+	value string
+}
+
+// configLeaves flattens a config tree into its leaves keyed by config path.
+func configLeaves(root *oc.Root) (map[string]*leaf, error) {
+	leaves := map[string]*leaf{}
+	if root == nil {
+		return leaves, nil
+	}
+	notifs, err := ygot.TogNMINotifications(root, 0, ygot.GNMINotificationsConfig{UsePathElem: true})
+	if err != nil {
+		return nil, fmt.Errorf("cannot render config: %w", err)
+	}
+	for _, n := range notifs {
+		for _, u := range n.GetUpdate() {
+			elems := append(append([]*gpb.PathElem{}, n.GetPrefix().GetElem()...), u.GetPath().GetElem()...)
+			// List keys outside the config container are duplicates of the
+			// leaves inside it.
+			if len(elems) < 2 || elems[len(elems)-2].GetName() != "state" && elems[len(elems)-2].GetName() != "config" {
+				continue
+			}
+			p := &gpb.Path{}
+			for _, e := range elems {
+				ce := &gpb.PathElem{Name: e.GetName(), Key: e.GetKey()}
+				if ce.Name == "state" {
+					ce.Name = "config"
+				}
+				p.Elem = append(p.Elem, ce)
+			}
+			name, err := ygot.PathToString(p)
+			if err != nil {
+				return nil, err
+			}
+			leaves[name] = &leaf{name: name, path: p, value: prototext.MarshalOptions{}.Format(u.GetVal())}
+		}
+	}
+	return leaves, nil
+}
+
+// pathHasPrefix reports whether p is at or below prefix.  A prefix element
+// matches any list entry unless it names a key, and "state" matches "config".
+func pathHasPrefix(p, prefix *gpb.Path) bool {
+	if len(prefix.GetElem()) > len(p.GetElem()) {
+		return false
+	}
+	for i, pe := range prefix.GetElem() {
+		e := p.GetElem()[i]
+		name := pe.GetName()
+		if name == "state" {
+			name = "config"
+		}
+		if name != e.GetName() {
+			return false
+		}
+		for k, v := range pe.GetKey() {
+			if v != "*" && e.GetKey()[k] != v {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// driftEntity classifies a leaf and returns the list entry that owns it:
+// the static route for static routes, otherwise the outermost list entry,
+// such as the VRF, policy definition or ACL set.
+func driftEntity(p *gpb.Path) (category, entity string) {
+	elems := p.GetElem()
+	if len(elems) == 0 {
+		return "", "/"
+	}
+	category = strings.TrimSuffix(elems[0].GetName(), "s")
+	end := -1
+	for i, e := range elems {
+		if e.GetName() == "static" {
+			category, end = "static-route", i
+			break
+		}
+		if end < 0 && len(e.GetKey()) > 0 {
+			end = i
+		}
+	}
+	if end < 0 {
+		end = len(elems) - 1
+	}
+	s, err := ygot.PathToString(&gpb.Path{Elem: elems[:end+1]})
+	if err != nil {
+		s = prototext.MarshalOptions{}.Format(p)
+	}
+	return category, s
+}
+
+// driftEntities summarizes drifts as one line per leaked entity.
+func driftEntities(drifts []*Drift) []string {
+	type summary struct {
+		category string
+		kinds    map[DriftKind]int
+	}
+	byEntity := map[string]*summary{}
+	var order []string
+	for _, d := range drifts {
+		s, ok := byEntity[d.Entity]
+		if !ok {
+			s = &summary{category: d.Category, kinds: map[DriftKind]int{}}
+			byEntity[d.Entity] = s
+			order = append(order, d.Entity)
+		}
+		s.kinds[d.Kind]++
+	}
+	var lines []string
+	for _, entity := range order {
+		s := byEntity[entity]
+		var counts []string
+		for _, k := range []DriftKind{DriftAdded, DriftRemoved, DriftChanged} {
+			if n := s.kinds[k]; n > 0 {
+				counts = append(counts, fmt.Sprintf("%d %s", n, k))
+			}
+		}
+		lines = append(lines, fmt.Sprintf("%s %s: %s leaves", s.category, entity, strings.Join(counts, ", ")))
+	}
+	return lines
+}
@@ -0,0 +1,72 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fptest
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/ondatra/gnmi/oc"
+	"github.com/openconfig/ygot/ygot"
+)
+
+func TestConfigDrift(t *testing.T) {
+	before := &oc.Root{}
+	before.GetOrCreateInterface("port1").Description = ygot.String("to ATE")
+	before.GetOrCreateNetworkInstance("DEFAULT").Type = oc.NetworkInstanceTypes_NETWORK_INSTANCE_TYPE_DEFAULT_INSTANCE
+
+	after := &oc.Root{}
+	after.GetOrCreateInterface("port1").Description = ygot.String("changed")
+	after.GetOrCreateNetworkInstance("DEFAULT").Type = oc.NetworkInstanceTypes_NETWORK_INSTANCE_TYPE_DEFAULT_INSTANCE
+	after.GetOrCreateNetworkInstance("VRF-A").Type = oc.NetworkInstanceTypes_NETWORK_INSTANCE_TYPE_L3VRF
+	static := after.GetOrCreateNetworkInstance("DEFAULT").
+		GetOrCreateProtocol(oc.PolicyTypes_INSTALL_PROTOCOL_TYPE_STATIC, "STATIC").
+		GetOrCreateStatic("192.0.2.0/24")
+	static.GetOrCreateNextHop("0").NextHop = oc.UnionString("198.51.100.1")
+	after.GetOrCreateRoutingPolicy().GetOrCreatePolicyDefinition("ALLOW")
+
+	tests := []struct {
+		desc    string
+		allowed []string
+		want    []string
+	}{{
+		desc: "nothing allowed",
+		want: []string{
+			"interface /interfaces/interface[name=port1]: 1 changed leaves",
+			"network-instance /network-instances/network-instance[name=DEFAULT]: 2 added leaves",
+			"static-route /network-instances/network-instance[name=DEFAULT]/protocols/protocol[identifier=STATIC][name=STATIC]/static-routes/static[prefix=192.0.2.0/24]: 3 added leaves",
+			"network-instance /network-instances/network-instance[name=VRF-A]: 2 added leaves",
+			"routing-policy /routing-policy/policy-definitions/policy-definition[name=ALLOW]: 1 added leaves",
+		},
+	}, {
+		desc:    "allowed subtrees",
+		allowed: []string{"/interfaces/interface/config/description", "/network-instances/network-instance[name=*]/protocols", "/routing-policy"},
+		want: []string{
+			"network-instance /network-instances/network-instance[name=VRF-A]: 2 added leaves",
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			drifts, err := ConfigDrift(before, after, tt.allowed...)
+			if err != nil {
+				t.Fatalf("ConfigDrift() failed: %v", err)
+			}
+			got := driftEntities(drifts)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("ConfigDrift() entities differ (-want +got):\n%s\ndrifts: %v", diff, drifts)
+			}
+		})
+	}
+}
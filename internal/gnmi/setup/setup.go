@@ -20,12 +20,13 @@ package setup
 import (
 	"fmt"
 	"net"
+	"net/netip"
 	"strconv"
-	"strings"
 	"testing"
 	"time"
 
 	"github.com/open-traffic-generator/snappi/gosnappi"
+	"github.com/openconfig/featureprofiles/internal/allocator"
 	"github.com/openconfig/featureprofiles/internal/attrs"
 	"github.com/openconfig/featureprofiles/internal/deviations"
 	"github.com/openconfig/featureprofiles/internal/fptest"
@@ -58,7 +59,8 @@ const (
 	dutAreaAddress        = "49.0001"
 	dutSysID              = "1920.0000.2001"
 	dutStartIPAddr        = "192.0.2.1"
-	ateStartIPAddr        = "192.0.2.2"
+	portIPv4Block         = "192.0.0.0/16"
+	portIPv4Start         = "192.0.2.0"
 	plenIPv4              = 30
 	authPassword          = "ISISAuthPassword"
 	advertiseISISRoutesv4 = "198.18.0.0"
@@ -76,45 +78,37 @@ var (
 
 // buildPortIPs generates ip addresses for the ports in binding file.
 // (Both DUT and ATE ports).
-func buildPortIPs(dut *ondatra.DUTDevice) {
-	var dutIPIndex, ipSubnet, ateIPIndex int = 1, 2, 2
-	var endSubnetIndex = 253
+func buildPortIPs(t *testing.T, dut *ondatra.DUTDevice) {
+	t.Helper()
+	links, err := allocator.NewRegistry().NewSubnetPool("port-links", portIPv4Block, plenIPv4)
+	if err != nil {
+		t.Fatalf("Cannot create port subnet pool: %v", err)
+	}
+	// Port links start at portIPv4Start, as they did before the allocator.
+	if err := links.StartAt(netip.PrefixFrom(netip.MustParseAddr(portIPv4Start), plenIPv4)); err != nil {
+		t.Fatalf("Cannot start port subnet pool at %s: %v", portIPv4Start, err)
+	}
 	for _, dp := range dut.Ports() {
-		dutNextIP := nextIP(net.ParseIP(dutStartIPAddr), dutIPIndex, ipSubnet)
-		ateNextIP := nextIP(net.ParseIP(ateStartIPAddr), ateIPIndex, ipSubnet)
-		DUTIPList[dp.ID()] = dutNextIP
-		ATEIPList[dp.ID()] = ateNextIP
-
-		// Increment DUT and ATE host ip index by 4.
-		dutIPIndex = dutIPIndex + 4
-		ateIPIndex = ateIPIndex + 4
-
-		// Reset DUT and ATE ip indexes when it is greater than endSubnetIndex.
-		if dutIPIndex > int(endSubnetIndex) {
-			ipSubnet = ipSubnet + 1
-			dutIPIndex = 1
-			ateIPIndex = 2
+		link, err := links.Next()
+		if err != nil {
+			t.Fatalf("Cannot allocate subnet for port %s: %v", dp.ID(), err)
 		}
+		dutIP, ateIP, err := allocator.LinkAddrs(link)
+		if err != nil {
+			t.Fatalf("Cannot allocate addresses for port %s: %v", dp.ID(), err)
+		}
+		DUTIPList[dp.ID()] = net.IP(dutIP.AsSlice())
+		ATEIPList[dp.ID()] = net.IP(ateIP.AsSlice())
 	}
 }
 
-// nextIP returns ip address based on hostIndex and subnetIndex provided.
-func nextIP(ip net.IP, hostIndex int, subnetIndex int) net.IP {
-	s := ip.String()
-	sa := strings.Split(s, ".")
-	sa[2] = strconv.Itoa(subnetIndex)
-	sa[3] = strconv.Itoa(hostIndex)
-	s = strings.Join(sa, ".")
-	return net.ParseIP(s)
-}
-
 // BuildBenchmarkingConfig builds required configuration for DUT interfaces, ISIS and BGP.
 func BuildBenchmarkingConfig(t *testing.T) *oc.Root {
 	dut := ondatra.DUT(t, "dut")
 	d := &oc.Root{}
 
 	// Generate ip addresses to configure DUT and ATE ports.
-	buildPortIPs(dut)
+	buildPortIPs(t, dut)
 
 	// Network instance and BGP configs.
 	netInstance := d.GetOrCreateNetworkInstance(deviations.DefaultNetworkInstance(dut))
@@ -16,13 +16,12 @@
 package iputil
 
 import (
-	"encoding/binary"
 	"fmt"
-	"math"
-	"math/big"
 	"net"
 	"net/netip"
 	"strings"
+
+	"github.com/openconfig/featureprofiles/internal/allocator"
 )
 
 // GenerateIPs creates list of n IPs using ipBlock
@@ -32,20 +31,39 @@ func GenerateIPs(ipBlock string, n int) []string {
 	if err != nil {
 		return entries
 	}
-	netMask := binary.BigEndian.Uint32(netCIDR.Mask)
-	firstIP := binary.BigEndian.Uint32(netCIDR.IP)
-	lastIP := (firstIP & netMask) | (netMask ^ 0xffffffff)
-
-	for i := firstIP; i <= lastIP && n > 0; i++ {
-		ip := make(net.IP, 4)
-		binary.BigEndian.PutUint32(ip, i)
-		entries = append(entries, fmt.Sprint(ip))
-		n--
+	block, ok := netipx(netCIDR)
+	if !ok {
+		return entries
+	}
+	for i := 0; i < n; i++ {
+		ip, ok := allocator.AddrStep(block.Addr(), one(block.Addr()), uint64(i))
+		if !ok || !block.Contains(ip) {
+			break
+		}
+		entries = append(entries, ip.String())
 	}
 
 	return entries
 }
 
+// netipx converts a net.IPNet, as returned by net.ParseCIDR, to a prefix.
+func netipx(n *net.IPNet) (netip.Prefix, bool) {
+	a, ok := netip.AddrFromSlice(n.IP)
+	if !ok {
+		return netip.Prefix{}, false
+	}
+	bits, _ := n.Mask.Size()
+	return netip.PrefixFrom(a.Unmap(), bits), true
+}
+
+// one returns the step that increments an address of a's family by one.
+func one(a netip.Addr) netip.Addr {
+	if a.Is4() {
+		return netip.AddrFrom4([4]byte{0, 0, 0, 1})
+	}
+	return netip.IPv6Loopback()
+}
+
 // GenerateIPsWithStep creates a list of IPv4 addresses.
 // Returns a slice of IPv4 address strings or an error if inputs are invalid.
 func GenerateIPsWithStep(startIP string, count int, stepIP string) ([]string, error) {
@@ -56,55 +74,43 @@ func GenerateIPsWithStep(startIP string, count int, stepIP string) ([]string, er
 		return []string{}, nil
 	}
 
-	ip := net.ParseIP(startIP).To4()
-	if ip == nil {
+	ip, err := netip.ParseAddr(startIP)
+	if ip = ip.Unmap(); err != nil || !ip.Is4() {
 		return nil, fmt.Errorf("invalid startIP")
 	}
-	step := net.ParseIP(stepIP).To4()
-	if step == nil {
+	step, err := netip.ParseAddr(stepIP)
+	if step = step.Unmap(); err != nil || !step.Is4() {
 		return nil, fmt.Errorf("invalid stepIP")
 	}
 
-	start := binary.BigEndian.Uint32(ip)
-	stepVal := binary.BigEndian.Uint32(step)
-
 	// --- New overflow checks ---
-	if stepVal == 0 {
+	if step.IsUnspecified() {
 		return nil, fmt.Errorf("invalid stepIP: step is zero")
 	}
 	// Step overflow check (first increment already too large)
-	if start+stepVal < start {
+	if _, ok := allocator.AddrStep(ip, step, 1); !ok {
 		return nil, fmt.Errorf("step causes overflow")
 	}
 	// Count overflow check (final increment too large)
-	if uint64(start)+uint64(stepVal)*uint64(count-1) > math.MaxUint32 {
+	if _, ok := allocator.AddrStep(ip, step, uint64(count-1)); !ok {
 		return nil, fmt.Errorf("count causes overflow")
 	}
 
 	out := make([]string, 0, count)
 	for i := 0; i < count; i++ {
-		val := start + uint32(i)*stepVal
-		buf := make(net.IP, 4)
-		binary.BigEndian.PutUint32(buf, val)
-		out = append(out, buf.String())
+		next, _ := allocator.AddrStep(ip, step, uint64(i))
+		out = append(out, next.String())
 	}
 	return out, nil
 }
 
-func ipToBigInt(ip net.IP) *big.Int {
-	ip = ip.To16()
-	return big.NewInt(0).SetBytes(ip)
-}
-
-func bigIntToIP(ipInt *big.Int) net.IP {
-	ipBytes := ipInt.Bytes()
-	// Ensure the slice is 16 bytes long
-	if len(ipBytes) < 16 {
-		padded := make([]byte, 16)
-		copy(padded[16-len(ipBytes):], ipBytes)
-		ipBytes = padded
+// parseIPv6 parses s as an IPv6 address that is not an IPv4-mapped address.
+func parseIPv6(s string) (netip.Addr, bool) {
+	ip := net.ParseIP(s)
+	if ip == nil || ip.To4() != nil {
+		return netip.Addr{}, false
 	}
-	return net.IP(ipBytes)
+	return netip.AddrFrom16([16]byte(ip.To16())), true
 }
 
 // GenerateIPv6sWithStep creates a list of IPv6 addresses.
@@ -117,37 +123,30 @@ func GenerateIPv6sWithStep(startIP string, count int, stepIP string) ([]string,
 		return []string{}, nil
 	}
 
-	ip := net.ParseIP(startIP).To16()
-	if ip == nil || ip.To4() != nil {
+	ip, ok := parseIPv6(startIP)
+	if !ok {
 		return nil, fmt.Errorf("invalid start IPv6")
 	}
 
-	step := net.ParseIP(stepIP).To16()
-	if step == nil || step.To4() != nil {
+	step, ok := parseIPv6(stepIP)
+	if !ok {
 		return nil, fmt.Errorf("invalid step IPv6")
 	}
 
-	ipInt := ipToBigInt(ip)
-	stepInt := ipToBigInt(step)
-
-	if stepInt.Sign() == 0 {
+	if step.IsUnspecified() {
 		return nil, fmt.Errorf("invalid step IPv6: step is zero")
 	}
 
-	maxIPv6 := new(big.Int).Lsh(big.NewInt(1), 128) // 2^128
-
 	// --- Overflow check ---
-	lastIPInt := new(big.Int).Add(ipInt, new(big.Int).Mul(stepInt, big.NewInt(int64(count-1))))
-	if lastIPInt.Cmp(maxIPv6) >= 0 {
+	if _, ok := allocator.AddrStep(ip, step, uint64(count-1)); !ok {
 		return nil, fmt.Errorf("overflow IPv6")
 	}
 
 	// Generate sequence
 	ips := make([]string, 0, count)
 	for i := 0; i < count; i++ {
-		newIPInt := new(big.Int).Add(ipInt, new(big.Int).Mul(stepInt, big.NewInt(int64(i))))
-		newIP := bigIntToIP(newIPInt)
-		ips = append(ips, newIP.String())
+		next, _ := allocator.AddrStep(ip, step, uint64(i))
+		ips = append(ips, net.IP(next.AsSlice()).String())
 	}
 
 	return ips, nil
@@ -172,25 +171,15 @@ func GenerateMACs(startMAC string, count int, stepMACStr string) []string {
 		return []string{} // invalid step MAC
 	}
 
-	baseInt := new(big.Int).SetBytes(baseMAC)
-	stepInt := new(big.Int).SetBytes(stepMAC)
-
-	// Maximum MAC value = 2^48 - 1
-	maxMac := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 48), big.NewInt(1))
-
 	// Check final value does not overflow: base + step*(count-1) <= maxMac
-	mul := new(big.Int).Mul(stepInt, big.NewInt(int64(count-1)))
-	final := new(big.Int).Add(baseInt, mul)
-	if final.Cmp(maxMac) > 0 {
+	if _, ok := allocator.MACStep(baseMAC, stepMAC, uint64(count-1)); !ok {
 		return []string{} // overflow → return empty
 	}
 
 	// Generate sequence
 	out := make([]string, 0, count)
 	for i := 0; i < count; i++ {
-		cur := new(big.Int).Add(baseInt, new(big.Int).Mul(stepInt, big.NewInt(int64(i))))
-		buf := cur.FillBytes(make([]byte, 6)) // 6 bytes for MAC
-		hw := net.HardwareAddr(buf)
+		hw, _ := allocator.MACStep(baseMAC, stepMAC, uint64(i))
 		out = append(out, hw.String()) // canonical lower-case hex with colons
 	}
 
@@ -199,21 +188,12 @@ func GenerateMACs(startMAC string, count int, stepMACStr string) []string {
 
 // NextIPMultiSteps returns the next IPv4 or IPv6 address after incrementing the last octet by count times.
 func NextIPMultiSteps(ip net.IP, count int) net.IP {
-	nextIPAddress := ip
-	for i := 0; i < count; i++ {
-		nextIPAddress = func(ip net.IP) net.IP {
-			next := make(net.IP, len(ip))
-			copy(next, ip)
-			for i := len(next) - 1; i >= 0; i-- {
-				next[i]++
-				if next[i] > 0 {
-					break
-				}
-			}
-			return next
-		}(nextIPAddress)
-	}
-	return nextIPAddress
+	a, ok := netip.AddrFromSlice(ip)
+	if !ok || count <= 0 {
+		return ip
+	}
+	next, _ := allocator.AddrStep(a, one(a), uint64(count))
+	return net.IP(next.AsSlice())
 }
 
 // GenerateIPv6s generates a list of consecutive IPv6 addresses starting from a given base IP.
@@ -227,15 +207,11 @@ func GenerateIPv6s(baseIP net.IP, n int) ([]string, error) {
 	if ip == nil || baseIP.To4() != nil {
 		return nil, fmt.Errorf("not a valid IPv6 address")
 	}
-
-	baseInt := new(big.Int).SetBytes(ip)
-	pmax := new(big.Int).Lsh(big.NewInt(1), 128) // 2^128
+	base := netip.AddrFrom16([16]byte(ip))
 
 	for i := 0; i < n; i++ {
-		nextInt := new(big.Int).Add(baseInt, big.NewInt(int64(i)))
-		nextInt.Mod(nextInt, pmax) // wrap around if overflow
-		ipBytes := nextInt.FillBytes(make([]byte, 16))
-		entries = append(entries, net.IP(ipBytes).String())
+		next, _ := allocator.AddrStep(base, one(base), uint64(i)) // wrap around if overflow
+		entries = append(entries, net.IP(next.AsSlice()).String())
 	}
 
 	return entries, nil
@@ -285,13 +261,12 @@ func GenerateIPv6(startIP string, count uint64) ([]string, error) {
 		return nil, fmt.Errorf("expected IPv6 mask, got %d bits", bits)
 	}
 
-	ip := new(big.Int).SetBytes(ipBytes)
-	mask := new(big.Int).SetBytes(netCIDR.Mask)
-	networkIP := new(big.Int).And(ip, mask)
-
-	step := new(big.Int).Lsh(big.NewInt(1), uint(128-maskSize))
-	hostOffset := big.NewInt(1)
-	pmax := new(big.Int).Lsh(big.NewInt(1), 128)
+	network := netip.AddrFrom16([16]byte(ipBytes))
+	// step is 2^(128-maskSize), which is zero for a /0.
+	var step [16]byte
+	if maskSize > 0 {
+		step[(maskSize-1)/8] = 1 << (7 - (maskSize-1)%8)
+	}
 
 	if count == 0 {
 		count = 1
@@ -299,12 +274,9 @@ func GenerateIPv6(startIP string, count uint64) ([]string, error) {
 
 	entries := []string{}
 	for i := uint64(0); i < count; i++ {
-		nextInt := new(big.Int).Mul(new(big.Int).SetUint64(i), step)
-		nextInt.Add(nextInt, networkIP)
-		nextInt.Add(nextInt, hostOffset)
-		nextInt.Mod(nextInt, pmax)
-		ipv6 := nextInt.FillBytes(make([]byte, 16))
-		entries = append(entries, net.IP(ipv6).String())
+		next, _ := allocator.AddrStep(network, netip.AddrFrom16(step), i)
+		next, _ = allocator.AddrStep(next, one(next), 1)
+		entries = append(entries, net.IP(next.AsSlice()).String())
 	}
 	return entries, nil
 }
@@ -0,0 +1,171 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package isislsdb
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/gnmi"
+	"github.com/openconfig/ondatra/gnmi/oc"
+	"github.com/openconfig/ygnmi/ygnmi"
+)
+
+// maxReported is the number of differences of each kind Diff.String lists.
+const maxReported = 20
+
+// Diff is what a graph lacks compared to the expected one.  Things the graph
+// has beyond the expected ones, such as the DUT's own LSP, are not reported.
+type Diff struct {
+	MissingNodes       []string
+	MissingAdjacencies []string
+	MissingPrefixes    []string
+	// Mismatches are hostnames, metrics, link counts and SIDs that differ.
+	Mismatches []string
+}
+
+// Empty reports whether the graphs agree.
+func (d *Diff) Empty() bool {
+	return len(d.MissingNodes)+len(d.MissingAdjacencies)+len(d.MissingPrefixes)+len(d.Mismatches) == 0
+}
+
+func (d *Diff) String() string {
+	var b strings.Builder
+	for _, c := range []struct {
+		name  string
+		items []string
+	}{
+		{"missing nodes", d.MissingNodes},
+		{"missing adjacencies", d.MissingAdjacencies},
+		{"missing prefixes", d.MissingPrefixes},
+		{"mismatches", d.Mismatches},
+	} {
+		if len(c.items) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "%d %s:\n", len(c.items), c.name)
+		for i, s := range c.items {
+			if i == maxReported {
+				fmt.Fprintf(&b, "  ... and %d more\n", len(c.items)-maxReported)
+				break
+			}
+			fmt.Fprintf(&b, "  %s\n", s)
+		}
+	}
+	return b.String()
+}
+
+// containsAll reports whether every value of want is in got.
+func containsAll(got, want []uint32) bool {
+	for _, w := range want {
+		if !slices.Contains(got, w) {
+			return false
+		}
+	}
+	return true
+}
+
+// Compare returns what got lacks of want.  Expected link counts are only
+// compared when got knows them, and SIDs only when want has them.
+func Compare(want, got *Graph) *Diff {
+	d := &Diff{}
+	for _, wn := range want.SortedNodes() {
+		gn, ok := got.Nodes[wn.SystemID]
+		if !ok {
+			d.MissingNodes = append(d.MissingNodes, wn.String())
+			continue
+		}
+		if wn.Hostname != "" && gn.Hostname != "" && wn.Hostname != gn.Hostname {
+			d.Mismatches = append(d.Mismatches, fmt.Sprintf("%s: hostname %q, want %q", wn.SystemID, gn.Hostname, wn.Hostname))
+		}
+
+		var nbrs []string
+		for id := range wn.Neighbors {
+			nbrs = append(nbrs, id)
+		}
+		sort.Strings(nbrs)
+		for _, id := range nbrs {
+			wa, ga := wn.Neighbors[id], gn.Neighbors[id]
+			if ga == nil {
+				d.MissingAdjacencies = append(d.MissingAdjacencies, fmt.Sprintf("%v -> %s", wn, id))
+				continue
+			}
+			if ga.Metric != wa.Metric {
+				d.Mismatches = append(d.Mismatches, fmt.Sprintf("%s -> %s: metric %d, want %d", wn.SystemID, id, ga.Metric, wa.Metric))
+			}
+			if ga.Links > 0 && wa.Links > 0 && ga.Links != wa.Links {
+				d.Mismatches = append(d.Mismatches, fmt.Sprintf("%s -> %s: %d links, want %d", wn.SystemID, id, ga.Links, wa.Links))
+			}
+			if !containsAll(ga.AdjSIDs, wa.AdjSIDs) {
+				d.Mismatches = append(d.Mismatches, fmt.Sprintf("%s -> %s: adjacency SIDs %v, want %v", wn.SystemID, id, ga.AdjSIDs, wa.AdjSIDs))
+			}
+		}
+
+		var prefixes []string
+		for p := range wn.Prefixes {
+			prefixes = append(prefixes, p)
+		}
+		sort.Strings(prefixes)
+		for _, p := range prefixes {
+			wp, gp := wn.Prefixes[p], gn.Prefixes[p]
+			if gp == nil {
+				d.MissingPrefixes = append(d.MissingPrefixes, fmt.Sprintf("%v: %s", wn, p))
+				continue
+			}
+			if gp.Metric != wp.Metric {
+				d.Mismatches = append(d.Mismatches, fmt.Sprintf("%s: %s metric %d, want %d", wn.SystemID, p, gp.Metric, wp.Metric))
+			}
+			if !containsAll(gp.SIDs, wp.SIDs) {
+				d.Mismatches = append(d.Mismatches, fmt.Sprintf("%s: %s prefix SIDs %v, want %v", wn.SystemID, p, gp.SIDs, wp.SIDs))
+			}
+		}
+	}
+	return d
+}
+
+// Get returns the topology in the LSDB of an ISIS level of dut.
+func Get(t testing.TB, dut *ondatra.DUTDevice, ni, instance string, level uint8) *Graph {
+	t.Helper()
+	return New(gnmi.Get(t, dut, gnmi.OC().NetworkInstance(ni).Protocol(oc.PolicyTypes_INSTALL_PROTOCOL_TYPE_ISIS, instance).Isis().Level(level).State()))
+}
+
+// Await waits up to timeout for the LSDB of an ISIS level of dut to contain
+// want, reporting what is still missing as a test error.  It returns the
+// last topology received.
+func Await(t testing.TB, dut *ondatra.DUTDevice, ni, instance string, level uint8, want *Graph, timeout time.Duration) *Graph {
+	t.Helper()
+	got := NewGraph()
+	diff := Compare(want, got)
+	path := gnmi.OC().NetworkInstance(ni).Protocol(oc.PolicyTypes_INSTALL_PROTOCOL_TYPE_ISIS, instance).Isis().Level(level)
+	gnmi.Watch(t, dut, path.State(), timeout, func(v *ygnmi.Value[*oc.NetworkInstance_Protocol_Isis_Level]) bool {
+		val, ok := v.Val()
+		if !ok {
+			return false
+		}
+		got = New(val)
+		diff = Compare(want, got)
+		return diff.Empty()
+	}).Await(t)
+	t.Logf("ISIS level %d LSDB of %s: %v, want at least %v", level, dut.Name(), got, want)
+	if !diff.Empty() {
+		t.Errorf("ISIS level %d LSDB of %s after %v:\n%v", level, dut.Name(), timeout, diff)
+	}
+	return got
+}
@@ -0,0 +1,326 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package isislsdb rebuilds the ISIS topology from the link-state database
+// of a DUT and compares it with the topology emulated by an ATE.
+//
+// A scale test proves every emulated node, adjacency and prefix reached the
+// DUT with:
+//
+//	want, err := isislsdb.FromOTG(top, isislsdb.OTGOptions{DUTSystemID: dutSysID})
+//	if err != nil {
+//		t.Fatal(err)
+//	}
+//	isislsdb.Await(t, dut, ni, isisName, 2, want, 5*time.Minute)
+package isislsdb
+
+import (
+	"fmt"
+	"net/netip"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/openconfig/ondatra/gnmi/oc"
+)
+
+// Adjacency is the reachability of a neighbor advertised by a node.
+type Adjacency struct {
+	// Neighbor is the system ID of the neighbor.
+	Neighbor string
+	// Metric is the lowest metric of the links to the neighbor.
+	Metric uint32
+	// Links is the number of parallel links to the neighbor, or 0 if only
+	// narrow IS reachability, which does not tell links apart, is known.
+	Links int
+	// AdjSIDs are the adjacency SIDs of the links.
+	AdjSIDs []uint32
+}
+
+// Prefix is an IP prefix advertised by a node.
+type Prefix struct {
+	Prefix string
+	Metric uint32
+	// SIDs are the prefix SIDs, as index or label depending on their flags.
+	SIDs []uint32
+}
+
+// Node is an ISIS router and what its LSPs advertise.
+type Node struct {
+	// SystemID is in the "xxxx.xxxx.xxxx" form.
+	SystemID  string
+	Hostname  string
+	Neighbors map[string]*Adjacency
+	Prefixes  map[string]*Prefix
+}
+
+func (n *Node) String() string {
+	if n.Hostname == "" {
+		return n.SystemID
+	}
+	return fmt.Sprintf("%s (%s)", n.SystemID, n.Hostname)
+}
+
+// addLink records links to a neighbor, keeping the lowest metric.
+func (n *Node) addLink(neighbor string, metric uint32, links int, sids []uint32) {
+	a, ok := n.Neighbors[neighbor]
+	if !ok {
+		a = &Adjacency{Neighbor: neighbor, Metric: metric}
+		n.Neighbors[neighbor] = a
+	}
+	a.Metric = min(a.Metric, metric)
+	a.Links += links
+	a.AdjSIDs = append(a.AdjSIDs, sids...)
+}
+
+// addPrefix records a prefix, keeping the lowest metric if several TLVs
+// advertise it.
+func (n *Node) addPrefix(prefix string, metric uint32, sids []uint32) {
+	key, err := normalizePrefix(prefix)
+	if err != nil {
+		return
+	}
+	p, ok := n.Prefixes[key]
+	if !ok {
+		n.Prefixes[key] = &Prefix{Prefix: key, Metric: metric, SIDs: sids}
+		return
+	}
+	p.Metric = min(p.Metric, metric)
+	for _, s := range sids {
+		if !slices.Contains(p.SIDs, s) {
+			p.SIDs = append(p.SIDs, s)
+		}
+	}
+}
+
+// Graph is an ISIS topology keyed by system ID.
+type Graph struct {
+	Nodes map[string]*Node
+}
+
+// NewGraph returns an empty graph.
+func NewGraph() *Graph {
+	return &Graph{Nodes: map[string]*Node{}}
+}
+
+// Node returns the node with a system ID, adding it if needed.
+func (g *Graph) Node(systemID string) *Node {
+	n, ok := g.Nodes[systemID]
+	if !ok {
+		n = &Node{SystemID: systemID, Neighbors: map[string]*Adjacency{}, Prefixes: map[string]*Prefix{}}
+		g.Nodes[systemID] = n
+	}
+	return n
+}
+
+// SortedNodes returns the nodes ordered by system ID.
+func (g *Graph) SortedNodes() []*Node {
+	var nodes []*Node
+	for _, n := range g.Nodes {
+		nodes = append(nodes, n)
+	}
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].SystemID < nodes[j].SystemID })
+	return nodes
+}
+
+// Counts returns the number of nodes, adjacencies and prefixes in g.
+func (g *Graph) Counts() (nodes, adjacencies, prefixes int) {
+	for _, n := range g.Nodes {
+		adjacencies += len(n.Neighbors)
+		prefixes += len(n.Prefixes)
+	}
+	return len(g.Nodes), adjacencies, prefixes
+}
+
+func (g *Graph) String() string {
+	n, a, p := g.Counts()
+	return fmt.Sprintf("%d nodes, %d adjacencies, %d prefixes", n, a, p)
+}
+
+// ParseSystemID parses a system ID, neighbor ID or LSP ID in any of the
+// "xxxx.xxxx.xxxx[.pn][-frag]" or plain hex forms.  It returns the system
+// ID in the "xxxx.xxxx.xxxx" form and the pseudonode ID.
+func ParseSystemID(s string) (systemID string, pseudonode uint8, err error) {
+	id, _, _ := strings.Cut(s, "-")
+	id = strings.ReplaceAll(id, ".", "")
+	if len(id) != 12 && len(id) != 14 {
+		return "", 0, fmt.Errorf("invalid ISIS ID %q", s)
+	}
+	if _, err := strconv.ParseUint(id, 16, 64); err != nil {
+		return "", 0, fmt.Errorf("invalid ISIS ID %q", s)
+	}
+	if len(id) == 14 {
+		pn, _ := strconv.ParseUint(id[12:], 16, 8)
+		pseudonode = uint8(pn)
+	}
+	id = strings.ToLower(id)
+	return id[0:4] + "." + id[4:8] + "." + id[8:12], pseudonode, nil
+}
+
+// nodeID returns the graph key of a router or pseudonode.
+func nodeID(s string) (string, bool) {
+	sys, pn, err := ParseSystemID(s)
+	if err != nil {
+		return "", false
+	}
+	if pn != 0 {
+		return fmt.Sprintf("%s.%02x", sys, pn), true
+	}
+	return sys, true
+}
+
+func isPseudonode(id string) bool {
+	return len(id) > len("xxxx.xxxx.xxxx")
+}
+
+func normalizePrefix(s string) (string, error) {
+	p, err := netip.ParsePrefix(s)
+	if err != nil {
+		return "", err
+	}
+	return p.Masked().String(), nil
+}
+
+// New rebuilds the topology described by the LSDB of an ISIS level.  LSP
+// fragments are merged per system, and LAN adjacencies through a pseudonode
+// become adjacencies between the routers on the LAN.
+func New(level *oc.NetworkInstance_Protocol_Isis_Level) *Graph {
+	g := NewGraph()
+	if level == nil {
+		return g
+	}
+	lans := map[string][]string{}
+	for key, lsp := range level.Lsp {
+		if lsp.RemainingLifetime != nil && lsp.GetRemainingLifetime() == 0 {
+			// Purged LSP.
+			continue
+		}
+		id, ok := nodeID(key)
+		if !ok {
+			continue
+		}
+		if isPseudonode(id) {
+			for _, tlv := range lsp.Tlv {
+				var members []string
+				if r := tlv.ExtendedIsReachability; r != nil {
+					for nk, nbr := range r.Neighbor {
+						members = append(members, neighborID(nk, nbr.SystemId))
+					}
+				}
+				if r := tlv.IsReachability; r != nil {
+					for nk, nbr := range r.Neighbor {
+						members = append(members, neighborID(nk, nbr.SystemId))
+					}
+				}
+				for _, m := range members {
+					if m, ok := nodeID(m); ok && !slices.Contains(lans[id], m) {
+						lans[id] = append(lans[id], m)
+					}
+				}
+			}
+			continue
+		}
+		addTLVs(g.Node(id), lsp.Tlv)
+	}
+
+	for _, n := range g.Nodes {
+		for id, a := range n.Neighbors {
+			if !isPseudonode(id) {
+				continue
+			}
+			delete(n.Neighbors, id)
+			for _, m := range lans[id] {
+				if m != n.SystemID {
+					n.addLink(m, a.Metric, max(a.Links, 1), a.AdjSIDs)
+				}
+			}
+		}
+	}
+	return g
+}
+
+func neighborID(key string, systemID *string) string {
+	if systemID != nil {
+		return *systemID
+	}
+	return key
+}
+
+func sidValues[S interface{ GetValue() uint32 }](sids map[uint32]S) []uint32 {
+	var vals []uint32
+	for _, sid := range sids {
+		vals = append(vals, sid.GetValue())
+	}
+	slices.Sort(vals)
+	return vals
+}
+
+func addTLVs(n *Node, tlvs map[oc.E_IsisLsdbTypes_ISIS_TLV_TYPE]*oc.NetworkInstance_Protocol_Isis_Level_Lsp_Tlv) {
+	for _, tlv := range tlvs {
+		if h := tlv.GetHostname().GetHostname(); len(h) > 0 {
+			n.Hostname = h[0]
+		}
+		if r := tlv.ExtendedIsReachability; r != nil {
+			for nk, nbr := range r.Neighbor {
+				id, ok := nodeID(neighborID(nk, nbr.SystemId))
+				if !ok {
+					continue
+				}
+				for _, inst := range nbr.Instance {
+					var sids []uint32
+					for _, st := range inst.Subtlv {
+						sids = append(sids, sidValues(st.AdjacencySid)...)
+					}
+					n.addLink(id, inst.GetMetric(), 1, sids)
+				}
+			}
+		}
+		if r := tlv.IsReachability; r != nil {
+			for nk, nbr := range r.Neighbor {
+				if id, ok := nodeID(neighborID(nk, nbr.SystemId)); ok {
+					n.addLink(id, uint32(nbr.GetDefaultMetric().GetMetric()), 0, nil)
+				}
+			}
+		}
+		if r := tlv.ExtendedIpv4Reachability; r != nil {
+			for pk, p := range r.Prefix {
+				var sids []uint32
+				for _, st := range p.Subtlv {
+					sids = append(sids, sidValues(st.PrefixSid)...)
+				}
+				n.addPrefix(pk, p.GetMetric(), sids)
+			}
+		}
+		if r := tlv.Ipv4InternalReachability; r != nil {
+			for pk, p := range r.Prefix {
+				n.addPrefix(pk, uint32(p.GetDefaultMetric().GetMetric()), nil)
+			}
+		}
+		if r := tlv.Ipv4ExternalReachability; r != nil {
+			for pk, p := range r.Prefix {
+				n.addPrefix(pk, uint32(p.GetDefaultMetric().GetMetric()), nil)
+			}
+		}
+		if r := tlv.Ipv6Reachability; r != nil {
+			for pk, p := range r.Prefix {
+				var sids []uint32
+				for _, st := range p.Subtlv {
+					sids = append(sids, sidValues(st.PrefixSid)...)
+				}
+				n.addPrefix(pk, p.GetMetric(), sids)
+			}
+		}
+	}
+}
@@ -0,0 +1,296 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package isislsdb
+
+import (
+	"net/netip"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/open-traffic-generator/snappi/gosnappi"
+	otgconfighelpers "github.com/openconfig/featureprofiles/internal/otg_helpers/otg_config_helpers"
+	"github.com/openconfig/ondatra/gnmi/oc"
+	"github.com/openconfig/ygot/ygot"
+)
+
+func TestParseSystemID(t *testing.T) {
+	tests := []struct {
+		in     string
+		want   string
+		wantPN uint8
+		bad    bool
+	}{
+		{in: "1920.0000.2001", want: "1920.0000.2001"},
+		{in: "640000000101", want: "6400.0000.0101"},
+		{in: "1920.0000.2001.00-00", want: "1920.0000.2001"},
+		{in: "1920.0000.2001.0A-01", want: "1920.0000.2001", wantPN: 10},
+		{in: "1920.0000", bad: true},
+		{in: "xyz0.0000.2001", bad: true},
+	}
+	for _, tt := range tests {
+		got, pn, err := ParseSystemID(tt.in)
+		if (err != nil) != tt.bad {
+			t.Errorf("ParseSystemID(%q) got err %v, want error %t", tt.in, err, tt.bad)
+			continue
+		}
+		if got != tt.want || pn != tt.wantPN {
+			t.Errorf("ParseSystemID(%q) = %q, %d, want %q, %d", tt.in, got, pn, tt.want, tt.wantPN)
+		}
+	}
+}
+
+func TestNthPrefix(t *testing.T) {
+	tests := []struct {
+		p    string
+		n    uint64
+		want string
+	}{
+		{"10.1.1.0/24", 0, "10.1.1.0/24"},
+		{"10.1.1.0/24", 255, "10.2.0.0/24"},
+		{"10.0.0.0/32", 1 << 16, "10.1.0.0/32"},
+		{"2001:db8::/64", 1, "2001:db8:0:1::/64"},
+		{"2001:db8::/128", 0x10000, "2001:db8::1:0/128"},
+		{"2001:db8:0:ffff::/64", 1, "2001:db8:1::/64"},
+		{"255.255.255.0/24", 1, ""},
+	}
+	for _, tt := range tests {
+		got, ok := nthPrefix(netip.MustParsePrefix(tt.p), tt.n)
+		if !ok {
+			got = netip.Prefix{}
+		}
+		if (tt.want == "" && ok) || (tt.want != "" && got.String() != tt.want) {
+			t.Errorf("nthPrefix(%s, %d) = %v, %t, want %q", tt.p, tt.n, got, ok, tt.want)
+		}
+	}
+}
+
+// lsdb holds LSPs for a test level.
+type lsdb struct {
+	level *oc.NetworkInstance_Protocol_Isis_Level
+}
+
+func newLSDB() *lsdb {
+	return &lsdb{level: &oc.NetworkInstance_Protocol_Isis_Level{}}
+}
+
+func (l *lsdb) tlv(lspID string, typ oc.E_IsisLsdbTypes_ISIS_TLV_TYPE) *oc.NetworkInstance_Protocol_Isis_Level_Lsp_Tlv {
+	lsp := l.level.GetOrCreateLsp(lspID)
+	lsp.RemainingLifetime = ygot.Uint16(1200)
+	return lsp.GetOrCreateTlv(typ)
+}
+
+func (l *lsdb) hostname(lspID, name string) {
+	l.tlv(lspID, oc.IsisLsdbTypes_ISIS_TLV_TYPE_DYNAMIC_NAME).GetOrCreateHostname().Hostname = []string{name}
+}
+
+func (l *lsdb) link(lspID, neighbor string, instance uint64, metric uint32, adjSID uint32) {
+	inst := l.tlv(lspID, oc.IsisLsdbTypes_ISIS_TLV_TYPE_EXTENDED_IS_REACHABILITY).
+		GetOrCreateExtendedIsReachability().GetOrCreateNeighbor(neighbor).GetOrCreateInstance(instance)
+	inst.Metric = ygot.Uint32(metric)
+	if adjSID != 0 {
+		inst.GetOrCreateSubtlv(oc.IsisLsdbTypes_ISIS_SUBTLV_TYPE_IS_REACHABILITY_ADJ_SID).GetOrCreateAdjacencySid(adjSID).Value = ygot.Uint32(adjSID)
+	}
+}
+
+func (l *lsdb) prefix(lspID, prefix string, metric uint32, sid uint32) {
+	if strings.Contains(prefix, ":") {
+		p := l.tlv(lspID, oc.IsisLsdbTypes_ISIS_TLV_TYPE_IPV6_REACHABILITY).GetOrCreateIpv6Reachability().GetOrCreatePrefix(prefix)
+		p.Metric = ygot.Uint32(metric)
+		if sid != 0 {
+			p.GetOrCreateSubtlv(oc.IsisLsdbTypes_ISIS_SUBTLV_TYPE_IP_REACHABILITY_PREFIX_SID).GetOrCreatePrefixSid(sid).Value = ygot.Uint32(sid)
+		}
+		return
+	}
+	p := l.tlv(lspID, oc.IsisLsdbTypes_ISIS_TLV_TYPE_EXTENDED_IPV4_REACHABILITY).GetOrCreateExtendedIpv4Reachability().GetOrCreatePrefix(prefix)
+	p.Metric = ygot.Uint32(metric)
+	if sid != 0 {
+		p.GetOrCreateSubtlv(oc.IsisLsdbTypes_ISIS_SUBTLV_TYPE_IP_REACHABILITY_PREFIX_SID).GetOrCreatePrefixSid(sid).Value = ygot.Uint32(sid)
+	}
+}
+
+func TestNew(t *testing.T) {
+	l := newLSDB()
+	const (
+		dut = "1920.0000.2001"
+		r1  = "6400.0000.0101"
+		r2  = "6400.0000.0102"
+	)
+	l.hostname(dut+".00-00", "dut")
+	l.link(dut+".00-00", r1+".00", 0, 10, 24001)
+	l.link(dut+".00-00", r1+".00", 1, 20, 24002)
+	// Fragments are merged.
+	l.prefix(dut+".00-01", "192.0.2.1/32", 0, 16001)
+	l.prefix(dut+".00-01", "2001:db8::1/128", 0, 0)
+	// Narrow IS and IP reachability.
+	l.tlv(r1+".00-00", oc.IsisLsdbTypes_ISIS_TLV_TYPE_IIS_NEIGHBORS).GetOrCreateIsReachability().
+		GetOrCreateNeighbor(dut).GetOrCreateDefaultMetric().Metric = ygot.Uint8(10)
+	l.tlv(r1+".00-00", oc.IsisLsdbTypes_ISIS_TLV_TYPE_IPV4_INTERNAL_REACHABILITY).GetOrCreateIpv4InternalReachability().
+		GetOrCreatePrefix("10.1.1.0/24").GetOrCreateDefaultMetric().Metric = ygot.Uint8(30)
+	// A LAN between r1 and r2 through pseudonode r2.01.
+	l.link(r1+".00-00", r2+".01", 0, 15, 0)
+	l.link(r2+".00-00", r2+".01", 0, 25, 0)
+	l.link(r2+".01-00", r1+".00", 0, 0, 0)
+	l.link(r2+".01-00", r2+".00", 0, 0, 0)
+	// Purged LSPs are ignored.
+	l.level.GetOrCreateLsp("6400.0000.0199.00-00").RemainingLifetime = ygot.Uint16(0)
+
+	g := New(l.level)
+	want := &Graph{Nodes: map[string]*Node{
+		dut: {
+			SystemID: dut, Hostname: "dut",
+			Neighbors: map[string]*Adjacency{r1: {Neighbor: r1, Metric: 10, Links: 2, AdjSIDs: []uint32{24001, 24002}}},
+			Prefixes: map[string]*Prefix{
+				"192.0.2.1/32":    {Prefix: "192.0.2.1/32", SIDs: []uint32{16001}},
+				"2001:db8::1/128": {Prefix: "2001:db8::1/128"},
+			},
+		},
+		r1: {
+			SystemID: r1,
+			Neighbors: map[string]*Adjacency{
+				dut: {Neighbor: dut, Metric: 10},
+				r2:  {Neighbor: r2, Metric: 15, Links: 1},
+			},
+			Prefixes: map[string]*Prefix{"10.1.1.0/24": {Prefix: "10.1.1.0/24", Metric: 30}},
+		},
+		r2: {
+			SystemID:  r2,
+			Neighbors: map[string]*Adjacency{r1: {Neighbor: r1, Metric: 25, Links: 1}},
+			Prefixes:  map[string]*Prefix{},
+		},
+	}}
+	sortSIDs := cmpopts.SortSlices(func(a, b uint32) bool { return a < b })
+	if diff := cmp.Diff(want, g, sortSIDs); diff != "" {
+		t.Errorf("New() differs (-want +got):\n%s", diff)
+	}
+}
+
+// gridConfig returns an OTG configuration with an emulated router and a 2x3
+// grid behind it, as isis_scale_test builds.
+func gridConfig(t *testing.T) gosnappi.Config {
+	t.Helper()
+	top := gosnappi.NewConfig()
+	er := top.Devices().Add().SetName("R101")
+	eth := er.Ethernets().Add().SetName("R101.Eth")
+	eth.Connection().SetLagName("lag1")
+	eth.Ipv4Addresses().Add().SetName("R101.IPv4").SetAddress("192.0.2.2").SetGateway("192.0.2.1").SetPrefix(30)
+	isis := er.Isis().SetSystemId("640000000101").SetName("R101.isis")
+	isis.Basic().SetHostname("R101")
+	isis.Interfaces().Add().SetEthName(eth.Name()).SetName("R101.ISISInt").SetMetric(10)
+
+	grid := otgconfighelpers.NewGridIsisData(top)
+	grid.SetRow(2).SetCol(3).SetSystemIDFirstOctet("20").SetLinkIP4FirstOctet(11).SetLinkMultiplier(2).SetBlockName("b1")
+	grid.V4RouteInfo().SetAddressFirstOctet("12").SetPrefix(32).SetCount(2)
+	grid.V6RouteInfo().SetAddressFirstOctet("2001").SetPrefix(64).SetCount(1)
+	topo, err := grid.GenerateTopology()
+	if err != nil {
+		t.Fatalf("GenerateTopology() failed: %v", err)
+	}
+	if err := topo.Connect(er, 0, 1); err != nil {
+		t.Fatalf("Connect() failed: %v", err)
+	}
+	return top
+}
+
+func TestFromOTG(t *testing.T) {
+	const dut = "1920.0000.2001"
+	want, err := FromOTG(gridConfig(t), OTGOptions{DUTSystemID: dut, InterfacePrefixes: true})
+	if err != nil {
+		t.Fatalf("FromOTG() failed: %v", err)
+	}
+	if got, want := want.String(), "7 nodes, 17 adjacencies, 49 prefixes"; got != want {
+		t.Errorf("FromOTG() = %s, want %s", got, want)
+	}
+
+	er := want.Nodes["6400.0000.0101"]
+	if er == nil || er.Hostname != "R101" || er.Neighbors[dut] == nil || er.Prefixes["192.0.2.0/30"] == nil {
+		t.Fatalf("FromOTG() emulated router = %+v, want hostname R101, DUT neighbor and link prefix", er)
+	}
+	// The grid node at row 0, column 1 is the second one.
+	corner := want.Nodes["2000.0000.0002"]
+	if corner == nil {
+		t.Fatalf("FromOTG() has no grid node 2000.0000.0002: %v", want.SortedNodes())
+	}
+	if a := corner.Neighbors["2000.0000.0001"]; a == nil || a.Links != 2 || a.Metric != 10 {
+		t.Errorf("FromOTG() grid adjacency = %+v, want 2 links of metric 10", a)
+	}
+	if a := corner.Neighbors["6400.0000.0101"]; a == nil || a.Links != 1 {
+		t.Errorf("FromOTG() grid to emulated router adjacency = %+v, want 1 link", a)
+	}
+	for _, p := range []string{"12.1.2.0/32", "12.1.2.1/32", "2001:1:2::/64"} {
+		if corner.Prefixes[p] == nil {
+			t.Errorf("FromOTG() grid node lacks prefix %s", p)
+		}
+	}
+
+	bad := gosnappi.NewConfig()
+	bad.Devices().Add().SetName("d").Isis().SetSystemId("64")
+	if _, err := FromOTG(bad, OTGOptions{}); err == nil {
+		t.Errorf("FromOTG() with bad system ID succeeded, want error")
+	}
+}
+
+func TestCompare(t *testing.T) {
+	want, err := FromOTG(gridConfig(t), OTGOptions{})
+	if err != nil {
+		t.Fatalf("FromOTG() failed: %v", err)
+	}
+
+	// Advertise the expected topology, then break parts of it.
+	l := newLSDB()
+	for _, n := range want.SortedNodes() {
+		lsp := n.SystemID + ".00-00"
+		l.hostname(lsp, n.Hostname)
+		for _, a := range n.Neighbors {
+			for i := range a.Links {
+				l.link(lsp, a.Neighbor+".00", uint64(i), a.Metric, 0)
+			}
+		}
+		for _, p := range n.Prefixes {
+			l.prefix(lsp, p.Prefix, p.Metric, 0)
+		}
+	}
+	if d := Compare(want, New(l.level)); !d.Empty() {
+		t.Fatalf("Compare() of matching LSDB reported:\n%v", d)
+	}
+
+	delete(l.level.Lsp, "2000.0000.0006.00-00")
+	delete(l.level.Lsp["2000.0000.0001.00-00"].Tlv[oc.IsisLsdbTypes_ISIS_TLV_TYPE_EXTENDED_IPV4_REACHABILITY].ExtendedIpv4Reachability.Prefix, "12.1.1.1/32")
+	l.link("2000.0000.0001.00-00", "2000.0000.0002.00", 0, 30, 0)
+	l.link("2000.0000.0001.00-00", "2000.0000.0002.00", 1, 30, 0)
+	l.link("2000.0000.0001.00-00", "2000.0000.0004.00", 2, 10, 0)
+	l.hostname("6400.0000.0101.00-00", "other")
+
+	d := Compare(want, New(l.level))
+	if got := len(d.MissingNodes); got != 1 {
+		t.Errorf("Compare() missing nodes = %v, want 1", d.MissingNodes)
+	}
+	if got := len(d.MissingPrefixes); got != 1 {
+		t.Errorf("Compare() missing prefixes = %v, want 1", d.MissingPrefixes)
+	}
+	s := d.String()
+	for _, w := range []string{
+		"1 missing nodes:\n  2000.0000.0006",
+		"12.1.1.1/32",
+		"2000.0000.0001 -> 2000.0000.0002: metric 30, want 10",
+		"2000.0000.0001 -> 2000.0000.0004: 3 links, want 2",
+		`6400.0000.0101: hostname "other", want "R101"`,
+	} {
+		if !strings.Contains(s, w) {
+			t.Errorf("Compare() = %s\nwant it to contain %q", s, w)
+		}
+	}
+}
@@ -0,0 +1,222 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package isislsdb
+
+import (
+	"fmt"
+	"net/netip"
+
+	"github.com/open-traffic-generator/snappi/gosnappi"
+)
+
+// defaultMetric is the OTG default metric of ISIS interfaces and routes.
+const defaultMetric = 10
+
+// OTGOptions controls what FromOTG expects of an ATE configuration.
+type OTGOptions struct {
+	// DUTSystemID is the system ID of the DUT.  If set, emulated routers
+	// with an ISIS interface on an ATE port or LAG are expected to list the
+	// DUT as neighbor.
+	DUTSystemID string
+	// InterfacePrefixes expects the subnets of ISIS interfaces to be
+	// advertised with the interface metric.
+	InterfacePrefixes bool
+}
+
+// FromOTG returns the topology the ISIS routers of an OTG configuration,
+// such as the grids built by otgconfighelpers.GridIsisData, advertise.
+func FromOTG(cfg gosnappi.Config, opts OTGOptions) (*Graph, error) {
+	dut := ""
+	if opts.DUTSystemID != "" {
+		id, _, err := ParseSystemID(opts.DUTSystemID)
+		if err != nil {
+			return nil, fmt.Errorf("DUT: %w", err)
+		}
+		dut = id
+	}
+
+	// The system ID of the ISIS router on each Ethernet interface.
+	ethSystemID := map[string]string{}
+	ethernets := map[string]gosnappi.DeviceEthernet{}
+	for _, d := range cfg.Devices().Items() {
+		if !d.HasIsis() {
+			continue
+		}
+		id, _, err := ParseSystemID(d.Isis().SystemId())
+		if err != nil {
+			return nil, fmt.Errorf("device %s: %w", d.Name(), err)
+		}
+		for _, e := range d.Ethernets().Items() {
+			ethSystemID[e.Name()] = id
+			ethernets[e.Name()] = e
+		}
+	}
+
+	g := NewGraph()
+	for _, d := range cfg.Devices().Items() {
+		if !d.HasIsis() {
+			continue
+		}
+		isis := d.Isis()
+		id, _, _ := ParseSystemID(isis.SystemId())
+		n := g.Node(id)
+		if isis.Basic().HasHostname() {
+			n.Hostname = isis.Basic().Hostname()
+		}
+		for _, ifc := range isis.Interfaces().Items() {
+			e, ok := ethernets[ifc.EthName()]
+			if !ok {
+				return nil, fmt.Errorf("ISIS interface %s: unknown Ethernet %q", ifc.Name(), ifc.EthName())
+			}
+			metric := uint32(defaultMetric)
+			if ifc.HasMetric() {
+				metric = ifc.Metric()
+			}
+			var sids []uint32
+			for _, s := range ifc.AdjacencySids().Items() {
+				if s.Choice() == gosnappi.IsisInterfaceAdjacencySidChoice.SID_VALUES {
+					sids = append(sids, s.SidValues()...)
+				}
+			}
+			conn := e.Connection()
+			switch {
+			case conn.Choice() == gosnappi.EthernetConnectionChoice.SIMULATED_LINK:
+				remote := conn.SimulatedLink().RemoteSimulatedLink()
+				peer, ok := ethSystemID[remote]
+				if !ok {
+					return nil, fmt.Errorf("ISIS interface %s: simulated link to unknown Ethernet %q", ifc.Name(), remote)
+				}
+				n.addLink(peer, metric, 1, sids)
+			case dut != "":
+				n.addLink(dut, metric, 1, sids)
+			}
+			if opts.InterfacePrefixes {
+				for _, a := range e.Ipv4Addresses().Items() {
+					addAddressPrefix(n, a.Address(), a.Prefix(), metric)
+				}
+				for _, a := range e.Ipv6Addresses().Items() {
+					addAddressPrefix(n, a.Address(), a.Prefix(), metric)
+				}
+			}
+		}
+		for _, r := range isis.V4Routes().Items() {
+			metric := uint32(defaultMetric)
+			if r.HasLinkMetric() {
+				metric = r.LinkMetric()
+			}
+			sids := routeSIDs(r.PrefixSids().Items())
+			for _, a := range r.Addresses().Items() {
+				if err := addRoutePrefixes(n, a.Address(), a.Prefix(), a.Count(), a.Step(), metric, sids); err != nil {
+					return nil, fmt.Errorf("route %s: %w", r.Name(), err)
+				}
+			}
+		}
+		for _, r := range isis.V6Routes().Items() {
+			metric := uint32(defaultMetric)
+			if r.HasLinkMetric() {
+				metric = r.LinkMetric()
+			}
+			sids := routeSIDs(r.PrefixSids().Items())
+			for _, a := range r.Addresses().Items() {
+				if err := addRoutePrefixes(n, a.Address(), a.Prefix(), a.Count(), a.Step(), metric, sids); err != nil {
+					return nil, fmt.Errorf("route %s: %w", r.Name(), err)
+				}
+			}
+		}
+	}
+	return g, nil
+}
+
+// routeSIDs returns the prefix SIDs of a route range, as absolute values or
+// indices.  The nth SID is attached to the nth prefix of the range.
+func routeSIDs(sids []gosnappi.IsisSRPrefixSid) []uint32 {
+	var vals []uint32
+	for _, s := range sids {
+		switch s.Choice() {
+		case gosnappi.IsisSRPrefixSidChoice.SID_VALUES:
+			vals = append(vals, s.SidValues()...)
+		case gosnappi.IsisSRPrefixSidChoice.SID_INDICES:
+			vals = append(vals, s.SidIndices()...)
+		}
+	}
+	return vals
+}
+
+func addAddressPrefix(n *Node, addr string, length, metric uint32) {
+	a, err := netip.ParseAddr(addr)
+	if err != nil {
+		return
+	}
+	if p, err := a.Prefix(int(length)); err == nil {
+		n.addPrefix(p.String(), metric, nil)
+	}
+}
+
+// addRoutePrefixes adds count prefixes from addr, each step prefixes apart.
+func addRoutePrefixes(n *Node, addr string, length, count, step, metric uint32, sids []uint32) error {
+	a, err := netip.ParseAddr(addr)
+	if err != nil {
+		return err
+	}
+	p, err := a.Prefix(int(length))
+	if err != nil {
+		return err
+	}
+	step = max(step, 1)
+	for i := range count {
+		next, ok := nthPrefix(p, uint64(i)*uint64(step))
+		if !ok {
+			return fmt.Errorf("%d prefixes from %v overflow the address family", count, p)
+		}
+		var sid []uint32
+		if int(i) < len(sids) {
+			sid = []uint32{sids[i]}
+		}
+		n.addPrefix(next.String(), metric, sid)
+	}
+	return nil
+}
+
+// nthPrefix returns the prefix of the same length n prefixes after p.
+func nthPrefix(p netip.Prefix, n uint64) (netip.Prefix, bool) {
+	b := p.Addr().As16()
+	shift := p.Addr().BitLen() - p.Bits()
+	// Add n<<shift to the 128 bit address, from the lowest byte up.
+	carry := uint64(0)
+	for i := 15; i >= 0; i-- {
+		bit := (15 - i) * 8
+		var add uint64
+		if bit+8 > shift && bit < shift+64 {
+			if bit >= shift {
+				add = (n >> (bit - shift)) & 0xff
+			} else {
+				add = (n << (shift - bit)) & 0xff
+			}
+		}
+		sum := uint64(b[i]) + add + carry
+		b[i], carry = byte(sum), sum>>8
+	}
+	addr := netip.AddrFrom16(b)
+	if p.Addr().Is4() {
+		if !addr.Is4In6() {
+			return netip.Prefix{}, false
+		}
+		addr = addr.Unmap()
+	}
+	if carry != 0 {
+		return netip.Prefix{}, false
+	}
+	return netip.PrefixFrom(addr, p.Bits()), true
+}
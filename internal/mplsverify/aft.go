@@ -0,0 +1,146 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mplsverify
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/gnmi"
+	"github.com/openconfig/ondatra/gnmi/oc"
+)
+
+// CheckAFT reports swap and pop LSPs whose label entry in afts is missing or
+// forwards differently.  Push LSPs have no label entry and are skipped.
+func CheckAFT(lsps []*LSP, afts *oc.NetworkInstance_Afts) Report {
+	r := Report{}
+	for _, l := range lsps {
+		if l.Op == Push {
+			continue
+		}
+		e, ok := afts.LabelEntry[oc.UnionUint32(l.In)]
+		if !ok {
+			r.add(l.Name, "no AFT label entry for label %d", l.In)
+			continue
+		}
+		nhg, ok := afts.NextHopGroup[e.GetNextHopGroup()]
+		if !ok {
+			r.add(l.Name, "label %d: unknown next hop group %d", l.In, e.GetNextHopGroup())
+			continue
+		}
+		var idx []uint64
+		for i := range nhg.NextHop {
+			idx = append(idx, i)
+		}
+		slices.Sort(idx)
+		var ips []string
+		for _, i := range idx {
+			nh, ok := afts.NextHop[i]
+			if !ok {
+				r.add(l.Name, "label %d: unknown next hop %d", l.In, i)
+				continue
+			}
+			if nh.IpAddress != nil {
+				ips = append(ips, nh.GetIpAddress())
+			}
+			var pushed []uint32
+			for _, u := range nh.PushedMplsLabelStack {
+				if v, ok := labelOf(u); ok && v != ImplicitNull {
+					pushed = append(pushed, v)
+				}
+			}
+			switch l.Op {
+			case Swap:
+				if !slices.Equal(pushed, l.Out) {
+					r.add(l.Name, "label %d: next hop %d pushes %v, want %v", l.In, i, pushed, l.Out)
+				}
+			case Pop:
+				if len(pushed) > 0 {
+					r.add(l.Name, "label %d: next hop %d pushes %v, want none", l.In, i, pushed)
+				}
+			}
+		}
+		if len(l.NextHops) > 0 && len(ips) > 0 && !sameSet(ips, l.NextHops) {
+			r.add(l.Name, "label %d: next hops %v, want %v", l.In, ips, l.NextHops)
+		}
+	}
+	return r
+}
+
+// GetAFT returns the label entries of the AFT of a network instance of dut
+// and the next hop groups and next hops they use.
+func GetAFT(t testing.TB, dut *ondatra.DUTDevice, ni string) *oc.NetworkInstance_Afts {
+	t.Helper()
+	afts := &oc.NetworkInstance_Afts{}
+	path := gnmi.OC().NetworkInstance(ni).Afts()
+	for _, e := range gnmi.GetAll(t, dut, path.LabelEntryAny().State()) {
+		if err := afts.AppendLabelEntry(e); err != nil {
+			t.Fatalf("AFT label entry %v: %v", e.GetLabel(), err)
+		}
+		id := e.GetNextHopGroup()
+		if _, ok := afts.NextHopGroup[id]; ok {
+			continue
+		}
+		nhg := gnmi.Get(t, dut, path.NextHopGroup(id).State())
+		if err := afts.AppendNextHopGroup(nhg); err != nil {
+			t.Fatalf("AFT next hop group %d: %v", id, err)
+		}
+		for i := range nhg.NextHop {
+			if _, ok := afts.NextHop[i]; ok {
+				continue
+			}
+			if err := afts.AppendNextHop(gnmi.Get(t, dut, path.NextHop(i).State())); err != nil {
+				t.Fatalf("AFT next hop %d: %v", i, err)
+			}
+		}
+	}
+	return afts
+}
+
+// Verify checks the static LSPs in the MPLS state and the label entries in
+// the AFT of a network instance of dut against want, reporting mismatches
+// per LSP as test errors.
+func Verify(t testing.TB, dut *ondatra.DUTDevice, ni string, want []*LSP) {
+	t.Helper()
+	got, err := StaticLSPs(gnmi.Get(t, dut, gnmi.OC().NetworkInstance(ni).Mpls().State()))
+	if err != nil {
+		t.Errorf("MPLS state of %s: %v", dut.Name(), err)
+	}
+	r := CompareLSPs(want, got)
+	for name, diffs := range CheckAFT(want, GetAFT(t, dut, ni)) {
+		r[name] = append(r[name], diffs...)
+	}
+	if !r.Empty() {
+		t.Errorf("MPLS LSPs of %s differ:\n%v", dut.Name(), r)
+	}
+}
+
+// VerifyBlocks checks the reserved label blocks of a network instance of dut
+// against want, and that the incoming labels of lsps are allocated from the
+// static block.
+func VerifyBlocks(t testing.TB, dut *ondatra.DUTDevice, ni string, want map[string][2]uint32, lsps []*LSP) {
+	t.Helper()
+	blocks := Blocks(gnmi.Get(t, dut, gnmi.OC().NetworkInstance(ni).Mpls().State()))
+	for _, d := range CompareBlocks(want, blocks) {
+		t.Errorf("%s: %s", dut.Name(), d)
+	}
+	if len(lsps) == 0 {
+		return
+	}
+	if r := CheckAllocation(lsps, blocks[StaticBlock]); !r.Empty() {
+		t.Errorf("Label allocation of %s:\n%v", dut.Name(), r)
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mplsverify
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/google/gopacket/pcapgo"
+	"github.com/openconfig/featureprofiles/internal/otg_helpers/packetvalidationhelpers"
+)
+
+// CheckCapture checks every MPLS packet in a pcap or pcapng capture against
+// want with packetvalidationhelpers.ValidateMPLSPacket.  Packets without
+// MPLS are skipped.
+func CheckCapture(capture []byte, want *packetvalidationhelpers.MPLSLayer) error {
+	var (
+		src      gopacket.PacketDataSource
+		linkType layers.LinkType
+	)
+	if r, err := pcapgo.NewReader(bytes.NewReader(capture)); err == nil {
+		src, linkType = r, r.LinkType()
+	} else if ng, ngErr := pcapgo.NewNgReader(bytes.NewReader(capture), pcapgo.DefaultNgReaderOptions); ngErr == nil {
+		src, linkType = ng, ng.LinkType()
+	} else {
+		return fmt.Errorf("capture is neither pcap (%v) nor pcapng (%v)", err, ngErr)
+	}
+
+	var total, bad int
+	var first error
+	for i := 0; ; i++ {
+		data, _, err := src.ReadPacketData()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("packet %d: %w", i, err)
+		}
+		pkt := gopacket.NewPacket(data, linkType, gopacket.Default)
+		if pkt.Layer(layers.LayerTypeMPLS) == nil {
+			continue
+		}
+		total++
+		if err := packetvalidationhelpers.ValidateMPLSPacket(pkt, want); err != nil {
+			bad++
+			if first == nil {
+				first = fmt.Errorf("packet %d: %w", i, err)
+			}
+		}
+	}
+	if total == 0 {
+		return fmt.Errorf("no MPLS packets captured")
+	}
+	if bad > 0 {
+		return fmt.Errorf("%d of %d MPLS packets differ, e.g. %w", bad, total, first)
+	}
+	return nil
+}
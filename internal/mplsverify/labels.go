@@ -0,0 +1,154 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mplsverify
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/openconfig/ondatra/gnmi/oc"
+)
+
+// StaticBlock is the local ID of the label block static LSPs are allocated
+// from by cfgplugins.LabelRangeOCConfig.
+const StaticBlock = "static"
+
+// Block is a reserved label block.  An upper bound of 0 disables the block.
+type Block struct {
+	LocalID      string
+	Lower, Upper uint32
+}
+
+// Contains reports whether label is in b.
+func (b Block) Contains(label uint32) bool {
+	return b.Upper != 0 && b.Lower <= label && label <= b.Upper
+}
+
+func (b Block) String() string {
+	return fmt.Sprintf("%s [%d, %d]", b.LocalID, b.Lower, b.Upper)
+}
+
+// Blocks returns the reserved label blocks of an MPLS configuration or
+// state, keyed by local ID.
+func Blocks(m *oc.NetworkInstance_Mpls) map[string]Block {
+	blocks := map[string]Block{}
+	for id, rlb := range m.GetGlobal().ReservedLabelBlock {
+		lo, _ := labelOf(rlb.LowerBound)
+		hi, _ := labelOf(rlb.UpperBound)
+		blocks[id] = Block{LocalID: id, Lower: lo, Upper: hi}
+	}
+	return blocks
+}
+
+// CompareBlocks returns how the blocks in got differ from want, which maps
+// local IDs to lower and upper bounds like cfgplugins.LabelRanges().
+func CompareBlocks(want map[string][2]uint32, got map[string]Block) []string {
+	var ids []string
+	for id := range want {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	var diffs []string
+	for _, id := range ids {
+		w := Block{LocalID: id, Lower: want[id][0], Upper: want[id][1]}
+		g, ok := got[id]
+		if !ok {
+			diffs = append(diffs, fmt.Sprintf("label block %s missing", w))
+			continue
+		}
+		if g != w {
+			diffs = append(diffs, fmt.Sprintf("label block %v, want %v", g, w))
+		}
+	}
+	return diffs
+}
+
+// CheckAllocation reports LSPs whose incoming label is reserved by RFC 3032
+// or outside block, and whose outgoing labels are not valid 20 bit labels.
+func CheckAllocation(lsps []*LSP, block Block) Report {
+	r := Report{}
+	for _, l := range lsps {
+		if l.Op != Push {
+			switch {
+			case l.In < MinUnreserved:
+				r.add(l.Name, "incoming label %d is reserved", l.In)
+			case !block.Contains(l.In):
+				r.add(l.Name, "incoming label %d outside label block %v", l.In, block)
+			}
+		}
+		for _, out := range l.Out {
+			if out > MaxLabel {
+				r.add(l.Name, "outgoing label %d exceeds 20 bits", out)
+			}
+		}
+	}
+	return r
+}
+
+// SRGB is a segment routing global block.
+type SRGB struct {
+	Lower, Upper uint32
+}
+
+// Label returns the label of the prefix SID with index in g.
+func (g SRGB) Label(index uint32) (uint32, error) {
+	if g.Lower+index > g.Upper {
+		return 0, fmt.Errorf("SID index %d outside SRGB [%d, %d]", index, g.Lower, g.Upper)
+	}
+	return g.Lower + index, nil
+}
+
+// PrefixSIDLSP returns how a transit router forwards the prefix SID with
+// index towards nextHop: swapping to the label of the index in the SRGB of
+// the next hop, or popping it if the next hop is the penultimate hop and
+// requested PHP.
+func PrefixSIDLSP(name string, local, next SRGB, index uint32, nextHop string, php bool) (*LSP, error) {
+	in, err := local.Label(index)
+	if err != nil {
+		return nil, err
+	}
+	if php {
+		return &LSP{Name: name, Op: Pop, In: in, NextHops: []string{nextHop}}, nil
+	}
+	out, err := next.Label(index)
+	if err != nil {
+		return nil, fmt.Errorf("next hop %s: %w", nextHop, err)
+	}
+	return &LSP{Name: name, Op: Swap, In: in, Out: []uint32{out}, NextHops: []string{nextHop}}, nil
+}
+
+// Pseudowire is a static pseudowire, as configured by
+// cfgplugins.ConfigureMplsStaticPseudowire.
+type Pseudowire struct {
+	Name string
+	// LocalLabel is the label the DUT expects, RemoteLabel the label the
+	// DUT pushes towards the neighbor.
+	LocalLabel, RemoteLabel uint32
+	// Transport are the labels of the LSP to the neighbor, top first.
+	Transport []uint32
+	NextHops  []string
+}
+
+// Imposition returns the LSP frames from the attachment circuit take.
+func (p *Pseudowire) Imposition() *LSP {
+	out := append(append([]uint32(nil), p.Transport...), p.RemoteLabel)
+	return &LSP{Name: p.Name + "-imposition", Op: Push, Out: out, NextHops: p.NextHops}
+}
+
+// Disposition returns the LSP packets from the neighbor take to the
+// attachment circuit.
+func (p *Pseudowire) Disposition() *LSP {
+	return &LSP{Name: p.Name + "-disposition", Op: Pop, In: p.LocalLabel}
+}
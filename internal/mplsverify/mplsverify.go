@@ -0,0 +1,307 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package mplsverify checks MPLS LSPs end to end: the LSPs and label blocks
+// in /network-instances/network-instance/mpls state, the label entries in
+// the AFT, and the label stacks of captured packets.
+//
+// Expected LSPs are derived from the same configuration the test pushes,
+// instead of hard-coding labels per test:
+//
+//	want, err := mplsverify.StaticLSPs(mplsCfg)
+//	if err != nil {
+//		t.Fatal(err)
+//	}
+//	mplsverify.Verify(t, dut, niName, want)
+//	...
+//	capture := ate.OTG().GetCapture(t, gosnappi.NewCaptureRequest().SetPortName("port2"))
+//	stack := &packetvalidationhelpers.MPLSLayer{Stack: want[0].Apply([]uint32{100}), SkipTcCheck: true}
+//	if err := mplsverify.CheckCapture(capture, stack); err != nil {
+//		t.Error(err)
+//	}
+package mplsverify
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/openconfig/ondatra/gnmi/oc"
+)
+
+// Reserved label values.
+const (
+	IPv4ExplicitNull = 0
+	RouterAlert      = 1
+	IPv6ExplicitNull = 2
+	ImplicitNull     = 3
+	EntropyIndicator = 7
+	// MinUnreserved is the lowest label not reserved by RFC 3032.
+	MinUnreserved = 16
+	// MaxLabel is the highest 20 bit label.
+	MaxLabel = 1<<20 - 1
+)
+
+// reservedLabels maps the names of the special label enumerations used by
+// OpenConfig unions to label values.
+var reservedLabels = map[string]uint32{
+	"IPV4_EXPLICIT_NULL":      IPv4ExplicitNull,
+	"ROUTER_ALERT":            RouterAlert,
+	"IPV6_EXPLICIT_NULL":      IPv6ExplicitNull,
+	"IMPLICIT_NULL":           ImplicitNull,
+	"ENTROPY_LABEL_INDICATOR": EntropyIndicator,
+}
+
+// labelOf returns the label value of an OpenConfig label union.  It returns
+// false for unset values and NO_LABEL.
+func labelOf(u any) (uint32, bool) {
+	switch v := u.(type) {
+	case nil:
+		return 0, false
+	case oc.UnionUint32:
+		return uint32(v), true
+	case fmt.Stringer:
+		l, ok := reservedLabels[v.String()]
+		return l, ok
+	}
+	return 0, false
+}
+
+// Op is what an LSP does to the label stack of a packet.
+type Op int
+
+const (
+	// Push adds labels to an unlabeled packet or on top of its stack.
+	Push Op = iota
+	// Swap replaces the top label.
+	Swap
+	// Pop removes the top label.
+	Pop
+)
+
+func (o Op) String() string {
+	switch o {
+	case Push:
+		return "push"
+	case Swap:
+		return "swap"
+	case Pop:
+		return "pop"
+	}
+	return fmt.Sprintf("Op(%d)", int(o))
+}
+
+// LSP is the forwarding of a label switched path on one router.
+type LSP struct {
+	Name string
+	Op   Op
+	// In is the incoming label of swap and pop LSPs.
+	In uint32
+	// Out are the labels pushed, or the labels replacing In for swap, top
+	// of stack first.
+	Out      []uint32
+	NextHops []string
+}
+
+func (l *LSP) String() string {
+	switch l.Op {
+	case Push:
+		return fmt.Sprintf("%s: push %v via %v", l.Name, l.Out, l.NextHops)
+	case Swap:
+		return fmt.Sprintf("%s: swap %d to %v via %v", l.Name, l.In, l.Out, l.NextHops)
+	default:
+		return fmt.Sprintf("%s: pop %d via %v", l.Name, l.In, l.NextHops)
+	}
+}
+
+// Apply returns the label stack, top first, of a packet with stack in after
+// the LSP forwards it.
+func (l *LSP) Apply(in []uint32) []uint32 {
+	switch l.Op {
+	case Push:
+		return append(slices.Clone(l.Out), in...)
+	case Swap:
+		if len(in) == 0 {
+			return nil
+		}
+		return append(slices.Clone(l.Out), in[1:]...)
+	default:
+		if len(in) == 0 {
+			return nil
+		}
+		return slices.Clone(in[1:])
+	}
+}
+
+// staticPath is one of the ingress, transit and egress containers of a
+// static LSP, which share their leaves.
+type staticPath struct {
+	incoming any
+	nextHop  *string
+	push     any
+	hops     map[uint32]staticHop
+}
+
+type staticHop struct {
+	ip   *string
+	push any
+}
+
+// StaticLSPs returns the static LSPs of an MPLS configuration or state,
+// ordered by name.  An LSP without an incoming label pushes, one whose
+// push label is implicit null or unset pops, and any other swaps.
+func StaticLSPs(m *oc.NetworkInstance_Mpls) ([]*LSP, error) {
+	var lsps []*LSP
+	for name, s := range m.GetLsps().StaticLsp {
+		var paths []staticPath
+		if p := s.Ingress; p != nil {
+			sp := staticPath{incoming: p.IncomingLabel, nextHop: p.NextHop, push: p.PushLabel, hops: map[uint32]staticHop{}}
+			for i, h := range p.LspNextHop {
+				sp.hops[i] = staticHop{ip: h.IpAddress, push: h.PushLabel}
+			}
+			paths = append(paths, sp)
+		}
+		if p := s.Transit; p != nil {
+			sp := staticPath{incoming: p.IncomingLabel, nextHop: p.NextHop, push: p.PushLabel, hops: map[uint32]staticHop{}}
+			for i, h := range p.LspNextHop {
+				sp.hops[i] = staticHop{ip: h.IpAddress, push: h.PushLabel}
+			}
+			paths = append(paths, sp)
+		}
+		if p := s.Egress; p != nil {
+			sp := staticPath{incoming: p.IncomingLabel, nextHop: p.NextHop, push: p.PushLabel, hops: map[uint32]staticHop{}}
+			for i, h := range p.LspNextHop {
+				sp.hops[i] = staticHop{ip: h.IpAddress, push: h.PushLabel}
+			}
+			paths = append(paths, sp)
+		}
+		if len(paths) != 1 {
+			return nil, fmt.Errorf("static LSP %s: %d of ingress, transit and egress set, want 1", name, len(paths))
+		}
+		l, err := lspFromPath(name, paths[0])
+		if err != nil {
+			return nil, fmt.Errorf("static LSP %s: %w", name, err)
+		}
+		lsps = append(lsps, l)
+	}
+	sort.Slice(lsps, func(i, j int) bool { return lsps[i].Name < lsps[j].Name })
+	return lsps, nil
+}
+
+func lspFromPath(name string, p staticPath) (*LSP, error) {
+	l := &LSP{Name: name}
+	in, hasIn := labelOf(p.incoming)
+	if p.nextHop != nil {
+		l.NextHops = append(l.NextHops, *p.nextHop)
+	}
+	var push []uint32
+	if v, ok := labelOf(p.push); ok {
+		push = append(push, v)
+	}
+	var idx []uint32
+	for i := range p.hops {
+		idx = append(idx, i)
+	}
+	slices.Sort(idx)
+	for _, i := range idx {
+		h := p.hops[i]
+		if h.ip != nil {
+			l.NextHops = append(l.NextHops, *h.ip)
+		}
+		if v, ok := labelOf(h.push); ok && !slices.Contains(push, v) {
+			push = append(push, v)
+		}
+	}
+	if len(push) > 1 {
+		return nil, fmt.Errorf("next hops push different labels %v", push)
+	}
+	switch {
+	case !hasIn:
+		if len(push) == 0 || push[0] == ImplicitNull {
+			return nil, fmt.Errorf("no incoming label and no label to push")
+		}
+		l.Op, l.Out = Push, push
+	case len(push) == 0 || push[0] == ImplicitNull:
+		l.Op, l.In = Pop, in
+	default:
+		l.Op, l.In, l.Out = Swap, in, push
+	}
+	return l, nil
+}
+
+// Report lists the mismatches found for each LSP.
+type Report map[string][]string
+
+func (r Report) add(lsp, format string, args ...any) {
+	r[lsp] = append(r[lsp], fmt.Sprintf(format, args...))
+}
+
+// Empty reports whether no mismatch was found.
+func (r Report) Empty() bool {
+	return len(r) == 0
+}
+
+func (r Report) String() string {
+	var names []string
+	for n := range r {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	var b strings.Builder
+	for _, n := range names {
+		fmt.Fprintf(&b, "LSP %s:\n", n)
+		for _, m := range r[n] {
+			fmt.Fprintf(&b, "  %s\n", m)
+		}
+	}
+	return b.String()
+}
+
+// CompareLSPs reports how the LSPs in got differ from want.  LSPs only in
+// got are not reported.
+func CompareLSPs(want, got []*LSP) Report {
+	r := Report{}
+	byName := map[string]*LSP{}
+	for _, l := range got {
+		byName[l.Name] = l
+	}
+	for _, w := range want {
+		g, ok := byName[w.Name]
+		if !ok {
+			r.add(w.Name, "missing, want %v", w)
+			continue
+		}
+		if g.Op != w.Op {
+			r.add(w.Name, "operation %v, want %v", g.Op, w.Op)
+		}
+		if w.Op != Push && g.In != w.In {
+			r.add(w.Name, "incoming label %d, want %d", g.In, w.In)
+		}
+		if !slices.Equal(g.Out, w.Out) {
+			r.add(w.Name, "outgoing labels %v, want %v", g.Out, w.Out)
+		}
+		if !sameSet(g.NextHops, w.NextHops) {
+			r.add(w.Name, "next hops %v, want %v", g.NextHops, w.NextHops)
+		}
+	}
+	return r
+}
+
+func sameSet(a, b []string) bool {
+	a, b = slices.Clone(a), slices.Clone(b)
+	slices.Sort(a)
+	slices.Sort(b)
+	return slices.Equal(slices.Compact(a), slices.Compact(b))
+}
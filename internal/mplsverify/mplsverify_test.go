@@ -0,0 +1,321 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mplsverify
+
+import (
+	"bytes"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/google/gopacket/pcapgo"
+	"github.com/openconfig/featureprofiles/internal/otg_helpers/packetvalidationhelpers"
+	"github.com/openconfig/ondatra/gnmi/oc"
+)
+
+func testMpls() *oc.NetworkInstance_Mpls {
+	m := &oc.NetworkInstance_Mpls{}
+	lsps := m.GetOrCreateLsps()
+	pop := lsps.GetOrCreateStaticLsp("pop").GetOrCreateEgress()
+	pop.SetIncomingLabel(oc.UnionUint32(1000))
+	pop.SetNextHop("192.0.2.2")
+	pop.SetPushLabel(oc.Egress_PushLabel_IMPLICIT_NULL)
+	swap := lsps.GetOrCreateStaticLsp("swap").GetOrCreateEgress()
+	swap.SetIncomingLabel(oc.UnionUint32(1001))
+	swap.GetOrCreateLspNextHop(1).SetIpAddress("192.0.2.6")
+	swap.GetOrCreateLspNextHop(1).SetPushLabel(oc.UnionUint32(2001))
+	push := lsps.GetOrCreateStaticLsp("push").GetOrCreateIngress()
+	push.SetNextHop("192.0.2.10")
+	push.SetPushLabel(oc.UnionUint32(3000))
+	return m
+}
+
+func TestStaticLSPs(t *testing.T) {
+	got, err := StaticLSPs(testMpls())
+	if err != nil {
+		t.Fatalf("StaticLSPs() failed: %v", err)
+	}
+	want := []*LSP{
+		{Name: "pop", Op: Pop, In: 1000, NextHops: []string{"192.0.2.2"}},
+		{Name: "push", Op: Push, Out: []uint32{3000}, NextHops: []string{"192.0.2.10"}},
+		{Name: "swap", Op: Swap, In: 1001, Out: []uint32{2001}, NextHops: []string{"192.0.2.6"}},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("StaticLSPs() diff (-want +got):\n%s", diff)
+	}
+
+	m := testMpls()
+	m.GetLsps().GetStaticLsp("pop").GetOrCreateIngress().SetNextHop("192.0.2.2")
+	if _, err := StaticLSPs(m); err == nil || !strings.Contains(err.Error(), "want 1") {
+		t.Errorf("StaticLSPs(ingress and egress) got error %v, want 1 path", err)
+	}
+}
+
+func TestApply(t *testing.T) {
+	tests := []struct {
+		lsp  *LSP
+		in   []uint32
+		want []uint32
+	}{
+		{&LSP{Op: Push, Out: []uint32{16001, 16002}}, nil, []uint32{16001, 16002}},
+		{&LSP{Op: Push, Out: []uint32{16001}}, []uint32{100}, []uint32{16001, 100}},
+		{&LSP{Op: Swap, In: 100, Out: []uint32{200}}, []uint32{100, 300}, []uint32{200, 300}},
+		{&LSP{Op: Pop, In: 100}, []uint32{100, 300}, []uint32{300}},
+		{&LSP{Op: Pop, In: 100}, []uint32{100}, nil},
+	}
+	for _, tc := range tests {
+		if got := tc.lsp.Apply(tc.in); !cmp.Equal(got, tc.want, cmpopts.EquateEmpty()) {
+			t.Errorf("%v.Apply(%v) = %v, want %v", tc.lsp, tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestCompareLSPs(t *testing.T) {
+	want, err := StaticLSPs(testMpls())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r := CompareLSPs(want, want); !r.Empty() {
+		t.Errorf("CompareLSPs(want, want) = %v, want empty", r)
+	}
+	got := []*LSP{
+		{Name: "pop", Op: Swap, In: 1000, Out: []uint32{5}, NextHops: []string{"192.0.2.2"}},
+		{Name: "swap", Op: Swap, In: 1002, Out: []uint32{2001}, NextHops: []string{"192.0.2.6"}},
+	}
+	r := CompareLSPs(want, got)
+	wantReport := Report{
+		"pop":  {"operation swap, want pop", "outgoing labels [5], want []"},
+		"push": {"missing, want push: push [3000] via [192.0.2.10]"},
+		"swap": {"incoming label 1002, want 1001"},
+	}
+	if diff := cmp.Diff(wantReport, r); diff != "" {
+		t.Errorf("CompareLSPs() diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestBlocks(t *testing.T) {
+	m := &oc.NetworkInstance_Mpls{}
+	for id, b := range map[string][2]uint32{"static": {16, 1048560}, "srlb": {16, 0}} {
+		rlb := m.GetOrCreateGlobal().GetOrCreateReservedLabelBlock(id)
+		rlb.SetLowerBound(oc.UnionUint32(b[0]))
+		rlb.SetUpperBound(oc.UnionUint32(b[1]))
+	}
+	blocks := Blocks(m)
+	want := map[string][2]uint32{"static": {16, 1048560}, "srlb": {16, 0}, "isis-sr": {16, 0}}
+	if diff := cmp.Diff([]string{"label block isis-sr [16, 0] missing"}, CompareBlocks(want, blocks)); diff != "" {
+		t.Errorf("CompareBlocks() diff (-want +got):\n%s", diff)
+	}
+	if blocks["srlb"].Contains(16) {
+		t.Errorf("disabled block %v contains 16", blocks["srlb"])
+	}
+
+	lsps := []*LSP{
+		{Name: "ok", Op: Swap, In: 1000, Out: []uint32{2000}},
+		{Name: "reserved", Op: Pop, In: 3},
+		{Name: "push", Op: Push, Out: []uint32{1 << 20}},
+	}
+	r := CheckAllocation(lsps, Block{LocalID: "static", Lower: 100, Upper: 2000})
+	wantReport := Report{
+		"reserved": {"incoming label 3 is reserved"},
+		"push":     {"outgoing label 1048576 exceeds 20 bits"},
+	}
+	if diff := cmp.Diff(wantReport, r); diff != "" {
+		t.Errorf("CheckAllocation() diff (-want +got):\n%s", diff)
+	}
+	r = CheckAllocation(lsps[:1], Block{LocalID: "static", Lower: 100, Upper: 500})
+	if diff := cmp.Diff(Report{"ok": {"incoming label 1000 outside label block static [100, 500]"}}, r); diff != "" {
+		t.Errorf("CheckAllocation() diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestSegmentRouting(t *testing.T) {
+	local, next := SRGB{Lower: 16000, Upper: 23999}, SRGB{Lower: 400000, Upper: 407999}
+	got, err := PrefixSIDLSP("sid-5", local, next, 5, "192.0.2.6", false)
+	if err != nil {
+		t.Fatalf("PrefixSIDLSP() failed: %v", err)
+	}
+	want := &LSP{Name: "sid-5", Op: Swap, In: 16005, Out: []uint32{400005}, NextHops: []string{"192.0.2.6"}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("PrefixSIDLSP() diff (-want +got):\n%s", diff)
+	}
+	if got, _ := PrefixSIDLSP("sid-5", local, next, 5, "192.0.2.6", true); got.Op != Pop {
+		t.Errorf("PrefixSIDLSP(php) = %v, want pop", got)
+	}
+	if _, err := PrefixSIDLSP("sid", local, next, 9000, "192.0.2.6", false); err == nil {
+		t.Errorf("PrefixSIDLSP(index outside SRGB) succeeded, want error")
+	}
+}
+
+func TestPseudowire(t *testing.T) {
+	pw := &Pseudowire{Name: "pw1", LocalLabel: 100, RemoteLabel: 200, Transport: []uint32{16002}, NextHops: []string{"192.0.2.2"}}
+	if got, want := pw.Imposition().Apply(nil), []uint32{16002, 200}; !cmp.Equal(got, want) {
+		t.Errorf("Imposition().Apply() = %v, want %v", got, want)
+	}
+	if got := pw.Disposition().Apply([]uint32{100}); len(got) != 0 {
+		t.Errorf("Disposition().Apply() = %v, want empty stack", got)
+	}
+}
+
+func TestCheckAFT(t *testing.T) {
+	want, err := StaticLSPs(testMpls())
+	if err != nil {
+		t.Fatal(err)
+	}
+	afts := &oc.NetworkInstance_Afts{}
+	afts.GetOrCreateLabelEntry(oc.UnionUint32(1000)).SetNextHopGroup(1)
+	afts.GetOrCreateNextHopGroup(1).GetOrCreateNextHop(11)
+	afts.GetOrCreateNextHop(11).SetIpAddress("192.0.2.2")
+	afts.GetOrCreateLabelEntry(oc.UnionUint32(1001)).SetNextHopGroup(2)
+	afts.GetOrCreateNextHopGroup(2).GetOrCreateNextHop(21)
+	nh := afts.GetOrCreateNextHop(21)
+	nh.SetIpAddress("192.0.2.6")
+	nh.SetPushedMplsLabelStack([]oc.NetworkInstance_Afts_NextHop_PushedMplsLabelStack_Union{oc.UnionUint32(2001)})
+	if r := CheckAFT(want, afts); !r.Empty() {
+		t.Errorf("CheckAFT() = %v, want empty", r)
+	}
+
+	nh.SetPushedMplsLabelStack([]oc.NetworkInstance_Afts_NextHop_PushedMplsLabelStack_Union{oc.UnionUint32(2002)})
+	delete(afts.LabelEntry, oc.UnionUint32(1000))
+	wantReport := Report{
+		"pop":  {"no AFT label entry for label 1000"},
+		"swap": {"label 1001: next hop 21 pushes [2002], want [2001]"},
+	}
+	if diff := cmp.Diff(wantReport, CheckAFT(want, afts)); diff != "" {
+		t.Errorf("CheckAFT() diff (-want +got):\n%s", diff)
+	}
+}
+
+var (
+	// ipv4Payload is an IPv4 header.
+	ipv4Payload = []byte{0x45, 0, 0, 20, 0, 0, 0, 0, 64, 17, 0, 0, 192, 0, 2, 1, 192, 0, 2, 2}
+	// controlWordPayload is a control word of sequence 7 before an IPv4
+	// header.
+	controlWordPayload = append([]byte{0, 0, 0, 7}, ipv4Payload...)
+	// ethernetPayload is an Ethernet frame to a destination MAC whose first
+	// nibble is 0, as a pseudowire without control word carries it.
+	ethernetPayload = []byte{0x02, 0, 0, 0, 0, 2, 0x02, 0, 0, 0, 0, 1, 0x08, 0x00}
+)
+
+// pcapOf returns a pcap of Ethernet frames with the given label stacks, each
+// followed by payload.
+func pcapOf(t *testing.T, payload []byte, stacks ...[]uint32) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w := pcapgo.NewWriter(&buf)
+	if err := w.WriteFileHeader(65535, layers.LinkTypeEthernet); err != nil {
+		t.Fatal(err)
+	}
+	for _, stack := range stacks {
+		ls := []gopacket.SerializableLayer{&layers.Ethernet{
+			SrcMAC:       net.HardwareAddr{2, 0, 0, 0, 0, 1},
+			DstMAC:       net.HardwareAddr{2, 0, 0, 0, 0, 2},
+			EthernetType: layers.EthernetTypeMPLSUnicast,
+		}}
+		for i, l := range stack {
+			ls = append(ls, &layers.MPLS{Label: l, TTL: 64, StackBottom: i == len(stack)-1})
+		}
+		ls = append(ls, gopacket.Payload(payload))
+		sb := gopacket.NewSerializeBuffer()
+		if err := gopacket.SerializeLayers(sb, gopacket.SerializeOptions{}, ls...); err != nil {
+			t.Fatal(err)
+		}
+		data := sb.Bytes()
+		ci := gopacket.CaptureInfo{Timestamp: time.Unix(0, 0), CaptureLength: len(data), Length: len(data)}
+		if err := w.WritePacket(ci, data); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return buf.Bytes()
+}
+
+func TestCheckCapture(t *testing.T) {
+	swap := &LSP{Name: "swap", Op: Swap, In: 100, Out: []uint32{200}}
+	want := swap.Apply([]uint32{100, 300})
+	tests := []struct {
+		desc    string
+		capture []byte
+		want    *packetvalidationhelpers.MPLSLayer
+		wantErr string
+	}{{
+		desc:    "swapped",
+		capture: pcapOf(t, ipv4Payload, want, want),
+		want:    &packetvalidationhelpers.MPLSLayer{Stack: want},
+	}, {
+		desc:    "one not swapped",
+		capture: pcapOf(t, ipv4Payload, want, []uint32{100, 300}),
+		want:    &packetvalidationhelpers.MPLSLayer{Stack: want},
+		wantErr: "1 of 2 MPLS packets differ",
+	}, {
+		desc:    "top label",
+		capture: pcapOf(t, ipv4Payload, want),
+		want:    &packetvalidationhelpers.MPLSLayer{Label: want[0]},
+	}, {
+		desc:    "traffic class",
+		capture: pcapOf(t, ipv4Payload, want),
+		want:    &packetvalidationhelpers.MPLSLayer{Stack: want, Tc: 3},
+		wantErr: "traffic class",
+	}, {
+		desc:    "traffic class skipped",
+		capture: pcapOf(t, ipv4Payload, want),
+		want:    &packetvalidationhelpers.MPLSLayer{Stack: want, Tc: 3, SkipTcCheck: true},
+	}, {
+		desc:    "control word",
+		capture: pcapOf(t, controlWordPayload, want),
+		want:    &packetvalidationhelpers.MPLSLayer{Stack: want, ControlWordHeader: true, ControlWordSequence: 7},
+	}, {
+		desc:    "control word sequence",
+		capture: pcapOf(t, controlWordPayload, want),
+		want:    &packetvalidationhelpers.MPLSLayer{Stack: want, ControlWordHeader: true},
+		wantErr: "control word sequence",
+	}, {
+		desc:    "no control word",
+		capture: pcapOf(t, ipv4Payload, want),
+		want:    &packetvalidationhelpers.MPLSLayer{Stack: want, ControlWordHeader: true},
+		wantErr: "control word header not found",
+	}, {
+		desc:    "ethernet pseudowire",
+		capture: pcapOf(t, ethernetPayload, want),
+		want:    &packetvalidationhelpers.MPLSLayer{Stack: want},
+	}, {
+		desc:    "empty",
+		capture: pcapOf(t, ipv4Payload),
+		want:    &packetvalidationhelpers.MPLSLayer{Stack: want},
+		wantErr: "no MPLS packets",
+	}, {
+		desc:    "garbage",
+		capture: []byte("not a capture"),
+		want:    &packetvalidationhelpers.MPLSLayer{Stack: want},
+		wantErr: "neither pcap",
+	}}
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := CheckCapture(tc.capture, tc.want)
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Errorf("CheckCapture() failed: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("CheckCapture() got error %v, want error containing %q", err, tc.wantErr)
+			}
+		})
+	}
+}
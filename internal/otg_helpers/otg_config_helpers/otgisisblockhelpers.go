@@ -2,10 +2,10 @@ package otgconfighelpers
 
 import (
 	"fmt"
-	"net"
 	"strings"
 
 	"github.com/open-traffic-generator/snappi/gosnappi"
+	"github.com/openconfig/featureprofiles/internal/allocator"
 )
 
 // V4IsisStRouteInfo is a struct that contains the data needed to generate a V4 route in the ISIS topology.
@@ -66,8 +66,8 @@ type GridIsisData struct {
 	col                int
 	systemIDFirstOctet string
 	linkIP4FirstOctet  int
-	nextLinkIP4ToUse   net.IP
 	linkIP6FirstOctet  string
+	registry           *allocator.Registry
 	v4StRoute          *V4IsisStRouteInfo
 	v6StRoute          *V6IsisStRouteInfo
 	linkMultiplier     int
@@ -96,13 +96,15 @@ func (v *GridIsisData) SetSystemIDFirstOctet(firstOctSysID string) *GridIsisData
 // The link IP4 address will be in the format of XX.XX.XX.XX/31 where XX is the first octet.
 func (v *GridIsisData) SetLinkIP4FirstOctet(oct int) *GridIsisData {
 	v.linkIP4FirstOctet = oct
-	v.nextLinkIP4ToUse = net.ParseIP(fmt.Sprintf("%d.0.0.0", oct))
 	return v
 }
 
-// NextLinkIP4ToUse returns a pointer to the next link IP4 address to use.
-func (v *GridIsisData) NextLinkIP4ToUse() *net.IP {
-	return &v.nextLinkIP4ToUse
+// SetRegistry sets the registry the link IP4 subnets of the grid are
+// allocated from. Grids sharing a registry fail to generate if their link
+// IP4 subnets overlap. By default each grid uses its own registry.
+func (v *GridIsisData) SetRegistry(r *allocator.Registry) *GridIsisData {
+	v.registry = r
+	return v
 }
 
 // SetLinkIP6FirstOctet sets the first octet of the link IP6 address.
@@ -151,17 +153,18 @@ type GridIsisTopo struct {
 	gridNodes         [][]int
 	devices           []gosnappi.Device
 	linkIP4FirstOctet int
+	linkIP4Subnets    *allocator.SubnetPool
 	linkIP6FirstOctet string
 	linkMultiplier    int
 }
 
 // Connect connects one of the devices in the grid to the given emulated router.
-func (v *GridIsisTopo) Connect(emuDev gosnappi.Device, rowIdx int, colIdx int, nextLinkIP4ToUse *net.IP) error {
+func (v *GridIsisTopo) Connect(emuDev gosnappi.Device, rowIdx int, colIdx int) error {
 	devIdx := v.gridNodes[rowIdx][colIdx]
 	simDev := v.devices[devIdx]
 	emuIdx := len(v.devices)
 	if err := createLink(emuDev, simDev, emuIdx, devIdx,
-		v.linkIP4FirstOctet, v.linkIP6FirstOctet, 1, nextLinkIP4ToUse); err != nil {
+		v.linkIP4Subnets, v.linkIP6FirstOctet, 1); err != nil {
 		return err
 	}
 
@@ -194,6 +197,18 @@ func (v *GridIsisData) GenerateTopology() (GridIsisTopo, error) {
 		return gridTopo, fmt.Errorf("system ID first octet for ISIS must be configured")
 	}
 
+	if v.linkIP4FirstOctet != 0 {
+		r := v.registry
+		if r == nil {
+			r = allocator.NewRegistry()
+		}
+		subnets, err := r.NewSubnetPool(v.blockName+" links", fmt.Sprintf("%d.0.0.0/8", v.linkIP4FirstOctet), 31)
+		if err != nil {
+			return gridTopo, err
+		}
+		gridTopo.linkIP4Subnets = subnets
+	}
+
 	gridTopo.gridNodes = make([][]int, v.row)
 	for i := range gridTopo.gridNodes {
 		gridTopo.gridNodes[i] = make([]int, v.col)
@@ -217,7 +232,7 @@ func (v *GridIsisData) GenerateTopology() (GridIsisTopo, error) {
 			if colIdx+1 != v.col {
 				val2 := row1[colIdx+1]
 				if err := createLink(gridTopo.devices[val1], gridTopo.devices[val2],
-					val1, val2, v.linkIP4FirstOctet, v.linkIP6FirstOctet, v.linkMultiplier, &v.nextLinkIP4ToUse); err != nil {
+					val1, val2, gridTopo.linkIP4Subnets, v.linkIP6FirstOctet, v.linkMultiplier); err != nil {
 					return gridTopo, err
 				}
 			}
@@ -225,7 +240,7 @@ func (v *GridIsisData) GenerateTopology() (GridIsisTopo, error) {
 				row2 := gridTopo.gridNodes[rowIdx+1]
 				val2 := row2[colIdx]
 				if err := createLink(gridTopo.devices[val1], gridTopo.devices[val2],
-					val1, val2, v.linkIP4FirstOctet, v.linkIP6FirstOctet, v.linkMultiplier, &v.nextLinkIP4ToUse); err != nil {
+					val1, val2, gridTopo.linkIP4Subnets, v.linkIP6FirstOctet, v.linkMultiplier); err != nil {
 					return gridTopo, err
 				}
 			}
@@ -314,7 +329,8 @@ func createSimDev(config gosnappi.Config, nodeIDx int, systemIDFirstOctet string
 }
 
 // createLink creates a simulated link between the two devices in the ISIS topology.
-func createLink(d1 gosnappi.Device, d2 gosnappi.Device, IDx1 int, IDx2 int, linkIP4FirstOctet int, linkIP6FirstOctet string, linkMultiplier int, ipv4Add *net.IP) error {
+// The link IP4 addresses are allocated from linkIP4Subnets if it is set.
+func createLink(d1 gosnappi.Device, d2 gosnappi.Device, IDx1 int, IDx2 int, linkIP4Subnets *allocator.SubnetPool, linkIP6FirstOctet string, linkMultiplier int) error {
 	d1name := d1.Name()
 	d2name := d2.Name()
 
@@ -340,18 +356,18 @@ func createLink(d1 gosnappi.Device, d2 gosnappi.Device, IDx1 int, IDx2 int, link
 		d2.Isis().Interfaces().Add().SetName(isisInf2Name).SetEthName(eth2Name).
 			SetNetworkType(gosnappi.IsisInterfaceNetworkType.POINT_TO_POINT)
 
-		if linkIP4FirstOctet != 0 {
+		if linkIP4Subnets != nil {
 			ip1Name := fmt.Sprintf("%vip4", eth1Name)
 			ip2Name := fmt.Sprintf("%vip4", eth2Name)
-			ip1 := ipv4Add.String()
-
-			*ipv4Add = nextIP(*ipv4Add)
-			if ip4 := (*ipv4Add).To4(); ip4 == nil || ip4[0] != byte(linkIP4FirstOctet) {
-				return fmt.Errorf("no free ipv4 address in the major subnet %d/8", linkIP4FirstOctet)
+			subnet, err := linkIP4Subnets.Next()
+			if err != nil {
+				return fmt.Errorf("no free ipv4 address in the major subnet %s: %w", linkIP4Subnets.Parent(), err)
 			}
-			ip2 := ipv4Add.String()
-
-			*ipv4Add = nextIP(*ipv4Add)
+			a1, a2, err := allocator.LinkAddrs(subnet)
+			if err != nil {
+				return err
+			}
+			ip1, ip2 := a1.String(), a2.String()
 
 			d1eth.Ipv4Addresses().Add().
 				SetName(ip1Name).
@@ -385,17 +401,3 @@ func createLink(d1 gosnappi.Device, d2 gosnappi.Device, IDx1 int, IDx2 int, link
 	}
 	return nil
 }
-
-// nextIP returns the next IPv4 or v6 address in the same subnet.
-func nextIP(ip net.IP) net.IP {
-	next := make(net.IP, len(ip))
-	copy(next, ip)
-
-	for i := len(next) - 1; i >= 0; i-- {
-		next[i]++
-		if next[i] > 0 {
-			break
-		}
-	}
-	return next
-}
@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/open-traffic-generator/snappi/gosnappi"
+	"github.com/openconfig/featureprofiles/internal/allocator"
 	"github.com/openconfig/ondatra"
 )
 
@@ -134,11 +135,12 @@ func createAppendIPV4Flow(t *testing.T, ate *ondatra.ATEDevice, top gosnappi.Con
 	return flow
 }
 
-func createISISBlock(t *testing.T, top gosnappi.Config, block *ISISOTGBlock, emulatedRouterIdx int) gosnappi.Config {
+func createISISBlock(t *testing.T, top gosnappi.Config, block *ISISOTGBlock, emulatedRouterIdx int, registry *allocator.Registry) gosnappi.Config {
 	t.Helper()
 
 	gridSt := NewGridIsisData(top)
-	gridSt.SetRow(block.Row).SetCol(block.Col).
+	gridSt.SetRegistry(registry).
+		SetRow(block.Row).SetCol(block.Col).
 		SetSystemIDFirstOctet(block.ISISIDFirstOct).
 		SetLinkIP4FirstOctet(block.LinkIP4FirstOct).
 		SetLinkMultiplier(block.LinkMultiplier).
@@ -162,7 +164,7 @@ func createISISBlock(t *testing.T, top gosnappi.Config, block *ISISOTGBlock, emu
 	if err != nil {
 		t.Fatalf("failed to generate isis topology for otg: %v", err)
 	}
-	if err := gridTopo.Connect(top.Devices().Items()[emulatedRouterIdx], 0, 1, gridSt.NextLinkIP4ToUse()); err != nil {
+	if err := gridTopo.Connect(top.Devices().Items()[emulatedRouterIdx], 0, 1); err != nil {
 		t.Fatalf("failed to connect isis topology to the emultaed router in the otg: %v", err)
 	}
 
@@ -174,6 +176,9 @@ func createISISBlock(t *testing.T, top gosnappi.Config, block *ISISOTGBlock, emu
 func ConfigureATE(t *testing.T, ate *ondatra.ATEDevice, ateData *ATEData) gosnappi.Config {
 	t.Helper()
 	top := gosnappi.NewConfig()
+	// The link subnets of all ISIS blocks are allocated from one registry, so
+	// that blocks with overlapping link subnets fail here.
+	registry := allocator.NewRegistry()
 
 	var pmd100GFRPorts []string
 	for i, l := range ateData.Lags {
@@ -209,7 +214,7 @@ func ConfigureATE(t *testing.T, ate *ondatra.ATEDevice, ateData *ATEData) gosnap
 				emulatedRouterIdx := len(top.Devices().Items()) - 1
 				for _, b := range er.ISISBlocks {
 					t.Logf("Creating ISIS block %s and connecting it to the emulated router %s in the topology", b.Name, er.Name)
-					top = createISISBlock(t, top, b, emulatedRouterIdx)
+					top = createISISBlock(t, top, b, emulatedRouterIdx, registry)
 				}
 			}
 		}
@@ -4,6 +4,7 @@ package packetvalidationhelpers
 import (
 	"fmt"
 	"os"
+	"slices"
 	"testing"
 
 	"github.com/google/gopacket"
@@ -155,8 +156,15 @@ type MacsecLayer struct {
 
 // MPLSLayer holds MPLS layer properties
 type MPLSLayer struct {
-	Label               uint32
-	Tc                  uint8
+	Label uint32
+	// Stack, when set, is the whole label stack, top first, and Label is
+	// ignored.
+	Stack       []uint32
+	Tc          uint8
+	SkipTcCheck bool
+	// ControlWordHeader requires a pseudowire control word after the bottom
+	// of stack, with sequence number ControlWordSequence.  The payload is not
+	// checked otherwise.
 	ControlWordHeader   bool
 	ControlWordSequence uint32
 }
@@ -477,32 +485,63 @@ func validateMPLSLayer(t *testing.T, packetSource *gopacket.PacketSource, packet
 	t.Log("Validating MPLS layer")
 
 	for packet := range packetSource.Packets() {
-		if mplsLayer := packet.Layer(layers.LayerTypeMPLS); mplsLayer != nil {
-			mpls, _ := mplsLayer.(*layers.MPLS)
+		if packet.Layer(layers.LayerTypeMPLS) == nil {
+			continue
+		}
+		if err := ValidateMPLSPacket(packet, packetVal.MPLSLayer); err != nil {
+			return err
+		}
+		if packetVal.MPLSLayer.ControlWordHeader {
+			t.Logf("Control word with sequence %d follows the MPLS label stack", packetVal.MPLSLayer.ControlWordSequence)
+		}
+		// If validation is successful for one packet, we can return.
+		return nil
+	}
+	return fmt.Errorf("no MPLS packets found")
+}
 
-			if mpls.Label != packetVal.MPLSLayer.Label {
-				return fmt.Errorf("mpls label is not set properly. expected: %d, Actual: %d", packetVal.MPLSLayer.Label, mpls.Label)
-			}
-			if mpls.TrafficClass != packetVal.MPLSLayer.Tc {
-				return fmt.Errorf("mpls traffic class is not set properly. expected: %d, actual: %d", packetVal.MPLSLayer.Tc, mpls.TrafficClass)
-			}
+// ValidateMPLSPacket validates the MPLS layers of a packet.
+func ValidateMPLSPacket(packet gopacket.Packet, mplsLayer *MPLSLayer) error {
+	var stack []uint32
+	var top, bottom *layers.MPLS
+	for _, l := range packet.Layers() {
+		mpls, ok := l.(*layers.MPLS)
+		if !ok {
+			continue
+		}
+		if top == nil {
+			top = mpls
+		}
+		bottom = mpls
+		stack = append(stack, mpls.Label)
+	}
+	if top == nil {
+		return fmt.Errorf("no MPLS layer found")
+	}
 
-			if packetVal.MPLSLayer.ControlWordHeader {
-				if len(mpls.Payload) >= 4 {
-					controlWord := mpls.Payload[:4]
-					if uint16(controlWord[0])<<8|uint16(controlWord[1]) == uint16(packetVal.MPLSLayer.ControlWordSequence) {
-						t.Logf("%v (32-bit field ) control word is inserted between the MPLS label stack and the Layer 2 payload (the Ethernet frame).0", packetVal.MPLSLayer.ControlWordSequence)
-					}
-				} else {
-					t.Errorf("Control Word header not found")
-				}
-			}
+	if mplsLayer.Stack != nil {
+		if !slices.Equal(stack, mplsLayer.Stack) {
+			return fmt.Errorf("mpls label stack is not set properly. expected: %v, actual: %v", mplsLayer.Stack, stack)
+		}
+	} else if top.Label != mplsLayer.Label {
+		return fmt.Errorf("mpls label is not set properly. expected: %d, Actual: %d", mplsLayer.Label, top.Label)
+	}
+	if !mplsLayer.SkipTcCheck && top.TrafficClass != mplsLayer.Tc {
+		return fmt.Errorf("mpls traffic class is not set properly. expected: %d, actual: %d", mplsLayer.Tc, top.TrafficClass)
+	}
 
-			// If validation is successful for one packet, we can return.
-			return nil
+	if mplsLayer.ControlWordHeader {
+		// RFC 4385: the first nibble of the control word is 0 and its last
+		// 16 bits are the sequence number.
+		controlWord := bottom.Payload
+		if len(controlWord) < 4 || controlWord[0]>>4 != 0 {
+			return fmt.Errorf("control word header not found after label stack %v", stack)
+		}
+		if seq := uint32(controlWord[2])<<8 | uint32(controlWord[3]); seq != mplsLayer.ControlWordSequence {
+			return fmt.Errorf("control word sequence is not set properly. expected: %d, actual: %d", mplsLayer.ControlWordSequence, seq)
 		}
 	}
-	return fmt.Errorf("no MPLS packets found")
+	return nil
 }
 
 // validateTCPHeader validates the TCP header.
@@ -23,6 +23,19 @@ import (
 	"github.com/openconfig/ygnmi/ygnmi"
 )
 
+// FlowCounters are the ATE counters of a flow.
+type FlowCounters struct {
+	TxPkts uint64
+	RxPkts uint64
+}
+
+// GetFlowCounters returns the current counters of the given flow.
+func GetFlowCounters(t testing.TB, otg *otg.OTG, flowName string) FlowCounters {
+	t.Helper()
+	c := gnmi.Get(t, otg, gnmi.OTG().Flow(flowName).Counters().State())
+	return FlowCounters{TxPkts: c.GetOutPkts(), RxPkts: c.GetInPkts()}
+}
+
 // GetFlowStats checks to see if all the flows are completely stopped and returns tx and rx packets for the given flow
 func GetFlowStats(t testing.TB, otg *otg.OTG, flowName string, timeout time.Duration) (txPackets, rxPackets uint64) {
 	flow := gnmi.OTG().Flow(flowName)
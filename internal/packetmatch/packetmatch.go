@@ -0,0 +1,232 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package packetmatch holds the header matching shared by the offline
+// evaluators of ACLs (aclverify) and policy-forwarding policies (pfsim):
+// protocol numbers, MAC and port matching, and the search for a packet
+// hitting a rule over candidate values of its fields.
+package packetmatch
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"slices"
+)
+
+// IP protocol numbers.
+const (
+	ProtocolICMP   = 1
+	ProtocolIPinIP = 4
+	ProtocolTCP    = 6
+	ProtocolUDP    = 17
+	ProtocolIPv6   = 41
+	ProtocolGRE    = 47
+	ProtocolICMPv6 = 58
+)
+
+// Ethertypes of IP packets.
+const (
+	EtherTypeIPv4 = 0x0800
+	EtherTypeIPv6 = 0x86dd
+)
+
+// ParseMAC parses a 48 bit MAC address.
+func ParseMAC(s string) (net.HardwareAddr, error) {
+	mac, err := net.ParseMAC(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(mac) != 6 {
+		return nil, fmt.Errorf("invalid MAC %q", s)
+	}
+	return mac, nil
+}
+
+// MACMatches reports whether addr matches the MAC of a rule under mask,
+// which defaults to all ones.  An empty rule matches any address.
+func MACMatches(addr, rule, mask string) bool {
+	if rule == "" {
+		return true
+	}
+	a, err := ParseMAC(addr)
+	if err != nil {
+		return false
+	}
+	r, _ := ParseMAC(rule)
+	m := net.HardwareAddr{0xff, 0xff, 0xff, 0xff, 0xff, 0xff}
+	if mask != "" {
+		m, _ = ParseMAC(mask)
+	}
+	for i := range a {
+		if a[i]&m[i] != r[i]&m[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// PortRange is an inclusive range of transport ports.  The zero value
+// matches any port.
+type PortRange struct {
+	Lo, Hi uint16
+}
+
+// Any reports whether r matches any port.
+func (r PortRange) Any() bool {
+	return r == PortRange{}
+}
+
+// Contains reports whether r matches port p.
+func (r PortRange) Contains(p uint16) bool {
+	return r.Any() || r.Lo <= p && p <= r.Hi
+}
+
+func (r PortRange) String() string {
+	if r.Lo == r.Hi {
+		return fmt.Sprint(r.Lo)
+	}
+	return fmt.Sprintf("%d..%d", r.Lo, r.Hi)
+}
+
+// defaultAddrs are documentation addresses tried for unset address fields.
+var defaultAddrs = map[bool][]string{
+	false: {"198.51.100.1", "203.0.113.1", "192.0.2.1", "100.64.0.1"},
+	true:  {"2001:db8:1::1", "2001:db8:2::1", "2001:db8::1", "fd00::1"},
+}
+
+// DefaultAddr returns the i-th address tried for unset address fields.
+func DefaultAddr(is6 bool, i int) netip.Addr {
+	return netip.MustParseAddr(defaultAddrs[is6][i])
+}
+
+// AddrCandidates returns the addresses tried for an address field matching
+// prefixes: the address offset into, the last and the first address of
+// each prefix, or documentation addresses if prefixes is empty.
+func AddrCandidates(is6 bool, offset uint64, prefixes []netip.Prefix) []netip.Addr {
+	var addrs []netip.Addr
+	if len(prefixes) == 0 {
+		for _, s := range defaultAddrs[is6] {
+			addrs = append(addrs, netip.MustParseAddr(s))
+		}
+		return addrs
+	}
+	for _, p := range prefixes {
+		p = p.Masked()
+		for _, a := range []netip.Addr{AddrAt(p, offset), LastAddr(p), p.Addr()} {
+			if !slices.Contains(addrs, a) {
+				addrs = append(addrs, a)
+			}
+		}
+	}
+	return addrs
+}
+
+// AddrAt returns the address offset into p, or the first address of p if p
+// is smaller.
+func AddrAt(p netip.Prefix, offset uint64) netip.Addr {
+	host := p.Addr().BitLen() - p.Bits()
+	if host < 64 && offset >= 1<<host {
+		return p.Addr()
+	}
+	b := p.Addr().As16()
+	for i := 15; i >= 0 && offset > 0; i-- {
+		sum := uint64(b[i]) + offset&0xff
+		b[i] = byte(sum)
+		offset = offset>>8 + sum>>8
+	}
+	addr := netip.AddrFrom16(b)
+	if p.Addr().Is4() {
+		addr = addr.Unmap()
+	}
+	return addr
+}
+
+// LastAddr returns the last address of p.
+func LastAddr(p netip.Prefix) netip.Addr {
+	b := p.Addr().As16()
+	host := p.Addr().BitLen() - p.Bits()
+	for i := 15; i >= 0 && host > 0; i-- {
+		n := min(host, 8)
+		b[i] |= byte(1<<n - 1)
+		host -= n
+	}
+	addr := netip.AddrFrom16(b)
+	if p.Addr().Is4() {
+		addr = addr.Unmap()
+	}
+	return addr
+}
+
+// PortCandidates returns the ports tried for a port field matching ranges:
+// the bounds of each range, or ephemeral and edge ports if ranges is empty.
+func PortCandidates(ranges []PortRange) []uint16 {
+	var ports []uint16
+	for _, r := range ranges {
+		if r.Any() {
+			continue
+		}
+		for _, p := range []uint16{r.Lo, r.Hi} {
+			if !slices.Contains(ports, p) {
+				ports = append(ports, p)
+			}
+		}
+	}
+	if len(ports) == 0 {
+		return []uint16{49152, 1024, 65535}
+	}
+	return ports
+}
+
+// Values returns a setter of a field of packet P for each candidate value.
+func Values[P, T any](vals []T, set func(*P, T)) []func(*P) {
+	var opts []func(*P)
+	for _, v := range vals {
+		opts = append(opts, func(p *P) { set(p, v) })
+	}
+	return opts
+}
+
+// maxCandidates bounds the packets Search tries.
+const maxCandidates = 1 << 14
+
+// Search returns the first packet built from one setter of each dimension
+// of dims for which hit returns true, or nil if none of the first
+// candidates does.
+func Search[P any](dims [][]func(*P), hit func(*P) bool) *P {
+	// Walk the cartesian product of the candidates like an odometer, the
+	// first dimension turning fastest.
+	idx := make([]int, len(dims))
+	for tried := 0; tried < maxCandidates; tried++ {
+		p := new(P)
+		for i, d := range dims {
+			d[idx[i]](p)
+		}
+		if hit(p) {
+			return p
+		}
+		i := 0
+		for ; i < len(dims); i++ {
+			idx[i]++
+			if idx[i] < len(dims[i]) {
+				break
+			}
+			idx[i] = 0
+		}
+		if i == len(dims) {
+			break
+		}
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package packetmatch
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestMACMatches(t *testing.T) {
+	tests := []struct {
+		addr, rule, mask string
+		want             bool
+	}{
+		{"02:00:00:00:00:01", "", "", true},
+		{"02:00:00:00:00:01", "02:00:00:00:00:01", "", true},
+		{"02:00:00:00:00:02", "02:00:00:00:00:01", "", false},
+		{"02:00:00:00:01:42", "02:00:00:00:01:00", "ff:ff:ff:ff:ff:00", true},
+		{"02:00:00:00:02:42", "02:00:00:00:01:00", "ff:ff:ff:ff:ff:00", false},
+		{"not a mac", "02:00:00:00:00:01", "", false},
+	}
+	for _, tc := range tests {
+		if got := MACMatches(tc.addr, tc.rule, tc.mask); got != tc.want {
+			t.Errorf("MACMatches(%q, %q, %q) = %v, want %v", tc.addr, tc.rule, tc.mask, got, tc.want)
+		}
+	}
+}
+
+func TestAddrCandidates(t *testing.T) {
+	tests := []struct {
+		desc     string
+		is6      bool
+		offset   uint64
+		prefixes []string
+		want     []string
+	}{{
+		desc:     "ipv4",
+		offset:   2,
+		prefixes: []string{"192.0.2.0/28", "192.0.2.64/31"},
+		want:     []string{"192.0.2.2", "192.0.2.15", "192.0.2.0", "192.0.2.64", "192.0.2.65"},
+	}, {
+		desc:     "host",
+		offset:   1,
+		prefixes: []string{"192.0.2.7/32"},
+		want:     []string{"192.0.2.7"},
+	}, {
+		desc:     "ipv6",
+		is6:      true,
+		offset:   1,
+		prefixes: []string{"2001:db8::/120"},
+		want:     []string{"2001:db8::1", "2001:db8::ff", "2001:db8::"},
+	}, {
+		desc: "defaults",
+		is6:  true,
+		want: []string{"2001:db8:1::1", "2001:db8:2::1", "2001:db8::1", "fd00::1"},
+	}}
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			var prefixes []netip.Prefix
+			for _, p := range tc.prefixes {
+				prefixes = append(prefixes, netip.MustParsePrefix(p))
+			}
+			var got []string
+			for _, a := range AddrCandidates(tc.is6, tc.offset, prefixes) {
+				got = append(got, a.String())
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("AddrCandidates() diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPortCandidates(t *testing.T) {
+	if diff := cmp.Diff([]uint16{80, 8000, 8080}, PortCandidates([]PortRange{{80, 80}, {8000, 8080}})); diff != "" {
+		t.Errorf("PortCandidates() diff (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]uint16{49152, 1024, 65535}, PortCandidates([]PortRange{{}})); diff != "" {
+		t.Errorf("PortCandidates(any) diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestSearch(t *testing.T) {
+	type packet struct{ a, b int }
+	dims := [][]func(*packet){
+		Values([]int{1, 2, 3}, func(p *packet, v int) { p.a = v }),
+		Values([]int{10, 20}, func(p *packet, v int) { p.b = v }),
+	}
+	if got, want := Search(dims, func(p *packet) bool { return p.a == 3 && p.b == 20 }), (&packet{3, 20}); *got != *want {
+		t.Errorf("Search() = %+v, want %+v", got, want)
+	}
+	if got := Search(dims, func(p *packet) bool { return p.a == 4 }); got != nil {
+		t.Errorf("Search(no hit) = %+v, want nil", got)
+	}
+}
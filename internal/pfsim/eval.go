@@ -0,0 +1,378 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pfsim
+
+import (
+	"fmt"
+	"net/netip"
+	"slices"
+
+	"github.com/openconfig/featureprofiles/internal/packetmatch"
+	"github.com/openconfig/ondatra/gnmi/oc"
+)
+
+// Packet is the header fields of an IP packet a policy matches on.
+type Packet struct {
+	SrcMAC, DstMAC   string
+	Src, Dst         netip.Addr
+	Protocol         uint8
+	DSCP             uint8
+	HopLimit         uint8
+	SrcPort, DstPort uint16
+	TCPFlags         []oc.E_PacketMatchTypes_TCP_FLAGS
+	ICMPType         uint8
+	// Inner is the encapsulated header of IP in IP packets, whose protocol
+	// is packetmatch.ProtocolIPinIP or packetmatch.ProtocolIPv6.
+	Inner *Packet
+}
+
+// EtherType returns the ethertype of p.
+func (p *Packet) EtherType() uint16 {
+	if p.Src.Is6() {
+		return packetmatch.EtherTypeIPv6
+	}
+	return packetmatch.EtherTypeIPv4
+}
+
+func (p *Packet) String() string {
+	s := fmt.Sprintf("%v > %v proto %d dscp %d hop-limit %d", p.Src, p.Dst, p.Protocol, p.DSCP, p.HopLimit)
+	switch p.Protocol {
+	case packetmatch.ProtocolTCP, packetmatch.ProtocolUDP:
+		s += fmt.Sprintf(" ports %d > %d", p.SrcPort, p.DstPort)
+		if len(p.TCPFlags) > 0 {
+			s += fmt.Sprintf(" flags %v", p.TCPFlags)
+		}
+	case packetmatch.ProtocolICMP, packetmatch.ProtocolICMPv6:
+		s += fmt.Sprintf(" type %d", p.ICMPType)
+	}
+	if p.Inner != nil {
+		s += fmt.Sprintf(" [%v]", p.Inner)
+	}
+	return s
+}
+
+func inAny(addr netip.Addr, prefixes []netip.Prefix) bool {
+	return slices.ContainsFunc(prefixes, func(p netip.Prefix) bool { return p.Contains(addr) })
+}
+
+func portMatches(port uint16, ranges []packetmatch.PortRange) bool {
+	return ranges == nil || slices.ContainsFunc(ranges, func(r packetmatch.PortRange) bool { return r.Contains(port) })
+}
+
+func (m *ipMatch) matches(p *Packet) bool {
+	if m.src != nil && !inAny(p.Src, m.src) || m.srcSet != nil && !inAny(p.Src, m.srcSet) {
+		return false
+	}
+	if m.dst != nil && !inAny(p.Dst, m.dst) || m.dstSet != nil && !inAny(p.Dst, m.dstSet) {
+		return false
+	}
+	if m.dscp != nil && !slices.Contains(m.dscp, p.DSCP) {
+		return false
+	}
+	if m.protocol != nil && p.Protocol != *m.protocol {
+		return false
+	}
+	if m.hopLimit != nil && p.HopLimit != *m.hopLimit {
+		return false
+	}
+	return m.icmpType == nil || p.ICMPType == *m.icmpType
+}
+
+func (r *rule) matches(p *Packet) bool {
+	if !p.Src.IsValid() {
+		return false
+	}
+	is6 := p.Src.Is6()
+	switch {
+	case is6 && r.v6 != nil:
+		if !r.v6.matches(p) {
+			return false
+		}
+	case !is6 && r.v4 != nil:
+		if !r.v4.matches(p) {
+			return false
+		}
+	case r.v4 != nil || r.v6 != nil:
+		// The rule only matches the other family.
+		return false
+	}
+	if r.etherType != 0 && p.EtherType() != r.etherType {
+		return false
+	}
+	if !packetmatch.MACMatches(p.SrcMAC, r.srcMAC, r.srcMACMask) || !packetmatch.MACMatches(p.DstMAC, r.dstMAC, r.dstMACMask) {
+		return false
+	}
+	if r.srcPort != nil || r.dstPort != nil || len(r.tcpFlags) > 0 {
+		if p.Protocol != packetmatch.ProtocolTCP && p.Protocol != packetmatch.ProtocolUDP {
+			return false
+		}
+		if !portMatches(p.SrcPort, r.srcPort) || !portMatches(p.DstPort, r.dstPort) {
+			return false
+		}
+		for _, f := range r.tcpFlags {
+			if p.Protocol != packetmatch.ProtocolTCP || !slices.Contains(p.TCPFlags, f) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Evaluate returns the first rule of the policy p matches, or nil if it
+// matches none.
+func (s *Simulator) Evaluate(p *Packet) *oc.NetworkInstance_PolicyForwarding_Policy_Rule {
+	if r := s.evaluate(p); r != nil {
+		return r.oc
+	}
+	return nil
+}
+
+func (s *Simulator) evaluate(p *Packet) *rule {
+	for _, r := range s.rules {
+		if r.matches(p) {
+			return r
+		}
+	}
+	return nil
+}
+
+// Result is how a policy forwards a packet.
+type Result struct {
+	// Rule is the rule the packet hits, or nil if it hits none.
+	Rule *oc.NetworkInstance_PolicyForwarding_Policy_Rule
+	// Discard reports whether the packet is dropped.
+	Discard bool
+	// Decapsulated reports whether the outer header is removed, in which
+	// case the inner packet is forwarded.
+	Decapsulated bool
+	// NetworkInstance is the network instance the packet is forwarded in.
+	NetworkInstance string
+	// NextHop and NextHopGroup are set if the rule redirects to them.
+	NextHop, NextHopGroup string
+}
+
+func (r Result) String() string {
+	seq := "no rule"
+	if r.Rule != nil {
+		seq = fmt.Sprintf("rule %d", r.Rule.GetSequenceId())
+	}
+	switch {
+	case r.Discard:
+		return seq + ": discard"
+	case r.NextHopGroup != "":
+		return fmt.Sprintf("%s: next hop group %s", seq, r.NextHopGroup)
+	case r.NextHop != "":
+		return fmt.Sprintf("%s: next hop %s", seq, r.NextHop)
+	case r.Decapsulated:
+		return fmt.Sprintf("%s: decapsulate to %s", seq, r.NetworkInstance)
+	}
+	return fmt.Sprintf("%s: forward in %s", seq, r.NetworkInstance)
+}
+
+// hasDecap reports whether the lookup of dst in ni hits a decapsulation
+// entry.
+func (s *Simulator) hasDecap(ni string, dst netip.Addr) bool {
+	return inAny(dst, s.decap[ni])
+}
+
+// Forward returns how the policy forwards p.  Rules with a decapsulation
+// network instance decapsulate IP in IP packets whose destination has a
+// decapsulation entry added with AddDecap in it, and forward the inner
+// packet in the post-decapsulation network instance.  Other packets hitting
+// them are forwarded in the fallback network instance.
+func (s *Simulator) Forward(p *Packet) Result {
+	r := s.Evaluate(p)
+	res := Result{Rule: r, NetworkInstance: s.NetworkInstance}
+	if r == nil {
+		return res
+	}
+	a := r.GetAction()
+	switch {
+	case a.GetDiscard():
+		res.Discard = true
+	case a.DecapNetworkInstance != nil:
+		ipInIP := p.Protocol == packetmatch.ProtocolIPinIP || p.Protocol == packetmatch.ProtocolIPv6
+		if ipInIP && s.hasDecap(a.GetDecapNetworkInstance(), p.Dst) {
+			res.Decapsulated = true
+			if a.PostDecapNetworkInstance != nil {
+				res.NetworkInstance = a.GetPostDecapNetworkInstance()
+			}
+		} else if a.DecapFallbackNetworkInstance != nil {
+			res.NetworkInstance = a.GetDecapFallbackNetworkInstance()
+		}
+	case a.GetDecapsulateGre() || a.GetDecapsulateGue() || a.GetDecapsulateMplsInUdp():
+		res.Decapsulated = true
+		if a.PostDecapNetworkInstance != nil {
+			res.NetworkInstance = a.GetPostDecapNetworkInstance()
+		}
+	case a.NetworkInstance != nil:
+		res.NetworkInstance = a.GetNetworkInstance()
+	}
+	res.NextHop, res.NextHopGroup = a.GetNextHop(), a.GetNextHopGroup()
+	return res
+}
+
+// Sample returns a packet that hits rule seq of the policy, from srcMAC to
+// dstMAC unless the rule matches on MACs.  Packets are tried from the
+// edges of the prefixes and ranges of the rule, and with values of other
+// fields outside those of earlier rules; it fails if no tried packet gets
+// past the earlier rules.
+func (s *Simulator) Sample(seq uint32, srcMAC, dstMAC string) (*Packet, error) {
+	i := slices.IndexFunc(s.rules, func(r *rule) bool { return r.seq == seq })
+	if i < 0 {
+		return nil, fmt.Errorf("no rule %d", seq)
+	}
+	r := s.rules[i]
+	var families []bool
+	switch {
+	case r.etherType == packetmatch.EtherTypeIPv4:
+		families = []bool{false}
+	case r.etherType == packetmatch.EtherTypeIPv6:
+		families = []bool{true}
+	case r.v4 != nil && r.v6 == nil:
+		families = []bool{false}
+	case r.v6 != nil && r.v4 == nil:
+		families = []bool{true}
+	default:
+		families = []bool{false, true}
+	}
+	for _, is6 := range families {
+		if p := s.sample(r, is6, srcMAC, dstMAC); p != nil {
+			return p, nil
+		}
+	}
+	return nil, fmt.Errorf("rule %d is shadowed by earlier rules", seq)
+}
+
+func (s *Simulator) sample(r *rule, is6 bool, srcMAC, dstMAC string) *Packet {
+	m := r.v4
+	if is6 {
+		m = r.v6
+	}
+	if m == nil {
+		m = &ipMatch{}
+	}
+	var dims [][]func(*Packet)
+	add := func(opts ...func(*Packet)) { dims = append(dims, opts) }
+
+	add(func(p *Packet) {
+		p.SrcMAC, p.DstMAC = srcMAC, dstMAC
+		if r.srcMAC != "" {
+			p.SrcMAC = r.srcMAC
+		}
+		if r.dstMAC != "" {
+			p.DstMAC = r.dstMAC
+		}
+	})
+	add(packetmatch.Values(addrCandidates(is6, 1, m.src, m.srcSet), func(p *Packet, a netip.Addr) { p.Src = a })...)
+	add(packetmatch.Values(addrCandidates(is6, 2, m.dst, m.dstSet), func(p *Packet, a netip.Addr) { p.Dst = a })...)
+
+	var protos []uint8
+	switch {
+	case m.protocol != nil:
+		protos = []uint8{*m.protocol}
+	case r.srcPort != nil || r.dstPort != nil || len(r.tcpFlags) > 0:
+		protos = []uint8{packetmatch.ProtocolTCP, packetmatch.ProtocolUDP}
+	case is6:
+		protos = []uint8{packetmatch.ProtocolUDP, packetmatch.ProtocolTCP, packetmatch.ProtocolICMPv6}
+	default:
+		protos = []uint8{packetmatch.ProtocolUDP, packetmatch.ProtocolTCP, packetmatch.ProtocolICMP}
+	}
+	add(packetmatch.Values(protos, func(p *Packet, v uint8) { p.Protocol = v })...)
+
+	dscps := m.dscp
+	if dscps == nil {
+		dscps = s.unusedDSCPs()
+	}
+	add(packetmatch.Values(dscps, func(p *Packet, v uint8) { p.DSCP = v })...)
+	hopLimits := []uint8{64, 255, 2}
+	if m.hopLimit != nil {
+		hopLimits = []uint8{*m.hopLimit}
+	}
+	add(packetmatch.Values(hopLimits, func(p *Packet, v uint8) { p.HopLimit = v })...)
+	add(packetmatch.Values(packetmatch.PortCandidates(r.srcPort), func(p *Packet, v uint16) { p.SrcPort = v })...)
+	add(packetmatch.Values(packetmatch.PortCandidates(r.dstPort), func(p *Packet, v uint16) { p.DstPort = v })...)
+
+	flagSets := [][]oc.E_PacketMatchTypes_TCP_FLAGS{{oc.PacketMatchTypes_TCP_FLAGS_TCP_SYN}, {oc.PacketMatchTypes_TCP_FLAGS_TCP_ACK}}
+	if len(r.tcpFlags) > 0 {
+		flagSets = [][]oc.E_PacketMatchTypes_TCP_FLAGS{r.tcpFlags}
+	}
+	add(packetmatch.Values(flagSets, func(p *Packet, v []oc.E_PacketMatchTypes_TCP_FLAGS) {
+		if p.Protocol == packetmatch.ProtocolTCP {
+			p.TCPFlags = v
+		}
+	})...)
+	icmpTypes := []uint8{8, 0}
+	if is6 {
+		icmpTypes = []uint8{128, 129}
+	}
+	if m.icmpType != nil {
+		icmpTypes = []uint8{*m.icmpType}
+	}
+	add(packetmatch.Values(icmpTypes, func(p *Packet, v uint8) {
+		if p.Protocol == packetmatch.ProtocolICMP || p.Protocol == packetmatch.ProtocolICMPv6 {
+			p.ICMPType = v
+		}
+	})...)
+
+	return packetmatch.Search(dims, func(p *Packet) bool {
+		addInner(p)
+		return s.evaluate(p) == r
+	})
+}
+
+// addInner gives IP in IP packets an inner header.
+func addInner(p *Packet) {
+	switch p.Protocol {
+	case packetmatch.ProtocolIPinIP:
+		p.Inner = &Packet{Src: netip.MustParseAddr("198.18.0.1"), Dst: netip.MustParseAddr("198.18.1.1"), Protocol: packetmatch.ProtocolUDP, HopLimit: 64, SrcPort: 49152, DstPort: 49152}
+	case packetmatch.ProtocolIPv6:
+		p.Inner = &Packet{Src: netip.MustParseAddr("2001:db8:18::1"), Dst: netip.MustParseAddr("2001:db8:18:1::1"), Protocol: packetmatch.ProtocolUDP, HopLimit: 64, SrcPort: 49152, DstPort: 49152}
+	}
+	if p.Inner != nil {
+		p.Inner.DSCP = p.DSCP
+	}
+}
+
+// unusedDSCPs returns DSCPs for rules that do not match on DSCP, starting
+// with the lowest not matched by any rule.
+func (s *Simulator) unusedDSCPs() []uint8 {
+	used := map[uint8]bool{}
+	for _, r := range s.rules {
+		for _, m := range []*ipMatch{r.v4, r.v6} {
+			if m != nil {
+				for _, d := range m.dscp {
+					used[d] = true
+				}
+			}
+		}
+	}
+	for d := uint8(0); d < 64; d++ {
+		if !used[d] {
+			return []uint8{d}
+		}
+	}
+	return []uint8{0}
+}
+
+// addrCandidates returns the addresses tried for an address field.  An
+// address must be in both the prefix and the set of a field that has both,
+// so only the prefix is used.
+func addrCandidates(is6 bool, offset uint64, prefixes, set []netip.Prefix) []netip.Addr {
+	if prefixes == nil {
+		prefixes = set
+	}
+	return packetmatch.AddrCandidates(is6, offset, prefixes)
+}
@@ -0,0 +1,201 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pfsim
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/open-traffic-generator/snappi/gosnappi"
+	"github.com/openconfig/featureprofiles/internal/otgutils"
+	"github.com/openconfig/featureprofiles/internal/packetmatch"
+	"github.com/openconfig/ondatra/gnmi/oc"
+)
+
+// lossTolerancePct is the loss forwarded flows may see.
+const lossTolerancePct = 1
+
+// FlowSpec describes the flows AddFlows generates.
+type FlowSpec struct {
+	// Prefix is prepended to the flow names, which end with the rule
+	// sequence number.
+	Prefix string
+	TxPort string
+	// Egress maps the network instances packets are forwarded in to the
+	// port the DUT forwards them out of.  A flow is received on the port
+	// of its expected network instance, so that its loss shows whether it
+	// took the expected forwarding.
+	Egress map[string]string
+	// SrcMAC is the source MAC of rules that do not match on it, and DstMAC
+	// the destination MAC, normally that of the DUT port.
+	SrcMAC, DstMAC string
+	// Packets is the number of packets of each flow, sent at PPS packets
+	// per second.
+	Packets   uint32
+	PPS       uint64
+	FrameSize uint32
+}
+
+// RuleFlow is a flow sent at a rule, and how the policy forwards it.
+type RuleFlow struct {
+	Flow   string
+	Seq    uint32
+	Packet *Packet
+	Want   Result
+	// RxPort is the port the flow is expected on, empty if it is dropped.
+	RxPort string
+}
+
+// AddFlows adds to top a flow of packets hitting each rule of the policy.
+func (s *Simulator) AddFlows(top gosnappi.Config, spec FlowSpec) ([]RuleFlow, error) {
+	var flows []RuleFlow
+	for _, r := range s.rules {
+		p, err := s.Sample(r.seq, spec.SrcMAC, spec.DstMAC)
+		if err != nil {
+			return nil, err
+		}
+		f := RuleFlow{Flow: fmt.Sprintf("%s%d", spec.Prefix, r.seq), Seq: r.seq, Packet: p, Want: s.Forward(p)}
+		var rxNames []string
+		if !f.Want.Discard {
+			port, ok := spec.Egress[f.Want.NetworkInstance]
+			if !ok {
+				return nil, fmt.Errorf("rule %d forwards in network instance %s, which has no egress port", r.seq, f.Want.NetworkInstance)
+			}
+			f.RxPort = port
+			rxNames = []string{port}
+		} else {
+			for _, port := range spec.Egress {
+				if !slices.Contains(rxNames, port) {
+					rxNames = append(rxNames, port)
+				}
+			}
+			slices.Sort(rxNames)
+		}
+		addFlow(top, f.Flow, p, rxNames, spec)
+		flows = append(flows, f)
+	}
+	return flows, nil
+}
+
+func addFlow(top gosnappi.Config, name string, p *Packet, rxNames []string, spec FlowSpec) {
+	flow := top.Flows().Add().SetName(name)
+	flow.Metrics().SetEnable(true)
+	flow.TxRx().Port().SetTxName(spec.TxPort).SetRxNames(rxNames)
+	flow.Duration().FixedPackets().SetPackets(spec.Packets)
+	if spec.PPS > 0 {
+		flow.Rate().SetPps(spec.PPS)
+	}
+	if spec.FrameSize > 0 {
+		flow.Size().SetFixed(spec.FrameSize)
+	}
+
+	eth := flow.Packet().Add().Ethernet()
+	eth.Src().SetValue(p.SrcMAC)
+	eth.Dst().SetValue(p.DstMAC)
+	for h := p; h != nil; h = h.Inner {
+		addIP(flow, h)
+	}
+	inner := p
+	for inner.Inner != nil {
+		inner = inner.Inner
+	}
+	switch inner.Protocol {
+	case packetmatch.ProtocolTCP:
+		tcp := flow.Packet().Add().Tcp()
+		tcp.SrcPort().SetValue(uint32(inner.SrcPort))
+		tcp.DstPort().SetValue(uint32(inner.DstPort))
+		for _, f := range inner.TCPFlags {
+			switch f {
+			case oc.PacketMatchTypes_TCP_FLAGS_TCP_SYN:
+				tcp.CtlSyn().SetValue(1)
+			case oc.PacketMatchTypes_TCP_FLAGS_TCP_ACK:
+				tcp.CtlAck().SetValue(1)
+			case oc.PacketMatchTypes_TCP_FLAGS_TCP_FIN:
+				tcp.CtlFin().SetValue(1)
+			case oc.PacketMatchTypes_TCP_FLAGS_TCP_RST:
+				tcp.CtlRst().SetValue(1)
+			case oc.PacketMatchTypes_TCP_FLAGS_TCP_PSH:
+				tcp.CtlPsh().SetValue(1)
+			case oc.PacketMatchTypes_TCP_FLAGS_TCP_URG:
+				tcp.CtlUrg().SetValue(1)
+			case oc.PacketMatchTypes_TCP_FLAGS_TCP_ECE:
+				tcp.EcnEcho().SetValue(1)
+			case oc.PacketMatchTypes_TCP_FLAGS_TCP_CWR:
+				tcp.EcnCwr().SetValue(1)
+			}
+		}
+	case packetmatch.ProtocolUDP:
+		udp := flow.Packet().Add().Udp()
+		udp.SrcPort().SetValue(uint32(inner.SrcPort))
+		udp.DstPort().SetValue(uint32(inner.DstPort))
+	case packetmatch.ProtocolICMP:
+		echo := flow.Packet().Add().Icmp().Echo()
+		echo.Type().SetValue(uint32(inner.ICMPType))
+	case packetmatch.ProtocolICMPv6:
+		echo := flow.Packet().Add().Icmpv6().Echo()
+		echo.Type().SetValue(uint32(inner.ICMPType))
+	}
+}
+
+func addIP(flow gosnappi.Flow, p *Packet) {
+	if p.Src.Is4() {
+		ip := flow.Packet().Add().Ipv4()
+		ip.Src().SetValue(p.Src.String())
+		ip.Dst().SetValue(p.Dst.String())
+		ip.Priority().Dscp().Phb().SetValue(uint32(p.DSCP))
+		ip.TimeToLive().SetValue(uint32(p.HopLimit))
+		ip.Protocol().SetValue(uint32(p.Protocol))
+		return
+	}
+	ip := flow.Packet().Add().Ipv6()
+	ip.Src().SetValue(p.Src.String())
+	ip.Dst().SetValue(p.Dst.String())
+	ip.TrafficClass().SetValue(uint32(p.DSCP) << 2)
+	ip.HopLimit().SetValue(uint32(p.HopLimit))
+	ip.NextHeader().SetValue(uint32(p.Protocol))
+}
+
+// Check compares the counters of a run of flows with how the policy
+// forwards them.  Forwarded flows must be received on their egress port
+// and discarded flows must not be received.  If after is not nil, the
+// matched-packets counters of each rule, read before and after the run,
+// must have counted the packets sent at it.
+func Check(flows []RuleFlow, counters map[string]otgutils.FlowCounters, before, after map[uint32]uint64) []error {
+	var errs []error
+	for _, f := range flows {
+		c := counters[f.Flow]
+		if c.TxPkts == 0 {
+			errs = append(errs, fmt.Errorf("flow %s: sent no packets", f.Flow))
+			continue
+		}
+		if after != nil {
+			if got, ok := after[f.Seq]; !ok {
+				errs = append(errs, fmt.Errorf("rule %d: no matched-packets counter", f.Seq))
+			} else if got-before[f.Seq] < c.TxPkts {
+				errs = append(errs, fmt.Errorf("rule %d: matched %d packets, want >= %d sent by flow %s (%v)", f.Seq, got-before[f.Seq], c.TxPkts, f.Flow, f.Packet))
+			}
+		}
+		lossPct := float64(c.TxPkts-min(c.RxPkts, c.TxPkts)) * 100 / float64(c.TxPkts)
+		switch {
+		case f.Want.Discard:
+			if c.RxPkts > 0 {
+				errs = append(errs, fmt.Errorf("flow %s: %v, but %d packets were received", f.Flow, f.Want, c.RxPkts))
+			}
+		case lossPct > lossTolerancePct:
+			errs = append(errs, fmt.Errorf("flow %s: %v, but it lost %.2f%% of packets on %s (%v)", f.Flow, f.Want, lossPct, f.RxPort, f.Packet))
+		}
+	}
+	return errs
+}
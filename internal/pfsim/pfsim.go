@@ -0,0 +1,406 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package pfsim simulates policy-forwarding policies offline.  It finds the
+// rule of an OpenConfig policy a packet hits and the network instance the
+// packet is forwarded in, and generates an OTG flow hitting each rule, so
+// that tests derive the expected forwarding of their flows from the policy
+// they push instead of by hand:
+//
+//	pf := vrfpolicy.BuildVRFSelectionPolicyW(t, dut, niName)
+//	sim, err := pfsim.New(niName, pf.GetPolicy(vrfpolicy.VRFPolicyW), nil)
+//	if err != nil {
+//		t.Fatal(err)
+//	}
+//	sim.AddDecap("DECAP_TE_VRF", "192.51.100.64/32")
+//	flows, err := sim.AddFlows(top, pfsim.FlowSpec{
+//		TxPort: "port1",
+//		Egress: map[string]string{"ENCAP_TE_VRF_A": "port2", "DEFAULT": "port3"},
+//		...
+//	})
+package pfsim
+
+import (
+	"fmt"
+	"net/netip"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/openconfig/featureprofiles/internal/packetmatch"
+	"github.com/openconfig/ondatra/gnmi/oc"
+)
+
+// protocols maps OpenConfig IP protocol names to numbers.
+var protocols = map[string]uint8{
+	"IP_ICMP":  packetmatch.ProtocolICMP,
+	"IP_IGMP":  2,
+	"IP_IN_IP": packetmatch.ProtocolIPinIP,
+	"IP_TCP":   packetmatch.ProtocolTCP,
+	"IP_UDP":   packetmatch.ProtocolUDP,
+	"IP_RSVP":  46,
+	"IP_GRE":   packetmatch.ProtocolGRE,
+	"IP_AUTH":  51,
+	"IP_PIM":   103,
+	"IP_L2TP":  115,
+}
+
+// icmpTypes maps OpenConfig ICMP type names to numbers, by IP version.
+var icmpTypes = map[bool]map[string]uint8{
+	false: {
+		"ECHO_REPLY":           0,
+		"DST_UNREACHABLE":      3,
+		"REDIRECT":             5,
+		"ECHO":                 8,
+		"ROUTER_ADVERTISEMENT": 9,
+		"ROUTER_SOLICITATION":  10,
+		"TIME_EXCEEDED":        11,
+		"PARAM_PROBLEM":        12,
+		"TIMESTAMP":            13,
+		"TIMESTAMP_REPLY":      14,
+		"TRACEROUTE":           30,
+		"PHOTURIS":             40,
+		"EXT_ECHO_REQUEST":     42,
+		"EXT_ECHO_REPLY":       43,
+	},
+	true: {
+		"DESTINATION_UNREACHABLE":   1,
+		"PACKET_TOO_BIG":            2,
+		"TIME_EXCEEDED":             3,
+		"PARAMETER_PROBLEM":         4,
+		"ECHO_REQUEST":              128,
+		"ECHO_REPLY":                129,
+		"MULTICAST_LISTENER_QUERY":  130,
+		"MULTICAST_LISTENER_REPORT": 131,
+		"MULTICAST_LISTENER_DONE":   132,
+		"ROUTER_SOLICITATION":       133,
+		"ROUTER_ADVERTISEMENT":      134,
+		"NEIGHBOR_SOLICITATION":     135,
+		"NEIGHBOR_ADVERTISEMENT":    136,
+		"REDIRECT":                  137,
+		"EXT_ECHO_REQUEST":          160,
+		"EXT_ECHO_REPLY":            161,
+	},
+}
+
+// ipMatch is the ipv4 or ipv6 container of a rule.  Nil slices and
+// pointers match any value.
+type ipMatch struct {
+	src, srcSet []netip.Prefix
+	dst, dstSet []netip.Prefix
+	dscp        []uint8
+	protocol    *uint8
+	hopLimit    *uint8
+	icmpType    *uint8
+}
+
+// rule is a policy rule, with the matches parsed.
+type rule struct {
+	seq uint32
+	oc  *oc.NetworkInstance_PolicyForwarding_Policy_Rule
+	// v4 and v6 are the ipv4 and ipv6 containers.  A rule with neither
+	// matches both families, one with one of them only its family.
+	v4, v6             *ipMatch
+	etherType          uint16
+	srcMAC, srcMACMask string
+	dstMAC, dstMACMask string
+	srcPort, dstPort   []packetmatch.PortRange
+	tcpFlags           []oc.E_PacketMatchTypes_TCP_FLAGS
+}
+
+// Simulator evaluates a policy-forwarding policy.
+type Simulator struct {
+	// NetworkInstance is the network instance the policy is applied in,
+	// which forwards packets no rule redirects.
+	NetworkInstance string
+	Policy          *oc.NetworkInstance_PolicyForwarding_Policy
+	rules           []*rule
+	// decap are the prefixes with a decapsulation entry, by network
+	// instance.
+	decap map[string][]netip.Prefix
+}
+
+// New returns a simulator of policy p applied in network instance ni.  The
+// prefix and port sets the rules refer to are looked up in sets, which may
+// be nil if there are none.  It fails if a rule matches on a field the
+// simulator does not model.
+func New(ni string, p *oc.NetworkInstance_PolicyForwarding_Policy, sets *oc.DefinedSets) (*Simulator, error) {
+	s := &Simulator{NetworkInstance: ni, Policy: p, decap: map[string][]netip.Prefix{}}
+	var seqs []uint32
+	for seq := range p.Rule {
+		seqs = append(seqs, seq)
+	}
+	slices.Sort(seqs)
+	for _, seq := range seqs {
+		r, err := parseRule(seq, p.Rule[seq], sets)
+		if err != nil {
+			return nil, fmt.Errorf("policy %s rule %d: %w", p.GetPolicyId(), seq, err)
+		}
+		s.rules = append(s.rules, r)
+	}
+	return s, nil
+}
+
+// AddDecap records that the lookup of destinations in prefixes in network
+// instance ni hits a decapsulation entry, as installed by gRIBI or static
+// configuration.
+func (s *Simulator) AddDecap(ni string, prefixes ...string) error {
+	for _, p := range prefixes {
+		pfx, err := netip.ParsePrefix(p)
+		if err != nil {
+			return err
+		}
+		s.decap[ni] = append(s.decap[ni], pfx.Masked())
+	}
+	return nil
+}
+
+func parseRule(seq uint32, r *oc.NetworkInstance_PolicyForwarding_Policy_Rule, sets *oc.DefinedSets) (*rule, error) {
+	pr := &rule{seq: seq, oc: r}
+	var err error
+	if v4 := r.Ipv4; v4 != nil {
+		if len(v4.FragmentOffsets) > 0 || v4.Length != nil {
+			return nil, fmt.Errorf("unsupported ipv4 match on fragment offsets or length")
+		}
+		var icmpType any
+		if v4.Icmpv4 != nil {
+			if v4.Icmpv4.Code != oc.Icmpv4Types_CODE_UNSET {
+				return nil, fmt.Errorf("unsupported ipv4 match on ICMP code")
+			}
+			if v4.Icmpv4.Type != oc.Icmpv4Types_TYPE_UNSET {
+				icmpType = v4.Icmpv4.Type
+			}
+		}
+		pr.v4, err = parseIP(false, ipFields{
+			src: v4.SourceAddress, srcSet: v4.SourceAddressPrefixSet,
+			dst: v4.DestinationAddress, dstSet: v4.DestinationAddressPrefixSet,
+			dscp: v4.Dscp, dscpSet: v4.DscpSet, protocol: v4.Protocol, hopLimit: v4.HopLimit, icmpType: icmpType,
+		}, sets)
+		if err != nil {
+			return nil, fmt.Errorf("ipv4: %w", err)
+		}
+	}
+	if v6 := r.Ipv6; v6 != nil {
+		if v6.Length != nil || v6.SourceFlowLabel != nil || v6.DestinationFlowLabel != nil {
+			return nil, fmt.Errorf("unsupported ipv6 match on length or flow label")
+		}
+		var icmpType any
+		if v6.Icmpv6 != nil {
+			if v6.Icmpv6.Code != oc.Icmpv6Types_CODE_UNSET {
+				return nil, fmt.Errorf("unsupported ipv6 match on ICMP code")
+			}
+			if v6.Icmpv6.Type != oc.Icmpv6Types_TYPE_UNSET {
+				icmpType = v6.Icmpv6.Type
+			}
+		}
+		pr.v6, err = parseIP(true, ipFields{
+			src: v6.SourceAddress, srcSet: v6.SourceAddressPrefixSet,
+			dst: v6.DestinationAddress, dstSet: v6.DestinationAddressPrefixSet,
+			dscp: v6.Dscp, dscpSet: v6.DscpSet, protocol: v6.Protocol, hopLimit: v6.HopLimit, icmpType: icmpType,
+		}, sets)
+		if err != nil {
+			return nil, fmt.Errorf("ipv6: %w", err)
+		}
+	}
+	if l2 := r.L2; l2 != nil {
+		pr.srcMAC, pr.srcMACMask = l2.GetSourceMac(), l2.GetSourceMacMask()
+		pr.dstMAC, pr.dstMACMask = l2.GetDestinationMac(), l2.GetDestinationMacMask()
+		for _, mac := range []string{pr.srcMAC, pr.srcMACMask, pr.dstMAC, pr.dstMACMask} {
+			if _, err := packetmatch.ParseMAC(mac); mac != "" && err != nil {
+				return nil, err
+			}
+		}
+		switch et := l2.Ethertype.(type) {
+		case nil:
+		case oc.UnionUint16:
+			pr.etherType = uint16(et)
+		case oc.E_PacketMatchTypes_ETHERTYPE:
+			switch et {
+			case oc.PacketMatchTypes_ETHERTYPE_ETHERTYPE_IPV4:
+				pr.etherType = packetmatch.EtherTypeIPv4
+			case oc.PacketMatchTypes_ETHERTYPE_ETHERTYPE_IPV6:
+				pr.etherType = packetmatch.EtherTypeIPv6
+			default:
+				return nil, fmt.Errorf("unsupported ethertype %v", et)
+			}
+		}
+	}
+	if tr := r.Transport; tr != nil {
+		if tr.BuiltinDetail != oc.Transport_BuiltinDetail_UNSET || tr.DetailMode != oc.Transport_DetailMode_UNSET {
+			return nil, fmt.Errorf("unsupported transport match on builtin detail")
+		}
+		if pr.srcPort, err = parsePorts(tr.SourcePort, tr.SourcePortSet, sets); err != nil {
+			return nil, fmt.Errorf("source port: %w", err)
+		}
+		if pr.dstPort, err = parsePorts(tr.DestinationPort, tr.DestinationPortSet, sets); err != nil {
+			return nil, fmt.Errorf("destination port: %w", err)
+		}
+		pr.tcpFlags = tr.ExplicitTcpFlags
+	}
+	return pr, nil
+}
+
+// ipFields are the leaves shared by the ipv4 and ipv6 containers.
+type ipFields struct {
+	src, srcSet, dst, dstSet *string
+	dscp                     *uint8
+	dscpSet                  []uint8
+	protocol                 any
+	hopLimit                 *uint8
+	icmpType                 any
+}
+
+func parseIP(is6 bool, f ipFields, sets *oc.DefinedSets) (*ipMatch, error) {
+	m := &ipMatch{hopLimit: f.hopLimit}
+	var err error
+	if m.src, err = parsePrefixes(is6, f.src); err != nil {
+		return nil, err
+	}
+	if m.dst, err = parsePrefixes(is6, f.dst); err != nil {
+		return nil, err
+	}
+	if m.srcSet, err = prefixSet(is6, f.srcSet, sets); err != nil {
+		return nil, err
+	}
+	if m.dstSet, err = prefixSet(is6, f.dstSet, sets); err != nil {
+		return nil, err
+	}
+	if f.dscp != nil {
+		m.dscp = append(m.dscp, *f.dscp)
+	}
+	m.dscp = append(m.dscp, f.dscpSet...)
+	switch p := f.protocol.(type) {
+	case nil:
+	case oc.UnionUint8:
+		v := uint8(p)
+		m.protocol = &v
+	case fmt.Stringer:
+		v, ok := protocols[p.String()]
+		if !ok {
+			return nil, fmt.Errorf("unsupported protocol %v", p)
+		}
+		m.protocol = &v
+	}
+	if t, ok := f.icmpType.(fmt.Stringer); ok {
+		v, ok := icmpTypes[is6][t.String()]
+		if !ok {
+			return nil, fmt.Errorf("unsupported ICMP type %v", t)
+		}
+		m.icmpType = &v
+		icmp := uint8(packetmatch.ProtocolICMP)
+		if is6 {
+			icmp = packetmatch.ProtocolICMPv6
+		}
+		if m.protocol == nil {
+			m.protocol = &icmp
+		} else if *m.protocol != icmp {
+			return nil, fmt.Errorf("ICMP type with protocol %d", *m.protocol)
+		}
+	}
+	return m, nil
+}
+
+func parsePrefixes(is6 bool, s *string, more ...string) ([]netip.Prefix, error) {
+	var strs []string
+	if s != nil {
+		strs = append(strs, *s)
+	}
+	strs = append(strs, more...)
+	var prefixes []netip.Prefix
+	for _, s := range strs {
+		p, err := netip.ParsePrefix(s)
+		if err != nil {
+			return nil, err
+		}
+		if p.Addr().Is6() != is6 {
+			return nil, fmt.Errorf("prefix %s of the wrong address family", s)
+		}
+		prefixes = append(prefixes, p.Masked())
+	}
+	return prefixes, nil
+}
+
+func prefixSet(is6 bool, name *string, sets *oc.DefinedSets) ([]netip.Prefix, error) {
+	if name == nil {
+		return nil, nil
+	}
+	var prefixes []string
+	switch {
+	case !is6 && sets.GetIpv4PrefixSet(*name) != nil:
+		prefixes = sets.GetIpv4PrefixSet(*name).Prefix
+	case is6 && sets.GetIpv6PrefixSet(*name) != nil:
+		prefixes = sets.GetIpv6PrefixSet(*name).Prefix
+	default:
+		return nil, fmt.Errorf("unknown prefix set %q", *name)
+	}
+	if len(prefixes) == 0 {
+		return nil, fmt.Errorf("empty prefix set %q", *name)
+	}
+	return parsePrefixes(is6, nil, prefixes...)
+}
+
+// parsePort parses a port union: a port, a "lo..hi" range or ANY.  It
+// returns nil for ANY.
+func parsePort(u any) ([]packetmatch.PortRange, error) {
+	switch v := u.(type) {
+	case nil:
+		return nil, nil
+	case oc.UnionUint16:
+		return []packetmatch.PortRange{{Lo: uint16(v), Hi: uint16(v)}}, nil
+	case oc.UnionString:
+		lo, hi, ok := strings.Cut(string(v), "..")
+		if !ok {
+			return nil, fmt.Errorf("invalid port range %q", v)
+		}
+		l, err := strconv.ParseUint(lo, 10, 16)
+		if err != nil {
+			return nil, fmt.Errorf("invalid port range %q", v)
+		}
+		h, err := strconv.ParseUint(hi, 10, 16)
+		if err != nil || h < l {
+			return nil, fmt.Errorf("invalid port range %q", v)
+		}
+		return []packetmatch.PortRange{{Lo: uint16(l), Hi: uint16(h)}}, nil
+	case fmt.Stringer:
+		if v.String() == "ANY" {
+			return nil, nil
+		}
+	}
+	return nil, fmt.Errorf("unsupported port %v", u)
+}
+
+func parsePorts(port any, set *string, sets *oc.DefinedSets) ([]packetmatch.PortRange, error) {
+	ranges, err := parsePort(port)
+	if err != nil || set == nil {
+		return ranges, err
+	}
+	if ranges != nil {
+		return nil, fmt.Errorf("both a port and port set %q", *set)
+	}
+	ps := sets.GetPortSet(*set)
+	if ps == nil {
+		return nil, fmt.Errorf("unknown port set %q", *set)
+	}
+	for _, p := range ps.Port {
+		r, err := parsePort(p)
+		if err != nil {
+			return nil, fmt.Errorf("port set %q: %w", *set, err)
+		}
+		if r == nil {
+			return nil, nil
+		}
+		ranges = append(ranges, r...)
+	}
+	return ranges, nil
+}
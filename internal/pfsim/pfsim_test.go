@@ -0,0 +1,279 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pfsim
+
+import (
+	"net/netip"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/open-traffic-generator/snappi/gosnappi"
+	"github.com/openconfig/featureprofiles/internal/otgutils"
+	"github.com/openconfig/featureprofiles/internal/packetmatch"
+	"github.com/openconfig/ondatra/gnmi/oc"
+	"github.com/openconfig/ygot/ygot"
+)
+
+const (
+	src111 = "198.51.100.111/32"
+	src222 = "198.51.100.222/32"
+)
+
+// testPolicy returns the first rules of vrf_selection_policy_c: decapsulate
+// IP in IP by DSCP and source, then select an encap VRF by DSCP.
+func testPolicy() *oc.NetworkInstance_PolicyForwarding_Policy {
+	pf := &oc.NetworkInstance_PolicyForwarding{}
+	p := pf.GetOrCreatePolicy("vrf_selection_policy_c")
+	p.SetType(oc.Policy_Type_VRF_SELECTION_POLICY)
+	decap := func(seq uint32, proto uint8, dscp []uint8, src, post, fallback string) {
+		r := p.GetOrCreateRule(seq)
+		ip := r.GetOrCreateIpv4()
+		ip.Protocol = oc.UnionUint8(proto)
+		ip.DscpSet = dscp
+		ip.SourceAddress = ygot.String(src)
+		a := r.GetOrCreateAction()
+		a.DecapNetworkInstance = ygot.String("DECAP_TE_VRF")
+		a.PostDecapNetworkInstance = ygot.String(post)
+		a.DecapFallbackNetworkInstance = ygot.String(fallback)
+	}
+	decap(1, 4, []uint8{10, 18}, src222, "ENCAP_TE_VRF_A", "TE_VRF_222")
+	decap(2, 41, []uint8{10, 18}, src222, "ENCAP_TE_VRF_A", "TE_VRF_222")
+	decap(3, 4, []uint8{10, 18}, src111, "ENCAP_TE_VRF_A", "TE_VRF_111")
+	decap(9, 4, nil, src222, "DEFAULT", "TE_VRF_222")
+	r := p.GetOrCreateRule(13)
+	r.GetOrCreateIpv4().DscpSet = []uint8{10, 18}
+	r.GetOrCreateAction().NetworkInstance = ygot.String("ENCAP_TE_VRF_A")
+	r = p.GetOrCreateRule(14)
+	r.GetOrCreateIpv6().DscpSet = []uint8{10, 18}
+	r.GetOrCreateAction().NetworkInstance = ygot.String("ENCAP_TE_VRF_A")
+	r = p.GetOrCreateRule(15)
+	r.GetOrCreateIpv4().Protocol = oc.PacketMatchTypes_IP_PROTOCOL_IP_UDP
+	r.GetOrCreateTransport().DestinationPort = oc.UnionString("5000..5999")
+	r.GetOrCreateAction().Discard = ygot.Bool(true)
+	p.GetOrCreateRule(17).GetOrCreateAction().NetworkInstance = ygot.String("DEFAULT")
+	return p
+}
+
+func newSim(t *testing.T) *Simulator {
+	t.Helper()
+	s, err := New("DEFAULT", testPolicy(), nil)
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	if err := s.AddDecap("DECAP_TE_VRF", "192.51.100.64/32"); err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func TestForward(t *testing.T) {
+	s := newSim(t)
+	addr := netip.MustParseAddr
+	tests := []struct {
+		desc string
+		p    *Packet
+		want string
+	}{{
+		desc: "decapsulated",
+		p:    &Packet{Src: addr("198.51.100.222"), Dst: addr("192.51.100.64"), Protocol: 4, DSCP: 18},
+		want: "rule 1: decapsulate to ENCAP_TE_VRF_A",
+	}, {
+		desc: "no decap entry",
+		p:    &Packet{Src: addr("198.51.100.111"), Dst: addr("192.51.100.65"), Protocol: 4, DSCP: 10},
+		want: "rule 3: forward in TE_VRF_111",
+	}, {
+		desc: "decapsulated without DSCP match",
+		p:    &Packet{Src: addr("198.51.100.222"), Dst: addr("192.51.100.64"), Protocol: 4, DSCP: 0},
+		want: "rule 9: decapsulate to DEFAULT",
+	}, {
+		desc: "encap VRF by DSCP",
+		p:    &Packet{Src: addr("198.51.100.111"), Dst: addr("198.18.0.1"), Protocol: packetmatch.ProtocolUDP, DSCP: 10, DstPort: 5500},
+		want: "rule 13: forward in ENCAP_TE_VRF_A",
+	}, {
+		desc: "IPv6 by DSCP",
+		p:    &Packet{Src: addr("2001:db8::1"), Dst: addr("2001:db8::2"), Protocol: packetmatch.ProtocolTCP, DSCP: 18},
+		want: "rule 14: forward in ENCAP_TE_VRF_A",
+	}, {
+		desc: "discarded port range",
+		p:    &Packet{Src: addr("198.51.100.111"), Dst: addr("198.18.0.1"), Protocol: packetmatch.ProtocolUDP, DstPort: 5999},
+		want: "rule 15: discard",
+	}, {
+		desc: "catch all",
+		p:    &Packet{Src: addr("2001:db8::1"), Dst: addr("2001:db8::2"), Protocol: packetmatch.ProtocolUDP},
+		want: "rule 17: forward in DEFAULT",
+	}}
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := s.Forward(tc.p).String(); got != tc.want {
+				t.Errorf("Forward(%v) = %q, want %q", tc.p, got, tc.want)
+			}
+		})
+	}
+
+	delete(s.Policy.Rule, 17)
+	s, err := New("DEFAULT", s.Policy, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := &Packet{Src: addr("2001:db8::1"), Dst: addr("2001:db8::2"), Protocol: packetmatch.ProtocolUDP}
+	if got, want := s.Forward(p).String(), "no rule: forward in DEFAULT"; got != want {
+		t.Errorf("Forward(%v) without catch all = %q, want %q", p, got, want)
+	}
+}
+
+func TestSample(t *testing.T) {
+	s := newSim(t)
+	for _, r := range s.rules {
+		p, err := s.Sample(r.seq, "02:00:01:01:01:01", "02:00:02:01:01:01")
+		if err != nil {
+			t.Errorf("Sample(%d) failed: %v", r.seq, err)
+			continue
+		}
+		if got := s.Evaluate(p); got != r.oc {
+			t.Errorf("Sample(%d) = %v, which hits rule %d", r.seq, p, got.GetSequenceId())
+		}
+	}
+
+	// Rule 9 matches everything rule 10 does.
+	r := s.Policy.GetOrCreateRule(10)
+	r.GetOrCreateIpv4().Protocol = oc.UnionUint8(4)
+	r.GetIpv4().SourceAddress = ygot.String(src222)
+	r.GetOrCreateAction().NetworkInstance = ygot.String("DEFAULT")
+	s, err := New("DEFAULT", s.Policy, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Sample(10, "02:00:01:01:01:01", "02:00:02:01:01:01"); err == nil || !strings.Contains(err.Error(), "shadowed") {
+		t.Errorf("Sample(shadowed rule) got error %v, want shadowed", err)
+	}
+}
+
+func TestNewErrors(t *testing.T) {
+	tests := []struct {
+		desc    string
+		rule    func(*oc.NetworkInstance_PolicyForwarding_Policy_Rule)
+		wantErr string
+	}{{
+		desc: "wrong family",
+		rule: func(r *oc.NetworkInstance_PolicyForwarding_Policy_Rule) {
+			r.GetOrCreateIpv4().DestinationAddress = ygot.String("2001:db8::/64")
+		},
+		wantErr: "wrong address family",
+	}, {
+		desc: "unknown prefix set",
+		rule: func(r *oc.NetworkInstance_PolicyForwarding_Policy_Rule) {
+			r.GetOrCreateIpv6().SourceAddressPrefixSet = ygot.String("nope")
+		},
+		wantErr: "unknown prefix set",
+	}, {
+		desc: "ICMP code",
+		rule: func(r *oc.NetworkInstance_PolicyForwarding_Policy_Rule) {
+			r.GetOrCreateIpv4().GetOrCreateIcmpv4().Code = oc.Icmpv4Types_CODE_ECHO_NO_CODE
+		},
+		wantErr: "unsupported ipv4 match on ICMP code",
+	}, {
+		desc: "bad port range",
+		rule: func(r *oc.NetworkInstance_PolicyForwarding_Policy_Rule) {
+			r.GetOrCreateTransport().SourcePort = oc.UnionString("9..1")
+		},
+		wantErr: "invalid port range",
+	}}
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			p := &oc.NetworkInstance_PolicyForwarding_Policy{PolicyId: ygot.String("p")}
+			tc.rule(p.GetOrCreateRule(1))
+			if _, err := New("DEFAULT", p, nil); err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("New() got error %v, want %q", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestDefinedSets(t *testing.T) {
+	p := &oc.NetworkInstance_PolicyForwarding_Policy{PolicyId: ygot.String("p")}
+	r := p.GetOrCreateRule(1)
+	r.GetOrCreateIpv4().DestinationAddressPrefixSet = ygot.String("servers")
+	r.GetIpv4().Protocol = oc.PacketMatchTypes_IP_PROTOCOL_IP_TCP
+	r.GetOrCreateTransport().DestinationPortSet = ygot.String("web")
+	r.GetOrCreateAction().NextHopGroup = ygot.String("nhg-web")
+	sets := &oc.DefinedSets{}
+	sets.GetOrCreateIpv4PrefixSet("servers").Prefix = []string{"192.0.2.0/28"}
+	sets.GetOrCreatePortSet("web").Port = []oc.DefinedSets_PortSet_Port_Union{oc.UnionUint16(80), oc.UnionUint16(443)}
+	s, err := New("DEFAULT", p, sets)
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	pkt, err := s.Sample(1, "02:00:01:01:01:01", "02:00:02:01:01:01")
+	if err != nil {
+		t.Fatalf("Sample(1) failed: %v", err)
+	}
+	if got, want := s.Forward(pkt).String(), "rule 1: next hop group nhg-web"; got != want {
+		t.Errorf("Forward(%v) = %q, want %q", pkt, got, want)
+	}
+	pkt.DstPort = 8080
+	if got := s.Evaluate(pkt); got != nil {
+		t.Errorf("Evaluate(%v) hit rule %d, want none", pkt, got.GetSequenceId())
+	}
+}
+
+func TestAddFlowsAndCheck(t *testing.T) {
+	s := newSim(t)
+	top := gosnappi.NewConfig()
+	egress := map[string]string{
+		"ENCAP_TE_VRF_A": "port2",
+		"TE_VRF_111":     "port3",
+		"TE_VRF_222":     "port3",
+		"DEFAULT":        "port4",
+	}
+	flows, err := s.AddFlows(top, FlowSpec{Prefix: "pf-", TxPort: "port1", Egress: egress, SrcMAC: "02:00:01:01:01:01", DstMAC: "02:00:02:01:01:01", Packets: 100, PPS: 100})
+	if err != nil {
+		t.Fatalf("AddFlows() failed: %v", err)
+	}
+	var got []string
+	for _, f := range flows {
+		got = append(got, f.Flow+" "+f.RxPort)
+	}
+	// The sampled decap flows go to a destination without a decap entry.
+	want := []string{"pf-1 port3", "pf-2 port3", "pf-3 port3", "pf-9 port3", "pf-13 port2", "pf-14 port2", "pf-15 ", "pf-17 port4"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("AddFlows() diff (-want +got):\n%s", diff)
+	}
+	otgFlow := top.Flows().Items()[0]
+	if got, want := len(otgFlow.Packet().Items()), 4; got != want {
+		t.Errorf("flow %s has %d headers, want 4 (Ethernet, IPv4, IPv4, UDP)", otgFlow.Name(), got)
+	}
+	if got, want := top.Flows().Items()[6].TxRx().Port().RxNames(), []string{"port2", "port3", "port4"}; !cmp.Equal(got, want) {
+		t.Errorf("discarded flow received on %v, want %v", got, want)
+	}
+
+	counters := map[string]otgutils.FlowCounters{}
+	for _, f := range flows {
+		c := otgutils.FlowCounters{TxPkts: 100}
+		if !f.Want.Discard {
+			c.RxPkts = 100
+		}
+		counters[f.Flow] = c
+	}
+	if errs := Check(flows, counters, nil, nil); len(errs) != 0 {
+		t.Errorf("Check() = %v, want no errors", errs)
+	}
+	counters["pf-13"] = otgutils.FlowCounters{TxPkts: 100}
+	counters["pf-15"] = otgutils.FlowCounters{TxPkts: 100, RxPkts: 7}
+	errs := Check(flows, counters, nil, nil)
+	if len(errs) != 2 || !strings.Contains(errs[0].Error(), "lost 100.00%") || !strings.Contains(errs[1].Error(), "7 packets were received") {
+		t.Errorf("Check() = %v, want loss of pf-13 and received pf-15", errs)
+	}
+}
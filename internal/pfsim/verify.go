@@ -0,0 +1,45 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pfsim
+
+import (
+	"testing"
+
+	"github.com/openconfig/featureprofiles/internal/otgutils"
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/gnmi"
+)
+
+// GetMatchedPackets returns the matched packets of each rule of the policy
+// in the network instance of dut the simulator is for.
+func (s *Simulator) GetMatchedPackets(t testing.TB, dut *ondatra.DUTDevice) map[uint32]uint64 {
+	t.Helper()
+	matched := map[uint32]uint64{}
+	path := gnmi.OC().NetworkInstance(s.NetworkInstance).PolicyForwarding().Policy(s.Policy.GetPolicyId())
+	for seq, r := range gnmi.Get(t, dut, path.State()).Rule {
+		matched[seq] = r.GetMatchedPkts()
+	}
+	return matched
+}
+
+// Verify checks the counters of a run of flows with Check, reporting each
+// mismatch as a test error.  before and after are the matched packets of the
+// rules; pass nil for after on DUTs without rule counters.
+func Verify(t testing.TB, flows []RuleFlow, counters map[string]otgutils.FlowCounters, before, after map[uint32]uint64) {
+	t.Helper()
+	for _, err := range Check(flows, counters, before, after) {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package qosverify
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Offer is a flow offered to the egress interface.
+type Offer struct {
+	Flow  string
+	Class *Class
+	// Rate is in percent of the egress line rate.
+	Rate float64
+}
+
+// Expected returns the rate each flow is expected to be forwarded at when
+// offers share capacity, in the unit of the offered rates.
+//
+// Strict queues are served first, in sequence order.  What is left is shared
+// by the weighted queues in proportion to their weights, a queue offered less
+// than its share leaving the rest to the others.  Queues with a zero weight
+// only get what the weighted queues leave.  Flows of a queue get its
+// bandwidth in proportion to their offered rates, as tail drop does.
+func (m *Model) Expected(offers []Offer, capacity float64) (map[string]float64, error) {
+	demand := map[string]float64{}
+	for _, o := range offers {
+		if _, ok := m.Queues[o.Class.Queue]; !ok {
+			return nil, fmt.Errorf("flow %s: queue %s is not scheduled", o.Flow, o.Class.Queue)
+		}
+		demand[o.Class.Queue] += o.Rate
+	}
+
+	var strict, weighted, unweighted []*Queue
+	for name := range demand {
+		q := m.Queues[name]
+		switch {
+		case q.Strict:
+			strict = append(strict, q)
+		case q.Weight > 0:
+			weighted = append(weighted, q)
+		default:
+			unweighted = append(unweighted, q)
+		}
+	}
+	sort.Slice(strict, func(i, j int) bool {
+		if strict[i].Sequence != strict[j].Sequence {
+			return strict[i].Sequence < strict[j].Sequence
+		}
+		return strict[i].Name < strict[j].Name
+	})
+
+	served := map[string]float64{}
+	remaining := capacity
+	for _, q := range strict {
+		served[q.Name] = min(demand[q.Name], remaining)
+		remaining -= served[q.Name]
+	}
+
+	// Water-fill the weighted queues: satisfy every queue offered less than
+	// its weighted share and share the rest again among the others.
+	for len(weighted) > 0 && remaining > 0 {
+		var total float64
+		for _, q := range weighted {
+			total += float64(q.Weight)
+		}
+		var left []*Queue
+		satisfied := 0.0
+		for _, q := range weighted {
+			if demand[q.Name] <= remaining*float64(q.Weight)/total {
+				served[q.Name] = demand[q.Name]
+				satisfied += demand[q.Name]
+			} else {
+				left = append(left, q)
+			}
+		}
+		if len(left) == len(weighted) {
+			for _, q := range weighted {
+				served[q.Name] = remaining * float64(q.Weight) / total
+			}
+			remaining = 0
+			break
+		}
+		remaining -= satisfied
+		weighted = left
+	}
+
+	var leftover float64
+	for _, q := range unweighted {
+		leftover += demand[q.Name]
+	}
+	for _, q := range unweighted {
+		if remaining > 0 {
+			served[q.Name] = min(demand[q.Name], remaining*demand[q.Name]/leftover)
+		}
+	}
+
+	want := map[string]float64{}
+	for _, o := range offers {
+		if d := demand[o.Class.Queue]; d > 0 {
+			want[o.Flow] = served[o.Class.Queue] * o.Rate / d
+		}
+	}
+	return want, nil
+}
@@ -0,0 +1,110 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package qosverify
+
+import (
+	"fmt"
+
+	"github.com/open-traffic-generator/snappi/gosnappi"
+	"github.com/openconfig/featureprofiles/internal/attrs"
+	"github.com/openconfig/ondatra/gnmi/oc"
+)
+
+// ectZero is the ECN codepoint ECT(0).
+const ectZero = 2
+
+// FlowSpec describes the flows AddFlows generates.  All ports are assumed to
+// run at the same speed.
+type FlowSpec struct {
+	// Tx are the ATE interfaces sending, each sending every class.
+	Tx []*attrs.Attributes
+	// Rx is the ATE interface behind the egress interface.
+	Rx        *attrs.Attributes
+	FrameSize uint32
+	// Oversubscription is the offered load in multiples of the egress line
+	// rate, split evenly across classes and Tx interfaces.
+	Oversubscription float64
+	// Classes are the classes to send, all classes of the model if nil.
+	Classes []*Class
+	// MPLSLabel is the label MPLS classes are sent with.
+	MPLSLabel uint32
+	// ECN marks IP packets ECN capable, so congested queues with ECN enabled
+	// mark instead of dropping them.
+	ECN bool
+}
+
+// AddFlows adds a flow per class and Tx interface to top and returns what
+// they offer to the egress interface.
+func (m *Model) AddFlows(top gosnappi.Config, spec FlowSpec) ([]Offer, error) {
+	classes := spec.Classes
+	if classes == nil {
+		classes = m.Classes
+	}
+	if len(classes) == 0 || len(spec.Tx) == 0 {
+		return nil, fmt.Errorf("no classes or Tx interfaces to send")
+	}
+	rate := spec.Oversubscription * 100 / float64(len(classes)*len(spec.Tx))
+	if perPort := rate * float64(len(classes)); perPort > 100 {
+		return nil, fmt.Errorf("oversubscription %v needs %.2f%% of each of %d Tx ports", spec.Oversubscription, perPort, len(spec.Tx))
+	}
+
+	var offers []Offer
+	for _, tx := range spec.Tx {
+		for _, c := range classes {
+			name := fmt.Sprintf("%s-%v", tx.Name, c)
+			addFlow(top, name, tx, spec, c, rate)
+			offers = append(offers, Offer{Flow: name, Class: c, Rate: rate})
+		}
+	}
+	return offers, nil
+}
+
+func addFlow(top gosnappi.Config, name string, tx *attrs.Attributes, spec FlowSpec, c *Class, rate float64) {
+	flow := top.Flows().Add().SetName(name)
+	flow.Metrics().SetEnable(true)
+	family := ".IPv4"
+	if c.Type == oc.Qos_Classifier_Type_IPV6 {
+		family = ".IPv6"
+	}
+	flow.TxRx().Device().SetTxNames([]string{tx.Name + family}).SetRxNames([]string{spec.Rx.Name + family})
+	flow.Packet().Add().Ethernet().Src().SetValue(tx.MAC)
+
+	var ecn uint32
+	if spec.ECN {
+		ecn = ectZero
+	}
+	switch c.Type {
+	case oc.Qos_Classifier_Type_MPLS:
+		mpls := flow.Packet().Add().Mpls()
+		mpls.Label().SetValue(spec.MPLSLabel)
+		mpls.TrafficClass().SetValue(uint32(c.Value))
+		ip := flow.Packet().Add().Ipv4()
+		ip.Src().SetValue(tx.IPv4)
+		ip.Dst().SetValue(spec.Rx.IPv4)
+	case oc.Qos_Classifier_Type_IPV6:
+		ip := flow.Packet().Add().Ipv6()
+		ip.Src().SetValue(tx.IPv6)
+		ip.Dst().SetValue(spec.Rx.IPv6)
+		ip.TrafficClass().SetValue(uint32(c.Value)<<2 | ecn)
+	default:
+		ip := flow.Packet().Add().Ipv4()
+		ip.Src().SetValue(tx.IPv4)
+		ip.Dst().SetValue(spec.Rx.IPv4)
+		ip.Priority().Dscp().Phb().SetValue(uint32(c.Value))
+		ip.Priority().Dscp().Ecn().SetValue(ecn)
+	}
+	flow.Size().SetFixed(spec.FrameSize)
+	flow.Rate().SetPercentage(float32(rate))
+}
@@ -0,0 +1,199 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package qosverify
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/openconfig/featureprofiles/internal/otgutils"
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/gnmi"
+	"github.com/openconfig/ondatra/gnmi/oc"
+)
+
+// Policer is a two-rate three-color policer, as in RFC 2698.  Traffic up to
+// CIR conforms, traffic above CIR up to PIR exceeds and the rest violates.
+// Violating traffic is always dropped.
+type Policer struct {
+	// CIR and PIR are the committed and peak information rates, in bits per
+	// second.
+	CIR, PIR uint64
+	// ExceedDrop drops exceeding traffic, which is forwarded otherwise.
+	ExceedDrop bool
+}
+
+// PolicerFromOC reads the two-rate three-color policer of scheduler seq of
+// a scheduler policy from q.
+func PolicerFromOC(q *oc.Qos, policy string, seq uint32) (*Policer, error) {
+	sp, ok := q.SchedulerPolicy[policy]
+	if !ok {
+		return nil, fmt.Errorf("unknown scheduler policy %q", policy)
+	}
+	trtc := sp.GetScheduler(seq).GetTwoRateThreeColor()
+	if trtc == nil {
+		return nil, fmt.Errorf("scheduler %d of policy %s has no two-rate three-color policer", seq, policy)
+	}
+	p := &Policer{
+		CIR:        trtc.GetCir(),
+		PIR:        trtc.GetPir(),
+		ExceedDrop: trtc.GetExceedAction().GetDrop(),
+	}
+	if p.PIR < p.CIR {
+		return nil, fmt.Errorf("scheduler %d of policy %s: PIR %d is below CIR %d", seq, policy, p.PIR, p.CIR)
+	}
+	if !trtc.GetViolateAction().GetDrop() {
+		return nil, fmt.Errorf("scheduler %d of policy %s does not drop violating traffic", seq, policy)
+	}
+	return p, nil
+}
+
+// ethernetOverhead is the preamble, start of frame delimiter and minimum
+// inter-frame gap, in bytes, that take line rate on top of each frame.
+const ethernetOverhead = 20
+
+// L2Rate returns the rate in bits per second of the frames of frameSize
+// bytes, including the FCS, in traffic sent at l1Rate bits per second on the
+// wire, which is how the ATE sets flow rates.  Policers meter frame bytes, so
+// their rates compare with the L2 rate.
+func L2Rate(l1Rate float64, frameSize uint32) float64 {
+	return l1Rate * float64(frameSize) / float64(frameSize+ethernetOverhead)
+}
+
+// PolicerRates is traffic split by the color a policer gives it.
+type PolicerRates struct {
+	Conforming, Exceeding, Violating float64
+}
+
+// Colors returns how traffic offered at rate bits per second is colored.
+func (p *Policer) Colors(rate float64) PolicerRates {
+	conforming := min(rate, float64(p.CIR))
+	peak := min(rate, float64(p.PIR))
+	return PolicerRates{
+		Conforming: conforming,
+		Exceeding:  peak - conforming,
+		Violating:  rate - peak,
+	}
+}
+
+// Forwarded returns the rate at which traffic offered at rate bits per
+// second is forwarded.
+func (p *Policer) Forwarded(rate float64) float64 {
+	c := p.Colors(rate)
+	if p.ExceedDrop {
+		return c.Conforming
+	}
+	return c.Conforming + c.Exceeding
+}
+
+// PolicerCounters are the counters of a policer.
+type PolicerCounters struct {
+	ConformingPkts uint64
+	ExceedingPkts  uint64
+	ViolatingPkts  uint64
+}
+
+// Sub returns the counters accumulated since before.
+func (c PolicerCounters) Sub(before PolicerCounters) PolicerCounters {
+	return PolicerCounters{
+		ConformingPkts: c.ConformingPkts - before.ConformingPkts,
+		ExceedingPkts:  c.ExceedingPkts - before.ExceedingPkts,
+		ViolatingPkts:  c.ViolatingPkts - before.ViolatingPkts,
+	}
+}
+
+// GetPolicerCounters returns the counters of scheduler seq of the input
+// scheduler policy of an interface.
+func GetPolicerCounters(t testing.TB, dut *ondatra.DUTDevice, intf string, seq uint32) PolicerCounters {
+	t.Helper()
+	s := gnmi.Get(t, dut, gnmi.OC().Qos().Interface(intf).Input().SchedulerPolicy().Scheduler(seq).State())
+	return PolicerCounters{
+		ConformingPkts: s.GetConformingPkts(),
+		ExceedingPkts:  s.GetExceedingPkts(),
+		ViolatingPkts:  s.GetViolatingPkts(),
+	}
+}
+
+// Check compares the counters of a flow offered at rate bits per second of
+// frames, as returned by L2Rate, to the policer with what it is expected to forward.  A flow the policer is
+// expected to forward entirely must lose no packet.  counters, if not nil,
+// are the counters the run added to the policer; the share of packets of
+// each color must then be within opts.ThroughputPct of the expected share,
+// and unless opts.SkipDrops is set, the policer must have dropped exactly
+// the packets the ATE lost.
+func (p *Policer) Check(rate float64, flow otgutils.FlowCounters, counters *PolicerCounters, opts CheckOptions) []error {
+	if flow.TxPkts == 0 {
+		return []error{fmt.Errorf("policed flow sent no packets")}
+	}
+	if rate <= 0 {
+		return []error{fmt.Errorf("policed flow offered at %v bps", rate)}
+	}
+	var errs []error
+	want := p.Forwarded(rate) * 100 / rate
+	got := float64(flow.RxPkts) * 100 / float64(flow.TxPkts)
+	switch {
+	case want == 100 && flow.RxPkts < flow.TxPkts:
+		errs = append(errs, fmt.Errorf("policer below CIR: lost %d of %d packets, want none", flow.TxPkts-flow.RxPkts, flow.TxPkts))
+	case math.Abs(got-want) > opts.ThroughputPct:
+		errs = append(errs, fmt.Errorf("policer forwarded %.2f%% of offered traffic, want %.2f%% +/- %.2f", got, want, opts.ThroughputPct))
+	}
+	if counters == nil {
+		return errs
+	}
+
+	total := counters.ConformingPkts + counters.ExceedingPkts + counters.ViolatingPkts
+	if total == 0 {
+		return append(errs, fmt.Errorf("policer counted no packets"))
+	}
+	colors := p.Colors(rate)
+	for _, c := range []struct {
+		color string
+		pkts  uint64
+		rate  float64
+	}{
+		{"conforming", counters.ConformingPkts, colors.Conforming},
+		{"exceeding", counters.ExceedingPkts, colors.Exceeding},
+		{"violating", counters.ViolatingPkts, colors.Violating},
+	} {
+		got, want := float64(c.pkts)*100/float64(total), c.rate*100/rate
+		if math.Abs(got-want) > opts.ThroughputPct {
+			errs = append(errs, fmt.Errorf("policer counted %.2f%% of packets %s, want %.2f%% +/- %.2f", got, c.color, want, opts.ThroughputPct))
+		}
+	}
+	dropped := counters.ViolatingPkts
+	if p.ExceedDrop {
+		dropped += counters.ExceedingPkts
+	}
+	if lost := flow.TxPkts - min(flow.RxPkts, flow.TxPkts); !opts.SkipDrops && dropped != lost {
+		errs = append(errs, fmt.Errorf("policer dropped %d packets, want %d lost by the ATE", dropped, lost))
+	}
+	return errs
+}
+
+// VerifyPolicer reads the counters of a flow offered at rate bits per second
+// of frames, as returned by L2Rate, from the ATE and of the policer of scheduler seq of the input scheduler
+// policy of intf from the DUT, and reports as test errors where they differ
+// from what p is expected to do.  before are the policer counters taken
+// before traffic started.
+func VerifyPolicer(t testing.TB, dut *ondatra.DUTDevice, ate *ondatra.ATEDevice, intf string, seq uint32, p *Policer, flow string, rate float64, before PolicerCounters, opts CheckOptions) {
+	t.Helper()
+	c := otgutils.GetFlowCounters(t, ate.OTG(), flow)
+	counters := GetPolicerCounters(t, dut, intf, seq).Sub(before)
+	t.Logf("Policer %d of %s: %+v", seq, intf, counters)
+	for _, err := range p.Check(rate, c, &counters, opts) {
+		t.Errorf("Flow %s: %v", flow, err)
+	}
+}
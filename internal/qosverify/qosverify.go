@@ -0,0 +1,206 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package qosverify checks that the queues of a DUT forward traffic the way
+// its QoS configuration says they should.
+//
+// The model is read from the same oc.Qos the test pushes, flows are generated
+// for every classified DSCP, EXP or traffic class, and the bandwidth each
+// queue gets under congestion is compared with what strict priority and
+// weighted round robin scheduling allow:
+//
+//	m, err := qosverify.ModelFromOC(q, dp1.Name(), dp3.Name())
+//	if err != nil {
+//		t.Fatal(err)
+//	}
+//	offers, err := m.AddFlows(top, qosverify.FlowSpec{
+//		Tx:               []*attrs.Attributes{&atePort1, &atePort2},
+//		Rx:               &atePort3,
+//		FrameSize:        512,
+//		Oversubscription: 1.5,
+//	})
+//	...
+//	before := qosverify.GetQueueCounters(t, dut, dp3.Name(), m.QueueNames())
+//	ate.OTG().StartTraffic(t)
+//	...
+//	qosverify.Verify(t, dut, ate, dp3.Name(), m, offers, before, qosverify.CheckOptions{ThroughputPct: 3})
+//
+// Two-rate three-color policers are checked the same way, against the rate
+// of the flow they police:
+//
+//	p, err := qosverify.PolicerFromOC(q, "policer", 1)
+//	...
+//	before := qosverify.GetPolicerCounters(t, dut, dp1.Name(), 1)
+//	ate.OTG().StartTraffic(t)
+//	...
+//	qosverify.VerifyPolicer(t, dut, ate, dp1.Name(), 1, p, "flow", 4e9, before, qosverify.CheckOptions{ThroughputPct: 0.5})
+package qosverify
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/openconfig/ondatra/gnmi/oc"
+)
+
+// Class is a traffic class a classifier maps to a queue.
+type Class struct {
+	// Type is the header the classifier matches, IPV4, IPV6 or MPLS.
+	Type oc.E_Qos_Classifier_Type
+	// Value is the DSCP, or the traffic class (EXP) for MPLS.
+	Value           uint8
+	ForwardingGroup string
+	Queue           string
+}
+
+func (c *Class) String() string {
+	switch c.Type {
+	case oc.Qos_Classifier_Type_MPLS:
+		return fmt.Sprintf("exp%d", c.Value)
+	case oc.Qos_Classifier_Type_IPV6:
+		return fmt.Sprintf("v6dscp%d", c.Value)
+	default:
+		return fmt.Sprintf("dscp%d", c.Value)
+	}
+}
+
+// Queue is how the scheduler of the egress interface serves a queue.
+type Queue struct {
+	Name string
+	// Strict queues are served before any weighted queue, in the order of
+	// their scheduler sequence.
+	Strict   bool
+	Sequence uint32
+	// Weight is the share of a weighted queue relative to the other
+	// weighted queues.
+	Weight uint64
+}
+
+// Model is the QoS treatment of traffic between an ingress and an egress
+// interface.
+type Model struct {
+	Classes []*Class
+	Queues  map[string]*Queue
+}
+
+// QueueNames returns the names of the queues classes map to, sorted.
+func (m *Model) QueueNames() []string {
+	seen := map[string]bool{}
+	var names []string
+	for _, c := range m.Classes {
+		if !seen[c.Queue] {
+			seen[c.Queue] = true
+			names = append(names, c.Queue)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ModelFromOC reads the classifiers bound to the ingress interface and the
+// scheduler policy of the egress interface from q.  Every queue a class
+// maps to must be scheduled by the policy.
+func ModelFromOC(q *oc.Qos, ingress, egress string) (*Model, error) {
+	in := q.GetInterface(ingress).GetInput()
+	if in == nil || len(in.Classifier) == 0 {
+		return nil, fmt.Errorf("no input classifier on interface %s", ingress)
+	}
+	m := &Model{Queues: map[string]*Queue{}}
+
+	var types []oc.E_Input_Classifier_Type
+	for typ := range in.Classifier {
+		types = append(types, typ)
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i] < types[j] })
+	for _, typ := range types {
+		name := in.Classifier[typ].GetName()
+		c, ok := q.Classifier[name]
+		if !ok {
+			return nil, fmt.Errorf("interface %s: unknown classifier %q", ingress, name)
+		}
+		classes, err := classesOf(q, c)
+		if err != nil {
+			return nil, fmt.Errorf("classifier %s: %w", name, err)
+		}
+		m.Classes = append(m.Classes, classes...)
+	}
+
+	policyName := q.GetInterface(egress).GetOutput().GetSchedulerPolicy().GetName()
+	policy, ok := q.SchedulerPolicy[policyName]
+	if !ok {
+		return nil, fmt.Errorf("interface %s: unknown scheduler policy %q", egress, policyName)
+	}
+	for seq, s := range policy.Scheduler {
+		for _, input := range s.Input {
+			queue := input.GetQueue()
+			if queue == "" {
+				continue
+			}
+			m.Queues[queue] = &Queue{
+				Name:     queue,
+				Strict:   s.GetPriority() == oc.Scheduler_Priority_STRICT,
+				Sequence: seq,
+				Weight:   input.GetWeight(),
+			}
+		}
+	}
+	for _, c := range m.Classes {
+		if _, ok := m.Queues[c.Queue]; !ok {
+			return nil, fmt.Errorf("%v maps to queue %s, which scheduler policy %s does not serve", c, c.Queue, policyName)
+		}
+	}
+	return m, nil
+}
+
+// classesOf returns the classes the terms of a classifier match, ordered by
+// term ID.
+func classesOf(q *oc.Qos, c *oc.Qos_Classifier) ([]*Class, error) {
+	var ids []string
+	for id := range c.Term {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	var classes []*Class
+	for _, id := range ids {
+		term := c.Term[id]
+		group := term.GetActions().GetTargetGroup()
+		fg, ok := q.ForwardingGroup[group]
+		if !ok {
+			return nil, fmt.Errorf("term %s: unknown forwarding group %q", id, group)
+		}
+		add := func(typ oc.E_Qos_Classifier_Type, values []uint8) {
+			for _, v := range values {
+				classes = append(classes, &Class{Type: typ, Value: v, ForwardingGroup: group, Queue: fg.GetOutputQueue()})
+			}
+		}
+		cond := term.GetConditions()
+		if v4 := cond.GetIpv4(); v4 != nil {
+			add(oc.Qos_Classifier_Type_IPV4, dscps(v4.Dscp, v4.DscpSet))
+		}
+		if v6 := cond.GetIpv6(); v6 != nil {
+			add(oc.Qos_Classifier_Type_IPV6, dscps(v6.Dscp, v6.DscpSet))
+		}
+		if mpls := cond.GetMpls(); mpls != nil && mpls.TrafficClass != nil {
+			add(oc.Qos_Classifier_Type_MPLS, []uint8{mpls.GetTrafficClass()})
+		}
+	}
+	return classes, nil
+}
+
+func dscps(dscp *uint8, set []uint8) []uint8 {
+	if dscp != nil {
+		return append([]uint8{*dscp}, set...)
+	}
+	return set
+}
@@ -0,0 +1,445 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package qosverify
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/open-traffic-generator/snappi/gosnappi"
+	"github.com/openconfig/featureprofiles/internal/attrs"
+	"github.com/openconfig/featureprofiles/internal/otgutils"
+	"github.com/openconfig/ondatra/gnmi/oc"
+)
+
+// testQos maps DSCP 46 to strict queue NC1, DSCP 34 and 10 to WRR queues
+// AF4 and AF1 weighted 4:1, and EXP 5 to AF4.
+func testQos(t *testing.T) *oc.Qos {
+	t.Helper()
+	q := &oc.Qos{}
+	for fg, queue := range map[string]string{"fg-nc1": "NC1", "fg-af4": "AF4", "fg-af1": "AF1"} {
+		q.GetOrCreateForwardingGroup(fg).SetOutputQueue(queue)
+	}
+	v4 := q.GetOrCreateClassifier("dscp")
+	v4.SetType(oc.Qos_Classifier_Type_IPV4)
+	for id, c := range map[string]struct {
+		fg   string
+		dscp []uint8
+	}{
+		"0": {"fg-nc1", []uint8{46}},
+		"1": {"fg-af4", []uint8{34}},
+		"2": {"fg-af1", []uint8{10}},
+	} {
+		term := v4.GetOrCreateTerm(id)
+		term.GetOrCreateActions().SetTargetGroup(c.fg)
+		term.GetOrCreateConditions().GetOrCreateIpv4().SetDscpSet(c.dscp)
+	}
+	exp := q.GetOrCreateClassifier("exp")
+	exp.SetType(oc.Qos_Classifier_Type_MPLS)
+	term := exp.GetOrCreateTerm("0")
+	term.GetOrCreateActions().SetTargetGroup("fg-af4")
+	term.GetOrCreateConditions().GetOrCreateMpls().SetTrafficClass(5)
+
+	in := q.GetOrCreateInterface("port1").GetOrCreateInput()
+	in.GetOrCreateClassifier(oc.Input_Classifier_Type_IPV4).SetName("dscp")
+	in.GetOrCreateClassifier(oc.Input_Classifier_Type_MPLS).SetName("exp")
+
+	sp := q.GetOrCreateSchedulerPolicy("scheduler")
+	s := sp.GetOrCreateScheduler(0)
+	s.SetPriority(oc.Scheduler_Priority_STRICT)
+	s.GetOrCreateInput("NC1").SetQueue("NC1")
+	s = sp.GetOrCreateScheduler(1)
+	s.GetOrCreateInput("AF4").SetQueue("AF4")
+	s.GetOrCreateInput("AF4").SetWeight(4)
+	s.GetOrCreateInput("AF1").SetQueue("AF1")
+	s.GetOrCreateInput("AF1").SetWeight(1)
+	q.GetOrCreateInterface("port3").GetOrCreateOutput().GetOrCreateSchedulerPolicy().SetName("scheduler")
+	return q
+}
+
+func TestModelFromOC(t *testing.T) {
+	m, err := ModelFromOC(testQos(t), "port1", "port3")
+	if err != nil {
+		t.Fatalf("ModelFromOC() failed: %v", err)
+	}
+	wantClasses := []*Class{
+		{Type: oc.Qos_Classifier_Type_IPV4, Value: 46, ForwardingGroup: "fg-nc1", Queue: "NC1"},
+		{Type: oc.Qos_Classifier_Type_IPV4, Value: 34, ForwardingGroup: "fg-af4", Queue: "AF4"},
+		{Type: oc.Qos_Classifier_Type_IPV4, Value: 10, ForwardingGroup: "fg-af1", Queue: "AF1"},
+		{Type: oc.Qos_Classifier_Type_MPLS, Value: 5, ForwardingGroup: "fg-af4", Queue: "AF4"},
+	}
+	if diff := cmp.Diff(wantClasses, m.Classes); diff != "" {
+		t.Errorf("ModelFromOC() classes diff (-want +got):\n%s", diff)
+	}
+	wantQueues := map[string]*Queue{
+		"NC1": {Name: "NC1", Strict: true},
+		"AF4": {Name: "AF4", Sequence: 1, Weight: 4},
+		"AF1": {Name: "AF1", Sequence: 1, Weight: 1},
+	}
+	if diff := cmp.Diff(wantQueues, m.Queues); diff != "" {
+		t.Errorf("ModelFromOC() queues diff (-want +got):\n%s", diff)
+	}
+	if got, want := m.QueueNames(), []string{"AF1", "AF4", "NC1"}; !cmp.Equal(got, want) {
+		t.Errorf("QueueNames() = %v, want %v", got, want)
+	}
+}
+
+func TestModelFromOCErrors(t *testing.T) {
+	tests := []struct {
+		desc    string
+		mutate  func(*oc.Qos)
+		ingress string
+		wantErr string
+	}{{
+		desc:    "no classifier",
+		ingress: "port2",
+		wantErr: "no input classifier",
+	}, {
+		desc:    "unknown forwarding group",
+		mutate:  func(q *oc.Qos) { delete(q.ForwardingGroup, "fg-af1") },
+		wantErr: "unknown forwarding group",
+	}, {
+		desc:    "unscheduled queue",
+		mutate:  func(q *oc.Qos) { delete(q.SchedulerPolicy["scheduler"].Scheduler[1].Input, "AF1") },
+		wantErr: "does not serve",
+	}}
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			q := testQos(t)
+			if tc.mutate != nil {
+				tc.mutate(q)
+			}
+			ingress := "port1"
+			if tc.ingress != "" {
+				ingress = tc.ingress
+			}
+			_, err := ModelFromOC(q, ingress, "port3")
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("ModelFromOC() got error %v, want %q", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestExpected(t *testing.T) {
+	m, err := ModelFromOC(testQos(t), "port1", "port3")
+	if err != nil {
+		t.Fatalf("ModelFromOC() failed: %v", err)
+	}
+	nc1, af4, af1, exp5 := m.Classes[0], m.Classes[1], m.Classes[2], m.Classes[3]
+	tests := []struct {
+		desc   string
+		offers []Offer
+		want   map[string]float64
+	}{{
+		desc:   "no congestion",
+		offers: []Offer{{"nc1", nc1, 20}, {"af4", af4, 30}, {"af1", af1, 40}},
+		want:   map[string]float64{"nc1": 20, "af4": 30, "af1": 40},
+	}, {
+		desc:   "weighted shares",
+		offers: []Offer{{"nc1", nc1, 50}, {"af4", af4, 50}, {"af1", af1, 50}},
+		want:   map[string]float64{"nc1": 50, "af4": 40, "af1": 10},
+	}, {
+		desc:   "unused share redistributed",
+		offers: []Offer{{"nc1", nc1, 20}, {"af4", af4, 10}, {"af1", af1, 100}},
+		want:   map[string]float64{"nc1": 20, "af4": 10, "af1": 70},
+	}, {
+		desc:   "strict starves weighted",
+		offers: []Offer{{"nc1", nc1, 120}, {"af4", af4, 50}},
+		want:   map[string]float64{"nc1": 100, "af4": 0},
+	}, {
+		desc:   "flows share their queue",
+		offers: []Offer{{"af4", af4, 90}, {"exp5", exp5, 30}, {"af1", af1, 60}},
+		want:   map[string]float64{"af4": 60, "exp5": 20, "af1": 20},
+	}}
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := m.Expected(tc.offers, 100)
+			if err != nil {
+				t.Fatalf("Expected() failed: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, got, cmpopts.EquateApprox(0, 1e-9)); diff != "" {
+				t.Errorf("Expected() diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestAddFlows(t *testing.T) {
+	m, err := ModelFromOC(testQos(t), "port1", "port3")
+	if err != nil {
+		t.Fatalf("ModelFromOC() failed: %v", err)
+	}
+	tx := []*attrs.Attributes{
+		{Name: "ate1", MAC: "02:00:01:01:01:01", IPv4: "198.51.100.1"},
+		{Name: "ate2", MAC: "02:00:02:01:01:01", IPv4: "198.51.100.3"},
+	}
+	rx := &attrs.Attributes{Name: "ate3", MAC: "02:00:03:01:01:01", IPv4: "198.51.100.5"}
+
+	top := gosnappi.NewConfig()
+	offers, err := m.AddFlows(top, FlowSpec{Tx: tx, Rx: rx, FrameSize: 512, Oversubscription: 1.6, MPLSLabel: 100})
+	if err != nil {
+		t.Fatalf("AddFlows() failed: %v", err)
+	}
+	if got, want := len(top.Flows().Items()), 8; got != want {
+		t.Fatalf("AddFlows() added %d flows, want %d", got, want)
+	}
+	for _, o := range offers {
+		if math.Abs(o.Rate-20) > 1e-9 {
+			t.Errorf("AddFlows() offers %s at %v%%, want 20%%", o.Flow, o.Rate)
+		}
+	}
+	flow := top.Flows().Items()[7]
+	if got, want := flow.Name(), "ate2-exp5"; got != want {
+		t.Errorf("AddFlows() last flow %q, want %q", got, want)
+	}
+	if got, want := flow.Packet().Items()[1].Mpls().TrafficClass().Value(), uint32(5); got != want {
+		t.Errorf("AddFlows() MPLS traffic class %d, want %d", got, want)
+	}
+
+	if _, err := m.AddFlows(gosnappi.NewConfig(), FlowSpec{Tx: tx[:1], Rx: rx, Oversubscription: 1.6}); err == nil {
+		t.Error("AddFlows() oversubscribing a single Tx port succeeded, want error")
+	}
+}
+
+func TestCheck(t *testing.T) {
+	m, err := ModelFromOC(testQos(t), "port1", "port3")
+	if err != nil {
+		t.Fatalf("ModelFromOC() failed: %v", err)
+	}
+	nc1, af4, af1 := m.Classes[0], m.Classes[1], m.Classes[2]
+	offers := []Offer{{"nc1", nc1, 50}, {"af4", af4, 50}, {"af1", af1, 50}}
+	flows := map[string]otgutils.FlowCounters{
+		"nc1": {TxPkts: 1000, RxPkts: 1000},
+		"af4": {TxPkts: 1000, RxPkts: 790},
+		"af1": {TxPkts: 1000, RxPkts: 200},
+	}
+	queues := map[string]QueueCounters{
+		"NC1": {TransmitPkts: 1000},
+		"AF4": {TransmitPkts: 790, DroppedPkts: 210, EcnMarkedPkts: 760},
+		"AF1": {TransmitPkts: 150, DroppedPkts: 700, EcnMarkedPkts: 70},
+	}
+	var got []string
+	for _, err := range m.Check(offers, flows, queues, CheckOptions{ThroughputPct: 3, ECN: true, ECNMinPct: 50, ECNPct: 5}) {
+		got = append(got, err.Error())
+	}
+	// Every queue forwards its share, but AF1 counters miss packets and
+	// marks.
+	want := []string{
+		"queue AF1: transmitted 150 packets, want >= 200 received by the ATE",
+		"queue AF1: dropped 700 packets, want >= 800 lost by the ATE",
+		"queue AF1: congested but marked 70 of 150 transmitted packets (46.67%), want >= 50.00%",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Check() diff (-want +got):\n%s", diff)
+	}
+
+	flows["af4"] = otgutils.FlowCounters{TxPkts: 1000, RxPkts: 500}
+	errs := m.Check(offers, flows, nil, CheckOptions{ThroughputPct: 3})
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), "queue AF4: forwarded 50.00% of offered traffic, want 80.00%") {
+		t.Errorf("Check() = %v, want an AF4 throughput error", errs)
+	}
+}
+
+func TestCheckECN(t *testing.T) {
+	m, err := ModelFromOC(testQos(t), "port1", "port3")
+	if err != nil {
+		t.Fatalf("ModelFromOC() failed: %v", err)
+	}
+	nc1, af4 := m.Classes[0], m.Classes[1]
+	// NC1 is not congested and AF4 is offered twice what it gets.
+	offers := []Offer{{"nc1", nc1, 50}, {"af4", af4, 100}}
+	flows := map[string]otgutils.FlowCounters{
+		"nc1": {TxPkts: 1000, RxPkts: 1000},
+		"af4": {TxPkts: 2000, RxPkts: 1000},
+	}
+	tests := []struct {
+		desc                 string
+		nc1Marked, af4Marked uint64
+		wantErr              string
+	}{{
+		desc:      "every packet marked",
+		af4Marked: 1000,
+	}, {
+		desc:      "congested queue marks some",
+		af4Marked: 620,
+	}, {
+		desc:      "congested queue below floor",
+		af4Marked: 499,
+		wantErr:   "queue AF4: congested but marked 499 of 1000 transmitted packets (49.90%), want >= 50.00%",
+	}, {
+		desc:    "congested queue marks none",
+		wantErr: "queue AF4: congested but marked 0 of 1000 transmitted packets (0.00%), want >= 50.00%",
+	}, {
+		desc:      "uncongested queue within tolerance",
+		nc1Marked: 50,
+		af4Marked: 1000,
+	}, {
+		desc:      "uncongested queue marks",
+		nc1Marked: 60,
+		af4Marked: 1000,
+		wantErr:   "queue NC1: not congested but marked 60 of 1000 transmitted packets (6.00%), want <= 5.00%",
+	}}
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			queues := map[string]QueueCounters{
+				"NC1": {TransmitPkts: 1000, EcnMarkedPkts: tc.nc1Marked},
+				"AF4": {TransmitPkts: 1000, DroppedPkts: 1000, EcnMarkedPkts: tc.af4Marked},
+			}
+			var got []string
+			for _, err := range m.Check(offers, flows, queues, CheckOptions{ThroughputPct: 3, ECN: true, ECNMinPct: 50, ECNPct: 5}) {
+				got = append(got, err.Error())
+			}
+			var want []string
+			if tc.wantErr != "" {
+				want = []string{tc.wantErr}
+			}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("Check() diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPolicerFromOC(t *testing.T) {
+	q := &oc.Qos{}
+	trtc := q.GetOrCreateSchedulerPolicy("policer").GetOrCreateScheduler(1).GetOrCreateTwoRateThreeColor()
+	trtc.SetCir(1e9)
+	trtc.SetPir(2e9)
+	trtc.GetOrCreateExceedAction().SetDrop(true)
+	trtc.GetOrCreateViolateAction().SetDrop(true)
+
+	p, err := PolicerFromOC(q, "policer", 1)
+	if err != nil {
+		t.Fatalf("PolicerFromOC() failed: %v", err)
+	}
+	if diff := cmp.Diff(&Policer{CIR: 1e9, PIR: 2e9, ExceedDrop: true}, p); diff != "" {
+		t.Errorf("PolicerFromOC() diff (-want +got):\n%s", diff)
+	}
+
+	for _, tc := range []struct {
+		desc    string
+		mutate  func()
+		seq     uint32
+		wantErr string
+	}{{
+		desc:    "no policer",
+		seq:     2,
+		wantErr: "no two-rate three-color policer",
+	}, {
+		desc:    "violating traffic forwarded",
+		mutate:  func() { trtc.GetViolateAction().SetDrop(false) },
+		seq:     1,
+		wantErr: "does not drop violating traffic",
+	}, {
+		desc:    "PIR below CIR",
+		mutate:  func() { trtc.SetPir(5e8) },
+		seq:     1,
+		wantErr: "PIR 500000000 is below CIR 1000000000",
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			if tc.mutate != nil {
+				tc.mutate()
+			}
+			if _, err := PolicerFromOC(q, "policer", tc.seq); err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("PolicerFromOC() got error %v, want %q", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestL2Rate(t *testing.T) {
+	// 512 byte frames take 532 bytes on the wire.
+	if got, want := L2Rate(532e6, 512), 512e6; math.Abs(got-want) > 1 {
+		t.Errorf("L2Rate(532e6, 512) = %v, want %v", got, want)
+	}
+}
+
+func TestPolicerCheck(t *testing.T) {
+	p := &Policer{CIR: 1e9, PIR: 2e9}
+	tests := []struct {
+		desc     string
+		policer  *Policer
+		rate     float64
+		flow     otgutils.FlowCounters
+		counters *PolicerCounters
+		want     []string
+	}{{
+		desc:     "below CIR",
+		rate:     1.5e9,
+		flow:     otgutils.FlowCounters{TxPkts: 1000, RxPkts: 1000},
+		counters: &PolicerCounters{ConformingPkts: 667, ExceedingPkts: 333},
+	}, {
+		desc: "loss below PIR",
+		rate: 1.5e9,
+		flow: otgutils.FlowCounters{TxPkts: 1000, RxPkts: 999},
+		want: []string{"policer below CIR: lost 1 of 1000 packets, want none"},
+	}, {
+		desc:     "above PIR",
+		rate:     4e9,
+		flow:     otgutils.FlowCounters{TxPkts: 1000, RxPkts: 502},
+		counters: &PolicerCounters{ConformingPkts: 250, ExceedingPkts: 252, ViolatingPkts: 498},
+	}, {
+		desc:     "above PIR dropping exceeding traffic",
+		policer:  &Policer{CIR: 1e9, PIR: 2e9, ExceedDrop: true},
+		rate:     4e9,
+		flow:     otgutils.FlowCounters{TxPkts: 1000, RxPkts: 250},
+		counters: &PolicerCounters{ConformingPkts: 250, ExceedingPkts: 250, ViolatingPkts: 500},
+	}, {
+		desc: "forwarding too much",
+		rate: 4e9,
+		flow: otgutils.FlowCounters{TxPkts: 1000, RxPkts: 600},
+		want: []string{"policer forwarded 60.00% of offered traffic, want 50.00% +/- 1.00"},
+	}, {
+		desc:     "wrong colors and drops",
+		rate:     4e9,
+		flow:     otgutils.FlowCounters{TxPkts: 1000, RxPkts: 500},
+		counters: &PolicerCounters{ConformingPkts: 500, ExceedingPkts: 100, ViolatingPkts: 400},
+		want: []string{
+			"policer counted 50.00% of packets conforming, want 25.00% +/- 1.00",
+			"policer counted 10.00% of packets exceeding, want 25.00% +/- 1.00",
+			"policer counted 40.00% of packets violating, want 50.00% +/- 1.00",
+			"policer dropped 400 packets, want 500 lost by the ATE",
+		},
+	}, {
+		desc:     "no packets counted",
+		rate:     4e9,
+		flow:     otgutils.FlowCounters{TxPkts: 1000, RxPkts: 500},
+		counters: &PolicerCounters{},
+		want:     []string{"policer counted no packets"},
+	}, {
+		desc: "no packets sent",
+		rate: 4e9,
+		want: []string{"policed flow sent no packets"},
+	}}
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			policer := p
+			if tc.policer != nil {
+				policer = tc.policer
+			}
+			var got []string
+			for _, err := range policer.Check(tc.rate, tc.flow, tc.counters, CheckOptions{ThroughputPct: 1}) {
+				got = append(got, err.Error())
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Check() diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,193 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package qosverify
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/openconfig/featureprofiles/internal/otgutils"
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/gnmi"
+	"github.com/openconfig/ygnmi/ygnmi"
+)
+
+// counterTimeout is how long GetQueueCounters waits for a queue to report.
+const counterTimeout = time.Minute
+
+// QueueCounters are the counters of an egress queue.
+type QueueCounters struct {
+	TransmitPkts   uint64
+	TransmitOctets uint64
+	DroppedPkts    uint64
+	DroppedOctets  uint64
+	EcnMarkedPkts  uint64
+}
+
+// Sub returns the counters accumulated since before.
+func (c QueueCounters) Sub(before QueueCounters) QueueCounters {
+	return QueueCounters{
+		TransmitPkts:   c.TransmitPkts - before.TransmitPkts,
+		TransmitOctets: c.TransmitOctets - before.TransmitOctets,
+		DroppedPkts:    c.DroppedPkts - before.DroppedPkts,
+		DroppedOctets:  c.DroppedOctets - before.DroppedOctets,
+		EcnMarkedPkts:  c.EcnMarkedPkts - before.EcnMarkedPkts,
+	}
+}
+
+// GetQueueCounters returns the counters of the output queues of an
+// interface, waiting for each queue to report transmitted packets.
+func GetQueueCounters(t testing.TB, dut *ondatra.DUTDevice, intf string, queues []string) map[string]QueueCounters {
+	t.Helper()
+	counters := map[string]QueueCounters{}
+	for _, name := range queues {
+		path := gnmi.OC().Qos().Interface(intf).Output().Queue(name)
+		_, ok := gnmi.Watch(t, dut, path.TransmitPkts().State(), counterTimeout, func(v *ygnmi.Value[uint64]) bool {
+			return v.IsPresent()
+		}).Await(t)
+		if !ok {
+			t.Errorf("TransmitPkts of queue %s on interface %s not available within %v", name, intf, counterTimeout)
+			continue
+		}
+		q := gnmi.Get(t, dut, path.State())
+		counters[name] = QueueCounters{
+			TransmitPkts:   q.GetTransmitPkts(),
+			TransmitOctets: q.GetTransmitOctets(),
+			DroppedPkts:    q.GetDroppedPkts(),
+			DroppedOctets:  q.GetDroppedOctets(),
+			EcnMarkedPkts:  q.GetEcnMarkedPkts(),
+		}
+	}
+	return counters
+}
+
+// CheckOptions are the tolerances of Check.
+type CheckOptions struct {
+	// ThroughputPct is how far, in percentage points, the share of its
+	// offered traffic a queue forwards may be from the expected share.
+	ThroughputPct float64
+	// SkipDrops does not require queue drop counters to account for the
+	// traffic the ATE lost, for DUTs that do not count dequeue deletes.
+	SkipDrops bool
+	// ECN requires queues to mark ECN capable packets they transmit when
+	// congested, and none otherwise.  How many packets a congested queue
+	// marks depends on its ECN profile and on how deep the queue runs, so
+	// only a floor is checked.
+	ECN bool
+	// ECNMinPct is the smallest share, in percent, of its transmitted
+	// packets a congested queue must mark.  It must mark at least one.
+	ECNMinPct float64
+	// ECNPct is the largest share, in percent, of its transmitted packets
+	// an uncongested queue may mark.
+	ECNPct float64
+}
+
+// Check compares the counters of a run with the expected forwarding of
+// offers on a link of capacity 100.  queues are the counters the run added
+// to each queue; queues without counters are only checked for throughput.
+func (m *Model) Check(offers []Offer, flows map[string]otgutils.FlowCounters, queues map[string]QueueCounters, opts CheckOptions) []error {
+	want, err := m.Expected(offers, 100)
+	if err != nil {
+		return []error{err}
+	}
+
+	type queueTotals struct {
+		offered, forwarded, expected float64
+		txPkts, rxPkts               uint64
+	}
+	totals := map[string]*queueTotals{}
+	var errs []error
+	for _, o := range offers {
+		q, ok := totals[o.Class.Queue]
+		if !ok {
+			q = &queueTotals{}
+			totals[o.Class.Queue] = q
+		}
+		f := flows[o.Flow]
+		if f.TxPkts == 0 {
+			errs = append(errs, fmt.Errorf("flow %s: sent no packets", o.Flow))
+			continue
+		}
+		q.offered += o.Rate
+		q.forwarded += o.Rate * float64(f.RxPkts) / float64(f.TxPkts)
+		q.expected += want[o.Flow]
+		q.txPkts += f.TxPkts
+		q.rxPkts += f.RxPkts
+	}
+
+	var names []string
+	for name := range totals {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		q := totals[name]
+		if q.offered == 0 {
+			continue
+		}
+		got, exp := q.forwarded*100/q.offered, q.expected*100/q.offered
+		if math.Abs(got-exp) > opts.ThroughputPct {
+			errs = append(errs, fmt.Errorf("queue %s: forwarded %.2f%% of offered traffic, want %.2f%% +/- %.2f", name, got, exp, opts.ThroughputPct))
+		}
+		c, ok := queues[name]
+		if !ok {
+			continue
+		}
+		if c.TransmitPkts < q.rxPkts {
+			errs = append(errs, fmt.Errorf("queue %s: transmitted %d packets, want >= %d received by the ATE", name, c.TransmitPkts, q.rxPkts))
+		}
+		lost := q.txPkts - min(q.rxPkts, q.txPkts)
+		if !opts.SkipDrops && c.DroppedPkts < lost {
+			errs = append(errs, fmt.Errorf("queue %s: dropped %d packets, want >= %d lost by the ATE", name, c.DroppedPkts, lost))
+		}
+		if opts.ECN && c.TransmitPkts > 0 {
+			marked := float64(c.EcnMarkedPkts) * 100 / float64(c.TransmitPkts)
+			switch congested := exp < 100; {
+			case congested && (c.EcnMarkedPkts == 0 || marked < opts.ECNMinPct):
+				errs = append(errs, fmt.Errorf("queue %s: congested but marked %d of %d transmitted packets (%.2f%%), want >= %.2f%%", name, c.EcnMarkedPkts, c.TransmitPkts, marked, opts.ECNMinPct))
+			case !congested && marked > opts.ECNPct:
+				errs = append(errs, fmt.Errorf("queue %s: not congested but marked %d of %d transmitted packets (%.2f%%), want <= %.2f%%", name, c.EcnMarkedPkts, c.TransmitPkts, marked, opts.ECNPct))
+			}
+		}
+	}
+	return errs
+}
+
+// Verify reads the counters of the flows of offers from the ATE and of the
+// output queues of intf from the DUT, and reports as test errors where they
+// differ from what the model expects.  before are the queue counters taken
+// before traffic started.
+func Verify(t testing.TB, dut *ondatra.DUTDevice, ate *ondatra.ATEDevice, intf string, m *Model, offers []Offer, before map[string]QueueCounters, opts CheckOptions) {
+	t.Helper()
+	flows := map[string]otgutils.FlowCounters{}
+	for _, o := range offers {
+		flows[o.Flow] = otgutils.GetFlowCounters(t, ate.OTG(), o.Flow)
+	}
+	queues := map[string]QueueCounters{}
+	for name, after := range GetQueueCounters(t, dut, intf, m.QueueNames()) {
+		if b, ok := before[name]; ok {
+			queues[name] = after.Sub(b)
+		}
+	}
+	for name, c := range queues {
+		t.Logf("Queue %s of %s: %+v", name, intf, c)
+	}
+	for _, err := range m.Check(offers, flows, queues, opts) {
+		t.Error(err)
+	}
+}
@@ -53,7 +53,7 @@ import (
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/encoding/prototext"
-	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/proto"
 )
 
 const (
@@ -84,6 +84,7 @@ const (
 	gribiGetPath         = "/gribi.gRIBI/Get"
 	p4rtCapabilitiesPath = "/p4.v1.P4Runtime/Capabilities"
 	defaultSSHPort       = 22
+	nokiaGrpcPort        = 10162
 	ipProto              = 6
 )
 
@@ -501,8 +502,15 @@ func (x *nokiaAcctzRecordSubscribeClient) Recv() (*acctzpb.RecordResponse, error
 	return m, nil
 }
 
-// GetNokiaCustomAcctzClient returns a custom gNSI Acctz client for Nokia devices connecting to port 10162.
+// GetNokiaCustomAcctzClient returns a custom gNSI Acctz client for Nokia devices connecting to port nokiaGrpcPort.
 func GetNokiaCustomAcctzClient(t *testing.T, dut *ondatra.DUTDevice) AcctzStreamClient {
+	t.Helper()
+	return &nokiaAcctzClient{conn: dialNokiaGrpcServer(context.Background(), t, dut)}
+}
+
+// dialNokiaGrpcServer dials the gRPC server SetupUsers creates on Nokia
+// devices, which authenticates users with metadata.
+func dialNokiaGrpcServer(ctx context.Context, t *testing.T, dut *ondatra.DUTDevice) *grpc.ClientConn {
 	t.Helper()
 	var dialer interface {
 		DialGRPCWithPort(context.Context, int, ...grpc.DialOption) (*grpc.ClientConn, error)
@@ -511,12 +519,11 @@ func GetNokiaCustomAcctzClient(t *testing.T, dut *ondatra.DUTDevice) AcctzStream
 	if err := binding.DUTAs(bindingDUT, &dialer); err != nil {
 		t.Fatalf("BindingDUT %T does not implement DialGRPCWithPort, which is required for Nokia custom client: %v", bindingDUT, err)
 	}
-
-	conn, err := dialer.DialGRPCWithPort(context.Background(), 10162)
+	conn, err := dialer.DialGRPCWithPort(ctx, nokiaGrpcPort)
 	if err != nil {
-		t.Fatalf("DialGRPCWithPort failed for port 10162: %v", err)
+		t.Fatalf("DialGRPCWithPort failed for port %d: %v", nokiaGrpcPort, err)
 	}
-	return &nokiaAcctzClient{conn: conn}
+	return conn
 }
 
 // func getGrpcTarget(t *testing.T, dut *ondatra.DUTDevice, service introspect.Service) string {
@@ -760,14 +767,14 @@ func getMetadataKeys(dut *ondatra.DUTDevice) (string, string) {
 }
 
 // SendGnmiRPCs Setup gNMI test RPCs (successful and failed) to be used in the acctz client tests.
-func SendGnmiRPCs(t *testing.T, dut *ondatra.DUTDevice) []*acctzpb.RecordResponse {
+func SendGnmiRPCs(t *testing.T, dut *ondatra.DUTDevice) []*Expectation {
 	// Per https://github.com/openconfig/featureprofiles/issues/2637, waiting to see what the
 	// "best"/"preferred" way is to get the v4/v6 of the dut. For now, we just use introspection
 	// but that won't get us v4 and v6, it will just get us whatever is configured in binding,
 	// so while the test asks for v4 and v6 we'll just be doing it for whatever we get.
 	// target := getGrpcTarget(t, dut, introspect.GNMI)
 
-	var records []*acctzpb.RecordResponse
+	var want []*Expectation
 	// grpcConn := dialGrpc(t, target)
 	userKey, passKey := getMetadataKeys(dut)
 	if dut.Vendor() == ondatra.ARISTA {
@@ -781,18 +788,7 @@ func SendGnmiRPCs(t *testing.T, dut *ondatra.DUTDevice) []*acctzpb.RecordRespons
 	var gnmiClient gnmipb.GNMIClient
 	var err error
 	if dut.Vendor() == ondatra.NOKIA {
-		var dialer interface {
-			DialGRPCWithPort(context.Context, int, ...grpc.DialOption) (*grpc.ClientConn, error)
-		}
-		bindingDUT := dut.RawAPIs().BindingDUT()
-		if err := binding.DUTAs(bindingDUT, &dialer); err != nil {
-			t.Fatalf("BindingDUT %T does not implement DialGRPCWithPort: %v", bindingDUT, err)
-		}
-		conn, err := dialer.DialGRPCWithPort(ctx, 10162)
-		if err != nil {
-			t.Fatalf("Failed dialing custom gNMI port: %v", err)
-		}
-		gnmiClient = gnmipb.NewGNMIClient(conn)
+		gnmiClient = gnmipb.NewGNMIClient(dialNokiaGrpcServer(ctx, t, dut))
 	} else {
 		gnmiClient, err = dut.RawAPIs().BindingDUT().DialGNMI(ctx)
 		if err != nil {
@@ -808,27 +804,7 @@ func SendGnmiRPCs(t *testing.T, dut *ondatra.DUTDevice) []*acctzpb.RecordRespons
 	}
 
 	if !deviations.AcctzRecordFailGrpcUnsupported(dut) {
-		records = append(records, &acctzpb.RecordResponse{
-			ServiceRequest: &acctzpb.RecordResponse_GrpcService{
-				GrpcService: &acctzpb.GrpcService{
-					ServiceType: acctzpb.GrpcService_GRPC_SERVICE_TYPE_GNMI,
-					RpcName:     gnmiCapabilitiesPath,
-					Authz: &acctzpb.AuthzDetail{
-						Status: expectedAuthzStatus(dut, acctzpb.AuthzDetail_AUTHZ_STATUS_DENY, gnmiCapabilitiesPath),
-					},
-				},
-			},
-			SessionInfo: &acctzpb.SessionInfo{
-				Status: acctzpb.SessionInfo_SESSION_STATUS_ONCE,
-				Authn: &acctzpb.AuthnDetail{
-					Type:   acctzpb.AuthnDetail_AUTHN_TYPE_UNSPECIFIED,
-					Status: acctzpb.AuthnDetail_AUTHN_STATUS_UNSPECIFIED,
-				},
-				User: &acctzpb.UserDetail{
-					Identity: failuser,
-				},
-			},
-		})
+		want = append(want, failedGrpcExpectation(dut, acctzpb.GrpcService_GRPC_SERVICE_TYPE_GNMI, gnmiCapabilitiesPath, failuser, acctzpb.AuthzDetail_AUTHZ_STATUS_DENY))
 	}
 
 	// Send a successful gNMI capabilities request.
@@ -836,11 +812,6 @@ func SendGnmiRPCs(t *testing.T, dut *ondatra.DUTDevice) []*acctzpb.RecordRespons
 	ctx = metadata.AppendToOutgoingContext(ctx, "username", SuccessUsername)
 	ctx = metadata.AppendToOutgoingContext(ctx, "password", successPassword)
 	req := &gnmipb.CapabilityRequest{}
-	payload, err := anypb.New(req)
-
-	if err != nil {
-		t.Fatal("Failed creating anypb payload.")
-	}
 	_, err = gnmiClient.Capabilities(ctx, req)
 	if err != nil {
 		t.Fatalf("Error fetching capabilities, error: %s", err)
@@ -856,49 +827,20 @@ func SendGnmiRPCs(t *testing.T, dut *ondatra.DUTDevice) []*acctzpb.RecordRespons
 	// 	t.Logf("Interface: %v", intf)
 	// }
 
-	records = append(records, &acctzpb.RecordResponse{
-		ServiceRequest: &acctzpb.RecordResponse_GrpcService{
-			GrpcService: &acctzpb.GrpcService{
-				ServiceType: acctzpb.GrpcService_GRPC_SERVICE_TYPE_GNMI,
-				RpcName:     gnmiCapabilitiesPath,
-				Payload: &acctzpb.GrpcService_ProtoVal{
-					ProtoVal: payload,
-				},
-				Authz: &acctzpb.AuthzDetail{
-					Status: acctzpb.AuthzDetail_AUTHZ_STATUS_PERMIT,
-				},
-			},
-		},
-		SessionInfo: &acctzpb.SessionInfo{
-			Status: acctzpb.SessionInfo_SESSION_STATUS_ONCE,
-			//			LocalAddress:  localIP,
-			//			LocalPort:     localPort,
-			//			RemoteAddress: remoteIP,
-			//			RemotePort:    remotePort,
-			IpProto: ipProto,
-			Authn: &acctzpb.AuthnDetail{
-				Type:   acctzpb.AuthnDetail_AUTHN_TYPE_UNSPECIFIED,
-				Status: acctzpb.AuthnDetail_AUTHN_STATUS_SUCCESS,
-				Cause:  "authentication_method: local",
-			},
-			User: &acctzpb.UserDetail{
-				Identity: SuccessUsername,
-			},
-		},
-	})
+	want = append(want, grpcExpectation(acctzpb.GrpcService_GRPC_SERVICE_TYPE_GNMI, gnmiCapabilitiesPath, req))
 
-	return records
+	return want
 }
 
 // SendGnoiRPCs Setup gNOI test RPCs (successful and failed) to be used in the acctz client tests.
-func SendGnoiRPCs(t *testing.T, dut *ondatra.DUTDevice) []*acctzpb.RecordResponse {
+func SendGnoiRPCs(t *testing.T, dut *ondatra.DUTDevice) []*Expectation {
 	// Per https://github.com/openconfig/featureprofiles/issues/2637, waiting to see what the
 	// "best"/"preferred" way is to get the v4/v6 of the dut. For now, we just use introspection
 	// but that won't get us v4 and v6, it will just get us whatever is configured in binding,
 	// so while the test asks for v4 and v6 we'll just be doing it for whatever we get.
 	// target := getGrpcTarget(t, dut, introspect.GNOI)
 
-	var records []*acctzpb.RecordResponse
+	var want []*Expectation
 	// grpcConn := dialGrpc(t, target)
 	// gnoiSystemClient := dut.RawAPIs().GNOI(t).System()
 	// systempb.NewSystemClient(grpcConn)
@@ -914,24 +856,13 @@ func SendGnoiRPCs(t *testing.T, dut *ondatra.DUTDevice) []*acctzpb.RecordRespons
 	ctx := context.Background()
 
 	if dut.Vendor() == ondatra.NOKIA {
-		var dialer interface {
-			DialGRPCWithPort(context.Context, int, ...grpc.DialOption) (*grpc.ClientConn, error)
-		}
-		bindingDUT := dut.RawAPIs().BindingDUT()
-		if err := binding.DUTAs(bindingDUT, &dialer); err != nil {
-			t.Fatalf("BindingDUT %T does not implement DialGRPCWithPort: %v", bindingDUT, err)
-		}
-		conn, err := dialer.DialGRPCWithPort(ctx, 10162)
-		if err != nil {
-			t.Fatalf("Failed dialing custom gNOI port: %v", err)
-		}
-		gnoiSystemClient = systempb.NewSystemClient(conn)
+		gnoiSystemClient = systempb.NewSystemClient(dialNokiaGrpcServer(ctx, t, dut))
 	} else {
 		gnoiSystemClient = dut.RawAPIs().GNOI(t).System()
 	}
 	ctx = metadata.NewOutgoingContext(context.Background(), metadata.Pairs(userKey, failuser, passKey, failpass))
 	var rpcName string
-	var payload *anypb.Any
+	var payload proto.Message
 	var err error
 	if dut.Vendor() == ondatra.NOKIA {
 		rpcName = gnoiTimePath
@@ -959,27 +890,7 @@ func SendGnoiRPCs(t *testing.T, dut *ondatra.DUTDevice) []*acctzpb.RecordRespons
 	}
 
 	if !deviations.AcctzRecordFailGrpcUnsupported(dut) {
-		records = append(records, &acctzpb.RecordResponse{
-			ServiceRequest: &acctzpb.RecordResponse_GrpcService{
-				GrpcService: &acctzpb.GrpcService{
-					ServiceType: acctzpb.GrpcService_GRPC_SERVICE_TYPE_GNOI,
-					RpcName:     rpcName,
-					Authz: &acctzpb.AuthzDetail{
-						Status: expectedAuthzStatus(dut, acctzpb.AuthzDetail_AUTHZ_STATUS_DENY, rpcName),
-					},
-				},
-			},
-			SessionInfo: &acctzpb.SessionInfo{
-				Status: acctzpb.SessionInfo_SESSION_STATUS_ONCE,
-				Authn: &acctzpb.AuthnDetail{
-					Type:   acctzpb.AuthnDetail_AUTHN_TYPE_UNSPECIFIED,
-					Status: acctzpb.AuthnDetail_AUTHN_STATUS_UNSPECIFIED,
-				},
-				User: &acctzpb.UserDetail{
-					Identity: failuser,
-				},
-			},
-		})
+		want = append(want, failedGrpcExpectation(dut, acctzpb.GrpcService_GRPC_SERVICE_TYPE_GNOI, rpcName, failuser, acctzpb.AuthzDetail_AUTHZ_STATUS_DENY))
 	}
 
 	// Send a successful gNOI request.
@@ -989,10 +900,7 @@ func SendGnoiRPCs(t *testing.T, dut *ondatra.DUTDevice) []*acctzpb.RecordRespons
 
 	if dut.Vendor() == ondatra.NOKIA {
 		req := &systempb.TimeRequest{}
-		payload, err = anypb.New(req)
-		if err != nil {
-			t.Errorf("Failed creating anypb payload.")
-		}
+		payload = req
 		_, err = gnoiSystemClient.Time(ctx, req)
 		if err != nil {
 			t.Errorf("Error fetching gnoi system time, error: %s", err)
@@ -1002,10 +910,7 @@ func SendGnoiRPCs(t *testing.T, dut *ondatra.DUTDevice) []*acctzpb.RecordRespons
 			Destination: "127.0.0.1",
 			Count:       1,
 		}
-		payload, err = anypb.New(req)
-		if err != nil {
-			t.Errorf("Failed creating anypb payload.")
-		}
+		payload = req
 		gnoiSystemPingClient, err1 := gnoiSystemClient.Ping(ctx, req)
 		if err1 != nil {
 			t.Errorf("Error fetching gnoi system ping, error: %s", err1)
@@ -1020,49 +925,20 @@ func SendGnoiRPCs(t *testing.T, dut *ondatra.DUTDevice) []*acctzpb.RecordRespons
 	// remoteIP, remotePort := getHostPortInfo(t, gRPCClientAddr.String())
 	// localIP, localPort := getHostPortInfo(t, target)
 
-	records = append(records, &acctzpb.RecordResponse{
-		ServiceRequest: &acctzpb.RecordResponse_GrpcService{
-			GrpcService: &acctzpb.GrpcService{
-				ServiceType: acctzpb.GrpcService_GRPC_SERVICE_TYPE_GNOI,
-				RpcName:     rpcName,
-				Payload: &acctzpb.GrpcService_ProtoVal{
-					ProtoVal: payload,
-				},
-				Authz: &acctzpb.AuthzDetail{
-					Status: acctzpb.AuthzDetail_AUTHZ_STATUS_PERMIT,
-				},
-			},
-		},
-		SessionInfo: &acctzpb.SessionInfo{
-			Status: acctzpb.SessionInfo_SESSION_STATUS_ONCE,
-			// LocalAddress:  localIP,
-			// LocalPort:     localPort,
-			// RemoteAddress: remoteIP,
-			// RemotePort:    remotePort,
-			IpProto: ipProto,
-			Authn: &acctzpb.AuthnDetail{
-				Type:   acctzpb.AuthnDetail_AUTHN_TYPE_UNSPECIFIED,
-				Status: acctzpb.AuthnDetail_AUTHN_STATUS_SUCCESS,
-				Cause:  "authentication_method: local",
-			},
-			User: &acctzpb.UserDetail{
-				Identity: SuccessUsername,
-			},
-		},
-	})
+	want = append(want, grpcExpectation(acctzpb.GrpcService_GRPC_SERVICE_TYPE_GNOI, rpcName, payload))
 
-	return records
+	return want
 }
 
 // SendGnsiRPCs Setup gNSI test RPCs (successful and failed) to be used in the acctz client tests.
-func SendGnsiRPCs(t *testing.T, dut *ondatra.DUTDevice) []*acctzpb.RecordResponse {
+func SendGnsiRPCs(t *testing.T, dut *ondatra.DUTDevice) []*Expectation {
 	// Per https://github.com/openconfig/featureprofiles/issues/2637, waiting to see what the
 	// "best"/"preferred" way is to get the v4/v6 of the dut. For now, we just use introspection
 	// but that won't get us v4 and v6, it will just get us whatever is configured in binding,
 	// so while the test asks for v4 and v6 we'll just be doing it for whatever we get.
 	// target := getGrpcTarget(t, dut, introspect.GNSI)
 
-	var records []*acctzpb.RecordResponse
+	var want []*Expectation
 	// grpcConn := dialGrpc(t, target)
 	// authzClient := dut.RawAPIs().GNSI(t).Authz()
 	userKey, passKey := getMetadataKeys(dut)
@@ -1076,18 +952,7 @@ func SendGnsiRPCs(t *testing.T, dut *ondatra.DUTDevice) []*acctzpb.RecordRespons
 	ctx := metadata.NewOutgoingContext(context.Background(), metadata.Pairs(userKey, failuser, passKey, failpass))
 	var authzClient authzpb.AuthzClient
 	if dut.Vendor() == ondatra.NOKIA {
-		var dialer interface {
-			DialGRPCWithPort(context.Context, int, ...grpc.DialOption) (*grpc.ClientConn, error)
-		}
-		bindingDUT := dut.RawAPIs().BindingDUT()
-		if err := binding.DUTAs(bindingDUT, &dialer); err != nil {
-			t.Fatalf("BindingDUT %T does not implement DialGRPCWithPort: %v", bindingDUT, err)
-		}
-		conn, err := dialer.DialGRPCWithPort(ctx, 10162)
-		if err != nil {
-			t.Fatalf("Failed dialing custom gNSI port: %v", err)
-		}
-		authzClient = authzpb.NewAuthzClient(conn)
+		authzClient = authzpb.NewAuthzClient(dialNokiaGrpcServer(ctx, t, dut))
 	} else {
 		authzClient = dut.RawAPIs().GNSI(t).Authz()
 	}
@@ -1100,37 +965,13 @@ func SendGnsiRPCs(t *testing.T, dut *ondatra.DUTDevice) []*acctzpb.RecordRespons
 		t.Errorf("Did not get expected error fetching authz policy with no permissions. error: %s", err)
 	}
 	if !deviations.AcctzRecordFailGrpcUnsupported(dut) {
-		records = append(records, &acctzpb.RecordResponse{
-			ServiceRequest: &acctzpb.RecordResponse_GrpcService{
-				GrpcService: &acctzpb.GrpcService{
-					ServiceType: acctzpb.GrpcService_GRPC_SERVICE_TYPE_GNSI,
-					RpcName:     gnsiGetPath,
-					Authz: &acctzpb.AuthzDetail{
-						Status: expectedAuthzStatus(dut, acctzpb.AuthzDetail_AUTHZ_STATUS_DENY, gnsiGetPath),
-					},
-				},
-			},
-			SessionInfo: &acctzpb.SessionInfo{
-				Status: acctzpb.SessionInfo_SESSION_STATUS_ONCE,
-				Authn: &acctzpb.AuthnDetail{
-					Type:   acctzpb.AuthnDetail_AUTHN_TYPE_UNSPECIFIED,
-					Status: acctzpb.AuthnDetail_AUTHN_STATUS_UNSPECIFIED,
-				},
-				User: &acctzpb.UserDetail{
-					Identity: failuser,
-				},
-			},
-		})
+		want = append(want, failedGrpcExpectation(dut, acctzpb.GrpcService_GRPC_SERVICE_TYPE_GNSI, gnsiGetPath, failuser, acctzpb.AuthzDetail_AUTHZ_STATUS_DENY))
 	}
 	// Send a successful gNSI authz get request.
 	ctx = context.Background()
 	ctx = metadata.AppendToOutgoingContext(ctx, "username", SuccessUsername)
 	ctx = metadata.AppendToOutgoingContext(ctx, "password", successPassword)
 	req := &authzpb.GetRequest{}
-	payload, err := anypb.New(req)
-	if err != nil {
-		t.Errorf("Failed creating anypb payload.")
-	}
 	msg, err := authzClient.Get(ctx, &authzpb.GetRequest{})
 	if err != nil && msg != nil {
 		t.Errorf("Error fetching authz policy, error: %s", err)
@@ -1140,42 +981,13 @@ func SendGnsiRPCs(t *testing.T, dut *ondatra.DUTDevice) []*acctzpb.RecordRespons
 	// remoteIP, remotePort := getHostPortInfo(t, gRPCClientAddr.String())
 	// localIP, localPort := getHostPortInfo(t, target)
 
-	records = append(records, &acctzpb.RecordResponse{
-		ServiceRequest: &acctzpb.RecordResponse_GrpcService{
-			GrpcService: &acctzpb.GrpcService{
-				ServiceType: acctzpb.GrpcService_GRPC_SERVICE_TYPE_GNSI,
-				RpcName:     gnsiGetPath,
-				Payload: &acctzpb.GrpcService_ProtoVal{
-					ProtoVal: payload,
-				},
-				Authz: &acctzpb.AuthzDetail{
-					Status: acctzpb.AuthzDetail_AUTHZ_STATUS_PERMIT,
-				},
-			},
-		},
-		SessionInfo: &acctzpb.SessionInfo{
-			Status: acctzpb.SessionInfo_SESSION_STATUS_ONCE,
-			// LocalAddress:  localIP,
-			// LocalPort:     localPort,
-			// RemoteAddress: remoteIP,
-			// RemotePort:    remotePort,
-			IpProto: ipProto,
-			Authn: &acctzpb.AuthnDetail{
-				Type:   acctzpb.AuthnDetail_AUTHN_TYPE_UNSPECIFIED,
-				Status: acctzpb.AuthnDetail_AUTHN_STATUS_SUCCESS,
-				Cause:  "authentication_method: local",
-			},
-			User: &acctzpb.UserDetail{
-				Identity: SuccessUsername,
-			},
-		},
-	})
+	want = append(want, grpcExpectation(acctzpb.GrpcService_GRPC_SERVICE_TYPE_GNSI, gnsiGetPath, req))
 
-	return records
+	return want
 }
 
 // SendGribiRPCs Setup gRIBI test RPCs (successful and failed) to be used in the acctz client tests.
-func SendGribiRPCs(t *testing.T, dut *ondatra.DUTDevice) []*acctzpb.RecordResponse {
+func SendGribiRPCs(t *testing.T, dut *ondatra.DUTDevice) []*Expectation {
 	// Per https://github.com/openconfig/featureprofiles/issues/2637, waiting to see what the
 	// "best"/"preferred" way is to get the v4/v6 of the dut. For now, we just use introspection
 	// but that won't get us v4 and v6, it will just get us whatever is configured in binding,
@@ -1183,7 +995,7 @@ func SendGribiRPCs(t *testing.T, dut *ondatra.DUTDevice) []*acctzpb.RecordRespon
 
 	// target := getGrpcTarget(t, dut, introspect.GRIBI)
 
-	var records []*acctzpb.RecordResponse
+	var want []*Expectation
 	// grpcConn := dialGrpc(t, target)
 	// gribiClient := gribi.NewGRIBIClient(grpcConn)
 	// gribiClient,err := dut.RawAPIs().BindingDUT().DialGRIBI
@@ -1229,27 +1041,7 @@ func SendGribiRPCs(t *testing.T, dut *ondatra.DUTDevice) []*acctzpb.RecordRespon
 		}
 	}
 
-	records = append(records, &acctzpb.RecordResponse{
-		ServiceRequest: &acctzpb.RecordResponse_GrpcService{
-			GrpcService: &acctzpb.GrpcService{
-				ServiceType: acctzpb.GrpcService_GRPC_SERVICE_TYPE_GRIBI,
-				RpcName:     gribiGetPath,
-				Authz: &acctzpb.AuthzDetail{
-					Status: expectedAuthzStatus(dut, rpcExpStatus, gribiGetPath),
-				},
-			},
-		},
-		SessionInfo: &acctzpb.SessionInfo{
-			Status: acctzpb.SessionInfo_SESSION_STATUS_ONCE,
-			Authn: &acctzpb.AuthnDetail{
-				Type:   acctzpb.AuthnDetail_AUTHN_TYPE_UNSPECIFIED,
-				Status: acctzpb.AuthnDetail_AUTHN_STATUS_UNSPECIFIED,
-			},
-			User: &acctzpb.UserDetail{
-				Identity: failuser,
-			},
-		},
-	})
+	want = append(want, failedGrpcExpectation(dut, acctzpb.GrpcService_GRPC_SERVICE_TYPE_GRIBI, gribiGetPath, failuser, rpcExpStatus))
 
 	// Send a successful gRIBI get request.
 	ctx = context.Background()
@@ -1259,10 +1051,6 @@ func SendGribiRPCs(t *testing.T, dut *ondatra.DUTDevice) []*acctzpb.RecordRespon
 		NetworkInstance: &gribi.GetRequest_All{},
 		Aft:             gribi.AFTType_IPV4,
 	}
-	payload, err := anypb.New(req)
-	if err != nil {
-		t.Fatal("Failed creating anypb payload.")
-	}
 	gribiGetClient, err = gribiClient.Get(ctx, req)
 	if err != nil {
 		t.Fatalf("Got unexpected error during gribi get request, error: %s", err)
@@ -1279,42 +1067,13 @@ func SendGribiRPCs(t *testing.T, dut *ondatra.DUTDevice) []*acctzpb.RecordRespon
 	// remoteIP, remotePort := getHostPortInfo(t, gRPCClientAddr.String())
 	// localIP, localPort := getHostPortInfo(t, target)
 
-	records = append(records, &acctzpb.RecordResponse{
-		ServiceRequest: &acctzpb.RecordResponse_GrpcService{
-			GrpcService: &acctzpb.GrpcService{
-				ServiceType: acctzpb.GrpcService_GRPC_SERVICE_TYPE_GRIBI,
-				RpcName:     gribiGetPath,
-				Payload: &acctzpb.GrpcService_ProtoVal{
-					ProtoVal: payload,
-				},
-				Authz: &acctzpb.AuthzDetail{
-					Status: expectedAuthzStatus(dut, acctzpb.AuthzDetail_AUTHZ_STATUS_PERMIT, gribiGetPath),
-				},
-			},
-		},
-		SessionInfo: &acctzpb.SessionInfo{
-			Status: acctzpb.SessionInfo_SESSION_STATUS_ONCE,
-			// LocalAddress:  localIP,
-			// LocalPort:     localPort,
-			// RemoteAddress: remoteIP,
-			// RemotePort:    remotePort,
-			IpProto: ipProto,
-			Authn: &acctzpb.AuthnDetail{
-				Type:   acctzpb.AuthnDetail_AUTHN_TYPE_UNSPECIFIED,
-				Status: acctzpb.AuthnDetail_AUTHN_STATUS_SUCCESS,
-				Cause:  "authentication_method: local",
-			},
-			User: &acctzpb.UserDetail{
-				Identity: SuccessUsername,
-			},
-		},
-	})
+	want = append(want, grpcExpectation(acctzpb.GrpcService_GRPC_SERVICE_TYPE_GRIBI, gribiGetPath, req))
 
-	return records
+	return want
 }
 
 // SendP4rtRPCs Setup P4RT test RPCs (successful and failed) to be used in the acctz client tests.
-func SendP4rtRPCs(t *testing.T, dut *ondatra.DUTDevice) []*acctzpb.RecordResponse {
+func SendP4rtRPCs(t *testing.T, dut *ondatra.DUTDevice) []*Expectation {
 	// Per https://github.com/openconfig/featureprofiles/issues/2637, waiting to see what the
 	// "best"/"preferred" way is to get the v4/v6 of the dut. For now, we just use introspection
 	// but that won't get us v4 and v6, it will just get us whatever is configured in binding,
@@ -1355,7 +1114,7 @@ func SendP4rtRPCs(t *testing.T, dut *ondatra.DUTDevice) []*acctzpb.RecordRespons
 		}
 		t.Log("P4Runtime agent is up and running")
 	}
-	var records []*acctzpb.RecordResponse
+	var want []*Expectation
 	// grpcConn := dialGrpc(t, target)
 	userKey, passKey := getMetadataKeys(dut)
 	if dut.Vendor() == ondatra.ARISTA {
@@ -1386,36 +1145,12 @@ func SendP4rtRPCs(t *testing.T, dut *ondatra.DUTDevice) []*acctzpb.RecordRespons
 		}
 	}
 	if !deviations.AcctzRecordFailGrpcUnsupported(dut) {
-		records = append(records, &acctzpb.RecordResponse{
-			ServiceRequest: &acctzpb.RecordResponse_GrpcService{
-				GrpcService: &acctzpb.GrpcService{
-					ServiceType: acctzpb.GrpcService_GRPC_SERVICE_TYPE_P4RT,
-					RpcName:     p4rtCapabilitiesPath,
-					Authz: &acctzpb.AuthzDetail{
-						Status: expectedAuthzStatus(dut, rpcExpStatus, p4rtCapabilitiesPath),
-					},
-				},
-			},
-			SessionInfo: &acctzpb.SessionInfo{
-				Status: acctzpb.SessionInfo_SESSION_STATUS_ONCE,
-				Authn: &acctzpb.AuthnDetail{
-					Type:   acctzpb.AuthnDetail_AUTHN_TYPE_UNSPECIFIED,
-					Status: acctzpb.AuthnDetail_AUTHN_STATUS_UNSPECIFIED,
-				},
-				User: &acctzpb.UserDetail{
-					Identity: failuser,
-				},
-			},
-		})
+		want = append(want, failedGrpcExpectation(dut, acctzpb.GrpcService_GRPC_SERVICE_TYPE_P4RT, p4rtCapabilitiesPath, failuser, rpcExpStatus))
 	}
 	ctx = context.Background()
 	ctx = metadata.AppendToOutgoingContext(ctx, "username", SuccessUsername)
 	ctx = metadata.AppendToOutgoingContext(ctx, "password", successPassword)
 	req := &p4pb.CapabilitiesRequest{}
-	payload, err := anypb.New(req)
-	if err != nil {
-		t.Fatal("Failed creating anypb payload.")
-	}
 	_, err = p4rtclient.Capabilities(ctx, req)
 	if err != nil {
 		t.Fatalf("Error fetching p4rt capabilities, error: %s", err)
@@ -1425,49 +1160,18 @@ func SendP4rtRPCs(t *testing.T, dut *ondatra.DUTDevice) []*acctzpb.RecordRespons
 	// remoteIP, remotePort := getHostPortInfo(t, gRPCClientAddr.String())
 	// localIP, localPort := getHostPortInfo(t, target)
 
-	records = append(records, &acctzpb.RecordResponse{
-		ServiceRequest: &acctzpb.RecordResponse_GrpcService{
-			GrpcService: &acctzpb.GrpcService{
-				ServiceType: acctzpb.GrpcService_GRPC_SERVICE_TYPE_P4RT,
-				RpcName:     p4rtCapabilitiesPath,
-				Payload: &acctzpb.GrpcService_ProtoVal{
-					ProtoVal: payload,
-				},
-				Authz: &acctzpb.AuthzDetail{
-					Status: expectedAuthzStatus(dut, acctzpb.AuthzDetail_AUTHZ_STATUS_PERMIT, p4rtCapabilitiesPath),
-				},
-			},
-		},
-		SessionInfo: &acctzpb.SessionInfo{
-			Status: acctzpb.SessionInfo_SESSION_STATUS_ONCE,
-			// LocalAddress:  localIP,
-			// LocalPort:     localPort,
-			// RemoteAddress: remoteIP,
-			// RemotePort:    remotePort,
-			IpProto: ipProto,
-			Authn: &acctzpb.AuthnDetail{
-				Type:   acctzpb.AuthnDetail_AUTHN_TYPE_UNSPECIFIED,
-				Status: acctzpb.AuthnDetail_AUTHN_STATUS_SUCCESS,
-				Cause:  "authentication_method: local",
-			},
-			User: &acctzpb.UserDetail{
-				Identity: SuccessUsername,
-			},
-		},
-	})
+	want = append(want, grpcExpectation(acctzpb.GrpcService_GRPC_SERVICE_TYPE_P4RT, p4rtCapabilitiesPath, req))
 
-	return records
+	return want
 }
 
 // SendSuccessCliCommand Setup test CLI command (successful) to be used in the acctz client tests.
-func SendSuccessCliCommand(t *testing.T, dut *ondatra.DUTDevice, staticBinding bool) []*acctzpb.RecordResponse {
+func SendSuccessCliCommand(t *testing.T, dut *ondatra.DUTDevice, staticBinding bool) []*Expectation {
 	// Per https://github.com/openconfig/featureprofiles/issues/2637, waiting to see what the
 	// "best"/"preferred" way is to get the v4/v6 of the dut. For now, we use this workaround
 	// because ssh isn't exposed in introspection.
 	target := GetSSHTarget(t, dut, staticBinding)
 
-	var records []*acctzpb.RecordResponse
-
 	sshConn, w := dialSSH(t, dut, SuccessUsername, successPassword, target)
 	defer func() {
 		// Give things a second to percolate then close the connection.
@@ -1487,69 +1191,18 @@ func SendSuccessCliCommand(t *testing.T, dut *ondatra.DUTDevice, staticBinding b
 	// remoteIP, remotePort := getHostPortInfo(t, sshConn.LocalAddr().String())
 	// localIP, localPort := getHostPortInfo(t, target)
 
-	var authnField *acctzpb.AuthnDetail
-
-	switch dut.Vendor() {
-	case ondatra.CISCO:
-		// Authn field popoulated for Cisco only for Login/Once/Enable records.
-		authnField = nil
-	default:
-		authnField = &acctzpb.AuthnDetail{
-			Type:   acctzpb.AuthnDetail_AUTHN_TYPE_UNSPECIFIED,
-			Status: acctzpb.AuthnDetail_AUTHN_STATUS_SUCCESS,
-			Cause:  "authentication_method: local",
-		}
-	}
-
-	var userRole string
-	switch dut.Vendor() {
-	case ondatra.CISCO:
-		userRole = "root-lr, cisco-support"
-	case ondatra.NOKIA:
-		userRole = "admin"
-	case ondatra.ARISTA:
-		userRole = "network-admin"
-	default:
-		userRole = ""
-	}
-
-	records = append(records, &acctzpb.RecordResponse{
-		ServiceRequest: &acctzpb.RecordResponse_CmdService{
-			CmdService: &acctzpb.CommandService{
-				ServiceType: acctzpb.CommandService_CMD_SERVICE_TYPE_CLI,
-				Cmd:         successCliCommand,
-				Authz: &acctzpb.AuthzDetail{
-					Status: expectedAuthzStatus(dut, acctzpb.AuthzDetail_AUTHZ_STATUS_PERMIT, successCliCommand),
-				},
-			},
-		},
-		SessionInfo: &acctzpb.SessionInfo{
-			Status: acctzpb.SessionInfo_SESSION_STATUS_OPERATION,
-			// LocalAddress:  localIP,
-			// LocalPort:     localPort,
-			// RemoteAddress: remoteIP,
-			// RemotePort:    remotePort,
-			IpProto: ipProto,
-			Authn:   authnField,
-			User: &acctzpb.UserDetail{
-				Identity: SuccessUsername,
-				Role:     userRole,
-			},
-		},
-	})
-
-	return records
+	e := cmdExpectation(acctzpb.CommandService_CMD_SERVICE_TYPE_CLI, successCliCommand, SuccessUsername, userRole(dut, "network-admin"), acctzpb.AuthzDetail_AUTHZ_STATUS_PERMIT)
+	e.Authn = cliAuthn(dut)
+	return []*Expectation{e}
 }
 
 // SendFailCliCommand Setup test CLI command (failed) to be used in the acctz client tests.
-func SendFailCliCommand(t *testing.T, dut *ondatra.DUTDevice, staticBinding bool) []*acctzpb.RecordResponse {
+func SendFailCliCommand(t *testing.T, dut *ondatra.DUTDevice, staticBinding bool) []*Expectation {
 	// Per https://github.com/openconfig/featureprofiles/issues/2637, waiting to see what the
 	// "best"/"preferred" way is to get the v4/v6 of the dut. For now, we use this workaround
 	// because ssh isn't exposed in introspection.
 	target := GetSSHTarget(t, dut, staticBinding)
 
-	var records []*acctzpb.RecordResponse
-
 	if dut.Vendor() == ondatra.ARISTA || dut.Vendor() == ondatra.NOKIA || dut.Vendor() == ondatra.CISCO {
 		failuser = failAuthorizeUsername
 		failpass = failAuthorizePassword
@@ -1576,77 +1229,22 @@ func SendFailCliCommand(t *testing.T, dut *ondatra.DUTDevice, staticBinding bool
 	// remoteIP, remotePort := getHostPortInfo(t, sshConn.LocalAddr().String())
 	// localIP, localPort := getHostPortInfo(t, target)
 
-	var authnField *acctzpb.AuthnDetail
-
-	switch dut.Vendor() {
-	case ondatra.CISCO:
-		// Authn field popoulated for Cisco only for Login/Once/Enable records.
-		authnField = nil
-	default:
-		authnField = &acctzpb.AuthnDetail{
-			Type:   acctzpb.AuthnDetail_AUTHN_TYPE_UNSPECIFIED,
-			Status: acctzpb.AuthnDetail_AUTHN_STATUS_SUCCESS,
-			Cause:  "authentication_method: local",
-		}
-	}
-
-	var authzStatusField *acctzpb.AuthzDetail
+	authz := acctzpb.AuthzDetail_AUTHZ_STATUS_DENY
 	if deviations.AcctzRecordsAuthzStatusDenyUnsupported(dut) {
-		authzStatusField = &acctzpb.AuthzDetail{
-			Status: acctzpb.AuthzDetail_AUTHZ_STATUS_UNSPECIFIED,
-		}
-	} else {
-		authzStatusField = &acctzpb.AuthzDetail{
-			Status: expectedAuthzStatus(dut, acctzpb.AuthzDetail_AUTHZ_STATUS_DENY, failCliCommand),
-		}
-	}
-
-	var userRole string
-	switch dut.Vendor() {
-	case ondatra.CISCO:
-		userRole = "root-lr, cisco-support"
-	case ondatra.NOKIA:
-		userRole = "admin"
-	case ondatra.ARISTA:
-		userRole = "acctz-fp-test-fail"
-	default:
-		userRole = ""
+		authz = acctzpb.AuthzDetail_AUTHZ_STATUS_UNSPECIFIED
 	}
-
-	records = append(records, &acctzpb.RecordResponse{
-		ServiceRequest: &acctzpb.RecordResponse_CmdService{
-			CmdService: &acctzpb.CommandService{
-				ServiceType: acctzpb.CommandService_CMD_SERVICE_TYPE_CLI,
-				Cmd:         failCliCommand,
-				Authz:       authzStatusField,
-			},
-		},
-		SessionInfo: &acctzpb.SessionInfo{
-			Status: acctzpb.SessionInfo_SESSION_STATUS_OPERATION,
-			// LocalAddress:  localIP,
-			// LocalPort:     localPort,
-			// RemoteAddress: remoteIP,
-			// RemotePort:    remotePort,
-			IpProto: ipProto,
-			Authn:   authnField,
-			User: &acctzpb.UserDetail{
-				Identity: failuser,
-				Role:     userRole,
-			},
-		},
-	})
-
-	return records
+	e := cmdExpectation(acctzpb.CommandService_CMD_SERVICE_TYPE_CLI, failCliCommand, failuser, userRole(dut, failRoleName), authz)
+	e.Authn = cliAuthn(dut)
+	return []*Expectation{e}
 }
 
 // SendShellCommand Setup test shell command (successful) to be used in the acctz client tests.
-func SendShellCommand(t *testing.T, dut *ondatra.DUTDevice, staticBinding bool) []*acctzpb.RecordResponse {
+func SendShellCommand(t *testing.T, dut *ondatra.DUTDevice, staticBinding bool) []*Expectation {
 	// Per https://github.com/openconfig/featureprofiles/issues/2637, waiting to see what the
 	// "best"/"preferred" way is to get the v4/v6 of the dut. For now, we use this workaround
 	// because ssh isn't exposed in introspection.
 	target := GetSSHTarget(t, dut, staticBinding)
 
-	var records []*acctzpb.RecordResponse
 	shellUsername := SuccessUsername
 	shellPassword := successPassword
 
@@ -1658,18 +1256,6 @@ func SendShellCommand(t *testing.T, dut *ondatra.DUTDevice, staticBinding bool)
 		shellPassword = "NokiaSrl1!"
 	}
 
-	var userRole string
-	switch dut.Vendor() {
-	case ondatra.CISCO:
-		userRole = "root-lr, cisco-support"
-	case ondatra.NOKIA:
-		userRole = "admin"
-	case ondatra.ARISTA:
-		userRole = "network-admin"
-	default:
-		userRole = ""
-	}
-
 	sshConn, w := dialSSH(t, dut, shellUsername, shellPassword, target)
 	defer func() {
 		// Give things a second to percolate then close the connection.
@@ -1687,39 +1273,7 @@ func SendShellCommand(t *testing.T, dut *ondatra.DUTDevice, staticBinding bool)
 		t.Fatalf("Failed sending cli command, error: %s", err)
 	}
 
-	// Remote from the perspective of the router.
-	// remoteIP, remotePort := getHostPortInfo(t, sshConn.LocalAddr().String())
-	// localIP, localPort := getHostPortInfo(t, target)
-
-	records = append(records, &acctzpb.RecordResponse{
-		ServiceRequest: &acctzpb.RecordResponse_CmdService{
-			CmdService: &acctzpb.CommandService{
-				ServiceType: acctzpb.CommandService_CMD_SERVICE_TYPE_SHELL,
-				Cmd:         shellCommand,
-				Authz: &acctzpb.AuthzDetail{
-					Status: expectedAuthzStatus(dut, acctzpb.AuthzDetail_AUTHZ_STATUS_PERMIT, shellCommand),
-				},
-			},
-		},
-		SessionInfo: &acctzpb.SessionInfo{
-			Status: acctzpb.SessionInfo_SESSION_STATUS_OPERATION,
-			// LocalAddress:  localIP,
-			// LocalPort:     localPort,
-			// RemoteAddress: remoteIP,
-			// RemotePort:    remotePort,
-			IpProto: ipProto,
-			Authn: &acctzpb.AuthnDetail{
-				Type:   acctzpb.AuthnDetail_AUTHN_TYPE_UNSPECIFIED,
-				Status: acctzpb.AuthnDetail_AUTHN_STATUS_UNSPECIFIED,
-			},
-			User: &acctzpb.UserDetail{
-				Identity: shellUsername,
-				Role:     userRole,
-			},
-		},
-	})
-
-	return records
+	return []*Expectation{cmdExpectation(acctzpb.CommandService_CMD_SERVICE_TYPE_SHELL, shellCommand, shellUsername, userRole(dut, "network-admin"), acctzpb.AuthzDetail_AUTHZ_STATUS_PERMIT)}
 }
 
 func enableAccountingStartStop(t *testing.T, dut *ondatra.DUTDevice) {
@@ -1878,9 +1432,72 @@ func getPrivEscalationCommand(dut *ondatra.DUTDevice) string {
 	}
 }
 
-func expectedAuthzStatus(dut *ondatra.DUTDevice, status acctzpb.AuthzDetail_AuthzStatus, rpcName string) acctzpb.AuthzDetail_AuthzStatus {
-	if dut.Vendor() == ondatra.ARISTA && rpcName == gribiGetPath && status == acctzpb.AuthzDetail_AUTHZ_STATUS_DENY {
-		return acctzpb.AuthzDetail_AUTHZ_STATUS_PERMIT
+// failedGrpcExpectation returns the expectation of the record of a request
+// of rpc of service sent by user without the permission, with authorization
+// status authz unless dut is known to account it otherwise.
+func failedGrpcExpectation(dut *ondatra.DUTDevice, service acctzpb.GrpcService_GrpcServiceType, rpc, user string, authz acctzpb.AuthzDetail_AuthzStatus) *Expectation {
+	if dut.Vendor() == ondatra.ARISTA && rpc == gribiGetPath {
+		authz = acctzpb.AuthzDetail_AUTHZ_STATUS_PERMIT
+	}
+	return &Expectation{
+		GrpcService: service,
+		RPC:         rpc,
+		Authz:       authz,
+		Session:     acctzpb.SessionInfo_SESSION_STATUS_ONCE,
+		User:        user,
+	}
+}
+
+// grpcExpectation returns the expectation of the record of request req of
+// rpc of service sent by SuccessUsername.
+func grpcExpectation(service acctzpb.GrpcService_GrpcServiceType, rpc string, req proto.Message) *Expectation {
+	return &Expectation{
+		GrpcService: service,
+		RPC:         rpc,
+		Payload:     req,
+		Authz:       acctzpb.AuthzDetail_AUTHZ_STATUS_PERMIT,
+		Session:     acctzpb.SessionInfo_SESSION_STATUS_ONCE,
+		Authn:       acctzpb.AuthnDetail_AUTHN_STATUS_SUCCESS,
+		IPProto:     ipProto,
+		User:        SuccessUsername,
+	}
+}
+
+// cmdExpectation returns the expectation of the record of cmd of service run
+// by user with role over SSH.
+func cmdExpectation(service acctzpb.CommandService_CmdServiceType, cmd, user, role string, authz acctzpb.AuthzDetail_AuthzStatus) *Expectation {
+	return &Expectation{
+		CmdService: service,
+		Cmd:        cmd,
+		Authz:      authz,
+		Session:    acctzpb.SessionInfo_SESSION_STATUS_OPERATION,
+		IPProto:    ipProto,
+		User:       user,
+		Role:       role,
+	}
+}
+
+// cliAuthn returns the authentication status dut accounts for the CLI
+// commands of a user logged in with a password.
+func cliAuthn(dut *ondatra.DUTDevice) acctzpb.AuthnDetail_AuthnStatus {
+	// Cisco populates the authentication detail only for login, once and
+	// enable records.
+	if dut.Vendor() == ondatra.CISCO {
+		return acctzpb.AuthnDetail_AUTHN_STATUS_UNSPECIFIED
+	}
+	return acctzpb.AuthnDetail_AUTHN_STATUS_SUCCESS
+}
+
+// userRole returns the role dut accounts for the test users, aristaRole on
+// Arista devices.
+func userRole(dut *ondatra.DUTDevice, aristaRole string) string {
+	switch dut.Vendor() {
+	case ondatra.CISCO:
+		return "root-lr, cisco-support"
+	case ondatra.NOKIA:
+		return "admin"
+	case ondatra.ARISTA:
+		return aristaRole
 	}
-	return status
+	return ""
 }
@@ -0,0 +1,474 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package acctz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/openconfig/featureprofiles/internal/collectors"
+	"github.com/openconfig/ondatra"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	acctzpb "github.com/openconfig/gnsi/acctz"
+)
+
+// RecordStream is a RecordSubscribe stream.
+type RecordStream interface {
+	Recv() (*acctzpb.RecordResponse, error)
+}
+
+// Subscriber opens a RecordSubscribe stream for req.  The stream ends when
+// ctx is done.
+type Subscriber func(ctx context.Context, req *acctzpb.RecordRequest) (RecordStream, error)
+
+// StreamSubscriber returns a Subscriber using a gNSI acctz client.
+func StreamSubscriber(c acctzpb.AcctzStreamClient) Subscriber {
+	return func(ctx context.Context, req *acctzpb.RecordRequest) (RecordStream, error) {
+		return c.RecordSubscribe(ctx, req)
+	}
+}
+
+// CustomSubscriber returns a Subscriber using a local acctz client, such as
+// the one returned by GetNokiaCustomAcctzClient.
+func CustomSubscriber(c AcctzStreamClient) Subscriber {
+	return func(ctx context.Context, req *acctzpb.RecordRequest) (RecordStream, error) {
+		return c.RecordSubscribe(ctx, req)
+	}
+}
+
+// DUTSubscriber returns a Subscriber for the acctz stream of dut, using the
+// custom client on Nokia devices and the gNSI client otherwise.
+func DUTSubscriber(t *testing.T, dut *ondatra.DUTDevice) Subscriber {
+	t.Helper()
+	if dut.Vendor() == ondatra.NOKIA {
+		return CustomSubscriber(GetNokiaCustomAcctzClient(t, dut))
+	}
+	return StreamSubscriber(dut.RawAPIs().GNSI(t).AcctzStream())
+}
+
+// recordKey identifies a record across streams.  The history truncation flag
+// depends on the request, not the record, so it is ignored.
+func recordKey(r *acctzpb.RecordResponse) string {
+	r = proto.Clone(r).(*acctzpb.RecordResponse)
+	r.HistoryIstruncated = false
+	b, err := proto.MarshalOptions{Deterministic: true}.Marshal(r)
+	if err != nil {
+		return r.String()
+	}
+	return string(b)
+}
+
+// Session is one RecordSubscribe stream of a Collector.
+type Session struct {
+	// Since is the timestamp of the request of the stream.
+	Since time.Time
+	// Started and Ended are when the stream was opened and when it ended.
+	// Ended is zero while the stream is open.
+	Started, Ended time.Time
+	// Received is the number of new records of the stream.
+	Received int
+	// Resent is the number of records received from an earlier stream and
+	// resent at the resume timestamp.  Resending them is permitted and they
+	// are not added to the records of the collector again.
+	Resent int
+	// Duplicates is the number of records received twice in the stream or
+	// resent from before the resume timestamp.
+	Duplicates int
+	// Stale is the number of new records timestamped before Since.
+	Stale int
+	// OutOfOrder is the number of records timestamped before the previous
+	// record of the stream.
+	OutOfOrder int
+	// Truncated is whether a record of the stream reported that the history
+	// was truncated.
+	Truncated bool
+	// Err is the error which ended the stream.
+	Err error
+}
+
+// Errors returns a line per anomaly of the stream.
+func (s *Session) Errors() []string {
+	var errs []string
+	if s.Duplicates > 0 {
+		errs = append(errs, fmt.Sprintf("%d duplicate records", s.Duplicates))
+	}
+	if s.Stale > 0 {
+		errs = append(errs, fmt.Sprintf("%d records before the requested timestamp %v", s.Stale, s.Since))
+	}
+	if s.OutOfOrder > 0 {
+		errs = append(errs, fmt.Sprintf("%d records out of timestamp order", s.OutOfOrder))
+	}
+	return errs
+}
+
+func (s *Session) String() string {
+	str := fmt.Sprintf("stream since %v: %d new records, %d resent", s.Since, s.Received, s.Resent)
+	if errs := s.Errors(); len(errs) > 0 {
+		str += ", " + strings.Join(errs, ", ")
+	}
+	if s.Truncated {
+		str += ", history truncated"
+	}
+	if s.Err != nil {
+		str += fmt.Sprintf(", ended by %v", s.Err)
+	}
+	return str
+}
+
+// Collector receives the records of a DUT in the background for the whole
+// test.  When a stream ends, it resubscribes from the timestamp of the last
+// record received, so records generated while it was disconnected are
+// collected from the history of the DUT:
+//
+//	c := acctz.StartCollector(t, acctz.DUTSubscriber(t, dut), time.Now())
+//	...
+//	acctz.VerifyRecords(t, m, c.Records.All(), want)
+//	c.VerifyStreams(t)
+//	c.VerifyReplay(t, 10*time.Second)
+type Collector struct {
+	// Records holds every record received once, in order of arrival.
+	Records *collectors.Store[*acctzpb.RecordResponse]
+	// Backoff is the delay before resubscribing after a stream ended.
+	Backoff time.Duration
+
+	subscribe Subscriber
+	since     time.Time
+
+	mu       sync.Mutex
+	seen     map[string]bool
+	last     *timestamppb.Timestamp
+	sessions []*Session
+	// closeStream ends the current stream.
+	closeStream context.CancelFunc
+	cancel      context.CancelFunc
+	done        chan struct{}
+}
+
+// NewCollector returns a collector of the records since the given time.  It
+// collects nothing until started.
+func NewCollector(subscribe Subscriber, since time.Time) *Collector {
+	return &Collector{
+		Records:   collectors.NewStore[*acctzpb.RecordResponse](),
+		Backoff:   time.Second,
+		subscribe: subscribe,
+		since:     since,
+		seen:      map[string]bool{},
+	}
+}
+
+// StartCollector starts a collector of the records since the given time and
+// stops it when the test ends.
+func StartCollector(t testing.TB, subscribe Subscriber, since time.Time) *Collector {
+	t.Helper()
+	c := NewCollector(subscribe, since)
+	c.Start(context.Background())
+	t.Cleanup(func() {
+		c.Stop()
+		for _, s := range c.Sessions() {
+			t.Logf("Acctz collector %v", &s)
+		}
+	})
+	return c
+}
+
+// Start starts collecting in the background until ctx is done or Stop is
+// called.
+func (c *Collector) Start(ctx context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	c.mu.Lock()
+	c.cancel, c.done = cancel, make(chan struct{})
+	c.mu.Unlock()
+	go c.run(ctx)
+}
+
+// Stop stops collecting and waits for the current stream to end.
+func (c *Collector) Stop() {
+	c.mu.Lock()
+	cancel, done := c.cancel, c.done
+	c.mu.Unlock()
+	if cancel == nil {
+		return
+	}
+	cancel()
+	<-done
+}
+
+// Disconnect ends the current stream, as a collector restart would.  The
+// collector resubscribes after Backoff.
+func (c *Collector) Disconnect() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closeStream != nil {
+		c.closeStream()
+	}
+}
+
+// Sessions returns the streams of the collector so far.
+func (c *Collector) Sessions() []Session {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var sessions []Session
+	for _, s := range c.sessions {
+		sessions = append(sessions, *s)
+	}
+	return sessions
+}
+
+// Last returns the timestamp of the latest record received, or the start of
+// the collector if none was.
+func (c *Collector) Last() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.resumeFrom().AsTime()
+}
+
+func (c *Collector) resumeFrom() *timestamppb.Timestamp {
+	if c.last != nil {
+		return c.last
+	}
+	return timestamppb.New(c.since)
+}
+
+func (c *Collector) run(ctx context.Context) {
+	defer close(c.done)
+	for ctx.Err() == nil {
+		streamCtx, closeStream := context.WithCancel(ctx)
+		c.mu.Lock()
+		from := c.resumeFrom()
+		s := &Session{Since: from.AsTime(), Started: time.Now()}
+		c.sessions = append(c.sessions, s)
+		c.closeStream = closeStream
+		c.mu.Unlock()
+
+		err := c.receive(streamCtx, &acctzpb.RecordRequest{Timestamp: from}, s)
+		closeStream()
+		c.mu.Lock()
+		s.Ended, s.Err = time.Now(), err
+		c.closeStream = nil
+		c.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+		case <-time.After(c.Backoff):
+		}
+	}
+}
+
+func (c *Collector) receive(ctx context.Context, req *acctzpb.RecordRequest, s *Session) error {
+	stream, err := c.subscribe(ctx, req)
+	if err != nil {
+		return fmt.Errorf("subscribe: %w", err)
+	}
+	streamSeen := map[string]bool{}
+	var prev time.Time
+	for {
+		r, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		ts := r.GetTimestamp().AsTime()
+		key := recordKey(r)
+		c.mu.Lock()
+		if r.GetHistoryIstruncated() {
+			s.Truncated = true
+		}
+		if ts.Before(prev) {
+			s.OutOfOrder++
+		}
+		prev = ts
+		switch {
+		case streamSeen[key]:
+			s.Duplicates++
+		case c.seen[key] && ts.Equal(s.Since):
+			s.Resent++
+		case c.seen[key]:
+			s.Duplicates++
+		default:
+			if ts.Before(s.Since) {
+				s.Stale++
+			}
+			s.Received++
+			c.seen[key] = true
+			if c.last == nil || ts.After(c.last.AsTime()) {
+				c.last = r.GetTimestamp()
+			}
+			c.Records.Add(r)
+		}
+		streamSeen[key] = true
+		c.mu.Unlock()
+	}
+}
+
+// VerifyStreams reports the anomalies of the streams of c as test errors.
+func (c *Collector) VerifyStreams(t testing.TB) {
+	t.Helper()
+	for i, s := range c.Sessions() {
+		for _, err := range s.Errors() {
+			t.Errorf("Acctz stream %d since %v: %s", i, s.Since, err)
+		}
+	}
+}
+
+// Replay subscribes to the history since the given time and returns the
+// records received until none arrives for idle.
+func Replay(ctx context.Context, subscribe Subscriber, since time.Time, idle time.Duration) ([]*acctzpb.RecordResponse, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := subscribe(ctx, &acctzpb.RecordRequest{Timestamp: timestamppb.New(since)})
+	if err != nil {
+		return nil, fmt.Errorf("subscribe: %w", err)
+	}
+	type result struct {
+		record *acctzpb.RecordResponse
+		err    error
+	}
+	results := make(chan result)
+	go func() {
+		for {
+			r, err := stream.Recv()
+			select {
+			case results <- result{r, err}:
+			case <-ctx.Done():
+				return
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+	var records []*acctzpb.RecordResponse
+	timer := time.NewTimer(idle)
+	defer timer.Stop()
+	for {
+		select {
+		case res := <-results:
+			if res.err != nil {
+				return records, res.err
+			}
+			records = append(records, res.record)
+			timer.Reset(idle)
+		case <-timer.C:
+			return records, nil
+		case <-ctx.Done():
+			return records, ctx.Err()
+		}
+	}
+}
+
+// ReplayReport is the result of comparing a history replay with the records
+// received before.
+type ReplayReport struct {
+	// Replayed is the number of records of the replay.
+	Replayed int
+	// Missing are the records received before and absent from the replay.
+	Missing []*acctzpb.RecordResponse
+	// Duplicates are the records the replay contains more than once.
+	Duplicates []*acctzpb.RecordResponse
+	// OutOfOrder is the number of replayed records timestamped before the
+	// previous one.
+	OutOfOrder int
+	// Truncated is whether the replay reported that the history was
+	// truncated.  Records older than the first replayed record are then not
+	// missing.
+	Truncated bool
+}
+
+// OK returns whether the replay has no gaps, duplicates or reordering.
+func (r *ReplayReport) OK() bool {
+	return len(r.Missing) == 0 && len(r.Duplicates) == 0 && r.OutOfOrder == 0
+}
+
+// Errors returns a line per missing and duplicate record.
+func (r *ReplayReport) Errors() []string {
+	var errs []string
+	for _, m := range r.Missing {
+		errs = append(errs, fmt.Sprintf("record missing from replay: %s", PrettyPrint(m)))
+	}
+	for _, d := range r.Duplicates {
+		errs = append(errs, fmt.Sprintf("record replayed more than once: %s", PrettyPrint(d)))
+	}
+	if r.OutOfOrder > 0 {
+		errs = append(errs, fmt.Sprintf("%d replayed records out of timestamp order", r.OutOfOrder))
+	}
+	return errs
+}
+
+func (r *ReplayReport) String() string {
+	str := fmt.Sprintf("%d records replayed, %d missing, %d duplicate, %d out of order", r.Replayed, len(r.Missing), len(r.Duplicates), r.OutOfOrder)
+	if r.Truncated {
+		str += ", history truncated"
+	}
+	return str
+}
+
+// CompareReplay compares a replay of the history with the records received
+// before.  Replayed records which were not received before are newer and are
+// ignored.
+func CompareReplay(received, replay []*acctzpb.RecordResponse) *ReplayReport {
+	report := &ReplayReport{Replayed: len(replay)}
+	count := map[string]int{}
+	var prev, first time.Time
+	for i, r := range replay {
+		ts := r.GetTimestamp().AsTime()
+		if i == 0 {
+			first = ts
+		}
+		if ts.Before(prev) {
+			report.OutOfOrder++
+		}
+		prev = ts
+		if r.GetHistoryIstruncated() {
+			report.Truncated = true
+		}
+		key := recordKey(r)
+		if count[key]++; count[key] == 2 {
+			report.Duplicates = append(report.Duplicates, r)
+		}
+	}
+	for _, r := range received {
+		if count[recordKey(r)] > 0 {
+			continue
+		}
+		if report.Truncated && r.GetTimestamp().AsTime().Before(first) {
+			continue
+		}
+		report.Missing = append(report.Missing, r)
+	}
+	return report
+}
+
+// VerifyReplay replays the history since the start of c, reads it until no
+// record arrives for idle and reports gaps and duplicates compared to the
+// records c received as test errors.
+func (c *Collector) VerifyReplay(t testing.TB, idle time.Duration) *ReplayReport {
+	t.Helper()
+	replay, err := Replay(context.Background(), c.subscribe, c.since, idle)
+	if err != nil && !errors.Is(err, context.Canceled) {
+		t.Errorf("Acctz history replay since %v: %v", c.since, err)
+	}
+	report := CompareReplay(c.Records.All(), replay)
+	for _, err := range report.Errors() {
+		t.Error(err)
+	}
+	t.Logf("Acctz history replay since %v: %v", c.since, report)
+	return report
+}
@@ -0,0 +1,243 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package acctz
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	acctzpb "github.com/openconfig/gnsi/acctz"
+)
+
+// fakeDevice serves RecordSubscribe streams from its history, inclusive of
+// the requested timestamp.
+type fakeDevice struct {
+	mu      sync.Mutex
+	history []*acctzpb.RecordResponse
+	added   chan struct{}
+	// failAfter ends every stream with an error after that many records, if
+	// positive.
+	failAfter int
+}
+
+func newFakeDevice(records ...*acctzpb.RecordResponse) *fakeDevice {
+	return &fakeDevice{history: records, added: make(chan struct{})}
+}
+
+func (d *fakeDevice) add(records ...*acctzpb.RecordResponse) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.history = append(d.history, records...)
+	close(d.added)
+	d.added = make(chan struct{})
+}
+
+func (d *fakeDevice) subscribe(ctx context.Context, req *acctzpb.RecordRequest) (RecordStream, error) {
+	return &fakeStream{d: d, ctx: ctx, since: req.GetTimestamp().AsTime()}, nil
+}
+
+type fakeStream struct {
+	d     *fakeDevice
+	ctx   context.Context
+	since time.Time
+	next  int
+	sent  int
+}
+
+func (s *fakeStream) Recv() (*acctzpb.RecordResponse, error) {
+	for {
+		s.d.mu.Lock()
+		if s.d.failAfter > 0 && s.sent == s.d.failAfter {
+			s.d.mu.Unlock()
+			return nil, errors.New("stream reset")
+		}
+		for s.next < len(s.d.history) {
+			r := s.d.history[s.next]
+			s.next++
+			if !r.GetTimestamp().AsTime().Before(s.since) {
+				s.sent++
+				s.d.mu.Unlock()
+				return r, nil
+			}
+		}
+		added := s.d.added
+		s.d.mu.Unlock()
+		select {
+		case <-added:
+		case <-s.ctx.Done():
+			return nil, s.ctx.Err()
+		}
+	}
+}
+
+func records(n int) []*acctzpb.RecordResponse {
+	var rs []*acctzpb.RecordResponse
+	for i := 0; i < n; i++ {
+		rs = append(rs, grpcRecord(gnmiCapabilitiesPath, SuccessUsername, acctzpb.AuthzDetail_AUTHZ_STATUS_PERMIT, time.Duration(i)*time.Second))
+	}
+	return rs
+}
+
+func awaitRecords(t *testing.T, c *Collector, n int) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if _, err := c.Records.AwaitN(ctx, n, func(*acctzpb.RecordResponse) bool { return true }); err != nil {
+		t.Fatalf("Records.AwaitN(%d) failed: %v", n, err)
+	}
+}
+
+func checkRecords(t *testing.T, c *Collector, want []*acctzpb.RecordResponse) {
+	t.Helper()
+	got := c.Records.All()
+	if len(got) != len(want) {
+		t.Fatalf("Records.All() got %d records, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if !proto.Equal(got[i], want[i]) {
+			t.Errorf("Records.All()[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestCollectorReconnect(t *testing.T) {
+	rs := records(4)
+	d := newFakeDevice(rs[:3]...)
+	d.failAfter = 2
+	c := NewCollector(d.subscribe, base)
+	c.Backoff = time.Millisecond
+	c.Start(context.Background())
+	awaitRecords(t, c, 3)
+	d.add(rs[3])
+	awaitRecords(t, c, 4)
+	c.Stop()
+
+	checkRecords(t, c, rs)
+	sessions := c.Sessions()
+	if len(sessions) < 3 {
+		t.Fatalf("Sessions() = %v, want at least 3 streams", sessions)
+	}
+	if got, want := sessions[1].Since, rs[1].GetTimestamp().AsTime(); !got.Equal(want) {
+		t.Errorf("Sessions()[1].Since = %v, want the timestamp %v of the last record received", got, want)
+	}
+	for i, s := range sessions {
+		if errs := s.Errors(); len(errs) != 0 {
+			t.Errorf("Sessions()[%d].Errors() = %v, want none", i, errs)
+		}
+	}
+	if sessions[1].Resent != 1 {
+		t.Errorf("Sessions()[1].Resent = %d, want 1", sessions[1].Resent)
+	}
+}
+
+func TestCollectorDisconnect(t *testing.T) {
+	rs := records(2)
+	d := newFakeDevice(rs[0])
+	c := NewCollector(d.subscribe, base)
+	c.Backoff = time.Millisecond
+	c.Start(context.Background())
+	awaitRecords(t, c, 1)
+	c.Disconnect()
+	d.add(rs[1])
+	awaitRecords(t, c, 2)
+	c.Stop()
+
+	checkRecords(t, c, rs)
+	if sessions := c.Sessions(); len(sessions) != 2 {
+		t.Errorf("Sessions() = %v, want 2 streams", sessions)
+	}
+	if got, want := c.Last(), rs[1].GetTimestamp().AsTime(); !got.Equal(want) {
+		t.Errorf("Last() = %v, want %v", got, want)
+	}
+}
+
+func TestCollectorAnomalies(t *testing.T) {
+	rs := records(3)
+	d := newFakeDevice(rs[0], rs[2], rs[2], rs[1])
+	c := NewCollector(d.subscribe, base.Add(time.Second))
+	c.Start(context.Background())
+	awaitRecords(t, c, 2)
+	c.Stop()
+
+	s := c.Sessions()[0]
+	if s.Duplicates != 1 || s.OutOfOrder != 1 || s.Stale != 0 {
+		t.Errorf("Sessions()[0] = %v, want 1 duplicate and 1 out of order record", &s)
+	}
+	if errs := s.Errors(); len(errs) != 2 {
+		t.Errorf("Errors() = %q, want 2 errors", errs)
+	}
+}
+
+func TestReplay(t *testing.T) {
+	rs := records(3)
+	d := newFakeDevice(rs...)
+	got, err := Replay(context.Background(), d.subscribe, base.Add(time.Second), 50*time.Millisecond)
+	if err != nil {
+		t.Fatalf("Replay() failed: %v", err)
+	}
+	if len(got) != 2 {
+		t.Errorf("Replay() got %d records, want 2", len(got))
+	}
+}
+
+func TestCompareReplay(t *testing.T) {
+	rs := records(3)
+	truncated := proto.Clone(rs[1]).(*acctzpb.RecordResponse)
+	truncated.HistoryIstruncated = true
+	tests := []struct {
+		desc           string
+		replay         []*acctzpb.RecordResponse
+		wantMissing    int
+		wantDuplicates int
+		wantOutOfOrder int
+	}{{
+		desc:   "complete",
+		replay: rs,
+	}, {
+		desc:   "newer records",
+		replay: append(records(4), grpcRecord(gnoiPingPath, SuccessUsername, 0, time.Minute)),
+	}, {
+		desc:        "gap",
+		replay:      []*acctzpb.RecordResponse{rs[0], rs[2]},
+		wantMissing: 1,
+	}, {
+		desc:           "duplicate",
+		replay:         []*acctzpb.RecordResponse{rs[0], rs[1], rs[1], rs[2]},
+		wantDuplicates: 1,
+	}, {
+		desc:           "out of order",
+		replay:         []*acctzpb.RecordResponse{rs[0], rs[2], rs[1]},
+		wantOutOfOrder: 1,
+	}, {
+		desc:   "truncated",
+		replay: []*acctzpb.RecordResponse{truncated, rs[2]},
+	}}
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			r := CompareReplay(rs, tc.replay)
+			if len(r.Missing) != tc.wantMissing || len(r.Duplicates) != tc.wantDuplicates || r.OutOfOrder != tc.wantOutOfOrder {
+				t.Errorf("CompareReplay() = %v, want %d missing, %d duplicate and %d out of order", r, tc.wantMissing, tc.wantDuplicates, tc.wantOutOfOrder)
+			}
+			if got, want := r.OK(), tc.wantMissing+tc.wantDuplicates+tc.wantOutOfOrder == 0; got != want {
+				t.Errorf("OK() = %v, want %v", got, want)
+			}
+		})
+	}
+}
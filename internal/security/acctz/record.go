@@ -0,0 +1,376 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package acctz
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+
+	acctzpb "github.com/openconfig/gnsi/acctz"
+)
+
+// Expectation is a record the DUT is expected to account.  Zero fields match
+// any value, so an expectation only states what a test knows about a record.
+type Expectation struct {
+	// Desc describes the expectation in reports.
+	Desc string
+
+	// GrpcService and RPC are the service type and RPC name of a gRPC
+	// record.
+	GrpcService acctzpb.GrpcService_GrpcServiceType
+	RPC         string
+	// Payload is the request of a gRPC record, matched against the payload
+	// recorded as a proto or, as its prototext, as a string.  A truncated
+	// recorded payload matches if it is a prefix of the string form.
+	Payload proto.Message
+
+	// CmdService and Cmd are the service type and command of a command
+	// record.  If Cmd is set, CmdArgs must be the recorded arguments and
+	// CmdTruncated whether the recorded command is truncated, to a prefix
+	// of Cmd.
+	CmdService   acctzpb.CommandService_CmdServiceType
+	Cmd          string
+	CmdArgs      []string
+	CmdTruncated bool
+
+	Authz acctzpb.AuthzDetail_AuthzStatus
+	// AuthzDetail requires the authorization detail to be populated.
+	AuthzDetail bool
+
+	Session acctzpb.SessionInfo_SessionStatus
+	Authn   acctzpb.AuthnDetail_AuthnStatus
+	IPProto uint32
+	// User and Role identify the session of the record.
+	User string
+	Role string
+}
+
+func (e *Expectation) String() string {
+	if e.Desc != "" {
+		return e.Desc
+	}
+	var parts []string
+	if e.RPC != "" {
+		parts = append(parts, e.RPC)
+	}
+	if e.Cmd != "" {
+		parts = append(parts, fmt.Sprintf("%q", e.Cmd))
+	}
+	if e.User != "" {
+		parts = append(parts, "by "+e.User)
+	}
+	if e.Authz != acctzpb.AuthzDetail_AUTHZ_STATUS_UNSPECIFIED {
+		parts = append(parts, e.Authz.String())
+	}
+	if len(parts) == 0 {
+		return "any record"
+	}
+	return strings.Join(parts, " ")
+}
+
+// FromRecord returns the expectation of the fields of r a test can know:
+// service, command or RPC and payload, authz, authn and session status, IP
+// protocol, user identity and role.
+func FromRecord(r *acctzpb.RecordResponse) *Expectation {
+	s := r.GetSessionInfo()
+	e := &Expectation{
+		Session: s.GetStatus(),
+		Authn:   s.GetAuthn().GetStatus(),
+		IPProto: s.GetIpProto(),
+		User:    s.GetUser().GetIdentity(),
+		Role:    s.GetUser().GetRole(),
+	}
+	if g := r.GetGrpcService(); g != nil {
+		e.GrpcService = g.GetServiceType()
+		e.RPC = g.GetRpcName()
+		e.Authz = g.GetAuthz().GetStatus()
+		if p := g.GetProtoVal(); p != nil {
+			if m, err := p.UnmarshalNew(); err == nil {
+				e.Payload = m
+			}
+		}
+	}
+	if c := r.GetCmdService(); c != nil {
+		e.CmdService = c.GetServiceType()
+		e.Cmd = c.GetCmd()
+		e.CmdArgs = c.GetCmdArgs()
+		e.CmdTruncated = c.GetCmdIstruncated()
+		e.Authz = c.GetAuthz().GetStatus()
+	}
+	return e
+}
+
+// FromRecords returns the expectations of records.
+func FromRecords(records []*acctzpb.RecordResponse) []*Expectation {
+	var es []*Expectation
+	for _, r := range records {
+		es = append(es, FromRecord(r))
+	}
+	return es
+}
+
+func truncatedMatch(want, got string, truncated bool) bool {
+	if truncated {
+		return strings.HasPrefix(want, got)
+	}
+	return want == got
+}
+
+func checkPayload(want proto.Message, g *acctzpb.GrpcService) string {
+	if want == nil {
+		return ""
+	}
+	truncated := g.GetPayloadIstruncated()
+	switch p := g.GetPayload().(type) {
+	case *acctzpb.GrpcService_ProtoVal:
+		if truncated {
+			// A truncated proto cannot be compared.
+			return ""
+		}
+		got, err := p.ProtoVal.UnmarshalNew()
+		if err != nil {
+			return fmt.Sprintf("payload: cannot unmarshal %v: %v", p.ProtoVal.GetTypeUrl(), err)
+		}
+		if !proto.Equal(got, want) {
+			return fmt.Sprintf("payload: got %v, want %v", got, want)
+		}
+	case *acctzpb.GrpcService_StringVal:
+		if w := prototext.Format(want); !truncatedMatch(w, p.StringVal, truncated) {
+			return fmt.Sprintf("payload: got %q, want %q", p.StringVal, w)
+		}
+	default:
+		if !truncated {
+			return "payload: got none"
+		}
+	}
+	return ""
+}
+
+// mismatches returns the fields of r which do not match e, and nil if r
+// matches e.
+func (e *Expectation) mismatches(r *acctzpb.RecordResponse) []string {
+	var diffs []string
+	check := func(field string, ok bool, got, want any) {
+		if !ok {
+			diffs = append(diffs, fmt.Sprintf("%s: got %v, want %v", field, got, want))
+		}
+	}
+	var authz *acctzpb.AuthzDetail
+	if e.GrpcService != acctzpb.GrpcService_GRPC_SERVICE_TYPE_UNSPECIFIED || e.RPC != "" || e.Payload != nil {
+		g := r.GetGrpcService()
+		if g == nil {
+			return []string{"service: got a command record, want a gRPC record"}
+		}
+		check("grpc service", e.GrpcService == acctzpb.GrpcService_GRPC_SERVICE_TYPE_UNSPECIFIED || e.GrpcService == g.GetServiceType(), g.GetServiceType(), e.GrpcService)
+		check("rpc", e.RPC == "" || e.RPC == g.GetRpcName(), g.GetRpcName(), e.RPC)
+		if d := checkPayload(e.Payload, g); d != "" {
+			diffs = append(diffs, d)
+		}
+		authz = g.GetAuthz()
+	}
+	if e.CmdService != acctzpb.CommandService_CMD_SERVICE_TYPE_UNSPECIFIED || e.Cmd != "" {
+		c := r.GetCmdService()
+		if c == nil {
+			return []string{"service: got a gRPC record, want a command record"}
+		}
+		check("cmd service", e.CmdService == acctzpb.CommandService_CMD_SERVICE_TYPE_UNSPECIFIED || e.CmdService == c.GetServiceType(), c.GetServiceType(), e.CmdService)
+		if e.Cmd != "" {
+			check("cmd", truncatedMatch(e.Cmd, c.GetCmd(), c.GetCmdIstruncated()), fmt.Sprintf("%q", c.GetCmd()), fmt.Sprintf("%q", e.Cmd))
+			check("cmd args", slices.Equal(e.CmdArgs, c.GetCmdArgs()), fmt.Sprintf("%q", c.GetCmdArgs()), fmt.Sprintf("%q", e.CmdArgs))
+			check("cmd truncated", e.CmdTruncated == c.GetCmdIstruncated(), c.GetCmdIstruncated(), e.CmdTruncated)
+		}
+		authz = c.GetAuthz()
+	}
+	if authz == nil {
+		authz = r.GetGrpcService().GetAuthz()
+		if authz == nil {
+			authz = r.GetCmdService().GetAuthz()
+		}
+	}
+	check("authz status", e.Authz == acctzpb.AuthzDetail_AUTHZ_STATUS_UNSPECIFIED || e.Authz == authz.GetStatus(), authz.GetStatus(), e.Authz)
+	check("authz detail", !e.AuthzDetail || authz.GetDetail() != "", `""`, "a detail")
+	s := r.GetSessionInfo()
+	check("session status", e.Session == acctzpb.SessionInfo_SESSION_STATUS_UNSPECIFIED || e.Session == s.GetStatus(), s.GetStatus(), e.Session)
+	check("authn status", e.Authn == acctzpb.AuthnDetail_AUTHN_STATUS_UNSPECIFIED || e.Authn == s.GetAuthn().GetStatus(), s.GetAuthn().GetStatus(), e.Authn)
+	check("ip proto", e.IPProto == 0 || e.IPProto == s.GetIpProto(), s.GetIpProto(), e.IPProto)
+	check("user", e.User == "" || e.User == s.GetUser().GetIdentity(), s.GetUser().GetIdentity(), e.User)
+	check("role", e.Role == "" || e.Role == s.GetUser().GetRole(), s.GetUser().GetRole(), e.Role)
+	return diffs
+}
+
+// Matcher matches the records a DUT returned against expectations.
+type Matcher struct {
+	// Ordered requires the records matching the expectations to be in the
+	// order of the expectations.
+	Ordered bool
+	// Start and End bound the timestamps of matching records, which must be
+	// strictly after Start and not after End, widened by Skew for the clock
+	// difference of the DUT and the test.  Zero values do not bound.
+	Start, End time.Time
+	Skew       time.Duration
+	// Relevant selects the records reported as unexpected if no expectation
+	// matches them.  If nil, all records are relevant.
+	Relevant func(*acctzpb.RecordResponse) bool
+}
+
+// Match is a record matching an expectation.
+type Match struct {
+	Want   *Expectation
+	Record *acctzpb.RecordResponse
+}
+
+// Miss is an expectation no record matches.
+type Miss struct {
+	Want *Expectation
+	// Closest is the relevant record with the fewest mismatches, if any,
+	// and Diffs its mismatches.
+	Closest *acctzpb.RecordResponse
+	Diffs   []string
+}
+
+// Report is the result of matching records against expectations.
+type Report struct {
+	Matched    []Match
+	Missing    []Miss
+	Unexpected []*acctzpb.RecordResponse
+	// Got and Want are the numbers of matched and relevant records and of
+	// expectations.
+	Got, Want int
+}
+
+// OK returns whether every expectation matched and no relevant record was
+// unexpected.
+func (r *Report) OK() bool {
+	return len(r.Missing) == 0 && len(r.Unexpected) == 0
+}
+
+// Errors returns a line for a count of relevant records other than the
+// count of expectations, and a line per missing expectation and unexpected
+// record.
+func (r *Report) Errors() []string {
+	var errs []string
+	if r.Got != r.Want {
+		errs = append(errs, fmt.Sprintf("got %d records, want %d", r.Got, r.Want))
+	}
+	for _, m := range r.Missing {
+		s := fmt.Sprintf("missing record: %v", m.Want)
+		if m.Closest != nil {
+			s += fmt.Sprintf("; closest record differs in %s", strings.Join(m.Diffs, ", "))
+		}
+		errs = append(errs, s)
+	}
+	for _, u := range r.Unexpected {
+		errs = append(errs, fmt.Sprintf("unexpected record: %s", PrettyPrint(u)))
+	}
+	return errs
+}
+
+func (r *Report) String() string {
+	if r.OK() {
+		return fmt.Sprintf("%d records matched", len(r.Matched))
+	}
+	return fmt.Sprintf("%d records matched, %d missing, %d unexpected:\n%s", len(r.Matched), len(r.Missing), len(r.Unexpected), strings.Join(r.Errors(), "\n"))
+}
+
+func (m *Matcher) inWindow(r *acctzpb.RecordResponse) error {
+	if m.Start.IsZero() && m.End.IsZero() {
+		return nil
+	}
+	if r.GetTimestamp() == nil {
+		return fmt.Errorf("timestamp: got none")
+	}
+	ts := r.GetTimestamp().AsTime()
+	if !m.Start.IsZero() && !ts.After(m.Start.Add(-m.Skew)) {
+		return fmt.Errorf("timestamp: got %v, want after %v", ts, m.Start)
+	}
+	if !m.End.IsZero() && ts.After(m.End.Add(m.Skew)) {
+		return fmt.Errorf("timestamp: got %v, want before %v", ts, m.End)
+	}
+	return nil
+}
+
+func (m *Matcher) mismatches(e *Expectation, r *acctzpb.RecordResponse) []string {
+	diffs := e.mismatches(r)
+	if err := m.inWindow(r); err != nil {
+		diffs = append(diffs, err.Error())
+	}
+	return diffs
+}
+
+func (m *Matcher) relevant(r *acctzpb.RecordResponse) bool {
+	return m.Relevant == nil || m.Relevant(r)
+}
+
+// Match matches got against want.  Each record matches at most one
+// expectation, the first one in want it matches.
+func (m *Matcher) Match(got []*acctzpb.RecordResponse, want []*Expectation) *Report {
+	report := &Report{Want: len(want)}
+	used := make([]bool, len(got))
+	// next is the index of the first record an ordered expectation may match.
+	next := 0
+	for _, e := range want {
+		found := -1
+		for i := next; i < len(got); i++ {
+			if !used[i] && len(m.mismatches(e, got[i])) == 0 {
+				found = i
+				break
+			}
+		}
+		if found < 0 {
+			report.Missing = append(report.Missing, Miss{Want: e})
+			continue
+		}
+		used[found] = true
+		report.Matched = append(report.Matched, Match{Want: e, Record: got[found]})
+		if m.Ordered {
+			next = found + 1
+		}
+	}
+	var unused []*acctzpb.RecordResponse
+	for i, r := range got {
+		if !used[i] && m.relevant(r) {
+			unused = append(unused, r)
+		}
+	}
+	report.Got = len(report.Matched) + len(unused)
+	report.Unexpected = unused
+	for i := range report.Missing {
+		miss := &report.Missing[i]
+		for _, r := range unused {
+			if diffs := m.mismatches(miss.Want, r); miss.Closest == nil || len(diffs) < len(miss.Diffs) {
+				miss.Closest, miss.Diffs = r, diffs
+			}
+		}
+	}
+	return report
+}
+
+// VerifyRecords matches got against want and reports missing and unexpected
+// records as test errors.
+func VerifyRecords(t testing.TB, m *Matcher, got []*acctzpb.RecordResponse, want []*Expectation) *Report {
+	t.Helper()
+	report := m.Match(got, want)
+	for _, err := range report.Errors() {
+		t.Error(err)
+	}
+	t.Logf("Acctz records: %v", report)
+	return report
+}
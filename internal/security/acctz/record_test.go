@@ -0,0 +1,281 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package acctz
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+	acctzpb "github.com/openconfig/gnsi/acctz"
+)
+
+var base = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func grpcRecord(rpc, user string, status acctzpb.AuthzDetail_AuthzStatus, at time.Duration) *acctzpb.RecordResponse {
+	return &acctzpb.RecordResponse{
+		Timestamp: timestamppb.New(base.Add(at)),
+		ServiceRequest: &acctzpb.RecordResponse_GrpcService{GrpcService: &acctzpb.GrpcService{
+			ServiceType: acctzpb.GrpcService_GRPC_SERVICE_TYPE_GNMI,
+			RpcName:     rpc,
+			Authz:       &acctzpb.AuthzDetail{Status: status},
+		}},
+		SessionInfo: &acctzpb.SessionInfo{
+			Status: acctzpb.SessionInfo_SESSION_STATUS_ONCE,
+			User:   &acctzpb.UserDetail{Identity: user},
+		},
+	}
+}
+
+func cmdRecord(cmd string, truncated bool, user string) *acctzpb.RecordResponse {
+	return &acctzpb.RecordResponse{
+		Timestamp: timestamppb.New(base),
+		ServiceRequest: &acctzpb.RecordResponse_CmdService{CmdService: &acctzpb.CommandService{
+			ServiceType:    acctzpb.CommandService_CMD_SERVICE_TYPE_CLI,
+			Cmd:            cmd,
+			CmdIstruncated: truncated,
+		}},
+		SessionInfo: &acctzpb.SessionInfo{User: &acctzpb.UserDetail{Identity: user}},
+	}
+}
+
+func TestMatch(t *testing.T) {
+	permit, deny := acctzpb.AuthzDetail_AUTHZ_STATUS_PERMIT, acctzpb.AuthzDetail_AUTHZ_STATUS_DENY
+	capPermit := &Expectation{RPC: gnmiCapabilitiesPath, User: SuccessUsername, Authz: permit}
+	capDeny := &Expectation{RPC: gnmiCapabilitiesPath, User: FailUsername, Authz: deny}
+	tests := []struct {
+		desc           string
+		m              Matcher
+		got            []*acctzpb.RecordResponse
+		want           []*Expectation
+		wantMissing    int
+		wantUnexpected int
+	}{{
+		desc: "unordered",
+		got: []*acctzpb.RecordResponse{
+			grpcRecord(gnmiCapabilitiesPath, SuccessUsername, permit, time.Second),
+			grpcRecord(gnmiCapabilitiesPath, FailUsername, deny, 0),
+		},
+		want: []*Expectation{capDeny, capPermit},
+	}, {
+		desc: "ordered out of order",
+		m:    Matcher{Ordered: true},
+		got: []*acctzpb.RecordResponse{
+			grpcRecord(gnmiCapabilitiesPath, SuccessUsername, permit, time.Second),
+			grpcRecord(gnmiCapabilitiesPath, FailUsername, deny, 0),
+		},
+		want:           []*Expectation{capDeny, capPermit},
+		wantMissing:    1,
+		wantUnexpected: 1,
+	}, {
+		desc: "other users are unexpected",
+		m:    Matcher{Ordered: true},
+		got: []*acctzpb.RecordResponse{
+			grpcRecord(gnmiCapabilitiesPath, "admin", permit, 0),
+			grpcRecord(gnmiCapabilitiesPath, SuccessUsername, permit, time.Second),
+		},
+		want:           []*Expectation{capPermit},
+		wantUnexpected: 1,
+	}, {
+		desc: "duplicate record",
+		got: []*acctzpb.RecordResponse{
+			grpcRecord(gnmiCapabilitiesPath, SuccessUsername, permit, 0),
+			grpcRecord(gnmiCapabilitiesPath, SuccessUsername, permit, time.Second),
+		},
+		want:           []*Expectation{capPermit},
+		wantUnexpected: 1,
+	}, {
+		desc: "outside window",
+		m:    Matcher{Start: base.Add(time.Minute)},
+		got: []*acctzpb.RecordResponse{
+			grpcRecord(gnmiCapabilitiesPath, SuccessUsername, permit, 0),
+		},
+		want:           []*Expectation{capPermit},
+		wantMissing:    1,
+		wantUnexpected: 1,
+	}, {
+		desc: "at window start",
+		m:    Matcher{Start: base},
+		got: []*acctzpb.RecordResponse{
+			grpcRecord(gnmiCapabilitiesPath, SuccessUsername, permit, 0),
+		},
+		want:           []*Expectation{capPermit},
+		wantMissing:    1,
+		wantUnexpected: 1,
+	}, {
+		desc: "window skew",
+		m:    Matcher{Start: base.Add(time.Second), Skew: 2 * time.Second},
+		got: []*acctzpb.RecordResponse{
+			grpcRecord(gnmiCapabilitiesPath, SuccessUsername, permit, 0),
+		},
+		want: []*Expectation{capPermit},
+	}, {
+		desc: "truncated command",
+		got:  []*acctzpb.RecordResponse{cmdRecord("show ver", true, SuccessUsername)},
+		want: []*Expectation{{Cmd: successCliCommand, CmdTruncated: true, User: SuccessUsername}},
+	}, {
+		desc:           "unexpectedly truncated command",
+		got:            []*acctzpb.RecordResponse{cmdRecord("show ver", true, SuccessUsername)},
+		want:           []*Expectation{{Cmd: successCliCommand, User: SuccessUsername}},
+		wantMissing:    1,
+		wantUnexpected: 1,
+	}, {
+		desc:           "missing command arguments",
+		got:            []*acctzpb.RecordResponse{cmdRecord("show", false, SuccessUsername)},
+		want:           []*Expectation{{Cmd: "show", CmdArgs: []string{"version"}, User: SuccessUsername}},
+		wantMissing:    1,
+		wantUnexpected: 1,
+	}, {
+		desc:           "different command",
+		got:            []*acctzpb.RecordResponse{cmdRecord("show ver", false, SuccessUsername)},
+		want:           []*Expectation{{Cmd: successCliCommand, User: SuccessUsername}},
+		wantMissing:    1,
+		wantUnexpected: 1,
+	}, {
+		desc:           "gRPC record for a command",
+		got:            []*acctzpb.RecordResponse{grpcRecord(gnmiCapabilitiesPath, SuccessUsername, permit, 0)},
+		want:           []*Expectation{{Cmd: successCliCommand, User: SuccessUsername}},
+		wantMissing:    1,
+		wantUnexpected: 1,
+	}, {
+		desc: "custom relevance",
+		m: Matcher{Relevant: func(r *acctzpb.RecordResponse) bool {
+			return r.GetGrpcService().GetRpcName() == gnmiCapabilitiesPath
+		}},
+		got: []*acctzpb.RecordResponse{
+			grpcRecord(gnmiCapabilitiesPath, "admin", permit, 0),
+			grpcRecord(gribiGetPath, SuccessUsername, permit, 0),
+		},
+		want:           []*Expectation{capPermit},
+		wantMissing:    1,
+		wantUnexpected: 1,
+	}}
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			r := tc.m.Match(tc.got, tc.want)
+			if len(r.Missing) != tc.wantMissing || len(r.Unexpected) != tc.wantUnexpected {
+				t.Errorf("Match() = %v, want %d missing and %d unexpected", r, tc.wantMissing, tc.wantUnexpected)
+			}
+			if got, want := r.OK(), tc.wantMissing+tc.wantUnexpected == 0; got != want {
+				t.Errorf("OK() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestMissReport(t *testing.T) {
+	m := &Matcher{}
+	r := m.Match([]*acctzpb.RecordResponse{
+		grpcRecord(gnmiCapabilitiesPath, SuccessUsername, acctzpb.AuthzDetail_AUTHZ_STATUS_DENY, 0),
+		grpcRecord(gnoiPingPath, SuccessUsername, acctzpb.AuthzDetail_AUTHZ_STATUS_DENY, 0),
+	}, []*Expectation{{
+		RPC:   gnmiCapabilitiesPath,
+		User:  SuccessUsername,
+		Authz: acctzpb.AuthzDetail_AUTHZ_STATUS_PERMIT,
+	}})
+	if len(r.Missing) != 1 {
+		t.Fatalf("Match() = %v, want 1 missing", r)
+	}
+	miss := r.Missing[0]
+	if miss.Closest.GetGrpcService().GetRpcName() != gnmiCapabilitiesPath || len(miss.Diffs) != 1 || !strings.HasPrefix(miss.Diffs[0], "authz status") {
+		t.Errorf("Missing[0] closest %v differs in %v, want the %s record differing in authz status", miss.Closest, miss.Diffs, gnmiCapabilitiesPath)
+	}
+	if errs := r.Errors(); len(errs) != 4 {
+		t.Errorf("Errors() = %q, want a record count, a missing and 2 unexpected records", errs)
+	}
+}
+
+func TestPayload(t *testing.T) {
+	req := &gnmipb.CapabilityRequest{}
+	other := &gnmipb.GetRequest{Prefix: &gnmipb.Path{Target: "dut"}}
+	reqAny, err := anypb.New(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherAny, err := anypb.New(other)
+	if err != nil {
+		t.Fatal(err)
+	}
+	text := prototext.Format(other)
+	tests := []struct {
+		desc      string
+		want      *Expectation
+		payload   *acctzpb.GrpcService
+		wantMatch bool
+	}{{
+		desc:      "proto",
+		want:      &Expectation{Payload: req},
+		payload:   &acctzpb.GrpcService{Payload: &acctzpb.GrpcService_ProtoVal{ProtoVal: reqAny}},
+		wantMatch: true,
+	}, {
+		desc:    "other proto",
+		want:    &Expectation{Payload: req},
+		payload: &acctzpb.GrpcService{Payload: &acctzpb.GrpcService_ProtoVal{ProtoVal: otherAny}},
+	}, {
+		desc:      "truncated proto",
+		want:      &Expectation{Payload: req},
+		payload:   &acctzpb.GrpcService{Payload: &acctzpb.GrpcService_ProtoVal{ProtoVal: otherAny}, PayloadIstruncated: true},
+		wantMatch: true,
+	}, {
+		desc:      "string",
+		want:      &Expectation{Payload: other},
+		payload:   &acctzpb.GrpcService{Payload: &acctzpb.GrpcService_StringVal{StringVal: text}},
+		wantMatch: true,
+	}, {
+		desc:      "truncated string",
+		want:      &Expectation{Payload: other},
+		payload:   &acctzpb.GrpcService{Payload: &acctzpb.GrpcService_StringVal{StringVal: text[:3]}, PayloadIstruncated: true},
+		wantMatch: true,
+	}, {
+		desc:    "short string",
+		want:    &Expectation{Payload: other},
+		payload: &acctzpb.GrpcService{Payload: &acctzpb.GrpcService_StringVal{StringVal: text[:3]}},
+	}, {
+		desc: "no payload",
+		want: &Expectation{Payload: req},
+	}}
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			r := &acctzpb.RecordResponse{ServiceRequest: &acctzpb.RecordResponse_GrpcService{GrpcService: tc.payload}}
+			if tc.payload == nil {
+				r.ServiceRequest = &acctzpb.RecordResponse_GrpcService{GrpcService: &acctzpb.GrpcService{}}
+			}
+			diffs := tc.want.mismatches(r)
+			if got := len(diffs) == 0; got != tc.wantMatch {
+				t.Errorf("mismatches() = %v, want match %v", diffs, tc.wantMatch)
+			}
+		})
+	}
+}
+
+func TestFromRecord(t *testing.T) {
+	r := grpcRecord(gnmiCapabilitiesPath, SuccessUsername, acctzpb.AuthzDetail_AUTHZ_STATUS_PERMIT, 0)
+	e := FromRecord(r)
+	if e.RPC != gnmiCapabilitiesPath || e.User != SuccessUsername || e.Session != acctzpb.SessionInfo_SESSION_STATUS_ONCE {
+		t.Errorf("FromRecord() = %+v", e)
+	}
+	if diffs := e.mismatches(r); len(diffs) != 0 {
+		t.Errorf("FromRecord() does not match its record: %v", diffs)
+	}
+	c := FromRecord(cmdRecord(successCliCommand, false, SuccessUsername))
+	if c.Cmd != successCliCommand || c.CmdService != acctzpb.CommandService_CMD_SERVICE_TYPE_CLI {
+		t.Errorf("FromRecord() = %+v", c)
+	}
+}
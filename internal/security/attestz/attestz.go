@@ -0,0 +1,339 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package attestz provides helper APIs to verify TPM 2.0 enrollment and
+// attestation of a device independently of its vendor: the EK, IAK and
+// IDevID certificate chains returned by enrollz, the owner IAK and IDevID
+// certificates installed by RotateOIakCert, and attestz quotes against
+// expected PCR banks. SoftTPM and FakeServer stand in for the TPM of a
+// device in unit tests.
+//
+//	id := attestz.Enroll(t, gnsiC.Enrollz(), attestz.Role(cpb.ControlCardRole_CONTROL_CARD_ROLE_ACTIVE), mfgRoots)
+//	attestz.RotateOwnerCerts(t, gnsiC.Enrollz(), "", owner, id)
+//	attestz.Attest(t, gnsiC.Attestz(), id, cpb.Tpm20HashAlgo_TPM_2_0_HASH_ALGO_SHA256, []int32{0, 7}, &attestz.QuoteOptions{
+//		Roots: owner.Pool(),
+//		Want:  attestz.PCRBanks{cpb.Tpm20HashAlgo_TPM_2_0_HASH_ALGO_SHA256: golden},
+//	})
+package attestz
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openconfig/attestz/service/biz"
+	"github.com/openconfig/featureprofiles/internal/security/certz"
+
+	cpb "github.com/openconfig/attestz/proto/common_definitions"
+	epb "github.com/openconfig/attestz/proto/tpm_enrollz"
+)
+
+// Identity is the verified TPM identity of a control card, as returned by
+// GetIakCert.
+type Identity struct {
+	// Selection is the selection of the control card in requests.
+	Selection     *cpb.ControlCardSelection
+	ControlCardID *cpb.ControlCardVendorId
+	IAKCert       *x509.Certificate
+	// IDevIDCert is nil when the control card did not return one, as allowed
+	// on a standby control card.
+	IDevIDCert *x509.Certificate
+	// AtomicRotation is whether the device rotates the owner certificates of
+	// all control cards at once.
+	AtomicRotation bool
+}
+
+// Role returns the selection of the control card of role.
+func Role(role cpb.ControlCardRole) *cpb.ControlCardSelection {
+	return &cpb.ControlCardSelection{ControlCardId: &cpb.ControlCardSelection_Role{Role: role}}
+}
+
+// Serial returns the selection of the control card of serial number serial.
+func Serial(serial string) *cpb.ControlCardSelection {
+	return &cpb.ControlCardSelection{ControlCardId: &cpb.ControlCardSelection_Serial{Serial: serial}}
+}
+
+// parseChain returns the certificates of the PEM chain, leaf first.
+func parseChain(chain string) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	rest := []byte(chain)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("no certificate in %q", chain)
+	}
+	return certs, nil
+}
+
+// verifyChain verifies the PEM chain against roots and the strength of the
+// key of its leaf, which it returns.
+func verifyChain(ctx context.Context, chain string, roots *x509.CertPool) (*x509.Certificate, error) {
+	leaf, err := biz.VerifyAndParsePemCert(ctx, chain, x509.VerifyOptions{Roots: roots})
+	if err != nil {
+		return nil, err
+	}
+	if _, err := biz.VerifyAndSerializePubKey(ctx, leaf); err != nil {
+		return nil, err
+	}
+	return leaf, nil
+}
+
+// certSerial returns the serial number of the subject of cert, which
+// vendors encode either as is or as "PID:<pid> SN:<serial>".
+func certSerial(cert *x509.Certificate) string {
+	serial := cert.Subject.SerialNumber
+	if _, sn, ok := strings.Cut(serial, "SN:"); ok {
+		return sn
+	}
+	return serial
+}
+
+func samePublicKey(a, b *x509.Certificate) bool {
+	return bytes.Equal(a.RawSubjectPublicKeyInfo, b.RawSubjectPublicKeyInfo)
+}
+
+// VerifyEKCert verifies the PEM EK certificate chain against the TPM
+// manufacturer roots and returns the EK certificate. When serial is not
+// empty, it is the serial number the subject must carry.
+func VerifyEKCert(ctx context.Context, chain string, roots *x509.CertPool, serial string) (*x509.Certificate, error) {
+	ek, err := verifyChain(ctx, chain, roots)
+	if err != nil {
+		return nil, fmt.Errorf("could not verify EK certificate: %w", err)
+	}
+	if serial != "" && certSerial(ek) != serial {
+		return nil, fmt.Errorf("EK certificate subject serial %q, want %q", certSerial(ek), serial)
+	}
+	return ek, nil
+}
+
+// VerifyIAKCert verifies the IAK and IDevID certificates of a GetIakCert
+// response of the control card sel against the manufacturer roots: their
+// chains, that their subject serial matches the control card and that they
+// certify different keys.
+func VerifyIAKCert(ctx context.Context, sel *cpb.ControlCardSelection, resp *epb.GetIakCertResponse, roots *x509.CertPool) (*Identity, error) {
+	if resp.GetControlCardId() == nil {
+		return nil, fmt.Errorf("GetIakCert response has no control card id")
+	}
+	if resp.GetIakCert() == "" {
+		return nil, fmt.Errorf("GetIakCert response has no IAK certificate")
+	}
+	if err := matchSelection(sel, resp.GetControlCardId()); err != nil {
+		return nil, err
+	}
+	v := &biz.DefaultTpmCertVerifier{}
+	if _, err := v.VerifyIakAndIDevIDCerts(ctx, &biz.VerifyIakAndIDevIDCertsReq{
+		ControlCardID:        resp.GetControlCardId(),
+		CertVerificationOpts: x509.VerifyOptions{Roots: roots},
+		IakCertPem:           resp.GetIakCert(),
+		IDevIDCertPem:        resp.GetIdevidCert(),
+	}); err != nil {
+		return nil, err
+	}
+	id := &Identity{
+		Selection:      sel,
+		ControlCardID:  resp.GetControlCardId(),
+		AtomicRotation: resp.GetAtomicCertRotationSupported(),
+	}
+	certs, err := parseChain(resp.GetIakCert())
+	if err != nil {
+		return nil, err
+	}
+	id.IAKCert = certs[0]
+	if resp.GetIdevidCert() == "" {
+		return id, nil
+	}
+	if certs, err = parseChain(resp.GetIdevidCert()); err != nil {
+		return nil, err
+	}
+	id.IDevIDCert = certs[0]
+	if samePublicKey(id.IAKCert, id.IDevIDCert) {
+		return nil, fmt.Errorf("IAK and IDevID certificates certify the same key")
+	}
+	return id, nil
+}
+
+// matchSelection checks that the control card id answers the selection.
+func matchSelection(sel *cpb.ControlCardSelection, id *cpb.ControlCardVendorId) error {
+	switch s := sel.GetControlCardId().(type) {
+	case *cpb.ControlCardSelection_Role:
+		if id.GetControlCardRole() != s.Role {
+			return fmt.Errorf("response of control card role %v, want %v", id.GetControlCardRole(), s.Role)
+		}
+	case *cpb.ControlCardSelection_Serial:
+		if id.GetControlCardSerial() != s.Serial {
+			return fmt.Errorf("response of control card serial %q, want %q", id.GetControlCardSerial(), s.Serial)
+		}
+	case *cpb.ControlCardSelection_Slot:
+		if id.GetControlCardSlot() != s.Slot {
+			return fmt.Errorf("response of control card slot %q, want %q", id.GetControlCardSlot(), s.Slot)
+		}
+	}
+	return nil
+}
+
+// ownerCert returns a PEM owner certificate issued by ca for the key
+// certified by cert, keeping its subject serial as required by enrollz.
+func ownerCert(ca *certz.CA, cert *x509.Certificate, cn string) (string, error) {
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 127))
+	if err != nil {
+		return "", err
+	}
+	now := time.Now()
+	tmpl := &x509.Certificate{
+		SerialNumber: serial,
+		Subject: pkix.Name{
+			CommonName:   cn,
+			SerialNumber: cert.Subject.SerialNumber,
+			Organization: []string{"OpenconfigFeatureProfiles"},
+		},
+		NotBefore:   now.Add(-time.Hour),
+		NotAfter:    ca.Cert.NotAfter,
+		KeyUsage:    cert.KeyUsage,
+		ExtKeyUsage: cert.ExtKeyUsage,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.Cert, cert.PublicKey, ca.Key)
+	if err != nil {
+		return "", err
+	}
+	owned, err := x509.ParseCertificate(der)
+	if err != nil {
+		return "", err
+	}
+	var chain []*x509.Certificate
+	for c := ca; c.Parent != nil; c = c.Parent {
+		chain = append(chain, c.Cert)
+	}
+	return string(certz.CertPEM(append([]*x509.Certificate{owned}, chain...)...)), nil
+}
+
+// NewOwnerCertUpdate returns the RotateOIakCert update of the control card
+// of id with owner IAK and IDevID certificates issued by ca for its IAK and
+// IDevID keys.
+func NewOwnerCertUpdate(ca *certz.CA, id *Identity) (*epb.ControlCardCertUpdate, error) {
+	update := &epb.ControlCardCertUpdate{ControlCardSelection: id.Selection}
+	var err error
+	if update.OiakCert, err = ownerCert(ca, id.IAKCert, "oIAK"); err != nil {
+		return nil, fmt.Errorf("could not issue owner IAK certificate: %w", err)
+	}
+	if id.IDevIDCert == nil {
+		return update, nil
+	}
+	if update.OidevidCert, err = ownerCert(ca, id.IDevIDCert, "oIDevID"); err != nil {
+		return nil, fmt.Errorf("could not issue owner IDevID certificate: %w", err)
+	}
+	return update, nil
+}
+
+// VerifyOwnerCerts verifies PEM owner IAK and IDevID certificate chains
+// against the owner roots and that they certify the IAK and IDevID keys of
+// id. An empty oidevid is not checked.
+func VerifyOwnerCerts(ctx context.Context, id *Identity, oiak, oidevid string, roots *x509.CertPool) error {
+	cert, err := verifyChain(ctx, oiak, roots)
+	if err != nil {
+		return fmt.Errorf("could not verify owner IAK certificate: %w", err)
+	}
+	if !samePublicKey(cert, id.IAKCert) {
+		return fmt.Errorf("owner IAK certificate does not certify the IAK")
+	}
+	if certSerial(cert) != certSerial(id.IAKCert) {
+		return fmt.Errorf("owner IAK certificate subject serial %q, want %q", certSerial(cert), certSerial(id.IAKCert))
+	}
+	if oidevid == "" {
+		return nil
+	}
+	if id.IDevIDCert == nil {
+		return fmt.Errorf("owner IDevID certificate for a control card without IDevID")
+	}
+	if cert, err = verifyChain(ctx, oidevid, roots); err != nil {
+		return fmt.Errorf("could not verify owner IDevID certificate: %w", err)
+	}
+	if !samePublicKey(cert, id.IDevIDCert) {
+		return fmt.Errorf("owner IDevID certificate does not certify the IDevID key")
+	}
+	return nil
+}
+
+// Enroll gets the IAK and IDevID certificates of the control card sel and
+// verifies them against the manufacturer roots.
+func Enroll(t testing.TB, c epb.TpmEnrollzServiceClient, sel *cpb.ControlCardSelection, roots *x509.CertPool) *Identity {
+	t.Helper()
+	ctx := context.Background()
+	resp, err := c.GetIakCert(ctx, &epb.GetIakCertRequest{ControlCardSelection: sel})
+	if err != nil {
+		t.Fatalf("Failed to get IAK certificate of %v, error: %s", sel, err)
+	}
+	id, err := VerifyIAKCert(ctx, sel, resp, roots)
+	if err != nil {
+		t.Fatalf("Failed to verify IAK certificate of %v, error: %s", sel, err)
+	}
+	return id
+}
+
+// RotateOwnerCerts installs owner IAK and IDevID certificates issued by ca
+// on the control cards of ids in a single RotateOIakCert and returns the
+// updates sent. sslProfileID is the profile the owner IDevID certificate is
+// installed in, empty for the default one.
+func RotateOwnerCerts(t testing.TB, c epb.TpmEnrollzServiceClient, sslProfileID string, ca *certz.CA, ids ...*Identity) []*epb.ControlCardCertUpdate {
+	t.Helper()
+	req := &epb.RotateOIakCertRequest{SslProfileId: sslProfileID}
+	for _, id := range ids {
+		update, err := NewOwnerCertUpdate(ca, id)
+		if err != nil {
+			t.Fatalf("Failed to create owner certificates of %v, error: %s", id.ControlCardID, err)
+		}
+		req.Updates = append(req.Updates, update)
+	}
+	if _, err := c.RotateOIakCert(context.Background(), req); err != nil {
+		t.Fatalf("Failed to rotate owner IAK certificates, error: %s", err)
+	}
+	return req.Updates
+}
+
+// hashAlgos are the crypto hashes of the attestz hash algorithms.
+var hashAlgos = map[cpb.Tpm20HashAlgo]crypto.Hash{
+	cpb.Tpm20HashAlgo_TPM_2_0_HASH_ALGO_SHA1:   crypto.SHA1,
+	cpb.Tpm20HashAlgo_TPM_2_0_HASH_ALGO_SHA256: crypto.SHA256,
+	cpb.Tpm20HashAlgo_TPM_2_0_HASH_ALGO_SHA384: crypto.SHA384,
+	cpb.Tpm20HashAlgo_TPM_2_0_HASH_ALGO_SHA512: crypto.SHA512,
+}
+
+func hashOf(algo cpb.Tpm20HashAlgo) (crypto.Hash, error) {
+	h, ok := hashAlgos[algo]
+	if !ok {
+		return 0, fmt.Errorf("hash algorithm %v is not supported", algo)
+	}
+	return h, nil
+}
@@ -0,0 +1,343 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attestz
+
+import (
+	"context"
+	"crypto"
+	"crypto/x509"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/openconfig/featureprofiles/internal/security/certz"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	cpb "github.com/openconfig/attestz/proto/common_definitions"
+	apb "github.com/openconfig/attestz/proto/tpm_attestz"
+	epb "github.com/openconfig/attestz/proto/tpm_enrollz"
+)
+
+const (
+	sha256     = cpb.Tpm20HashAlgo_TPM_2_0_HASH_ALGO_SHA256
+	sha384     = cpb.Tpm20HashAlgo_TPM_2_0_HASH_ALGO_SHA384
+	roleActive = cpb.ControlCardRole_CONTROL_CARD_ROLE_ACTIVE
+)
+
+type device struct {
+	mfg, owner *certz.CA
+	tpms       []*SoftTPM
+	enrollz    epb.TpmEnrollzServiceClient
+	attestz    apb.TpmAttestzServiceClient
+}
+
+func newDevice(t *testing.T) *device {
+	t.Helper()
+	mfgRoot, err := certz.NewRootCA("mfg root", x509.ECDSA)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mfg, err := mfgRoot.NewIntermediate("mfg ica", x509.ECDSA)
+	if err != nil {
+		t.Fatal(err)
+	}
+	owner, err := certz.NewRootCA("owner", x509.ECDSA)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := &device{mfg: mfg, owner: owner}
+	for _, id := range []*cpb.ControlCardVendorId{{
+		ControlCardRole:     roleActive,
+		ControlCardSerial:   "SN-RP0",
+		ControlCardSlot:     "RP0",
+		ChassisSerialNumber: "CHASSIS",
+	}, {
+		ControlCardRole:     cpb.ControlCardRole_CONTROL_CARD_ROLE_STANDBY,
+		ControlCardSerial:   "SN-RP1",
+		ControlCardSlot:     "RP1",
+		ChassisSerialNumber: "CHASSIS",
+	}} {
+		tpm, err := NewSoftTPM(id, mfg)
+		if err != nil {
+			t.Fatalf("NewSoftTPM() failed: %v", err)
+		}
+		d.tpms = append(d.tpms, tpm)
+	}
+	d.enrollz, d.attestz = NewFakeServer(d.tpms...).Start(t)
+	return d
+}
+
+func TestEnrollAndAttest(t *testing.T) {
+	d := newDevice(t)
+	for i, tpm := range d.tpms {
+		if err := tpm.Measure(0, []byte("bootloader")); err != nil {
+			t.Fatal(err)
+		}
+		if err := tpm.Measure(7, []byte("secure boot policy")); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := VerifyEKCert(context.Background(), tpm.EKCert, d.mfg.Pool(), tpm.ControlCardID.GetControlCardSerial()); err != nil {
+			t.Errorf("VerifyEKCert(%d) failed: %v", i, err)
+		}
+	}
+	active := Enroll(t, d.enrollz, Role(roleActive), d.mfg.Pool())
+	standby := Enroll(t, d.enrollz, Serial("SN-RP1"), d.mfg.Pool())
+	updates := RotateOwnerCerts(t, d.enrollz, "", d.owner, active, standby)
+	for i, id := range []*Identity{active, standby} {
+		if err := VerifyOwnerCerts(context.Background(), id, updates[i].GetOiakCert(), updates[i].GetOidevidCert(), d.owner.Pool()); err != nil {
+			t.Errorf("VerifyOwnerCerts(%v) failed: %v", id.Selection, err)
+		}
+	}
+
+	h := crypto.SHA256
+	zero := make([]byte, h.Size())
+	golden := PCRBank{
+		0: ExtendPCR(h, zero, digest(h, []byte("bootloader"))),
+		7: ExtendPCR(h, zero, digest(h, []byte("secure boot policy"))),
+		9: zero,
+	}
+	resp := Attest(t, d.attestz, active, sha256, []int32{9, 0, 7}, &QuoteOptions{
+		Roots: d.owner.Pool(),
+		Want:  PCRBanks{sha256: golden},
+	})
+	if resp.GetOidevidCert() != updates[0].GetOidevidCert() {
+		t.Errorf("Attest() owner IDevID certificate is not the rotated one")
+	}
+	Attest(t, d.attestz, standby, sha384, []int32{0}, &QuoteOptions{Roots: d.owner.Pool()})
+}
+
+func TestVerifyIAKCert(t *testing.T) {
+	d := newDevice(t)
+	other, err := certz.NewRootCA("other", x509.ECDSA)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	resp, err := d.enrollz.GetIakCert(ctx, &epb.GetIakCertRequest{ControlCardSelection: Role(roleActive)})
+	if err != nil {
+		t.Fatalf("GetIakCert() failed: %v", err)
+	}
+	tests := []struct {
+		desc    string
+		sel     *cpb.ControlCardSelection
+		mutate  func(*epb.GetIakCertResponse)
+		roots   *x509.CertPool
+		wantErr string
+	}{{
+		desc:  "valid",
+		sel:   Role(roleActive),
+		roots: d.mfg.Pool(),
+	}, {
+		desc:    "untrusted roots",
+		sel:     Role(roleActive),
+		roots:   other.Pool(),
+		wantErr: "unknown authority",
+	}, {
+		desc:    "other control card",
+		sel:     Serial("SN-RP1"),
+		roots:   d.mfg.Pool(),
+		wantErr: "control card serial",
+	}, {
+		desc:    "serial mismatch",
+		sel:     Role(roleActive),
+		mutate:  func(r *epb.GetIakCertResponse) { r.ControlCardId.ControlCardSerial = "SN-RP9" },
+		roots:   d.mfg.Pool(),
+		wantErr: "mismatched subject serial number",
+	}, {
+		desc:    "IDevID of other control card",
+		sel:     Role(roleActive),
+		mutate:  func(r *epb.GetIakCertResponse) { r.IdevidCert = d.tpms[1].IDevIDCert },
+		roots:   d.mfg.Pool(),
+		wantErr: "mismatched subject serial numbers",
+	}, {
+		desc:    "IAK as IDevID",
+		sel:     Role(roleActive),
+		mutate:  func(r *epb.GetIakCertResponse) { r.IdevidCert = r.IakCert },
+		roots:   d.mfg.Pool(),
+		wantErr: "same key",
+	}, {
+		desc:    "no control card id",
+		sel:     Role(roleActive),
+		mutate:  func(r *epb.GetIakCertResponse) { r.ControlCardId = nil },
+		roots:   d.mfg.Pool(),
+		wantErr: "no control card id",
+	}}
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			r := proto.Clone(resp).(*epb.GetIakCertResponse)
+			if tc.mutate != nil {
+				tc.mutate(r)
+			}
+			id, err := VerifyIAKCert(ctx, tc.sel, r, tc.roots)
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("VerifyIAKCert() failed: %v", err)
+				}
+				if id.IDevIDCert == nil || certSerial(id.IAKCert) != "SN-RP0" {
+					t.Errorf("VerifyIAKCert() = %+v, want IAK and IDevID certificates of SN-RP0", id)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("VerifyIAKCert() error = %v, want %q", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestRotateOIakCertRejectsOtherKey(t *testing.T) {
+	d := newDevice(t)
+	active := Enroll(t, d.enrollz, Role(roleActive), d.mfg.Pool())
+	standby := Enroll(t, d.enrollz, Role(cpb.ControlCardRole_CONTROL_CARD_ROLE_STANDBY), d.mfg.Pool())
+	update, err := NewOwnerCertUpdate(d.owner, standby)
+	if err != nil {
+		t.Fatal(err)
+	}
+	update.ControlCardSelection = active.Selection
+	_, err = d.enrollz.RotateOIakCert(context.Background(), &epb.RotateOIakCertRequest{Updates: []*epb.ControlCardCertUpdate{update}})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("RotateOIakCert() of certificates of another control card error = %v, want %v", err, codes.InvalidArgument)
+	}
+	if oiak, _ := d.tpms[0].OwnerCerts(); oiak != "" {
+		t.Errorf("RotateOIakCert() installed a rejected certificate")
+	}
+	if err := VerifyOwnerCerts(context.Background(), active, update.GetOiakCert(), "", d.owner.Pool()); err == nil {
+		t.Errorf("VerifyOwnerCerts() of another IAK succeeded, want error")
+	}
+}
+
+func TestVerifyQuote(t *testing.T) {
+	d := newDevice(t)
+	id := Enroll(t, d.enrollz, Role(roleActive), d.mfg.Pool())
+	RotateOwnerCerts(t, d.enrollz, "", d.owner, id)
+	if err := d.tpms[0].Measure(4, []byte("kernel")); err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	req := &apb.AttestRequest{
+		ControlCardSelection: id.Selection,
+		Nonce:                []byte("nonce"),
+		HashAlgo:             sha256,
+		PcrIndices:           []int32{0, 4},
+	}
+	resp, err := d.attestz.Attest(ctx, req)
+	if err != nil {
+		t.Fatalf("Attest() failed: %v", err)
+	}
+	golden := d.tpms[0].PCRs(sha256)
+	stale := PCRBank{4: make([]byte, crypto.SHA256.Size())}
+	other, err := certz.NewRootCA("other", x509.ECDSA)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherIAK := Enroll(t, d.enrollz, Serial("SN-RP1"), d.mfg.Pool()).IAKCert
+
+	tests := []struct {
+		desc    string
+		mutateQ func(*apb.AttestRequest)
+		mutateR func(*apb.AttestResponse)
+		opts    *QuoteOptions
+		wantErr string
+	}{{
+		desc: "valid",
+		opts: &QuoteOptions{Roots: d.owner.Pool(), IAKCert: id.IAKCert, ControlCardID: id.ControlCardID, Want: PCRBanks{sha256: golden}},
+	}, {
+		desc:    "unexpected PCR value",
+		opts:    &QuoteOptions{Roots: d.owner.Pool(), Want: PCRBanks{sha256: stale}},
+		wantErr: "PCR 4 =",
+	}, {
+		desc:    "untrusted owner IAK certificate",
+		opts:    &QuoteOptions{Roots: other.Pool()},
+		wantErr: "owner IAK certificate",
+	}, {
+		desc:    "owner IAK certificate of another IAK",
+		opts:    &QuoteOptions{Roots: d.owner.Pool(), IAKCert: otherIAK},
+		wantErr: "does not certify the IAK",
+	}, {
+		desc:    "replayed nonce",
+		mutateQ: func(q *apb.AttestRequest) { q.Nonce = []byte("other") },
+		opts:    &QuoteOptions{Roots: d.owner.Pool()},
+		wantErr: "nonce",
+	}, {
+		desc:    "other PCR selection",
+		mutateQ: func(q *apb.AttestRequest) { q.PcrIndices = []int32{0, 4, 7} },
+		opts:    &QuoteOptions{Roots: d.owner.Pool()},
+		wantErr: "quote of PCRs",
+	}, {
+		desc:    "other bank",
+		mutateQ: func(q *apb.AttestRequest) { q.HashAlgo = sha384 },
+		opts:    &QuoteOptions{Roots: d.owner.Pool()},
+		wantErr: "quote of PCR bank",
+	}, {
+		desc:    "reported PCR value not quoted",
+		mutateR: func(r *apb.AttestResponse) { r.PcrValues[4] = make([]byte, crypto.SHA256.Size()) },
+		opts:    &QuoteOptions{Roots: d.owner.Pool()},
+		wantErr: "digest",
+	}, {
+		desc:    "missing PCR value",
+		mutateR: func(r *apb.AttestResponse) { delete(r.PcrValues, 4) },
+		opts:    &QuoteOptions{Roots: d.owner.Pool()},
+		wantErr: "PCR values",
+	}, {
+		desc:    "tampered quote",
+		mutateR: func(r *apb.AttestResponse) { r.Quoted[len(r.Quoted)-1] ^= 1 },
+		opts:    &QuoteOptions{Roots: d.owner.Pool()},
+		wantErr: "signature",
+	}, {
+		desc:    "other control card",
+		opts:    &QuoteOptions{Roots: d.owner.Pool(), ControlCardID: d.tpms[1].ControlCardID},
+		wantErr: "response of control card",
+	}}
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			q := proto.Clone(req).(*apb.AttestRequest)
+			if tc.mutateQ != nil {
+				tc.mutateQ(q)
+			}
+			r := proto.Clone(resp).(*apb.AttestResponse)
+			if tc.mutateR != nil {
+				tc.mutateR(r)
+			}
+			err := VerifyQuote(ctx, q, r, tc.opts)
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Errorf("VerifyQuote() failed: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("VerifyQuote() error = %v, want %q", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestPCRSelect(t *testing.T) {
+	bitmap, err := pcrSelect([]int32{23, 0, 7, 8, 7})
+	if err != nil {
+		t.Fatalf("pcrSelect() failed: %v", err)
+	}
+	if want := []byte{0x81, 0x01, 0x80}; !slices.Equal(bitmap, want) {
+		t.Errorf("pcrSelect() = %x, want %x", bitmap, want)
+	}
+	if got, want := pcrIndices(bitmap), []int32{0, 7, 8, 23}; !slices.Equal(got, want) {
+		t.Errorf("pcrIndices() = %v, want %v", got, want)
+	}
+	if _, err := pcrSelect([]int32{24}); err == nil {
+		t.Errorf("pcrSelect(24) succeeded, want error")
+	}
+}
@@ -0,0 +1,155 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attestz
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	cpb "github.com/openconfig/attestz/proto/common_definitions"
+	apb "github.com/openconfig/attestz/proto/tpm_attestz"
+	epb "github.com/openconfig/attestz/proto/tpm_enrollz"
+)
+
+// FakeServer is an enrollz and attestz server of a device whose control
+// cards hold SoftTPMs, for unit tests. Only GetIakCert, RotateOIakCert and
+// Attest are implemented.
+type FakeServer struct {
+	epb.UnimplementedTpmEnrollzServiceServer
+	apb.UnimplementedTpmAttestzServiceServer
+
+	// TPMs are the TPMs of the control cards of the device.
+	TPMs []*SoftTPM
+}
+
+// NewFakeServer returns a server of the control cards holding tpms.
+func NewFakeServer(tpms ...*SoftTPM) *FakeServer {
+	return &FakeServer{TPMs: tpms}
+}
+
+// Start serves s on a local port until the end of the test and returns the
+// enrollz and attestz clients connected to it.
+func (s *FakeServer) Start(t testing.TB) (epb.TpmEnrollzServiceClient, apb.TpmAttestzServiceClient) {
+	t.Helper()
+	lis, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatalf("Failed to listen, error: %s", err)
+	}
+	srv := grpc.NewServer()
+	epb.RegisterTpmEnrollzServiceServer(srv, s)
+	apb.RegisterTpmAttestzServiceServer(srv, s)
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+	conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("Failed to connect to fake server, error: %s", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return epb.NewTpmEnrollzServiceClient(conn), apb.NewTpmAttestzServiceClient(conn)
+}
+
+// tpm returns the TPM of the control card sel.
+func (s *FakeServer) tpm(sel *cpb.ControlCardSelection) (*SoftTPM, error) {
+	if sel.GetControlCardId() == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "no control card selection")
+	}
+	for _, tpm := range s.TPMs {
+		if matchSelection(sel, tpm.ControlCardID) == nil {
+			return tpm, nil
+		}
+	}
+	return nil, status.Errorf(codes.NotFound, "no control card %v", sel)
+}
+
+// GetIakCert returns the IAK and IDevID certificates of the selected
+// control card.
+func (s *FakeServer) GetIakCert(_ context.Context, req *epb.GetIakCertRequest) (*epb.GetIakCertResponse, error) {
+	tpm, err := s.tpm(req.GetControlCardSelection())
+	if err != nil {
+		return nil, err
+	}
+	return &epb.GetIakCertResponse{
+		ControlCardId: tpm.ControlCardID,
+		IakCert:       tpm.IAKCert,
+		IdevidCert:    tpm.IDevIDCert,
+	}, nil
+}
+
+// RotateOIakCert installs the owner certificates of every update, or of
+// the deprecated fields when there is none. Nothing is installed if any
+// update is invalid.
+func (s *FakeServer) RotateOIakCert(_ context.Context, req *epb.RotateOIakCertRequest) (*epb.RotateOIakCertResponse, error) {
+	updates := req.GetUpdates()
+	if len(updates) == 0 {
+		updates = []*epb.ControlCardCertUpdate{{
+			ControlCardSelection: req.GetControlCardSelection(),
+			OiakCert:             req.GetOiakCert(),
+			OidevidCert:          req.GetOidevidCert(),
+		}}
+	}
+	tpms := make([]*SoftTPM, len(updates))
+	for i, u := range updates {
+		tpm, err := s.tpm(u.GetControlCardSelection())
+		if err != nil {
+			return nil, err
+		}
+		if u.GetOiakCert() == "" {
+			return nil, status.Errorf(codes.InvalidArgument, "no owner IAK certificate for %v", u.GetControlCardSelection())
+		}
+		if err := tpm.checkOwnerCerts(u.GetOiakCert(), u.GetOidevidCert()); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "%v: %v", u.GetControlCardSelection(), err)
+		}
+		tpms[i] = tpm
+	}
+	for i, u := range updates {
+		if err := tpms[i].InstallOwnerCerts(u.GetOiakCert(), u.GetOidevidCert()); err != nil {
+			return nil, status.Errorf(codes.Internal, "%v: %v", u.GetControlCardSelection(), err)
+		}
+	}
+	return &epb.RotateOIakCertResponse{}, nil
+}
+
+// Attest returns a quote of the selected control card, which must hold an
+// owner IAK certificate.
+func (s *FakeServer) Attest(_ context.Context, req *apb.AttestRequest) (*apb.AttestResponse, error) {
+	tpm, err := s.tpm(req.GetControlCardSelection())
+	if err != nil {
+		return nil, err
+	}
+	oiak, oidevid := tpm.OwnerCerts()
+	if oiak == "" {
+		return nil, status.Errorf(codes.FailedPrecondition, "no owner IAK certificate installed")
+	}
+	quoted, sig, values, err := tpm.Quote(req.GetNonce(), req.GetHashAlgo(), req.GetPcrIndices())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	return &apb.AttestResponse{
+		ControlCardId:  tpm.ControlCardID,
+		PcrValues:      values,
+		Quoted:         quoted,
+		QuoteSignature: sig,
+		OidevidCert:    oidevid,
+		AttestationCert: &apb.AttestResponse_AttestationCert{
+			Value: &apb.AttestResponse_AttestationCert_OiakCert{OiakCert: oiak},
+		},
+	}, nil
+}
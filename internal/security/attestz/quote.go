@@ -0,0 +1,291 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attestz
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"fmt"
+	"math/big"
+	"slices"
+	"testing"
+
+	"github.com/google/go-tpm/tpm2"
+	"google.golang.org/protobuf/proto"
+
+	cpb "github.com/openconfig/attestz/proto/common_definitions"
+	apb "github.com/openconfig/attestz/proto/tpm_attestz"
+)
+
+// pcrCount is the number of PCRs of a bank of a PC client TPM.
+const pcrCount = 24
+
+// PCRBank holds PCR values of one hash algorithm by PCR index.
+type PCRBank map[int32][]byte
+
+// PCRBanks holds PCR banks by hash algorithm.
+type PCRBanks map[cpb.Tpm20HashAlgo]PCRBank
+
+// ExtendPCR returns the value of a PCR of hash h after extending value with
+// digest, as done by TPM2_PCR_Extend.
+func ExtendPCR(h crypto.Hash, value, digest []byte) []byte {
+	hh := h.New()
+	hh.Write(value)
+	hh.Write(digest)
+	return hh.Sum(nil)
+}
+
+// pcrSelect returns the TPMS_PCR_SELECTION bitmap of indices.
+func pcrSelect(indices []int32) ([]byte, error) {
+	bitmap := make([]byte, pcrCount/8)
+	for _, i := range indices {
+		if i < 0 || i >= pcrCount {
+			return nil, fmt.Errorf("PCR index %d out of range [0, %d)", i, pcrCount)
+		}
+		bitmap[i/8] |= 1 << (i % 8)
+	}
+	return bitmap, nil
+}
+
+// pcrIndices returns the PCR indices selected by bitmap, in ascending order.
+func pcrIndices(bitmap []byte) []int32 {
+	var indices []int32
+	for i := range int32(len(bitmap) * 8) {
+		if bitmap[i/8]&(1<<(i%8)) != 0 {
+			indices = append(indices, i)
+		}
+	}
+	return indices
+}
+
+// pcrDigest returns the digest of the values of indices, in ascending order.
+func pcrDigest(h crypto.Hash, values map[int32][]byte, indices []int32) []byte {
+	hh := h.New()
+	for _, i := range indices {
+		hh.Write(values[i])
+	}
+	return hh.Sum(nil)
+}
+
+// QuoteOptions is what an attestz response is verified against.
+type QuoteOptions struct {
+	// Roots verify the owner IAK certificate of the response.
+	Roots *x509.CertPool
+	// IAKCert, when set, is the IAK certificate whose key the owner IAK
+	// certificate must certify.
+	IAKCert *x509.Certificate
+	// ControlCardID, when set, is the control card the response must be of.
+	ControlCardID *cpb.ControlCardVendorId
+	// Want holds the expected PCR values. PCRs of the quote not in the bank
+	// of the requested hash algorithm are only checked against the quoted
+	// digest.
+	Want PCRBanks
+}
+
+// ownerIAKCert returns the owner IAK certificate chain of resp, falling back
+// to the deprecated field.
+func ownerIAKCert(resp *apb.AttestResponse) string {
+	if c := resp.GetAttestationCert().GetOiakCert(); c != "" {
+		return c
+	}
+	return resp.GetOiakCert()
+}
+
+// VerifyQuote verifies the attestz response to req: the owner IAK
+// certificate, that the quote is a TPM generated quote of the requested PCRs
+// with the request nonce, signed by the owner IAK, and that the reported PCR
+// values match the quoted digest and the expected values.
+func VerifyQuote(ctx context.Context, req *apb.AttestRequest, resp *apb.AttestResponse, opts *QuoteOptions) error {
+	if opts.ControlCardID != nil && !proto.Equal(resp.GetControlCardId(), opts.ControlCardID) {
+		return fmt.Errorf("response of control card %v, want %v", resp.GetControlCardId(), opts.ControlCardID)
+	}
+	if err := matchSelection(req.GetControlCardSelection(), resp.GetControlCardId()); err != nil {
+		return err
+	}
+	oiak, err := verifyChain(ctx, ownerIAKCert(resp), opts.Roots)
+	if err != nil {
+		return fmt.Errorf("could not verify owner IAK certificate: %w", err)
+	}
+	if opts.IAKCert != nil && !samePublicKey(oiak, opts.IAKCert) {
+		return fmt.Errorf("owner IAK certificate does not certify the IAK")
+	}
+
+	sig, err := tpm2.Unmarshal[tpm2.TPMTSignature](resp.GetQuoteSignature())
+	if err != nil {
+		return fmt.Errorf("could not parse quote signature: %w", err)
+	}
+	sigHash, err := verifySignature(oiak.PublicKey, resp.GetQuoted(), sig)
+	if err != nil {
+		return fmt.Errorf("could not verify quote signature: %w", err)
+	}
+
+	attest, err := tpm2.Unmarshal[tpm2.TPMSAttest](resp.GetQuoted())
+	if err != nil {
+		return fmt.Errorf("could not parse quote: %w", err)
+	}
+	if attest.Type != tpm2.TPMSTAttestQuote {
+		return fmt.Errorf("attestation of type %#x, want quote", attest.Type)
+	}
+	if !bytes.Equal(attest.ExtraData.Buffer, req.GetNonce()) {
+		return fmt.Errorf("quote nonce %x, want %x", attest.ExtraData.Buffer, req.GetNonce())
+	}
+	info, err := attest.Attested.Quote()
+	if err != nil {
+		return err
+	}
+	if len(info.PCRSelect.PCRSelections) != 1 {
+		return fmt.Errorf("quote selects %d PCR banks, want 1", len(info.PCRSelect.PCRSelections))
+	}
+	sel := info.PCRSelect.PCRSelections[0]
+	h, err := hashOf(req.GetHashAlgo())
+	if err != nil {
+		return err
+	}
+	if got, err := sel.Hash.Hash(); err != nil || got != h {
+		return fmt.Errorf("quote of PCR bank %#x, want %v", sel.Hash, h)
+	}
+	indices := pcrIndices(sel.PCRSelect)
+	want := slices.Sorted(slices.Values(req.GetPcrIndices()))
+	want = slices.Compact(want)
+	if !slices.Equal(indices, want) {
+		return fmt.Errorf("quote of PCRs %v, want %v", indices, want)
+	}
+
+	values := resp.GetPcrValues()
+	if len(values) != len(indices) {
+		return fmt.Errorf("response holds %d PCR values, want %d", len(values), len(indices))
+	}
+	for _, i := range indices {
+		v, ok := values[i]
+		if !ok {
+			return fmt.Errorf("response has no value of PCR %d", i)
+		}
+		if len(v) != h.Size() {
+			return fmt.Errorf("PCR %d value of %d bytes, want %d", i, len(v), h.Size())
+		}
+	}
+	if digest := pcrDigest(sigHash, values, indices); !bytes.Equal(info.PCRDigest.Buffer, digest) {
+		return fmt.Errorf("quoted PCR digest %x, reported PCR values digest %x", info.PCRDigest.Buffer, digest)
+	}
+	for i, w := range opts.Want[req.GetHashAlgo()] {
+		v, ok := values[i]
+		if !ok {
+			continue
+		}
+		if !bytes.Equal(v, w) {
+			return fmt.Errorf("PCR %d = %x, want %x", i, v, w)
+		}
+	}
+	return nil
+}
+
+// verifySignature verifies the TPM signature of data with pub and returns
+// its hash.
+func verifySignature(pub crypto.PublicKey, data []byte, sig *tpm2.TPMTSignature) (crypto.Hash, error) {
+	switch sig.SigAlg {
+	case tpm2.TPMAlgECDSA:
+		s, err := sig.Signature.ECDSA()
+		if err != nil {
+			return 0, err
+		}
+		key, ok := pub.(*ecdsa.PublicKey)
+		if !ok {
+			return 0, fmt.Errorf("ECDSA signature for a %T key", pub)
+		}
+		h, err := s.Hash.Hash()
+		if err != nil {
+			return 0, err
+		}
+		r, ss := new(big.Int).SetBytes(s.SignatureR.Buffer), new(big.Int).SetBytes(s.SignatureS.Buffer)
+		if !ecdsa.Verify(key, digest(h, data), r, ss) {
+			return 0, fmt.Errorf("invalid ECDSA signature")
+		}
+		return h, nil
+	case tpm2.TPMAlgRSASSA, tpm2.TPMAlgRSAPSS:
+		var s *tpm2.TPMSSignatureRSA
+		var err error
+		if sig.SigAlg == tpm2.TPMAlgRSASSA {
+			s, err = sig.Signature.RSASSA()
+		} else {
+			s, err = sig.Signature.RSAPSS()
+		}
+		if err != nil {
+			return 0, err
+		}
+		key, ok := pub.(*rsa.PublicKey)
+		if !ok {
+			return 0, fmt.Errorf("RSA signature for a %T key", pub)
+		}
+		h, err := s.Hash.Hash()
+		if err != nil {
+			return 0, err
+		}
+		if sig.SigAlg == tpm2.TPMAlgRSASSA {
+			err = rsa.VerifyPKCS1v15(key, h, digest(h, data), s.Sig.Buffer)
+		} else {
+			err = rsa.VerifyPSS(key, h, digest(h, data), s.Sig.Buffer, nil)
+		}
+		return h, err
+	}
+	return 0, fmt.Errorf("signature algorithm %#x is not supported", sig.SigAlg)
+}
+
+func digest(h crypto.Hash, data []byte) []byte {
+	hh := h.New()
+	hh.Write(data)
+	return hh.Sum(nil)
+}
+
+// Attest gets a quote of the PCRs indices of bank algo of the control card
+// of id with a fresh nonce and verifies it with opts, which default to
+// checking that the owner IAK certificate certifies the IAK of id. A nil
+// opts trusts the system roots.
+func Attest(t testing.TB, c apb.TpmAttestzServiceClient, id *Identity, algo cpb.Tpm20HashAlgo, indices []int32, opts *QuoteOptions) *apb.AttestResponse {
+	t.Helper()
+	nonce := make([]byte, 32)
+	if _, err := rand.Read(nonce); err != nil {
+		t.Fatalf("Failed to generate nonce, error: %s", err)
+	}
+	req := &apb.AttestRequest{
+		ControlCardSelection: id.Selection,
+		Nonce:                nonce,
+		HashAlgo:             algo,
+		PcrIndices:           indices,
+	}
+	ctx := context.Background()
+	resp, err := c.Attest(ctx, req)
+	if err != nil {
+		t.Fatalf("Failed to attest %v, error: %s", id.Selection, err)
+	}
+	var o QuoteOptions
+	if opts != nil {
+		o = *opts
+	}
+	if o.IAKCert == nil {
+		o.IAKCert = id.IAKCert
+	}
+	if o.ControlCardID == nil {
+		o.ControlCardID = id.ControlCardID
+	}
+	if err := VerifyQuote(ctx, req, resp, &o); err != nil {
+		t.Fatalf("Failed to verify quote of %v, error: %s", id.Selection, err)
+	}
+	return resp
+}
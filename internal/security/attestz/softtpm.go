@@ -0,0 +1,320 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attestz
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/google/go-tpm/tpm2"
+	"github.com/openconfig/featureprofiles/internal/security/certz"
+
+	cpb "github.com/openconfig/attestz/proto/common_definitions"
+)
+
+// SoftTPM is a software stand-in for the TPM 2.0 of a control card. It holds
+// an RSA EK and P-384 IAK and IDevID keys certified by a manufacturer CA,
+// the SHA-1, SHA-256 and SHA-384 PCR banks, and produces quotes signed by
+// the IAK as a TPM would. It does not implement the TPM command interface.
+type SoftTPM struct {
+	ControlCardID *cpb.ControlCardVendorId
+	EK            *rsa.PrivateKey
+	IAK           *ecdsa.PrivateKey
+	IDevID        *ecdsa.PrivateKey
+	// EKCert, IAKCert and IDevIDCert are the PEM chains issued by the
+	// manufacturer CA, leaf first.
+	EKCert     string
+	IAKCert    string
+	IDevIDCert string
+	// FirmwareVersion is reported in quotes.
+	FirmwareVersion uint64
+
+	iakName []byte
+	start   time.Time
+
+	mu      sync.Mutex
+	banks   PCRBanks
+	oiak    string
+	oidevid string
+}
+
+// tpmHashAlgs are the TPM hash algorithms of the PCR banks of a SoftTPM.
+var tpmHashAlgs = map[cpb.Tpm20HashAlgo]tpm2.TPMIAlgHash{
+	cpb.Tpm20HashAlgo_TPM_2_0_HASH_ALGO_SHA1:   tpm2.TPMAlgSHA1,
+	cpb.Tpm20HashAlgo_TPM_2_0_HASH_ALGO_SHA256: tpm2.TPMAlgSHA256,
+	cpb.Tpm20HashAlgo_TPM_2_0_HASH_ALGO_SHA384: tpm2.TPMAlgSHA384,
+}
+
+// NewSoftTPM returns a SoftTPM of the control card id, whose EK, IAK and
+// IDevID certificates are issued by mfg for the control card serial, and
+// whose PCRs are all zero.
+func NewSoftTPM(id *cpb.ControlCardVendorId, mfg *certz.CA) (*SoftTPM, error) {
+	tpm := &SoftTPM{
+		ControlCardID: id,
+		start:         time.Now(),
+		banks:         PCRBanks{},
+	}
+	var err error
+	if tpm.EK, err = rsa.GenerateKey(rand.Reader, 2048); err != nil {
+		return nil, err
+	}
+	if tpm.IAK, err = ecdsa.GenerateKey(elliptic.P384(), rand.Reader); err != nil {
+		return nil, err
+	}
+	if tpm.IDevID, err = ecdsa.GenerateKey(elliptic.P384(), rand.Reader); err != nil {
+		return nil, err
+	}
+	for _, c := range []struct {
+		cert *string
+		key  crypto.Signer
+		cn   string
+		ku   x509.KeyUsage
+		eku  []x509.ExtKeyUsage
+	}{
+		{&tpm.EKCert, tpm.EK, "EK", x509.KeyUsageKeyEncipherment, nil},
+		{&tpm.IAKCert, tpm.IAK, "IAK", x509.KeyUsageDigitalSignature, nil},
+		{&tpm.IDevIDCert, tpm.IDevID, "IDevID", x509.KeyUsageDigitalSignature, []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth}},
+	} {
+		if *c.cert, err = mfgCert(mfg, c.key, c.cn, id.GetControlCardSerial(), c.ku, c.eku); err != nil {
+			return nil, fmt.Errorf("could not issue %s certificate: %w", c.cn, err)
+		}
+	}
+	if tpm.iakName, err = iakName(&tpm.IAK.PublicKey); err != nil {
+		return nil, err
+	}
+	for algo := range tpmHashAlgs {
+		h := hashAlgos[algo]
+		bank := PCRBank{}
+		for i := range int32(pcrCount) {
+			bank[i] = make([]byte, h.Size())
+		}
+		tpm.banks[algo] = bank
+	}
+	return tpm, nil
+}
+
+// mfgCert returns the PEM chain of a certificate issued by mfg for key.
+func mfgCert(mfg *certz.CA, key crypto.Signer, cn, serial string, ku x509.KeyUsage, eku []x509.ExtKeyUsage) (string, error) {
+	sn, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 127))
+	if err != nil {
+		return "", err
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: sn,
+		Subject: pkix.Name{
+			CommonName:   cn,
+			SerialNumber: serial,
+			Organization: []string{"OpenconfigFeatureProfiles"},
+		},
+		NotBefore:   time.Now().Add(-time.Hour),
+		NotAfter:    mfg.Cert.NotAfter,
+		KeyUsage:    ku,
+		ExtKeyUsage: eku,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, mfg.Cert, key.Public(), mfg.Key)
+	if err != nil {
+		return "", err
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return "", err
+	}
+	chain := []*x509.Certificate{cert}
+	for c := mfg; c.Parent != nil; c = c.Parent {
+		chain = append(chain, c.Cert)
+	}
+	return string(certz.CertPEM(chain...)), nil
+}
+
+// iakName returns the TPM name of the IAK, a restricted P-384 ECDSA signing
+// key.
+func iakName(pub *ecdsa.PublicKey) ([]byte, error) {
+	k, err := pub.ECDH()
+	if err != nil {
+		return nil, err
+	}
+	// The uncompressed point is 0x04 || X || Y.
+	point := k.Bytes()
+	size := (len(point) - 1) / 2
+	public := &tpm2.TPMTPublic{
+		Type:    tpm2.TPMAlgECC,
+		NameAlg: tpm2.TPMAlgSHA384,
+		ObjectAttributes: tpm2.TPMAObject{
+			FixedTPM:            true,
+			FixedParent:         true,
+			SensitiveDataOrigin: true,
+			UserWithAuth:        true,
+			AdminWithPolicy:     true,
+			Restricted:          true,
+			SignEncrypt:         true,
+		},
+		Parameters: tpm2.NewTPMUPublicParms(tpm2.TPMAlgECC, &tpm2.TPMSECCParms{
+			Symmetric: tpm2.TPMTSymDefObject{Algorithm: tpm2.TPMAlgNull},
+			Scheme: tpm2.TPMTECCScheme{
+				Scheme:  tpm2.TPMAlgECDSA,
+				Details: tpm2.NewTPMUAsymScheme(tpm2.TPMAlgECDSA, &tpm2.TPMSSigSchemeECDSA{HashAlg: tpm2.TPMAlgSHA384}),
+			},
+			CurveID: tpm2.TPMECCNistP384,
+			KDF:     tpm2.TPMTKDFScheme{Scheme: tpm2.TPMAlgNull},
+		}),
+		Unique: tpm2.NewTPMUPublicID(tpm2.TPMAlgECC, &tpm2.TPMSECCPoint{
+			X: tpm2.TPM2BECCParameter{Buffer: point[1 : 1+size]},
+			Y: tpm2.TPM2BECCParameter{Buffer: point[1+size:]},
+		}),
+	}
+	name, err := tpm2.ObjectName(public)
+	if err != nil {
+		return nil, err
+	}
+	return name.Buffer, nil
+}
+
+// Measure extends PCR index of every bank with the digest of data in the
+// hash of the bank, as firmware measuring data would.
+func (tpm *SoftTPM) Measure(index int32, data []byte) error {
+	if index < 0 || index >= pcrCount {
+		return fmt.Errorf("PCR index %d out of range [0, %d)", index, pcrCount)
+	}
+	tpm.mu.Lock()
+	defer tpm.mu.Unlock()
+	for algo, bank := range tpm.banks {
+		h := hashAlgos[algo]
+		bank[index] = ExtendPCR(h, bank[index], digest(h, data))
+	}
+	return nil
+}
+
+// PCRs returns a copy of the PCR bank of algo.
+func (tpm *SoftTPM) PCRs(algo cpb.Tpm20HashAlgo) PCRBank {
+	tpm.mu.Lock()
+	defer tpm.mu.Unlock()
+	bank := PCRBank{}
+	for i, v := range tpm.banks[algo] {
+		bank[i] = append([]byte(nil), v...)
+	}
+	return bank
+}
+
+// Quote returns a TPMS_ATTEST quote of the PCRs indices of bank algo with
+// nonce, its TPMT_SIGNATURE by the IAK, and the quoted PCR values.
+func (tpm *SoftTPM) Quote(nonce []byte, algo cpb.Tpm20HashAlgo, indices []int32) (quoted, signature []byte, values PCRBank, err error) {
+	bank, ok := tpm.banks[algo]
+	if !ok {
+		return nil, nil, nil, fmt.Errorf("no PCR bank of %v", algo)
+	}
+	bitmap, err := pcrSelect(indices)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	tpm.mu.Lock()
+	values = PCRBank{}
+	for _, i := range pcrIndices(bitmap) {
+		values[i] = append([]byte(nil), bank[i]...)
+	}
+	tpm.mu.Unlock()
+
+	attest := tpm2.TPMSAttest{
+		Magic:           tpm2.TPMGeneratedValue,
+		Type:            tpm2.TPMSTAttestQuote,
+		QualifiedSigner: tpm2.TPM2BName{Buffer: tpm.iakName},
+		ExtraData:       tpm2.TPM2BData{Buffer: nonce},
+		ClockInfo: tpm2.TPMSClockInfo{
+			Clock: uint64(time.Since(tpm.start).Milliseconds()),
+			Safe:  true,
+		},
+		FirmwareVersion: tpm.FirmwareVersion,
+		Attested: tpm2.NewTPMUAttest(tpm2.TPMSTAttestQuote, &tpm2.TPMSQuoteInfo{
+			PCRSelect: tpm2.TPMLPCRSelection{
+				PCRSelections: []tpm2.TPMSPCRSelection{{Hash: tpmHashAlgs[algo], PCRSelect: bitmap}},
+			},
+			PCRDigest: tpm2.TPM2BDigest{Buffer: pcrDigest(crypto.SHA384, values, pcrIndices(bitmap))},
+		}),
+	}
+	quoted = tpm2.Marshal(&attest)
+	r, s, err := ecdsa.Sign(rand.Reader, tpm.IAK, digest(crypto.SHA384, quoted))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	size := (tpm.IAK.Curve.Params().BitSize + 7) / 8
+	signature = tpm2.Marshal(&tpm2.TPMTSignature{
+		SigAlg: tpm2.TPMAlgECDSA,
+		Signature: tpm2.NewTPMUSignature(tpm2.TPMAlgECDSA, &tpm2.TPMSSignatureECC{
+			Hash:       tpm2.TPMAlgSHA384,
+			SignatureR: tpm2.TPM2BECCParameter{Buffer: r.FillBytes(make([]byte, size))},
+			SignatureS: tpm2.TPM2BECCParameter{Buffer: s.FillBytes(make([]byte, size))},
+		}),
+	})
+	return quoted, signature, values, nil
+}
+
+// checkOwnerCerts checks that the PEM owner IAK and IDevID certificates
+// certify the IAK and IDevID keys. An empty oidevid is not checked.
+func (tpm *SoftTPM) checkOwnerCerts(oiak, oidevid string) error {
+	certs, err := parseChain(oiak)
+	if err != nil {
+		return fmt.Errorf("could not parse owner IAK certificate: %w", err)
+	}
+	if !certifies(certs[0], tpm.IAK) {
+		return fmt.Errorf("owner IAK certificate does not certify the IAK")
+	}
+	if oidevid == "" {
+		return nil
+	}
+	if certs, err = parseChain(oidevid); err != nil {
+		return fmt.Errorf("could not parse owner IDevID certificate: %w", err)
+	}
+	if !certifies(certs[0], tpm.IDevID) {
+		return fmt.Errorf("owner IDevID certificate does not certify the IDevID key")
+	}
+	return nil
+}
+
+// InstallOwnerCerts installs the PEM owner IAK and IDevID certificates
+// after checking that they certify the IAK and IDevID keys, as a device does
+// on RotateOIakCert. An empty oidevid keeps the installed one.
+func (tpm *SoftTPM) InstallOwnerCerts(oiak, oidevid string) error {
+	if err := tpm.checkOwnerCerts(oiak, oidevid); err != nil {
+		return err
+	}
+	tpm.mu.Lock()
+	defer tpm.mu.Unlock()
+	tpm.oiak = oiak
+	if oidevid != "" {
+		tpm.oidevid = oidevid
+	}
+	return nil
+}
+
+// certifies returns whether cert is issued for key.
+func certifies(cert *x509.Certificate, key *ecdsa.PrivateKey) bool {
+	pub, ok := cert.PublicKey.(*ecdsa.PublicKey)
+	return ok && pub.Equal(&key.PublicKey)
+}
+
+// OwnerCerts returns the installed PEM owner IAK and IDevID certificates.
+func (tpm *SoftTPM) OwnerCerts() (oiak, oidevid string) {
+	tpm.mu.Lock()
+	defer tpm.mu.Unlock()
+	return tpm.oiak, tpm.oidevid
+}
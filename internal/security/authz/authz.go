@@ -22,6 +22,7 @@ import (
 
 	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"os"
 	"testing"
 	"time"
@@ -266,6 +267,10 @@ func Verify(t testing.TB, dut *ondatra.DUTDevice, spiffe *Spiffe, rpc *gnxi.RPC,
 	if hardVerify {
 		opts := []grpc.DialOption{grpc.WithTransportCredentials(credentials.NewTLS(spiffe.TLSConf))}
 		err := rpc.Exec(context.Background(), dut, opts)
+		if errors.Is(err, gnxi.ErrNotExecuted) {
+			t.Logf("The execution of rpc %s is skipped: %v", rpc.Path, err)
+			return
+		}
 		if status.Code(err) != expectedExecErr {
 			if status.Code(err) == codes.Unimplemented {
 				t.Fatalf("The execution of rpc %s is failed due to error %v, please add implementation for the rpc", rpc.Path, err)
@@ -0,0 +1,188 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authz
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/openconfig/featureprofiles/internal/security/gnxi"
+
+	authzpb "github.com/openconfig/gnsi/authz"
+)
+
+// Decision is the decision of a policy for a request, as returned by the
+// Probe RPC, and the rule it was made by.
+type Decision struct {
+	Action authzpb.ProbeResponse_Action
+	// Rule is the name of the matching rule, empty if no rule matched and
+	// the request is denied by default.
+	Rule string
+}
+
+func (d Decision) String() string {
+	if d.Rule == "" {
+		return d.Action.String() + " (default)"
+	}
+	return fmt.Sprintf("%v (%s)", d.Action, d.Rule)
+}
+
+// matchString matches s against a value of a gRPC authorization policy: "*"
+// matches any string, a trailing "*" a prefix, a leading "*" a suffix, and
+// any other value the exact string.
+func matchString(value, s string) bool {
+	switch {
+	case value == "*":
+		return true
+	case strings.HasSuffix(value, "*"):
+		return strings.HasPrefix(s, strings.TrimSuffix(value, "*"))
+	case strings.HasPrefix(value, "*"):
+		return strings.HasSuffix(s, strings.TrimPrefix(value, "*"))
+	}
+	return value == s
+}
+
+// matches reports whether r matches principal user, typically the SPIFFE ID
+// of the client certificate, calling rpc path.  A rule without principals
+// matches any user and one without paths any RPC.  The principal "*" only
+// matches authenticated users, i.e. a non-empty user.
+func (r *Rule) matches(user, path string) bool {
+	if len(r.Source.Principals) > 0 {
+		ok := false
+		for _, p := range r.Source.Principals {
+			if p == "*" && user == "" {
+				continue
+			}
+			if matchString(p, user) {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			return false
+		}
+	}
+	if len(r.Request.Paths) == 0 {
+		return true
+	}
+	for _, p := range r.Request.Paths {
+		if matchString(p, path) {
+			return true
+		}
+	}
+	return false
+}
+
+// Evaluate returns the decision of p for principal user calling rpc path,
+// following the gRPC authorization policy semantics the Probe RPC mirrors:
+// a request matching any deny rule is denied, otherwise one matching any
+// allow rule is permitted, and any other request is denied.
+func (p *AuthorizationPolicy) Evaluate(user, path string) Decision {
+	for i := range p.DenyRules {
+		if p.DenyRules[i].matches(user, path) {
+			return Decision{Action: authzpb.ProbeResponse_ACTION_DENY, Rule: p.DenyRules[i].Name}
+		}
+	}
+	for i := range p.AllowRules {
+		if p.AllowRules[i].matches(user, path) {
+			return Decision{Action: authzpb.ProbeResponse_ACTION_PERMIT, Rule: p.AllowRules[i].Name}
+		}
+	}
+	return Decision{Action: authzpb.ProbeResponse_ACTION_DENY}
+}
+
+// Permits reports whether p permits principal user to call rpc.
+func (p *AuthorizationPolicy) Permits(user string, rpc *gnxi.RPC) bool {
+	return p.Evaluate(user, rpc.Path).Action == authzpb.ProbeResponse_ACTION_PERMIT
+}
+
+// Validate checks that p is a policy a gRPC authorization engine accepts:
+// it has a name and allow rules, its rules have unique names, and its
+// values have wildcards only at their start or end.
+func (p *AuthorizationPolicy) Validate() error {
+	if p.Name == "" {
+		return errors.New("policy has no name")
+	}
+	if len(p.AllowRules) == 0 {
+		return fmt.Errorf("policy %s has no allow rules", p.Name)
+	}
+	names := map[string]bool{}
+	for _, rules := range [][]Rule{p.DenyRules, p.AllowRules} {
+		for _, r := range rules {
+			if r.Name == "" {
+				return fmt.Errorf("policy %s has a rule without name", p.Name)
+			}
+			if names[r.Name] {
+				return fmt.Errorf("policy %s has duplicate rule %s", p.Name, r.Name)
+			}
+			names[r.Name] = true
+			for _, v := range append(append([]string(nil), r.Source.Principals...), r.Request.Paths...) {
+				if len(v) > 1 && strings.Contains(v[1:len(v)-1], "*") {
+					return fmt.Errorf("policy %s rule %s: wildcard inside %q", p.Name, r.Name, v)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// Matrix holds the decisions of a policy for users and RPCs, keyed by user
+// and then by RPC path.
+type Matrix map[string]map[string]Decision
+
+// Matrix returns the decisions of p for every pair of users and rpcs.
+// RPCs with a wildcard path, such as gnxi.RPCs.GnmiAllRPC, are evaluated
+// with their path taken literally, as Probe does.
+func (p *AuthorizationPolicy) Matrix(users []string, rpcs []*gnxi.RPC) Matrix {
+	m := Matrix{}
+	for _, u := range users {
+		m[u] = map[string]Decision{}
+		for _, rpc := range rpcs {
+			m[u][rpc.Path] = p.Evaluate(u, rpc.Path)
+		}
+	}
+	return m
+}
+
+// Diff returns the pairs of users and RPCs whose action differs between m
+// and got, one line each.  Pairs missing from got are reported too.
+func (m Matrix) Diff(got Matrix) []string {
+	var users []string
+	for u := range m {
+		users = append(users, u)
+	}
+	sort.Strings(users)
+	var diffs []string
+	for _, u := range users {
+		var paths []string
+		for path := range m[u] {
+			paths = append(paths, path)
+		}
+		sort.Strings(paths)
+		for _, path := range paths {
+			want := m[u][path]
+			g, ok := got[u][path]
+			switch {
+			case !ok:
+				diffs = append(diffs, fmt.Sprintf("user %s rpc %s: no decision, want %v", u, path, want))
+			case g.Action != want.Action:
+				diffs = append(diffs, fmt.Sprintf("user %s rpc %s: got %v, want %v", u, path, g.Action, want))
+			}
+		}
+	}
+	return diffs
+}
@@ -0,0 +1,138 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authz
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/featureprofiles/internal/security/gnxi"
+
+	authzpb "github.com/openconfig/gnsi/authz"
+)
+
+const (
+	admin = "spiffe://test-abc.foo.bar/xyz/admin"
+	user1 = "spiffe://test-abc.foo.bar/xyz/user1"
+	other = "spiffe://other.example/ops"
+
+	permit = authzpb.ProbeResponse_ACTION_PERMIT
+	deny   = authzpb.ProbeResponse_ACTION_DENY
+)
+
+const testPolicy = `{
+  "name": "policy-gribi-admin",
+  "allow_rules": [
+    {"name": "admin-can-all", "source": {"principals": ["` + admin + `"]}, "request": {}},
+    {"name": "xyz-can-gnmi", "source": {"principals": ["spiffe://test-abc.foo.bar/xyz/*"]}, "request": {"paths": ["/gnmi.gNMI/*"]}},
+    {"name": "everyone-can-get", "source": {"principals": ["*"]}, "request": {"paths": ["*/Get"]}}
+  ],
+  "deny_rules": [
+    {"name": "no-one-can-gribi-modify", "source": {"principals": ["*"]}, "request": {"paths": ["/gribi.gRIBI/Modify"]}}
+  ]
+}`
+
+func TestEvaluate(t *testing.T) {
+	p := &AuthorizationPolicy{}
+	if err := p.Unmarshal(testPolicy); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		user, path string
+		want       Decision
+	}{
+		{admin, "/gnoi.system.System/Reboot", Decision{permit, "admin-can-all"}},
+		{admin, "/gribi.gRIBI/Modify", Decision{deny, "no-one-can-gribi-modify"}},
+		{user1, "/gnmi.gNMI/Set", Decision{permit, "xyz-can-gnmi"}},
+		{user1, "/gribi.gRIBI/Get", Decision{permit, "everyone-can-get"}},
+		{user1, "/gribi.gRIBI/Flush", Decision{deny, ""}},
+		{other, "/gnmi.gNMI/Set", Decision{deny, ""}},
+		{"", "/gnmi.gNMI/Get", Decision{deny, ""}},
+	}
+	for _, tc := range tests {
+		if got := p.Evaluate(tc.user, tc.path); got != tc.want {
+			t.Errorf("Evaluate(%q, %q) = %v, want %v", tc.user, tc.path, got, tc.want)
+		}
+	}
+	if !p.Permits(user1, gnxi.RPCs.GnmiGet) || p.Permits(user1, gnxi.RPCs.GribiModify) {
+		t.Errorf("Permits(%q) got gNMI Get %v and gRIBI Modify %v, want true and false", user1, p.Permits(user1, gnxi.RPCs.GnmiGet), p.Permits(user1, gnxi.RPCs.GribiModify))
+	}
+}
+
+func TestValidate(t *testing.T) {
+	p := &AuthorizationPolicy{}
+	if err := p.Unmarshal(testPolicy); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Validate(); err != nil {
+		t.Errorf("Validate() failed: %v", err)
+	}
+	tests := []struct {
+		desc    string
+		p       *AuthorizationPolicy
+		wantErr string
+	}{{
+		desc:    "no allow rules",
+		p:       &AuthorizationPolicy{Name: "p", DenyRules: []Rule{{Name: "r"}}},
+		wantErr: "no allow rules",
+	}, {
+		desc:    "duplicate rule",
+		p:       &AuthorizationPolicy{Name: "p", AllowRules: []Rule{{Name: "r"}}, DenyRules: []Rule{{Name: "r"}}},
+		wantErr: "duplicate rule",
+	}, {
+		desc: "inner wildcard",
+		p: func() *AuthorizationPolicy {
+			p := NewAuthorizationPolicy("p")
+			p.AddAllowRules("r", []string{"*"}, []*gnxi.RPC{{Path: "/gnmi.*/Get"}})
+			return p
+		}(),
+		wantErr: "wildcard inside",
+	}}
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			if err := tc.p.Validate(); err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("Validate() got error %v, want %q", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestMatrix(t *testing.T) {
+	p := NewAuthorizationPolicy("p")
+	p.AddAllowRules("gnmi", []string{user1}, []*gnxi.RPC{gnxi.RPCs.GnmiAllRPC})
+	p.AddDenyRules("no-set", []string{user1}, []*gnxi.RPC{gnxi.RPCs.GnmiSet})
+	rpcs := []*gnxi.RPC{gnxi.RPCs.GnmiGet, gnxi.RPCs.GnmiSet}
+	m := p.Matrix([]string{user1, other}, rpcs)
+	want := Matrix{
+		user1: {"/gnmi.gNMI/Get": {permit, "gnmi"}, "/gnmi.gNMI/Set": {deny, "no-set"}},
+		other: {"/gnmi.gNMI/Get": {deny, ""}, "/gnmi.gNMI/Set": {deny, ""}},
+	}
+	if diff := cmp.Diff(want, m); diff != "" {
+		t.Errorf("Matrix() diff (-want +got):\n%s", diff)
+	}
+
+	got := Matrix{
+		user1: {"/gnmi.gNMI/Get": {Action: permit}, "/gnmi.gNMI/Set": {Action: permit}},
+		other: {"/gnmi.gNMI/Get": {Action: deny}},
+	}
+	wantDiffs := []string{
+		"user spiffe://other.example/ops rpc /gnmi.gNMI/Set: no decision, want ACTION_DENY (default)",
+		"user spiffe://test-abc.foo.bar/xyz/user1 rpc /gnmi.gNMI/Set: got ACTION_PERMIT, want ACTION_DENY (no-set)",
+	}
+	if diff := cmp.Diff(wantDiffs, m.Diff(got)); diff != "" {
+		t.Errorf("Diff() diff (-want +got):\n%s", diff)
+	}
+}
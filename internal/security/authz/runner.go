@@ -0,0 +1,199 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authz
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openconfig/featureprofiles/internal/security/gnxi"
+	"github.com/openconfig/featureprofiles/internal/security/svid"
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/gnmi"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+
+	authzpb "github.com/openconfig/gnsi/authz"
+)
+
+// execTimeout bounds the execution of a single RPC by the matrix runner.
+const execTimeout = 2 * time.Minute
+
+// NewSpiffe generates an SVID for SPIFFE ID id, signed by caCert and caKey,
+// and returns it with a TLS config that trusts roots.
+func NewSpiffe(id string, caCert *x509.Certificate, caKey any, roots *x509.CertPool, keyAlgo x509.PublicKeyAlgorithm) (*Spiffe, error) {
+	cert, err := svid.GenSVID("", id, 300, caCert, caKey, keyAlgo)
+	if err != nil {
+		return nil, fmt.Errorf("could not generate svid for %s: %w", id, err)
+	}
+	return &Spiffe{
+		ID: id,
+		TLSConf: &tls.Config{
+			Certificates: []tls.Certificate{*cert},
+			RootCAs:      roots,
+		},
+	}, nil
+}
+
+// Outcome is the result of probing and executing an RPC as a user.
+type Outcome struct {
+	User string
+	Path string
+	// Want is the decision of the policy.
+	Want Decision
+	// Probe is the action returned by the Probe RPC.
+	Probe authzpb.ProbeResponse_Action
+	// Exec is the action the DUT took when the RPC was executed with the
+	// SVID of the user, ACTION_UNSPECIFIED if it was not executed.
+	Exec authzpb.ProbeResponse_Action
+	// ExecErr is why the RPC was not executed or why its result shows
+	// neither a permit nor a deny.
+	ExecErr error
+}
+
+// execAction returns the action an execution error shows: a permit if the
+// RPC passed authorization, whether or not the service then rejected the
+// request, and a deny if the RPC was denied.  Any other error is returned.
+func execAction(err error) (authzpb.ProbeResponse_Action, error) {
+	switch {
+	case err == nil:
+		return authzpb.ProbeResponse_ACTION_PERMIT, nil
+	case status.Code(err) == codes.PermissionDenied:
+		return authzpb.ProbeResponse_ACTION_DENY, nil
+	}
+	return authzpb.ProbeResponse_ACTION_UNSPECIFIED, err
+}
+
+// RunMatrix probes and executes every pair of users and rpcs on dut, with the
+// installed policy expected to be p.  RPCs with a wildcard path are probed
+// but not executed.  The exec functions of gnxi send requests the services
+// reject, except GnmiSet, which replaces the hostname of dut.  They return
+// ErrNotExecuted without sending any request for RPCs that such a request
+// would still change and for generated exec functions not yet reviewed.
+func RunMatrix(ctx context.Context, dut *ondatra.DUTDevice, p *AuthorizationPolicy, users []*Spiffe, rpcs []*gnxi.RPC) ([]*Outcome, error) {
+	gnsiC, err := dut.RawAPIs().BindingDUT().DialGNSI(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not connect gnsi: %w", err)
+	}
+	var outcomes []*Outcome
+	for _, u := range users {
+		opts := []grpc.DialOption{grpc.WithTransportCredentials(credentials.NewTLS(u.TLSConf))}
+		for _, rpc := range rpcs {
+			o := &Outcome{User: u.ID, Path: rpc.Path, Want: p.Evaluate(u.ID, rpc.Path)}
+			resp, err := gnsiC.Authz().Probe(ctx, &authzpb.ProbeRequest{User: u.ID, Rpc: rpc.Path})
+			if err != nil {
+				return nil, fmt.Errorf("probe of rpc %s for user %s failed: %w", rpc.Path, u.ID, err)
+			}
+			o.Probe = resp.GetAction()
+			if rpc.IsWildcard() {
+				o.ExecErr = gnxi.ErrNotExecuted
+			} else {
+				execCtx, cancel := context.WithTimeout(ctx, execTimeout)
+				o.Exec, o.ExecErr = execAction(rpc.Exec(execCtx, dut, opts))
+				cancel()
+			}
+			outcomes = append(outcomes, o)
+		}
+	}
+	return outcomes, nil
+}
+
+// CheckOutcomes returns the outcomes whose probe or execution disagrees with
+// the policy, and the RPCs whose execution failed otherwise, one line each.
+// RPCs that were not executed are not reported.
+func CheckOutcomes(outcomes []*Outcome) []string {
+	var diffs []string
+	for _, o := range outcomes {
+		if o.Probe != o.Want.Action {
+			diffs = append(diffs, fmt.Sprintf("user %s rpc %s: probe got %v, want %v", o.User, o.Path, o.Probe, o.Want))
+		}
+		switch {
+		case o.skipped():
+		case o.ExecErr != nil:
+			diffs = append(diffs, fmt.Sprintf("user %s rpc %s: exec failed: %v", o.User, o.Path, o.ExecErr))
+		case o.Exec != o.Want.Action:
+			diffs = append(diffs, fmt.Sprintf("user %s rpc %s: exec got %v, want %v", o.User, o.Path, o.Exec, o.Want))
+		}
+	}
+	return diffs
+}
+
+// skipped reports whether the RPC of o was not executed.
+func (o *Outcome) skipped() bool {
+	return errors.Is(o.ExecErr, gnxi.ErrNotExecuted)
+}
+
+// Skipped returns the outcomes whose RPC was not executed, one line each, so
+// that only their probe is evidence of the policy.
+func Skipped(outcomes []*Outcome) []string {
+	var lines []string
+	for _, o := range outcomes {
+		if o.skipped() {
+			lines = append(lines, fmt.Sprintf("user %s rpc %s: exec skipped: %v", o.User, o.Path, o.ExecErr))
+		}
+	}
+	return lines
+}
+
+// Summary formats outcomes as a table, one row per user and RPC, to keep as
+// evidence of the run.
+func Summary(outcomes []*Outcome) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%-50s %-70s %-40s %-15s %s\n", "USER", "RPC", "POLICY", "PROBE", "EXEC")
+	for _, o := range outcomes {
+		exec := o.Exec.String()
+		switch {
+		case o.skipped():
+			exec = "SKIPPED: " + o.ExecErr.Error()
+		case o.ExecErr != nil:
+			exec = o.ExecErr.Error()
+		}
+		fmt.Fprintf(&b, "%-50s %-70s %-40v %-15v %s\n", o.User, o.Path, o.Want, o.Probe, exec)
+	}
+	return b.String()
+}
+
+// VerifyMatrix runs the matrix of users and rpcs on dut and checks that both
+// the Probe results and the executions of the RPCs match policy p, which
+// must be installed on dut.  The outcome of every pair and the executions
+// skipped are logged, and the hostname GnmiSet replaces is restored.
+func VerifyMatrix(t testing.TB, dut *ondatra.DUTDevice, p *AuthorizationPolicy, users []*Spiffe, rpcs []*gnxi.RPC) {
+	t.Helper()
+	hostname := gnmi.OC().System().Hostname().Config()
+	if name, ok := gnmi.Lookup(t, dut, hostname).Val(); ok {
+		defer gnmi.Replace(t, dut, hostname, name)
+	} else {
+		defer gnmi.Delete(t, dut, hostname)
+	}
+	outcomes, err := RunMatrix(context.Background(), dut, p, users, rpcs)
+	if err != nil {
+		t.Fatalf("Authz matrix of policy %s on dut %s failed: %v", p.Name, dut.Name(), err)
+	}
+	t.Logf("Authz matrix of policy %s on dut %s:\n%s", p.Name, dut.Name(), Summary(outcomes))
+	if skipped := Skipped(outcomes); len(skipped) > 0 {
+		t.Logf("%d of %d executions were skipped:\n%s", len(skipped), len(outcomes), strings.Join(skipped, "\n"))
+	}
+	for _, d := range CheckOutcomes(outcomes) {
+		t.Error(d)
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authz
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/featureprofiles/internal/security/gnxi"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	authzpb "github.com/openconfig/gnsi/authz"
+)
+
+func TestExecAction(t *testing.T) {
+	unavailable := status.Error(codes.Unavailable, "connection refused")
+	tests := []struct {
+		err     error
+		want    authzpb.ProbeResponse_Action
+		wantErr error
+	}{
+		{nil, permit, nil},
+		{status.Error(codes.PermissionDenied, "denied"), deny, nil},
+		{unavailable, authzpb.ProbeResponse_ACTION_UNSPECIFIED, unavailable},
+	}
+	for _, tc := range tests {
+		got, err := execAction(tc.err)
+		if got != tc.want || err != tc.wantErr {
+			t.Errorf("execAction(%v) = %v, %v, want %v, %v", tc.err, got, err, tc.want, tc.wantErr)
+		}
+	}
+}
+
+func TestCheckOutcomes(t *testing.T) {
+	outcomes := []*Outcome{
+		{User: user1, Path: "/gnmi.gNMI/Get", Want: Decision{permit, "gnmi"}, Probe: permit, Exec: permit},
+		{User: user1, Path: "/gnmi.gNMI/Set", Want: Decision{deny, "no-set"}, Probe: deny, Exec: permit},
+		{User: user1, Path: "/gnmi.gNMI/*", Want: Decision{permit, "gnmi"}, Probe: deny, ExecErr: gnxi.ErrNotExecuted},
+		{User: other, Path: "/gribi.gRIBI/Get", Want: Decision{Action: deny}, Probe: deny, ExecErr: errors.New("unavailable")},
+	}
+	want := []string{
+		"user spiffe://test-abc.foo.bar/xyz/user1 rpc /gnmi.gNMI/Set: exec got ACTION_PERMIT, want ACTION_DENY (no-set)",
+		"user spiffe://test-abc.foo.bar/xyz/user1 rpc /gnmi.gNMI/*: probe got ACTION_DENY, want ACTION_PERMIT (gnmi)",
+		"user spiffe://other.example/ops rpc /gribi.gRIBI/Get: exec failed: unavailable",
+	}
+	if diff := cmp.Diff(want, CheckOutcomes(outcomes)); diff != "" {
+		t.Errorf("CheckOutcomes() diff (-want +got):\n%s", diff)
+	}
+	s := Summary(outcomes)
+	if got := strings.Count(s, "\n"); got != len(outcomes)+1 {
+		t.Errorf("Summary() has %d lines, want %d:\n%s", got, len(outcomes)+1, s)
+	}
+	if want := "SKIPPED: " + gnxi.ErrNotExecuted.Error(); !strings.Contains(s, want) {
+		t.Errorf("Summary() does not contain %q:\n%s", want, s)
+	}
+	wantSkipped := []string{"user spiffe://test-abc.foo.bar/xyz/user1 rpc /gnmi.gNMI/*: exec skipped: " + gnxi.ErrNotExecuted.Error()}
+	if diff := cmp.Diff(wantSkipped, Skipped(outcomes)); diff != "" {
+		t.Errorf("Skipped() diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestSortedRPCs(t *testing.T) {
+	rpcs := gnxi.SortedRPCs()
+	if len(rpcs) != len(gnxi.RPCMAP) {
+		t.Fatalf("SortedRPCs() returned %d RPCs, want %d", len(rpcs), len(gnxi.RPCMAP))
+	}
+	for _, rpc := range rpcs {
+		if rpc.Exec == nil {
+			t.Errorf("RPC %s has no exec function", rpc.Path)
+		}
+	}
+}
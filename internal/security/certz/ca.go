@@ -0,0 +1,264 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package certz provides helper APIs to simplify writing certz test cases: a
+// local CA hierarchy minting server and client certificates and CRLs, the
+// certz entities carrying them, Rotate flows that finalize or abort, and a
+// check of the certificate the DUT serves.
+//
+//	root, _ := certz.NewRootCA("root", x509.ECDSA)
+//	ica, _ := root.NewIntermediate("ica", x509.ECDSA)
+//	server, _ := ica.NewServer("dut", []string{dut.Name()}, x509.ECDSA)
+//	client, _ := ica.NewClient("client", "spiffe://test/client", x509.ECDSA)
+//	chain, _ := certz.ChainEntity("v2", server)
+//	certz.Rotate(t, dut, "profile", func() error {
+//		return certz.CheckServed(ctx, dut, client.TLSConfig(root.Pool(), dut.Name()), server.Cert)
+//	}, chain, certz.TrustBundleEntity("v2", root))
+package certz
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"time"
+
+	"github.com/openconfig/featureprofiles/internal/security/svid"
+)
+
+// validity is how long the certificates of the hierarchy are valid.
+const validity = 365 * 24 * time.Hour
+
+// CA is a certificate authority of a local hierarchy.
+type CA struct {
+	Cert *x509.Certificate
+	Key  crypto.Signer
+	// Parent is the CA that issued Cert, nil for a root.
+	Parent *CA
+
+	crlNumber int64
+}
+
+// Leaf is a server or client certificate issued by a CA.
+type Leaf struct {
+	Cert *x509.Certificate
+	Key  crypto.Signer
+	// Chain holds the issuers of Cert up to and excluding the root.
+	Chain []*x509.Certificate
+	// Issuer is the CA that issued Cert.
+	Issuer *CA
+}
+
+func generateKey(algo x509.PublicKeyAlgorithm) (crypto.Signer, error) {
+	switch algo {
+	case x509.RSA:
+		return rsa.GenerateKey(rand.Reader, 2048)
+	case x509.ECDSA:
+		return ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	}
+	return nil, fmt.Errorf("key algorithm %v is not supported", algo)
+}
+
+func serialNumber() (*big.Int, error) {
+	return rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 127))
+}
+
+func template(cn string) (*x509.Certificate, error) {
+	serial, err := serialNumber()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	return &x509.Certificate{
+		SerialNumber: serial,
+		Subject: pkix.Name{
+			CommonName:   cn,
+			Organization: []string{"OpenconfigFeatureProfiles"},
+			Country:      []string{"US"},
+		},
+		NotBefore: now.Add(-time.Hour),
+		NotAfter:  now.Add(validity),
+	}, nil
+}
+
+// sign issues tmpl for key with parent, or self-signs it if parent is nil.
+func sign(tmpl *x509.Certificate, key crypto.Signer, parent *CA) (*x509.Certificate, error) {
+	issuer, signer := tmpl, key
+	if parent != nil {
+		issuer, signer = parent.Cert, parent.Key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, issuer, key.Public(), signer)
+	if err != nil {
+		return nil, err
+	}
+	return x509.ParseCertificate(der)
+}
+
+func newCA(cn string, algo x509.PublicKeyAlgorithm, parent *CA) (*CA, error) {
+	key, err := generateKey(algo)
+	if err != nil {
+		return nil, err
+	}
+	tmpl, err := template(cn)
+	if err != nil {
+		return nil, err
+	}
+	tmpl.IsCA = true
+	tmpl.BasicConstraintsValid = true
+	tmpl.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature
+	cert, err := sign(tmpl, key, parent)
+	if err != nil {
+		return nil, fmt.Errorf("could not create CA %s: %w", cn, err)
+	}
+	return &CA{Cert: cert, Key: key, Parent: parent}, nil
+}
+
+// NewRootCA returns a self-signed root CA with a key of algorithm algo,
+// x509.RSA or x509.ECDSA.
+func NewRootCA(cn string, algo x509.PublicKeyAlgorithm) (*CA, error) {
+	return newCA(cn, algo, nil)
+}
+
+// NewIntermediate returns an intermediate CA issued by ca.
+func (ca *CA) NewIntermediate(cn string, algo x509.PublicKeyAlgorithm) (*CA, error) {
+	return newCA(cn, algo, ca)
+}
+
+// Root returns the root of the hierarchy of ca.
+func (ca *CA) Root() *CA {
+	for ca.Parent != nil {
+		ca = ca.Parent
+	}
+	return ca
+}
+
+// chain returns ca and its issuers up to and excluding the root.
+func (ca *CA) chain() []*x509.Certificate {
+	var chain []*x509.Certificate
+	for c := ca; c.Parent != nil; c = c.Parent {
+		chain = append(chain, c.Cert)
+	}
+	return chain
+}
+
+// Pool returns a pool holding the root of the hierarchy of ca.
+func (ca *CA) Pool() *x509.CertPool {
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.Root().Cert)
+	return pool
+}
+
+// NewServer returns a server certificate issued by ca for sans, which are
+// DNS names or IP addresses.
+func (ca *CA) NewServer(cn string, sans []string, algo x509.PublicKeyAlgorithm) (*Leaf, error) {
+	key, err := generateKey(algo)
+	if err != nil {
+		return nil, err
+	}
+	tmpl, err := template(cn)
+	if err != nil {
+		return nil, err
+	}
+	tmpl.KeyUsage = x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment
+	tmpl.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}
+	for _, san := range sans {
+		if ip := net.ParseIP(san); ip != nil {
+			tmpl.IPAddresses = append(tmpl.IPAddresses, ip)
+		} else {
+			tmpl.DNSNames = append(tmpl.DNSNames, san)
+		}
+	}
+	cert, err := sign(tmpl, key, ca)
+	if err != nil {
+		return nil, fmt.Errorf("could not create server certificate %s: %w", cn, err)
+	}
+	return &Leaf{Cert: cert, Key: key, Chain: ca.chain(), Issuer: ca}, nil
+}
+
+// NewClient returns a client SVID for spiffeID issued by ca.
+func (ca *CA) NewClient(cn, spiffeID string, algo x509.PublicKeyAlgorithm) (*Leaf, error) {
+	cert, err := svid.GenSVID(cn, spiffeID, int(validity.Hours()/24), ca.Cert, ca.Key, algo)
+	if err != nil {
+		return nil, fmt.Errorf("could not create client certificate %s: %w", spiffeID, err)
+	}
+	return &Leaf{Cert: cert.Leaf, Key: cert.PrivateKey.(crypto.Signer), Chain: ca.chain(), Issuer: ca}, nil
+}
+
+// TLSCertificate returns l with its chain as a TLS certificate.
+func (l *Leaf) TLSCertificate() tls.Certificate {
+	c := tls.Certificate{Certificate: [][]byte{l.Cert.Raw}, PrivateKey: l.Key, Leaf: l.Cert}
+	for _, ic := range l.Chain {
+		c.Certificate = append(c.Certificate, ic.Raw)
+	}
+	return c
+}
+
+// TLSConfig returns the config of a client presenting l that trusts roots
+// and expects the server to be serverName.
+func (l *Leaf) TLSConfig(roots *x509.CertPool, serverName string) *tls.Config {
+	return &tls.Config{
+		Certificates: []tls.Certificate{l.TLSCertificate()},
+		RootCAs:      roots,
+		ServerName:   serverName,
+	}
+}
+
+// NewCRL returns a DER CRL of ca revoking certs, with a number higher than
+// that of the CRLs ca issued before.
+func (ca *CA) NewCRL(certs ...*x509.Certificate) ([]byte, error) {
+	ca.crlNumber++
+	now := time.Now()
+	tmpl := &x509.RevocationList{
+		Number:     big.NewInt(ca.crlNumber),
+		ThisUpdate: now.Add(-time.Hour),
+		NextUpdate: now.Add(validity),
+	}
+	for _, c := range certs {
+		tmpl.RevokedCertificateEntries = append(tmpl.RevokedCertificateEntries, x509.RevocationListEntry{
+			SerialNumber:   c.SerialNumber,
+			RevocationTime: now.Add(-time.Minute),
+		})
+	}
+	crl, err := x509.CreateRevocationList(rand.Reader, tmpl, ca.Cert, ca.Key)
+	if err != nil {
+		return nil, fmt.Errorf("could not create CRL of %s: %w", ca.Cert.Subject.CommonName, err)
+	}
+	return crl, nil
+}
+
+// CertPEM returns certs PEM encoded.
+func CertPEM(certs ...*x509.Certificate) []byte {
+	var b []byte
+	for _, c := range certs {
+		b = append(b, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: c.Raw})...)
+	}
+	return b
+}
+
+// KeyPEM returns key PEM encoded in PKCS #8.
+func KeyPEM(key crypto.Signer) ([]byte, error) {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), nil
+}
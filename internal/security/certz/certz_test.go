@@ -0,0 +1,264 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package certz
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"io"
+	"testing"
+
+	"go.mozilla.org/pkcs7"
+
+	certzpb "github.com/openconfig/gnsi/certz"
+)
+
+func mustHierarchy(t *testing.T, algo x509.PublicKeyAlgorithm) (*CA, *CA) {
+	t.Helper()
+	root, err := NewRootCA("root", algo)
+	if err != nil {
+		t.Fatalf("NewRootCA() failed: %v", err)
+	}
+	ica, err := root.NewIntermediate("ica", algo)
+	if err != nil {
+		t.Fatalf("NewIntermediate() failed: %v", err)
+	}
+	return root, ica
+}
+
+// handshake serves server over TLS, requiring a client certificate issued by
+// the hierarchy of clientCA, and connects to it with client.
+func handshake(t *testing.T, server *Leaf, clientCA *CA, client *tls.Config) error {
+	t.Helper()
+	l, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{
+		Certificates: []tls.Certificate{server.TLSCertificate()},
+		ClientCAs:    clientCA.Pool(),
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		conn.(*tls.Conn).Handshake()
+		io.Copy(io.Discard, conn)
+	}()
+	conn, err := tls.Dial("tcp", l.Addr().String(), client)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	// The server verifies the client certificate after the client finishes
+	// its handshake, so read to learn whether it was accepted.
+	conn.Write([]byte("ping"))
+	conn.CloseWrite()
+	_, err = conn.Read(make([]byte, 1))
+	if err == io.EOF {
+		return nil
+	}
+	return err
+}
+
+func TestHierarchy(t *testing.T) {
+	for _, algo := range []x509.PublicKeyAlgorithm{x509.RSA, x509.ECDSA} {
+		t.Run(algo.String(), func(t *testing.T) {
+			root, ica := mustHierarchy(t, algo)
+			server, err := ica.NewServer("dut", []string{"dut.test", "127.0.0.1"}, algo)
+			if err != nil {
+				t.Fatalf("NewServer() failed: %v", err)
+			}
+			client, err := ica.NewClient("client", "spiffe://test/client", algo)
+			if err != nil {
+				t.Fatalf("NewClient() failed: %v", err)
+			}
+			if ica.Root() != root {
+				t.Errorf("Root() = %v, want %v", ica.Root().Cert.Subject, root.Cert.Subject)
+			}
+			for _, l := range []*Leaf{server, client} {
+				inter := x509.NewCertPool()
+				for _, c := range l.Chain {
+					inter.AddCert(c)
+				}
+				_, err := l.Cert.Verify(x509.VerifyOptions{
+					Roots:         root.Pool(),
+					Intermediates: inter,
+					KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+				})
+				if err != nil {
+					t.Errorf("Verify() of %s failed: %v", l.Cert.Subject.CommonName, err)
+				}
+			}
+
+			if err := handshake(t, server, root, client.TLSConfig(root.Pool(), "127.0.0.1")); err != nil {
+				t.Errorf("Handshake with a trusted client failed: %v", err)
+			}
+			other, err := NewRootCA("other", algo)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := handshake(t, server, root, client.TLSConfig(other.Pool(), "127.0.0.1")); err == nil {
+				t.Errorf("Handshake with a client not trusting the server succeeded, want error")
+			}
+			if err := handshake(t, server, other, client.TLSConfig(root.Pool(), "127.0.0.1")); err == nil {
+				t.Errorf("Handshake with an untrusted client succeeded, want error")
+			}
+		})
+	}
+}
+
+func TestCRL(t *testing.T) {
+	_, ica := mustHierarchy(t, x509.ECDSA)
+	server, err := ica.NewServer("dut", []string{"dut.test"}, x509.ECDSA)
+	if err != nil {
+		t.Fatal(err)
+	}
+	first, err := ica.NewCRL()
+	if err != nil {
+		t.Fatalf("NewCRL() failed: %v", err)
+	}
+	second, err := ica.NewCRL(server.Cert)
+	if err != nil {
+		t.Fatalf("NewCRL() failed: %v", err)
+	}
+	crl1, err := x509.ParseRevocationList(first)
+	if err != nil {
+		t.Fatal(err)
+	}
+	crl2, err := x509.ParseRevocationList(second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := crl2.CheckSignatureFrom(ica.Cert); err != nil {
+		t.Errorf("CRL signature check failed: %v", err)
+	}
+	if crl2.Number.Cmp(crl1.Number) <= 0 {
+		t.Errorf("CRL number %v is not higher than previous %v", crl2.Number, crl1.Number)
+	}
+	if got := crl2.RevokedCertificateEntries; len(got) != 1 || got[0].SerialNumber.Cmp(server.Cert.SerialNumber) != 0 {
+		t.Errorf("CRL revokes %v, want serial %x", got, server.Cert.SerialNumber)
+	}
+
+	e, err := CRLEntity("v1", first, second)
+	if err != nil {
+		t.Fatalf("CRLEntity() failed: %v", err)
+	}
+	crls := e.GetCertificateRevocationListBundle().GetCertificateRevocationLists()
+	if len(crls) != 2 || crls[0].GetId() == crls[1].GetId() {
+		t.Errorf("CRLEntity() = %v, want 2 CRLs with distinct ids", crls)
+	}
+	if _, err := CRLEntity("v1", []byte("garbage")); err == nil {
+		t.Errorf("CRLEntity() of an invalid CRL succeeded, want error")
+	}
+}
+
+func parsePEM(t *testing.T, b []byte) *x509.Certificate {
+	t.Helper()
+	block, _ := pem.Decode(b)
+	if block == nil {
+		t.Fatalf("no PEM block in %q", b)
+	}
+	c, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestEntities(t *testing.T) {
+	root, ica := mustHierarchy(t, x509.RSA)
+	server, err := ica.NewServer("dut", []string{"dut.test"}, x509.ECDSA)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := NewRootCA("other", x509.ECDSA)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	e, err := ChainEntity("v1", server)
+	if err != nil {
+		t.Fatalf("ChainEntity() failed: %v", err)
+	}
+	if e.GetVersion() != "v1" || e.GetCreatedOn() == 0 {
+		t.Errorf("ChainEntity() version %q created on %d, want v1 and a time", e.GetVersion(), e.GetCreatedOn())
+	}
+	chain := e.GetCertificateChain()
+	if got := parsePEM(t, chain.GetCertificate().GetRawCertificate()); !got.Equal(server.Cert) {
+		t.Errorf("ChainEntity() certificate %v, want %v", got.Subject, server.Cert.Subject)
+	}
+	if len(chain.GetCertificate().GetRawPrivateKey()) == 0 {
+		t.Errorf("ChainEntity() has no private key")
+	}
+	if got := parsePEM(t, chain.GetParent().GetCertificate().GetRawCertificate()); !got.Equal(ica.Cert) {
+		t.Errorf("ChainEntity() parent %v, want %v", got.Subject, ica.Cert.Subject)
+	}
+	if chain.GetParent().GetParent() != nil {
+		t.Errorf("ChainEntity() includes the root")
+	}
+
+	bundle := TrustBundleEntity("v1", ica, other).GetTrustBundle()
+	if got := parsePEM(t, bundle.GetCertificate().GetRawCertificate()); !got.Equal(root.Cert) {
+		t.Errorf("TrustBundleEntity() first root %v, want %v", got.Subject, root.Cert.Subject)
+	}
+	if got := parsePEM(t, bundle.GetParent().GetCertificate().GetRawCertificate()); !got.Equal(other.Cert) {
+		t.Errorf("TrustBundleEntity() second root %v, want %v", got.Subject, other.Cert.Subject)
+	}
+
+	e, err = TrustBundlePKCS7Entity("v1", ica, other)
+	if err != nil {
+		t.Fatalf("TrustBundlePKCS7Entity() failed: %v", err)
+	}
+	block, _ := pem.Decode([]byte(e.GetTrustBundlePkcs7().GetPkcs7Block()))
+	if block == nil {
+		t.Fatalf("TrustBundlePKCS7Entity() has no PEM block")
+	}
+	p7, err := pkcs7.Parse(block.Bytes)
+	if err != nil {
+		t.Fatalf("TrustBundlePKCS7Entity() is not PKCS #7: %v", err)
+	}
+	if len(p7.Certificates) != 2 || !p7.Certificates[0].Equal(root.Cert) || !p7.Certificates[1].Equal(other.Cert) {
+		t.Errorf("TrustBundlePKCS7Entity() holds %d certificates, want the 2 roots", len(p7.Certificates))
+	}
+
+	existing := ExistingEntity("v1", "gNxI", certzpb.ExistingEntity_ENTITY_TYPE_TRUST_BUNDLE).GetExistingEntity()
+	if existing.GetSslProfileId() != "gNxI" || existing.GetEntityType() != certzpb.ExistingEntity_ENTITY_TYPE_TRUST_BUNDLE {
+		t.Errorf("ExistingEntity() = %v", existing)
+	}
+}
+
+func TestCaptureConfig(t *testing.T) {
+	root, ica := mustHierarchy(t, x509.ECDSA)
+	server, err := ica.NewServer("dut", []string{"127.0.0.1"}, x509.ECDSA)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := ica.NewClient("client", "spiffe://test/client", x509.ECDSA)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conf, peer := captureConfig(client.TLSConfig(root.Pool(), "127.0.0.1"))
+	if err := handshake(t, server, root, conf); err != nil {
+		t.Fatalf("Handshake failed: %v", err)
+	}
+	if got := peer(); len(got) != 2 || !got[0].Equal(server.Cert) || !got[1].Equal(ica.Cert) {
+		t.Errorf("captureConfig() captured %d certificates, want the server and its issuer", len(got))
+	}
+}
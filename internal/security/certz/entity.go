@@ -0,0 +1,125 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package certz
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"time"
+
+	"go.mozilla.org/pkcs7"
+
+	certzpb "github.com/openconfig/gnsi/certz"
+)
+
+func entity(version string) *certzpb.Entity {
+	return &certzpb.Entity{Version: version, CreatedOn: uint64(time.Now().Unix())}
+}
+
+func pemCertificate(c *x509.Certificate) *certzpb.Certificate {
+	return &certzpb.Certificate{
+		Type:            certzpb.CertificateType_CERTIFICATE_TYPE_X509,
+		Encoding:        certzpb.CertificateEncoding_CERTIFICATE_ENCODING_PEM,
+		CertificateType: &certzpb.Certificate_RawCertificate{RawCertificate: CertPEM(c)},
+	}
+}
+
+// certificateChain returns certs as a chain, the first certificate being
+// the one of the chain and each other the parent of the previous one.
+func certificateChain(certs []*x509.Certificate) *certzpb.CertificateChain {
+	var chain *certzpb.CertificateChain
+	for i := len(certs) - 1; i >= 0; i-- {
+		chain = &certzpb.CertificateChain{Certificate: pemCertificate(certs[i]), Parent: chain}
+	}
+	return chain
+}
+
+// ChainEntity returns the certificate chain entity of server certificate l,
+// with its private key and its intermediate CAs.
+func ChainEntity(version string, l *Leaf) (*certzpb.Entity, error) {
+	key, err := KeyPEM(l.Key)
+	if err != nil {
+		return nil, fmt.Errorf("could not encode the key of %s: %w", l.Cert.Subject.CommonName, err)
+	}
+	chain := certificateChain(append([]*x509.Certificate{l.Cert}, l.Chain...))
+	chain.Certificate.PrivateKeyType = &certzpb.Certificate_RawPrivateKey{RawPrivateKey: key}
+	e := entity(version)
+	e.Entity = &certzpb.Entity_CertificateChain{CertificateChain: chain}
+	return e, nil
+}
+
+func roots(cas []*CA) []*x509.Certificate {
+	var certs []*x509.Certificate
+	for _, ca := range cas {
+		certs = append(certs, ca.Root().Cert)
+	}
+	return certs
+}
+
+// TrustBundleEntity returns the PEM trust bundle entity holding the roots of
+// the hierarchies of cas.
+func TrustBundleEntity(version string, cas ...*CA) *certzpb.Entity {
+	e := entity(version)
+	e.Entity = &certzpb.Entity_TrustBundle{TrustBundle: certificateChain(roots(cas))}
+	return e
+}
+
+// TrustBundlePKCS7Entity returns the PKCS #7 trust bundle entity holding the
+// roots of the hierarchies of cas.
+func TrustBundlePKCS7Entity(version string, cas ...*CA) (*certzpb.Entity, error) {
+	var der []byte
+	for _, c := range roots(cas) {
+		der = append(der, c.Raw...)
+	}
+	p7, err := pkcs7.DegenerateCertificate(der)
+	if err != nil {
+		return nil, fmt.Errorf("could not create PKCS #7 trust bundle: %w", err)
+	}
+	e := entity(version)
+	e.Entity = &certzpb.Entity_TrustBundlePkcs7{TrustBundlePkcs7: &certzpb.TrustBundle{
+		Pkcs7Block: string(pem.EncodeToMemory(&pem.Block{Type: "PKCS7", Bytes: p7})),
+	}}
+	return e, nil
+}
+
+// CRLEntity returns the CRL bundle entity holding DER CRLs, as returned by
+// NewCRL.
+func CRLEntity(version string, crls ...[]byte) (*certzpb.Entity, error) {
+	bundle := &certzpb.CertificateRevocationListBundle{}
+	for _, der := range crls {
+		crl, err := x509.ParseRevocationList(der)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CRL: %w", err)
+		}
+		bundle.CertificateRevocationLists = append(bundle.CertificateRevocationLists, &certzpb.CertificateRevocationList{
+			Type:                      certzpb.CertificateType_CERTIFICATE_TYPE_X509,
+			Encoding:                  certzpb.CertificateEncoding_CERTIFICATE_ENCODING_PEM,
+			CertificateRevocationList: pem.EncodeToMemory(&pem.Block{Type: "X509 CRL", Bytes: der}),
+			Id:                        fmt.Sprintf("%x-%v", crl.AuthorityKeyId, crl.Number),
+		})
+	}
+	e := entity(version)
+	e.Entity = &certzpb.Entity_CertificateRevocationListBundle{CertificateRevocationListBundle: bundle}
+	return e, nil
+}
+
+// ExistingEntity returns an entity reusing the entity of type typ of
+// profile, e.g. the trust bundle of another profile.
+func ExistingEntity(version, profile string, typ certzpb.ExistingEntity_EntityType) *certzpb.Entity {
+	e := entity(version)
+	e.Entity = &certzpb.Entity_ExistingEntity{ExistingEntity: &certzpb.ExistingEntity{SslProfileId: profile, EntityType: typ}}
+	return e
+}
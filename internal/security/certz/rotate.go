@@ -0,0 +1,248 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package certz
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/openconfig/ondatra"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+	certzpb "github.com/openconfig/gnsi/certz"
+)
+
+// Rotation is an ongoing Rotate of the entities of an SSL profile.  The DUT
+// uses the uploaded entities until the rotation is finalized, and rolls them
+// back if the stream ends before.
+type Rotation struct {
+	Profile        string
+	ForceOverwrite bool
+	stream         certzpb.Certz_RotateClient
+}
+
+// StartRotation opens a Rotate stream for profile on dut.
+func StartRotation(ctx context.Context, dut *ondatra.DUTDevice, profile string, forceOverwrite bool) (*Rotation, error) {
+	gnsiC, err := dut.RawAPIs().BindingDUT().DialGNSI(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not connect gnsi: %w", err)
+	}
+	stream, err := gnsiC.Certz().Rotate(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not start a rotate stream: %w", err)
+	}
+	return &Rotation{Profile: profile, ForceOverwrite: forceOverwrite, stream: stream}, nil
+}
+
+func (r *Rotation) send(req *certzpb.RotateCertificateRequest) error {
+	req.SslProfileId = r.Profile
+	req.ForceOverwrite = r.ForceOverwrite
+	return r.stream.Send(req)
+}
+
+// Upload uploads entities and waits for the DUT to accept them.
+func (r *Rotation) Upload(entities ...*certzpb.Entity) error {
+	err := r.send(&certzpb.RotateCertificateRequest{RotateRequest: &certzpb.RotateCertificateRequest_Certificates{
+		Certificates: &certzpb.UploadRequest{Entities: entities},
+	}})
+	if err != nil {
+		return fmt.Errorf("could not send upload request: %w", err)
+	}
+	if _, err := r.stream.Recv(); err != nil {
+		return fmt.Errorf("upload to profile %s failed: %w", r.Profile, err)
+	}
+	return nil
+}
+
+// Finalize makes the uploaded entities permanent and ends the rotation.
+func (r *Rotation) Finalize() error {
+	err := r.send(&certzpb.RotateCertificateRequest{RotateRequest: &certzpb.RotateCertificateRequest_FinalizeRotation{
+		FinalizeRotation: &certzpb.FinalizeRequest{},
+	}})
+	if err != nil {
+		return fmt.Errorf("could not send finalize request: %w", err)
+	}
+	if err := r.stream.CloseSend(); err != nil {
+		return err
+	}
+	if _, err := r.stream.Recv(); err != io.EOF {
+		return fmt.Errorf("finalize of profile %s: got %v, want end of stream", r.Profile, err)
+	}
+	return nil
+}
+
+// Abort ends the rotation without finalizing it, so that the DUT rolls back
+// to the entities it used before.
+func (r *Rotation) Abort() error {
+	if err := r.stream.CloseSend(); err != nil {
+		return err
+	}
+	_, err := r.stream.Recv()
+	switch {
+	case err == io.EOF, status.Code(err) == codes.Aborted, status.Code(err) == codes.Canceled:
+		return nil
+	case err == nil:
+		return fmt.Errorf("abort of profile %s: got a response, want end of stream", r.Profile)
+	}
+	return fmt.Errorf("abort of profile %s failed: %w", r.Profile, err)
+}
+
+func rotate(t testing.TB, dut *ondatra.DUTDevice, profile string, finalize bool, check func() error, entities []*certzpb.Entity) {
+	t.Helper()
+	r, err := StartRotation(context.Background(), dut, profile, false)
+	if err != nil {
+		t.Fatalf("Certz.Rotate on device %s: %v", dut.Name(), err)
+	}
+	if err := r.Upload(entities...); err != nil {
+		r.Abort()
+		t.Fatalf("Certz.Rotate on device %s: %v", dut.Name(), err)
+	}
+	t.Logf("Certz.Rotate uploaded %d entities to profile %s on device %s", len(entities), profile, dut.Name())
+	if err := check(); err != nil {
+		if abortErr := r.Abort(); abortErr != nil {
+			t.Errorf("Certz.Rotate on device %s: %v", dut.Name(), abortErr)
+		}
+		t.Fatalf("Check of the rotated entities of profile %s on device %s failed, rotation aborted: %v", profile, dut.Name(), err)
+	}
+	if !finalize {
+		if err := r.Abort(); err != nil {
+			t.Fatalf("Certz.Rotate on device %s: %v", dut.Name(), err)
+		}
+		t.Logf("Certz.Rotate of profile %s on device %s aborted", profile, dut.Name())
+		return
+	}
+	if err := r.Finalize(); err != nil {
+		t.Fatalf("Certz.Rotate on device %s: %v", dut.Name(), err)
+	}
+	t.Logf("Certz.Rotate of profile %s on device %s finalized", profile, dut.Name())
+}
+
+// Rotate uploads entities to profile on dut and runs check while the DUT uses
+// them.  It finalizes the rotation if check succeeds and aborts it and fails
+// the test otherwise.
+func Rotate(t testing.TB, dut *ondatra.DUTDevice, profile string, check func() error, entities ...*certzpb.Entity) {
+	t.Helper()
+	rotate(t, dut, profile, true, check, entities)
+}
+
+// RotateAndAbort uploads entities to profile on dut, runs check while the DUT
+// uses them and then aborts the rotation, for testing that the DUT rolls
+// back to the entities it used before.
+func RotateAndAbort(t testing.TB, dut *ondatra.DUTDevice, profile string, check func() error, entities ...*certzpb.Entity) {
+	t.Helper()
+	rotate(t, dut, profile, false, check, entities)
+}
+
+// AddProfile adds SSL profile id on dut.
+func AddProfile(t testing.TB, dut *ondatra.DUTDevice, id string) {
+	t.Helper()
+	gnsiC, err := dut.RawAPIs().BindingDUT().DialGNSI(context.Background())
+	if err != nil {
+		t.Fatalf("Could not connect gnsi %v", err)
+	}
+	if _, err := gnsiC.Certz().AddProfile(context.Background(), &certzpb.AddProfileRequest{SslProfileId: id}); err != nil {
+		t.Fatalf("Certz.AddProfile %s on device %s failed: %v", id, dut.Name(), err)
+	}
+}
+
+// DeleteProfile deletes SSL profile id from dut.
+func DeleteProfile(t testing.TB, dut *ondatra.DUTDevice, id string) {
+	t.Helper()
+	gnsiC, err := dut.RawAPIs().BindingDUT().DialGNSI(context.Background())
+	if err != nil {
+		t.Fatalf("Could not connect gnsi %v", err)
+	}
+	if _, err := gnsiC.Certz().DeleteProfile(context.Background(), &certzpb.DeleteProfileRequest{SslProfileId: id}); err != nil {
+		t.Fatalf("Certz.DeleteProfile %s on device %s failed: %v", id, dut.Name(), err)
+	}
+}
+
+// Profiles returns the SSL profiles of dut.
+func Profiles(t testing.TB, dut *ondatra.DUTDevice) []string {
+	t.Helper()
+	gnsiC, err := dut.RawAPIs().BindingDUT().DialGNSI(context.Background())
+	if err != nil {
+		t.Fatalf("Could not connect gnsi %v", err)
+	}
+	resp, err := gnsiC.Certz().GetProfileList(context.Background(), &certzpb.GetProfileListRequest{})
+	if err != nil {
+		t.Fatalf("Certz.GetProfileList on device %s failed: %v", dut.Name(), err)
+	}
+	return resp.GetSslProfileIds()
+}
+
+// captureConfig returns a copy of conf that records the certificates the
+// server presents, and a function returning them.
+func captureConfig(conf *tls.Config) (*tls.Config, func() []*x509.Certificate) {
+	var mu sync.Mutex
+	var peer []*x509.Certificate
+	c := conf.Clone()
+	verify := c.VerifyConnection
+	c.VerifyConnection = func(cs tls.ConnectionState) error {
+		mu.Lock()
+		peer = cs.PeerCertificates
+		mu.Unlock()
+		if verify != nil {
+			return verify(cs)
+		}
+		return nil
+	}
+	return c, func() []*x509.Certificate {
+		mu.Lock()
+		defer mu.Unlock()
+		return peer
+	}
+}
+
+// Served dials the gNMI service of dut with TLS config conf, which verifies
+// the server against its roots, and returns the certificates the DUT served.
+// It fails if the handshake fails, e.g. because the DUT serves a certificate
+// conf does not trust.
+func Served(ctx context.Context, dut *ondatra.DUTDevice, conf *tls.Config) ([]*x509.Certificate, error) {
+	c, peer := captureConfig(conf)
+	gnmiC, err := dut.RawAPIs().BindingDUT().DialGNMI(ctx, grpc.WithTransportCredentials(credentials.NewTLS(c)))
+	if err != nil {
+		return nil, err
+	}
+	_, err = gnmiC.Capabilities(ctx, &gpb.CapabilityRequest{})
+	if certs := peer(); len(certs) > 0 {
+		return certs, nil
+	}
+	if err == nil {
+		err = fmt.Errorf("no certificate served")
+	}
+	return nil, err
+}
+
+// CheckServed checks that dut serves certificate want, verified by conf.
+func CheckServed(ctx context.Context, dut *ondatra.DUTDevice, conf *tls.Config, want *x509.Certificate) error {
+	certs, err := Served(ctx, dut, conf)
+	if err != nil {
+		return fmt.Errorf("could not get the certificate served by %s: %w", dut.Name(), err)
+	}
+	if !certs[0].Equal(want) {
+		return fmt.Errorf("%s serves certificate %s serial %x, want %s serial %x", dut.Name(), certs[0].Subject, certs[0].SerialNumber, want.Subject, want.SerialNumber)
+	}
+	return nil
+}
@@ -23,7 +23,7 @@ import (
 	"fmt"
 	"math/rand"
 	"os"
-	"os/exec"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -35,6 +35,7 @@ import (
 	"github.com/openconfig/ondatra/binding"
 	"github.com/openconfig/ondatra/gnmi"
 	"github.com/openconfig/ondatra/gnmi/oc"
+	"golang.org/x/crypto/ssh"
 )
 
 const (
@@ -434,20 +435,15 @@ func GetDutPublicKey(t *testing.T, dut *ondatra.DUTDevice, targetAlgo string) []
 }
 
 // CreateSSHKeyPairAlgo creates ssh keypair with a filename of keyName in the specified directory with the specified algo.
+// See GenerateSSHKeyPair for the supported algos.
 func CreateSSHKeyPairAlgo(t *testing.T, dir, keyName, algo string) {
-	args := []string{
-		"-t", algo,
-	}
-	if algo == "rsa" {
-		args = append(args, "-b", "4096")
-	}
-	args = append(args, "-f", keyName, "-C", keyName, "-q", "-N", "")
-	sshCmd := exec.Command("ssh-keygen", args...)
-	sshCmd.Dir = dir
-	err := sshCmd.Run()
+	key, err := GenerateSSHKeyPair(keyName, algo)
 	if err != nil {
 		t.Fatalf("Failed generating %s key pair, error: %s", keyName, err)
 	}
+	if err := key.WriteFiles(dir); err != nil {
+		t.Fatalf("Failed writing %s key pair, error: %s", keyName, err)
+	}
 }
 
 // CreateSSHKeyPair creates ssh keypair with a filename of keyName in the specified directory.
@@ -458,19 +454,22 @@ func CreateSSHKeyPair(t *testing.T, dir, keyName string) {
 
 // CreateUserCertificate creates ssh user certificate in the specified directory.
 func CreateUserCertificate(t *testing.T, dir, userPrincipal string) {
-	userCertCmd := exec.Command(
-		"ssh-keygen",
-		"-s", caKey,
-		"-I", userKey,
-		"-n", userPrincipal,
-		"-V", "-1d:+52w",
-		fmt.Sprintf("%s.pub", userKey),
-	)
-	userCertCmd.Dir = dir
-	err := userCertCmd.Run()
+	CreateUserCertificateValidity(t, dir, userPrincipal, "-1d:+52w")
+}
+
+// CreateUserCertificateValidity creates ssh user certificate in the specified directory, valid for the
+// specified ssh-keygen validity interval, e.g. "-2w:-1w" for an expired certificate.
+func CreateUserCertificateValidity(t *testing.T, dir, userPrincipal, validity string) {
+	after, before, err := ParseValidity(validity, time.Now())
 	if err != nil {
-		t.Fatalf("Failed generating user cert, error: %s", err)
+		t.Fatalf("Failed parsing user cert validity, error: %s", err)
 	}
+	signCertificate(t, dir, userKey, true, &CertOptions{
+		KeyID:       userKey,
+		Principals:  []string{userPrincipal},
+		ValidAfter:  after,
+		ValidBefore: before,
+	})
 }
 
 // CreateHostCertificate takes in dut key contents & creates ssh host certificate in the specified directory.
@@ -480,177 +479,132 @@ func CreateHostCertificate(t *testing.T, dut *ondatra.DUTDevice, dir string, dut
 	if err != nil {
 		t.Fatalf("Failed writing dut public key to temp dir, error: %s", err)
 	}
-	cmd := exec.Command(
-		"ssh-keygen",
-		"-s", caKey, // sign using this ca key
-		"-I", "identity", // key identity
-		"-h",                 // create host (not user) certificate
-		"-n", "dut.test.com", // principal(s)
-		"-V", "-1d:+52w", // validity
-		fmt.Sprintf("%s.pub", dut.ID()),
-	)
-	t.Logf("Generating host certificate: %v", cmd)
-	cmd.Dir = dir
-	err = cmd.Run()
+	after, before, err := ParseValidity("-1d:+52w", time.Now())
 	if err != nil {
-		t.Fatalf("Failed generating dut cert, error: %s", err)
+		t.Fatalf("Failed parsing dut cert validity, error: %s", err)
 	}
+	signCertificate(t, dir, dut.ID(), false, &CertOptions{
+		KeyID:       "identity",
+		Principals:  []string{"dut.test.com"},
+		ValidAfter:  after,
+		ValidBefore: before,
+	})
 }
 
-func createHibaKeysCopy(t *testing.T, keysDir string) {
-	keyFiles := []string{
-		"ca",
-		"ca.pub",
-		"hosts/dut",
-		"hosts/dut.pub",
-		"hosts/dut-cert.pub",
-		"users/testuser",
-		"users/testuser.pub",
-		"users/testuser-cert.pub",
+// signCertificate signs the public key keyName.pub in dir with the ca key in dir and writes the certificate
+// next to it.
+func signCertificate(t *testing.T, dir, keyName string, user bool, opts *CertOptions) {
+	ca, err := ReadSSHKeyPair(dir, caKey)
+	if err != nil {
+		t.Fatalf("Failed reading ca key, error: %s", err)
 	}
-	err := os.Mkdir(fmt.Sprintf("%s/hosts", keysDir), 0o700)
+	data, err := os.ReadFile(fmt.Sprintf("%s/%s.pub", dir, keyName))
 	if err != nil {
-		t.Fatalf("Failed ensuring hosts dir in temp dir, error: %s", err)
+		t.Fatalf("Failed reading %s public key, error: %s", keyName, err)
 	}
-	err = os.Mkdir(fmt.Sprintf("%s/users", keysDir), 0o700)
+	pub, _, _, _, err := ssh.ParseAuthorizedKey(data)
 	if err != nil {
-		t.Fatalf("Failed ensuring users dir in temp dir, error: %s", err)
+		t.Fatalf("Failed parsing %s public key, error: %s", keyName, err)
 	}
-
-	for _, keyFile := range keyFiles {
-		var input []byte
-		input, err = os.ReadFile(keyFile)
-		if err != nil {
-			t.Errorf("Error reading file %v, error: %s", keyFile, err)
-			return
-		}
-		err = os.WriteFile(fmt.Sprintf("%s/%s", keysDir, keyFile), input, 0o600)
-		if err != nil {
-			t.Fatalf("Failed copying key file %s to temp test dir, error: %s", keyFile, err)
-		}
+	newCert := NewHostCertificate
+	if user {
+		newCert = NewUserCertificate
 	}
-}
-
-func createHibaKeysGen(t *testing.T, hibaCa, hibaGen, keysDir string) {
-	caCmd := exec.Command(
-		hibaCa,
-		"-c",
-		"-d", keysDir, // output to the temp dir
-		"--",           // pass the rest to ssh-keygen
-		"-q", "-N", "", // quiet, empty passphrase
-
-	)
-	err := caCmd.Run()
-	if err != nil {
-		t.Fatalf("Failed generating ca key pair, error: %s", err)
-	}
-
-	userKeyCmd := exec.Command(
-		hibaCa,
-		"-c",
-		"-d", keysDir,
-		"-u", "-I", userKey,
-		"--",
-		"-q", "-N", "",
-	)
-	err = userKeyCmd.Run()
-	if err != nil {
-		t.Fatalf("Failed generating user key pair, error: %s", err)
-	}
-
-	dutKeyCmd := exec.Command(
-		hibaCa,
-		"-c",
-		"-d", keysDir,
-		"-h", "-I", dutKey,
-		"--",
-		"-q", "-N", "",
-	)
-	err = dutKeyCmd.Run()
+	cert, err := newCert(ca, pub, opts)
 	if err != nil {
-		t.Fatalf("Failed generating dut key pair, error: %s", err)
+		t.Fatalf("Failed generating %s cert, error: %s", keyName, err)
 	}
-
-	prodIdentityCmd := exec.Command(
-		hibaGen,
-		"-i",
-		"-f", fmt.Sprintf("%s/policy/identities/prod", keysDir),
-		"domain", "google.com",
-	)
-	err = prodIdentityCmd.Run()
-	if err != nil {
-		t.Fatalf("Failed creating prod identity, error: %s", err)
+	if err := WriteCertificate(dir, keyName, cert); err != nil {
+		t.Fatalf("Failed writing %s cert, error: %s", keyName, err)
 	}
+}
 
-	shellGrantCmd := exec.Command(
-		hibaGen,
-		"-f", fmt.Sprintf("%s/policy/grants/shell", keysDir),
-		"domain", "google.com",
-	)
-	err = shellGrantCmd.Run()
-	if err != nil {
-		t.Fatalf("Failed creating shell grant, error: %s", err)
-	}
+// HIBAKeys are the keys and certificates of a HIBA setup: a CA, a user
+// certificate granted shell access in domain google.com and a dut host
+// certificate with the prod identity of that domain.
+type HIBAKeys struct {
+	CA, User, Host     *SSHKeyPair
+	UserCert, HostCert *ssh.Certificate
+}
 
-	grantShellToUserCmd := exec.Command(
-		hibaCa,
-		"-d", keysDir,
-		"-p",
-		"-I", userKey,
-		"-H", "shell",
-	)
-	err = grantShellToUserCmd.Run()
-	if err != nil {
-		t.Fatalf("Failed granting shell grant to testuser, error: %s", err)
-	}
-
-	createHostCertCmd := exec.Command(
-		hibaCa,
-		"-d", keysDir,
-		"-s",
-		"-h",
-		"-I", dutKey,
-		"-H", "prod",
-		"-V", "+52w",
-	)
-	err = createHostCertCmd.Run()
+// NewHIBAKeys generates the keys and certificates hiba-ca.sh creates when following the steps of
+// https://github.com/google/hiba/blob/main/CA.md.
+func NewHIBAKeys() (*HIBAKeys, error) {
+	k := &HIBAKeys{}
+	var err error
+	if k.CA, err = GenerateSSHKeyPair(caKey, "ed25519"); err != nil {
+		return nil, err
+	}
+	if k.User, err = GenerateSSHKeyPair(userKey, "ed25519"); err != nil {
+		return nil, err
+	}
+	if k.Host, err = GenerateSSHKeyPair(dutKey, "ed25519"); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	prod := &HIBAExtension{Domain: "google.com"}
+	k.HostCert, err = NewHostCertificate(k.CA, k.Host.PublicKey(), &CertOptions{
+		KeyID:       dutKey,
+		Principals:  []string{dutKey},
+		ValidAfter:  now,
+		ValidBefore: now.Add(52 * 7 * 24 * time.Hour),
+		Extensions:  map[string]string{HIBAIdentityExtension: HIBAExtensionValue(prod)},
+	})
 	if err != nil {
-		t.Fatalf("Failed creating host certificate, error: %s", err)
-	}
-
-	createUserCertCmd := exec.Command(
-		hibaCa,
-		"-d", keysDir,
-		"-s",
-		"-u",
-		"-I", userKey,
-		"-H", "shell",
-	)
-	err = createUserCertCmd.Run()
+		return nil, err
+	}
+	shell := &HIBAExtension{Domain: "google.com"}
+	k.UserCert, err = NewUserCertificate(k.CA, k.User.PublicKey(), &CertOptions{
+		KeyID:      userKey,
+		Principals: []string{userKey},
+		Extensions: map[string]string{
+			HIBAGrantsExtension: HIBAExtensionValue(shell),
+			"permit-pty":        "",
+		},
+	})
 	if err != nil {
-		t.Fatalf("Failed creating user certificate, error: %s", err)
+		return nil, err
+	}
+	return k, nil
+}
+
+// WriteFiles writes the keys and certificates of k to dir in the layout of hiba-ca.sh: ca and ca.pub,
+// hosts/dut, hosts/dut.pub and hosts/dut-cert.pub, users/testuser, users/testuser.pub and
+// users/testuser-cert.pub.
+func (k *HIBAKeys) WriteFiles(dir string) error {
+	if err := k.CA.WriteFiles(dir); err != nil {
+		return err
+	}
+	for _, sub := range []struct {
+		dir  string
+		key  *SSHKeyPair
+		cert *ssh.Certificate
+	}{
+		{"hosts", k.Host, k.HostCert},
+		{"users", k.User, k.UserCert},
+	} {
+		d := filepath.Join(dir, sub.dir)
+		if err := os.MkdirAll(d, 0o700); err != nil {
+			return err
+		}
+		if err := sub.key.WriteFiles(d); err != nil {
+			return err
+		}
+		if err := WriteCertificate(d, sub.key.Name, sub.cert); err != nil {
+			return err
+		}
 	}
+	return nil
 }
 
-// CreateHibaKeys creates/copies hiba granted keys/certificates in the specified directory.
-// If hiba tool is not installed on the testbed, ensure following files (generated after executing steps
-// from https://github.com/google/hiba/blob/main/CA.md) are present in the test directory :
-// feature/security/gnsi/credentialz/tests/hiba_authentication/ca,
-// feature/security/gnsi/credentialz/tests/hiba_authentication/ca.pub,
-// feature/security/gnsi/credentialz/tests/hiba_authentication/hosts/dut,
-// feature/security/gnsi/credentialz/tests/hiba_authentication/hosts/dut.pub,
-// feature/security/gnsi/credentialz/tests/hiba_authentication/hosts/dut-cert.pub,
-// feature/security/gnsi/credentialz/tests/hiba_authentication/users/testuser,
-// feature/security/gnsi/credentialz/tests/hiba_authentication/users/testuser.pub,
-// feature/security/gnsi/credentialz/tests/hiba_authentication/users/testuser-cert.pub,
+// CreateHibaKeys creates hiba granted keys/certificates in the specified directory, see HIBAKeys.WriteFiles.
 func CreateHibaKeys(t *testing.T, dut *ondatra.DUTDevice, keysDir string) {
-	hibaCa, _ := exec.LookPath("hiba-ca.sh")
-	hibaGen, _ := exec.LookPath("hiba-gen")
-	if hibaCa == "" || hibaGen == "" {
-		t.Log("hiba-ca and/or hiba-gen not found on path, will try to use certs in local test dir if present.")
-		createHibaKeysCopy(t, keysDir)
-	} else {
-		createHibaKeysGen(t, hibaCa, hibaGen, keysDir)
+	keys, err := NewHIBAKeys()
+	if err != nil {
+		t.Fatalf("Failed generating hiba keys, error: %s", err)
+	}
+	if err := keys.WriteFiles(keysDir); err != nil {
+		t.Fatalf("Failed writing hiba keys, error: %s", err)
 	}
 }
 
@@ -0,0 +1,426 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package credz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openconfig/featureprofiles/internal/deviations"
+	cpb "github.com/openconfig/gnsi/credentialz"
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/binding"
+)
+
+const (
+	// loginTimeout bounds a single ssh login attempt of the matrix runner.
+	loginTimeout = 30 * time.Second
+	// counterSettle is how long the runner waits after a login attempt for
+	// the ssh server counters to be updated.
+	counterSettle = 2 * time.Second
+	// expiredValidity is the ssh-keygen validity interval of expired user
+	// certificates.
+	expiredValidity = "-2w:-1w"
+)
+
+// AuthMethod is the way an ssh client authenticates.
+type AuthMethod int
+
+const (
+	// AuthPassword authenticates with the password of the user.
+	AuthPassword AuthMethod = iota
+	// AuthKey authenticates with an authorized key of the user.
+	AuthKey
+	// AuthCertificate authenticates with a user certificate signed by a
+	// trusted CA.
+	AuthCertificate
+)
+
+func (m AuthMethod) String() string {
+	switch m {
+	case AuthPassword:
+		return "password"
+	case AuthKey:
+		return "key"
+	case AuthCertificate:
+		return "certificate"
+	}
+	return fmt.Sprintf("AuthMethod(%d)", int(m))
+}
+
+// CredentialState is the state of the credential presented in a login
+// attempt.
+type CredentialState int
+
+const (
+	// CredentialValid is the current credential of the user.
+	CredentialValid CredentialState = iota
+	// CredentialInvalid is a credential the DUT never accepted: a wrong
+	// password, an unauthorized key or a certificate of an untrusted CA.
+	CredentialInvalid
+	// CredentialExpired is a credential the DUT no longer accepts: the
+	// previous password or authorized key of the user, or a certificate
+	// whose validity has ended.
+	CredentialExpired
+)
+
+func (s CredentialState) String() string {
+	switch s {
+	case CredentialValid:
+		return "valid"
+	case CredentialInvalid:
+		return "invalid"
+	case CredentialExpired:
+		return "expired"
+	}
+	return fmt.Sprintf("CredentialState(%d)", int(s))
+}
+
+// Attempt is a login attempt with a credential.
+type Attempt struct {
+	Method     AuthMethod
+	Credential CredentialState
+}
+
+func (a Attempt) String() string {
+	return fmt.Sprintf("%v %v", a.Credential, a.Method)
+}
+
+// AllAttempts returns an attempt per method and credential state.
+func AllAttempts() []Attempt {
+	var attempts []Attempt
+	for _, m := range []AuthMethod{AuthPassword, AuthKey, AuthCertificate} {
+		for _, s := range []CredentialState{CredentialValid, CredentialInvalid, CredentialExpired} {
+			attempts = append(attempts, Attempt{Method: m, Credential: s})
+		}
+	}
+	return attempts
+}
+
+// MatrixCase is a DUT configuration of the matrix.
+type MatrixCase struct {
+	// AuthTypes are the authentication types allowed on the DUT.
+	AuthTypes []cpb.AuthenticationType
+	// Tool is the authorized principal check tool of the DUT.
+	Tool cpb.AuthorizedPrincipalCheckRequest_Tool
+	// KeyAlgo is the ssh-keygen key type of the user and CA keys.
+	KeyAlgo string
+}
+
+func (c MatrixCase) String() string {
+	var types []string
+	for _, a := range c.AuthTypes {
+		types = append(types, strings.TrimPrefix(a.String(), "AUTHENTICATION_TYPE_"))
+	}
+	return fmt.Sprintf("auth=%s tool=%s algo=%s", strings.Join(types, "+"), strings.TrimPrefix(c.Tool.String(), "TOOL_"), c.KeyAlgo)
+}
+
+// WantAccept returns whether the DUT configured as c accepts attempt a.
+// Only valid credentials are accepted, password logins require the password
+// authentication type and key and certificate logins the public key type.
+// The certificates of the matrix carry no HIBA grants, so certificate logins
+// are rejected when HIBA checks the authorized principals.
+func WantAccept(c MatrixCase, a Attempt) bool {
+	if a.Credential != CredentialValid {
+		return false
+	}
+	switch a.Method {
+	case AuthPassword:
+		return slices.Contains(c.AuthTypes, cpb.AuthenticationType_AUTHENTICATION_TYPE_PASSWORD)
+	case AuthKey:
+		return slices.Contains(c.AuthTypes, cpb.AuthenticationType_AUTHENTICATION_TYPE_PUBKEY)
+	case AuthCertificate:
+		return slices.Contains(c.AuthTypes, cpb.AuthenticationType_AUTHENTICATION_TYPE_PUBKEY) &&
+			c.Tool != cpb.AuthorizedPrincipalCheckRequest_TOOL_HIBA_DEFAULT
+	}
+	return false
+}
+
+// Matrix is the set of DUT configurations and login attempts of a
+// credentialz qualification.  Every attempt is made against every
+// combination of authentication types, principal check tool and key
+// algorithm.
+type Matrix struct {
+	// Username is the account the attempts log in as.
+	Username string
+	// Principal is the authorized principal of the account and of its
+	// certificates.  It defaults to Username.
+	Principal string
+	// AuthTypes are the sets of allowed authentication types to test.
+	AuthTypes [][]cpb.AuthenticationType
+	// Tools are the authorized principal check tools to test.
+	Tools []cpb.AuthorizedPrincipalCheckRequest_Tool
+	// KeyAlgos are the ssh-keygen key types to test, e.g. "ed25519",
+	// "ecdsa" or "rsa".
+	KeyAlgos []string
+	// Attempts are the login attempts of every case.  They default to
+	// AllAttempts.
+	Attempts []Attempt
+}
+
+// Cases returns the DUT configurations of m.
+func (m *Matrix) Cases() []MatrixCase {
+	var cases []MatrixCase
+	for _, types := range m.AuthTypes {
+		for _, tool := range m.Tools {
+			for _, algo := range m.KeyAlgos {
+				cases = append(cases, MatrixCase{AuthTypes: types, Tool: tool, KeyAlgo: algo})
+			}
+		}
+	}
+	return cases
+}
+
+func (m *Matrix) principal() string {
+	if m.Principal != "" {
+		return m.Principal
+	}
+	return m.Username
+}
+
+func (m *Matrix) attempts() []Attempt {
+	if len(m.Attempts) > 0 {
+		return m.Attempts
+	}
+	return AllAttempts()
+}
+
+// LoginOutcome is the result of a login attempt of the matrix.
+type LoginOutcome struct {
+	Case    MatrixCase
+	Attempt Attempt
+	// Want is whether the DUT is expected to accept the attempt.
+	Want bool
+	// Err is the error of the login, nil if it was accepted.
+	Err error
+	// Counters is whether the ssh server counters were read.
+	Counters bool
+	// Accepts and Rejects are the increments of the ssh server accept and
+	// reject counters during the attempt.
+	Accepts, Rejects uint64
+	// CounterErr is why the increments could not be computed, e.g. because
+	// a counter was reset during the attempt.
+	CounterErr error
+}
+
+// Accepted returns whether the DUT accepted the login.
+func (o *LoginOutcome) Accepted() bool {
+	return o.Err == nil
+}
+
+// CheckLogins returns the outcomes whose login result or counter increments
+// disagree with the expectation, one line each.
+func CheckLogins(outcomes []*LoginOutcome) []string {
+	var diffs []string
+	for _, o := range outcomes {
+		prefix := fmt.Sprintf("%v: %v login", o.Case, o.Attempt)
+		switch {
+		case o.Want && !o.Accepted():
+			diffs = append(diffs, fmt.Sprintf("%s rejected, want accepted: %v", prefix, o.Err))
+		case !o.Want && o.Accepted():
+			diffs = append(diffs, fmt.Sprintf("%s accepted, want rejected", prefix))
+		}
+		if !o.Counters {
+			continue
+		}
+		if o.CounterErr != nil {
+			diffs = append(diffs, fmt.Sprintf("%s: %v", prefix, o.CounterErr))
+			continue
+		}
+		if o.Accepted() && o.Accepts == 0 {
+			diffs = append(diffs, fmt.Sprintf("%s accepted but accept counter did not increment", prefix))
+		}
+		if !o.Accepted() {
+			if o.Rejects == 0 {
+				diffs = append(diffs, fmt.Sprintf("%s rejected but reject counter did not increment", prefix))
+			}
+			if o.Accepts != 0 {
+				diffs = append(diffs, fmt.Sprintf("%s rejected but accept counter incremented by %d", prefix, o.Accepts))
+			}
+		}
+	}
+	return diffs
+}
+
+// LoginSummary formats outcomes as a table, one row per case and attempt, to
+// keep as evidence of the run.
+func LoginSummary(outcomes []*LoginOutcome) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%-60s %-22s %-9s %-9s %s\n", "CASE", "ATTEMPT", "WANT", "GOT", "COUNTERS")
+	for _, o := range outcomes {
+		counters := "-"
+		switch {
+		case o.Counters && o.CounterErr != nil:
+			counters = o.CounterErr.Error()
+		case o.Counters:
+			counters = fmt.Sprintf("+%d accepts +%d rejects", o.Accepts, o.Rejects)
+		}
+		fmt.Fprintf(&b, "%-60v %-22v %-9s %-9s %s\n", o.Case, o.Attempt, loginResult(o.Want), loginResult(o.Accepted()), counters)
+	}
+	return b.String()
+}
+
+// counterDelta returns the increment of counter name from before to after,
+// and an error if the counter went backwards.
+func counterDelta(name string, before, after uint64) (uint64, error) {
+	if after < before {
+		return 0, fmt.Errorf("%s counter reset from %d to %d during the attempt", name, before, after)
+	}
+	return after - before, nil
+}
+
+func loginResult(accepted bool) string {
+	if accepted {
+		return "accept"
+	}
+	return "reject"
+}
+
+// matrixCredentials are the credentials of a case, by state.
+type matrixCredentials struct {
+	passwords map[CredentialState]string
+	// dirs hold a user key pair and certificate per state.
+	dirs map[CredentialState]string
+}
+
+// createMatrixCredentials creates the credentials of a case in dir:
+// the valid key pair and certificate of the trusted CA, the key pair and
+// certificate of an untrusted CA, and a superseded key pair with an expired
+// certificate of the trusted CA.
+func createMatrixCredentials(t *testing.T, dir, algo, principal string) *matrixCredentials {
+	creds := &matrixCredentials{
+		passwords: map[CredentialState]string{
+			CredentialValid:   GeneratePassword(),
+			CredentialInvalid: GeneratePassword(),
+			CredentialExpired: GeneratePassword(),
+		},
+		dirs: map[CredentialState]string{},
+	}
+	for _, s := range []CredentialState{CredentialValid, CredentialInvalid, CredentialExpired} {
+		d := filepath.Join(dir, s.String())
+		if err := os.Mkdir(d, 0o700); err != nil {
+			t.Fatalf("Failed creating %s credentials dir, error: %s", s, err)
+		}
+		creds.dirs[s] = d
+	}
+
+	valid, invalid, expired := creds.dirs[CredentialValid], creds.dirs[CredentialInvalid], creds.dirs[CredentialExpired]
+	for _, d := range []string{valid, invalid} {
+		CreateSSHKeyPairAlgo(t, d, caKey, algo)
+		CreateSSHKeyPairAlgo(t, d, userKey, algo)
+		CreateUserCertificate(t, d, principal)
+	}
+	for _, f := range []string{caKey, caKey + ".pub"} {
+		data, err := os.ReadFile(filepath.Join(valid, f))
+		if err != nil {
+			t.Fatalf("Failed reading ca key %s, error: %s", f, err)
+		}
+		if err := os.WriteFile(filepath.Join(expired, f), data, 0o600); err != nil {
+			t.Fatalf("Failed copying ca key %s, error: %s", f, err)
+		}
+	}
+	CreateSSHKeyPairAlgo(t, expired, userKey, algo)
+	CreateUserCertificateValidity(t, expired, principal, expiredValidity)
+	return creds
+}
+
+// configureMatrixCase rotates the credentials and host parameters of c on the
+// dut.  The expired password and key are rotated in first and superseded by
+// the valid ones.
+func configureMatrixCase(t *testing.T, dut *ondatra.DUTDevice, username, principal string, c MatrixCase, creds *matrixCredentials) {
+	for _, s := range []CredentialState{CredentialExpired, CredentialValid} {
+		RotateUserPassword(t, dut, username, creds.passwords[s], GenerateVersion(), uint64(time.Now().Unix()))
+		RotateAuthorizedKey(t, dut, creds.dirs[s], username, GenerateVersion(), uint64(time.Now().Unix()))
+	}
+	RotateTrustedUserCA(t, dut, creds.dirs[CredentialValid])
+	RotateAuthorizedPrincipal(t, dut, username, principal)
+	RotateAuthorizedPrincipalCheck(t, dut, c.Tool)
+	RotateAuthenticationTypes(t, dut, c.AuthTypes)
+}
+
+func login(ctx context.Context, t *testing.T, dut *ondatra.DUTDevice, username string, a Attempt, creds *matrixCredentials) error {
+	var client binding.SSHClient
+	var err error
+	switch a.Method {
+	case AuthPassword:
+		client, err = SSHWithPassword(ctx, dut, username, creds.passwords[a.Credential])
+	case AuthKey:
+		client, err = SSHWithKey(ctx, t, dut, username, creds.dirs[a.Credential])
+	case AuthCertificate:
+		client, err = SSHWithCertificate(ctx, t, dut, username, creds.dirs[a.Credential])
+	default:
+		return fmt.Errorf("unsupported auth method %v", a.Method)
+	}
+	if err != nil {
+		return err
+	}
+	return client.Close()
+}
+
+// RunMatrix configures dut for every case of m in turn and makes every
+// attempt of m against it, reading the ssh server counters around each
+// attempt unless the dut does not support them.  The user must exist on the
+// dut, see SetupUser.  The allowed authentication types and principal check
+// tool of the last case are left configured.
+func RunMatrix(t *testing.T, dut *ondatra.DUTDevice, m *Matrix) []*LoginOutcome {
+	t.Helper()
+	counters := !deviations.SSHServerCountersUnsupported(dut)
+	var outcomes []*LoginOutcome
+	for _, c := range m.Cases() {
+		creds := createMatrixCredentials(t, t.TempDir(), c.KeyAlgo, m.principal())
+		configureMatrixCase(t, dut, m.Username, m.principal(), c, creds)
+		for _, a := range m.attempts() {
+			o := &LoginOutcome{Case: c, Attempt: a, Want: WantAccept(c, a), Counters: counters}
+			var startAccepts, startRejects uint64
+			if counters {
+				startAccepts, _ = GetAcceptTelemetry(t, dut)
+				startRejects, _ = GetRejectTelemetry(t, dut)
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), loginTimeout)
+			o.Err = login(ctx, t, dut, m.Username, a, creds)
+			cancel()
+			if counters {
+				time.Sleep(counterSettle)
+				accepts, _ := GetAcceptTelemetry(t, dut)
+				rejects, _ := GetRejectTelemetry(t, dut)
+				var acceptErr, rejectErr error
+				o.Accepts, acceptErr = counterDelta("accept", startAccepts, accepts)
+				o.Rejects, rejectErr = counterDelta("reject", startRejects, rejects)
+				o.CounterErr = errors.Join(acceptErr, rejectErr)
+			}
+			outcomes = append(outcomes, o)
+		}
+	}
+	return outcomes
+}
+
+// VerifyMatrix runs matrix m on dut and checks that every login is accepted
+// or rejected as expected and counted accordingly by the ssh server.  The
+// outcome of every attempt is logged.
+func VerifyMatrix(t *testing.T, dut *ondatra.DUTDevice, m *Matrix) {
+	t.Helper()
+	outcomes := RunMatrix(t, dut, m)
+	t.Logf("Credentialz login matrix of user %s on dut %s:\n%s", m.Username, dut.Name(), LoginSummary(outcomes))
+	for _, d := range CheckLogins(outcomes) {
+		t.Error(d)
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package credz
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	cpb "github.com/openconfig/gnsi/credentialz"
+)
+
+var (
+	password = cpb.AuthenticationType_AUTHENTICATION_TYPE_PASSWORD
+	pubkey   = cpb.AuthenticationType_AUTHENTICATION_TYPE_PUBKEY
+	noTool   = cpb.AuthorizedPrincipalCheckRequest_TOOL_UNSPECIFIED
+	hiba     = cpb.AuthorizedPrincipalCheckRequest_TOOL_HIBA_DEFAULT
+)
+
+func TestWantAccept(t *testing.T) {
+	tests := []struct {
+		c    MatrixCase
+		a    Attempt
+		want bool
+	}{
+		{MatrixCase{AuthTypes: []cpb.AuthenticationType{password}}, Attempt{AuthPassword, CredentialValid}, true},
+		{MatrixCase{AuthTypes: []cpb.AuthenticationType{password}}, Attempt{AuthPassword, CredentialExpired}, false},
+		{MatrixCase{AuthTypes: []cpb.AuthenticationType{password}}, Attempt{AuthKey, CredentialValid}, false},
+		{MatrixCase{AuthTypes: []cpb.AuthenticationType{pubkey}}, Attempt{AuthPassword, CredentialValid}, false},
+		{MatrixCase{AuthTypes: []cpb.AuthenticationType{pubkey}}, Attempt{AuthKey, CredentialValid}, true},
+		{MatrixCase{AuthTypes: []cpb.AuthenticationType{pubkey}}, Attempt{AuthKey, CredentialInvalid}, false},
+		{MatrixCase{AuthTypes: []cpb.AuthenticationType{pubkey}, Tool: noTool}, Attempt{AuthCertificate, CredentialValid}, true},
+		{MatrixCase{AuthTypes: []cpb.AuthenticationType{pubkey}, Tool: hiba}, Attempt{AuthCertificate, CredentialValid}, false},
+		{MatrixCase{AuthTypes: []cpb.AuthenticationType{password, pubkey}}, Attempt{AuthCertificate, CredentialExpired}, false},
+	}
+	for _, tc := range tests {
+		if got := WantAccept(tc.c, tc.a); got != tc.want {
+			t.Errorf("WantAccept(%v, %v) = %v, want %v", tc.c, tc.a, got, tc.want)
+		}
+	}
+}
+
+func TestCases(t *testing.T) {
+	m := &Matrix{
+		Username: "testuser",
+		AuthTypes: [][]cpb.AuthenticationType{
+			{password},
+			{pubkey},
+			{password, pubkey},
+		},
+		Tools:    []cpb.AuthorizedPrincipalCheckRequest_Tool{noTool, hiba},
+		KeyAlgos: []string{"ed25519", "ecdsa"},
+	}
+	cases := m.Cases()
+	if got, want := len(cases), 3*2*2; got != want {
+		t.Errorf("Cases() returned %d cases, want %d", got, want)
+	}
+	if got, want := cases[len(cases)-1].String(), "auth=PASSWORD+PUBKEY tool=HIBA_DEFAULT algo=ecdsa"; got != want {
+		t.Errorf("Cases()[%d] = %q, want %q", len(cases)-1, got, want)
+	}
+	if got, want := len(m.attempts()), 9; got != want {
+		t.Errorf("attempts() returned %d attempts, want %d", got, want)
+	}
+	if got, want := m.principal(), "testuser"; got != want {
+		t.Errorf("principal() = %q, want %q", got, want)
+	}
+}
+
+func TestCheckLogins(t *testing.T) {
+	c := MatrixCase{AuthTypes: []cpb.AuthenticationType{pubkey}, KeyAlgo: "ed25519"}
+	rejected := errors.New("ssh: unable to authenticate")
+	outcomes := []*LoginOutcome{
+		{Case: c, Attempt: Attempt{AuthKey, CredentialValid}, Want: true, Counters: true, Accepts: 1},
+		{Case: c, Attempt: Attempt{AuthKey, CredentialInvalid}, Err: rejected, Counters: true, Rejects: 1},
+		{Case: c, Attempt: Attempt{AuthPassword, CredentialValid}, Err: rejected, Counters: true},
+		{Case: c, Attempt: Attempt{AuthKey, CredentialExpired}, Counters: true, Accepts: 1},
+		{Case: c, Attempt: Attempt{AuthCertificate, CredentialValid}, Want: true, Err: rejected},
+		{Case: c, Attempt: Attempt{AuthCertificate, CredentialInvalid}, Err: rejected, Counters: true, CounterErr: errors.New("reject counter reset from 7 to 1 during the attempt")},
+	}
+	want := []string{
+		"auth=PUBKEY tool=UNSPECIFIED algo=ed25519: valid password login rejected but reject counter did not increment",
+		"auth=PUBKEY tool=UNSPECIFIED algo=ed25519: expired key login accepted, want rejected",
+		"auth=PUBKEY tool=UNSPECIFIED algo=ed25519: valid certificate login rejected, want accepted: ssh: unable to authenticate",
+		"auth=PUBKEY tool=UNSPECIFIED algo=ed25519: invalid certificate login: reject counter reset from 7 to 1 during the attempt",
+	}
+	if diff := cmp.Diff(want, CheckLogins(outcomes)); diff != "" {
+		t.Errorf("CheckLogins() diff (-want +got):\n%s", diff)
+	}
+	s := LoginSummary(outcomes)
+	if got := strings.Count(s, "\n"); got != len(outcomes)+1 {
+		t.Errorf("LoginSummary() has %d lines, want %d:\n%s", got, len(outcomes)+1, s)
+	}
+}
+
+func TestCounterDelta(t *testing.T) {
+	if got, err := counterDelta("accept", 5, 7); got != 2 || err != nil {
+		t.Errorf("counterDelta(5, 7) = %d, %v, want 2, nil", got, err)
+	}
+	if got, err := counterDelta("accept", 7, 7); got != 0 || err != nil {
+		t.Errorf("counterDelta(7, 7) = %d, %v, want 0, nil", got, err)
+	}
+	if _, err := counterDelta("accept", 7, 1); err == nil {
+		t.Errorf("counterDelta(7, 1) succeeded, want a reset error")
+	}
+}
@@ -0,0 +1,327 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package credz
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/pem"
+	"fmt"
+	"math"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	// HIBAIdentityExtension is the host certificate extension holding HIBA
+	// identities.
+	HIBAIdentityExtension = "identity@hibassh.dev"
+	// HIBAGrantsExtension is the user certificate extension holding HIBA
+	// grants.
+	HIBAGrantsExtension = "grants@hibassh.dev"
+
+	hibaMagic      = 0x48494241 // "HIBA"
+	hibaVersion    = 2
+	hibaMinVersion = 1
+)
+
+// defaultUserExtensions are the extensions ssh-keygen grants user
+// certificates by default.
+var defaultUserExtensions = map[string]string{
+	"permit-X11-forwarding":   "",
+	"permit-agent-forwarding": "",
+	"permit-port-forwarding":  "",
+	"permit-pty":              "",
+	"permit-user-rc":          "",
+}
+
+// SSHKeyPair is an ssh key pair held in memory.
+type SSHKeyPair struct {
+	// Name is the comment of the key and the base name of its files.
+	Name string
+	// Key is the private key, an *ecdsa.PrivateKey, ed25519.PrivateKey or
+	// *rsa.PrivateKey.
+	Key crypto.Signer
+	// Signer signs with Key.
+	Signer ssh.Signer
+}
+
+// PublicKey returns the ssh public key of k.
+func (k *SSHKeyPair) PublicKey() ssh.PublicKey {
+	return k.Signer.PublicKey()
+}
+
+// AuthorizedKey returns the public key of k in authorized_keys format, as
+// written to its .pub file.
+func (k *SSHKeyPair) AuthorizedKey() []byte {
+	return authorizedLine(k.PublicKey(), k.Name)
+}
+
+// PrivateKeyPEM returns the private key of k in OpenSSH PEM format.
+func (k *SSHKeyPair) PrivateKeyPEM() ([]byte, error) {
+	block, err := ssh.MarshalPrivateKey(k.Key, k.Name)
+	if err != nil {
+		return nil, fmt.Errorf("could not marshal %s private key: %w", k.Name, err)
+	}
+	return pem.EncodeToMemory(block), nil
+}
+
+// WriteFiles writes the private and public keys of k to dir, named as
+// ssh-keygen names them.
+func (k *SSHKeyPair) WriteFiles(dir string) error {
+	priv, err := k.PrivateKeyPEM()
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dir, k.Name), priv, 0o600); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, k.Name+".pub"), k.AuthorizedKey(), 0o644)
+}
+
+func authorizedLine(pub ssh.PublicKey, comment string) []byte {
+	line := ssh.MarshalAuthorizedKey(pub)
+	if comment == "" {
+		return line
+	}
+	return append(line[:len(line)-1], []byte(" "+comment+"\n")...)
+}
+
+// GenerateSSHKeyPair generates a key pair of the given ssh-keygen key type:
+// "ed25519", "ecdsa" (P-256), "ecdsa-p256", "ecdsa-p384", "ecdsa-p521", "rsa"
+// (4096 bits), "rsa-2048", "rsa-3072" or "rsa-4096".
+func GenerateSSHKeyPair(name, algo string) (*SSHKeyPair, error) {
+	var key crypto.Signer
+	var err error
+	switch algo {
+	case "ed25519":
+		_, key, err = ed25519.GenerateKey(rand.Reader)
+	case "ecdsa", "ecdsa-p256":
+		key, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case "ecdsa-p384":
+		key, err = ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	case "ecdsa-p521":
+		key, err = ecdsa.GenerateKey(elliptic.P521(), rand.Reader)
+	case "rsa", "rsa-4096":
+		key, err = rsa.GenerateKey(rand.Reader, 4096)
+	case "rsa-2048":
+		key, err = rsa.GenerateKey(rand.Reader, 2048)
+	case "rsa-3072":
+		key, err = rsa.GenerateKey(rand.Reader, 3072)
+	default:
+		return nil, fmt.Errorf("unsupported ssh key algorithm %q", algo)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not generate %s key %s: %w", algo, name, err)
+	}
+	return newSSHKeyPair(name, key)
+}
+
+func newSSHKeyPair(name string, key crypto.Signer) (*SSHKeyPair, error) {
+	signer, err := ssh.NewSignerFromSigner(key)
+	if err != nil {
+		return nil, fmt.Errorf("could not create signer for key %s: %w", name, err)
+	}
+	return &SSHKeyPair{Name: name, Key: key, Signer: signer}, nil
+}
+
+// ReadSSHKeyPair reads the unencrypted private key name from dir.
+func ReadSSHKeyPair(dir, name string) (*SSHKeyPair, error) {
+	data, err := os.ReadFile(filepath.Join(dir, name))
+	if err != nil {
+		return nil, err
+	}
+	raw, err := ssh.ParseRawPrivateKey(data)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse private key %s: %w", name, err)
+	}
+	var key crypto.Signer
+	switch k := raw.(type) {
+	case *ed25519.PrivateKey:
+		key = *k
+	case crypto.Signer:
+		key = k
+	default:
+		return nil, fmt.Errorf("unsupported private key %s of type %T", name, raw)
+	}
+	return newSSHKeyPair(name, key)
+}
+
+// CertOptions are the contents of an ssh certificate.
+type CertOptions struct {
+	// KeyID identifies the certificate in logs of the server.
+	KeyID string
+	// Principals are the users or host names the certificate is valid for.
+	Principals []string
+	// ValidAfter and ValidBefore bound the validity of the certificate.  A
+	// zero ValidBefore makes it valid forever.
+	ValidAfter, ValidBefore time.Time
+	// CriticalOptions are the critical options of the certificate, e.g.
+	// "force-command" or "source-address".
+	CriticalOptions map[string]string
+	// Extensions are the extensions of the certificate.  User certificates
+	// without extensions get the ones ssh-keygen grants by default.
+	Extensions map[string]string
+}
+
+// NewUserCertificate signs a user certificate of pub with ca.
+func NewUserCertificate(ca *SSHKeyPair, pub ssh.PublicKey, opts *CertOptions) (*ssh.Certificate, error) {
+	return newCertificate(ca, pub, ssh.UserCert, opts)
+}
+
+// NewHostCertificate signs a host certificate of pub with ca.
+func NewHostCertificate(ca *SSHKeyPair, pub ssh.PublicKey, opts *CertOptions) (*ssh.Certificate, error) {
+	return newCertificate(ca, pub, ssh.HostCert, opts)
+}
+
+func newCertificate(ca *SSHKeyPair, pub ssh.PublicKey, certType uint32, opts *CertOptions) (*ssh.Certificate, error) {
+	serial, err := rand.Int(rand.Reader, new(big.Int).SetUint64(math.MaxUint64))
+	if err != nil {
+		return nil, fmt.Errorf("could not generate serial: %w", err)
+	}
+	extensions := opts.Extensions
+	if certType == ssh.UserCert && len(extensions) == 0 {
+		extensions = defaultUserExtensions
+	}
+	cert := &ssh.Certificate{
+		Key:             pub,
+		Serial:          serial.Uint64(),
+		CertType:        certType,
+		KeyId:           opts.KeyID,
+		ValidPrincipals: opts.Principals,
+		ValidAfter:      certTime(opts.ValidAfter, 0),
+		ValidBefore:     certTime(opts.ValidBefore, ssh.CertTimeInfinity),
+		Permissions: ssh.Permissions{
+			CriticalOptions: opts.CriticalOptions,
+			Extensions:      extensions,
+		},
+	}
+	if err := cert.SignCert(rand.Reader, ca.Signer); err != nil {
+		return nil, fmt.Errorf("could not sign certificate %s with ca %s: %w", opts.KeyID, ca.Name, err)
+	}
+	return cert, nil
+}
+
+func certTime(t time.Time, zero uint64) uint64 {
+	if t.IsZero() {
+		return zero
+	}
+	return uint64(t.Unix())
+}
+
+// WriteCertificate writes cert to dir as the certificate of key name, named
+// as ssh-keygen names it.
+func WriteCertificate(dir, name string, cert *ssh.Certificate) error {
+	return os.WriteFile(filepath.Join(dir, name+"-cert.pub"), authorizedLine(cert, name), 0o644)
+}
+
+// ParseValidity parses an ssh-keygen validity interval relative to now, such
+// as "-1d:+52w" or "+52w", into its bounds.  A single time is the end of an
+// interval starting now.  Units are s, m, h, d and w, and a number without
+// unit is in seconds.
+func ParseValidity(validity string, now time.Time) (after, before time.Time, err error) {
+	from, to, ok := strings.Cut(validity, ":")
+	if !ok {
+		from, to = "", validity
+	}
+	after = now
+	if from != "" {
+		if after, err = parseRelativeTime(from, now); err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+	}
+	if before, err = parseRelativeTime(to, now); err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	if !before.After(after) {
+		return time.Time{}, time.Time{}, fmt.Errorf("empty validity interval %q", validity)
+	}
+	return after, before, nil
+}
+
+func parseRelativeTime(s string, now time.Time) (time.Time, error) {
+	if len(s) < 2 || (s[0] != '+' && s[0] != '-') {
+		return time.Time{}, fmt.Errorf("invalid relative time %q", s)
+	}
+	sign := time.Duration(1)
+	if s[0] == '-' {
+		sign = -1
+	}
+	num, unit := s[1:], time.Second
+	switch num[len(num)-1] {
+	case 's':
+		num = num[:len(num)-1]
+	case 'm':
+		num, unit = num[:len(num)-1], time.Minute
+	case 'h':
+		num, unit = num[:len(num)-1], time.Hour
+	case 'd':
+		num, unit = num[:len(num)-1], 24*time.Hour
+	case 'w':
+		num, unit = num[:len(num)-1], 7*24*time.Hour
+	}
+	n, err := strconv.ParseUint(num, 10, 32)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid relative time %q: %w", s, err)
+	}
+	return now.Add(sign * time.Duration(n) * unit), nil
+}
+
+// HIBAExtension is a HIBA identity or grant: a set of attributes in a
+// domain.
+type HIBAExtension struct {
+	Domain string
+	// Attributes are the key and value pairs of the extension, in order.
+	// A key may be repeated.
+	Attributes [][2]string
+}
+
+// Encode returns the base64 form of e, as hiba-gen writes it.
+func (e *HIBAExtension) Encode() string {
+	pairs := append([][2]string{{"domain", e.Domain}}, e.Attributes...)
+	var b []byte
+	for _, v := range []uint32{hibaMagic, hibaVersion, hibaMinVersion, uint32(len(pairs))} {
+		b = binary.BigEndian.AppendUint32(b, v)
+	}
+	for _, p := range pairs {
+		for _, s := range p {
+			b = binary.BigEndian.AppendUint32(b, uint32(len(s)))
+			b = append(b, s...)
+		}
+	}
+	return base64.StdEncoding.EncodeToString(b)
+}
+
+// HIBAExtensionValue returns the value of a HIBA certificate extension
+// holding exts, as hiba-ca.sh attaches them.
+func HIBAExtensionValue(exts ...*HIBAExtension) string {
+	var encoded []string
+	for _, e := range exts {
+		encoded = append(encoded, e.Encode())
+	}
+	return strings.Join(encoded, ",")
+}
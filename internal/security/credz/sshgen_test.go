@@ -0,0 +1,212 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package credz
+
+import (
+	"bytes"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+)
+
+func TestGenerateSSHKeyPair(t *testing.T) {
+	tests := []struct {
+		algo     string
+		wantType string
+	}{
+		{"ed25519", ssh.KeyAlgoED25519},
+		{"ecdsa", ssh.KeyAlgoECDSA256},
+		{"ecdsa-p384", ssh.KeyAlgoECDSA384},
+		{"ecdsa-p521", ssh.KeyAlgoECDSA521},
+		{"rsa-2048", ssh.KeyAlgoRSA},
+	}
+	for _, tc := range tests {
+		t.Run(tc.algo, func(t *testing.T) {
+			dir := t.TempDir()
+			key, err := GenerateSSHKeyPair(userKey, tc.algo)
+			if err != nil {
+				t.Fatalf("GenerateSSHKeyPair() failed: %v", err)
+			}
+			if got := key.PublicKey().Type(); got != tc.wantType {
+				t.Errorf("PublicKey().Type() = %q, want %q", got, tc.wantType)
+			}
+			if err := key.WriteFiles(dir); err != nil {
+				t.Fatalf("WriteFiles() failed: %v", err)
+			}
+			read, err := ReadSSHKeyPair(dir, userKey)
+			if err != nil {
+				t.Fatalf("ReadSSHKeyPair() failed: %v", err)
+			}
+			if !bytes.Equal(read.PublicKey().Marshal(), key.PublicKey().Marshal()) {
+				t.Errorf("ReadSSHKeyPair() read a different key")
+			}
+			pub, err := os.ReadFile(filepath.Join(dir, userKey+".pub"))
+			if err != nil {
+				t.Fatalf("Reading public key failed: %v", err)
+			}
+			if fields := bytes.Fields(pub); len(fields) != 3 || string(fields[0]) != tc.wantType || string(fields[2]) != userKey {
+				t.Errorf("Public key file = %q, want type, key and comment %s", pub, userKey)
+			}
+		})
+	}
+	if _, err := GenerateSSHKeyPair(userKey, "dsa"); err == nil {
+		t.Errorf("GenerateSSHKeyPair(dsa) succeeded, want error")
+	}
+}
+
+func TestNewUserCertificate(t *testing.T) {
+	ca, err := GenerateSSHKeyPair(caKey, "ecdsa")
+	if err != nil {
+		t.Fatalf("GenerateSSHKeyPair() failed: %v", err)
+	}
+	user, err := GenerateSSHKeyPair(userKey, "ed25519")
+	if err != nil {
+		t.Fatalf("GenerateSSHKeyPair() failed: %v", err)
+	}
+	now := time.Now()
+	cert, err := NewUserCertificate(ca, user.PublicKey(), &CertOptions{
+		KeyID:           userKey,
+		Principals:      []string{"alice"},
+		ValidAfter:      now.Add(-time.Hour),
+		ValidBefore:     now.Add(time.Hour),
+		CriticalOptions: map[string]string{"source-address": "192.0.2.0/24"},
+	})
+	if err != nil {
+		t.Fatalf("NewUserCertificate() failed: %v", err)
+	}
+	if _, ok := cert.Extensions["permit-pty"]; !ok {
+		t.Errorf("NewUserCertificate() extensions = %v, want the ssh-keygen defaults", cert.Extensions)
+	}
+	checker := &ssh.CertChecker{
+		IsUserAuthority: func(auth ssh.PublicKey) bool {
+			return bytes.Equal(auth.Marshal(), ca.PublicKey().Marshal())
+		},
+		SupportedCriticalOptions: []string{"source-address"},
+	}
+	if err := checker.CheckCert("alice", cert); err != nil {
+		t.Errorf("CheckCert(alice) failed: %v", err)
+	}
+	if err := checker.CheckCert("bob", cert); err == nil {
+		t.Errorf("CheckCert(bob) succeeded, want error")
+	}
+	checker.Clock = func() time.Time { return now.Add(2 * time.Hour) }
+	if err := checker.CheckCert("alice", cert); err == nil {
+		t.Errorf("CheckCert() of an expired certificate succeeded, want error")
+	}
+
+	dir := t.TempDir()
+	if err := WriteCertificate(dir, userKey, cert); err != nil {
+		t.Fatalf("WriteCertificate() failed: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, userKey+"-cert.pub"))
+	if err != nil {
+		t.Fatalf("Reading certificate failed: %v", err)
+	}
+	parsed, _, _, _, err := ssh.ParseAuthorizedKey(data)
+	if err != nil {
+		t.Fatalf("ParseAuthorizedKey() failed: %v", err)
+	}
+	if _, ok := parsed.(*ssh.Certificate); !ok {
+		t.Errorf("ParseAuthorizedKey() = %T, want *ssh.Certificate", parsed)
+	}
+}
+
+func TestParseValidity(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	day, week := 24*time.Hour, 7*24*time.Hour
+	tests := []struct {
+		validity              string
+		wantAfter, wantBefore time.Time
+		wantErr               bool
+	}{
+		{validity: "-1d:+52w", wantAfter: now.Add(-day), wantBefore: now.Add(52 * week)},
+		{validity: "+52w", wantAfter: now, wantBefore: now.Add(52 * week)},
+		{validity: "-2w:-1w", wantAfter: now.Add(-2 * week), wantBefore: now.Add(-week)},
+		{validity: "-30m:+90", wantAfter: now.Add(-30 * time.Minute), wantBefore: now.Add(90 * time.Second)},
+		{validity: "-1w:-2w", wantErr: true},
+		{validity: "52w", wantErr: true},
+		{validity: "+1y", wantErr: true},
+	}
+	for _, tc := range tests {
+		after, before, err := ParseValidity(tc.validity, now)
+		if (err != nil) != tc.wantErr {
+			t.Errorf("ParseValidity(%q) error = %v, want error %v", tc.validity, err, tc.wantErr)
+			continue
+		}
+		if !after.Equal(tc.wantAfter) || !before.Equal(tc.wantBefore) {
+			t.Errorf("ParseValidity(%q) = %v, %v, want %v, %v", tc.validity, after, before, tc.wantAfter, tc.wantBefore)
+		}
+	}
+}
+
+func TestHIBAKeys(t *testing.T) {
+	keys, err := NewHIBAKeys()
+	if err != nil {
+		t.Fatalf("NewHIBAKeys() failed: %v", err)
+	}
+	grant, ok := keys.UserCert.Extensions[HIBAGrantsExtension]
+	if !ok {
+		t.Fatalf("User certificate extensions = %v, want %s", keys.UserCert.Extensions, HIBAGrantsExtension)
+	}
+	b, err := base64.StdEncoding.DecodeString(grant)
+	if err != nil {
+		t.Fatalf("Decoding grant failed: %v", err)
+	}
+	if !bytes.HasPrefix(b, []byte("HIBA")) || !bytes.Contains(b, []byte("google.com")) {
+		t.Errorf("Grant = %q, want a HIBA extension of domain google.com", b)
+	}
+	if keys.HostCert.CertType != ssh.HostCert {
+		t.Errorf("Host certificate type = %d, want %d", keys.HostCert.CertType, ssh.HostCert)
+	}
+	if _, ok := keys.HostCert.Extensions[HIBAIdentityExtension]; !ok {
+		t.Errorf("Host certificate extensions = %v, want %s", keys.HostCert.Extensions, HIBAIdentityExtension)
+	}
+
+	dir := t.TempDir()
+	if err := keys.WriteFiles(dir); err != nil {
+		t.Fatalf("WriteFiles() failed: %v", err)
+	}
+	for _, f := range []string{"ca", "ca.pub", "hosts/dut", "hosts/dut.pub", "hosts/dut-cert.pub", "users/testuser", "users/testuser.pub", "users/testuser-cert.pub"} {
+		if _, err := os.Stat(filepath.Join(dir, f)); err != nil {
+			t.Errorf("WriteFiles() did not write %s: %v", f, err)
+		}
+	}
+}
+
+func TestCreateUserCertificate(t *testing.T) {
+	dir := t.TempDir()
+	CreateSSHKeyPairAlgo(t, dir, caKey, "ed25519")
+	CreateSSHKeyPairAlgo(t, dir, userKey, "ecdsa")
+	CreateUserCertificateValidity(t, dir, "alice", "-2w:-1w")
+	data, err := os.ReadFile(filepath.Join(dir, userKey+"-cert.pub"))
+	if err != nil {
+		t.Fatalf("Reading certificate failed: %v", err)
+	}
+	pub, _, _, _, err := ssh.ParseAuthorizedKey(data)
+	if err != nil {
+		t.Fatalf("ParseAuthorizedKey() failed: %v", err)
+	}
+	cert := pub.(*ssh.Certificate)
+	if cert.KeyId != userKey || len(cert.ValidPrincipals) != 1 || cert.ValidPrincipals[0] != "alice" {
+		t.Errorf("Certificate id %q principals %v, want %q [alice]", cert.KeyId, cert.ValidPrincipals, userKey)
+	}
+	if before := time.Unix(int64(cert.ValidBefore), 0); !before.Before(time.Now()) {
+		t.Errorf("Certificate valid before %v, want expired", before)
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"sort"
+	"strings"
+)
+
+// execKind is what an exec function does.
+type execKind int
+
+const (
+	// execImplemented sends a request.
+	execImplemented execKind = iota
+	// execStub returns codes.Unimplemented.
+	execStub
+	// execNotExecuted returns ErrNotExecuted, as any request changes the
+	// DUT.
+	execNotExecuted
+)
+
+// scanExecFuncs returns the kind of the exported functions of file.
+func scanExecFuncs(file string) (map[string]execKind, error) {
+	f, err := parser.ParseFile(token.NewFileSet(), file, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+	execs := map[string]execKind{}
+	for _, decl := range f.Decls {
+		fd, ok := decl.(*ast.FuncDecl)
+		if !ok || fd.Recv != nil || !fd.Name.IsExported() {
+			continue
+		}
+		execs[fd.Name.Name] = kindOf(fd.Body)
+	}
+	return execs, nil
+}
+
+func kindOf(body *ast.BlockStmt) execKind {
+	kind := execImplemented
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.SelectorExpr:
+			if x, ok := n.X.(*ast.Ident); ok && x.Name == "codes" && n.Sel.Name == "Unimplemented" {
+				kind = execStub
+			}
+		case *ast.Ident:
+			if n.Name == "ErrNotExecuted" && kind == execImplemented {
+				kind = execNotExecuted
+			}
+		}
+		return true
+	})
+	return kind
+}
+
+// report is the exec function coverage of the catalog.  Wildcards are not
+// counted as they are never executed.
+type report struct {
+	Implemented int
+	// NotExecuted, Stubs and Generated are the paths of the RPCs whose exec
+	// function is of that kind.
+	NotExecuted []string
+	Stubs       []string
+	Generated   []string
+	// Missing are the paths of the RPCs without exec function, when
+	// skeletons are not generated.
+	Missing []string
+	// Orphans are the exec functions of RPCs no longer in the catalog.
+	Orphans []string
+}
+
+func coverage(rpcs []*rpc, execs map[string]execKind, generated map[string]bool) *report {
+	r := &report{}
+	used := map[string]bool{}
+	for _, rpc := range rpcs {
+		name := funcName(rpc.Service, rpc.Name)
+		used[name] = true
+		if rpc.isWildcard() {
+			continue
+		}
+		kind, ok := execs[name]
+		switch {
+		case generated[name]:
+			r.Generated = append(r.Generated, rpc.Path)
+		case !ok:
+			r.Missing = append(r.Missing, rpc.Path)
+		case kind == execStub:
+			r.Stubs = append(r.Stubs, rpc.Path)
+		case kind == execNotExecuted:
+			r.NotExecuted = append(r.NotExecuted, rpc.Path)
+		default:
+			r.Implemented++
+		}
+	}
+	for name := range execs {
+		if !used[name] {
+			r.Orphans = append(r.Orphans, name)
+		}
+	}
+	sort.Strings(r.Orphans)
+	return r
+}
+
+func (r *report) String() string {
+	var b strings.Builder
+	total := r.Implemented + len(r.NotExecuted) + len(r.Stubs) + len(r.Generated) + len(r.Missing)
+	fmt.Fprintf(&b, "Exec function coverage of %d RPCs: %d implemented, %d not executed, %d stubs, %d generated (not executed without -gnxi_exec_generated), %d missing\n",
+		total, r.Implemented, len(r.NotExecuted), len(r.Stubs), len(r.Generated), len(r.Missing))
+	for _, section := range []struct {
+		title string
+		items []string
+	}{
+		{"Stubs returning Unimplemented", r.Stubs},
+		{"Generated skeletons sending a minimal request, to be reviewed", r.Generated},
+		{"Without exec function", r.Missing},
+		{"Not executed as any request changes the DUT", r.NotExecuted},
+		{"Exec functions of RPCs not in the catalog", r.Orphans},
+	} {
+		if len(section.items) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "\n%s:\n", section.title)
+		for _, item := range section.items {
+			fmt.Fprintf(&b, "  %s\n", item)
+		}
+	}
+	return b.String()
+}
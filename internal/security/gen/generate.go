@@ -13,280 +13,583 @@
 // limitations under the License.
 
 // package main generate  data structure and skeleton function for all rpc related to fp.
+//
+// The services of the catalog are discovered from the proto files linked into
+// the generator whose package is selected by --packages.  Exec functions are
+// written by hand in rpcexec.go; the generator writes a skeleton sending a
+// minimal request for every RPC that has none to rpcexec_gen.go, and reports
+// the RPCs whose exec function is still a stub or a generated skeleton.
+// Skeletons return ErrNotExecuted until they are reviewed and moved to
+// rpcexec.go, as a minimal request may change the DUT.
 package main
 
 import (
-	"bufio"
+	"bytes"
 	"flag"
 	"fmt"
+	"go/format"
 	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
 	"strings"
 	"text/template"
 
-	"github.com/openconfig/featureprofiles/internal/security/gnxi"
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
 	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
 
-	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
-	accpb "github.com/openconfig/gnsi/acctz"
-	authzpb "github.com/openconfig/gnsi/authz"
-	certzpb "github.com/openconfig/gnsi/certz"
-	credpb "github.com/openconfig/gnsi/credentialz"
-	pathzpb "github.com/openconfig/gnsi/pathz"
-	gribipb "github.com/openconfig/gribi/v1/proto/service"
-
-	bpb "github.com/openconfig/gnoi/bgp"
-	dpb "github.com/openconfig/gnoi/diag"
-	frpb "github.com/openconfig/gnoi/factory_reset"
-	fpb "github.com/openconfig/gnoi/file"
-	hpb "github.com/openconfig/gnoi/healthz"
-	lpb "github.com/openconfig/gnoi/layer2"
-	mpb "github.com/openconfig/gnoi/mpls"
-	ospb "github.com/openconfig/gnoi/os"
-	otpb "github.com/openconfig/gnoi/otdr"
-	plqpb "github.com/openconfig/gnoi/packet_link_qualification"
-	spb "github.com/openconfig/gnoi/system"
-	wpb "github.com/openconfig/gnoi/wavelength_router"
-	p4rtpb "github.com/p4lang/p4runtime/go/p4/v1"
+	// The proto packages the catalog can be built from.
+	_ "github.com/openconfig/attestz/proto/tpm_attestz"
+	_ "github.com/openconfig/attestz/proto/tpm_enrollz"
+	_ "github.com/openconfig/gnmi/proto/gnmi"
+	_ "github.com/openconfig/gnoi/bgp"
+	_ "github.com/openconfig/gnoi/bootconfig"
+	_ "github.com/openconfig/gnoi/cert"
+	_ "github.com/openconfig/gnoi/containerz"
+	_ "github.com/openconfig/gnoi/debug"
+	_ "github.com/openconfig/gnoi/diag"
+	_ "github.com/openconfig/gnoi/factory_reset"
+	_ "github.com/openconfig/gnoi/file"
+	_ "github.com/openconfig/gnoi/healthz"
+	_ "github.com/openconfig/gnoi/layer2"
+	_ "github.com/openconfig/gnoi/mpls"
+	_ "github.com/openconfig/gnoi/os"
+	_ "github.com/openconfig/gnoi/otdr"
+	_ "github.com/openconfig/gnoi/packet_capture"
+	_ "github.com/openconfig/gnoi/packet_link_qualification"
+	_ "github.com/openconfig/gnoi/system"
+	_ "github.com/openconfig/gnoi/wavelength_router"
+	_ "github.com/openconfig/gnpsi/proto/gnpsi"
+	_ "github.com/openconfig/gnsi/acctz"
+	_ "github.com/openconfig/gnsi/authz"
+	_ "github.com/openconfig/gnsi/certz"
+	_ "github.com/openconfig/gnsi/credentialz"
+	_ "github.com/openconfig/gnsi/pathz"
+	_ "github.com/openconfig/gribi/v1/proto/service"
+	_ "github.com/p4lang/p4runtime/go/p4/v1"
 
 	log "github.com/golang/glog"
 )
 
 var (
 	srcFolder   = flag.String("src_folder", ".", "The directory where the generated source code will be saved")
-	genExecFunc = flag.Bool("gen_exec_func", true, "if set to true, the skeleton for exec function with be generated")
+	genExecFunc = flag.Bool("gen_exec_func", true, "if set to true, the skeleton for exec functions missing from rpcexec.go will be generated")
 	pkgName     = flag.String("pkg_name", "gnxi", "The name of the package for the generated source code")
+	packages    = flag.String("packages", defaultPackages, "Comma separated proto packages whose services are added to the catalog, each as package=endpoint where endpoint is the ondatra introspect service serving it. A package selects its subpackages too.")
+	reportFile  = flag.String("report", "", "The file the exec function coverage report is written to, standard output if empty")
 )
 
-var (
-	services = map[string]protoreflect.ServiceDescriptors{
-		"gnmi":                    gnmipb.File_github_com_openconfig_gnmi_proto_gnmi_gnmi_proto.Services(),
-		"gribi":                   gribipb.File_v1_proto_service_gribi_proto.Services(),
-		"gnsi.authz":              authzpb.File_github_com_openconfig_gnsi_authz_authz_proto.Services(),
-		"gnsi.certz":              certzpb.File_github_com_openconfig_gnsi_certz_certz_proto.Services(),
-		"gnsi.pathz":              pathzpb.File_github_com_openconfig_gnsi_pathz_pathz_proto.Services(),
-		"gnsi.cred":               credpb.File_github_com_openconfig_gnsi_credentialz_credentialz_proto.Services(),
-		"gnsi.acc":                accpb.File_github_com_openconfig_gnsi_acctz_acctz_proto.Services(),
-		"gnoi.bgp":                bpb.File_github_com_openconfig_gnoi_bgp_bgp_proto.Services(),
-		"gnoi.diag":               dpb.File_github_com_openconfig_gnoi_diag_diag_proto.Services(),
-		"gnoi.factory_reset":      frpb.File_github_com_openconfig_gnoi_factory_reset_factory_reset_proto.Services(),
-		"gnoi.file":               fpb.File_github_com_openconfig_gnoi_file_file_proto.Services(),
-		"gnoi.healthz":            hpb.File_github_com_openconfig_gnoi_healthz_healthz_proto.Services(),
-		"gnoi.layer2":             lpb.File_github_com_openconfig_gnoi_layer2_layer2_proto.Services(),
-		"gnoi.mppls":              mpb.File_github_com_openconfig_gnoi_mpls_mpls_proto.Services(),
-		"gnoi.os":                 ospb.File_github_com_openconfig_gnoi_os_os_proto.Services(),
-		"gnoi.otdr":               otpb.File_github_com_openconfig_gnoi_otdr_otdr_proto.Services(),
-		"gnoi.link_qualification": plqpb.File_github_com_openconfig_gnoi_packet_link_qualification_packet_link_qualification_proto.Services(),
-		"gnoi.system":             spb.File_github_com_openconfig_gnoi_system_system_proto.Services(),
-		"gnoi.wpb":                wpb.File_github_com_openconfig_gnoi_wavelength_router_wavelength_router_proto.Services(),
-		"p4rt":                    p4rtpb.File_p4_v1_p4runtime_proto.Services(),
-	}
+const (
+	defaultPackages = "gnmi=gNMI,gribi=gRIBI,gnsi=gNSI,gnoi=gNOI,p4.v1=P4RT,openconfig.attestz=gNSI,gnpsi=gNPSI"
+
+	rpcsFile    = "rpcs.go"
+	execFile    = "rpcexec.go"
+	execGenFile = "rpcexec_gen.go"
 )
 
-func main() {
+// endpoints are the ondatra introspect constants of the well-known endpoints.
+var endpoints = map[string]string{
+	"gNMI":  "introspect.GNMI",
+	"gNOI":  "introspect.GNOI",
+	"gNPSI": "introspect.GNPSI",
+	"gNSI":  "introspect.GNSI",
+	"gRIBI": "introspect.GRIBI",
+	"P4RT":  "introspect.P4RT",
+}
+
+// protoPackage is a proto package of the catalog and the endpoint serving its
+// services.
+type protoPackage struct {
+	Name     string
+	Endpoint string
+}
 
-	rpcMap := make(map[string]*gnxi.RPC)
-	p4rtpb.File_p4_v1_p4data_proto.Services()
-	// add * that represent all grpc rpcs
-	rpcMap["ALL"] = &gnxi.RPC{
-		Name:    "*",
-		Service: "*",
-		FQN:     "*",
-		Path:    "*",
-	}
-	for serviceName, service := range services {
-		if service.Len() == 0 {
-			log.Warningf("service %s has no rpc methods\n", serviceName)
+func parsePackages(s string) ([]protoPackage, error) {
+	var pkgs []protoPackage
+	for _, p := range strings.Split(s, ",") {
+		name, endpoint, ok := strings.Cut(strings.TrimSpace(p), "=")
+		if !ok || name == "" || endpoint == "" {
+			return nil, fmt.Errorf("invalid package %q, want package=endpoint", p)
 		}
-		for i := 0; i < service.Len(); i++ {
-			log.Infof("Service %s RPCs are: \n", serviceName)
-			for j := 0; j < service.Get(i).Methods().Len(); j++ {
-				rpcName := fmt.Sprintf("%v", service.Get(i).Methods().Get(j).Name())
-				enumName := fmt.Sprintf("%s_%s", strings.ToUpper(serviceName), strings.ToUpper(rpcName))
-				rpcFQN := fmt.Sprintf("%v", service.Get(i).Methods().Get(j).FullName())
-				path := ""
-				lastPointIdx := strings.LastIndex(rpcFQN, ".")
-				if lastPointIdx > 0 {
-					path = "/" + rpcFQN[:lastPointIdx] + "/" + rpcFQN[lastPointIdx+1:]
-				}
-				serviceName = rpcFQN[:lastPointIdx]
-				rpcMap[enumName] = &gnxi.RPC{
-					Name:    rpcName,
-					Service: serviceName,
-					FQN:     rpcFQN,
-					Path:    path,
-				}
-				log.Infof("\t RPC is %v\n", rpcMap[enumName])
-				log.Infof("\t %s: %s \n", service.Get(i).Methods().Get(j).Name(), service.Get(i).Methods().Get(j).FullName())
+		pkgs = append(pkgs, protoPackage{Name: name, Endpoint: endpoint})
+	}
+	return pkgs, nil
+}
+
+// endpointOf returns the endpoint of the longest package of pkgs that is pkg
+// or a parent of it.
+func endpointOf(pkgs []protoPackage, pkg string) (string, bool) {
+	best := -1
+	for i, p := range pkgs {
+		if pkg != p.Name && !strings.HasPrefix(pkg, p.Name+".") {
+			continue
+		}
+		if best < 0 || len(p.Name) > len(pkgs[best].Name) {
+			best = i
+		}
+	}
+	if best < 0 {
+		return "", false
+	}
+	return pkgs[best].Endpoint, true
+}
 
+// rpc is an RPC of the catalog.
+type rpc struct {
+	Name    string
+	Service string
+	FQN     string
+	Path    string
+	// Endpoint is the introspect service serving the RPC, empty for "*".
+	Endpoint string
+	// Method describes the RPC, nil for wildcards.
+	Method protoreflect.MethodDescriptor
+}
+
+func (r *rpc) isWildcard() bool {
+	return r.Name == "*"
+}
+
+func wildcard(service, endpoint string) *rpc {
+	if service == "*" {
+		return &rpc{Name: "*", Service: "*", FQN: "*", Path: "*"}
+	}
+	return &rpc{Name: "*", Service: service, FQN: service + ".*", Path: "/" + service + "/*", Endpoint: endpoint}
+}
+
+// discover returns the RPCs of the services of files whose package is
+// selected by pkgs, sorted by path, with a wildcard per service and one for
+// all RPCs.
+func discover(files *protoregistry.Files, pkgs []protoPackage) ([]*rpc, error) {
+	rpcs := []*rpc{wildcard("*", "")}
+	matched := map[string]bool{}
+	files.RangeFiles(func(fd protoreflect.FileDescriptor) bool {
+		endpoint, ok := endpointOf(pkgs, string(fd.Package()))
+		if !ok {
+			return true
+		}
+		for i := 0; i < fd.Services().Len(); i++ {
+			sd := fd.Services().Get(i)
+			service := string(sd.FullName())
+			log.Infof("Service %s RPCs are:", service)
+			rpcs = append(rpcs, wildcard(service, endpoint))
+			for j := 0; j < sd.Methods().Len(); j++ {
+				md := sd.Methods().Get(j)
+				r := &rpc{
+					Name:     string(md.Name()),
+					Service:  service,
+					FQN:      string(md.FullName()),
+					Path:     "/" + service + "/" + string(md.Name()),
+					Endpoint: endpoint,
+					Method:   md,
+				}
+				log.Infof("\t RPC is %v", r.Path)
+				rpcs = append(rpcs, r)
 			}
-			// add service/* for each service that represents all RPC for the service
-			rpcMap[strings.ToUpper(serviceName)+"_ALL"] = &gnxi.RPC{
-				Name:    "*",
-				Service: serviceName,
-				FQN:     serviceName + ".*",
-				Path:    "/" + serviceName + "/*",
+			for _, p := range pkgs {
+				if _, ok := endpointOf([]protoPackage{p}, string(fd.Package())); ok {
+					matched[p.Name] = true
+				}
 			}
 		}
-
+		return true
+	})
+	for _, p := range pkgs {
+		if !matched[p.Name] {
+			log.Warningf("package %s has no service linked into the generator", p.Name)
+		}
+	}
+	sort.Slice(rpcs, func(i, j int) bool { return rpcs[i].Path < rpcs[j].Path })
+	names := map[string]string{}
+	for _, r := range rpcs {
+		name := funcName(r.Service, r.Name)
+		if other, ok := names[name]; ok {
+			return nil, fmt.Errorf("rpcs %s and %s have the same exec function name %s", other, r.Path, name)
+		}
+		names[name] = r.Path
 	}
+	return rpcs, nil
+}
 
-	rpcsCodeFile, err := os.Create(*srcFolder + "/rpcs.go")
+func main() {
+	flag.Parse()
+	pkgs, err := parsePackages(*packages)
 	if err != nil {
-		panic(err)
+		log.Exit(err)
 	}
-	defer rpcsCodeFile.Close()
-	writer := bufio.NewWriter(rpcsCodeFile)
-	codeGenForEnums, err := template.New("codeGenForEnums").Funcs(funcMap).Parse(authzTemplateRPCs)
-	if err == nil {
-		codeGenForEnums.Execute(writer, rpcMap)
-	} else {
-		panic(fmt.Sprintf("Code generation for RPC enums failed: %v", err))
+	rpcs, err := discover(protoregistry.GlobalFiles, pkgs)
+	if err != nil {
+		log.Exit(err)
+	}
+	execs, err := scanExecFuncs(filepath.Join(*srcFolder, execFile))
+	if err != nil {
+		log.Exitf("Could not read exec functions: %v", err)
 	}
-	writer.Flush()
 
+	var missing []*rpc
+	for _, r := range rpcs {
+		if _, ok := execs[funcName(r.Service, r.Name)]; !ok {
+			missing = append(missing, r)
+		}
+	}
+	generated := map[string]bool{}
 	if *genExecFunc {
-		rpcExecCodeFile, err := os.Create(*srcFolder + "/rpcexec.go")
-		if err != nil {
-			panic(err)
+		if err := writeExecSkeletons(filepath.Join(*srcFolder, execGenFile), missing); err != nil {
+			log.Exitf("Code generation for RPC exec functions failed: %v", err)
 		}
-		defer rpcExecCodeFile.Close()
-		writer := bufio.NewWriter(rpcExecCodeFile)
-		codeGenForExecFunction, err := template.New("codeGenForExecFunction").Funcs(funcMap).Parse(authzTemplateRPCExec)
-		if err == nil {
-			codeGenForExecFunction.Execute(writer, rpcMap)
-		} else {
-			panic(fmt.Sprintf("Code generation for RPC exec functions failed: %v", err))
+		for _, r := range missing {
+			generated[funcName(r.Service, r.Name)] = true
 		}
-		writer.Flush()
 	}
 
+	src, err := genRPCs(rpcs, func(name string) bool {
+		_, ok := execs[name]
+		return ok || generated[name]
+	})
+	if err != nil {
+		log.Exitf("Code generation for RPC enums failed: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(*srcFolder, rpcsFile), src, 0o644); err != nil {
+		log.Exit(err)
+	}
+
+	report := coverage(rpcs, execs, generated).String()
+	if *reportFile == "" {
+		fmt.Print(report)
+		return
+	}
+	if err := os.WriteFile(*reportFile, []byte(report), 0o644); err != nil {
+		log.Exit(err)
+	}
 }
 
-var (
-	funcMap = template.FuncMap{
-		"pkgName": func() string {
-			return *pkgName
-		},
-		"varName": func(service, name string) string {
-			varName := ""
-			if service != "*" {
-				parts := strings.Split(service, ".")
-				prevPart := ""
-				for _, part := range parts {
-					if !strings.EqualFold(part, prevPart) {
-						varName += part
-						prevPart = part
-					}
-				}
-			}
-			if name != "*" {
-				varName += name
-			} else {
-				varName += "ALL"
-			}
-			return varName
-		},
-		"funcName": func(service, name string) string {
-			if !*genExecFunc {
-				return "nil"
+// writeExecSkeletons writes the exec functions of rpcs to file, or removes
+// file if there are none.
+func writeExecSkeletons(file string, rpcs []*rpc) error {
+	if len(rpcs) == 0 {
+		if err := os.Remove(file); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+	src, err := genExecSkeletons(rpcs)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(file, src, 0o644)
+}
+
+func funcName(service, name string) string {
+	caser := cases.Title(language.English)
+	funcName := ""
+	if service != "*" {
+		parts := strings.Split(service, ".")
+		if len(parts) >= 1 {
+			funcName += caser.String(parts[0])
+			if len(parts) > 2 {
+				funcName += caser.String(parts[len(parts)-1])
 			}
-			caser := cases.Title(language.English)
-			funcName := ""
-			if service != "*" {
-				parts := strings.Split(service, ".")
-				if len(parts) >= 1 {
-					funcName += caser.String(parts[0])
-					if len(parts) > 2 {
-						funcName += caser.String(parts[len(parts)-1])
-					}
-				}
+		}
+	}
+	if name != "*" {
+		funcName += name
+	} else {
+		funcName += "AllRPC"
+	}
+	return funcName
+}
+
+func varName(service, name string) string {
+	varName := ""
+	if service != "*" {
+		parts := strings.Split(service, ".")
+		prevPart := ""
+		for _, part := range parts {
+			if !strings.EqualFold(part, prevPart) {
+				varName += part
+				prevPart = part
 			}
-			if name != "*" {
-				funcName += name
-			} else {
-				funcName += "AllRPC"
+		}
+	}
+	if name != "*" {
+		varName += name
+	} else {
+		varName += "ALL"
+	}
+	return varName
+}
+
+func execute(tmpl string, funcs template.FuncMap, data any) ([]byte, error) {
+	t, err := template.New("gen").Funcs(funcs).Parse(tmpl)
+	if err != nil {
+		return nil, err
+	}
+	var b bytes.Buffer
+	if err := t.Execute(&b, data); err != nil {
+		return nil, err
+	}
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("generated code does not parse: %w", err)
+	}
+	return src, nil
+}
+
+// genRPCs returns the source of the catalog.  RPCs whose exec function does
+// not exist have a nil Exec.
+func genRPCs(rpcs []*rpc, hasExec func(string) bool) ([]byte, error) {
+	return execute(authzTemplateRPCs, template.FuncMap{
+		"pkgName":  func() string { return *pkgName },
+		"varName":  varName,
+		"funcName": funcName,
+		"execName": func(service, name string) string {
+			if f := funcName(service, name); hasExec(f) {
+				return f
 			}
-			return funcName
+			return "nil"
 		},
-		"enumName": func(service, name string) string {
-			varName := ""
-			if service != "*" {
-				parts := strings.Split(service, ".")
-				prevPart := ""
-				for _, part := range parts {
-					if !strings.EqualFold(part, prevPart) {
-						varName += part + "_"
-						prevPart = part
-					}
-				}
-			}
-			if name != "*" {
-				varName += name
-			} else {
-				varName += "ALL"
+	}, rpcs)
+}
+
+// skeleton is the data of the generated exec function of an RPC.
+type skeleton struct {
+	*rpc
+	Func string
+	// Dial is the introspect service expression of the endpoint.
+	Dial string
+	// Client is the qualified constructor of the client of the service.
+	Client string
+	// Request is the qualified type of the request.
+	Request string
+	// GoMethod is the name of the method of the client.
+	GoMethod                         string
+	ClientStreaming, ServerStreaming bool
+}
+
+// goCamelCase returns the Go name protoc-gen-go-grpc gives a proto service or
+// method: underscores are dropped and the letters after them and the first
+// letter are upper-cased.
+func goCamelCase(s string) string {
+	var b strings.Builder
+	upper := true
+	for _, c := range s {
+		switch {
+		case c == '_':
+			upper = true
+		case upper:
+			b.WriteString(strings.ToUpper(string(c)))
+			upper = false
+		default:
+			b.WriteRune(c)
+		}
+	}
+	return b.String()
+}
+
+// goIdent returns the import path and name of the Go type of message md.
+func goIdent(md protoreflect.MessageDescriptor) (string, string, error) {
+	mt, err := protoregistry.GlobalTypes.FindMessageByName(md.FullName())
+	if err != nil {
+		return "", "", fmt.Errorf("no Go type for message %s: %w", md.FullName(), err)
+	}
+	t := reflect.TypeOf(mt.Zero().Interface()).Elem()
+	return t.PkgPath(), t.Name(), nil
+}
+
+// imports assigns import aliases to Go packages.
+type imports map[string]string
+
+func (im imports) alias(path string) string {
+	if a, ok := im[path]; ok {
+		return a
+	}
+	base := strings.NewReplacer("_", "", "-", "", ".", "").Replace(filepath.Base(path)) + "pb"
+	a := base
+	for i := 2; im.taken(a); i++ {
+		a = fmt.Sprintf("%s%d", base, i)
+	}
+	im[path] = a
+	return a
+}
+
+func (im imports) taken(alias string) bool {
+	for _, a := range im {
+		if a == alias {
+			return true
+		}
+	}
+	return false
+}
+
+// genExecSkeletons returns the source of the exec functions of rpcs.
+// Wildcards get a stub, as they cannot be executed.  Other RPCs get a
+// function dialing the endpoint of the RPC and sending a request with no
+// field set, which returns ErrNotExecuted unless the gnxi_exec_generated
+// flag is set: a request with no field set may still act on the DUT.
+func genExecSkeletons(rpcs []*rpc) ([]byte, error) {
+	im := imports{}
+	var skels []*skeleton
+	for _, r := range rpcs {
+		s := &skeleton{rpc: r, Func: funcName(r.Service, r.Name)}
+		skels = append(skels, s)
+		if r.isWildcard() {
+			continue
+		}
+		s.Dial = endpoints[r.Endpoint]
+		if s.Dial == "" {
+			s.Dial = fmt.Sprintf("introspect.Service(%q)", r.Endpoint)
+		}
+		reqPath, reqName, err := goIdent(r.Method.Input())
+		if err != nil {
+			return nil, err
+		}
+		s.Request = im.alias(reqPath) + "." + reqName
+		// The client is generated into the Go package of the messages of the
+		// file of the service.
+		clientPath := reqPath
+		if msgs := r.Method.Parent().ParentFile().Messages(); msgs.Len() > 0 {
+			if clientPath, _, err = goIdent(msgs.Get(0)); err != nil {
+				return nil, err
 			}
-			return strings.ToTitle(varName)
-		},
+		}
+		s.Client = im.alias(clientPath) + ".New" + goCamelCase(string(r.Method.Parent().Name())) + "Client"
+		s.GoMethod = goCamelCase(r.Name)
+		s.ClientStreaming, s.ServerStreaming = r.Method.IsStreamingClient(), r.Method.IsStreamingServer()
+	}
+	stubs := false
+	for _, s := range skels {
+		stubs = stubs || s.isWildcard()
 	}
+	type importSpec struct{ Alias, Path string }
+	var specs []importSpec
+	for path, alias := range im {
+		specs = append(specs, importSpec{alias, path})
+	}
+	sort.Slice(specs, func(i, j int) bool { return specs[i].Path < specs[j].Path })
+	return execute(authzTemplateRPCExec, template.FuncMap{
+		"pkgName": func() string { return *pkgName },
+	}, struct {
+		Imports   []importSpec
+		Skeletons []*skeleton
+		Dial      bool
+		Stubs     bool
+	}{specs, skels, len(specs) > 0, stubs})
+}
 
-	authzTemplateRPCExec = `package {{pkgName}}
+var (
+	authzTemplateRPCExec = `// Code generated by ../gen/generate.go. DO NOT EDIT.
+
+// Exec functions in this file send a minimal request that has not been
+// reviewed and may change the DUT, so they return ErrNotExecuted unless
+// the gnxi_exec_generated flag is set.  Move them to rpcexec.go once they are reviewed,
+// dropping the check and passing rejected the codes the service rejects the
+// request with.
+package {{pkgName}}
 
 import (
 	"context"
 
 	"github.com/openconfig/ondatra"
+	{{- if .Dial}}
+	"github.com/openconfig/ondatra/binding/introspect"
+	{{- end}}
 	"google.golang.org/grpc"
+	{{- if .Stubs}}
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	{{- end}}
+	{{range .Imports}}
+	{{.Alias}} "{{.Path}}"
+	{{- end}}
 )
 
+{{- range .Skeletons}}
 
-{{- range .}}
-// function {{funcName .Service .Name}} implements a sample request for service {{.Path}} to validate if authz works as expected.
-func {{ funcName .Service .Name}}(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, params ...any)  error  {
+// {{.Func}} implements a sample request for service {{.Path}} to validate if authz works as expected.
+{{- if .Dial}}
+func {{.Func}}(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	if !*execGenerated {
+		return notReviewed("{{.Path}}")
+	}
+	conn, err := dialService(ctx, dut, {{.Dial}}, opts)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	{{- if and .ClientStreaming .ServerStreaming}}
+	ctx, cancel := context.WithTimeout(ctx, streamWait)
+	defer cancel()
+	stream, err := {{.Client}}(conn).{{.GoMethod}}(ctx)
+	if err != nil {
+		return err
+	}
+	return waited(closeAndDrain(stream))
+	{{- else if .ClientStreaming}}
+	stream, err := {{.Client}}(conn).{{.GoMethod}}(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = stream.CloseAndRecv()
+	return rejected(err)
+	{{- else if .ServerStreaming}}
+	ctx, cancel := context.WithTimeout(ctx, streamWait)
+	defer cancel()
+	stream, err := {{.Client}}(conn).{{.GoMethod}}(ctx, &{{.Request}}{})
+	if err != nil {
+		return rejected(err)
+	}
+	return waited(drain(stream))
+	{{- else}}
+	_, err = {{.Client}}(conn).{{.GoMethod}}(ctx, &{{.Request}}{})
+	return rejected(err)
+	{{- end}}
+}
+{{- else}}
+func {{.Func}}(_ context.Context, _ *ondatra.DUTDevice, _ []grpc.DialOption, _ ...any) error {
 	return status.Errorf(codes.Unimplemented, "exec function for RPC {{.Path}} is not implemented")
 }
-{{ end }}
+{{- end}}
+{{- end}}
 `
-	authzTemplateRPCs = `
-// This following provide a list of all RPCs related to FP testing.
+	authzTemplateRPCs = `// Package gnxi populates a list of all RPCs related for featuresprofile tests.
 // The below code is generated using ../gen/generate.go. Please do not modify.
 package {{pkgName}}
-	type rpcs struct{
-	{{- range .}}
-		{{ funcName .Service .Name}}   *RPC
-	{{- end }}
-	}
 
+type rpcs struct {
+	{{- range .}}
+	{{funcName .Service .Name}} *RPC
+	{{- end}}
+}
 
-	var (
-		// ALL defines all FP related RPCs
+var (
+	{{- range .}}
+	{{- if eq .Path "*"}}
+	// ALL defines all FP related RPCs
+	{{- end}}
+	{{varName .Service .Name}} = &RPC{
+		Name:    "{{.Name}}",
+		Service: "{{.Service}}",
+		FQN:     "{{.FQN}}",
+		Path:    "{{.Path}}",
+		Exec:    {{execName .Service .Name}},
+	}
+	{{- end}}
 
+	// RPCs is a list of all FP related RPCs
+	RPCs = rpcs{
 		{{- range .}}
-		{{ varName .Service .Name}} = &RPC{
-			Name: "{{.Name}}",
-			Service: "{{.Service}}",
-			FQN: "{{.FQN}}",
-			Path: "{{.Path}}",
-			Exec: {{ funcName .Service .Name}},
-		}
-		{{- end }}
+		{{funcName .Service .Name}}: {{varName .Service .Name}},
+		{{- end}}
+	}
 
-		// RPCs is a list of all FP related RPCs
-		RPCs=rpcs{
+	// RPCMAP is a helper that  maps path to RPCs data that may be needed in tests.
+	RPCMAP = map[string]*RPC{
 		{{- range .}}
-			{{ funcName .Service .Name}} : {{ varName .Service .Name}},
-		{{- end }}
-		}
-
-		// RPCMAP is a helper that  maps path to RPCs data that may be needed in tests.
-		RPCMAP=map[string]*RPC{
-			{{- range .}}
-				"{{.Path}}" : {{ varName .Service .Name}},
-			{{- end }}
-		}
-	)
+		"{{.Path}}": {{varName .Service .Name}},
+		{{- end}}
+	}
+)
 `
 )
@@ -0,0 +1,179 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+func TestEndpointOf(t *testing.T) {
+	pkgs, err := parsePackages("gnoi=gNOI, gnoi.containerz=containerz,gnsi=gNSI")
+	if err != nil {
+		t.Fatalf("parsePackages() failed: %v", err)
+	}
+	tests := []struct {
+		pkg    string
+		want   string
+		wantOK bool
+	}{
+		{"gnoi.system", "gNOI", true},
+		{"gnoi.containerz", "containerz", true},
+		{"gnsi.authz.v1", "gNSI", true},
+		{"gnoigo", "", false},
+		{"gribi", "", false},
+	}
+	for _, tc := range tests {
+		got, ok := endpointOf(pkgs, tc.pkg)
+		if got != tc.want || ok != tc.wantOK {
+			t.Errorf("endpointOf(%q) = %q, %v, want %q, %v", tc.pkg, got, ok, tc.want, tc.wantOK)
+		}
+	}
+	if _, err := parsePackages("gnoi"); err == nil {
+		t.Errorf("parsePackages(gnoi) succeeded, want error")
+	}
+}
+
+func TestDiscover(t *testing.T) {
+	pkgs, err := parsePackages(defaultPackages)
+	if err != nil {
+		t.Fatalf("parsePackages() failed: %v", err)
+	}
+	rpcs, err := discover(protoregistry.GlobalFiles, pkgs)
+	if err != nil {
+		t.Fatalf("discover() failed: %v", err)
+	}
+	paths := map[string]*rpc{}
+	for _, r := range rpcs {
+		paths[r.Path] = r
+	}
+	for path, endpoint := range map[string]string{
+		"/gnmi.gNMI/Get":                                   "gNMI",
+		"/gnoi.containerz.Containerz/Deploy":               "gNOI",
+		"/openconfig.attestz.TpmEnrollzService/GetIakCert": "gNSI",
+		"/gnpsi.gNPSI/Subscribe":                           "gNPSI",
+		"/gnoi.system.System/*":                            "gNOI",
+	} {
+		r, ok := paths[path]
+		if !ok {
+			t.Errorf("discover() did not find %s", path)
+			continue
+		}
+		if r.Endpoint != endpoint {
+			t.Errorf("discover() endpoint of %s = %q, want %q", path, r.Endpoint, endpoint)
+		}
+	}
+	if rpcs[0].Path != "*" {
+		t.Errorf("discover()[0] = %s, want *", rpcs[0].Path)
+	}
+}
+
+func TestGenExecSkeletons(t *testing.T) {
+	pkgs, err := parsePackages(defaultPackages)
+	if err != nil {
+		t.Fatalf("parsePackages() failed: %v", err)
+	}
+	rpcs, err := discover(protoregistry.GlobalFiles, pkgs)
+	if err != nil {
+		t.Fatalf("discover() failed: %v", err)
+	}
+	var deploy []*rpc
+	for _, r := range rpcs {
+		if r.Path == "/gnoi.containerz.Containerz/Deploy" {
+			deploy = append(deploy, r)
+		}
+	}
+	src, err := genExecSkeletons(deploy)
+	if err != nil {
+		t.Fatalf("genExecSkeletons() failed: %v", err)
+	}
+	guard := "if !*execGenerated {\n\t\treturn notReviewed(\"/gnoi.containerz.Containerz/Deploy\")\n\t}\n\tconn, err := dialService("
+	if !strings.Contains(string(src), guard) {
+		t.Errorf("genExecSkeletons() does not check the review before dialing:\n%s", src)
+	}
+}
+
+func TestGoCamelCase(t *testing.T) {
+	for in, want := range map[string]string{
+		"gNPSI":          "GNPSI",
+		"Containerz":     "Containerz",
+		"get_iak_cert":   "GetIakCert",
+		"RotateOIakCert": "RotateOIakCert",
+	} {
+		if got := goCamelCase(in); got != want {
+			t.Errorf("goCamelCase(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestCoverage(t *testing.T) {
+	src := `package gnxi
+
+func GnmiGet(ctx context.Context) error {
+	_, err := c.Get(ctx, req)
+	return err
+}
+
+func GnmiSet(_ context.Context) error {
+	return status.Errorf(codes.Unimplemented, "not implemented")
+}
+
+func GnoiFactoryresetStart(_ context.Context) error {
+	return ErrNotExecuted
+}
+
+func GnsiAccountingpullRecordStream(_ context.Context) error {
+	return nil
+}
+
+func helper() {}
+`
+	file := filepath.Join(t.TempDir(), "rpcexec.go")
+	if err := os.WriteFile(file, []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	execs, err := scanExecFuncs(file)
+	if err != nil {
+		t.Fatalf("scanExecFuncs() failed: %v", err)
+	}
+	rpcs := []*rpc{
+		wildcard("*", ""),
+		{Name: "Get", Service: "gnmi.gNMI", Path: "/gnmi.gNMI/Get"},
+		{Name: "Set", Service: "gnmi.gNMI", Path: "/gnmi.gNMI/Set"},
+		{Name: "Subscribe", Service: "gnmi.gNMI", Path: "/gnmi.gNMI/Subscribe"},
+		{Name: "Start", Service: "gnoi.factory_reset.FactoryReset", Path: "/gnoi.factory_reset.FactoryReset/Start"},
+		{Name: "Deploy", Service: "gnoi.containerz.Containerz", Path: "/gnoi.containerz.Containerz/Deploy"},
+	}
+	got := coverage(rpcs, execs, map[string]bool{"GnoiContainerzDeploy": true})
+	want := &report{
+		Implemented: 1,
+		NotExecuted: []string{"/gnoi.factory_reset.FactoryReset/Start"},
+		Stubs:       []string{"/gnmi.gNMI/Set"},
+		Generated:   []string{"/gnoi.containerz.Containerz/Deploy"},
+		Missing:     []string{"/gnmi.gNMI/Subscribe"},
+		Orphans:     []string{"GnsiAccountingpullRecordStream"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("coverage() diff (-want +got):\n%s", diff)
+	}
+	if s := got.String(); !strings.HasPrefix(s, "Exec function coverage of 5 RPCs: 1 implemented, 1 not executed, 1 stubs, 1 generated (not executed without -gnxi_exec_generated), 1 missing\n") {
+		t.Errorf("String() = %q", s)
+	}
+}
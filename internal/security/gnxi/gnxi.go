@@ -21,9 +21,24 @@ package gnxi
 
 import (
 	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/binding"
+	"github.com/openconfig/ondatra/binding/introspect"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	tpb "github.com/openconfig/gnoi/types"
 )
 
 // ExecRPCFunction is a function that is used to provide an implementation for an RPC.
@@ -43,3 +58,113 @@ type RPC struct {
 	// a function that takes an grpc config (must include mtls cfg) and dut and executes the RPC against the dut.
 	Exec ExecRPCFunction
 }
+
+// ErrNotExecuted is returned by the exec functions of RPCs that cannot be
+// called without side effects on the DUT, such as a factory reset.
+var ErrNotExecuted = errors.New("rpc is not executed as any request to it changes the dut")
+
+// execGenerated enables the exec functions generated into rpcexec_gen.go.
+// They send a minimal request that has not been reviewed and may change the
+// DUT, so they return ErrNotExecuted unless it is set while reviewing them.
+var execGenerated = flag.Bool("gnxi_exec_generated", false, "Set to true to run the exec functions generated into rpcexec_gen.go, which send minimal requests that have not been reviewed and may change the DUT.")
+
+// notReviewed returns the error of the generated exec function of rpc path
+// while it is not reviewed.
+func notReviewed(path string) error {
+	return fmt.Errorf("generated exec function of rpc %s is not reviewed: %w", path, ErrNotExecuted)
+}
+
+// Exec functions that modify the DUT refer to entities that do not exist, so
+// that an authorized request is rejected by the service and changes nothing.
+const (
+	nonexistent         = "authz-exec-nonexistent"
+	nonexistentFile     = "/tmp/authz-exec-nonexistent"
+	nonexistentAddr     = "192.0.2.255"
+	nonexistentDeviceID = math.MaxUint64
+	// streamWait is how long an exec function waits on a stream that may
+	// not send anything.
+	streamWait = 10 * time.Second
+)
+
+func nonexistentComponent() *tpb.Path {
+	return &tpb.Path{Elem: []*tpb.PathElem{{Name: "components"}, {Name: "component", Key: map[string]string{"name": nonexistent}}}}
+}
+
+func nonexistentInterface() *tpb.Path {
+	return &tpb.Path{Elem: []*tpb.PathElem{{Name: "interfaces"}, {Name: "interface", Key: map[string]string{"name": nonexistent}}}}
+}
+
+// rejected returns nil if err has one of the codes allowed, which show
+// that the request passed authorization and was then rejected by the
+// service, e.g. for referring to an entity that does not exist, and err
+// otherwise.  Each exec function allows only the codes its RPC answers its
+// request with.
+func rejected(err error, allowed ...codes.Code) error {
+	if slices.Contains(allowed, status.Code(err)) {
+		return nil
+	}
+	return err
+}
+
+// waited returns nil if err shows that a stream passed authorization and
+// was still open when the wait for it ended, and rejected(err, allowed...)
+// otherwise.
+func waited(err error, allowed ...codes.Code) error {
+	if status.Code(err) == codes.DeadlineExceeded {
+		return nil
+	}
+	return rejected(err, allowed...)
+}
+
+// dialService dials the endpoint of service on dut, for the exec functions
+// of services the binding has no client for.
+func dialService(ctx context.Context, dut *ondatra.DUTDevice, service introspect.Service, opts []grpc.DialOption) (*grpc.ClientConn, error) {
+	var i introspect.Introspector
+	if err := binding.DUTAs(dut.RawAPIs().BindingDUT(), &i); err != nil {
+		return nil, err
+	}
+	d, err := i.Dialer(service)
+	if err != nil {
+		return nil, err
+	}
+	return d.Dial(ctx, opts...)
+}
+
+// drain receives from a stream until it ends.
+func drain[T any](s interface{ Recv() (T, error) }) error {
+	for {
+		if _, err := s.Recv(); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+	}
+}
+
+// closeAndDrain closes the send direction of a bidirectional stream without
+// sending anything and receives from it until it ends.
+func closeAndDrain[T any](s interface {
+	CloseSend() error
+	Recv() (T, error)
+}) error {
+	if err := s.CloseSend(); err != nil {
+		return err
+	}
+	return drain[T](s)
+}
+
+// IsWildcard reports whether rpc stands for all RPCs of a service, or all
+// RPCs, rather than a single RPC that can be executed.
+func (rpc *RPC) IsWildcard() bool {
+	return strings.HasSuffix(rpc.Path, "*")
+}
+
+// SortedRPCs returns the RPCs of RPCMAP sorted by path.
+func SortedRPCs() []*RPC {
+	var rpcs []*RPC
+	for _, rpc := range RPCMAP {
+		rpcs = append(rpcs, rpc)
+	}
+	sort.Slice(rpcs, func(i, j int) bool { return rpcs[i].Path < rpcs[j].Path })
+	return rpcs
+}
@@ -7,16 +7,39 @@ import (
 	"time"
 
 	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/binding/introspect"
 	"github.com/openconfig/ondatra/gnmi"
 	"github.com/openconfig/ygnmi/ygnmi"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	cdpb "github.com/openconfig/attestz/proto/common_definitions"
+	enrollzpb "github.com/openconfig/attestz/proto/tpm_enrollz"
 	gpb "github.com/openconfig/gnmi/proto/gnmi"
+	bpb "github.com/openconfig/gnoi/bgp"
+	bcpb "github.com/openconfig/gnoi/bootconfig"
+	cmpb "github.com/openconfig/gnoi/cert"
+	czpb "github.com/openconfig/gnoi/containerz"
+	dpb "github.com/openconfig/gnoi/diag"
+	fpb "github.com/openconfig/gnoi/file"
+	hpb "github.com/openconfig/gnoi/healthz"
+	lpb "github.com/openconfig/gnoi/layer2"
+	mpb "github.com/openconfig/gnoi/mpls"
+	ospb "github.com/openconfig/gnoi/os"
+	otpb "github.com/openconfig/gnoi/otdr"
+	plqpb "github.com/openconfig/gnoi/packet_link_qualification"
 	spb "github.com/openconfig/gnoi/system"
+	tpb "github.com/openconfig/gnoi/types"
+	wrpb "github.com/openconfig/gnoi/wavelength_router"
+	acctzpb "github.com/openconfig/gnsi/acctz"
 	authzpb "github.com/openconfig/gnsi/authz"
+	certzpb "github.com/openconfig/gnsi/certz"
+	credzpb "github.com/openconfig/gnsi/credentialz"
+	pathzpb "github.com/openconfig/gnsi/pathz"
 	grpb "github.com/openconfig/gribi/v1/proto/service"
+	p4pb "github.com/p4lang/p4runtime/go/p4/v1"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // AllRPC implements a sample request for service * to validate if authz works as expected.
@@ -98,8 +121,14 @@ func GnoiBgpAllRPC(_ context.Context, _ *ondatra.DUTDevice, _ []grpc.DialOption,
 }
 
 // GnoiBgpClearBGPNeighbor implements a sample request for service /gnoi.bgp.BGP/ClearBGPNeighbor to validate if authz works as expected.
-func GnoiBgpClearBGPNeighbor(_ context.Context, _ *ondatra.DUTDevice, _ []grpc.DialOption, _ ...any) error {
-	return status.Errorf(codes.Unimplemented, "exec function for RPC /gnoi.bgp.BGP/ClearBGPNeighbor is not implemented")
+func GnoiBgpClearBGPNeighbor(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	gnoiC, err := dut.RawAPIs().BindingDUT().DialGNOI(ctx, opts...)
+	if err != nil {
+		return err
+	}
+	// Soft clear of a neighbor that does not exist.
+	_, err = gnoiC.BGP().ClearBGPNeighbor(ctx, &bpb.ClearBGPNeighborRequest{Address: nonexistentAddr, RoutingInstance: nonexistent, Mode: bpb.ClearBGPNeighborRequest_SOFT})
+	return rejected(err, codes.NotFound, codes.InvalidArgument)
 }
 
 // GnoiDiagAllRPC implements a sample request for service /gnoi.diag.Diag/* to validate if authz works as expected.
@@ -108,18 +137,34 @@ func GnoiDiagAllRPC(_ context.Context, _ *ondatra.DUTDevice, _ []grpc.DialOption
 }
 
 // GnoiDiagGetBERTResult implements a sample request for service /gnoi.diag.Diag/GetBERTResult to validate if authz works as expected.
-func GnoiDiagGetBERTResult(_ context.Context, _ *ondatra.DUTDevice, _ []grpc.DialOption, _ ...any) error {
-	return status.Errorf(codes.Unimplemented, "exec function for RPC /gnoi.diag.Diag/GetBERTResult is not implemented")
+func GnoiDiagGetBERTResult(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	gnoiC, err := dut.RawAPIs().BindingDUT().DialGNOI(ctx, opts...)
+	if err != nil {
+		return err
+	}
+	_, err = gnoiC.Diag().GetBERTResult(ctx, &dpb.GetBERTResultRequest{BertOperationId: nonexistent})
+	return rejected(err, codes.NotFound, codes.InvalidArgument)
 }
 
 // GnoiDiagStopBERT implements a sample request for service /gnoi.diag.Diag/StopBERT to validate if authz works as expected.
-func GnoiDiagStopBERT(_ context.Context, _ *ondatra.DUTDevice, _ []grpc.DialOption, _ ...any) error {
-	return status.Errorf(codes.Unimplemented, "exec function for RPC /gnoi.diag.Diag/StopBERT is not implemented")
+func GnoiDiagStopBERT(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	gnoiC, err := dut.RawAPIs().BindingDUT().DialGNOI(ctx, opts...)
+	if err != nil {
+		return err
+	}
+	_, err = gnoiC.Diag().StopBERT(ctx, &dpb.StopBERTRequest{BertOperationId: nonexistent})
+	return rejected(err, codes.NotFound, codes.InvalidArgument)
 }
 
 // GnoiDiagStartBERT implements a sample request for service /gnoi.diag.Diag/StartBERT to validate if authz works as expected.
-func GnoiDiagStartBERT(_ context.Context, _ *ondatra.DUTDevice, _ []grpc.DialOption, _ ...any) error {
-	return status.Errorf(codes.Unimplemented, "exec function for RPC /gnoi.diag.Diag/StartBERT is not implemented")
+func GnoiDiagStartBERT(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	gnoiC, err := dut.RawAPIs().BindingDUT().DialGNOI(ctx, opts...)
+	if err != nil {
+		return err
+	}
+	// A BERT without ports is rejected before any port is touched.
+	_, err = gnoiC.Diag().StartBERT(ctx, &dpb.StartBERTRequest{BertOperationId: nonexistent})
+	return rejected(err, codes.InvalidArgument)
 }
 
 // GnoiFactoryresetAllRPC implements a sample request for service /gnoi.factory_reset.FactoryReset/* to validate if authz works as expected.
@@ -129,7 +174,25 @@ func GnoiFactoryresetAllRPC(_ context.Context, _ *ondatra.DUTDevice, _ []grpc.Di
 
 // GnoiFactoryresetStart implements a sample request for service /gnoi.factory_reset.FactoryReset/Start to validate if authz works as expected.
 func GnoiFactoryresetStart(_ context.Context, _ *ondatra.DUTDevice, _ []grpc.DialOption, _ ...any) error {
-	return status.Errorf(codes.Unimplemented, "exec function for RPC /gnoi.factory_reset.FactoryReset/Start is not implemented")
+	// Every Start request is valid and wipes the DUT, so it is never sent.
+	return ErrNotExecuted
+}
+
+// GnoiBootconfigSetBootConfig implements a sample request for service /gnoi.bootconfig.BootConfig/SetBootConfig to validate if authz works as expected.
+func GnoiBootconfigSetBootConfig(_ context.Context, _ *ondatra.DUTDevice, _ []grpc.DialOption, _ ...any) error {
+	// A request without boot config may replace the boot config of the DUT, so it is never sent.
+	return ErrNotExecuted
+}
+
+// GnoiBootconfigGetBootConfig implements a sample request for service /gnoi.bootconfig.BootConfig/GetBootConfig to validate if authz works as expected.
+func GnoiBootconfigGetBootConfig(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	conn, err := dialService(ctx, dut, introspect.GNOI, opts)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	_, err = bcpb.NewBootConfigClient(conn).GetBootConfig(ctx, &bcpb.GetBootConfigRequest{})
+	return err
 }
 
 // GnoiFileAllRPC implements a sample request for service /gnoi.file.File/* to validate if authz works as expected.
@@ -138,33 +201,71 @@ func GnoiFileAllRPC(_ context.Context, _ *ondatra.DUTDevice, _ []grpc.DialOption
 }
 
 // GnoiFilePut implements a sample request for service /gnoi.file.File/Put to validate if authz works as expected.
-func GnoiFilePut(_ context.Context, _ *ondatra.DUTDevice, _ []grpc.DialOption, _ ...any) error {
-	return status.Errorf(codes.Unimplemented, "exec function for RPC /gnoi.file.File/Put is not implemented")
+func GnoiFilePut(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	gnoiC, err := dut.RawAPIs().BindingDUT().DialGNOI(ctx, opts...)
+	if err != nil {
+		return err
+	}
+	putC, err := gnoiC.File().Put(ctx)
+	if err != nil {
+		return err
+	}
+	// A Put without the open message writes nothing.
+	_, err = putC.CloseAndRecv()
+	return rejected(err, codes.InvalidArgument)
 }
 
 // GnoiFileRemove implements a sample request for service /gnoi.file.File/Remove to validate if authz works as expected.
-func GnoiFileRemove(_ context.Context, _ *ondatra.DUTDevice, _ []grpc.DialOption, _ ...any) error {
-	return status.Errorf(codes.Unimplemented, "exec function for RPC /gnoi.file.File/Remove is not implemented")
+func GnoiFileRemove(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	gnoiC, err := dut.RawAPIs().BindingDUT().DialGNOI(ctx, opts...)
+	if err != nil {
+		return err
+	}
+	_, err = gnoiC.File().Remove(ctx, &fpb.RemoveRequest{RemoteFile: nonexistentFile})
+	return rejected(err, codes.NotFound)
 }
 
 // GnoiFileStat implements a sample request for service /gnoi.file.File/Stat to validate if authz works as expected.
-func GnoiFileStat(_ context.Context, _ *ondatra.DUTDevice, _ []grpc.DialOption, _ ...any) error {
-	return status.Errorf(codes.Unimplemented, "exec function for RPC /gnoi.file.File/Stat is not implemented")
+func GnoiFileStat(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	gnoiC, err := dut.RawAPIs().BindingDUT().DialGNOI(ctx, opts...)
+	if err != nil {
+		return err
+	}
+	_, err = gnoiC.File().Stat(ctx, &fpb.StatRequest{Path: nonexistentFile})
+	return rejected(err, codes.NotFound)
 }
 
 // GnoiFileTransferToRemote implements a sample request for service /gnoi.file.File/TransferToRemote to validate if authz works as expected.
-func GnoiFileTransferToRemote(_ context.Context, _ *ondatra.DUTDevice, _ []grpc.DialOption, _ ...any) error {
-	return status.Errorf(codes.Unimplemented, "exec function for RPC /gnoi.file.File/TransferToRemote is not implemented")
+func GnoiFileTransferToRemote(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	gnoiC, err := dut.RawAPIs().BindingDUT().DialGNOI(ctx, opts...)
+	if err != nil {
+		return err
+	}
+	_, err = gnoiC.File().TransferToRemote(ctx, &fpb.TransferToRemoteRequest{LocalPath: nonexistentFile})
+	return rejected(err, codes.NotFound, codes.InvalidArgument)
 }
 
 // GnoiFileGet implements a sample request for service /gnoi.file.File/Get to validate if authz works as expected.
-func GnoiFileGet(_ context.Context, _ *ondatra.DUTDevice, _ []grpc.DialOption, _ ...any) error {
-	return status.Errorf(codes.Unimplemented, "exec function for RPC /gnoi.file.File/Get is not implemented")
+func GnoiFileGet(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	gnoiC, err := dut.RawAPIs().BindingDUT().DialGNOI(ctx, opts...)
+	if err != nil {
+		return err
+	}
+	getC, err := gnoiC.File().Get(ctx, &fpb.GetRequest{RemoteFile: nonexistentFile})
+	if err != nil {
+		return err
+	}
+	return rejected(drain(getC), codes.NotFound)
 }
 
 // GnoiHealthzAcknowledge implements a sample request for service /gnoi.healthz.Healthz/Acknowledge to validate if authz works as expected.
-func GnoiHealthzAcknowledge(_ context.Context, _ *ondatra.DUTDevice, _ []grpc.DialOption, _ ...any) error {
-	return status.Errorf(codes.Unimplemented, "exec function for RPC /gnoi.healthz.Healthz/Acknowledge is not implemented")
+func GnoiHealthzAcknowledge(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	gnoiC, err := dut.RawAPIs().BindingDUT().DialGNOI(ctx, opts...)
+	if err != nil {
+		return err
+	}
+	_, err = gnoiC.Healthz().Acknowledge(ctx, &hpb.AcknowledgeRequest{Path: nonexistentComponent(), Id: nonexistent})
+	return rejected(err, codes.NotFound, codes.InvalidArgument)
 }
 
 // GnoiHealthzAllRPC implements a sample request for service /gnoi.healthz.Healthz/* to validate if authz works as expected.
@@ -173,23 +274,46 @@ func GnoiHealthzAllRPC(_ context.Context, _ *ondatra.DUTDevice, _ []grpc.DialOpt
 }
 
 // GnoiHealthzArtifact implements a sample request for service /gnoi.healthz.Healthz/Artifact to validate if authz works as expected.
-func GnoiHealthzArtifact(_ context.Context, _ *ondatra.DUTDevice, _ []grpc.DialOption, _ ...any) error {
-	return status.Errorf(codes.Unimplemented, "exec function for RPC /gnoi.healthz.Healthz/Artifact is not implemented")
+func GnoiHealthzArtifact(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	gnoiC, err := dut.RawAPIs().BindingDUT().DialGNOI(ctx, opts...)
+	if err != nil {
+		return err
+	}
+	artifactC, err := gnoiC.Healthz().Artifact(ctx, &hpb.ArtifactRequest{Id: nonexistent})
+	if err != nil {
+		return err
+	}
+	return rejected(drain(artifactC), codes.NotFound, codes.InvalidArgument)
 }
 
 // GnoiHealthzCheck implements a sample request for service /gnoi.healthz.Healthz/Check to validate if authz works as expected.
-func GnoiHealthzCheck(_ context.Context, _ *ondatra.DUTDevice, _ []grpc.DialOption, _ ...any) error {
-	return status.Errorf(codes.Unimplemented, "exec function for RPC /gnoi.healthz.Healthz/Check is not implemented")
+func GnoiHealthzCheck(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	gnoiC, err := dut.RawAPIs().BindingDUT().DialGNOI(ctx, opts...)
+	if err != nil {
+		return err
+	}
+	_, err = gnoiC.Healthz().Check(ctx, &hpb.CheckRequest{Path: nonexistentComponent()})
+	return rejected(err, codes.NotFound, codes.InvalidArgument)
 }
 
 // GnoiHealthzList implements a sample request for service /gnoi.healthz.Healthz/List to validate if authz works as expected.
-func GnoiHealthzList(_ context.Context, _ *ondatra.DUTDevice, _ []grpc.DialOption, _ ...any) error {
-	return status.Errorf(codes.Unimplemented, "exec function for RPC /gnoi.healthz.Healthz/List is not implemented")
+func GnoiHealthzList(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	gnoiC, err := dut.RawAPIs().BindingDUT().DialGNOI(ctx, opts...)
+	if err != nil {
+		return err
+	}
+	_, err = gnoiC.Healthz().List(ctx, &hpb.ListRequest{Path: nonexistentComponent()})
+	return rejected(err, codes.NotFound, codes.InvalidArgument)
 }
 
 // GnoiHealthzGet implements a sample request for service /gnoi.healthz.Healthz/Get to validate if authz works as expected.
-func GnoiHealthzGet(_ context.Context, _ *ondatra.DUTDevice, _ []grpc.DialOption, _ ...any) error {
-	return status.Errorf(codes.Unimplemented, "exec function for RPC /gnoi.healthz.Healthz/Get is not implemented")
+func GnoiHealthzGet(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	gnoiC, err := dut.RawAPIs().BindingDUT().DialGNOI(ctx, opts...)
+	if err != nil {
+		return err
+	}
+	_, err = gnoiC.Healthz().Get(ctx, &hpb.GetRequest{Path: nonexistentComponent()})
+	return rejected(err, codes.NotFound, codes.InvalidArgument)
 }
 
 // GnoiLayer2AllRPC implements a sample request for service /gnoi.layer2.Layer2/* to validate if authz works as expected.
@@ -198,33 +322,67 @@ func GnoiLayer2AllRPC(_ context.Context, _ *ondatra.DUTDevice, _ []grpc.DialOpti
 }
 
 // GnoiLayer2ClearLLDPInterface implements a sample request for service /gnoi.layer2.Layer2/ClearLLDPInterface to validate if authz works as expected.
-func GnoiLayer2ClearLLDPInterface(_ context.Context, _ *ondatra.DUTDevice, _ []grpc.DialOption, _ ...any) error {
-	return status.Errorf(codes.Unimplemented, "exec function for RPC /gnoi.layer2.Layer2/ClearLLDPInterface is not implemented")
+func GnoiLayer2ClearLLDPInterface(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	gnoiC, err := dut.RawAPIs().BindingDUT().DialGNOI(ctx, opts...)
+	if err != nil {
+		return err
+	}
+	_, err = gnoiC.Layer2().ClearLLDPInterface(ctx, &lpb.ClearLLDPInterfaceRequest{Interface: nonexistentInterface()})
+	return rejected(err, codes.NotFound, codes.InvalidArgument)
 }
 
 // GnoiLayer2ClearSpanningTree implements a sample request for service /gnoi.layer2.Layer2/ClearSpanningTree to validate if authz works as expected.
-func GnoiLayer2ClearSpanningTree(_ context.Context, _ *ondatra.DUTDevice, _ []grpc.DialOption, _ ...any) error {
-	return status.Errorf(codes.Unimplemented, "exec function for RPC /gnoi.layer2.Layer2/ClearSpanningTree is not implemented")
+func GnoiLayer2ClearSpanningTree(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	gnoiC, err := dut.RawAPIs().BindingDUT().DialGNOI(ctx, opts...)
+	if err != nil {
+		return err
+	}
+	_, err = gnoiC.Layer2().ClearSpanningTree(ctx, &lpb.ClearSpanningTreeRequest{Interface: nonexistentInterface()})
+	return rejected(err, codes.NotFound, codes.InvalidArgument)
 }
 
 // GnoiLayer2PerformBERT implements a sample request for service /gnoi.layer2.Layer2/PerformBERT to validate if authz works as expected.
-func GnoiLayer2PerformBERT(_ context.Context, _ *ondatra.DUTDevice, _ []grpc.DialOption, _ ...any) error {
-	return status.Errorf(codes.Unimplemented, "exec function for RPC /gnoi.layer2.Layer2/PerformBERT is not implemented")
+func GnoiLayer2PerformBERT(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	gnoiC, err := dut.RawAPIs().BindingDUT().DialGNOI(ctx, opts...)
+	if err != nil {
+		return err
+	}
+	bertC, err := gnoiC.Layer2().PerformBERT(ctx, &lpb.PerformBERTRequest{Id: nonexistent, Interface: nonexistentInterface()})
+	if err != nil {
+		return err
+	}
+	return rejected(drain(bertC), codes.NotFound, codes.InvalidArgument)
 }
 
 // GnoiLayer2SendWakeOnLAN implements a sample request for service /gnoi.layer2.Layer2/SendWakeOnLAN to validate if authz works as expected.
-func GnoiLayer2SendWakeOnLAN(_ context.Context, _ *ondatra.DUTDevice, _ []grpc.DialOption, _ ...any) error {
-	return status.Errorf(codes.Unimplemented, "exec function for RPC /gnoi.layer2.Layer2/SendWakeOnLAN is not implemented")
+func GnoiLayer2SendWakeOnLAN(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	gnoiC, err := dut.RawAPIs().BindingDUT().DialGNOI(ctx, opts...)
+	if err != nil {
+		return err
+	}
+	_, err = gnoiC.Layer2().SendWakeOnLAN(ctx, &lpb.SendWakeOnLANRequest{Interface: nonexistentInterface()})
+	return rejected(err, codes.NotFound, codes.InvalidArgument)
 }
 
 // GnoiLayer2ClearNeighborDiscovery implements a sample request for service /gnoi.layer2.Layer2/ClearNeighborDiscovery to validate if authz works as expected.
-func GnoiLayer2ClearNeighborDiscovery(_ context.Context, _ *ondatra.DUTDevice, _ []grpc.DialOption, _ ...any) error {
-	return status.Errorf(codes.Unimplemented, "exec function for RPC /gnoi.layer2.Layer2/ClearNeighborDiscovery is not implemented")
+func GnoiLayer2ClearNeighborDiscovery(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	gnoiC, err := dut.RawAPIs().BindingDUT().DialGNOI(ctx, opts...)
+	if err != nil {
+		return err
+	}
+	_, err = gnoiC.Layer2().ClearNeighborDiscovery(ctx, &lpb.ClearNeighborDiscoveryRequest{Protocol: tpb.L3Protocol_IPV4, Address: nonexistentAddr})
+	return rejected(err, codes.NotFound, codes.InvalidArgument)
 }
 
 // GnoiLinkqualificationCreate implements a sample request for service /gnoi.packet_link_qualification.LinkQualification/Create to validate if authz works as expected.
-func GnoiLinkqualificationCreate(_ context.Context, _ *ondatra.DUTDevice, _ []grpc.DialOption, _ ...any) error {
-	return status.Errorf(codes.Unimplemented, "exec function for RPC /gnoi.packet_link_qualification.LinkQualification/Create is not implemented")
+func GnoiLinkqualificationCreate(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	gnoiC, err := dut.RawAPIs().BindingDUT().DialGNOI(ctx, opts...)
+	if err != nil {
+		return err
+	}
+	// A Create without interfaces qualifies no link.
+	_, err = gnoiC.LinkQualification().Create(ctx, &plqpb.CreateRequest{})
+	return rejected(err, codes.InvalidArgument)
 }
 
 // GnoiMplsAllRPC implements a sample request for service /gnoi.mpls.MPLS/* to validate if authz works as expected.
@@ -233,18 +391,36 @@ func GnoiMplsAllRPC(_ context.Context, _ *ondatra.DUTDevice, _ []grpc.DialOption
 }
 
 // GnoiMplsClearLSPCounters implements a sample request for service /gnoi.mpls.MPLS/ClearLSPCounters to validate if authz works as expected.
-func GnoiMplsClearLSPCounters(_ context.Context, _ *ondatra.DUTDevice, _ []grpc.DialOption, _ ...any) error {
-	return status.Errorf(codes.Unimplemented, "exec function for RPC /gnoi.mpls.MPLS/ClearLSPCounters is not implemented")
+func GnoiMplsClearLSPCounters(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	gnoiC, err := dut.RawAPIs().BindingDUT().DialGNOI(ctx, opts...)
+	if err != nil {
+		return err
+	}
+	_, err = gnoiC.MPLS().ClearLSPCounters(ctx, &mpb.ClearLSPCountersRequest{Name: nonexistent})
+	return rejected(err, codes.NotFound, codes.InvalidArgument)
 }
 
 // GnoiMplsMPLSPing implements a sample request for service /gnoi.mpls.MPLS/MPLSPing to validate if authz works as expected.
-func GnoiMplsMPLSPing(_ context.Context, _ *ondatra.DUTDevice, _ []grpc.DialOption, _ ...any) error {
-	return status.Errorf(codes.Unimplemented, "exec function for RPC /gnoi.mpls.MPLS/MPLSPing is not implemented")
+func GnoiMplsMPLSPing(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	gnoiC, err := dut.RawAPIs().BindingDUT().DialGNOI(ctx, opts...)
+	if err != nil {
+		return err
+	}
+	pingC, err := gnoiC.MPLS().MPLSPing(ctx, &mpb.MPLSPingRequest{Destination: &mpb.MPLSPingRequest_RsvpteLspName{RsvpteLspName: nonexistent}, Count: 1})
+	if err != nil {
+		return err
+	}
+	return rejected(drain(pingC), codes.NotFound, codes.InvalidArgument)
 }
 
 // GnoiMplsClearLSP implements a sample request for service /gnoi.mpls.MPLS/ClearLSP to validate if authz works as expected.
-func GnoiMplsClearLSP(_ context.Context, _ *ondatra.DUTDevice, _ []grpc.DialOption, _ ...any) error {
-	return status.Errorf(codes.Unimplemented, "exec function for RPC /gnoi.mpls.MPLS/ClearLSP is not implemented")
+func GnoiMplsClearLSP(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	gnoiC, err := dut.RawAPIs().BindingDUT().DialGNOI(ctx, opts...)
+	if err != nil {
+		return err
+	}
+	_, err = gnoiC.MPLS().ClearLSP(ctx, &mpb.ClearLSPRequest{Name: nonexistent, Mode: mpb.ClearLSPRequest_NONAGGRESSIVE})
+	return rejected(err, codes.NotFound, codes.InvalidArgument)
 }
 
 // GnoiOtdrAllRPC implements a sample request for service /gnoi.optical.OTDR/* to validate if authz works as expected.
@@ -253,8 +429,16 @@ func GnoiOtdrAllRPC(_ context.Context, _ *ondatra.DUTDevice, _ []grpc.DialOption
 }
 
 // GnoiWavelengthrouterAdjustSpectrum implements a sample request for service /gnoi.optical.WavelengthRouter/AdjustSpectrum to validate if authz works as expected.
-func GnoiWavelengthrouterAdjustSpectrum(_ context.Context, _ *ondatra.DUTDevice, _ []grpc.DialOption, _ ...any) error {
-	return status.Errorf(codes.Unimplemented, "exec function for RPC /gnoi.optical.WavelengthRouter/AdjustSpectrum is not implemented")
+func GnoiWavelengthrouterAdjustSpectrum(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	gnoiC, err := dut.RawAPIs().BindingDUT().DialGNOI(ctx, opts...)
+	if err != nil {
+		return err
+	}
+	adjustC, err := gnoiC.WavelengthRouter().AdjustSpectrum(ctx, &wrpb.AdjustSpectrumRequest{Component: nonexistentComponent()})
+	if err != nil {
+		return err
+	}
+	return rejected(drain(adjustC), codes.NotFound, codes.InvalidArgument)
 }
 
 // GnoiWavelengthrouterAllRPC implements a sample request for service /gnoi.optical.WavelengthRouter/* to validate if authz works as expected.
@@ -263,18 +447,34 @@ func GnoiWavelengthrouterAllRPC(_ context.Context, _ *ondatra.DUTDevice, _ []grp
 }
 
 // GnoiWavelengthrouterCancelAdjustPSD implements a sample request for service /gnoi.optical.WavelengthRouter/CancelAdjustPSD to validate if authz works as expected.
-func GnoiWavelengthrouterCancelAdjustPSD(_ context.Context, _ *ondatra.DUTDevice, _ []grpc.DialOption, _ ...any) error {
-	return status.Errorf(codes.Unimplemented, "exec function for RPC /gnoi.optical.WavelengthRouter/CancelAdjustPSD is not implemented")
+func GnoiWavelengthrouterCancelAdjustPSD(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	gnoiC, err := dut.RawAPIs().BindingDUT().DialGNOI(ctx, opts...)
+	if err != nil {
+		return err
+	}
+	_, err = gnoiC.WavelengthRouter().CancelAdjustPSD(ctx, &wrpb.AdjustPSDRequest{Component: nonexistentComponent()})
+	return rejected(err, codes.NotFound, codes.FailedPrecondition)
 }
 
 // GnoiWavelengthrouterCancelAdjustSpectrum implements a sample request for service /gnoi.optical.WavelengthRouter/CancelAdjustSpectrum to validate if authz works as expected.
-func GnoiWavelengthrouterCancelAdjustSpectrum(_ context.Context, _ *ondatra.DUTDevice, _ []grpc.DialOption, _ ...any) error {
-	return status.Errorf(codes.Unimplemented, "exec function for RPC /gnoi.optical.WavelengthRouter/CancelAdjustSpectrum is not implemented")
+func GnoiWavelengthrouterCancelAdjustSpectrum(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	gnoiC, err := dut.RawAPIs().BindingDUT().DialGNOI(ctx, opts...)
+	if err != nil {
+		return err
+	}
+	_, err = gnoiC.WavelengthRouter().CancelAdjustSpectrum(ctx, &wrpb.AdjustSpectrumRequest{Component: nonexistentComponent()})
+	return rejected(err, codes.NotFound, codes.FailedPrecondition)
 }
 
 // GnoiOsActivate implements a sample request for service /gnoi.os.OS/Activate to validate if authz works as expected.
-func GnoiOsActivate(_ context.Context, _ *ondatra.DUTDevice, _ []grpc.DialOption, _ ...any) error {
-	return status.Errorf(codes.Unimplemented, "exec function for RPC /gnoi.os.OS/Activate is not implemented")
+func GnoiOsActivate(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	gnoiC, err := dut.RawAPIs().BindingDUT().DialGNOI(ctx, opts...)
+	if err != nil {
+		return err
+	}
+	// Activating a version that is not installed fails without a reboot.
+	_, err = gnoiC.OS().Activate(ctx, &ospb.ActivateRequest{Version: nonexistent, NoReboot: true})
+	return rejected(err, codes.NotFound, codes.InvalidArgument)
 }
 
 // GnoiOsAllRPC implements a sample request for service /gnoi.os.OS/* to validate if authz works as expected.
@@ -283,18 +483,40 @@ func GnoiOsAllRPC(_ context.Context, _ *ondatra.DUTDevice, _ []grpc.DialOption,
 }
 
 // GnoiOsVerify implements a sample request for service /gnoi.os.OS/Verify to validate if authz works as expected.
-func GnoiOsVerify(_ context.Context, _ *ondatra.DUTDevice, _ []grpc.DialOption, _ ...any) error {
-	return status.Errorf(codes.Unimplemented, "exec function for RPC /gnoi.os.OS/Verify is not implemented")
+func GnoiOsVerify(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	gnoiC, err := dut.RawAPIs().BindingDUT().DialGNOI(ctx, opts...)
+	if err != nil {
+		return err
+	}
+	_, err = gnoiC.OS().Verify(ctx, &ospb.VerifyRequest{})
+	return err
 }
 
 // GnoiOsInstall implements a sample request for service /gnoi.os.OS/Install to validate if authz works as expected.
-func GnoiOsInstall(_ context.Context, _ *ondatra.DUTDevice, _ []grpc.DialOption, _ ...any) error {
-	return status.Errorf(codes.Unimplemented, "exec function for RPC /gnoi.os.OS/Install is not implemented")
+func GnoiOsInstall(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	gnoiC, err := dut.RawAPIs().BindingDUT().DialGNOI(ctx, opts...)
+	if err != nil {
+		return err
+	}
+	installC, err := gnoiC.OS().Install(ctx)
+	if err != nil {
+		return err
+	}
+	// An Install without the transfer request installs nothing.
+	return rejected(closeAndDrain(installC), codes.InvalidArgument, codes.Aborted)
 }
 
 // GnoiOtdrInitiate implements a sample request for service /gnoi.optical.OTDR/Initiate to validate if authz works as expected.
-func GnoiOtdrInitiate(_ context.Context, _ *ondatra.DUTDevice, _ []grpc.DialOption, _ ...any) error {
-	return status.Errorf(codes.Unimplemented, "exec function for RPC /gnoi.optical.OTDR/Initiate is not implemented")
+func GnoiOtdrInitiate(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	gnoiC, err := dut.RawAPIs().BindingDUT().DialGNOI(ctx, opts...)
+	if err != nil {
+		return err
+	}
+	initiateC, err := gnoiC.OTDR().Initiate(ctx, &otpb.InitiateRequest{Component: nonexistentComponent()})
+	if err != nil {
+		return err
+	}
+	return rejected(drain(initiateC), codes.NotFound, codes.InvalidArgument)
 }
 
 // GnoiLinkqualificationAllRPC implements a sample request for service /gnoi.packet_link_qualification.LinkQualification/* to validate if authz works as expected.
@@ -303,23 +525,43 @@ func GnoiLinkqualificationAllRPC(_ context.Context, _ *ondatra.DUTDevice, _ []gr
 }
 
 // GnoiLinkqualificationCapabilities implements a sample request for service /gnoi.packet_link_qualification.LinkQualification/Capabilities to validate if authz works as expected.
-func GnoiLinkqualificationCapabilities(_ context.Context, _ *ondatra.DUTDevice, _ []grpc.DialOption, _ ...any) error {
-	return status.Errorf(codes.Unimplemented, "exec function for RPC /gnoi.packet_link_qualification.LinkQualification/Capabilities is not implemented")
+func GnoiLinkqualificationCapabilities(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	gnoiC, err := dut.RawAPIs().BindingDUT().DialGNOI(ctx, opts...)
+	if err != nil {
+		return err
+	}
+	_, err = gnoiC.LinkQualification().Capabilities(ctx, &plqpb.CapabilitiesRequest{})
+	return err
 }
 
 // GnoiLinkqualificationDelete implements a sample request for service /gnoi.packet_link_qualification.LinkQualification/Delete to validate if authz works as expected.
-func GnoiLinkqualificationDelete(_ context.Context, _ *ondatra.DUTDevice, _ []grpc.DialOption, _ ...any) error {
-	return status.Errorf(codes.Unimplemented, "exec function for RPC /gnoi.packet_link_qualification.LinkQualification/Delete is not implemented")
+func GnoiLinkqualificationDelete(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	gnoiC, err := dut.RawAPIs().BindingDUT().DialGNOI(ctx, opts...)
+	if err != nil {
+		return err
+	}
+	_, err = gnoiC.LinkQualification().Delete(ctx, &plqpb.DeleteRequest{Ids: []string{nonexistent}})
+	return rejected(err, codes.NotFound)
 }
 
 // GnoiLinkqualificationGet implements a sample request for service /gnoi.packet_link_qualification.LinkQualification/Get to validate if authz works as expected.
-func GnoiLinkqualificationGet(_ context.Context, _ *ondatra.DUTDevice, _ []grpc.DialOption, _ ...any) error {
-	return status.Errorf(codes.Unimplemented, "exec function for RPC /gnoi.packet_link_qualification.LinkQualification/Get is not implemented")
+func GnoiLinkqualificationGet(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	gnoiC, err := dut.RawAPIs().BindingDUT().DialGNOI(ctx, opts...)
+	if err != nil {
+		return err
+	}
+	_, err = gnoiC.LinkQualification().Get(ctx, &plqpb.GetRequest{Ids: []string{nonexistent}})
+	return rejected(err, codes.NotFound)
 }
 
 // GnoiLinkqualificationList implements a sample request for service /gnoi.packet_link_qualification.LinkQualification/List to validate if authz works as expected.
-func GnoiLinkqualificationList(_ context.Context, _ *ondatra.DUTDevice, _ []grpc.DialOption, _ ...any) error {
-	return status.Errorf(codes.Unimplemented, "exec function for RPC /gnoi.packet_link_qualification.LinkQualification/List is not implemented")
+func GnoiLinkqualificationList(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	gnoiC, err := dut.RawAPIs().BindingDUT().DialGNOI(ctx, opts...)
+	if err != nil {
+		return err
+	}
+	_, err = gnoiC.LinkQualification().List(ctx, &plqpb.ListRequest{})
+	return err
 }
 
 // GnoiSystemAllRPC implements a sample request for service /gnoi.system.System/* to validate if authz works as expected.
@@ -328,33 +570,65 @@ func GnoiSystemAllRPC(_ context.Context, _ *ondatra.DUTDevice, _ []grpc.DialOpti
 }
 
 // GnoiSystemCancelReboot implements a sample request for service /gnoi.system.System/CancelReboot to validate if authz works as expected.
-func GnoiSystemCancelReboot(_ context.Context, _ *ondatra.DUTDevice, _ []grpc.DialOption, _ ...any) error {
-	return status.Errorf(codes.Unimplemented, "exec function for RPC /gnoi.system.System/CancelReboot is not implemented")
+func GnoiSystemCancelReboot(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	gnoiC, err := dut.RawAPIs().BindingDUT().DialGNOI(ctx, opts...)
+	if err != nil {
+		return err
+	}
+	_, err = gnoiC.System().CancelReboot(ctx, &spb.CancelRebootRequest{Message: nonexistent, Subcomponents: []*tpb.Path{nonexistentComponent()}})
+	return rejected(err, codes.FailedPrecondition)
 }
 
 // GnoiSystemKillProcess implements a sample request for service /gnoi.system.System/KillProcess to validate if authz works as expected.
-func GnoiSystemKillProcess(_ context.Context, _ *ondatra.DUTDevice, _ []grpc.DialOption, _ ...any) error {
-	return status.Errorf(codes.Unimplemented, "exec function for RPC /gnoi.system.System/KillProcess is not implemented")
+func GnoiSystemKillProcess(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	gnoiC, err := dut.RawAPIs().BindingDUT().DialGNOI(ctx, opts...)
+	if err != nil {
+		return err
+	}
+	_, err = gnoiC.System().KillProcess(ctx, &spb.KillProcessRequest{Name: nonexistent, Signal: spb.KillProcessRequest_SIGNAL_TERM})
+	return rejected(err, codes.NotFound, codes.InvalidArgument)
 }
 
 // GnoiSystemReboot implements a sample request for service /gnoi.system.System/Reboot to validate if authz works as expected.
 func GnoiSystemReboot(_ context.Context, _ *ondatra.DUTDevice, _ []grpc.DialOption, _ ...any) error {
-	return status.Errorf(codes.Unimplemented, "exec function for RPC /gnoi.system.System/Reboot is not implemented")
+	// A DUT may reboot on a request with an unknown method instead of
+	// rejecting it, so it is never sent.
+	return ErrNotExecuted
 }
 
 // GnoiSystemRebootStatus implements a sample request for service /gnoi.system.System/RebootStatus to validate if authz works as expected.
-func GnoiSystemRebootStatus(_ context.Context, _ *ondatra.DUTDevice, _ []grpc.DialOption, _ ...any) error {
-	return status.Errorf(codes.Unimplemented, "exec function for RPC /gnoi.system.System/RebootStatus is not implemented")
+func GnoiSystemRebootStatus(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	gnoiC, err := dut.RawAPIs().BindingDUT().DialGNOI(ctx, opts...)
+	if err != nil {
+		return err
+	}
+	_, err = gnoiC.System().RebootStatus(ctx, &spb.RebootStatusRequest{})
+	return rejected(err, codes.FailedPrecondition)
 }
 
 // GnoiSystemSetPackage implements a sample request for service /gnoi.system.System/SetPackage to validate if authz works as expected.
-func GnoiSystemSetPackage(_ context.Context, _ *ondatra.DUTDevice, _ []grpc.DialOption, _ ...any) error {
-	return status.Errorf(codes.Unimplemented, "exec function for RPC /gnoi.system.System/SetPackage is not implemented")
+func GnoiSystemSetPackage(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	gnoiC, err := dut.RawAPIs().BindingDUT().DialGNOI(ctx, opts...)
+	if err != nil {
+		return err
+	}
+	setC, err := gnoiC.System().SetPackage(ctx)
+	if err != nil {
+		return err
+	}
+	// A SetPackage without the package message installs nothing.
+	_, err = setC.CloseAndRecv()
+	return rejected(err, codes.InvalidArgument, codes.Aborted)
 }
 
 // GnoiSystemSwitchControlProcessor implements a sample request for service /gnoi.system.System/SwitchControlProcessor to validate if authz works as expected.
-func GnoiSystemSwitchControlProcessor(_ context.Context, _ *ondatra.DUTDevice, _ []grpc.DialOption, _ ...any) error {
-	return status.Errorf(codes.Unimplemented, "exec function for RPC /gnoi.system.System/SwitchControlProcessor is not implemented")
+func GnoiSystemSwitchControlProcessor(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	gnoiC, err := dut.RawAPIs().BindingDUT().DialGNOI(ctx, opts...)
+	if err != nil {
+		return err
+	}
+	_, err = gnoiC.System().SwitchControlProcessor(ctx, &spb.SwitchControlProcessorRequest{ControlProcessor: nonexistentComponent()})
+	return rejected(err, codes.NotFound, codes.InvalidArgument)
 }
 
 // GnoiSystemTime implements a sample request for service /gnoi.system.System/Time to validate if authz works as expected.
@@ -368,8 +642,16 @@ func GnoiSystemTime(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.Dia
 }
 
 // GnoiSystemTraceroute implements a sample request for service /gnoi.system.System/Traceroute to validate if authz works as expected.
-func GnoiSystemTraceroute(_ context.Context, _ *ondatra.DUTDevice, _ []grpc.DialOption, _ ...any) error {
-	return status.Errorf(codes.Unimplemented, "exec function for RPC /gnoi.system.System/Traceroute is not implemented")
+func GnoiSystemTraceroute(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	gnoiC, err := dut.RawAPIs().BindingDUT().DialGNOI(ctx, opts...)
+	if err != nil {
+		return err
+	}
+	tracerouteC, err := gnoiC.System().Traceroute(ctx, &spb.TracerouteRequest{Destination: "127.0.0.1", MaxTtl: 1, DoNotResolve: true})
+	if err != nil {
+		return err
+	}
+	return rejected(drain(tracerouteC), codes.InvalidArgument)
 }
 
 // GnoiSystemPing implements a sample request for service /gnoi.system.System/Ping to validate if authz works as expected.
@@ -395,28 +677,16 @@ func GnoiSystemPing(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.Dia
 }
 
 // GnoiWavelengthrouterAdjustPSD implements a sample request for service /gnoi.optical.WavelengthRouter/AdjustPSD to validate if authz works as expected.
-func GnoiWavelengthrouterAdjustPSD(_ context.Context, _ *ondatra.DUTDevice, _ []grpc.DialOption, _ ...any) error {
-	return status.Errorf(codes.Unimplemented, "exec function for RPC /gnoi.optical.WavelengthRouter/AdjustPSD is not implemented")
-}
-
-// GnsiAccountingpullAllRPC implements a sample request for service /gnsi.accounting.v1.AccountingPull/* to validate if authz works as expected.
-func GnsiAccountingpullAllRPC(_ context.Context, _ *ondatra.DUTDevice, _ []grpc.DialOption, _ ...any) error {
-	return status.Errorf(codes.Unimplemented, "exec function for RPC /gnsi.accounting.v1.AccountingPull/* is not implemented")
-}
-
-// GnsiAccountingpushRecordStream implements a sample request for service /gnsi.accounting.v1.AccountingPush/RecordStream to validate if authz works as expected.
-func GnsiAccountingpushRecordStream(_ context.Context, _ *ondatra.DUTDevice, _ []grpc.DialOption, _ ...any) error {
-	return status.Errorf(codes.Unimplemented, "exec function for RPC /gnsi.accounting.v1.AccountingPush/RecordStream is not implemented")
-}
-
-// GnsiAccountingpushAllRPC implements a sample request for service /gnsi.accounting.v1.AccountingPush/* to validate if authz works as expected.
-func GnsiAccountingpushAllRPC(_ context.Context, _ *ondatra.DUTDevice, _ []grpc.DialOption, _ ...any) error {
-	return status.Errorf(codes.Unimplemented, "exec function for RPC /gnsi.accounting.v1.AccountingPush/* is not implemented")
-}
-
-// GnsiAccountingpullRecordStream implements a sample request for service /gnsi.accounting.v1.AccountingPull/RecordStream to validate if authz works as expected.
-func GnsiAccountingpullRecordStream(_ context.Context, _ *ondatra.DUTDevice, _ []grpc.DialOption, _ ...any) error {
-	return status.Errorf(codes.Unimplemented, "exec function for RPC /gnsi.accounting.v1.AccountingPull/RecordStream is not implemented")
+func GnoiWavelengthrouterAdjustPSD(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	gnoiC, err := dut.RawAPIs().BindingDUT().DialGNOI(ctx, opts...)
+	if err != nil {
+		return err
+	}
+	adjustC, err := gnoiC.WavelengthRouter().AdjustPSD(ctx, &wrpb.AdjustPSDRequest{Component: nonexistentComponent()})
+	if err != nil {
+		return err
+	}
+	return rejected(drain(adjustC), codes.NotFound, codes.InvalidArgument)
 }
 
 // GnsiAuthzAllRPC implements a sample request for service /gnsi.authz.v1.Authz/* to validate if authz works as expected.
@@ -482,8 +752,14 @@ func GnsiAuthzRotate(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.Di
 }
 
 // GnsiCertzAddProfile implements a sample request for service /gnsi.certz.v1.Certz/AddProfile to validate if authz works as expected.
-func GnsiCertzAddProfile(_ context.Context, _ *ondatra.DUTDevice, _ []grpc.DialOption, _ ...any) error {
-	return status.Errorf(codes.Unimplemented, "exec function for RPC /gnsi.certz.v1.Certz/AddProfile is not implemented")
+func GnsiCertzAddProfile(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	gnsiC, err := dut.RawAPIs().BindingDUT().DialGNSI(ctx, opts...)
+	if err != nil {
+		return err
+	}
+	// A profile without an ID is invalid.
+	_, err = gnsiC.Certz().AddProfile(ctx, &certzpb.AddProfileRequest{})
+	return rejected(err, codes.InvalidArgument)
 }
 
 // GnsiCertzAllRPC implements a sample request for service /gnsi.certz.v1.Certz/* to validate if authz works as expected.
@@ -492,23 +768,47 @@ func GnsiCertzAllRPC(_ context.Context, _ *ondatra.DUTDevice, _ []grpc.DialOptio
 }
 
 // GnsiCertzCanGenerateCSR implements a sample request for service /gnsi.certz.v1.Certz/CanGenerateCSR to validate if authz works as expected.
-func GnsiCertzCanGenerateCSR(_ context.Context, _ *ondatra.DUTDevice, _ []grpc.DialOption, _ ...any) error {
-	return status.Errorf(codes.Unimplemented, "exec function for RPC /gnsi.certz.v1.Certz/CanGenerateCSR is not implemented")
+func GnsiCertzCanGenerateCSR(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	gnsiC, err := dut.RawAPIs().BindingDUT().DialGNSI(ctx, opts...)
+	if err != nil {
+		return err
+	}
+	_, err = gnsiC.Certz().CanGenerateCSR(ctx, &certzpb.CanGenerateCSRRequest{})
+	return rejected(err, codes.InvalidArgument)
 }
 
 // GnsiCertzDeleteProfile implements a sample request for service /gnsi.certz.v1.Certz/DeleteProfile to validate if authz works as expected.
-func GnsiCertzDeleteProfile(_ context.Context, _ *ondatra.DUTDevice, _ []grpc.DialOption, _ ...any) error {
-	return status.Errorf(codes.Unimplemented, "exec function for RPC /gnsi.certz.v1.Certz/DeleteProfile is not implemented")
+func GnsiCertzDeleteProfile(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	gnsiC, err := dut.RawAPIs().BindingDUT().DialGNSI(ctx, opts...)
+	if err != nil {
+		return err
+	}
+	_, err = gnsiC.Certz().DeleteProfile(ctx, &certzpb.DeleteProfileRequest{SslProfileId: nonexistent})
+	return rejected(err, codes.NotFound)
 }
 
 // GnsiCertzGetProfileList implements a sample request for service /gnsi.certz.v1.Certz/GetProfileList to validate if authz works as expected.
-func GnsiCertzGetProfileList(_ context.Context, _ *ondatra.DUTDevice, _ []grpc.DialOption, _ ...any) error {
-	return status.Errorf(codes.Unimplemented, "exec function for RPC /gnsi.certz.v1.Certz/GetProfileList is not implemented")
+func GnsiCertzGetProfileList(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	gnsiC, err := dut.RawAPIs().BindingDUT().DialGNSI(ctx, opts...)
+	if err != nil {
+		return err
+	}
+	_, err = gnsiC.Certz().GetProfileList(ctx, &certzpb.GetProfileListRequest{})
+	return err
 }
 
 // GnsiCertzRotate implements a sample request for service /gnsi.certz.v1.Certz/Rotate to validate if authz works as expected.
-func GnsiCertzRotate(_ context.Context, _ *ondatra.DUTDevice, _ []grpc.DialOption, _ ...any) error {
-	return status.Errorf(codes.Unimplemented, "exec function for RPC /gnsi.certz.v1.Certz/Rotate is not implemented")
+func GnsiCertzRotate(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	gnsiC, err := dut.RawAPIs().BindingDUT().DialGNSI(ctx, opts...)
+	if err != nil {
+		return err
+	}
+	rotateC, err := gnsiC.Certz().Rotate(ctx)
+	if err != nil {
+		return err
+	}
+	// A rotation closed without any request changes nothing.
+	return rejected(closeAndDrain(rotateC), codes.InvalidArgument, codes.Aborted)
 }
 
 // GnsiCredentialzAllRPC implements a sample request for service /gnsi.credentialz.v1.Credentialz/* to validate if authz works as expected.
@@ -517,23 +817,36 @@ func GnsiCredentialzAllRPC(_ context.Context, _ *ondatra.DUTDevice, _ []grpc.Dia
 }
 
 // GnsiCredentialzCanGenerateKey implements a sample request for service /gnsi.credentialz.v1.Credentialz/CanGenerateKey to validate if authz works as expected.
-func GnsiCredentialzCanGenerateKey(_ context.Context, _ *ondatra.DUTDevice, _ []grpc.DialOption, _ ...any) error {
-	return status.Errorf(codes.Unimplemented, "exec function for RPC /gnsi.credentialz.v1.Credentialz/CanGenerateKey is not implemented")
+func GnsiCredentialzCanGenerateKey(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	gnsiC, err := dut.RawAPIs().BindingDUT().DialGNSI(ctx, opts...)
+	if err != nil {
+		return err
+	}
+	_, err = gnsiC.Credentialz().CanGenerateKey(ctx, &credzpb.CanGenerateKeyRequest{KeyParams: credzpb.KeyGen_KEY_GEN_SSH_KEY_UNSPECIFIED})
+	return rejected(err, codes.InvalidArgument)
 }
 
 // GnsiCredentialzGetPublicKeys implements a sample request for service /gnsi.credentialz.v1.Credentialz/GetPublicKeys to validate if authz works as expected.
-func GnsiCredentialzGetPublicKeys(_ context.Context, _ *ondatra.DUTDevice, _ []grpc.DialOption, _ ...any) error {
-	return status.Errorf(codes.Unimplemented, "exec function for RPC /gnsi.credentialz.v1.Credentialz/GetPublicKeys is not implemented")
-}
-
-// GnsiCredentialzRotateHostCredentials implements a sample request for service /gnsi.credentialz.v1.Credentialz/RotateHostCredentials to validate if authz works as expected.
-func GnsiCredentialzRotateHostCredentials(_ context.Context, _ *ondatra.DUTDevice, _ []grpc.DialOption, _ ...any) error {
-	return status.Errorf(codes.Unimplemented, "exec function for RPC /gnsi.credentialz.v1.Credentialz/RotateHostCredentials is not implemented")
+func GnsiCredentialzGetPublicKeys(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	gnsiC, err := dut.RawAPIs().BindingDUT().DialGNSI(ctx, opts...)
+	if err != nil {
+		return err
+	}
+	_, err = gnsiC.Credentialz().GetPublicKeys(ctx, &credzpb.GetPublicKeysRequest{})
+	return err
 }
 
 // GnsiCredentialzRotateAccountCredentials implements a sample request for service /gnsi.credentialz.v1.Credentialz/RotateAccountCredentials to validate if authz works as expected.
-func GnsiCredentialzRotateAccountCredentials(_ context.Context, _ *ondatra.DUTDevice, _ []grpc.DialOption, _ ...any) error {
-	return status.Errorf(codes.Unimplemented, "exec function for RPC /gnsi.credentialz.v1.Credentialz/RotateAccountCredentials is not implemented")
+func GnsiCredentialzRotateAccountCredentials(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	gnsiC, err := dut.RawAPIs().BindingDUT().DialGNSI(ctx, opts...)
+	if err != nil {
+		return err
+	}
+	rotateC, err := gnsiC.Credentialz().RotateAccountCredentials(ctx)
+	if err != nil {
+		return err
+	}
+	return rejected(closeAndDrain(rotateC), codes.InvalidArgument, codes.Aborted)
 }
 
 // GnsiPathzAllRPC implements a sample request for service /gnsi.pathz.v1.Pathz/* to validate if authz works as expected.
@@ -542,18 +855,41 @@ func GnsiPathzAllRPC(_ context.Context, _ *ondatra.DUTDevice, _ []grpc.DialOptio
 }
 
 // GnsiPathzGet implements a sample request for service /gnsi.pathz.v1.Pathz/Get to validate if authz works as expected.
-func GnsiPathzGet(_ context.Context, _ *ondatra.DUTDevice, _ []grpc.DialOption, _ ...any) error {
-	return status.Errorf(codes.Unimplemented, "exec function for RPC /gnsi.pathz.v1.Pathz/Get is not implemented")
+func GnsiPathzGet(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	gnsiC, err := dut.RawAPIs().BindingDUT().DialGNSI(ctx, opts...)
+	if err != nil {
+		return err
+	}
+	_, err = gnsiC.Pathz().Get(ctx, &pathzpb.GetRequest{PolicyInstance: pathzpb.PolicyInstance_POLICY_INSTANCE_ACTIVE})
+	return rejected(err, codes.NotFound, codes.FailedPrecondition)
 }
 
 // GnsiPathzProbe implements a sample request for service /gnsi.pathz.v1.Pathz/Probe to validate if authz works as expected.
-func GnsiPathzProbe(_ context.Context, _ *ondatra.DUTDevice, _ []grpc.DialOption, _ ...any) error {
-	return status.Errorf(codes.Unimplemented, "exec function for RPC /gnsi.pathz.v1.Pathz/Probe is not implemented")
+func GnsiPathzProbe(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	gnsiC, err := dut.RawAPIs().BindingDUT().DialGNSI(ctx, opts...)
+	if err != nil {
+		return err
+	}
+	_, err = gnsiC.Pathz().Probe(ctx, &pathzpb.ProbeRequest{
+		User:           "dummy",
+		Path:           &gpb.Path{Elem: []*gpb.PathElem{{Name: "system"}}},
+		Mode:           pathzpb.Mode_MODE_READ,
+		PolicyInstance: pathzpb.PolicyInstance_POLICY_INSTANCE_ACTIVE,
+	})
+	return rejected(err, codes.NotFound, codes.FailedPrecondition)
 }
 
 // GnsiPathzRotate implements a sample request for service /gnsi.pathz.v1.Pathz/Rotate to validate if authz works as expected.
-func GnsiPathzRotate(_ context.Context, _ *ondatra.DUTDevice, _ []grpc.DialOption, _ ...any) error {
-	return status.Errorf(codes.Unimplemented, "exec function for RPC /gnsi.pathz.v1.Pathz/Rotate is not implemented")
+func GnsiPathzRotate(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	gnsiC, err := dut.RawAPIs().BindingDUT().DialGNSI(ctx, opts...)
+	if err != nil {
+		return err
+	}
+	rotateC, err := gnsiC.Pathz().Rotate(ctx)
+	if err != nil {
+		return err
+	}
+	return rejected(closeAndDrain(rotateC), codes.InvalidArgument, codes.Aborted)
 }
 
 // GribiAllRPC implements a sample request for service /gribi.gRIBI/* to validate if authz works as expected.
@@ -632,33 +968,71 @@ func P4P4runtimeAllRPC(_ context.Context, _ *ondatra.DUTDevice, _ []grpc.DialOpt
 }
 
 // P4P4runtimeCapabilities implements a sample request for service /p4.v1.P4Runtime/Capabilities to validate if authz works as expected.
-func P4P4runtimeCapabilities(_ context.Context, _ *ondatra.DUTDevice, _ []grpc.DialOption, _ ...any) error {
-	return status.Errorf(codes.Unimplemented, "exec function for RPC /p4.v1.P4Runtime/Capabilities is not implemented")
+func P4P4runtimeCapabilities(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	p4rtC, err := dut.RawAPIs().BindingDUT().DialP4RT(ctx, opts...)
+	if err != nil {
+		return err
+	}
+	_, err = p4rtC.Capabilities(ctx, &p4pb.CapabilitiesRequest{})
+	return err
 }
 
 // P4P4runtimeGetForwardingPipelineConfig implements a sample request for service /p4.v1.P4Runtime/GetForwardingPipelineConfig to validate if authz works as expected.
-func P4P4runtimeGetForwardingPipelineConfig(_ context.Context, _ *ondatra.DUTDevice, _ []grpc.DialOption, _ ...any) error {
-	return status.Errorf(codes.Unimplemented, "exec function for RPC /p4.v1.P4Runtime/GetForwardingPipelineConfig is not implemented")
+func P4P4runtimeGetForwardingPipelineConfig(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	p4rtC, err := dut.RawAPIs().BindingDUT().DialP4RT(ctx, opts...)
+	if err != nil {
+		return err
+	}
+	_, err = p4rtC.GetForwardingPipelineConfig(ctx, &p4pb.GetForwardingPipelineConfigRequest{DeviceId: nonexistentDeviceID, ResponseType: p4pb.GetForwardingPipelineConfigRequest_COOKIE_ONLY})
+	return rejected(err, codes.NotFound, codes.FailedPrecondition)
 }
 
 // P4P4runtimeRead implements a sample request for service /p4.v1.P4Runtime/Read to validate if authz works as expected.
-func P4P4runtimeRead(_ context.Context, _ *ondatra.DUTDevice, _ []grpc.DialOption, _ ...any) error {
-	return status.Errorf(codes.Unimplemented, "exec function for RPC /p4.v1.P4Runtime/Read is not implemented")
+func P4P4runtimeRead(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	p4rtC, err := dut.RawAPIs().BindingDUT().DialP4RT(ctx, opts...)
+	if err != nil {
+		return err
+	}
+	readC, err := p4rtC.Read(ctx, &p4pb.ReadRequest{DeviceId: nonexistentDeviceID})
+	if err != nil {
+		return err
+	}
+	return rejected(drain(readC), codes.NotFound, codes.FailedPrecondition)
 }
 
 // P4P4runtimeSetForwardingPipelineConfig implements a sample request for service /p4.v1.P4Runtime/SetForwardingPipelineConfig to validate if authz works as expected.
-func P4P4runtimeSetForwardingPipelineConfig(_ context.Context, _ *ondatra.DUTDevice, _ []grpc.DialOption, _ ...any) error {
-	return status.Errorf(codes.Unimplemented, "exec function for RPC /p4.v1.P4Runtime/SetForwardingPipelineConfig is not implemented")
+func P4P4runtimeSetForwardingPipelineConfig(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	p4rtC, err := dut.RawAPIs().BindingDUT().DialP4RT(ctx, opts...)
+	if err != nil {
+		return err
+	}
+	// The UNSPECIFIED action is invalid.
+	_, err = p4rtC.SetForwardingPipelineConfig(ctx, &p4pb.SetForwardingPipelineConfigRequest{DeviceId: nonexistentDeviceID, Action: p4pb.SetForwardingPipelineConfigRequest_UNSPECIFIED})
+	return rejected(err, codes.NotFound, codes.InvalidArgument)
 }
 
 // P4P4runtimeStreamChannel implements a sample request for service /p4.v1.P4Runtime/StreamChannel to validate if authz works as expected.
-func P4P4runtimeStreamChannel(_ context.Context, _ *ondatra.DUTDevice, _ []grpc.DialOption, _ ...any) error {
-	return status.Errorf(codes.Unimplemented, "exec function for RPC /p4.v1.P4Runtime/StreamChannel is not implemented")
+func P4P4runtimeStreamChannel(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	p4rtC, err := dut.RawAPIs().BindingDUT().DialP4RT(ctx, opts...)
+	if err != nil {
+		return err
+	}
+	streamC, err := p4rtC.StreamChannel(ctx)
+	if err != nil {
+		return err
+	}
+	// Closing the stream before arbitration leaves the controllers unchanged.
+	return rejected(closeAndDrain(streamC), codes.InvalidArgument, codes.Aborted)
 }
 
 // P4P4runtimeWrite implements a sample request for service /p4.v1.P4Runtime/Write to validate if authz works as expected.
-func P4P4runtimeWrite(_ context.Context, _ *ondatra.DUTDevice, _ []grpc.DialOption, _ ...any) error {
-	return status.Errorf(codes.Unimplemented, "exec function for RPC /p4.v1.P4Runtime/Write is not implemented")
+func P4P4runtimeWrite(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	p4rtC, err := dut.RawAPIs().BindingDUT().DialP4RT(ctx, opts...)
+	if err != nil {
+		return err
+	}
+	_, err = p4rtC.Write(ctx, &p4pb.WriteRequest{DeviceId: nonexistentDeviceID, ElectionId: &p4pb.Uint128{Low: 1}})
+	return rejected(err, codes.NotFound, codes.FailedPrecondition)
 }
 
 // GnsiAcctzAllRPC implements a sample request for service /gnsi.acctz.v1.Acctz/* to validate if authz works as expected.
@@ -667,11 +1041,149 @@ func GnsiAcctzAllRPC(_ context.Context, _ *ondatra.DUTDevice, _ []grpc.DialOptio
 }
 
 // GnsiAcctzRecordSubscribe implements a sample request for service /gnsi.acctz.v1.Acctz/RecordSubscribe to validate if authz works as expected.
-func GnsiAcctzRecordSubscribe(_ context.Context, _ *ondatra.DUTDevice, _ []grpc.DialOption, _ ...any) error {
-	return status.Errorf(codes.Unimplemented, "exec function for RPC /gnsi.acctz.v1.Acctz/RecordSubscribe is not implemented")
+func GnsiAcctzRecordSubscribe(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	gnsiC, err := dut.RawAPIs().BindingDUT().DialGNSI(ctx, opts...)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, streamWait)
+	defer cancel()
+	subC, err := gnsiC.Acctz().RecordSubscribe(ctx)
+	if err != nil {
+		return err
+	}
+	if err := subC.Send(&acctzpb.RecordRequest{Timestamp: timestamppb.Now()}); err != nil {
+		return err
+	}
+	// An authorized subscription may see no record before the wait ends.
+	_, err = subC.Recv()
+	if status.Code(err) == codes.DeadlineExceeded {
+		return nil
+	}
+	return err
 }
 
 // GnsiCredentialzRotateHostParameters implements a sample request for service /gnsi.acctz.v1.Acctz/RecordSubscribe to validate if authz works as expected.
-func GnsiCredentialzRotateHostParameters(_ context.Context, _ *ondatra.DUTDevice, _ []grpc.DialOption, _ ...any) error {
-	return status.Errorf(codes.Unimplemented, "exec function for RPC /gnsi.acctz.v1.Acctz/RecordSubscribe is not implemented")
+func GnsiCredentialzRotateHostParameters(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	gnsiC, err := dut.RawAPIs().BindingDUT().DialGNSI(ctx, opts...)
+	if err != nil {
+		return err
+	}
+	rotateC, err := gnsiC.Credentialz().RotateHostParameters(ctx)
+	if err != nil {
+		return err
+	}
+	return rejected(closeAndDrain(rotateC), codes.InvalidArgument, codes.Aborted)
+}
+
+// GnoiCertificatemanagementCanGenerateCSR implements a sample request for service /gnoi.certificate.CertificateManagement/CanGenerateCSR to validate if authz works as expected.
+func GnoiCertificatemanagementCanGenerateCSR(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	conn, err := dialService(ctx, dut, introspect.GNOI, opts)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	_, err = cmpb.NewCertificateManagementClient(conn).CanGenerateCSR(ctx, &cmpb.CanGenerateCSRRequest{
+		KeyType:         cmpb.KeyType_KT_RSA,
+		CertificateType: cmpb.CertificateType_CT_X509,
+		KeySize:         2048,
+	})
+	return err
+}
+
+// GnoiCertificatemanagementGetCertificates implements a sample request for service /gnoi.certificate.CertificateManagement/GetCertificates to validate if authz works as expected.
+func GnoiCertificatemanagementGetCertificates(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	conn, err := dialService(ctx, dut, introspect.GNOI, opts)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	_, err = cmpb.NewCertificateManagementClient(conn).GetCertificates(ctx, &cmpb.GetCertificatesRequest{})
+	return err
+}
+
+// GnoiContainerzListContainer implements a sample request for service /gnoi.containerz.Containerz/ListContainer to validate if authz works as expected.
+func GnoiContainerzListContainer(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	conn, err := dialService(ctx, dut, introspect.GNOI, opts)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(ctx, streamWait)
+	defer cancel()
+	listC, err := czpb.NewContainerzClient(conn).ListContainer(ctx, &czpb.ListContainerRequest{})
+	if err != nil {
+		return err
+	}
+	return waited(drain(listC))
+}
+
+// GnoiContainerzListImage implements a sample request for service /gnoi.containerz.Containerz/ListImage to validate if authz works as expected.
+func GnoiContainerzListImage(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	conn, err := dialService(ctx, dut, introspect.GNOI, opts)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(ctx, streamWait)
+	defer cancel()
+	listC, err := czpb.NewContainerzClient(conn).ListImage(ctx, &czpb.ListImageRequest{})
+	if err != nil {
+		return err
+	}
+	return waited(drain(listC))
+}
+
+// GnoiContainerzListPlugins implements a sample request for service /gnoi.containerz.Containerz/ListPlugins to validate if authz works as expected.
+func GnoiContainerzListPlugins(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	conn, err := dialService(ctx, dut, introspect.GNOI, opts)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	_, err = czpb.NewContainerzClient(conn).ListPlugins(ctx, &czpb.ListPluginsRequest{})
+	return err
+}
+
+// GnoiContainerzListVolume implements a sample request for service /gnoi.containerz.Containerz/ListVolume to validate if authz works as expected.
+func GnoiContainerzListVolume(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	conn, err := dialService(ctx, dut, introspect.GNOI, opts)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(ctx, streamWait)
+	defer cancel()
+	listC, err := czpb.NewContainerzClient(conn).ListVolume(ctx, &czpb.ListVolumeRequest{})
+	if err != nil {
+		return err
+	}
+	return waited(drain(listC))
+}
+
+// activeControlCard selects the active control card in attestz requests.
+func activeControlCard() *cdpb.ControlCardSelection {
+	return &cdpb.ControlCardSelection{ControlCardId: &cdpb.ControlCardSelection_Role{Role: cdpb.ControlCardRole_CONTROL_CARD_ROLE_ACTIVE}}
+}
+
+// OpenconfigTpmenrollzserviceGetControlCardVendorID implements a sample request for service /openconfig.attestz.TpmEnrollzService/GetControlCardVendorID to validate if authz works as expected.
+func OpenconfigTpmenrollzserviceGetControlCardVendorID(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	conn, err := dialService(ctx, dut, introspect.GNSI, opts)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	_, err = enrollzpb.NewTpmEnrollzServiceClient(conn).GetControlCardVendorID(ctx, &enrollzpb.GetControlCardVendorIDRequest{ControlCardSelection: activeControlCard()})
+	return err
+}
+
+// OpenconfigTpmenrollzserviceGetIakCert implements a sample request for service /openconfig.attestz.TpmEnrollzService/GetIakCert to validate if authz works as expected.
+func OpenconfigTpmenrollzserviceGetIakCert(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	conn, err := dialService(ctx, dut, introspect.GNSI, opts)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	_, err = enrollzpb.NewTpmEnrollzServiceClient(conn).GetIakCert(ctx, &enrollzpb.GetIakCertRequest{ControlCardSelection: activeControlCard()})
+	return err
 }
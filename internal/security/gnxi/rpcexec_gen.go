@@ -0,0 +1,510 @@
+// Code generated by ../gen/generate.go. DO NOT EDIT.
+
+// Exec functions in this file send a minimal request that has not been
+// reviewed and may change the DUT, so they return ErrNotExecuted unless
+// the gnxi_exec_generated flag is set.  Move them to rpcexec.go once they are reviewed,
+// dropping the check and passing rejected the codes the service rejects the
+// request with.
+package gnxi
+
+import (
+	"context"
+
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/binding/introspect"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	tpmattestzpb "github.com/openconfig/attestz/proto/tpm_attestz"
+	tpmenrollzpb "github.com/openconfig/attestz/proto/tpm_enrollz"
+	certpb "github.com/openconfig/gnoi/cert"
+	containerzpb "github.com/openconfig/gnoi/containerz"
+	debugpb "github.com/openconfig/gnoi/debug"
+	packetcapturepb "github.com/openconfig/gnoi/packet_capture"
+	gnpsipb "github.com/openconfig/gnpsi/proto/gnpsi"
+	acctzpb "github.com/openconfig/gnsi/acctz"
+	certzpb "github.com/openconfig/gnsi/certz"
+)
+
+// GnoiBootconfigAllRPC implements a sample request for service /gnoi.bootconfig.BootConfig/* to validate if authz works as expected.
+func GnoiBootconfigAllRPC(_ context.Context, _ *ondatra.DUTDevice, _ []grpc.DialOption, _ ...any) error {
+	return status.Errorf(codes.Unimplemented, "exec function for RPC /gnoi.bootconfig.BootConfig/* is not implemented")
+}
+
+// GnoiCertificatemanagementAllRPC implements a sample request for service /gnoi.certificate.CertificateManagement/* to validate if authz works as expected.
+func GnoiCertificatemanagementAllRPC(_ context.Context, _ *ondatra.DUTDevice, _ []grpc.DialOption, _ ...any) error {
+	return status.Errorf(codes.Unimplemented, "exec function for RPC /gnoi.certificate.CertificateManagement/* is not implemented")
+}
+
+// GnoiCertificatemanagementGenerateCSR implements a sample request for service /gnoi.certificate.CertificateManagement/GenerateCSR to validate if authz works as expected.
+func GnoiCertificatemanagementGenerateCSR(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	if !*execGenerated {
+		return notReviewed("/gnoi.certificate.CertificateManagement/GenerateCSR")
+	}
+	conn, err := dialService(ctx, dut, introspect.GNOI, opts)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	_, err = certpb.NewCertificateManagementClient(conn).GenerateCSR(ctx, &certpb.GenerateCSRRequest{})
+	return rejected(err)
+}
+
+// GnoiCertificatemanagementInstall implements a sample request for service /gnoi.certificate.CertificateManagement/Install to validate if authz works as expected.
+func GnoiCertificatemanagementInstall(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	if !*execGenerated {
+		return notReviewed("/gnoi.certificate.CertificateManagement/Install")
+	}
+	conn, err := dialService(ctx, dut, introspect.GNOI, opts)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(ctx, streamWait)
+	defer cancel()
+	stream, err := certpb.NewCertificateManagementClient(conn).Install(ctx)
+	if err != nil {
+		return err
+	}
+	return waited(closeAndDrain(stream))
+}
+
+// GnoiCertificatemanagementLoadCertificate implements a sample request for service /gnoi.certificate.CertificateManagement/LoadCertificate to validate if authz works as expected.
+func GnoiCertificatemanagementLoadCertificate(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	if !*execGenerated {
+		return notReviewed("/gnoi.certificate.CertificateManagement/LoadCertificate")
+	}
+	conn, err := dialService(ctx, dut, introspect.GNOI, opts)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	_, err = certpb.NewCertificateManagementClient(conn).LoadCertificate(ctx, &certpb.LoadCertificateRequest{})
+	return rejected(err)
+}
+
+// GnoiCertificatemanagementLoadCertificateAuthorityBundle implements a sample request for service /gnoi.certificate.CertificateManagement/LoadCertificateAuthorityBundle to validate if authz works as expected.
+func GnoiCertificatemanagementLoadCertificateAuthorityBundle(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	if !*execGenerated {
+		return notReviewed("/gnoi.certificate.CertificateManagement/LoadCertificateAuthorityBundle")
+	}
+	conn, err := dialService(ctx, dut, introspect.GNOI, opts)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	_, err = certpb.NewCertificateManagementClient(conn).LoadCertificateAuthorityBundle(ctx, &certpb.LoadCertificateAuthorityBundleRequest{})
+	return rejected(err)
+}
+
+// GnoiCertificatemanagementRevokeCertificates implements a sample request for service /gnoi.certificate.CertificateManagement/RevokeCertificates to validate if authz works as expected.
+func GnoiCertificatemanagementRevokeCertificates(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	if !*execGenerated {
+		return notReviewed("/gnoi.certificate.CertificateManagement/RevokeCertificates")
+	}
+	conn, err := dialService(ctx, dut, introspect.GNOI, opts)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	_, err = certpb.NewCertificateManagementClient(conn).RevokeCertificates(ctx, &certpb.RevokeCertificatesRequest{})
+	return rejected(err)
+}
+
+// GnoiCertificatemanagementRotate implements a sample request for service /gnoi.certificate.CertificateManagement/Rotate to validate if authz works as expected.
+func GnoiCertificatemanagementRotate(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	if !*execGenerated {
+		return notReviewed("/gnoi.certificate.CertificateManagement/Rotate")
+	}
+	conn, err := dialService(ctx, dut, introspect.GNOI, opts)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(ctx, streamWait)
+	defer cancel()
+	stream, err := certpb.NewCertificateManagementClient(conn).Rotate(ctx)
+	if err != nil {
+		return err
+	}
+	return waited(closeAndDrain(stream))
+}
+
+// GnoiContainerzAllRPC implements a sample request for service /gnoi.containerz.Containerz/* to validate if authz works as expected.
+func GnoiContainerzAllRPC(_ context.Context, _ *ondatra.DUTDevice, _ []grpc.DialOption, _ ...any) error {
+	return status.Errorf(codes.Unimplemented, "exec function for RPC /gnoi.containerz.Containerz/* is not implemented")
+}
+
+// GnoiContainerzCreateVolume implements a sample request for service /gnoi.containerz.Containerz/CreateVolume to validate if authz works as expected.
+func GnoiContainerzCreateVolume(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	if !*execGenerated {
+		return notReviewed("/gnoi.containerz.Containerz/CreateVolume")
+	}
+	conn, err := dialService(ctx, dut, introspect.GNOI, opts)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	_, err = containerzpb.NewContainerzClient(conn).CreateVolume(ctx, &containerzpb.CreateVolumeRequest{})
+	return rejected(err)
+}
+
+// GnoiContainerzDeploy implements a sample request for service /gnoi.containerz.Containerz/Deploy to validate if authz works as expected.
+func GnoiContainerzDeploy(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	if !*execGenerated {
+		return notReviewed("/gnoi.containerz.Containerz/Deploy")
+	}
+	conn, err := dialService(ctx, dut, introspect.GNOI, opts)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(ctx, streamWait)
+	defer cancel()
+	stream, err := containerzpb.NewContainerzClient(conn).Deploy(ctx)
+	if err != nil {
+		return err
+	}
+	return waited(closeAndDrain(stream))
+}
+
+// GnoiContainerzLog implements a sample request for service /gnoi.containerz.Containerz/Log to validate if authz works as expected.
+func GnoiContainerzLog(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	if !*execGenerated {
+		return notReviewed("/gnoi.containerz.Containerz/Log")
+	}
+	conn, err := dialService(ctx, dut, introspect.GNOI, opts)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(ctx, streamWait)
+	defer cancel()
+	stream, err := containerzpb.NewContainerzClient(conn).Log(ctx, &containerzpb.LogRequest{})
+	if err != nil {
+		return rejected(err)
+	}
+	return waited(drain(stream))
+}
+
+// GnoiContainerzRemoveContainer implements a sample request for service /gnoi.containerz.Containerz/RemoveContainer to validate if authz works as expected.
+func GnoiContainerzRemoveContainer(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	if !*execGenerated {
+		return notReviewed("/gnoi.containerz.Containerz/RemoveContainer")
+	}
+	conn, err := dialService(ctx, dut, introspect.GNOI, opts)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	_, err = containerzpb.NewContainerzClient(conn).RemoveContainer(ctx, &containerzpb.RemoveContainerRequest{})
+	return rejected(err)
+}
+
+// GnoiContainerzRemoveImage implements a sample request for service /gnoi.containerz.Containerz/RemoveImage to validate if authz works as expected.
+func GnoiContainerzRemoveImage(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	if !*execGenerated {
+		return notReviewed("/gnoi.containerz.Containerz/RemoveImage")
+	}
+	conn, err := dialService(ctx, dut, introspect.GNOI, opts)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	_, err = containerzpb.NewContainerzClient(conn).RemoveImage(ctx, &containerzpb.RemoveImageRequest{})
+	return rejected(err)
+}
+
+// GnoiContainerzRemovePlugin implements a sample request for service /gnoi.containerz.Containerz/RemovePlugin to validate if authz works as expected.
+func GnoiContainerzRemovePlugin(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	if !*execGenerated {
+		return notReviewed("/gnoi.containerz.Containerz/RemovePlugin")
+	}
+	conn, err := dialService(ctx, dut, introspect.GNOI, opts)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	_, err = containerzpb.NewContainerzClient(conn).RemovePlugin(ctx, &containerzpb.RemovePluginRequest{})
+	return rejected(err)
+}
+
+// GnoiContainerzRemoveVolume implements a sample request for service /gnoi.containerz.Containerz/RemoveVolume to validate if authz works as expected.
+func GnoiContainerzRemoveVolume(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	if !*execGenerated {
+		return notReviewed("/gnoi.containerz.Containerz/RemoveVolume")
+	}
+	conn, err := dialService(ctx, dut, introspect.GNOI, opts)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	_, err = containerzpb.NewContainerzClient(conn).RemoveVolume(ctx, &containerzpb.RemoveVolumeRequest{})
+	return rejected(err)
+}
+
+// GnoiContainerzStartContainer implements a sample request for service /gnoi.containerz.Containerz/StartContainer to validate if authz works as expected.
+func GnoiContainerzStartContainer(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	if !*execGenerated {
+		return notReviewed("/gnoi.containerz.Containerz/StartContainer")
+	}
+	conn, err := dialService(ctx, dut, introspect.GNOI, opts)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	_, err = containerzpb.NewContainerzClient(conn).StartContainer(ctx, &containerzpb.StartContainerRequest{})
+	return rejected(err)
+}
+
+// GnoiContainerzStartPlugin implements a sample request for service /gnoi.containerz.Containerz/StartPlugin to validate if authz works as expected.
+func GnoiContainerzStartPlugin(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	if !*execGenerated {
+		return notReviewed("/gnoi.containerz.Containerz/StartPlugin")
+	}
+	conn, err := dialService(ctx, dut, introspect.GNOI, opts)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	_, err = containerzpb.NewContainerzClient(conn).StartPlugin(ctx, &containerzpb.StartPluginRequest{})
+	return rejected(err)
+}
+
+// GnoiContainerzStopContainer implements a sample request for service /gnoi.containerz.Containerz/StopContainer to validate if authz works as expected.
+func GnoiContainerzStopContainer(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	if !*execGenerated {
+		return notReviewed("/gnoi.containerz.Containerz/StopContainer")
+	}
+	conn, err := dialService(ctx, dut, introspect.GNOI, opts)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	_, err = containerzpb.NewContainerzClient(conn).StopContainer(ctx, &containerzpb.StopContainerRequest{})
+	return rejected(err)
+}
+
+// GnoiContainerzStopPlugin implements a sample request for service /gnoi.containerz.Containerz/StopPlugin to validate if authz works as expected.
+func GnoiContainerzStopPlugin(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	if !*execGenerated {
+		return notReviewed("/gnoi.containerz.Containerz/StopPlugin")
+	}
+	conn, err := dialService(ctx, dut, introspect.GNOI, opts)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	_, err = containerzpb.NewContainerzClient(conn).StopPlugin(ctx, &containerzpb.StopPluginRequest{})
+	return rejected(err)
+}
+
+// GnoiContainerzUpdateContainer implements a sample request for service /gnoi.containerz.Containerz/UpdateContainer to validate if authz works as expected.
+func GnoiContainerzUpdateContainer(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	if !*execGenerated {
+		return notReviewed("/gnoi.containerz.Containerz/UpdateContainer")
+	}
+	conn, err := dialService(ctx, dut, introspect.GNOI, opts)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	_, err = containerzpb.NewContainerzClient(conn).UpdateContainer(ctx, &containerzpb.UpdateContainerRequest{})
+	return rejected(err)
+}
+
+// GnoiDebugAllRPC implements a sample request for service /gnoi.debug.Debug/* to validate if authz works as expected.
+func GnoiDebugAllRPC(_ context.Context, _ *ondatra.DUTDevice, _ []grpc.DialOption, _ ...any) error {
+	return status.Errorf(codes.Unimplemented, "exec function for RPC /gnoi.debug.Debug/* is not implemented")
+}
+
+// GnoiDebugDebug implements a sample request for service /gnoi.debug.Debug/Debug to validate if authz works as expected.
+func GnoiDebugDebug(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	if !*execGenerated {
+		return notReviewed("/gnoi.debug.Debug/Debug")
+	}
+	conn, err := dialService(ctx, dut, introspect.GNOI, opts)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(ctx, streamWait)
+	defer cancel()
+	stream, err := debugpb.NewDebugClient(conn).Debug(ctx, &debugpb.DebugRequest{})
+	if err != nil {
+		return rejected(err)
+	}
+	return waited(drain(stream))
+}
+
+// GnoiPacketcaptureAllRPC implements a sample request for service /gnoi.pcap.PacketCapture/* to validate if authz works as expected.
+func GnoiPacketcaptureAllRPC(_ context.Context, _ *ondatra.DUTDevice, _ []grpc.DialOption, _ ...any) error {
+	return status.Errorf(codes.Unimplemented, "exec function for RPC /gnoi.pcap.PacketCapture/* is not implemented")
+}
+
+// GnoiPacketcapturePcap implements a sample request for service /gnoi.pcap.PacketCapture/Pcap to validate if authz works as expected.
+func GnoiPacketcapturePcap(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	if !*execGenerated {
+		return notReviewed("/gnoi.pcap.PacketCapture/Pcap")
+	}
+	conn, err := dialService(ctx, dut, introspect.GNOI, opts)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(ctx, streamWait)
+	defer cancel()
+	stream, err := packetcapturepb.NewPacketCaptureClient(conn).Pcap(ctx, &packetcapturepb.PcapRequest{})
+	if err != nil {
+		return rejected(err)
+	}
+	return waited(drain(stream))
+}
+
+// GnpsiAllRPC implements a sample request for service /gnpsi.gNPSI/* to validate if authz works as expected.
+func GnpsiAllRPC(_ context.Context, _ *ondatra.DUTDevice, _ []grpc.DialOption, _ ...any) error {
+	return status.Errorf(codes.Unimplemented, "exec function for RPC /gnpsi.gNPSI/* is not implemented")
+}
+
+// GnpsiSubscribe implements a sample request for service /gnpsi.gNPSI/Subscribe to validate if authz works as expected.
+func GnpsiSubscribe(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	if !*execGenerated {
+		return notReviewed("/gnpsi.gNPSI/Subscribe")
+	}
+	conn, err := dialService(ctx, dut, introspect.GNPSI, opts)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(ctx, streamWait)
+	defer cancel()
+	stream, err := gnpsipb.NewGNPSIClient(conn).Subscribe(ctx, &gnpsipb.Request{})
+	if err != nil {
+		return rejected(err)
+	}
+	return waited(drain(stream))
+}
+
+// GnsiAcctzstreamAllRPC implements a sample request for service /gnsi.acctz.v1.AcctzStream/* to validate if authz works as expected.
+func GnsiAcctzstreamAllRPC(_ context.Context, _ *ondatra.DUTDevice, _ []grpc.DialOption, _ ...any) error {
+	return status.Errorf(codes.Unimplemented, "exec function for RPC /gnsi.acctz.v1.AcctzStream/* is not implemented")
+}
+
+// GnsiAcctzstreamRecordSubscribe implements a sample request for service /gnsi.acctz.v1.AcctzStream/RecordSubscribe to validate if authz works as expected.
+func GnsiAcctzstreamRecordSubscribe(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	if !*execGenerated {
+		return notReviewed("/gnsi.acctz.v1.AcctzStream/RecordSubscribe")
+	}
+	conn, err := dialService(ctx, dut, introspect.GNSI, opts)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(ctx, streamWait)
+	defer cancel()
+	stream, err := acctzpb.NewAcctzStreamClient(conn).RecordSubscribe(ctx, &acctzpb.RecordRequest{})
+	if err != nil {
+		return rejected(err)
+	}
+	return waited(drain(stream))
+}
+
+// GnsiCertzGetIntegrityManifest implements a sample request for service /gnsi.certz.v1.Certz/GetIntegrityManifest to validate if authz works as expected.
+func GnsiCertzGetIntegrityManifest(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	if !*execGenerated {
+		return notReviewed("/gnsi.certz.v1.Certz/GetIntegrityManifest")
+	}
+	conn, err := dialService(ctx, dut, introspect.GNSI, opts)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	_, err = certzpb.NewCertzClient(conn).GetIntegrityManifest(ctx, &certzpb.GetIntegrityManifestRequest{})
+	return rejected(err)
+}
+
+// OpenconfigTpmattestzserviceAllRPC implements a sample request for service /openconfig.attestz.TpmAttestzService/* to validate if authz works as expected.
+func OpenconfigTpmattestzserviceAllRPC(_ context.Context, _ *ondatra.DUTDevice, _ []grpc.DialOption, _ ...any) error {
+	return status.Errorf(codes.Unimplemented, "exec function for RPC /openconfig.attestz.TpmAttestzService/* is not implemented")
+}
+
+// OpenconfigTpmattestzserviceAttest implements a sample request for service /openconfig.attestz.TpmAttestzService/Attest to validate if authz works as expected.
+func OpenconfigTpmattestzserviceAttest(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	if !*execGenerated {
+		return notReviewed("/openconfig.attestz.TpmAttestzService/Attest")
+	}
+	conn, err := dialService(ctx, dut, introspect.GNSI, opts)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	_, err = tpmattestzpb.NewTpmAttestzServiceClient(conn).Attest(ctx, &tpmattestzpb.AttestRequest{})
+	return rejected(err)
+}
+
+// OpenconfigTpmenrollzserviceAllRPC implements a sample request for service /openconfig.attestz.TpmEnrollzService/* to validate if authz works as expected.
+func OpenconfigTpmenrollzserviceAllRPC(_ context.Context, _ *ondatra.DUTDevice, _ []grpc.DialOption, _ ...any) error {
+	return status.Errorf(codes.Unimplemented, "exec function for RPC /openconfig.attestz.TpmEnrollzService/* is not implemented")
+}
+
+// OpenconfigTpmenrollzserviceChallenge implements a sample request for service /openconfig.attestz.TpmEnrollzService/Challenge to validate if authz works as expected.
+func OpenconfigTpmenrollzserviceChallenge(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	if !*execGenerated {
+		return notReviewed("/openconfig.attestz.TpmEnrollzService/Challenge")
+	}
+	conn, err := dialService(ctx, dut, introspect.GNSI, opts)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	_, err = tpmenrollzpb.NewTpmEnrollzServiceClient(conn).Challenge(ctx, &tpmenrollzpb.ChallengeRequest{})
+	return rejected(err)
+}
+
+// OpenconfigTpmenrollzserviceGetIdevidCsr implements a sample request for service /openconfig.attestz.TpmEnrollzService/GetIdevidCsr to validate if authz works as expected.
+func OpenconfigTpmenrollzserviceGetIdevidCsr(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	if !*execGenerated {
+		return notReviewed("/openconfig.attestz.TpmEnrollzService/GetIdevidCsr")
+	}
+	conn, err := dialService(ctx, dut, introspect.GNSI, opts)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	_, err = tpmenrollzpb.NewTpmEnrollzServiceClient(conn).GetIdevidCsr(ctx, &tpmenrollzpb.GetIdevidCsrRequest{})
+	return rejected(err)
+}
+
+// OpenconfigTpmenrollzserviceRotateAIKCert implements a sample request for service /openconfig.attestz.TpmEnrollzService/RotateAIKCert to validate if authz works as expected.
+func OpenconfigTpmenrollzserviceRotateAIKCert(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	if !*execGenerated {
+		return notReviewed("/openconfig.attestz.TpmEnrollzService/RotateAIKCert")
+	}
+	conn, err := dialService(ctx, dut, introspect.GNSI, opts)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(ctx, streamWait)
+	defer cancel()
+	stream, err := tpmenrollzpb.NewTpmEnrollzServiceClient(conn).RotateAIKCert(ctx)
+	if err != nil {
+		return err
+	}
+	return waited(closeAndDrain(stream))
+}
+
+// OpenconfigTpmenrollzserviceRotateOIakCert implements a sample request for service /openconfig.attestz.TpmEnrollzService/RotateOIakCert to validate if authz works as expected.
+func OpenconfigTpmenrollzserviceRotateOIakCert(ctx context.Context, dut *ondatra.DUTDevice, opts []grpc.DialOption, _ ...any) error {
+	if !*execGenerated {
+		return notReviewed("/openconfig.attestz.TpmEnrollzService/RotateOIakCert")
+	}
+	conn, err := dialService(ctx, dut, introspect.GNSI, opts)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	_, err = tpmenrollzpb.NewTpmEnrollzServiceClient(conn).RotateOIakCert(ctx, &tpmenrollzpb.RotateOIakCertRequest{})
+	return rejected(err)
+}
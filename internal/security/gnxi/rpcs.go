@@ -3,101 +3,148 @@
 package gnxi
 
 type rpcs struct {
-	AllRPC                                   *RPC
-	GnmiAllRPC                               *RPC
-	GnmiGet                                  *RPC
-	GnmiSet                                  *RPC
-	GnmiSubscribe                            *RPC
-	GnmiCapabilities                         *RPC
-	GnoiBgpAllRPC                            *RPC
-	GnoiBgpClearBGPNeighbor                  *RPC
-	GnoiDiagAllRPC                           *RPC
-	GnoiDiagGetBERTResult                    *RPC
-	GnoiDiagStopBERT                         *RPC
-	GnoiDiagStartBERT                        *RPC
-	GnoiFactoryresetAllRPC                   *RPC
-	GnoiFactoryresetStart                    *RPC
-	GnoiFileAllRPC                           *RPC
-	GnoiFilePut                              *RPC
-	GnoiFileRemove                           *RPC
-	GnoiFileStat                             *RPC
-	GnoiFileTransferToRemote                 *RPC
-	GnoiFileGet                              *RPC
-	GnoiHealthzAcknowledge                   *RPC
-	GnoiHealthzAllRPC                        *RPC
-	GnoiHealthzArtifact                      *RPC
-	GnoiHealthzCheck                         *RPC
-	GnoiHealthzList                          *RPC
-	GnoiHealthzGet                           *RPC
-	GnoiLayer2AllRPC                         *RPC
-	GnoiLayer2ClearLLDPInterface             *RPC
-	GnoiLayer2ClearSpanningTree              *RPC
-	GnoiLayer2PerformBERT                    *RPC
-	GnoiLayer2SendWakeOnLAN                  *RPC
-	GnoiLayer2ClearNeighborDiscovery         *RPC
-	GnoiLinkqualificationCreate              *RPC
-	GnoiMplsAllRPC                           *RPC
-	GnoiMplsClearLSPCounters                 *RPC
-	GnoiMplsMPLSPing                         *RPC
-	GnoiMplsClearLSP                         *RPC
-	GnoiOtdrAllRPC                           *RPC
-	GnoiWavelengthrouterAdjustSpectrum       *RPC
-	GnoiWavelengthrouterAllRPC               *RPC
-	GnoiWavelengthrouterCancelAdjustPSD      *RPC
-	GnoiWavelengthrouterCancelAdjustSpectrum *RPC
-	GnoiOsActivate                           *RPC
-	GnoiOsAllRPC                             *RPC
-	GnoiOsVerify                             *RPC
-	GnoiOsInstall                            *RPC
-	GnoiOtdrInitiate                         *RPC
-	GnoiLinkqualificationAllRPC              *RPC
-	GnoiLinkqualificationCapabilities        *RPC
-	GnoiLinkqualificationDelete              *RPC
-	GnoiLinkqualificationGet                 *RPC
-	GnoiLinkqualificationList                *RPC
-	GnoiSystemAllRPC                         *RPC
-	GnoiSystemCancelReboot                   *RPC
-	GnoiSystemKillProcess                    *RPC
-	GnoiSystemReboot                         *RPC
-	GnoiSystemRebootStatus                   *RPC
-	GnoiSystemSetPackage                     *RPC
-	GnoiSystemSwitchControlProcessor         *RPC
-	GnoiSystemTime                           *RPC
-	GnoiSystemTraceroute                     *RPC
-	GnoiSystemPing                           *RPC
-	GnoiWavelengthrouterAdjustPSD            *RPC
-	GnsiAcctzAllRPC                          *RPC
-	GnsiAcctzRecordSubscribe                 *RPC
-	GnsiAuthzAllRPC                          *RPC
-	GnsiAuthzGet                             *RPC
-	GnsiAuthzProbe                           *RPC
-	GnsiAuthzRotate                          *RPC
-	GnsiCertzAddProfile                      *RPC
-	GnsiCertzAllRPC                          *RPC
-	GnsiCertzCanGenerateCSR                  *RPC
-	GnsiCertzDeleteProfile                   *RPC
-	GnsiCertzGetProfileList                  *RPC
-	GnsiCertzRotate                          *RPC
-	GnsiCredentialzAllRPC                    *RPC
-	GnsiCredentialzCanGenerateKey            *RPC
-	GnsiCredentialzGetPublicKeys             *RPC
-	GnsiCredentialzRotateHostParameters      *RPC
-	GnsiCredentialzRotateAccountCredentials  *RPC
-	GnsiPathzAllRPC                          *RPC
-	GnsiPathzGet                             *RPC
-	GnsiPathzProbe                           *RPC
-	GnsiPathzRotate                          *RPC
-	GribiAllRPC                              *RPC
-	GribiFlush                               *RPC
-	GribiGet                                 *RPC
-	GribiModify                              *RPC
-	P4P4runtimeAllRPC                        *RPC
-	P4P4runtimeCapabilities                  *RPC
-	P4P4runtimeGetForwardingPipelineConfig   *RPC
-	P4P4runtimeRead                          *RPC
-	P4P4runtimeSetForwardingPipelineConfig   *RPC
-	P4P4runtimeStreamChannel                 *RPC
-	P4P4runtimeWrite                         *RPC
+	AllRPC                                                  *RPC
+	GnmiAllRPC                                              *RPC
+	GnmiCapabilities                                        *RPC
+	GnmiGet                                                 *RPC
+	GnmiSet                                                 *RPC
+	GnmiSubscribe                                           *RPC
+	GnoiBgpAllRPC                                           *RPC
+	GnoiBgpClearBGPNeighbor                                 *RPC
+	GnoiBootconfigAllRPC                                    *RPC
+	GnoiBootconfigGetBootConfig                             *RPC
+	GnoiBootconfigSetBootConfig                             *RPC
+	GnoiCertificatemanagementAllRPC                         *RPC
+	GnoiCertificatemanagementCanGenerateCSR                 *RPC
+	GnoiCertificatemanagementGenerateCSR                    *RPC
+	GnoiCertificatemanagementGetCertificates                *RPC
+	GnoiCertificatemanagementInstall                        *RPC
+	GnoiCertificatemanagementLoadCertificate                *RPC
+	GnoiCertificatemanagementLoadCertificateAuthorityBundle *RPC
+	GnoiCertificatemanagementRevokeCertificates             *RPC
+	GnoiCertificatemanagementRotate                         *RPC
+	GnoiContainerzAllRPC                                    *RPC
+	GnoiContainerzCreateVolume                              *RPC
+	GnoiContainerzDeploy                                    *RPC
+	GnoiContainerzListContainer                             *RPC
+	GnoiContainerzListImage                                 *RPC
+	GnoiContainerzListPlugins                               *RPC
+	GnoiContainerzListVolume                                *RPC
+	GnoiContainerzLog                                       *RPC
+	GnoiContainerzRemoveContainer                           *RPC
+	GnoiContainerzRemoveImage                               *RPC
+	GnoiContainerzRemovePlugin                              *RPC
+	GnoiContainerzRemoveVolume                              *RPC
+	GnoiContainerzStartContainer                            *RPC
+	GnoiContainerzStartPlugin                               *RPC
+	GnoiContainerzStopContainer                             *RPC
+	GnoiContainerzStopPlugin                                *RPC
+	GnoiContainerzUpdateContainer                           *RPC
+	GnoiDebugAllRPC                                         *RPC
+	GnoiDebugDebug                                          *RPC
+	GnoiDiagAllRPC                                          *RPC
+	GnoiDiagGetBERTResult                                   *RPC
+	GnoiDiagStartBERT                                       *RPC
+	GnoiDiagStopBERT                                        *RPC
+	GnoiFactoryresetAllRPC                                  *RPC
+	GnoiFactoryresetStart                                   *RPC
+	GnoiFileAllRPC                                          *RPC
+	GnoiFileGet                                             *RPC
+	GnoiFilePut                                             *RPC
+	GnoiFileRemove                                          *RPC
+	GnoiFileStat                                            *RPC
+	GnoiFileTransferToRemote                                *RPC
+	GnoiHealthzAllRPC                                       *RPC
+	GnoiHealthzAcknowledge                                  *RPC
+	GnoiHealthzArtifact                                     *RPC
+	GnoiHealthzCheck                                        *RPC
+	GnoiHealthzGet                                          *RPC
+	GnoiHealthzList                                         *RPC
+	GnoiLayer2AllRPC                                        *RPC
+	GnoiLayer2ClearLLDPInterface                            *RPC
+	GnoiLayer2ClearNeighborDiscovery                        *RPC
+	GnoiLayer2ClearSpanningTree                             *RPC
+	GnoiLayer2PerformBERT                                   *RPC
+	GnoiLayer2SendWakeOnLAN                                 *RPC
+	GnoiMplsAllRPC                                          *RPC
+	GnoiMplsClearLSP                                        *RPC
+	GnoiMplsClearLSPCounters                                *RPC
+	GnoiMplsMPLSPing                                        *RPC
+	GnoiOtdrAllRPC                                          *RPC
+	GnoiOtdrInitiate                                        *RPC
+	GnoiWavelengthrouterAllRPC                              *RPC
+	GnoiWavelengthrouterAdjustPSD                           *RPC
+	GnoiWavelengthrouterAdjustSpectrum                      *RPC
+	GnoiWavelengthrouterCancelAdjustPSD                     *RPC
+	GnoiWavelengthrouterCancelAdjustSpectrum                *RPC
+	GnoiOsAllRPC                                            *RPC
+	GnoiOsActivate                                          *RPC
+	GnoiOsInstall                                           *RPC
+	GnoiOsVerify                                            *RPC
+	GnoiLinkqualificationAllRPC                             *RPC
+	GnoiLinkqualificationCapabilities                       *RPC
+	GnoiLinkqualificationCreate                             *RPC
+	GnoiLinkqualificationDelete                             *RPC
+	GnoiLinkqualificationGet                                *RPC
+	GnoiLinkqualificationList                               *RPC
+	GnoiPacketcaptureAllRPC                                 *RPC
+	GnoiPacketcapturePcap                                   *RPC
+	GnoiSystemAllRPC                                        *RPC
+	GnoiSystemCancelReboot                                  *RPC
+	GnoiSystemKillProcess                                   *RPC
+	GnoiSystemPing                                          *RPC
+	GnoiSystemReboot                                        *RPC
+	GnoiSystemRebootStatus                                  *RPC
+	GnoiSystemSetPackage                                    *RPC
+	GnoiSystemSwitchControlProcessor                        *RPC
+	GnoiSystemTime                                          *RPC
+	GnoiSystemTraceroute                                    *RPC
+	GnpsiAllRPC                                             *RPC
+	GnpsiSubscribe                                          *RPC
+	GnsiAcctzAllRPC                                         *RPC
+	GnsiAcctzRecordSubscribe                                *RPC
+	GnsiAcctzstreamAllRPC                                   *RPC
+	GnsiAcctzstreamRecordSubscribe                          *RPC
+	GnsiAuthzAllRPC                                         *RPC
+	GnsiAuthzGet                                            *RPC
+	GnsiAuthzProbe                                          *RPC
+	GnsiAuthzRotate                                         *RPC
+	GnsiCertzAllRPC                                         *RPC
+	GnsiCertzAddProfile                                     *RPC
+	GnsiCertzCanGenerateCSR                                 *RPC
+	GnsiCertzDeleteProfile                                  *RPC
+	GnsiCertzGetIntegrityManifest                           *RPC
+	GnsiCertzGetProfileList                                 *RPC
+	GnsiCertzRotate                                         *RPC
+	GnsiCredentialzAllRPC                                   *RPC
+	GnsiCredentialzCanGenerateKey                           *RPC
+	GnsiCredentialzGetPublicKeys                            *RPC
+	GnsiCredentialzRotateAccountCredentials                 *RPC
+	GnsiCredentialzRotateHostParameters                     *RPC
+	GnsiPathzAllRPC                                         *RPC
+	GnsiPathzGet                                            *RPC
+	GnsiPathzProbe                                          *RPC
+	GnsiPathzRotate                                         *RPC
+	GribiAllRPC                                             *RPC
+	GribiFlush                                              *RPC
+	GribiGet                                                *RPC
+	GribiModify                                             *RPC
+	OpenconfigTpmattestzserviceAllRPC                       *RPC
+	OpenconfigTpmattestzserviceAttest                       *RPC
+	OpenconfigTpmenrollzserviceAllRPC                       *RPC
+	OpenconfigTpmenrollzserviceChallenge                    *RPC
+	OpenconfigTpmenrollzserviceGetControlCardVendorID       *RPC
+	OpenconfigTpmenrollzserviceGetIakCert                   *RPC
+	OpenconfigTpmenrollzserviceGetIdevidCsr                 *RPC
+	OpenconfigTpmenrollzserviceRotateAIKCert                *RPC
+	OpenconfigTpmenrollzserviceRotateOIakCert               *RPC
+	P4P4runtimeAllRPC                                       *RPC
+	P4P4runtimeCapabilities                                 *RPC
+	P4P4runtimeGetForwardingPipelineConfig                  *RPC
+	P4P4runtimeRead                                         *RPC
+	P4P4runtimeSetForwardingPipelineConfig                  *RPC
+	P4P4runtimeStreamChannel                                *RPC
+	P4P4runtimeWrite                                        *RPC
 }
 
 var (
@@ -116,6 +163,13 @@ var (
 		Path:    "/gnmi.gNMI/*",
 		Exec:    GnmiAllRPC,
 	}
+	gnmiCapabilities = &RPC{
+		Name:    "Capabilities",
+		Service: "gnmi.gNMI",
+		FQN:     "gnmi.gNMI.Capabilities",
+		Path:    "/gnmi.gNMI/Capabilities",
+		Exec:    GnmiCapabilities,
+	}
 	gnmiGet = &RPC{
 		Name:    "Get",
 		Service: "gnmi.gNMI",
@@ -137,13 +191,6 @@ var (
 		Path:    "/gnmi.gNMI/Subscribe",
 		Exec:    GnmiSubscribe,
 	}
-	gnmiCapabilities = &RPC{
-		Name:    "Capabilities",
-		Service: "gnmi.gNMI",
-		FQN:     "gnmi.gNMI.Capabilities",
-		Path:    "/gnmi.gNMI/Capabilities",
-		Exec:    GnmiCapabilities,
-	}
 	gnoibgpALL = &RPC{
 		Name:    "*",
 		Service: "gnoi.bgp.BGP",
@@ -158,6 +205,223 @@ var (
 		Path:    "/gnoi.bgp.BGP/ClearBGPNeighbor",
 		Exec:    GnoiBgpClearBGPNeighbor,
 	}
+	gnoibootconfigALL = &RPC{
+		Name:    "*",
+		Service: "gnoi.bootconfig.BootConfig",
+		FQN:     "gnoi.bootconfig.BootConfig.*",
+		Path:    "/gnoi.bootconfig.BootConfig/*",
+		Exec:    GnoiBootconfigAllRPC,
+	}
+	gnoibootconfigGetBootConfig = &RPC{
+		Name:    "GetBootConfig",
+		Service: "gnoi.bootconfig.BootConfig",
+		FQN:     "gnoi.bootconfig.BootConfig.GetBootConfig",
+		Path:    "/gnoi.bootconfig.BootConfig/GetBootConfig",
+		Exec:    GnoiBootconfigGetBootConfig,
+	}
+	gnoibootconfigSetBootConfig = &RPC{
+		Name:    "SetBootConfig",
+		Service: "gnoi.bootconfig.BootConfig",
+		FQN:     "gnoi.bootconfig.BootConfig.SetBootConfig",
+		Path:    "/gnoi.bootconfig.BootConfig/SetBootConfig",
+		Exec:    GnoiBootconfigSetBootConfig,
+	}
+	gnoicertificateCertificateManagementALL = &RPC{
+		Name:    "*",
+		Service: "gnoi.certificate.CertificateManagement",
+		FQN:     "gnoi.certificate.CertificateManagement.*",
+		Path:    "/gnoi.certificate.CertificateManagement/*",
+		Exec:    GnoiCertificatemanagementAllRPC,
+	}
+	gnoicertificateCertificateManagementCanGenerateCSR = &RPC{
+		Name:    "CanGenerateCSR",
+		Service: "gnoi.certificate.CertificateManagement",
+		FQN:     "gnoi.certificate.CertificateManagement.CanGenerateCSR",
+		Path:    "/gnoi.certificate.CertificateManagement/CanGenerateCSR",
+		Exec:    GnoiCertificatemanagementCanGenerateCSR,
+	}
+	gnoicertificateCertificateManagementGenerateCSR = &RPC{
+		Name:    "GenerateCSR",
+		Service: "gnoi.certificate.CertificateManagement",
+		FQN:     "gnoi.certificate.CertificateManagement.GenerateCSR",
+		Path:    "/gnoi.certificate.CertificateManagement/GenerateCSR",
+		Exec:    GnoiCertificatemanagementGenerateCSR,
+	}
+	gnoicertificateCertificateManagementGetCertificates = &RPC{
+		Name:    "GetCertificates",
+		Service: "gnoi.certificate.CertificateManagement",
+		FQN:     "gnoi.certificate.CertificateManagement.GetCertificates",
+		Path:    "/gnoi.certificate.CertificateManagement/GetCertificates",
+		Exec:    GnoiCertificatemanagementGetCertificates,
+	}
+	gnoicertificateCertificateManagementInstall = &RPC{
+		Name:    "Install",
+		Service: "gnoi.certificate.CertificateManagement",
+		FQN:     "gnoi.certificate.CertificateManagement.Install",
+		Path:    "/gnoi.certificate.CertificateManagement/Install",
+		Exec:    GnoiCertificatemanagementInstall,
+	}
+	gnoicertificateCertificateManagementLoadCertificate = &RPC{
+		Name:    "LoadCertificate",
+		Service: "gnoi.certificate.CertificateManagement",
+		FQN:     "gnoi.certificate.CertificateManagement.LoadCertificate",
+		Path:    "/gnoi.certificate.CertificateManagement/LoadCertificate",
+		Exec:    GnoiCertificatemanagementLoadCertificate,
+	}
+	gnoicertificateCertificateManagementLoadCertificateAuthorityBundle = &RPC{
+		Name:    "LoadCertificateAuthorityBundle",
+		Service: "gnoi.certificate.CertificateManagement",
+		FQN:     "gnoi.certificate.CertificateManagement.LoadCertificateAuthorityBundle",
+		Path:    "/gnoi.certificate.CertificateManagement/LoadCertificateAuthorityBundle",
+		Exec:    GnoiCertificatemanagementLoadCertificateAuthorityBundle,
+	}
+	gnoicertificateCertificateManagementRevokeCertificates = &RPC{
+		Name:    "RevokeCertificates",
+		Service: "gnoi.certificate.CertificateManagement",
+		FQN:     "gnoi.certificate.CertificateManagement.RevokeCertificates",
+		Path:    "/gnoi.certificate.CertificateManagement/RevokeCertificates",
+		Exec:    GnoiCertificatemanagementRevokeCertificates,
+	}
+	gnoicertificateCertificateManagementRotate = &RPC{
+		Name:    "Rotate",
+		Service: "gnoi.certificate.CertificateManagement",
+		FQN:     "gnoi.certificate.CertificateManagement.Rotate",
+		Path:    "/gnoi.certificate.CertificateManagement/Rotate",
+		Exec:    GnoiCertificatemanagementRotate,
+	}
+	gnoicontainerzALL = &RPC{
+		Name:    "*",
+		Service: "gnoi.containerz.Containerz",
+		FQN:     "gnoi.containerz.Containerz.*",
+		Path:    "/gnoi.containerz.Containerz/*",
+		Exec:    GnoiContainerzAllRPC,
+	}
+	gnoicontainerzCreateVolume = &RPC{
+		Name:    "CreateVolume",
+		Service: "gnoi.containerz.Containerz",
+		FQN:     "gnoi.containerz.Containerz.CreateVolume",
+		Path:    "/gnoi.containerz.Containerz/CreateVolume",
+		Exec:    GnoiContainerzCreateVolume,
+	}
+	gnoicontainerzDeploy = &RPC{
+		Name:    "Deploy",
+		Service: "gnoi.containerz.Containerz",
+		FQN:     "gnoi.containerz.Containerz.Deploy",
+		Path:    "/gnoi.containerz.Containerz/Deploy",
+		Exec:    GnoiContainerzDeploy,
+	}
+	gnoicontainerzListContainer = &RPC{
+		Name:    "ListContainer",
+		Service: "gnoi.containerz.Containerz",
+		FQN:     "gnoi.containerz.Containerz.ListContainer",
+		Path:    "/gnoi.containerz.Containerz/ListContainer",
+		Exec:    GnoiContainerzListContainer,
+	}
+	gnoicontainerzListImage = &RPC{
+		Name:    "ListImage",
+		Service: "gnoi.containerz.Containerz",
+		FQN:     "gnoi.containerz.Containerz.ListImage",
+		Path:    "/gnoi.containerz.Containerz/ListImage",
+		Exec:    GnoiContainerzListImage,
+	}
+	gnoicontainerzListPlugins = &RPC{
+		Name:    "ListPlugins",
+		Service: "gnoi.containerz.Containerz",
+		FQN:     "gnoi.containerz.Containerz.ListPlugins",
+		Path:    "/gnoi.containerz.Containerz/ListPlugins",
+		Exec:    GnoiContainerzListPlugins,
+	}
+	gnoicontainerzListVolume = &RPC{
+		Name:    "ListVolume",
+		Service: "gnoi.containerz.Containerz",
+		FQN:     "gnoi.containerz.Containerz.ListVolume",
+		Path:    "/gnoi.containerz.Containerz/ListVolume",
+		Exec:    GnoiContainerzListVolume,
+	}
+	gnoicontainerzLog = &RPC{
+		Name:    "Log",
+		Service: "gnoi.containerz.Containerz",
+		FQN:     "gnoi.containerz.Containerz.Log",
+		Path:    "/gnoi.containerz.Containerz/Log",
+		Exec:    GnoiContainerzLog,
+	}
+	gnoicontainerzRemoveContainer = &RPC{
+		Name:    "RemoveContainer",
+		Service: "gnoi.containerz.Containerz",
+		FQN:     "gnoi.containerz.Containerz.RemoveContainer",
+		Path:    "/gnoi.containerz.Containerz/RemoveContainer",
+		Exec:    GnoiContainerzRemoveContainer,
+	}
+	gnoicontainerzRemoveImage = &RPC{
+		Name:    "RemoveImage",
+		Service: "gnoi.containerz.Containerz",
+		FQN:     "gnoi.containerz.Containerz.RemoveImage",
+		Path:    "/gnoi.containerz.Containerz/RemoveImage",
+		Exec:    GnoiContainerzRemoveImage,
+	}
+	gnoicontainerzRemovePlugin = &RPC{
+		Name:    "RemovePlugin",
+		Service: "gnoi.containerz.Containerz",
+		FQN:     "gnoi.containerz.Containerz.RemovePlugin",
+		Path:    "/gnoi.containerz.Containerz/RemovePlugin",
+		Exec:    GnoiContainerzRemovePlugin,
+	}
+	gnoicontainerzRemoveVolume = &RPC{
+		Name:    "RemoveVolume",
+		Service: "gnoi.containerz.Containerz",
+		FQN:     "gnoi.containerz.Containerz.RemoveVolume",
+		Path:    "/gnoi.containerz.Containerz/RemoveVolume",
+		Exec:    GnoiContainerzRemoveVolume,
+	}
+	gnoicontainerzStartContainer = &RPC{
+		Name:    "StartContainer",
+		Service: "gnoi.containerz.Containerz",
+		FQN:     "gnoi.containerz.Containerz.StartContainer",
+		Path:    "/gnoi.containerz.Containerz/StartContainer",
+		Exec:    GnoiContainerzStartContainer,
+	}
+	gnoicontainerzStartPlugin = &RPC{
+		Name:    "StartPlugin",
+		Service: "gnoi.containerz.Containerz",
+		FQN:     "gnoi.containerz.Containerz.StartPlugin",
+		Path:    "/gnoi.containerz.Containerz/StartPlugin",
+		Exec:    GnoiContainerzStartPlugin,
+	}
+	gnoicontainerzStopContainer = &RPC{
+		Name:    "StopContainer",
+		Service: "gnoi.containerz.Containerz",
+		FQN:     "gnoi.containerz.Containerz.StopContainer",
+		Path:    "/gnoi.containerz.Containerz/StopContainer",
+		Exec:    GnoiContainerzStopContainer,
+	}
+	gnoicontainerzStopPlugin = &RPC{
+		Name:    "StopPlugin",
+		Service: "gnoi.containerz.Containerz",
+		FQN:     "gnoi.containerz.Containerz.StopPlugin",
+		Path:    "/gnoi.containerz.Containerz/StopPlugin",
+		Exec:    GnoiContainerzStopPlugin,
+	}
+	gnoicontainerzUpdateContainer = &RPC{
+		Name:    "UpdateContainer",
+		Service: "gnoi.containerz.Containerz",
+		FQN:     "gnoi.containerz.Containerz.UpdateContainer",
+		Path:    "/gnoi.containerz.Containerz/UpdateContainer",
+		Exec:    GnoiContainerzUpdateContainer,
+	}
+	gnoidebugALL = &RPC{
+		Name:    "*",
+		Service: "gnoi.debug.Debug",
+		FQN:     "gnoi.debug.Debug.*",
+		Path:    "/gnoi.debug.Debug/*",
+		Exec:    GnoiDebugAllRPC,
+	}
+	gnoidebugDebug = &RPC{
+		Name:    "Debug",
+		Service: "gnoi.debug.Debug",
+		FQN:     "gnoi.debug.Debug.Debug",
+		Path:    "/gnoi.debug.Debug/Debug",
+		Exec:    GnoiDebugDebug,
+	}
 	gnoidiagALL = &RPC{
 		Name:    "*",
 		Service: "gnoi.diag.Diag",
@@ -172,13 +436,6 @@ var (
 		Path:    "/gnoi.diag.Diag/GetBERTResult",
 		Exec:    GnoiDiagGetBERTResult,
 	}
-	gnoidiagStopBERT = &RPC{
-		Name:    "StopBERT",
-		Service: "gnoi.diag.Diag",
-		FQN:     "gnoi.diag.Diag.StopBERT",
-		Path:    "/gnoi.diag.Diag/StopBERT",
-		Exec:    GnoiDiagStopBERT,
-	}
 	gnoidiagStartBERT = &RPC{
 		Name:    "StartBERT",
 		Service: "gnoi.diag.Diag",
@@ -186,14 +443,21 @@ var (
 		Path:    "/gnoi.diag.Diag/StartBERT",
 		Exec:    GnoiDiagStartBERT,
 	}
-	gnoifactory_resetFactoryResetALL = &RPC{ //revive:disable-line the name of the rpc includes _
+	gnoidiagStopBERT = &RPC{
+		Name:    "StopBERT",
+		Service: "gnoi.diag.Diag",
+		FQN:     "gnoi.diag.Diag.StopBERT",
+		Path:    "/gnoi.diag.Diag/StopBERT",
+		Exec:    GnoiDiagStopBERT,
+	}
+	gnoifactory_resetFactoryResetALL = &RPC{
 		Name:    "*",
 		Service: "gnoi.factory_reset.FactoryReset",
 		FQN:     "gnoi.factory_reset.FactoryReset.*",
 		Path:    "/gnoi.factory_reset.FactoryReset/*",
 		Exec:    GnoiFactoryresetAllRPC,
 	}
-	gnoifactory_resetFactoryResetStart = &RPC{ //revive:disable-line the name of the rpc includes _
+	gnoifactory_resetFactoryResetStart = &RPC{
 		Name:    "Start",
 		Service: "gnoi.factory_reset.FactoryReset",
 		FQN:     "gnoi.factory_reset.FactoryReset.Start",
@@ -207,6 +471,13 @@ var (
 		Path:    "/gnoi.file.File/*",
 		Exec:    GnoiFileAllRPC,
 	}
+	gnoifileGet = &RPC{
+		Name:    "Get",
+		Service: "gnoi.file.File",
+		FQN:     "gnoi.file.File.Get",
+		Path:    "/gnoi.file.File/Get",
+		Exec:    GnoiFileGet,
+	}
 	gnoifilePut = &RPC{
 		Name:    "Put",
 		Service: "gnoi.file.File",
@@ -235,12 +506,12 @@ var (
 		Path:    "/gnoi.file.File/TransferToRemote",
 		Exec:    GnoiFileTransferToRemote,
 	}
-	gnoifileGet = &RPC{
-		Name:    "Get",
-		Service: "gnoi.file.File",
-		FQN:     "gnoi.file.File.Get",
-		Path:    "/gnoi.file.File/Get",
-		Exec:    GnoiFileGet,
+	gnoihealthzALL = &RPC{
+		Name:    "*",
+		Service: "gnoi.healthz.Healthz",
+		FQN:     "gnoi.healthz.Healthz.*",
+		Path:    "/gnoi.healthz.Healthz/*",
+		Exec:    GnoiHealthzAllRPC,
 	}
 	gnoihealthzAcknowledge = &RPC{
 		Name:    "Acknowledge",
@@ -249,13 +520,6 @@ var (
 		Path:    "/gnoi.healthz.Healthz/Acknowledge",
 		Exec:    GnoiHealthzAcknowledge,
 	}
-	gnoihealthzALL = &RPC{
-		Name:    "*",
-		Service: "gnoi.healthz.Healthz",
-		FQN:     "gnoi.healthz.Healthz.*",
-		Path:    "/gnoi.healthz.Healthz/*",
-		Exec:    GnoiHealthzAllRPC,
-	}
 	gnoihealthzArtifact = &RPC{
 		Name:    "Artifact",
 		Service: "gnoi.healthz.Healthz",
@@ -270,13 +534,6 @@ var (
 		Path:    "/gnoi.healthz.Healthz/Check",
 		Exec:    GnoiHealthzCheck,
 	}
-	gnoihealthzList = &RPC{
-		Name:    "List",
-		Service: "gnoi.healthz.Healthz",
-		FQN:     "gnoi.healthz.Healthz.List",
-		Path:    "/gnoi.healthz.Healthz/List",
-		Exec:    GnoiHealthzList,
-	}
 	gnoihealthzGet = &RPC{
 		Name:    "Get",
 		Service: "gnoi.healthz.Healthz",
@@ -284,6 +541,13 @@ var (
 		Path:    "/gnoi.healthz.Healthz/Get",
 		Exec:    GnoiHealthzGet,
 	}
+	gnoihealthzList = &RPC{
+		Name:    "List",
+		Service: "gnoi.healthz.Healthz",
+		FQN:     "gnoi.healthz.Healthz.List",
+		Path:    "/gnoi.healthz.Healthz/List",
+		Exec:    GnoiHealthzList,
+	}
 	gnoilayer2ALL = &RPC{
 		Name:    "*",
 		Service: "gnoi.layer2.Layer2",
@@ -298,6 +562,13 @@ var (
 		Path:    "/gnoi.layer2.Layer2/ClearLLDPInterface",
 		Exec:    GnoiLayer2ClearLLDPInterface,
 	}
+	gnoilayer2ClearNeighborDiscovery = &RPC{
+		Name:    "ClearNeighborDiscovery",
+		Service: "gnoi.layer2.Layer2",
+		FQN:     "gnoi.layer2.Layer2.ClearNeighborDiscovery",
+		Path:    "/gnoi.layer2.Layer2/ClearNeighborDiscovery",
+		Exec:    GnoiLayer2ClearNeighborDiscovery,
+	}
 	gnoilayer2ClearSpanningTree = &RPC{
 		Name:    "ClearSpanningTree",
 		Service: "gnoi.layer2.Layer2",
@@ -319,20 +590,6 @@ var (
 		Path:    "/gnoi.layer2.Layer2/SendWakeOnLAN",
 		Exec:    GnoiLayer2SendWakeOnLAN,
 	}
-	gnoilayer2ClearNeighborDiscovery = &RPC{
-		Name:    "ClearNeighborDiscovery",
-		Service: "gnoi.layer2.Layer2",
-		FQN:     "gnoi.layer2.Layer2.ClearNeighborDiscovery",
-		Path:    "/gnoi.layer2.Layer2/ClearNeighborDiscovery",
-		Exec:    GnoiLayer2ClearNeighborDiscovery,
-	}
-	gnoipacket_link_qualificationLinkQualificationCreate = &RPC{ //revive:disable-line the name of the rpc includes _
-		Name:    "Create",
-		Service: "gnoi.packet_link_qualification.LinkQualification",
-		FQN:     "gnoi.packet_link_qualification.LinkQualification.Create",
-		Path:    "/gnoi.packet_link_qualification.LinkQualification/Create",
-		Exec:    GnoiLinkqualificationCreate,
-	}
 	gnoimplsALL = &RPC{
 		Name:    "*",
 		Service: "gnoi.mpls.MPLS",
@@ -340,6 +597,13 @@ var (
 		Path:    "/gnoi.mpls.MPLS/*",
 		Exec:    GnoiMplsAllRPC,
 	}
+	gnoimplsClearLSP = &RPC{
+		Name:    "ClearLSP",
+		Service: "gnoi.mpls.MPLS",
+		FQN:     "gnoi.mpls.MPLS.ClearLSP",
+		Path:    "/gnoi.mpls.MPLS/ClearLSP",
+		Exec:    GnoiMplsClearLSP,
+	}
 	gnoimplsClearLSPCounters = &RPC{
 		Name:    "ClearLSPCounters",
 		Service: "gnoi.mpls.MPLS",
@@ -354,13 +618,6 @@ var (
 		Path:    "/gnoi.mpls.MPLS/MPLSPing",
 		Exec:    GnoiMplsMPLSPing,
 	}
-	gnoimplsClearLSP = &RPC{
-		Name:    "ClearLSP",
-		Service: "gnoi.mpls.MPLS",
-		FQN:     "gnoi.mpls.MPLS.ClearLSP",
-		Path:    "/gnoi.mpls.MPLS/ClearLSP",
-		Exec:    GnoiMplsClearLSP,
-	}
 	gnoiopticalOTDRALL = &RPC{
 		Name:    "*",
 		Service: "gnoi.optical.OTDR",
@@ -368,12 +625,12 @@ var (
 		Path:    "/gnoi.optical.OTDR/*",
 		Exec:    GnoiOtdrAllRPC,
 	}
-	gnoiopticalWavelengthRouterAdjustSpectrum = &RPC{
-		Name:    "AdjustSpectrum",
-		Service: "gnoi.optical.WavelengthRouter",
-		FQN:     "gnoi.optical.WavelengthRouter.AdjustSpectrum",
-		Path:    "/gnoi.optical.WavelengthRouter/AdjustSpectrum",
-		Exec:    GnoiWavelengthrouterAdjustSpectrum,
+	gnoiopticalOTDRInitiate = &RPC{
+		Name:    "Initiate",
+		Service: "gnoi.optical.OTDR",
+		FQN:     "gnoi.optical.OTDR.Initiate",
+		Path:    "/gnoi.optical.OTDR/Initiate",
+		Exec:    GnoiOtdrInitiate,
 	}
 	gnoiopticalWavelengthRouterALL = &RPC{
 		Name:    "*",
@@ -382,6 +639,20 @@ var (
 		Path:    "/gnoi.optical.WavelengthRouter/*",
 		Exec:    GnoiWavelengthrouterAllRPC,
 	}
+	gnoiopticalWavelengthRouterAdjustPSD = &RPC{
+		Name:    "AdjustPSD",
+		Service: "gnoi.optical.WavelengthRouter",
+		FQN:     "gnoi.optical.WavelengthRouter.AdjustPSD",
+		Path:    "/gnoi.optical.WavelengthRouter/AdjustPSD",
+		Exec:    GnoiWavelengthrouterAdjustPSD,
+	}
+	gnoiopticalWavelengthRouterAdjustSpectrum = &RPC{
+		Name:    "AdjustSpectrum",
+		Service: "gnoi.optical.WavelengthRouter",
+		FQN:     "gnoi.optical.WavelengthRouter.AdjustSpectrum",
+		Path:    "/gnoi.optical.WavelengthRouter/AdjustSpectrum",
+		Exec:    GnoiWavelengthrouterAdjustSpectrum,
+	}
 	gnoiopticalWavelengthRouterCancelAdjustPSD = &RPC{
 		Name:    "CancelAdjustPSD",
 		Service: "gnoi.optical.WavelengthRouter",
@@ -396,13 +667,6 @@ var (
 		Path:    "/gnoi.optical.WavelengthRouter/CancelAdjustSpectrum",
 		Exec:    GnoiWavelengthrouterCancelAdjustSpectrum,
 	}
-	gnoiosActivate = &RPC{
-		Name:    "Activate",
-		Service: "gnoi.os.OS",
-		FQN:     "gnoi.os.OS.Activate",
-		Path:    "/gnoi.os.OS/Activate",
-		Exec:    GnoiOsActivate,
-	}
 	gnoiosALL = &RPC{
 		Name:    "*",
 		Service: "gnoi.os.OS",
@@ -410,12 +674,12 @@ var (
 		Path:    "/gnoi.os.OS/*",
 		Exec:    GnoiOsAllRPC,
 	}
-	gnoiosVerify = &RPC{
-		Name:    "Verify",
+	gnoiosActivate = &RPC{
+		Name:    "Activate",
 		Service: "gnoi.os.OS",
-		FQN:     "gnoi.os.OS.Verify",
-		Path:    "/gnoi.os.OS/Verify",
-		Exec:    GnoiOsVerify,
+		FQN:     "gnoi.os.OS.Activate",
+		Path:    "/gnoi.os.OS/Activate",
+		Exec:    GnoiOsActivate,
 	}
 	gnoiosInstall = &RPC{
 		Name:    "Install",
@@ -424,49 +688,69 @@ var (
 		Path:    "/gnoi.os.OS/Install",
 		Exec:    GnoiOsInstall,
 	}
-	gnoiopticalOTDRInitiate = &RPC{
-		Name:    "Initiate",
-		Service: "gnoi.optical.OTDR",
-		FQN:     "gnoi.optical.OTDR.Initiate",
-		Path:    "/gnoi.optical.OTDR/Initiate",
-		Exec:    GnoiOtdrInitiate,
+	gnoiosVerify = &RPC{
+		Name:    "Verify",
+		Service: "gnoi.os.OS",
+		FQN:     "gnoi.os.OS.Verify",
+		Path:    "/gnoi.os.OS/Verify",
+		Exec:    GnoiOsVerify,
 	}
-	gnoipacket_link_qualificationLinkQualificationALL = &RPC{ //revive:disable-line the name of the rpc includes _
+	gnoipacket_link_qualificationLinkQualificationALL = &RPC{
+		Name:    "*",
 		Service: "gnoi.packet_link_qualification.LinkQualification",
 		FQN:     "gnoi.packet_link_qualification.LinkQualification.*",
 		Path:    "/gnoi.packet_link_qualification.LinkQualification/*",
 		Exec:    GnoiLinkqualificationAllRPC,
 	}
-	gnoipacket_link_qualificationLinkQualificationCapabilities = &RPC{ //revive:disable-line the name of the rpc includes _
-
+	gnoipacket_link_qualificationLinkQualificationCapabilities = &RPC{
 		Name:    "Capabilities",
 		Service: "gnoi.packet_link_qualification.LinkQualification",
 		FQN:     "gnoi.packet_link_qualification.LinkQualification.Capabilities",
 		Path:    "/gnoi.packet_link_qualification.LinkQualification/Capabilities",
 		Exec:    GnoiLinkqualificationCapabilities,
 	}
-	gnoipacket_link_qualificationLinkQualificationDelete = &RPC{ //revive:disable-line the name of the rpc includes _
-
+	gnoipacket_link_qualificationLinkQualificationCreate = &RPC{
+		Name:    "Create",
+		Service: "gnoi.packet_link_qualification.LinkQualification",
+		FQN:     "gnoi.packet_link_qualification.LinkQualification.Create",
+		Path:    "/gnoi.packet_link_qualification.LinkQualification/Create",
+		Exec:    GnoiLinkqualificationCreate,
+	}
+	gnoipacket_link_qualificationLinkQualificationDelete = &RPC{
 		Name:    "Delete",
 		Service: "gnoi.packet_link_qualification.LinkQualification",
 		FQN:     "gnoi.packet_link_qualification.LinkQualification.Delete",
 		Path:    "/gnoi.packet_link_qualification.LinkQualification/Delete",
 		Exec:    GnoiLinkqualificationDelete,
 	}
-	gnoipacket_link_qualificationLinkQualificationGet = &RPC{ //revive:disable-line the name of the rpc includes _
+	gnoipacket_link_qualificationLinkQualificationGet = &RPC{
 		Name:    "Get",
 		Service: "gnoi.packet_link_qualification.LinkQualification",
 		FQN:     "gnoi.packet_link_qualification.LinkQualification.Get",
 		Path:    "/gnoi.packet_link_qualification.LinkQualification/Get",
 		Exec:    GnoiLinkqualificationGet,
 	}
-	gnoipacket_link_qualificationLinkQualificationList = &RPC{ //revive:disable-line the name of the rpc includes _
+	gnoipacket_link_qualificationLinkQualificationList = &RPC{
 		Name:    "List",
 		Service: "gnoi.packet_link_qualification.LinkQualification",
 		FQN:     "gnoi.packet_link_qualification.LinkQualification.List",
 		Path:    "/gnoi.packet_link_qualification.LinkQualification/List",
 		Exec:    GnoiLinkqualificationList,
 	}
+	gnoipcapPacketCaptureALL = &RPC{
+		Name:    "*",
+		Service: "gnoi.pcap.PacketCapture",
+		FQN:     "gnoi.pcap.PacketCapture.*",
+		Path:    "/gnoi.pcap.PacketCapture/*",
+		Exec:    GnoiPacketcaptureAllRPC,
+	}
+	gnoipcapPacketCapturePcap = &RPC{
+		Name:    "Pcap",
+		Service: "gnoi.pcap.PacketCapture",
+		FQN:     "gnoi.pcap.PacketCapture.Pcap",
+		Path:    "/gnoi.pcap.PacketCapture/Pcap",
+		Exec:    GnoiPacketcapturePcap,
+	}
 	gnoisystemALL = &RPC{
 		Name:    "*",
 		Service: "gnoi.system.System",
@@ -488,6 +772,13 @@ var (
 		Path:    "/gnoi.system.System/KillProcess",
 		Exec:    GnoiSystemKillProcess,
 	}
+	gnoisystemPing = &RPC{
+		Name:    "Ping",
+		Service: "gnoi.system.System",
+		FQN:     "gnoi.system.System.Ping",
+		Path:    "/gnoi.system.System/Ping",
+		Exec:    GnoiSystemPing,
+	}
 	gnoisystemReboot = &RPC{
 		Name:    "Reboot",
 		Service: "gnoi.system.System",
@@ -530,19 +821,19 @@ var (
 		Path:    "/gnoi.system.System/Traceroute",
 		Exec:    GnoiSystemTraceroute,
 	}
-	gnoisystemPing = &RPC{
-		Name:    "Ping",
-		Service: "gnoi.system.System",
-		FQN:     "gnoi.system.System.Ping",
-		Path:    "/gnoi.system.System/Ping",
-		Exec:    GnoiSystemPing,
+	gnpsiALL = &RPC{
+		Name:    "*",
+		Service: "gnpsi.gNPSI",
+		FQN:     "gnpsi.gNPSI.*",
+		Path:    "/gnpsi.gNPSI/*",
+		Exec:    GnpsiAllRPC,
 	}
-	gnoiopticalWavelengthRouterAdjustPSD = &RPC{
-		Name:    "AdjustPSD",
-		Service: "gnoi.optical.WavelengthRouter",
-		FQN:     "gnoi.optical.WavelengthRouter.AdjustPSD",
-		Path:    "/gnoi.optical.WavelengthRouter/AdjustPSD",
-		Exec:    GnoiWavelengthrouterAdjustPSD,
+	gnpsiSubscribe = &RPC{
+		Name:    "Subscribe",
+		Service: "gnpsi.gNPSI",
+		FQN:     "gnpsi.gNPSI.Subscribe",
+		Path:    "/gnpsi.gNPSI/Subscribe",
+		Exec:    GnpsiSubscribe,
 	}
 	gnsiacctzv1AcctzALL = &RPC{
 		Name:    "*",
@@ -558,6 +849,20 @@ var (
 		Path:    "/gnsi.acctz.v1.Acctz/RecordSubscribe",
 		Exec:    GnsiAcctzRecordSubscribe,
 	}
+	gnsiacctzv1AcctzStreamALL = &RPC{
+		Name:    "*",
+		Service: "gnsi.acctz.v1.AcctzStream",
+		FQN:     "gnsi.acctz.v1.AcctzStream.*",
+		Path:    "/gnsi.acctz.v1.AcctzStream/*",
+		Exec:    GnsiAcctzstreamAllRPC,
+	}
+	gnsiacctzv1AcctzStreamRecordSubscribe = &RPC{
+		Name:    "RecordSubscribe",
+		Service: "gnsi.acctz.v1.AcctzStream",
+		FQN:     "gnsi.acctz.v1.AcctzStream.RecordSubscribe",
+		Path:    "/gnsi.acctz.v1.AcctzStream/RecordSubscribe",
+		Exec:    GnsiAcctzstreamRecordSubscribe,
+	}
 	gnsiauthzv1AuthzALL = &RPC{
 		Name:    "*",
 		Service: "gnsi.authz.v1.Authz",
@@ -586,13 +891,6 @@ var (
 		Path:    "/gnsi.authz.v1.Authz/Rotate",
 		Exec:    GnsiAuthzRotate,
 	}
-	gnsicertzv1CertzAddProfile = &RPC{
-		Name:    "AddProfile",
-		Service: "gnsi.certz.v1.Certz",
-		FQN:     "gnsi.certz.v1.Certz.AddProfile",
-		Path:    "/gnsi.certz.v1.Certz/AddProfile",
-		Exec:    GnsiCertzAddProfile,
-	}
 	gnsicertzv1CertzALL = &RPC{
 		Name:    "*",
 		Service: "gnsi.certz.v1.Certz",
@@ -600,6 +898,13 @@ var (
 		Path:    "/gnsi.certz.v1.Certz/*",
 		Exec:    GnsiCertzAllRPC,
 	}
+	gnsicertzv1CertzAddProfile = &RPC{
+		Name:    "AddProfile",
+		Service: "gnsi.certz.v1.Certz",
+		FQN:     "gnsi.certz.v1.Certz.AddProfile",
+		Path:    "/gnsi.certz.v1.Certz/AddProfile",
+		Exec:    GnsiCertzAddProfile,
+	}
 	gnsicertzv1CertzCanGenerateCSR = &RPC{
 		Name:    "CanGenerateCSR",
 		Service: "gnsi.certz.v1.Certz",
@@ -614,6 +919,13 @@ var (
 		Path:    "/gnsi.certz.v1.Certz/DeleteProfile",
 		Exec:    GnsiCertzDeleteProfile,
 	}
+	gnsicertzv1CertzGetIntegrityManifest = &RPC{
+		Name:    "GetIntegrityManifest",
+		Service: "gnsi.certz.v1.Certz",
+		FQN:     "gnsi.certz.v1.Certz.GetIntegrityManifest",
+		Path:    "/gnsi.certz.v1.Certz/GetIntegrityManifest",
+		Exec:    GnsiCertzGetIntegrityManifest,
+	}
 	gnsicertzv1CertzGetProfileList = &RPC{
 		Name:    "GetProfileList",
 		Service: "gnsi.certz.v1.Certz",
@@ -649,13 +961,6 @@ var (
 		Path:    "/gnsi.credentialz.v1.Credentialz/GetPublicKeys",
 		Exec:    GnsiCredentialzGetPublicKeys,
 	}
-	gnsicredentialzv1CredentialzRotateHostParameters = &RPC{
-		Name:    "RotateHostParameters",
-		Service: "gnsi.credentialz.v1.Credentialz",
-		FQN:     "gnsi.credentialz.v1.Credentialz.RotateHostParameters",
-		Path:    "/gnsi.credentialz.v1.Credentialz/RotateHostParameters",
-		Exec:    GnsiCredentialzRotateHostParameters,
-	}
 	gnsicredentialzv1CredentialzRotateAccountCredentials = &RPC{
 		Name:    "RotateAccountCredentials",
 		Service: "gnsi.credentialz.v1.Credentialz",
@@ -663,6 +968,13 @@ var (
 		Path:    "/gnsi.credentialz.v1.Credentialz/RotateAccountCredentials",
 		Exec:    GnsiCredentialzRotateAccountCredentials,
 	}
+	gnsicredentialzv1CredentialzRotateHostParameters = &RPC{
+		Name:    "RotateHostParameters",
+		Service: "gnsi.credentialz.v1.Credentialz",
+		FQN:     "gnsi.credentialz.v1.Credentialz.RotateHostParameters",
+		Path:    "/gnsi.credentialz.v1.Credentialz/RotateHostParameters",
+		Exec:    GnsiCredentialzRotateHostParameters,
+	}
 	gnsipathzv1PathzALL = &RPC{
 		Name:    "*",
 		Service: "gnsi.pathz.v1.Pathz",
@@ -719,6 +1031,69 @@ var (
 		Path:    "/gribi.gRIBI/Modify",
 		Exec:    GribiModify,
 	}
+	openconfigattestzTpmAttestzServiceALL = &RPC{
+		Name:    "*",
+		Service: "openconfig.attestz.TpmAttestzService",
+		FQN:     "openconfig.attestz.TpmAttestzService.*",
+		Path:    "/openconfig.attestz.TpmAttestzService/*",
+		Exec:    OpenconfigTpmattestzserviceAllRPC,
+	}
+	openconfigattestzTpmAttestzServiceAttest = &RPC{
+		Name:    "Attest",
+		Service: "openconfig.attestz.TpmAttestzService",
+		FQN:     "openconfig.attestz.TpmAttestzService.Attest",
+		Path:    "/openconfig.attestz.TpmAttestzService/Attest",
+		Exec:    OpenconfigTpmattestzserviceAttest,
+	}
+	openconfigattestzTpmEnrollzServiceALL = &RPC{
+		Name:    "*",
+		Service: "openconfig.attestz.TpmEnrollzService",
+		FQN:     "openconfig.attestz.TpmEnrollzService.*",
+		Path:    "/openconfig.attestz.TpmEnrollzService/*",
+		Exec:    OpenconfigTpmenrollzserviceAllRPC,
+	}
+	openconfigattestzTpmEnrollzServiceChallenge = &RPC{
+		Name:    "Challenge",
+		Service: "openconfig.attestz.TpmEnrollzService",
+		FQN:     "openconfig.attestz.TpmEnrollzService.Challenge",
+		Path:    "/openconfig.attestz.TpmEnrollzService/Challenge",
+		Exec:    OpenconfigTpmenrollzserviceChallenge,
+	}
+	openconfigattestzTpmEnrollzServiceGetControlCardVendorID = &RPC{
+		Name:    "GetControlCardVendorID",
+		Service: "openconfig.attestz.TpmEnrollzService",
+		FQN:     "openconfig.attestz.TpmEnrollzService.GetControlCardVendorID",
+		Path:    "/openconfig.attestz.TpmEnrollzService/GetControlCardVendorID",
+		Exec:    OpenconfigTpmenrollzserviceGetControlCardVendorID,
+	}
+	openconfigattestzTpmEnrollzServiceGetIakCert = &RPC{
+		Name:    "GetIakCert",
+		Service: "openconfig.attestz.TpmEnrollzService",
+		FQN:     "openconfig.attestz.TpmEnrollzService.GetIakCert",
+		Path:    "/openconfig.attestz.TpmEnrollzService/GetIakCert",
+		Exec:    OpenconfigTpmenrollzserviceGetIakCert,
+	}
+	openconfigattestzTpmEnrollzServiceGetIdevidCsr = &RPC{
+		Name:    "GetIdevidCsr",
+		Service: "openconfig.attestz.TpmEnrollzService",
+		FQN:     "openconfig.attestz.TpmEnrollzService.GetIdevidCsr",
+		Path:    "/openconfig.attestz.TpmEnrollzService/GetIdevidCsr",
+		Exec:    OpenconfigTpmenrollzserviceGetIdevidCsr,
+	}
+	openconfigattestzTpmEnrollzServiceRotateAIKCert = &RPC{
+		Name:    "RotateAIKCert",
+		Service: "openconfig.attestz.TpmEnrollzService",
+		FQN:     "openconfig.attestz.TpmEnrollzService.RotateAIKCert",
+		Path:    "/openconfig.attestz.TpmEnrollzService/RotateAIKCert",
+		Exec:    OpenconfigTpmenrollzserviceRotateAIKCert,
+	}
+	openconfigattestzTpmEnrollzServiceRotateOIakCert = &RPC{
+		Name:    "RotateOIakCert",
+		Service: "openconfig.attestz.TpmEnrollzService",
+		FQN:     "openconfig.attestz.TpmEnrollzService.RotateOIakCert",
+		Path:    "/openconfig.attestz.TpmEnrollzService/RotateOIakCert",
+		Exec:    OpenconfigTpmenrollzserviceRotateOIakCert,
+	}
 	p4v1P4RuntimeALL = &RPC{
 		Name:    "*",
 		Service: "p4.v1.P4Runtime",
@@ -773,197 +1148,291 @@ var (
 	RPCs = rpcs{
 		AllRPC:                                   ALL,
 		GnmiAllRPC:                               gnmiALL,
+		GnmiCapabilities:                         gnmiCapabilities,
 		GnmiGet:                                  gnmiGet,
 		GnmiSet:                                  gnmiSet,
 		GnmiSubscribe:                            gnmiSubscribe,
-		GnmiCapabilities:                         gnmiCapabilities,
 		GnoiBgpAllRPC:                            gnoibgpALL,
 		GnoiBgpClearBGPNeighbor:                  gnoibgpClearBGPNeighbor,
-		GnoiDiagAllRPC:                           gnoidiagALL,
-		GnoiDiagGetBERTResult:                    gnoidiagGetBERTResult,
-		GnoiDiagStopBERT:                         gnoidiagStopBERT,
-		GnoiDiagStartBERT:                        gnoidiagStartBERT,
-		GnoiFactoryresetAllRPC:                   gnoifactory_resetFactoryResetALL,
-		GnoiFactoryresetStart:                    gnoifactory_resetFactoryResetStart,
-		GnoiFileAllRPC:                           gnoifileALL,
-		GnoiFilePut:                              gnoifilePut,
-		GnoiFileRemove:                           gnoifileRemove,
-		GnoiFileStat:                             gnoifileStat,
-		GnoiFileTransferToRemote:                 gnoifileTransferToRemote,
-		GnoiFileGet:                              gnoifileGet,
-		GnoiHealthzAcknowledge:                   gnoihealthzAcknowledge,
-		GnoiHealthzAllRPC:                        gnoihealthzALL,
-		GnoiHealthzArtifact:                      gnoihealthzArtifact,
-		GnoiHealthzCheck:                         gnoihealthzCheck,
-		GnoiHealthzList:                          gnoihealthzList,
-		GnoiHealthzGet:                           gnoihealthzGet,
-		GnoiLayer2AllRPC:                         gnoilayer2ALL,
-		GnoiLayer2ClearLLDPInterface:             gnoilayer2ClearLLDPInterface,
-		GnoiLayer2ClearSpanningTree:              gnoilayer2ClearSpanningTree,
-		GnoiLayer2PerformBERT:                    gnoilayer2PerformBERT,
-		GnoiLayer2SendWakeOnLAN:                  gnoilayer2SendWakeOnLAN,
-		GnoiLayer2ClearNeighborDiscovery:         gnoilayer2ClearNeighborDiscovery,
-		GnoiLinkqualificationCreate:              gnoipacket_link_qualificationLinkQualificationCreate,
-		GnoiMplsAllRPC:                           gnoimplsALL,
-		GnoiMplsClearLSPCounters:                 gnoimplsClearLSPCounters,
-		GnoiMplsMPLSPing:                         gnoimplsMPLSPing,
-		GnoiMplsClearLSP:                         gnoimplsClearLSP,
-		GnoiOtdrAllRPC:                           gnoiopticalOTDRALL,
-		GnoiWavelengthrouterAdjustSpectrum:       gnoiopticalWavelengthRouterAdjustSpectrum,
-		GnoiWavelengthrouterAllRPC:               gnoiopticalWavelengthRouterALL,
-		GnoiWavelengthrouterCancelAdjustPSD:      gnoiopticalWavelengthRouterCancelAdjustPSD,
-		GnoiWavelengthrouterCancelAdjustSpectrum: gnoiopticalWavelengthRouterCancelAdjustSpectrum,
-		GnoiOsActivate:                           gnoiosActivate,
-		GnoiOsAllRPC:                             gnoiosALL,
-		GnoiOsVerify:                             gnoiosVerify,
-		GnoiOsInstall:                            gnoiosInstall,
-		GnoiOtdrInitiate:                         gnoiopticalOTDRInitiate,
-		GnoiLinkqualificationAllRPC:              gnoipacket_link_qualificationLinkQualificationALL,
-		GnoiLinkqualificationCapabilities:        gnoipacket_link_qualificationLinkQualificationCapabilities,
-		GnoiLinkqualificationDelete:              gnoipacket_link_qualificationLinkQualificationDelete,
-		GnoiLinkqualificationGet:                 gnoipacket_link_qualificationLinkQualificationGet,
-		GnoiLinkqualificationList:                gnoipacket_link_qualificationLinkQualificationList,
-		GnoiSystemAllRPC:                         gnoisystemALL,
-		GnoiSystemCancelReboot:                   gnoisystemCancelReboot,
-		GnoiSystemKillProcess:                    gnoisystemKillProcess,
-		GnoiSystemReboot:                         gnoisystemReboot,
-		GnoiSystemRebootStatus:                   gnoisystemRebootStatus,
-		GnoiSystemSetPackage:                     gnoisystemSetPackage,
-		GnoiSystemSwitchControlProcessor:         gnoisystemSwitchControlProcessor,
-		GnoiSystemTime:                           gnoisystemTime,
-		GnoiSystemTraceroute:                     gnoisystemTraceroute,
-		GnoiSystemPing:                           gnoisystemPing,
-		GnoiWavelengthrouterAdjustPSD:            gnoiopticalWavelengthRouterAdjustPSD,
-		GnsiAcctzAllRPC:                          gnsiacctzv1AcctzALL,
-		GnsiAcctzRecordSubscribe:                 gnsiacctzv1AcctzRecordSubscribe,
-		GnsiAuthzAllRPC:                          gnsiauthzv1AuthzALL,
-		GnsiAuthzGet:                             gnsiauthzv1AuthzGet,
-		GnsiAuthzProbe:                           gnsiauthzv1AuthzProbe,
-		GnsiAuthzRotate:                          gnsiauthzv1AuthzRotate,
-		GnsiCertzAddProfile:                      gnsicertzv1CertzAddProfile,
-		GnsiCertzAllRPC:                          gnsicertzv1CertzALL,
-		GnsiCertzCanGenerateCSR:                  gnsicertzv1CertzCanGenerateCSR,
-		GnsiCertzDeleteProfile:                   gnsicertzv1CertzDeleteProfile,
-		GnsiCertzGetProfileList:                  gnsicertzv1CertzGetProfileList,
-		GnsiCertzRotate:                          gnsicertzv1CertzRotate,
-		GnsiCredentialzAllRPC:                    gnsicredentialzv1CredentialzALL,
-		GnsiCredentialzCanGenerateKey:            gnsicredentialzv1CredentialzCanGenerateKey,
-		GnsiCredentialzGetPublicKeys:             gnsicredentialzv1CredentialzGetPublicKeys,
-		GnsiCredentialzRotateHostParameters:      gnsicredentialzv1CredentialzRotateHostParameters,
-		GnsiCredentialzRotateAccountCredentials:  gnsicredentialzv1CredentialzRotateAccountCredentials,
-		GnsiPathzAllRPC:                          gnsipathzv1PathzALL,
-		GnsiPathzGet:                             gnsipathzv1PathzGet,
-		GnsiPathzProbe:                           gnsipathzv1PathzProbe,
-		GnsiPathzRotate:                          gnsipathzv1PathzRotate,
-		GribiAllRPC:                              gribiALL,
-		GribiFlush:                               gribiFlush,
-		GribiGet:                                 gribiGet,
-		GribiModify:                              gribiModify,
-		P4P4runtimeAllRPC:                        p4v1P4RuntimeALL,
-		P4P4runtimeCapabilities:                  p4v1P4RuntimeCapabilities,
-		P4P4runtimeGetForwardingPipelineConfig:   p4v1P4RuntimeGetForwardingPipelineConfig,
-		P4P4runtimeRead:                          p4v1P4RuntimeRead,
-		P4P4runtimeSetForwardingPipelineConfig:   p4v1P4RuntimeSetForwardingPipelineConfig,
-		P4P4runtimeStreamChannel:                 p4v1P4RuntimeStreamChannel,
-		P4P4runtimeWrite:                         p4v1P4RuntimeWrite,
+		GnoiBootconfigAllRPC:                     gnoibootconfigALL,
+		GnoiBootconfigGetBootConfig:              gnoibootconfigGetBootConfig,
+		GnoiBootconfigSetBootConfig:              gnoibootconfigSetBootConfig,
+		GnoiCertificatemanagementAllRPC:          gnoicertificateCertificateManagementALL,
+		GnoiCertificatemanagementCanGenerateCSR:  gnoicertificateCertificateManagementCanGenerateCSR,
+		GnoiCertificatemanagementGenerateCSR:     gnoicertificateCertificateManagementGenerateCSR,
+		GnoiCertificatemanagementGetCertificates: gnoicertificateCertificateManagementGetCertificates,
+		GnoiCertificatemanagementInstall:         gnoicertificateCertificateManagementInstall,
+		GnoiCertificatemanagementLoadCertificate: gnoicertificateCertificateManagementLoadCertificate,
+		GnoiCertificatemanagementLoadCertificateAuthorityBundle: gnoicertificateCertificateManagementLoadCertificateAuthorityBundle,
+		GnoiCertificatemanagementRevokeCertificates:             gnoicertificateCertificateManagementRevokeCertificates,
+		GnoiCertificatemanagementRotate:                         gnoicertificateCertificateManagementRotate,
+		GnoiContainerzAllRPC:                                    gnoicontainerzALL,
+		GnoiContainerzCreateVolume:                              gnoicontainerzCreateVolume,
+		GnoiContainerzDeploy:                                    gnoicontainerzDeploy,
+		GnoiContainerzListContainer:                             gnoicontainerzListContainer,
+		GnoiContainerzListImage:                                 gnoicontainerzListImage,
+		GnoiContainerzListPlugins:                               gnoicontainerzListPlugins,
+		GnoiContainerzListVolume:                                gnoicontainerzListVolume,
+		GnoiContainerzLog:                                       gnoicontainerzLog,
+		GnoiContainerzRemoveContainer:                           gnoicontainerzRemoveContainer,
+		GnoiContainerzRemoveImage:                               gnoicontainerzRemoveImage,
+		GnoiContainerzRemovePlugin:                              gnoicontainerzRemovePlugin,
+		GnoiContainerzRemoveVolume:                              gnoicontainerzRemoveVolume,
+		GnoiContainerzStartContainer:                            gnoicontainerzStartContainer,
+		GnoiContainerzStartPlugin:                               gnoicontainerzStartPlugin,
+		GnoiContainerzStopContainer:                             gnoicontainerzStopContainer,
+		GnoiContainerzStopPlugin:                                gnoicontainerzStopPlugin,
+		GnoiContainerzUpdateContainer:                           gnoicontainerzUpdateContainer,
+		GnoiDebugAllRPC:                                         gnoidebugALL,
+		GnoiDebugDebug:                                          gnoidebugDebug,
+		GnoiDiagAllRPC:                                          gnoidiagALL,
+		GnoiDiagGetBERTResult:                                   gnoidiagGetBERTResult,
+		GnoiDiagStartBERT:                                       gnoidiagStartBERT,
+		GnoiDiagStopBERT:                                        gnoidiagStopBERT,
+		GnoiFactoryresetAllRPC:                                  gnoifactory_resetFactoryResetALL,
+		GnoiFactoryresetStart:                                   gnoifactory_resetFactoryResetStart,
+		GnoiFileAllRPC:                                          gnoifileALL,
+		GnoiFileGet:                                             gnoifileGet,
+		GnoiFilePut:                                             gnoifilePut,
+		GnoiFileRemove:                                          gnoifileRemove,
+		GnoiFileStat:                                            gnoifileStat,
+		GnoiFileTransferToRemote:                                gnoifileTransferToRemote,
+		GnoiHealthzAllRPC:                                       gnoihealthzALL,
+		GnoiHealthzAcknowledge:                                  gnoihealthzAcknowledge,
+		GnoiHealthzArtifact:                                     gnoihealthzArtifact,
+		GnoiHealthzCheck:                                        gnoihealthzCheck,
+		GnoiHealthzGet:                                          gnoihealthzGet,
+		GnoiHealthzList:                                         gnoihealthzList,
+		GnoiLayer2AllRPC:                                        gnoilayer2ALL,
+		GnoiLayer2ClearLLDPInterface:                            gnoilayer2ClearLLDPInterface,
+		GnoiLayer2ClearNeighborDiscovery:                        gnoilayer2ClearNeighborDiscovery,
+		GnoiLayer2ClearSpanningTree:                             gnoilayer2ClearSpanningTree,
+		GnoiLayer2PerformBERT:                                   gnoilayer2PerformBERT,
+		GnoiLayer2SendWakeOnLAN:                                 gnoilayer2SendWakeOnLAN,
+		GnoiMplsAllRPC:                                          gnoimplsALL,
+		GnoiMplsClearLSP:                                        gnoimplsClearLSP,
+		GnoiMplsClearLSPCounters:                                gnoimplsClearLSPCounters,
+		GnoiMplsMPLSPing:                                        gnoimplsMPLSPing,
+		GnoiOtdrAllRPC:                                          gnoiopticalOTDRALL,
+		GnoiOtdrInitiate:                                        gnoiopticalOTDRInitiate,
+		GnoiWavelengthrouterAllRPC:                              gnoiopticalWavelengthRouterALL,
+		GnoiWavelengthrouterAdjustPSD:                           gnoiopticalWavelengthRouterAdjustPSD,
+		GnoiWavelengthrouterAdjustSpectrum:                      gnoiopticalWavelengthRouterAdjustSpectrum,
+		GnoiWavelengthrouterCancelAdjustPSD:                     gnoiopticalWavelengthRouterCancelAdjustPSD,
+		GnoiWavelengthrouterCancelAdjustSpectrum:                gnoiopticalWavelengthRouterCancelAdjustSpectrum,
+		GnoiOsAllRPC:                                            gnoiosALL,
+		GnoiOsActivate:                                          gnoiosActivate,
+		GnoiOsInstall:                                           gnoiosInstall,
+		GnoiOsVerify:                                            gnoiosVerify,
+		GnoiLinkqualificationAllRPC:                             gnoipacket_link_qualificationLinkQualificationALL,
+		GnoiLinkqualificationCapabilities:                       gnoipacket_link_qualificationLinkQualificationCapabilities,
+		GnoiLinkqualificationCreate:                             gnoipacket_link_qualificationLinkQualificationCreate,
+		GnoiLinkqualificationDelete:                             gnoipacket_link_qualificationLinkQualificationDelete,
+		GnoiLinkqualificationGet:                                gnoipacket_link_qualificationLinkQualificationGet,
+		GnoiLinkqualificationList:                               gnoipacket_link_qualificationLinkQualificationList,
+		GnoiPacketcaptureAllRPC:                                 gnoipcapPacketCaptureALL,
+		GnoiPacketcapturePcap:                                   gnoipcapPacketCapturePcap,
+		GnoiSystemAllRPC:                                        gnoisystemALL,
+		GnoiSystemCancelReboot:                                  gnoisystemCancelReboot,
+		GnoiSystemKillProcess:                                   gnoisystemKillProcess,
+		GnoiSystemPing:                                          gnoisystemPing,
+		GnoiSystemReboot:                                        gnoisystemReboot,
+		GnoiSystemRebootStatus:                                  gnoisystemRebootStatus,
+		GnoiSystemSetPackage:                                    gnoisystemSetPackage,
+		GnoiSystemSwitchControlProcessor:                        gnoisystemSwitchControlProcessor,
+		GnoiSystemTime:                                          gnoisystemTime,
+		GnoiSystemTraceroute:                                    gnoisystemTraceroute,
+		GnpsiAllRPC:                                             gnpsiALL,
+		GnpsiSubscribe:                                          gnpsiSubscribe,
+		GnsiAcctzAllRPC:                                         gnsiacctzv1AcctzALL,
+		GnsiAcctzRecordSubscribe:                                gnsiacctzv1AcctzRecordSubscribe,
+		GnsiAcctzstreamAllRPC:                                   gnsiacctzv1AcctzStreamALL,
+		GnsiAcctzstreamRecordSubscribe:                          gnsiacctzv1AcctzStreamRecordSubscribe,
+		GnsiAuthzAllRPC:                                         gnsiauthzv1AuthzALL,
+		GnsiAuthzGet:                                            gnsiauthzv1AuthzGet,
+		GnsiAuthzProbe:                                          gnsiauthzv1AuthzProbe,
+		GnsiAuthzRotate:                                         gnsiauthzv1AuthzRotate,
+		GnsiCertzAllRPC:                                         gnsicertzv1CertzALL,
+		GnsiCertzAddProfile:                                     gnsicertzv1CertzAddProfile,
+		GnsiCertzCanGenerateCSR:                                 gnsicertzv1CertzCanGenerateCSR,
+		GnsiCertzDeleteProfile:                                  gnsicertzv1CertzDeleteProfile,
+		GnsiCertzGetIntegrityManifest:                           gnsicertzv1CertzGetIntegrityManifest,
+		GnsiCertzGetProfileList:                                 gnsicertzv1CertzGetProfileList,
+		GnsiCertzRotate:                                         gnsicertzv1CertzRotate,
+		GnsiCredentialzAllRPC:                                   gnsicredentialzv1CredentialzALL,
+		GnsiCredentialzCanGenerateKey:                           gnsicredentialzv1CredentialzCanGenerateKey,
+		GnsiCredentialzGetPublicKeys:                            gnsicredentialzv1CredentialzGetPublicKeys,
+		GnsiCredentialzRotateAccountCredentials:                 gnsicredentialzv1CredentialzRotateAccountCredentials,
+		GnsiCredentialzRotateHostParameters:                     gnsicredentialzv1CredentialzRotateHostParameters,
+		GnsiPathzAllRPC:                                         gnsipathzv1PathzALL,
+		GnsiPathzGet:                                            gnsipathzv1PathzGet,
+		GnsiPathzProbe:                                          gnsipathzv1PathzProbe,
+		GnsiPathzRotate:                                         gnsipathzv1PathzRotate,
+		GribiAllRPC:                                             gribiALL,
+		GribiFlush:                                              gribiFlush,
+		GribiGet:                                                gribiGet,
+		GribiModify:                                             gribiModify,
+		OpenconfigTpmattestzserviceAllRPC:                       openconfigattestzTpmAttestzServiceALL,
+		OpenconfigTpmattestzserviceAttest:                       openconfigattestzTpmAttestzServiceAttest,
+		OpenconfigTpmenrollzserviceAllRPC:                       openconfigattestzTpmEnrollzServiceALL,
+		OpenconfigTpmenrollzserviceChallenge:                    openconfigattestzTpmEnrollzServiceChallenge,
+		OpenconfigTpmenrollzserviceGetControlCardVendorID:       openconfigattestzTpmEnrollzServiceGetControlCardVendorID,
+		OpenconfigTpmenrollzserviceGetIakCert:                   openconfigattestzTpmEnrollzServiceGetIakCert,
+		OpenconfigTpmenrollzserviceGetIdevidCsr:                 openconfigattestzTpmEnrollzServiceGetIdevidCsr,
+		OpenconfigTpmenrollzserviceRotateAIKCert:                openconfigattestzTpmEnrollzServiceRotateAIKCert,
+		OpenconfigTpmenrollzserviceRotateOIakCert:               openconfigattestzTpmEnrollzServiceRotateOIakCert,
+		P4P4runtimeAllRPC:                                       p4v1P4RuntimeALL,
+		P4P4runtimeCapabilities:                                 p4v1P4RuntimeCapabilities,
+		P4P4runtimeGetForwardingPipelineConfig:                  p4v1P4RuntimeGetForwardingPipelineConfig,
+		P4P4runtimeRead:                                         p4v1P4RuntimeRead,
+		P4P4runtimeSetForwardingPipelineConfig:                  p4v1P4RuntimeSetForwardingPipelineConfig,
+		P4P4runtimeStreamChannel:                                p4v1P4RuntimeStreamChannel,
+		P4P4runtimeWrite:                                        p4v1P4RuntimeWrite,
 	}
 
 	// RPCMAP is a helper that  maps path to RPCs data that may be needed in tests.
 	RPCMAP = map[string]*RPC{
-		"*":                                                              ALL,
-		"/gnmi.gNMI/*":                                                   gnmiALL,
-		"/gnmi.gNMI/Get":                                                 gnmiGet,
-		"/gnmi.gNMI/Set":                                                 gnmiSet,
-		"/gnmi.gNMI/Subscribe":                                           gnmiSubscribe,
-		"/gnmi.gNMI/Capabilities":                                        gnmiCapabilities,
-		"/gnoi.bgp.BGP/*":                                                gnoibgpALL,
-		"/gnoi.bgp.BGP/ClearBGPNeighbor":                                 gnoibgpClearBGPNeighbor,
-		"/gnoi.diag.Diag/*":                                              gnoidiagALL,
-		"/gnoi.diag.Diag/GetBERTResult":                                  gnoidiagGetBERTResult,
-		"/gnoi.diag.Diag/StopBERT":                                       gnoidiagStopBERT,
-		"/gnoi.diag.Diag/StartBERT":                                      gnoidiagStartBERT,
-		"/gnoi.factory_reset.FactoryReset/*":                             gnoifactory_resetFactoryResetALL,
-		"/gnoi.factory_reset.FactoryReset/Start":                         gnoifactory_resetFactoryResetStart,
-		"/gnoi.file.File/*":                                              gnoifileALL,
-		"/gnoi.file.File/Put":                                            gnoifilePut,
-		"/gnoi.file.File/Remove":                                         gnoifileRemove,
-		"/gnoi.file.File/Stat":                                           gnoifileStat,
-		"/gnoi.file.File/TransferToRemote":                               gnoifileTransferToRemote,
-		"/gnoi.file.File/Get":                                            gnoifileGet,
-		"/gnoi.healthz.Healthz/Acknowledge":                              gnoihealthzAcknowledge,
-		"/gnoi.healthz.Healthz/*":                                        gnoihealthzALL,
-		"/gnoi.healthz.Healthz/Artifact":                                 gnoihealthzArtifact,
-		"/gnoi.healthz.Healthz/Check":                                    gnoihealthzCheck,
-		"/gnoi.healthz.Healthz/List":                                     gnoihealthzList,
-		"/gnoi.healthz.Healthz/Get":                                      gnoihealthzGet,
-		"/gnoi.layer2.Layer2/*":                                          gnoilayer2ALL,
-		"/gnoi.layer2.Layer2/ClearLLDPInterface":                         gnoilayer2ClearLLDPInterface,
-		"/gnoi.layer2.Layer2/ClearSpanningTree":                          gnoilayer2ClearSpanningTree,
-		"/gnoi.layer2.Layer2/PerformBERT":                                gnoilayer2PerformBERT,
-		"/gnoi.layer2.Layer2/SendWakeOnLAN":                              gnoilayer2SendWakeOnLAN,
-		"/gnoi.layer2.Layer2/ClearNeighborDiscovery":                     gnoilayer2ClearNeighborDiscovery,
-		"/gnoi.packet_link_qualification.LinkQualification/Create":       gnoipacket_link_qualificationLinkQualificationCreate,
-		"/gnoi.mpls.MPLS/*":                                              gnoimplsALL,
-		"/gnoi.mpls.MPLS/ClearLSPCounters":                               gnoimplsClearLSPCounters,
-		"/gnoi.mpls.MPLS/MPLSPing":                                       gnoimplsMPLSPing,
-		"/gnoi.mpls.MPLS/ClearLSP":                                       gnoimplsClearLSP,
-		"/gnoi.optical.OTDR/*":                                           gnoiopticalOTDRALL,
-		"/gnoi.optical.WavelengthRouter/AdjustSpectrum":                  gnoiopticalWavelengthRouterAdjustSpectrum,
-		"/gnoi.optical.WavelengthRouter/*":                               gnoiopticalWavelengthRouterALL,
-		"/gnoi.optical.WavelengthRouter/CancelAdjustPSD":                 gnoiopticalWavelengthRouterCancelAdjustPSD,
-		"/gnoi.optical.WavelengthRouter/CancelAdjustSpectrum":            gnoiopticalWavelengthRouterCancelAdjustSpectrum,
-		"/gnoi.os.OS/Activate":                                           gnoiosActivate,
-		"/gnoi.os.OS/*":                                                  gnoiosALL,
-		"/gnoi.os.OS/Verify":                                             gnoiosVerify,
-		"/gnoi.os.OS/Install":                                            gnoiosInstall,
-		"/gnoi.optical.OTDR/Initiate":                                    gnoiopticalOTDRInitiate,
-		"/gnoi.packet_link_qualification.LinkQualification/*":            gnoipacket_link_qualificationLinkQualificationALL,
-		"/gnoi.packet_link_qualification.LinkQualification/Capabilities": gnoipacket_link_qualificationLinkQualificationCapabilities,
-		"/gnoi.packet_link_qualification.LinkQualification/Delete":       gnoipacket_link_qualificationLinkQualificationDelete,
-		"/gnoi.packet_link_qualification.LinkQualification/Get":          gnoipacket_link_qualificationLinkQualificationGet,
-		"/gnoi.packet_link_qualification.LinkQualification/List":         gnoipacket_link_qualificationLinkQualificationList,
-		"/gnoi.system.System/*":                                          gnoisystemALL,
-		"/gnoi.system.System/CancelReboot":                               gnoisystemCancelReboot,
-		"/gnoi.system.System/KillProcess":                                gnoisystemKillProcess,
-		"/gnoi.system.System/Reboot":                                     gnoisystemReboot,
-		"/gnoi.system.System/RebootStatus":                               gnoisystemRebootStatus,
-		"/gnoi.system.System/SetPackage":                                 gnoisystemSetPackage,
-		"/gnoi.system.System/SwitchControlProcessor":                     gnoisystemSwitchControlProcessor,
-		"/gnoi.system.System/Time":                                       gnoisystemTime,
-		"/gnoi.system.System/Traceroute":                                 gnoisystemTraceroute,
-		"/gnoi.system.System/Ping":                                       gnoisystemPing,
-		"/gnoi.optical.WavelengthRouter/AdjustPSD":                       gnoiopticalWavelengthRouterAdjustPSD,
-		"/gnsi.acctz.v1.Acctz/*":                                         gnsiacctzv1AcctzALL,
-		"/gnsi.acctz.v1.Acctz/RecordSubscribe":                           gnsiacctzv1AcctzRecordSubscribe,
-		"/gnsi.authz.v1.Authz/*":                                         gnsiauthzv1AuthzALL,
-		"/gnsi.authz.v1.Authz/Get":                                       gnsiauthzv1AuthzGet,
-		"/gnsi.authz.v1.Authz/Probe":                                     gnsiauthzv1AuthzProbe,
-		"/gnsi.authz.v1.Authz/Rotate":                                    gnsiauthzv1AuthzRotate,
-		"/gnsi.certz.v1.Certz/AddProfile":                                gnsicertzv1CertzAddProfile,
-		"/gnsi.certz.v1.Certz/*":                                         gnsicertzv1CertzALL,
-		"/gnsi.certz.v1.Certz/CanGenerateCSR":                            gnsicertzv1CertzCanGenerateCSR,
-		"/gnsi.certz.v1.Certz/DeleteProfile":                             gnsicertzv1CertzDeleteProfile,
-		"/gnsi.certz.v1.Certz/GetProfileList":                            gnsicertzv1CertzGetProfileList,
-		"/gnsi.certz.v1.Certz/Rotate":                                    gnsicertzv1CertzRotate,
-		"/gnsi.credentialz.v1.Credentialz/*":                             gnsicredentialzv1CredentialzALL,
-		"/gnsi.credentialz.v1.Credentialz/CanGenerateKey":                gnsicredentialzv1CredentialzCanGenerateKey,
-		"/gnsi.credentialz.v1.Credentialz/GetPublicKeys":                 gnsicredentialzv1CredentialzGetPublicKeys,
-		"/gnsi.credentialz.v1.Credentialz/RotateHostParameters":          gnsicredentialzv1CredentialzRotateHostParameters,
-		"/gnsi.credentialz.v1.Credentialz/RotateAccountCredentials":      gnsicredentialzv1CredentialzRotateAccountCredentials,
-		"/gnsi.pathz.v1.Pathz/*":                                         gnsipathzv1PathzALL,
-		"/gnsi.pathz.v1.Pathz/Get":                                       gnsipathzv1PathzGet,
-		"/gnsi.pathz.v1.Pathz/Probe":                                     gnsipathzv1PathzProbe,
-		"/gnsi.pathz.v1.Pathz/Rotate":                                    gnsipathzv1PathzRotate,
-		"/gribi.gRIBI/*":                                                 gribiALL,
-		"/gribi.gRIBI/Flush":                                             gribiFlush,
-		"/gribi.gRIBI/Get":                                               gribiGet,
-		"/gribi.gRIBI/Modify":                                            gribiModify,
-		"/p4.v1.P4Runtime/*":                                             p4v1P4RuntimeALL,
-		"/p4.v1.P4Runtime/Capabilities":                                  p4v1P4RuntimeCapabilities,
-		"/p4.v1.P4Runtime/GetForwardingPipelineConfig":                   p4v1P4RuntimeGetForwardingPipelineConfig,
-		"/p4.v1.P4Runtime/Read":                                          p4v1P4RuntimeRead,
-		"/p4.v1.P4Runtime/SetForwardingPipelineConfig":                   p4v1P4RuntimeSetForwardingPipelineConfig,
-		"/p4.v1.P4Runtime/StreamChannel":                                 p4v1P4RuntimeStreamChannel,
-		"/p4.v1.P4Runtime/Write":                                         p4v1P4RuntimeWrite,
+		"*":                              ALL,
+		"/gnmi.gNMI/*":                   gnmiALL,
+		"/gnmi.gNMI/Capabilities":        gnmiCapabilities,
+		"/gnmi.gNMI/Get":                 gnmiGet,
+		"/gnmi.gNMI/Set":                 gnmiSet,
+		"/gnmi.gNMI/Subscribe":           gnmiSubscribe,
+		"/gnoi.bgp.BGP/*":                gnoibgpALL,
+		"/gnoi.bgp.BGP/ClearBGPNeighbor": gnoibgpClearBGPNeighbor,
+		"/gnoi.bootconfig.BootConfig/*":  gnoibootconfigALL,
+		"/gnoi.bootconfig.BootConfig/GetBootConfig":                              gnoibootconfigGetBootConfig,
+		"/gnoi.bootconfig.BootConfig/SetBootConfig":                              gnoibootconfigSetBootConfig,
+		"/gnoi.certificate.CertificateManagement/*":                              gnoicertificateCertificateManagementALL,
+		"/gnoi.certificate.CertificateManagement/CanGenerateCSR":                 gnoicertificateCertificateManagementCanGenerateCSR,
+		"/gnoi.certificate.CertificateManagement/GenerateCSR":                    gnoicertificateCertificateManagementGenerateCSR,
+		"/gnoi.certificate.CertificateManagement/GetCertificates":                gnoicertificateCertificateManagementGetCertificates,
+		"/gnoi.certificate.CertificateManagement/Install":                        gnoicertificateCertificateManagementInstall,
+		"/gnoi.certificate.CertificateManagement/LoadCertificate":                gnoicertificateCertificateManagementLoadCertificate,
+		"/gnoi.certificate.CertificateManagement/LoadCertificateAuthorityBundle": gnoicertificateCertificateManagementLoadCertificateAuthorityBundle,
+		"/gnoi.certificate.CertificateManagement/RevokeCertificates":             gnoicertificateCertificateManagementRevokeCertificates,
+		"/gnoi.certificate.CertificateManagement/Rotate":                         gnoicertificateCertificateManagementRotate,
+		"/gnoi.containerz.Containerz/*":                                          gnoicontainerzALL,
+		"/gnoi.containerz.Containerz/CreateVolume":                               gnoicontainerzCreateVolume,
+		"/gnoi.containerz.Containerz/Deploy":                                     gnoicontainerzDeploy,
+		"/gnoi.containerz.Containerz/ListContainer":                              gnoicontainerzListContainer,
+		"/gnoi.containerz.Containerz/ListImage":                                  gnoicontainerzListImage,
+		"/gnoi.containerz.Containerz/ListPlugins":                                gnoicontainerzListPlugins,
+		"/gnoi.containerz.Containerz/ListVolume":                                 gnoicontainerzListVolume,
+		"/gnoi.containerz.Containerz/Log":                                        gnoicontainerzLog,
+		"/gnoi.containerz.Containerz/RemoveContainer":                            gnoicontainerzRemoveContainer,
+		"/gnoi.containerz.Containerz/RemoveImage":                                gnoicontainerzRemoveImage,
+		"/gnoi.containerz.Containerz/RemovePlugin":                               gnoicontainerzRemovePlugin,
+		"/gnoi.containerz.Containerz/RemoveVolume":                               gnoicontainerzRemoveVolume,
+		"/gnoi.containerz.Containerz/StartContainer":                             gnoicontainerzStartContainer,
+		"/gnoi.containerz.Containerz/StartPlugin":                                gnoicontainerzStartPlugin,
+		"/gnoi.containerz.Containerz/StopContainer":                              gnoicontainerzStopContainer,
+		"/gnoi.containerz.Containerz/StopPlugin":                                 gnoicontainerzStopPlugin,
+		"/gnoi.containerz.Containerz/UpdateContainer":                            gnoicontainerzUpdateContainer,
+		"/gnoi.debug.Debug/*":                                                    gnoidebugALL,
+		"/gnoi.debug.Debug/Debug":                                                gnoidebugDebug,
+		"/gnoi.diag.Diag/*":                                                      gnoidiagALL,
+		"/gnoi.diag.Diag/GetBERTResult":                                          gnoidiagGetBERTResult,
+		"/gnoi.diag.Diag/StartBERT":                                              gnoidiagStartBERT,
+		"/gnoi.diag.Diag/StopBERT":                                               gnoidiagStopBERT,
+		"/gnoi.factory_reset.FactoryReset/*":                                     gnoifactory_resetFactoryResetALL,
+		"/gnoi.factory_reset.FactoryReset/Start":                                 gnoifactory_resetFactoryResetStart,
+		"/gnoi.file.File/*":                                                      gnoifileALL,
+		"/gnoi.file.File/Get":                                                    gnoifileGet,
+		"/gnoi.file.File/Put":                                                    gnoifilePut,
+		"/gnoi.file.File/Remove":                                                 gnoifileRemove,
+		"/gnoi.file.File/Stat":                                                   gnoifileStat,
+		"/gnoi.file.File/TransferToRemote":                                       gnoifileTransferToRemote,
+		"/gnoi.healthz.Healthz/*":                                                gnoihealthzALL,
+		"/gnoi.healthz.Healthz/Acknowledge":                                      gnoihealthzAcknowledge,
+		"/gnoi.healthz.Healthz/Artifact":                                         gnoihealthzArtifact,
+		"/gnoi.healthz.Healthz/Check":                                            gnoihealthzCheck,
+		"/gnoi.healthz.Healthz/Get":                                              gnoihealthzGet,
+		"/gnoi.healthz.Healthz/List":                                             gnoihealthzList,
+		"/gnoi.layer2.Layer2/*":                                                  gnoilayer2ALL,
+		"/gnoi.layer2.Layer2/ClearLLDPInterface":                                 gnoilayer2ClearLLDPInterface,
+		"/gnoi.layer2.Layer2/ClearNeighborDiscovery":                             gnoilayer2ClearNeighborDiscovery,
+		"/gnoi.layer2.Layer2/ClearSpanningTree":                                  gnoilayer2ClearSpanningTree,
+		"/gnoi.layer2.Layer2/PerformBERT":                                        gnoilayer2PerformBERT,
+		"/gnoi.layer2.Layer2/SendWakeOnLAN":                                      gnoilayer2SendWakeOnLAN,
+		"/gnoi.mpls.MPLS/*":                                                      gnoimplsALL,
+		"/gnoi.mpls.MPLS/ClearLSP":                                               gnoimplsClearLSP,
+		"/gnoi.mpls.MPLS/ClearLSPCounters":                                       gnoimplsClearLSPCounters,
+		"/gnoi.mpls.MPLS/MPLSPing":                                               gnoimplsMPLSPing,
+		"/gnoi.optical.OTDR/*":                                                   gnoiopticalOTDRALL,
+		"/gnoi.optical.OTDR/Initiate":                                            gnoiopticalOTDRInitiate,
+		"/gnoi.optical.WavelengthRouter/*":                                       gnoiopticalWavelengthRouterALL,
+		"/gnoi.optical.WavelengthRouter/AdjustPSD":                               gnoiopticalWavelengthRouterAdjustPSD,
+		"/gnoi.optical.WavelengthRouter/AdjustSpectrum":                          gnoiopticalWavelengthRouterAdjustSpectrum,
+		"/gnoi.optical.WavelengthRouter/CancelAdjustPSD":                         gnoiopticalWavelengthRouterCancelAdjustPSD,
+		"/gnoi.optical.WavelengthRouter/CancelAdjustSpectrum":                    gnoiopticalWavelengthRouterCancelAdjustSpectrum,
+		"/gnoi.os.OS/*":                                                          gnoiosALL,
+		"/gnoi.os.OS/Activate":                                                   gnoiosActivate,
+		"/gnoi.os.OS/Install":                                                    gnoiosInstall,
+		"/gnoi.os.OS/Verify":                                                     gnoiosVerify,
+		"/gnoi.packet_link_qualification.LinkQualification/*":                    gnoipacket_link_qualificationLinkQualificationALL,
+		"/gnoi.packet_link_qualification.LinkQualification/Capabilities":         gnoipacket_link_qualificationLinkQualificationCapabilities,
+		"/gnoi.packet_link_qualification.LinkQualification/Create":               gnoipacket_link_qualificationLinkQualificationCreate,
+		"/gnoi.packet_link_qualification.LinkQualification/Delete":               gnoipacket_link_qualificationLinkQualificationDelete,
+		"/gnoi.packet_link_qualification.LinkQualification/Get":                  gnoipacket_link_qualificationLinkQualificationGet,
+		"/gnoi.packet_link_qualification.LinkQualification/List":                 gnoipacket_link_qualificationLinkQualificationList,
+		"/gnoi.pcap.PacketCapture/*":                                             gnoipcapPacketCaptureALL,
+		"/gnoi.pcap.PacketCapture/Pcap":                                          gnoipcapPacketCapturePcap,
+		"/gnoi.system.System/*":                                                  gnoisystemALL,
+		"/gnoi.system.System/CancelReboot":                                       gnoisystemCancelReboot,
+		"/gnoi.system.System/KillProcess":                                        gnoisystemKillProcess,
+		"/gnoi.system.System/Ping":                                               gnoisystemPing,
+		"/gnoi.system.System/Reboot":                                             gnoisystemReboot,
+		"/gnoi.system.System/RebootStatus":                                       gnoisystemRebootStatus,
+		"/gnoi.system.System/SetPackage":                                         gnoisystemSetPackage,
+		"/gnoi.system.System/SwitchControlProcessor":                             gnoisystemSwitchControlProcessor,
+		"/gnoi.system.System/Time":                                               gnoisystemTime,
+		"/gnoi.system.System/Traceroute":                                         gnoisystemTraceroute,
+		"/gnpsi.gNPSI/*":                                                         gnpsiALL,
+		"/gnpsi.gNPSI/Subscribe":                                                 gnpsiSubscribe,
+		"/gnsi.acctz.v1.Acctz/*":                                                 gnsiacctzv1AcctzALL,
+		"/gnsi.acctz.v1.Acctz/RecordSubscribe":                                   gnsiacctzv1AcctzRecordSubscribe,
+		"/gnsi.acctz.v1.AcctzStream/*":                                           gnsiacctzv1AcctzStreamALL,
+		"/gnsi.acctz.v1.AcctzStream/RecordSubscribe":                             gnsiacctzv1AcctzStreamRecordSubscribe,
+		"/gnsi.authz.v1.Authz/*":                                                 gnsiauthzv1AuthzALL,
+		"/gnsi.authz.v1.Authz/Get":                                               gnsiauthzv1AuthzGet,
+		"/gnsi.authz.v1.Authz/Probe":                                             gnsiauthzv1AuthzProbe,
+		"/gnsi.authz.v1.Authz/Rotate":                                            gnsiauthzv1AuthzRotate,
+		"/gnsi.certz.v1.Certz/*":                                                 gnsicertzv1CertzALL,
+		"/gnsi.certz.v1.Certz/AddProfile":                                        gnsicertzv1CertzAddProfile,
+		"/gnsi.certz.v1.Certz/CanGenerateCSR":                                    gnsicertzv1CertzCanGenerateCSR,
+		"/gnsi.certz.v1.Certz/DeleteProfile":                                     gnsicertzv1CertzDeleteProfile,
+		"/gnsi.certz.v1.Certz/GetIntegrityManifest":                              gnsicertzv1CertzGetIntegrityManifest,
+		"/gnsi.certz.v1.Certz/GetProfileList":                                    gnsicertzv1CertzGetProfileList,
+		"/gnsi.certz.v1.Certz/Rotate":                                            gnsicertzv1CertzRotate,
+		"/gnsi.credentialz.v1.Credentialz/*":                                     gnsicredentialzv1CredentialzALL,
+		"/gnsi.credentialz.v1.Credentialz/CanGenerateKey":                        gnsicredentialzv1CredentialzCanGenerateKey,
+		"/gnsi.credentialz.v1.Credentialz/GetPublicKeys":                         gnsicredentialzv1CredentialzGetPublicKeys,
+		"/gnsi.credentialz.v1.Credentialz/RotateAccountCredentials":              gnsicredentialzv1CredentialzRotateAccountCredentials,
+		"/gnsi.credentialz.v1.Credentialz/RotateHostParameters":                  gnsicredentialzv1CredentialzRotateHostParameters,
+		"/gnsi.pathz.v1.Pathz/*":                                                 gnsipathzv1PathzALL,
+		"/gnsi.pathz.v1.Pathz/Get":                                               gnsipathzv1PathzGet,
+		"/gnsi.pathz.v1.Pathz/Probe":                                             gnsipathzv1PathzProbe,
+		"/gnsi.pathz.v1.Pathz/Rotate":                                            gnsipathzv1PathzRotate,
+		"/gribi.gRIBI/*":                                                         gribiALL,
+		"/gribi.gRIBI/Flush":                                                     gribiFlush,
+		"/gribi.gRIBI/Get":                                                       gribiGet,
+		"/gribi.gRIBI/Modify":                                                    gribiModify,
+		"/openconfig.attestz.TpmAttestzService/*":                                openconfigattestzTpmAttestzServiceALL,
+		"/openconfig.attestz.TpmAttestzService/Attest":                           openconfigattestzTpmAttestzServiceAttest,
+		"/openconfig.attestz.TpmEnrollzService/*":                                openconfigattestzTpmEnrollzServiceALL,
+		"/openconfig.attestz.TpmEnrollzService/Challenge":                        openconfigattestzTpmEnrollzServiceChallenge,
+		"/openconfig.attestz.TpmEnrollzService/GetControlCardVendorID":           openconfigattestzTpmEnrollzServiceGetControlCardVendorID,
+		"/openconfig.attestz.TpmEnrollzService/GetIakCert":                       openconfigattestzTpmEnrollzServiceGetIakCert,
+		"/openconfig.attestz.TpmEnrollzService/GetIdevidCsr":                     openconfigattestzTpmEnrollzServiceGetIdevidCsr,
+		"/openconfig.attestz.TpmEnrollzService/RotateAIKCert":                    openconfigattestzTpmEnrollzServiceRotateAIKCert,
+		"/openconfig.attestz.TpmEnrollzService/RotateOIakCert":                   openconfigattestzTpmEnrollzServiceRotateOIakCert,
+		"/p4.v1.P4Runtime/*":                                                     p4v1P4RuntimeALL,
+		"/p4.v1.P4Runtime/Capabilities":                                          p4v1P4RuntimeCapabilities,
+		"/p4.v1.P4Runtime/GetForwardingPipelineConfig":                           p4v1P4RuntimeGetForwardingPipelineConfig,
+		"/p4.v1.P4Runtime/Read":                                                  p4v1P4RuntimeRead,
+		"/p4.v1.P4Runtime/SetForwardingPipelineConfig":                           p4v1P4RuntimeSetForwardingPipelineConfig,
+		"/p4.v1.P4Runtime/StreamChannel":                                         p4v1P4RuntimeStreamChannel,
+		"/p4.v1.P4Runtime/Write":                                                 p4v1P4RuntimeWrite,
 	}
 )
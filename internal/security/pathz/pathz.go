@@ -0,0 +1,288 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package pathz provides helper APIs to simplify writing pathz test cases:
+// a policy builder, an offline evaluator of the best-match semantics, Rotate
+// and Probe helpers, and a verifier that performs gNMI requests as a user.
+//
+//	p := pathz.NewPolicy()
+//	p.AddGroup("admins", adminID)
+//	if err := p.Permit("reader-read-system", pathz.User(readerID), pathz.Read, "/system"); err != nil {
+//		t.Fatal(err)
+//	}
+//	if err := p.Deny("admins-no-hostname", pathz.Group("admins"), pathz.Write, "/system/config/hostname"); err != nil {
+//		t.Fatal(err)
+//	}
+//	pathz.Rotate(t, dut, p, "v1", uint64(time.Now().UnixMicro()), false)
+//	pathz.Verify(t, dut, reader, []pathz.Check{{Path: "/system/config/hostname", Mode: pathz.Write, Want: pathz.Deny}})
+package pathz
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/openconfig/ygot/ygot"
+	"google.golang.org/protobuf/proto"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+	pathzpb "github.com/openconfig/gnsi/pathz"
+)
+
+// Access modes and actions of rules.
+const (
+	Read   = pathzpb.Mode_MODE_READ
+	Write  = pathzpb.Mode_MODE_WRITE
+	Permit = pathzpb.Action_ACTION_PERMIT
+	Deny   = pathzpb.Action_ACTION_DENY
+)
+
+// Principal is the user or group a rule applies to.
+type Principal struct {
+	User  string
+	Group string
+}
+
+// User returns the principal of user name, typically a SPIFFE ID.
+func User(name string) Principal { return Principal{User: name} }
+
+// Group returns the principal of the members of group name.
+func Group(name string) Principal { return Principal{Group: name} }
+
+func (p Principal) String() string {
+	if p.Group != "" {
+		return "group " + p.Group
+	}
+	return "user " + p.User
+}
+
+// Policy is a pathz authorization policy under construction.
+type Policy struct {
+	pb *pathzpb.AuthorizationPolicy
+}
+
+// NewPolicy returns an empty policy, which denies everything.
+func NewPolicy() *Policy {
+	return &Policy{pb: &pathzpb.AuthorizationPolicy{}}
+}
+
+// FromProto returns a policy holding a copy of pb, e.g. one read with Get.
+func FromProto(pb *pathzpb.AuthorizationPolicy) *Policy {
+	if pb == nil {
+		return NewPolicy()
+	}
+	return &Policy{pb: proto.Clone(pb).(*pathzpb.AuthorizationPolicy)}
+}
+
+// Proto returns the policy as sent in a Rotate request.
+func (p *Policy) Proto() *pathzpb.AuthorizationPolicy {
+	return p.pb
+}
+
+// AddGroup adds group name with users, or adds users to it if it exists.
+func (p *Policy) AddGroup(name string, users ...string) {
+	var g *pathzpb.Group
+	for _, pg := range p.pb.GetGroups() {
+		if pg.GetName() == name {
+			g = pg
+		}
+	}
+	if g == nil {
+		g = &pathzpb.Group{Name: name}
+		p.pb.Groups = append(p.pb.Groups, g)
+	}
+	for _, u := range users {
+		g.Users = append(g.Users, &pathzpb.User{Name: u})
+	}
+}
+
+// AddRule adds rule id taking action for principal accessing path in mode.
+// Path is a gNMI path string, which may have "*" as key values, such as
+// "/interfaces/interface[name=*]/config".
+func (p *Policy) AddRule(id string, principal Principal, mode pathzpb.Mode, action pathzpb.Action, path string) error {
+	gp, err := ygot.StringToStructuredPath(path)
+	if err != nil {
+		return fmt.Errorf("rule %s: invalid path %q: %w", id, path, err)
+	}
+	r := &pathzpb.AuthorizationRule{Id: id, Path: gp, Mode: mode, Action: action}
+	if principal.Group != "" {
+		r.Principal = &pathzpb.AuthorizationRule_Group{Group: principal.Group}
+	} else {
+		r.Principal = &pathzpb.AuthorizationRule_User{User: principal.User}
+	}
+	p.pb.Rules = append(p.pb.Rules, r)
+	return nil
+}
+
+// Permit adds rule id permitting principal to access path in mode.
+func (p *Policy) Permit(id string, principal Principal, mode pathzpb.Mode, path string) error {
+	return p.AddRule(id, principal, mode, Permit, path)
+}
+
+// Deny adds rule id denying principal access to path in mode.
+func (p *Policy) Deny(id string, principal Principal, mode pathzpb.Mode, path string) error {
+	return p.AddRule(id, principal, mode, Deny, path)
+}
+
+// Validate checks that p is a policy a pathz server accepts: its rules have
+// unique ids, a principal, a mode, an action and a path with wildcards only
+// as key values, and the groups they refer to exist.
+func (p *Policy) Validate() error {
+	groups := map[string]bool{}
+	for _, g := range p.pb.GetGroups() {
+		if g.GetName() == "" {
+			return errors.New("policy has a group without name")
+		}
+		groups[g.GetName()] = true
+	}
+	ids := map[string]bool{}
+	for _, r := range p.pb.GetRules() {
+		id := r.GetId()
+		switch {
+		case id == "":
+			return errors.New("policy has a rule without id")
+		case ids[id]:
+			return fmt.Errorf("policy has duplicate rule %s", id)
+		case r.GetUser() == "" && r.GetGroup() == "":
+			return fmt.Errorf("rule %s has no principal", id)
+		case r.GetGroup() != "" && !groups[r.GetGroup()]:
+			return fmt.Errorf("rule %s refers to unknown group %s", id, r.GetGroup())
+		case r.GetMode() == pathzpb.Mode_MODE_UNSPECIFIED:
+			return fmt.Errorf("rule %s has no mode", id)
+		case r.GetAction() == pathzpb.Action_ACTION_UNSPECIFIED:
+			return fmt.Errorf("rule %s has no action", id)
+		case r.GetPath() == nil:
+			return fmt.Errorf("rule %s has no path", id)
+		}
+		ids[id] = true
+		for _, e := range r.GetPath().GetElem() {
+			if strings.Contains(e.GetName(), "*") || e.GetName() == "..." {
+				return fmt.Errorf("rule %s: wildcard in path element %q", id, e.GetName())
+			}
+		}
+	}
+	return nil
+}
+
+// Decision is the decision of a policy for a request, as returned by the
+// Probe RPC, and the rule it was made by.
+type Decision struct {
+	Action pathzpb.Action
+	// Rule is the id of the best matching rule, empty if no rule matched
+	// and the request is denied by default.
+	Rule string
+}
+
+func (d Decision) String() string {
+	if d.Rule == "" {
+		return d.Action.String() + " (default)"
+	}
+	return fmt.Sprintf("%v (%s)", d.Action, d.Rule)
+}
+
+// prefixMatch reports whether rule path rp is path gp or one of its parents,
+// with "*" key values of rp matching any value.  It returns the number of
+// definite keys of rp.
+func prefixMatch(rp, gp *gpb.Path) (bool, int) {
+	if rp.GetOrigin() != "" && gp.GetOrigin() != "" && rp.GetOrigin() != gp.GetOrigin() {
+		return false, 0
+	}
+	if len(rp.GetElem()) > len(gp.GetElem()) {
+		return false, 0
+	}
+	definite := 0
+	for i, re := range rp.GetElem() {
+		ge := gp.GetElem()[i]
+		if re.GetName() != ge.GetName() {
+			return false, 0
+		}
+		for k, v := range re.GetKey() {
+			if v == "*" {
+				continue
+			}
+			if gv, ok := ge.GetKey()[k]; !ok || gv != v {
+				return false, 0
+			}
+			definite++
+		}
+	}
+	return true, definite
+}
+
+// rank orders matching rules by the pathz best-match criteria: longer path,
+// then more definite keys, then user over group, then deny over permit.
+type rank [4]int
+
+func (r rank) better(o rank) bool {
+	for i := range r {
+		if r[i] != o[i] {
+			return r[i] > o[i]
+		}
+	}
+	return false
+}
+
+// Evaluate returns the decision of p for user accessing path in mode,
+// following the best-match semantics the Probe RPC mirrors.  Requests no
+// rule matches are denied.
+func (p *Policy) Evaluate(user string, path *gpb.Path, mode pathzpb.Mode) Decision {
+	member := map[string]bool{}
+	for _, g := range p.pb.GetGroups() {
+		for _, u := range g.GetUsers() {
+			if u.GetName() == user {
+				member[g.GetName()] = true
+			}
+		}
+	}
+	var best *pathzpb.AuthorizationRule
+	var bestRank rank
+	for _, r := range p.pb.GetRules() {
+		if r.GetMode() != mode {
+			continue
+		}
+		isUser := 0
+		switch {
+		case r.GetUser() != "" && r.GetUser() == user:
+			isUser = 1
+		case r.GetGroup() != "" && member[r.GetGroup()]:
+		default:
+			continue
+		}
+		ok, definite := prefixMatch(r.GetPath(), path)
+		if !ok {
+			continue
+		}
+		isDeny := 0
+		if r.GetAction() == pathzpb.Action_ACTION_DENY {
+			isDeny = 1
+		}
+		rk := rank{len(r.GetPath().GetElem()), definite, isUser, isDeny}
+		if best == nil || rk.better(bestRank) {
+			best, bestRank = r, rk
+		}
+	}
+	if best == nil {
+		return Decision{Action: pathzpb.Action_ACTION_DENY}
+	}
+	return Decision{Action: best.GetAction(), Rule: best.GetId()}
+}
+
+// EvaluateString is Evaluate with path given as a gNMI path string.
+func (p *Policy) EvaluateString(user, path string, mode pathzpb.Mode) (Decision, error) {
+	gp, err := ygot.StringToStructuredPath(path)
+	if err != nil {
+		return Decision{}, fmt.Errorf("invalid path %q: %w", path, err)
+	}
+	return p.Evaluate(user, gp, mode), nil
+}
@@ -0,0 +1,221 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pathz
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+	pathzpb "github.com/openconfig/gnsi/pathz"
+)
+
+const (
+	stevie = "spiffe://test-realm.foo.bar/role/stevie"
+	reader = "spiffe://test-realm.foo.bar/role/reader"
+	bgp    = "/network-instances/network-instance[name=DEFAULT]/protocols/protocol[identifier=BGP][name=BGP]"
+)
+
+func mustPolicy(t *testing.T, build func(p *Policy) error) *Policy {
+	t.Helper()
+	p := NewPolicy()
+	p.AddGroup("admin", stevie)
+	p.AddGroup("engineers", stevie)
+	if err := build(p); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Validate(); err != nil {
+		t.Fatalf("Validate() failed: %v", err)
+	}
+	return p
+}
+
+// TestEvaluate covers the examples of the pathz best-match documentation.
+func TestEvaluate(t *testing.T) {
+	tests := []struct {
+		desc  string
+		build func(p *Policy) error
+		want  Decision
+	}{{
+		desc: "more definite keys over user",
+		build: func(p *Policy) error {
+			if err := p.Permit("admin-bgp", Group("admin"), Read, "/network-instances/network-instance[name=DEFAULT]/protocols/protocol[identifier=BGP][name=BGP]"); err != nil {
+				return err
+			}
+			return p.Deny("stevie-any-ni", User(stevie), Read, "/network-instances/network-instance[name=*]/protocols/protocol[identifier=BGP][name=BGP]")
+		},
+		want: Decision{Permit, "admin-bgp"},
+	}, {
+		desc: "user over group",
+		build: func(p *Policy) error {
+			if err := p.Permit("stevie-bgp", User(stevie), Read, bgp); err != nil {
+				return err
+			}
+			return p.Deny("admin-bgp", Group("admin"), Read, bgp)
+		},
+		want: Decision{Permit, "stevie-bgp"},
+	}, {
+		desc: "deny over permit",
+		build: func(p *Policy) error {
+			if err := p.Permit("any-protocol", User(stevie), Read, "/network-instances/network-instance[name=DEFAULT]/protocols/protocol[identifier=*][name=BGP]"); err != nil {
+				return err
+			}
+			return p.Deny("any-ni", User(stevie), Read, "/network-instances/network-instance[name=*]/protocols/protocol[identifier=BGP][name=BGP]")
+		},
+		want: Decision{Deny, "any-ni"},
+	}, {
+		desc: "longer path",
+		build: func(p *Policy) error {
+			if err := p.Deny("ni", Group("engineers"), Read, "/network-instances/network-instance[name=DEFAULT]"); err != nil {
+				return err
+			}
+			return p.Permit("protocols", Group("engineers"), Read, "/network-instances/network-instance[name=DEFAULT]/protocols")
+		},
+		want: Decision{Permit, "protocols"},
+	}, {
+		desc: "other mode",
+		build: func(p *Policy) error {
+			return p.Permit("write", User(stevie), Write, bgp)
+		},
+		want: Decision{Action: Deny},
+	}}
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			p := mustPolicy(t, tc.build)
+			got, err := p.EvaluateString(stevie, bgp, Read)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tc.want {
+				t.Errorf("Evaluate() = %v, want %v", got, tc.want)
+			}
+			if got := p.Evaluate(reader, p.pb.GetRules()[0].GetPath(), Read); got.Rule != "" {
+				t.Errorf("Evaluate(%s) = %v, want default deny", reader, got)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		desc    string
+		build   func(p *Policy) error
+		wantErr string
+	}{{
+		desc: "duplicate rule",
+		build: func(p *Policy) error {
+			p.Permit("r", User(reader), Read, "/system")
+			return p.Deny("r", User(reader), Write, "/system")
+		},
+		wantErr: "duplicate rule",
+	}, {
+		desc: "unknown group",
+		build: func(p *Policy) error {
+			return p.Permit("r", Group("ops"), Read, "/system")
+		},
+		wantErr: "unknown group",
+	}, {
+		desc: "element wildcard",
+		build: func(p *Policy) error {
+			return p.Permit("r", User(reader), Read, "/interfaces/*/config")
+		},
+		wantErr: "wildcard in path element",
+	}, {
+		desc: "no mode",
+		build: func(p *Policy) error {
+			return p.AddRule("r", User(reader), pathzpb.Mode_MODE_UNSPECIFIED, Permit, "/system")
+		},
+		wantErr: "no mode",
+	}}
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			p := NewPolicy()
+			if err := tc.build(p); err != nil {
+				t.Fatal(err)
+			}
+			if err := p.Validate(); err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("Validate() got error %v, want %q", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestProto(t *testing.T) {
+	p := NewPolicy()
+	p.AddGroup("admin", stevie)
+	p.AddGroup("admin", reader)
+	if err := p.Permit("admin-interfaces", Group("admin"), Write, "/interfaces/interface[name=*]"); err != nil {
+		t.Fatal(err)
+	}
+	want := &pathzpb.AuthorizationPolicy{
+		Rules: []*pathzpb.AuthorizationRule{{
+			Id:        "admin-interfaces",
+			Principal: &pathzpb.AuthorizationRule_Group{Group: "admin"},
+			Path: &gpb.Path{Elem: []*gpb.PathElem{
+				{Name: "interfaces"},
+				{Name: "interface", Key: map[string]string{"name": "*"}},
+			}},
+			Action: pathzpb.Action_ACTION_PERMIT,
+			Mode:   pathzpb.Mode_MODE_WRITE,
+		}},
+		Groups: []*pathzpb.Group{{Name: "admin", Users: []*pathzpb.User{{Name: stevie}, {Name: reader}}}},
+	}
+	if diff := cmp.Diff(want, p.Proto(), protocmp.Transform()); diff != "" {
+		t.Errorf("Proto() diff (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(want, FromProto(want).Proto(), protocmp.Transform()); diff != "" {
+		t.Errorf("FromProto() diff (-want +got):\n%s", diff)
+	}
+	if err := p.Permit("bad", User(reader), Read, "/interfaces/interface[name]"); err == nil {
+		t.Errorf("Permit() with an invalid path succeeded, want error")
+	}
+}
+
+func TestActions(t *testing.T) {
+	denied := status.Error(codes.PermissionDenied, "denied")
+	unavailable := status.Error(codes.Unavailable, "unavailable")
+	readTests := []struct {
+		updates int
+		err     error
+		want    pathzpb.Action
+		wantErr bool
+	}{
+		{1, nil, Permit, false},
+		{0, nil, Deny, false},
+		{0, denied, Deny, false},
+		{0, status.Error(codes.NotFound, "no data"), Deny, false},
+		{0, unavailable, pathzpb.Action_ACTION_UNSPECIFIED, true},
+	}
+	for _, tc := range readTests {
+		got, err := readAction(tc.updates, tc.err)
+		if got != tc.want || (err != nil) != tc.wantErr {
+			t.Errorf("readAction(%d, %v) = %v, %v, want %v, error %v", tc.updates, tc.err, got, err, tc.want, tc.wantErr)
+		}
+	}
+	if got, err := writeAction(nil); got != Permit || err != nil {
+		t.Errorf("writeAction(nil) = %v, %v, want %v", got, err, Permit)
+	}
+	if got, err := writeAction(denied); got != Deny || err != nil {
+		t.Errorf("writeAction(%v) = %v, %v, want %v", denied, got, err, Deny)
+	}
+	if _, err := writeAction(unavailable); err == nil {
+		t.Errorf("writeAction(%v) succeeded, want error", unavailable)
+	}
+}
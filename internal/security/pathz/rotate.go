@@ -0,0 +1,127 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pathz
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ygot/ygot"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	pathzpb "github.com/openconfig/gnsi/pathz"
+)
+
+// Rotate applies policy p on device dut, checking that the sandbox holds p
+// after the upload and that it is active after the finalize.  This is a test
+// API for positive testing and it fails the test on failure.
+func Rotate(t testing.TB, dut *ondatra.DUTDevice, p *Policy, version string, createdOn uint64, forceOverwrite bool) {
+	t.Helper()
+	if err := p.Validate(); err != nil {
+		t.Fatalf("Invalid pathz policy: %v", err)
+	}
+	t.Logf("Performing Pathz.Rotate request on device %s", dut.Name())
+	gnsiC, err := dut.RawAPIs().BindingDUT().DialGNSI(context.Background())
+	if err != nil {
+		t.Fatalf("Could not connect gnsi %v", err)
+	}
+	rotateStream, err := gnsiC.Pathz().Rotate(context.Background())
+	if err != nil {
+		t.Fatalf("Could not start a rotate stream %v", err)
+	}
+	defer rotateStream.CloseSend()
+	req := &pathzpb.RotateRequest{
+		RotateRequest: &pathzpb.RotateRequest_UploadRequest{
+			UploadRequest: &pathzpb.UploadRequest{
+				Version:   version,
+				CreatedOn: createdOn,
+				Policy:    p.Proto(),
+			},
+		},
+		ForceOverwrite: forceOverwrite,
+	}
+	t.Logf("Sending Pathz.Rotate request on device:\n%s", prototext.Format(req))
+	if err := rotateStream.Send(req); err != nil {
+		t.Fatalf("Error while uploading pathz policy %v", err)
+	}
+	if _, err := rotateStream.Recv(); err != nil {
+		t.Fatalf("Error while receiving rotate request reply %v", err)
+	}
+	if diff := cmp.Diff(p.Proto(), Get(t, dut, pathzpb.PolicyInstance_POLICY_INSTANCE_SANDBOX).GetPolicy(), protocmp.Transform()); diff != "" {
+		t.Fatalf("Sandbox policy after upload is not the one uploaded, diff (-want +got):\n%s", diff)
+	}
+	finalize := &pathzpb.RotateRequest{RotateRequest: &pathzpb.RotateRequest_FinalizeRotation{FinalizeRotation: &pathzpb.FinalizeRequest{}}}
+	if err := rotateStream.Send(finalize); err != nil {
+		t.Fatalf("Error while finalizing rotate request %v", err)
+	}
+	if _, err := rotateStream.Recv(); err != nil && err != io.EOF {
+		t.Fatalf("Error while receiving finalize response %v", err)
+	}
+	resp := Get(t, dut, pathzpb.PolicyInstance_POLICY_INSTANCE_ACTIVE)
+	if diff := cmp.Diff(p.Proto(), resp.GetPolicy(), protocmp.Transform()); diff != "" {
+		t.Fatalf("Active policy after finalize is not the one uploaded, diff (-want +got):\n%s", diff)
+	}
+	if resp.GetVersion() != version {
+		t.Errorf("Active policy version is %q, want %q", resp.GetVersion(), version)
+	}
+}
+
+// Get reads the policy instance from device dut.  This is a test API and it
+// fails the test when it fails.
+func Get(t testing.TB, dut *ondatra.DUTDevice, instance pathzpb.PolicyInstance) *pathzpb.GetResponse {
+	t.Helper()
+	gnsiC, err := dut.RawAPIs().BindingDUT().DialGNSI(context.Background())
+	if err != nil {
+		t.Fatalf("Could not connect gnsi %v", err)
+	}
+	resp, err := gnsiC.Pathz().Get(context.Background(), &pathzpb.GetRequest{PolicyInstance: instance})
+	if err != nil {
+		t.Fatalf("Pathz.Get of %v failed on device %s: %v", instance, dut.Name(), err)
+	}
+	return resp
+}
+
+// Probe returns the decision of the active policy of dut for user accessing
+// path in mode.
+func Probe(t testing.TB, dut *ondatra.DUTDevice, user, path string, mode pathzpb.Mode) *pathzpb.ProbeResponse {
+	t.Helper()
+	gp, err := ygot.StringToStructuredPath(path)
+	if err != nil {
+		t.Fatalf("Invalid path %q: %v", path, err)
+	}
+	gnsiC, err := dut.RawAPIs().BindingDUT().DialGNSI(context.Background())
+	if err != nil {
+		t.Fatalf("Could not connect gnsi %v", err)
+	}
+	req := &pathzpb.ProbeRequest{User: user, Path: gp, Mode: mode, PolicyInstance: pathzpb.PolicyInstance_POLICY_INSTANCE_ACTIVE}
+	resp, err := gnsiC.Pathz().Probe(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Pathz.Probe %s failed on device %s: %v", prototext.Format(req), dut.Name(), err)
+	}
+	return resp
+}
+
+// VerifyProbe checks that the active policy of dut takes action want for
+// user accessing path in mode.
+func VerifyProbe(t testing.TB, dut *ondatra.DUTDevice, user, path string, mode pathzpb.Mode, want pathzpb.Action) {
+	t.Helper()
+	if got := Probe(t, dut, user, path, mode).GetAction(); got != want {
+		t.Errorf("Pathz.Probe of user %s path %s mode %v on device %s: got %v, want %v", user, path, mode, dut.Name(), got, want)
+	}
+}
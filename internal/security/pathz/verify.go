@@ -0,0 +1,195 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pathz
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/openconfig/featureprofiles/internal/security/authz"
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ygot/ygot"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+	pathzpb "github.com/openconfig/gnsi/pathz"
+)
+
+// Check is a gNMI access to verify.
+type Check struct {
+	// Path is a gNMI path string of an existing leaf or subtree.  Paths
+	// without origin are sent with the openconfig origin.
+	Path string
+	Mode pathzpb.Mode
+	Want pathzpb.Action
+	// Value is written by a write check.  If nil, the current value, read
+	// with the default credentials, is written back, so that a permitted
+	// write changes nothing.
+	Value *gpb.TypedValue
+}
+
+// readAction returns the action a read of an existing path shows: a deny if
+// it failed with PermissionDenied or returned no data, since pathz filters
+// out what a user may not read, and a permit if it returned data.
+func readAction(updates int, err error) (pathzpb.Action, error) {
+	switch {
+	case status.Code(err) == codes.PermissionDenied, status.Code(err) == codes.NotFound:
+		return pathzpb.Action_ACTION_DENY, nil
+	case err != nil:
+		return pathzpb.Action_ACTION_UNSPECIFIED, err
+	case updates == 0:
+		return pathzpb.Action_ACTION_DENY, nil
+	}
+	return pathzpb.Action_ACTION_PERMIT, nil
+}
+
+// writeAction returns the action the result of a Set shows.
+func writeAction(err error) (pathzpb.Action, error) {
+	switch {
+	case err == nil:
+		return pathzpb.Action_ACTION_PERMIT, nil
+	case status.Code(err) == codes.PermissionDenied:
+		return pathzpb.Action_ACTION_DENY, nil
+	}
+	return pathzpb.Action_ACTION_UNSPECIFIED, err
+}
+
+func countUpdates(notifs []*gpb.Notification) int {
+	n := 0
+	for _, notif := range notifs {
+		n += len(notif.GetUpdate())
+	}
+	return n
+}
+
+func get(ctx context.Context, c gpb.GNMIClient, path *gpb.Path, typ gpb.GetRequest_DataType) ([]*gpb.Notification, error) {
+	resp, err := c.Get(ctx, &gpb.GetRequest{Path: []*gpb.Path{path}, Type: typ, Encoding: gpb.Encoding_JSON_IETF})
+	return resp.GetNotification(), err
+}
+
+// subscribeOnce returns the number of updates of a ONCE subscription to path.
+func subscribeOnce(ctx context.Context, c gpb.GNMIClient, path *gpb.Path) (int, error) {
+	sub, err := c.Subscribe(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer sub.CloseSend()
+	err = sub.Send(&gpb.SubscribeRequest{Request: &gpb.SubscribeRequest_Subscribe{Subscribe: &gpb.SubscriptionList{
+		Mode:         gpb.SubscriptionList_ONCE,
+		Encoding:     gpb.Encoding_JSON_IETF,
+		Subscription: []*gpb.Subscription{{Path: path}},
+	}}})
+	if err != nil {
+		return 0, err
+	}
+	updates := 0
+	for {
+		resp, err := sub.Recv()
+		switch {
+		case err == io.EOF:
+			return updates, nil
+		case err != nil:
+			return updates, err
+		case resp.GetSyncResponse():
+			return updates, nil
+		}
+		updates += len(resp.GetUpdate().GetUpdate())
+	}
+}
+
+// access performs c with userC and returns the actions its requests show,
+// keyed by the gNMI RPC.  adminC reads the value written back by writes.
+func access(ctx context.Context, adminC, userC gpb.GNMIClient, c Check) (map[string]pathzpb.Action, error) {
+	path, err := ygot.StringToStructuredPath(c.Path)
+	if err != nil {
+		return nil, fmt.Errorf("invalid path %q: %w", c.Path, err)
+	}
+	if path.GetOrigin() == "" {
+		path.Origin = "openconfig"
+	}
+	actions := map[string]pathzpb.Action{}
+	if c.Mode == pathzpb.Mode_MODE_READ {
+		notifs, err := get(ctx, userC, path, gpb.GetRequest_ALL)
+		if actions["Get"], err = readAction(countUpdates(notifs), err); err != nil {
+			return nil, fmt.Errorf("Get of %s: %w", c.Path, err)
+		}
+		n, err := subscribeOnce(ctx, userC, path)
+		if actions["Subscribe"], err = readAction(n, err); err != nil {
+			return nil, fmt.Errorf("Subscribe to %s: %w", c.Path, err)
+		}
+		return actions, nil
+	}
+	val := c.Value
+	if val == nil {
+		notifs, err := get(ctx, adminC, path, gpb.GetRequest_CONFIG)
+		if err != nil {
+			return nil, fmt.Errorf("could not read the current value of %s: %w", c.Path, err)
+		}
+		for _, n := range notifs {
+			for _, u := range n.GetUpdate() {
+				if val == nil {
+					val = u.GetVal()
+				}
+			}
+		}
+		if val == nil {
+			return nil, fmt.Errorf("%s has no config value to write back", c.Path)
+		}
+	}
+	_, err = userC.Set(ctx, &gpb.SetRequest{Replace: []*gpb.Update{{Path: path, Val: val}}})
+	if actions["Set"], err = writeAction(err); err != nil {
+		return nil, fmt.Errorf("Set of %s: %w", c.Path, err)
+	}
+	return actions, nil
+}
+
+// Verify performs the gNMI requests of checks on dut with the SVID of user
+// and checks that pathz takes the wanted actions: read checks do a Get and
+// a ONCE Subscribe, and write checks a Set replacing the value.
+func Verify(t testing.TB, dut *ondatra.DUTDevice, user *authz.Spiffe, checks []Check) {
+	t.Helper()
+	ctx := context.Background()
+	adminC, err := dut.RawAPIs().BindingDUT().DialGNMI(ctx)
+	if err != nil {
+		t.Fatalf("Could not connect gnmi %v", err)
+	}
+	userC, err := dut.RawAPIs().BindingDUT().DialGNMI(ctx, grpc.WithTransportCredentials(credentials.NewTLS(user.TLSConf)))
+	if err != nil {
+		t.Fatalf("Could not connect gnmi as %s: %v", user.ID, err)
+	}
+	for _, c := range checks {
+		actions, err := access(ctx, adminC, userC, c)
+		if err != nil {
+			t.Errorf("User %s %v of %s on device %s failed: %v", user.ID, c.Mode, c.Path, dut.Name(), err)
+			continue
+		}
+		for _, rpc := range []string{"Get", "Subscribe", "Set"} {
+			got, ok := actions[rpc]
+			if !ok {
+				continue
+			}
+			if got != c.Want {
+				t.Errorf("User %s gNMI %s of %s on device %s: got %v, want %v", user.ID, rpc, c.Path, dut.Name(), got, c.Want)
+			} else {
+				t.Logf("User %s gNMI %s of %s on device %s: %v as expected", user.ID, rpc, c.Path, dut.Name(), got)
+			}
+		}
+	}
+}
@@ -0,0 +1,444 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package setrecorder provides a gRPC interceptor that records every gNMI
+// SetRequest a test sends, together with the SetResponse or error, so that
+// the exact payloads can be handed to a vendor when a config push fails.
+//
+// The interceptor is added to the binding dial options when the
+// --record_gnmi_set flag is set, so it sees every Set, whether it comes from
+// gnmi.Replace/Update, gnmi.SetBatch, cfgplugins or a CLI-origin push. Tests
+// label records and get a per-test file by calling Attach:
+//
+//	func TestFoo(t *testing.T) {
+//		setrecorder.Attach(t)
+//		...
+//	}
+//
+// When the top-level test ends, the records are written to a file in
+// --outputs_dir. Subtests may also call Attach to label their records. A Set
+// made while parallel subtests are attached cannot be told apart and is
+// labelled with their closest attached parent, unless it is sent with a
+// context from NewContext. The binding calls Flush when it releases the
+// testbed, which writes the records of tests that did not call Attach, so
+// every Set ends up in a file.
+package setrecorder
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+	"github.com/openconfig/ygot/ygot"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/prototext"
+)
+
+var (
+	enabled = flag.Bool("record_gnmi_set", false,
+		"records every gNMI SetRequest and SetResponse to a per-test file in --outputs_dir")
+	format = flag.String("record_gnmi_set_format", "textproto",
+		"format of recorded gNMI Set payloads: textproto or json")
+)
+
+const setMethod = "/gnmi.gNMI/Set"
+
+// Record is one recorded gNMI Set call.
+type Record struct {
+	Time     time.Time
+	Duration time.Duration
+	Test     string
+	Target   string
+	Request  *gpb.SetRequest
+	Response *gpb.SetResponse
+	Err      error
+}
+
+// CLI returns the CLI-origin payloads carried by the request.
+func (r *Record) CLI() []string {
+	var cli []string
+	prefixOrigin := r.Request.GetPrefix().GetOrigin()
+	for _, upds := range [][]*gpb.Update{r.Request.GetReplace(), r.Request.GetUpdate(), r.Request.GetUnionReplace()} {
+		for _, u := range upds {
+			origin := u.GetPath().GetOrigin()
+			if origin == "" {
+				origin = prefixOrigin
+			}
+			if origin == "cli" || u.GetVal().GetAsciiVal() != "" {
+				cli = append(cli, u.GetVal().GetAsciiVal())
+			}
+		}
+	}
+	return cli
+}
+
+// Recorder collects Set records. The package-level default recorder is used
+// by the interceptors and Attach; a separate Recorder is mainly useful in
+// unit tests.
+type Recorder struct {
+	mu      sync.Mutex
+	records []*Record
+	// attached are the attached tests that have not ended.
+	attached map[testing.TB]bool
+}
+
+var defaultRecorder = &Recorder{}
+
+// Default returns the package-level recorder.
+func Default() *Recorder {
+	return defaultRecorder
+}
+
+// Records returns a copy of the records collected so far.
+func (r *Recorder) Records() []*Record {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]*Record{}, r.records...)
+}
+
+// Reset discards all records.
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.records = nil
+}
+
+// take removes and returns the records of test and its subtests, or all
+// records if test is "".
+func (r *Recorder) take(test string) []*Record {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var recs, rest []*Record
+	for _, rec := range r.records {
+		if test == "" || within(rec.Test, test) {
+			recs = append(recs, rec)
+		} else {
+			rest = append(rest, rec)
+		}
+	}
+	r.records = rest
+	return recs
+}
+
+// within reports whether test is parent or one of its subtests.
+func within(test, parent string) bool {
+	return test == parent || strings.HasPrefix(test, parent+"/")
+}
+
+// add records rec, labelled with the test of ctx or else with the attached
+// test all other attached tests are a parent or subtest of.
+func (r *Recorder) add(ctx context.Context, rec *Record) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if t, ok := ctx.Value(testKey{}).(testing.TB); ok {
+		rec.Test = t.Name()
+	} else {
+		rec.Test = r.label()
+	}
+	r.records = append(r.records, rec)
+}
+
+// label returns the deepest attached test that every attached test is a
+// parent or subtest of, or "" if there is none. r.mu must be held.
+func (r *Recorder) label() string {
+	label := ""
+	for t := range r.attached {
+		name := t.Name()
+		if len(name) <= len(label) {
+			continue
+		}
+		related := true
+		for u := range r.attached {
+			if !within(u.Name(), name) && !within(name, u.Name()) {
+				related = false
+				break
+			}
+		}
+		if related {
+			label = name
+		}
+	}
+	return label
+}
+
+// attach labels subsequent records with t's name and returns a function
+// that removes the label and reports whether no parent of t is attached.
+func (r *Recorder) attach(t testing.TB) func() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.attached == nil {
+		r.attached = map[testing.TB]bool{}
+	}
+	r.attached[t] = true
+	return func() bool {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		delete(r.attached, t)
+		for u := range r.attached {
+			if within(t.Name(), u.Name()) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+type testKey struct{}
+
+// NewContext returns a context that labels the Set calls sent with it with
+// t's name, whichever tests are attached.
+func NewContext(ctx context.Context, t testing.TB) context.Context {
+	return context.WithValue(ctx, testKey{}, t)
+}
+
+// UnaryClientInterceptor returns a UnaryClientInterceptor that records gNMI
+// Set calls to r.
+func (r *Recorder) UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		setReq, ok := req.(*gpb.SetRequest)
+		if method != setMethod || !ok {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+		start := time.Now()
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		rec := &Record{
+			Time:     start,
+			Duration: time.Since(start),
+			Request:  setReq,
+			Err:      err,
+		}
+		if cc != nil {
+			rec.Target = cc.Target()
+		}
+		if resp, ok := reply.(*gpb.SetResponse); ok && err == nil {
+			rec.Response = resp
+		}
+		r.add(ctx, rec)
+		return err
+	}
+}
+
+// UnaryClientInterceptor returns an interceptor that records to the default
+// recorder.
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return defaultRecorder.UnaryClientInterceptor()
+}
+
+// DialOptions returns the dial options that install the recorder, or nil if
+// --record_gnmi_set is not set.
+func DialOptions() []grpc.DialOption {
+	if !*enabled {
+		return nil
+	}
+	return []grpc.DialOption{grpc.WithChainUnaryInterceptor(UnaryClientInterceptor())}
+}
+
+// Attach labels the Set calls made during t with t's name. When the
+// outermost attached test finishes, the records of it and its subtests are
+// written to a file named after it in --outputs_dir.
+func Attach(t testing.TB) {
+	t.Helper()
+	if !*enabled {
+		return
+	}
+	detach := defaultRecorder.attach(t)
+	t.Cleanup(func() {
+		if !detach() {
+			return
+		}
+		recs := defaultRecorder.take(t.Name())
+		name, err := write(t.Name()+"_gnmi_set", recs)
+		if err != nil {
+			t.Errorf("Cannot write recorded gNMI Set requests: %v", err)
+		} else if name != "" {
+			t.Logf("Recorded %d gNMI Set requests to %s", len(recs), name)
+		}
+	})
+}
+
+// Flush writes the records not yet written by Attach to a file in
+// --outputs_dir and discards them. It does nothing unless --record_gnmi_set
+// is set or if there are no such records.
+func Flush() error {
+	if !*enabled {
+		return nil
+	}
+	recs := defaultRecorder.take("")
+	if len(recs) == 0 {
+		return nil
+	}
+	name, err := write("gnmi_set", recs)
+	if err != nil {
+		return fmt.Errorf("cannot write recorded gNMI Set requests: %w", err)
+	}
+	if name != "" {
+		log.Printf("Recorded %d gNMI Set requests to %s", len(recs), name)
+	}
+	return nil
+}
+
+// write formats recs in the --record_gnmi_set_format and writes them to an
+// output file named after name.
+func write(name string, recs []*Record) (string, error) {
+	text, err := Format(recs, *format)
+	if err != nil {
+		return "", err
+	}
+	return writeOutput(name, text)
+}
+
+// Format renders records as "textproto" or "json".
+func Format(recs []*Record, format string) (string, error) {
+	switch format {
+	case "textproto":
+		return formatText(recs), nil
+	case "json":
+		return formatJSON(recs)
+	}
+	return "", fmt.Errorf("unknown format %q", format)
+}
+
+func formatText(recs []*Record) string {
+	var sb strings.Builder
+	for i, rec := range recs {
+		fmt.Fprintf(&sb, "# [%d] %s test=%q target=%s duration=%v\n", i, rec.Time.Format(time.RFC3339Nano), rec.Test, rec.Target, rec.Duration)
+		sb.WriteString("# SetRequest\n")
+		sb.WriteString(prototext.Format(rec.Request))
+		for _, cli := range rec.CLI() {
+			fmt.Fprintf(&sb, "# CLI payload\n%s\n", cli)
+		}
+		if rec.Err != nil {
+			fmt.Fprintf(&sb, "# Error\n# %v\n", rec.Err)
+		} else {
+			sb.WriteString("# SetResponse\n")
+			sb.WriteString(prototext.Format(rec.Response))
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// jsonOp is one replace, update or delete operation in the JSON format.
+type jsonOp struct {
+	Op    string `json:"op"`
+	Path  string `json:"path"`
+	Value any    `json:"value,omitempty"`
+}
+
+type jsonRecord struct {
+	Time     time.Time `json:"time"`
+	Duration string    `json:"duration"`
+	Test     string    `json:"test,omitempty"`
+	Target   string    `json:"target,omitempty"`
+	Ops      []jsonOp  `json:"ops"`
+	CLI      []string  `json:"cli,omitempty"`
+	Error    string    `json:"error,omitempty"`
+}
+
+// formatJSON renders each operation with its path as a string and its value
+// decoded from JSON_IETF where possible, the same form ygot emits.
+func formatJSON(recs []*Record) (string, error) {
+	out := make([]jsonRecord, 0, len(recs))
+	for _, rec := range recs {
+		jr := jsonRecord{
+			Time:     rec.Time,
+			Duration: rec.Duration.String(),
+			Test:     rec.Test,
+			Target:   rec.Target,
+			CLI:      rec.CLI(),
+		}
+		if rec.Err != nil {
+			jr.Error = rec.Err.Error()
+		}
+		prefix := rec.Request.GetPrefix()
+		for _, p := range rec.Request.GetDelete() {
+			jr.Ops = append(jr.Ops, jsonOp{Op: "delete", Path: pathString(prefix, p)})
+		}
+		for _, op := range []struct {
+			name string
+			upds []*gpb.Update
+		}{{"replace", rec.Request.GetReplace()}, {"update", rec.Request.GetUpdate()}, {"union_replace", rec.Request.GetUnionReplace()}} {
+			for _, u := range op.upds {
+				jr.Ops = append(jr.Ops, jsonOp{Op: op.name, Path: pathString(prefix, u.GetPath()), Value: jsonValue(u.GetVal())})
+			}
+		}
+		out = append(out, jr)
+	}
+	b, err := json.MarshalIndent(out, "", "  ")
+	return string(b), err
+}
+
+func pathString(prefix, p *gpb.Path) string {
+	full := &gpb.Path{Elem: append(append([]*gpb.PathElem{}, prefix.GetElem()...), p.GetElem()...)}
+	s, err := ygot.PathToString(full)
+	if err != nil {
+		return fmt.Sprintf("<unprintable path: %v>", err)
+	}
+	if origin := p.GetOrigin(); origin != "" {
+		return origin + ":" + s
+	}
+	return s
+}
+
+func jsonValue(tv *gpb.TypedValue) any {
+	var raw []byte
+	switch {
+	case tv.GetJsonIetfVal() != nil:
+		raw = tv.GetJsonIetfVal()
+	case tv.GetJsonVal() != nil:
+		raw = tv.GetJsonVal()
+	case tv.GetAsciiVal() != "":
+		return tv.GetAsciiVal()
+	default:
+		return prototext.Format(tv)
+	}
+	var v any
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return string(raw)
+	}
+	return v
+}
+
+// writeOutput writes content to a new file in the directory given by the
+// --outputs_dir flag registered by fptest, which this package cannot import
+// because the binding depends on it.
+func writeOutput(name, content string) (string, error) {
+	dir := os.Getenv("TEST_UNDECLARED_OUTPUTS_DIR")
+	if f := flag.Lookup("outputs_dir"); f != nil && f.Value.String() != "" {
+		dir = f.Value.String()
+	}
+	if dir == "" {
+		log.Printf("Test output %q is discarded without -outputs_dir.  Please specify -outputs_dir to keep it.", name)
+		return "", nil
+	}
+	name = strings.NewReplacer("/", "_", " ", "_").Replace(name)
+	f, err := os.CreateTemp(dir, name+".*.txt")
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	if _, err := f.WriteString(content); err != nil {
+		return "", err
+	}
+	return filepath.Base(f.Name()), nil
+}
@@ -0,0 +1,196 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package setrecorder
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+	"google.golang.org/grpc"
+)
+
+func TestUnaryClientInterceptor(t *testing.T) {
+	r := &Recorder{}
+	detach := r.attach(t)
+	defer detach()
+
+	req := &gpb.SetRequest{
+		Replace: []*gpb.Update{{
+			Path: &gpb.Path{Elem: []*gpb.PathElem{{Name: "system"}, {Name: "config"}, {Name: "hostname"}}},
+			Val:  &gpb.TypedValue{Value: &gpb.TypedValue_JsonIetfVal{JsonIetfVal: []byte(`"dut"`)}},
+		}},
+		Update: []*gpb.Update{{
+			Path: &gpb.Path{Origin: "cli"},
+			Val:  &gpb.TypedValue{Value: &gpb.TypedValue_AsciiVal{AsciiVal: "hostname dut"}},
+		}},
+	}
+	wantErr := errors.New("rejected")
+	invoker := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		if method == setMethod {
+			return wantErr
+		}
+		return nil
+	}
+
+	intercept := r.UnaryClientInterceptor()
+	if err := intercept(t.Context(), "/gnmi.gNMI/Get", &gpb.GetRequest{}, &gpb.GetResponse{}, nil, invoker); err != nil {
+		t.Fatalf("interceptor failed on Get: %v", err)
+	}
+	if err := intercept(t.Context(), setMethod, req, &gpb.SetResponse{}, nil, invoker); !errors.Is(err, wantErr) {
+		t.Fatalf("interceptor returned %v, want %v", err, wantErr)
+	}
+
+	recs := r.Records()
+	if len(recs) != 1 {
+		t.Fatalf("Records() returned %d records, want 1", len(recs))
+	}
+	rec := recs[0]
+	if rec.Test != t.Name() {
+		t.Errorf("Record.Test = %q, want %q", rec.Test, t.Name())
+	}
+	if got := rec.CLI(); len(got) != 1 || got[0] != "hostname dut" {
+		t.Errorf("Record.CLI() = %q, want [hostname dut]", got)
+	}
+
+	for _, tc := range []struct {
+		format string
+		want   []string
+	}{
+		{"textproto", []string{t.Name(), "hostname", "# CLI payload", "rejected"}},
+		{"json", []string{`"path": "/system/config/hostname"`, `"value": "dut"`, `"op": "update"`, `"error": "rejected"`}},
+	} {
+		got, err := Format(recs, tc.format)
+		if err != nil {
+			t.Fatalf("Format(%q) failed: %v", tc.format, err)
+		}
+		for _, w := range tc.want {
+			if !strings.Contains(got, w) {
+				t.Errorf("Format(%q) does not contain %q:\n%s", tc.format, w, got)
+			}
+		}
+	}
+	if _, err := Format(recs, "yaml"); err == nil {
+		t.Errorf("Format(yaml) succeeded, want error")
+	}
+}
+
+func TestAttachAndFlush(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("TEST_UNDECLARED_OUTPUTS_DIR", dir)
+	defer func(v bool) { *enabled = v }(*enabled)
+	*enabled = true
+	defaultRecorder.Reset()
+	defer defaultRecorder.Reset()
+
+	invoker := func(context.Context, string, any, any, *grpc.ClientConn, ...grpc.CallOption) error { return nil }
+	set := func() {
+		if err := UnaryClientInterceptor()(t.Context(), setMethod, &gpb.SetRequest{}, &gpb.SetResponse{}, nil, invoker); err != nil {
+			t.Fatalf("interceptor failed: %v", err)
+		}
+	}
+
+	set()
+	t.Run("Attached", func(t *testing.T) {
+		Attach(t)
+		set()
+		set()
+	})
+	if got := len(defaultRecorder.Records()); got != 1 {
+		t.Errorf("Records() after attached test returned %d records, want 1", got)
+	}
+	if err := Flush(); err != nil {
+		t.Fatalf("Flush() failed: %v", err)
+	}
+	if got := len(defaultRecorder.Records()); got != 0 {
+		t.Errorf("Records() after Flush returned %d records, want 0", got)
+	}
+
+	for _, tc := range []struct {
+		pattern string
+		want    int
+	}{
+		{"TestAttachAndFlush_Attached_gnmi_set.*.txt", 2},
+		{"gnmi_set.*.txt", 1},
+	} {
+		files, err := filepath.Glob(filepath.Join(dir, tc.pattern))
+		if err != nil || len(files) != 1 {
+			t.Fatalf("Output files matching %q: got %v (%v), want 1 file", tc.pattern, files, err)
+		}
+		b, err := os.ReadFile(files[0])
+		if err != nil {
+			t.Fatalf("Cannot read %s: %v", files[0], err)
+		}
+		if got := strings.Count(string(b), "# SetRequest"); got != tc.want {
+			t.Errorf("%s holds %d requests, want %d", files[0], got, tc.want)
+		}
+	}
+}
+
+func TestAttachParallel(t *testing.T) {
+	r := &Recorder{}
+	invoker := func(context.Context, string, any, any, *grpc.ClientConn, ...grpc.CallOption) error { return nil }
+	set := func(ctx context.Context) {
+		if err := r.UnaryClientInterceptor()(ctx, setMethod, &gpb.SetRequest{}, &gpb.SetResponse{}, nil, invoker); err != nil {
+			t.Errorf("interceptor failed: %v", err)
+		}
+	}
+
+	t.Run("Group", func(t *testing.T) {
+		detach := r.attach(t)
+		t.Cleanup(func() {
+			if !detach() {
+				t.Errorf("detach() of Group = false, want true")
+			}
+		})
+		// Both subtests are attached before either resumes after Parallel,
+		// which they do once Group returns.
+		for _, name := range []string{"A", "B"} {
+			t.Run(name, func(t *testing.T) {
+				detach := r.attach(t)
+				t.Cleanup(func() {
+					if detach() {
+						t.Errorf("detach() of %s = true, want false", name)
+					}
+				})
+				t.Parallel()
+				set(NewContext(t.Context(), t))
+			})
+		}
+		// This Set could come from either subtest.
+		set(t.Context())
+	})
+
+	got := map[string]int{}
+	for _, rec := range r.Records() {
+		got[rec.Test]++
+	}
+	want := map[string]int{
+		"TestAttachParallel/Group/A": 1,
+		"TestAttachParallel/Group/B": 1,
+		"TestAttachParallel/Group":   1,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Record.Test counts returned diff (-want +got):\n%s", diff)
+	}
+	if recs := r.take("TestAttachParallel/Group"); len(recs) != 3 {
+		t.Errorf("take() returned %d records, want 3", len(recs))
+	}
+}
@@ -0,0 +1,175 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sflow
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/google/gopacket/pcapgo"
+	"github.com/open-traffic-generator/snappi/gosnappi"
+	"github.com/openconfig/ondatra"
+)
+
+// DefaultPort is the well-known sFlow collector port.
+const DefaultPort = 6343
+
+// FromPCAP decodes the sFlow exports in a pcap or pcapng capture.  Packets
+// are treated as sFlow if their UDP destination port is one of ports, which
+// defaults to DefaultPort.  Packets that are not sFlow are skipped, and so
+// are sFlow packets that cannot be decoded; malformed is their number.  An
+// error is returned only if the capture itself cannot be read.
+func FromPCAP(capture []byte, ports ...uint16) (exports []*Export, malformed int, err error) {
+	if len(ports) == 0 {
+		ports = []uint16{DefaultPort}
+	}
+	var (
+		src      gopacket.PacketDataSource
+		linkType layers.LinkType
+	)
+	if r, err := pcapgo.NewReader(bytes.NewReader(capture)); err == nil {
+		src, linkType = r, r.LinkType()
+	} else if ng, ngErr := pcapgo.NewNgReader(bytes.NewReader(capture), pcapgo.DefaultNgReaderOptions); ngErr == nil {
+		src, linkType = ng, ng.LinkType()
+	} else {
+		return nil, 0, fmt.Errorf("capture is neither pcap (%v) nor pcapng (%v)", err, ngErr)
+	}
+
+	for i := 0; ; i++ {
+		data, _, err := src.ReadPacketData()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, 0, fmt.Errorf("packet %d: %w", i, err)
+		}
+		pkt := gopacket.NewPacket(data, linkType, gopacket.Default)
+		udp, ok := pkt.Layer(layers.LayerTypeUDP).(*layers.UDP)
+		if !ok || !slices.Contains(ports, uint16(udp.DstPort)) {
+			continue
+		}
+		dg, err := Decode(udp.Payload)
+		if err != nil {
+			malformed++
+			continue
+		}
+		e := &Export{Datagram: dg}
+		switch ip := pkt.NetworkLayer().(type) {
+		case *layers.IPv4:
+			dscp := ip.TOS >> 2
+			e.Source, e.Destination, e.DSCP = ip.SrcIP, ip.DstIP, &dscp
+		case *layers.IPv6:
+			dscp := ip.TrafficClass >> 2
+			e.Source, e.Destination, e.DSCP = ip.SrcIP, ip.DstIP, &dscp
+		}
+		exports = append(exports, e)
+	}
+	return exports, malformed, nil
+}
+
+// FromOTGCapture fetches the capture of an OTG port and decodes its sFlow
+// exports, returning them with the number of sFlow packets that could not be
+// decoded, as FromPCAP does.  Capture must have been enabled and stopped on
+// the port.
+func FromOTGCapture(t testing.TB, ate *ondatra.ATEDevice, portName string, ports ...uint16) (exports []*Export, malformed int) {
+	t.Helper()
+	capture := ate.OTG().GetCapture(t, gosnappi.NewCaptureRequest().SetPortName(portName))
+	exports, malformed, err := FromPCAP(capture, ports...)
+	if err != nil {
+		t.Fatalf("Cannot read the capture of %s: %v", portName, err)
+	}
+	t.Logf("Decoded %d sFlow datagrams from the capture of %s, skipped %d malformed ones", len(exports), portName, malformed)
+	return exports, malformed
+}
+
+// Listener is a local UDP sFlow collector.
+type Listener struct {
+	conn *net.UDPConn
+	done chan struct{}
+
+	mu      sync.Mutex
+	exports []*Export
+	errs    []error
+}
+
+// Listen starts collecting sFlow datagrams on the UDP address addr, such as
+// ":6343".
+func Listen(addr string) (*Listener, error) {
+	udpAddr, err := net.ResolveUDPAddr("udp", addr)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := net.ListenUDP("udp", udpAddr)
+	if err != nil {
+		return nil, err
+	}
+	l := &Listener{conn: conn, done: make(chan struct{})}
+	go l.serve()
+	return l, nil
+}
+
+func (l *Listener) serve() {
+	defer close(l.done)
+	buf := make([]byte, 65535)
+	for {
+		n, from, err := l.conn.ReadFromUDP(buf)
+		if err != nil {
+			return
+		}
+		dg, err := Decode(slices.Clone(buf[:n]))
+		l.mu.Lock()
+		if err != nil {
+			l.errs = append(l.errs, fmt.Errorf("datagram from %v: %w", from, err))
+		} else {
+			l.exports = append(l.exports, &Export{Source: from.IP, Datagram: dg})
+		}
+		l.mu.Unlock()
+	}
+}
+
+// Addr returns the local address of the listener.
+func (l *Listener) Addr() *net.UDPAddr {
+	return l.conn.LocalAddr().(*net.UDPAddr)
+}
+
+// Exports returns the datagrams received so far.  The DSCP of datagrams
+// received by a Listener is unknown.
+func (l *Listener) Exports() []*Export {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return slices.Clone(l.exports)
+}
+
+// Err returns the decoding errors seen so far, or nil.
+func (l *Listener) Err() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return errors.Join(l.errs...)
+}
+
+// Close stops the listener.
+func (l *Listener) Close() error {
+	err := l.conn.Close()
+	<-l.done
+	return err
+}
@@ -0,0 +1,215 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sflow decodes sFlow v5 datagrams and validates their samples.
+//
+// Datagrams can be read from an OTG capture (FromOTGCapture, FromPCAP), from
+// a local UDP collector (Listen), or decoded from raw bytes such as the packets
+// in a gNPSI stream (Decode).  Expectations then checks the export and its
+// samples against what the test configured:
+//
+//	exports, _ := sflow.FromOTGCapture(t, ate, "port2")
+//	want := sflow.Expectations{
+//	  SamplingRate:   1000000,
+//	  PacketsSent:    packetsToSend,
+//	  AgentAddress:   net.ParseIP(dutlo0Attrs.IPv4),
+//	  SourceAddress:  net.ParseIP(dutlo0Attrs.IPv4),
+//	  DSCP:           ygot.Uint8(8),
+//	}
+//	want.SetInterfaces(t, dut, dut.Port(t, "port1").Name(), dut.Port(t, "port2").Name())
+//	if err := want.Validate(exports); err != nil {
+//	  t.Error(err)
+//	}
+package sflow
+
+import (
+	"errors"
+	"fmt"
+	"net"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// ifIndexMask extracts the interface index from the compact sample format,
+// whose top two bits encode the format of the value.
+const ifIndexMask = 0x3fffffff
+
+// Export is an sFlow datagram as received by a collector.
+type Export struct {
+	// Source and Destination are the addresses of the export packet.  They are
+	// nil when decoding a bare datagram.
+	Source      net.IP
+	Destination net.IP
+	// DSCP of the export packet, or nil if unknown.
+	DSCP     *uint8
+	Datagram *Datagram
+}
+
+// Datagram is a decoded sFlow v5 datagram.
+type Datagram struct {
+	Version        uint32
+	AgentAddress   net.IP
+	SubAgentID     uint32
+	SequenceNumber uint32
+	Uptime         uint32
+	FlowSamples    []*FlowSample
+	CounterSamples []*CounterSample
+}
+
+// FlowSample is a flow sample with its sampled packet headers.
+type FlowSample struct {
+	SequenceNumber uint32
+	SourceIDIndex  uint32
+	SamplingRate   uint32
+	SamplePool     uint32
+	Dropped        uint32
+	InputIfIndex   uint32
+	OutputIfIndex  uint32
+	Headers        []*SampledHeader
+}
+
+// SampledHeader is a raw packet header record in a flow sample.
+type SampledHeader struct {
+	Protocol       layers.SFlowRawHeaderProtocol
+	FrameLength    uint32
+	PayloadRemoved uint32
+	// Packet is the sampled header decoded starting at the Ethernet layer.
+	Packet gopacket.Packet
+}
+
+// IPv4 returns the IPv4 layer of the sampled header, or nil.
+func (h *SampledHeader) IPv4() *layers.IPv4 {
+	if l, ok := h.Packet.Layer(layers.LayerTypeIPv4).(*layers.IPv4); ok {
+		return l
+	}
+	return nil
+}
+
+// IPv6 returns the IPv6 layer of the sampled header, or nil.
+func (h *SampledHeader) IPv6() *layers.IPv6 {
+	if l, ok := h.Packet.Layer(layers.LayerTypeIPv6).(*layers.IPv6); ok {
+		return l
+	}
+	return nil
+}
+
+// Addrs returns the IP source and destination of the sampled header.
+func (h *SampledHeader) Addrs() (src, dst net.IP, ok bool) {
+	if ip := h.IPv4(); ip != nil {
+		return ip.SrcIP, ip.DstIP, true
+	}
+	if ip := h.IPv6(); ip != nil {
+		return ip.SrcIP, ip.DstIP, true
+	}
+	return nil, nil, false
+}
+
+// CounterSample is a counter sample.  Only generic interface counters are
+// decoded; other records are kept in Records.
+type CounterSample struct {
+	SequenceNumber uint32
+	SourceIDIndex  uint32
+	Interface      *layers.SFlowGenericInterfaceCounters
+	Records        []layers.SFlowRecord
+}
+
+// Decode decodes an sFlow v5 datagram, the payload of an sFlow UDP packet.
+func Decode(b []byte) (dg *Datagram, err error) {
+	// The gopacket decoder does not check the datagram header length.
+	defer func() {
+		if r := recover(); r != nil {
+			dg, err = nil, fmt.Errorf("truncated sFlow datagram: %v", r)
+		}
+	}()
+	if len(b) < 28 {
+		return nil, errors.New("sFlow datagram too short")
+	}
+	var raw layers.SFlowDatagram
+	if err := raw.DecodeFromBytes(b, gopacket.NilDecodeFeedback); err != nil {
+		return nil, err
+	}
+	return fromLayer(&raw)
+}
+
+func fromLayer(raw *layers.SFlowDatagram) (*Datagram, error) {
+	if raw.DatagramVersion != 5 {
+		return nil, fmt.Errorf("unsupported sFlow version %d", raw.DatagramVersion)
+	}
+	dg := &Datagram{
+		Version:        raw.DatagramVersion,
+		AgentAddress:   raw.AgentAddress,
+		SubAgentID:     raw.SubAgentID,
+		SequenceNumber: raw.SequenceNumber,
+		Uptime:         raw.AgentUptime,
+	}
+	for _, fs := range raw.FlowSamples {
+		s := &FlowSample{
+			SequenceNumber: fs.SequenceNumber,
+			SourceIDIndex:  uint32(fs.SourceIDIndex),
+			SamplingRate:   fs.SamplingRate,
+			SamplePool:     fs.SamplePool,
+			Dropped:        fs.Dropped,
+			InputIfIndex:   fs.InputInterface,
+			OutputIfIndex:  fs.OutputInterface,
+		}
+		if fs.Format != layers.SFlowTypeExpandedFlowSample {
+			s.InputIfIndex &= ifIndexMask
+			s.OutputIfIndex &= ifIndexMask
+		}
+		for _, r := range fs.Records {
+			if h, ok := r.(layers.SFlowRawPacketFlowRecord); ok {
+				s.Headers = append(s.Headers, &SampledHeader{
+					Protocol:       h.HeaderProtocol,
+					FrameLength:    h.FrameLength,
+					PayloadRemoved: h.PayloadRemoved,
+					Packet:         h.Header,
+				})
+			}
+		}
+		dg.FlowSamples = append(dg.FlowSamples, s)
+	}
+	for _, cs := range raw.CounterSamples {
+		s := &CounterSample{
+			SequenceNumber: cs.SequenceNumber,
+			SourceIDIndex:  uint32(cs.SourceIDIndex),
+			Records:        cs.Records,
+		}
+		for _, r := range cs.Records {
+			if c, ok := r.(layers.SFlowGenericInterfaceCounters); ok {
+				s.Interface = &c
+			}
+		}
+		dg.CounterSamples = append(dg.CounterSamples, s)
+	}
+	return dg, nil
+}
+
+// FlowSamples returns the flow samples of all exports.
+func FlowSamples(exports []*Export) []*FlowSample {
+	var samples []*FlowSample
+	for _, e := range exports {
+		samples = append(samples, e.Datagram.FlowSamples...)
+	}
+	return samples
+}
+
+// CounterSamples returns the counter samples of all exports.
+func CounterSamples(exports []*Export) []*CounterSample {
+	var samples []*CounterSample
+	for _, e := range exports {
+		samples = append(samples, e.Datagram.CounterSamples...)
+	}
+	return samples
+}
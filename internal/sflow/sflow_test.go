@@ -0,0 +1,204 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sflow
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/google/gopacket/pcapgo"
+)
+
+var (
+	agentIP = net.ParseIP("192.0.2.1").To4()
+	srcMAC  = net.HardwareAddr{0x02, 0, 0, 0, 0, 1}
+	dstMAC  = net.HardwareAddr{0x02, 0, 0, 0, 0, 2}
+)
+
+func serialize(t *testing.T, ls ...gopacket.SerializableLayer) []byte {
+	t.Helper()
+	buf := gopacket.NewSerializeBuffer()
+	if err := gopacket.SerializeLayers(buf, gopacket.SerializeOptions{FixLengths: true, ComputeChecksums: true}, ls...); err != nil {
+		t.Fatalf("SerializeLayers() failed: %v", err)
+	}
+	return buf.Bytes()
+}
+
+// sampledHeader returns an Ethernet/IPv4 frame from 198.51.100.1 to
+// 203.0.113.1.
+func sampledHeader(t *testing.T) []byte {
+	t.Helper()
+	ip := &layers.IPv4{Version: 4, TTL: 64, Protocol: layers.IPProtocolUDP, SrcIP: net.ParseIP("198.51.100.1"), DstIP: net.ParseIP("203.0.113.1")}
+	udp := &layers.UDP{SrcPort: 1000, DstPort: 2000}
+	udp.SetNetworkLayerForChecksum(ip)
+	return serialize(t, &layers.Ethernet{SrcMAC: srcMAC, DstMAC: dstMAC, EthernetType: layers.EthernetTypeIPv4}, ip, udp, gopacket.Payload(make([]byte, 32)))
+}
+
+// datagram encodes an sFlow v5 datagram with one compact flow sample per
+// sequence number, each carrying a raw packet header record.
+func datagram(t *testing.T, rate, in, out uint32, seqs ...uint32) []byte {
+	t.Helper()
+	var b []byte
+	put := func(vs ...uint32) {
+		for _, v := range vs {
+			b = binary.BigEndian.AppendUint32(b, v)
+		}
+	}
+	hdr := sampledHeader(t)
+	padded := append(hdr, make([]byte, (4-len(hdr)%4)%4)...)
+
+	put(5, 1)
+	b = append(b, agentIP...)
+	put(0, 7, 1000, uint32(len(seqs)))
+	for _, seq := range seqs {
+		record := 16 + len(padded)
+		sample := 32 + 8 + record
+		put(1, uint32(sample))
+		put(seq, 3, rate, seq*rate, 0, in, out, 1)
+		put(1, uint32(record))
+		put(uint32(layers.SFlowProtoEthernet), uint32(len(hdr)+4), 4, uint32(len(hdr)))
+		b = append(b, padded...)
+	}
+	return b
+}
+
+func TestDecode(t *testing.T) {
+	dg, err := Decode(datagram(t, 1000, 10, 20|1<<30, 1, 2))
+	if err != nil {
+		t.Fatalf("Decode() failed: %v", err)
+	}
+	if !dg.AgentAddress.Equal(agentIP) || dg.SequenceNumber != 7 {
+		t.Errorf("Decode() header: got agent %v seq %d, want %v seq 7", dg.AgentAddress, dg.SequenceNumber, agentIP)
+	}
+	if len(dg.FlowSamples) != 2 {
+		t.Fatalf("Decode() got %d flow samples, want 2", len(dg.FlowSamples))
+	}
+	s := dg.FlowSamples[1]
+	if s.SamplingRate != 1000 || s.InputIfIndex != 10 || s.OutputIfIndex != 20 {
+		t.Errorf("Decode() flow sample: got rate %d in %d out %d, want 1000, 10, 20", s.SamplingRate, s.InputIfIndex, s.OutputIfIndex)
+	}
+	if len(s.Headers) != 1 {
+		t.Fatalf("Decode() got %d sampled headers, want 1", len(s.Headers))
+	}
+	src, dst, ok := s.Headers[0].Addrs()
+	if !ok || src.String() != "198.51.100.1" || dst.String() != "203.0.113.1" {
+		t.Errorf("SampledHeader.Addrs() = %v, %v, %v; want 198.51.100.1, 203.0.113.1, true", src, dst, ok)
+	}
+
+	if _, err := Decode([]byte{0, 0, 0, 5}); err == nil {
+		t.Errorf("Decode() of a truncated datagram succeeded, want error")
+	}
+}
+
+func TestFromPCAPAndValidate(t *testing.T) {
+	var capture bytes.Buffer
+	w := pcapgo.NewWriter(&capture)
+	if err := w.WriteFileHeader(65536, layers.LinkTypeEthernet); err != nil {
+		t.Fatal(err)
+	}
+	for i, payload := range [][]byte{datagram(t, 1000, 10, 20, 1, 2), datagram(t, 1000, 10, 20, 3, 4, 5)} {
+		ip := &layers.IPv4{Version: 4, TTL: 64, TOS: 8 << 2, Protocol: layers.IPProtocolUDP, SrcIP: agentIP, DstIP: net.ParseIP("192.0.2.2")}
+		udp := &layers.UDP{SrcPort: 50000, DstPort: DefaultPort}
+		udp.SetNetworkLayerForChecksum(ip)
+		pkt := serialize(t, &layers.Ethernet{SrcMAC: srcMAC, DstMAC: dstMAC, EthernetType: layers.EthernetTypeIPv4}, ip, udp, gopacket.Payload(payload))
+		ci := gopacket.CaptureInfo{Timestamp: time.Unix(int64(i), 0), CaptureLength: len(pkt), Length: len(pkt)}
+		if err := w.WritePacket(ci, pkt); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// A non-sFlow packet is skipped.
+	other := serialize(t, &layers.Ethernet{SrcMAC: srcMAC, DstMAC: dstMAC, EthernetType: layers.EthernetTypeIPv4}, &layers.IPv4{Version: 4, TTL: 64, Protocol: layers.IPProtocolICMPv4, SrcIP: agentIP, DstIP: agentIP})
+	if err := w.WritePacket(gopacket.CaptureInfo{CaptureLength: len(other), Length: len(other)}, other); err != nil {
+		t.Fatal(err)
+	}
+	// A malformed sFlow packet is skipped and counted.
+	ip := &layers.IPv4{Version: 4, TTL: 64, Protocol: layers.IPProtocolUDP, SrcIP: agentIP, DstIP: net.ParseIP("192.0.2.2")}
+	udp := &layers.UDP{SrcPort: 50000, DstPort: DefaultPort}
+	udp.SetNetworkLayerForChecksum(ip)
+	bad := serialize(t, &layers.Ethernet{SrcMAC: srcMAC, DstMAC: dstMAC, EthernetType: layers.EthernetTypeIPv4}, ip, udp, gopacket.Payload{0, 0, 0, 5})
+	if err := w.WritePacket(gopacket.CaptureInfo{CaptureLength: len(bad), Length: len(bad)}, bad); err != nil {
+		t.Fatal(err)
+	}
+
+	exports, malformed, err := FromPCAP(capture.Bytes())
+	if err != nil {
+		t.Fatalf("FromPCAP() failed: %v", err)
+	}
+	if len(exports) != 2 || malformed != 1 {
+		t.Fatalf("FromPCAP() got %d exports and %d malformed packets, want 2 and 1", len(exports), malformed)
+	}
+	if exports[0].DSCP == nil || *exports[0].DSCP != 8 {
+		t.Errorf("FromPCAP() DSCP = %v, want 8", exports[0].DSCP)
+	}
+
+	dscp := uint8(8)
+	want := Expectations{
+		SamplingRate:   1000,
+		PacketsSent:    5000,
+		AgentAddress:   agentIP,
+		SourceAddress:  agentIP,
+		DSCP:           &dscp,
+		IngressIfIndex: 10,
+		EgressIfIndex:  20,
+	}
+	if err := want.Validate(exports); err != nil {
+		t.Errorf("Validate() failed: %v", err)
+	}
+
+	wrong := want
+	wrongDSCP := uint8(0)
+	wrong.DSCP = &wrongDSCP
+	wrong.EgressIfIndex = 21
+	wrong.PacketsSent = 50000
+	err = wrong.Validate(exports)
+	for _, s := range []string{"DSCP 8, want 0", "output ifindex 20, want 21", "got 5 flow samples"} {
+		if err == nil || !strings.Contains(err.Error(), s) {
+			t.Errorf("Validate() = %v, want error containing %q", err, s)
+		}
+	}
+}
+
+func TestListener(t *testing.T) {
+	l, err := Listen("127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen() failed: %v", err)
+	}
+	defer l.Close()
+	conn, err := net.DialUDP("udp", nil, l.Addr())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	if _, err := conn.Write(datagram(t, 100, 1, 2, 1)); err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for len(l.Exports()) == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	exports := l.Exports()
+	if len(exports) != 1 {
+		t.Fatalf("Listener got %d exports, want 1 (errors: %v)", len(exports), l.Err())
+	}
+	if !exports[0].Source.IsLoopback() {
+		t.Errorf("Listener export source = %v, want loopback", exports[0].Source)
+	}
+}
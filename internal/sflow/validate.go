@@ -0,0 +1,136 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sflow
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"testing"
+
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/gnmi"
+)
+
+// DefaultSampleTolerance is the default allowed deviation of the number of
+// flow samples from PacketsSent / SamplingRate.
+const DefaultSampleTolerance = 0.2
+
+// Expectations describes the sFlow exports a test expects.  Zero fields are
+// not checked.
+type Expectations struct {
+	// SamplingRate is the configured sampling rate, which every flow sample
+	// must report.
+	SamplingRate uint32
+	// PacketsSent is the number of packets sent through the sampled
+	// interfaces.  With SamplingRate it gives the expected number of flow
+	// samples.
+	PacketsSent uint64
+	// SampleTolerance is the allowed relative deviation of the number of flow
+	// samples.  It defaults to DefaultSampleTolerance.
+	SampleTolerance float64
+	// AgentAddress is the agent address in the datagram header.
+	AgentAddress net.IP
+	// SourceAddress is the IP source address of the export packets.
+	SourceAddress net.IP
+	// DSCP is the DSCP of the export packets.  Exports with unknown DSCP
+	// are not checked.
+	DSCP *uint8
+	// IngressIfIndex and EgressIfIndex are the ifindex of the interfaces the
+	// sampled packets enter and leave the DUT by.
+	IngressIfIndex uint32
+	EgressIfIndex  uint32
+	// Match selects the flow samples that are checked, for example by the
+	// addresses of the sampled header.  Nil checks all flow samples.
+	Match func(*FlowSample) bool
+}
+
+// SetInterfaces sets IngressIfIndex and EgressIfIndex from the OC ifindex of
+// the named DUT interfaces.  An empty name leaves the field unchanged.
+func (e *Expectations) SetInterfaces(t testing.TB, dut *ondatra.DUTDevice, ingress, egress string) {
+	t.Helper()
+	if ingress != "" {
+		e.IngressIfIndex = IfIndex(t, dut, ingress)
+	}
+	if egress != "" {
+		e.EgressIfIndex = IfIndex(t, dut, egress)
+	}
+}
+
+// IfIndex returns the ifindex of a DUT interface, which is what flow samples
+// report as the input and output interface.
+func IfIndex(t testing.TB, dut *ondatra.DUTDevice, name string) uint32 {
+	t.Helper()
+	return gnmi.Get(t, dut, gnmi.OC().Interface(name).Ifindex().State())
+}
+
+// Validate checks the exports and returns an error describing every
+// violation, or nil.
+func (e *Expectations) Validate(exports []*Export) error {
+	if len(exports) == 0 {
+		return errors.New("no sFlow datagrams received")
+	}
+	var errs []error
+	for i, ex := range exports {
+		if e.AgentAddress != nil && !e.AgentAddress.Equal(ex.Datagram.AgentAddress) {
+			errs = append(errs, fmt.Errorf("datagram %d: agent address %v, want %v", i, ex.Datagram.AgentAddress, e.AgentAddress))
+		}
+		if e.SourceAddress != nil && ex.Source != nil && !e.SourceAddress.Equal(ex.Source) {
+			errs = append(errs, fmt.Errorf("datagram %d: source address %v, want %v", i, ex.Source, e.SourceAddress))
+		}
+		if e.DSCP != nil && ex.DSCP != nil && *ex.DSCP != *e.DSCP {
+			errs = append(errs, fmt.Errorf("datagram %d: DSCP %d, want %d", i, *ex.DSCP, *e.DSCP))
+		}
+	}
+
+	var samples []*FlowSample
+	for _, s := range FlowSamples(exports) {
+		if e.Match == nil || e.Match(s) {
+			samples = append(samples, s)
+		}
+	}
+	for _, s := range samples {
+		if e.SamplingRate != 0 && s.SamplingRate != e.SamplingRate {
+			errs = append(errs, fmt.Errorf("flow sample %d: sampling rate %d, want %d", s.SequenceNumber, s.SamplingRate, e.SamplingRate))
+		}
+		if e.IngressIfIndex != 0 && s.InputIfIndex != e.IngressIfIndex {
+			errs = append(errs, fmt.Errorf("flow sample %d: input ifindex %d, want %d", s.SequenceNumber, s.InputIfIndex, e.IngressIfIndex))
+		}
+		if e.EgressIfIndex != 0 && s.OutputIfIndex != e.EgressIfIndex {
+			errs = append(errs, fmt.Errorf("flow sample %d: output ifindex %d, want %d", s.SequenceNumber, s.OutputIfIndex, e.EgressIfIndex))
+		}
+	}
+	if e.PacketsSent != 0 && e.SamplingRate != 0 {
+		if err := CheckSampleCount(len(samples), e.PacketsSent, e.SamplingRate, e.SampleTolerance); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// CheckSampleCount checks that got flow samples is within tolerance of
+// packetsSent / samplingRate.  A zero tolerance means DefaultSampleTolerance.
+func CheckSampleCount(got int, packetsSent uint64, samplingRate uint32, tolerance float64) error {
+	if tolerance == 0 {
+		tolerance = DefaultSampleTolerance
+	}
+	want := float64(packetsSent) / float64(samplingRate)
+	lo, hi := math.Floor(want*(1-tolerance)), math.Ceil(want*(1+tolerance))
+	if float64(got) < lo || float64(got) > hi {
+		return fmt.Errorf("got %d flow samples, want %.0f (%.0f-%.0f) for %d packets at 1:%d", got, want, lo, hi, packetsSent, samplingRate)
+	}
+	return nil
+}
@@ -16,10 +16,12 @@
 package tescale
 
 import (
+	"fmt"
+	"math"
 	"sync"
 
+	"github.com/openconfig/featureprofiles/internal/allocator"
 	"github.com/openconfig/featureprofiles/internal/deviations"
-	"github.com/openconfig/featureprofiles/internal/iputil"
 	"github.com/openconfig/gribigo/fluent"
 	"github.com/openconfig/ondatra"
 )
@@ -33,11 +35,14 @@ const (
 	VRFRD = "vrf_rd"
 
 	// V4TunnelIPBlock tunnel IP block
-	V4TunnelIPBlock = "198.18.0.1/16"
+	V4TunnelIPBlock = "198.18.0.0/17"
 	// V4VIPIPBlock vip IP block
-	V4VIPIPBlock = "198.18.196.1/22"
+	V4VIPIPBlock = "198.18.196.0/22"
 
-	tunnelSrcIP = "198.18.204.1"
+	tunnelSrcIPBlock = "198.18.204.1/32"
+
+	nhKind  allocator.Kind = "gribi-nh"
+	nhgKind allocator.Kind = "gribi-nhg"
 )
 
 // IPPool for IPs
@@ -69,37 +74,59 @@ func (p *IPPool) AllIPs() []string {
 	return append([]string{}, p.ips...)
 }
 
+// newAddrPool allocates n addresses from block in the registry.
+func newAddrPool(r *allocator.Registry, name, block string, n int) (*IPPool, error) {
+	pool, err := r.NewAddrPool(name, block)
+	if err != nil {
+		return nil, err
+	}
+	addrs, err := pool.NextN(n)
+	if err != nil {
+		return nil, err
+	}
+	ips := make([]string, 0, n)
+	for _, a := range addrs {
+		ips = append(ips, a.String())
+	}
+	return NewIPPool(ips), nil
+}
+
 // IDPool for NH and NHG IDs
 type IDPool struct {
-	nhIndex  uint64
-	nhgIndex uint64
-	rw       sync.RWMutex
+	nhs  *allocator.IDPool
+	nhgs *allocator.IDPool
 }
 
-// NewIDPool creates a new IDPool
+// NewIDPool creates a new IDPool handing out NH and NHG IDs above base.
 func NewIDPool(base uint64) *IDPool {
-	return &IDPool{
-		nhIndex:  base,
-		nhgIndex: base,
+	r := allocator.NewRegistry()
+	nhs, err := r.NewIDPool("gribi-nh", nhKind, base+1, math.MaxUint64)
+	if err != nil {
+		panic(err)
+	}
+	nhgs, err := r.NewIDPool("gribi-nhg", nhgKind, base+1, math.MaxUint64)
+	if err != nil {
+		panic(err)
 	}
+	return &IDPool{nhs: nhs, nhgs: nhgs}
 }
 
 // NextNHID returns the next NHID
 func (p *IDPool) NextNHID() uint64 {
-	p.rw.Lock()
-	defer p.rw.Unlock()
-
-	p.nhIndex++
-	return p.nhIndex
+	id, err := p.nhs.Next()
+	if err != nil {
+		panic(err)
+	}
+	return id
 }
 
 // NextNHGID returns the next NHGID
 func (p *IDPool) NextNHGID() uint64 {
-	p.rw.Lock()
-	defer p.rw.Unlock()
-
-	p.nhgIndex++
-	return p.nhgIndex
+	id, err := p.nhgs.Next()
+	if err != nil {
+		panic(err)
+	}
+	return id
 }
 
 // VRFConfig holds NH, NHG and IPv4 entries for the VRF.
@@ -119,10 +146,24 @@ type Param struct {
 	V4ReEncapNHGCount     int
 }
 
-// BuildVRFConfig creates scale new scale VRF configurations.
-func BuildVRFConfig(dut *ondatra.DUTDevice, egressIPs []string, param Param) []*VRFConfig {
-	v4TunnelIPAddrs := NewIPPool(iputil.GenerateIPs(V4TunnelIPBlock, param.V4TunnelCount))
-	v4VIPAddrs := NewIPPool(iputil.GenerateIPs(V4VIPIPBlock, (param.V4TunnelNHGCount*param.V4TunnelNHGSplitCount)+2))
+// BuildVRFConfig creates scale new scale VRF configurations. The tunnel,
+// VIP and tunnel source addresses are allocated from non-overlapping blocks,
+// and an error is returned if param asks for more addresses than they hold.
+func BuildVRFConfig(dut *ondatra.DUTDevice, egressIPs []string, param Param) ([]*VRFConfig, error) {
+	r := allocator.NewRegistry()
+	v4TunnelIPAddrs, err := newAddrPool(r, "te-tunnel", V4TunnelIPBlock, param.V4TunnelCount)
+	if err != nil {
+		return nil, fmt.Errorf("could not allocate tunnel IPs: %w", err)
+	}
+	v4VIPAddrs, err := newAddrPool(r, "te-vip", V4VIPIPBlock, (param.V4TunnelNHGCount*param.V4TunnelNHGSplitCount)+2)
+	if err != nil {
+		return nil, fmt.Errorf("could not allocate VIPs: %w", err)
+	}
+	tunnelSrc, err := newAddrPool(r, "te-tunnel-src", tunnelSrcIPBlock, 1)
+	if err != nil {
+		return nil, fmt.Errorf("could not allocate tunnel source IP: %w", err)
+	}
+	tunnelSrcIP := tunnelSrc.NextIP()
 	v4EgressIPAddrs := NewIPPool(egressIPs)
 
 	defaultVRF := deviations.DefaultNetworkInstance(dut)
@@ -235,7 +276,8 @@ func BuildVRFConfig(dut *ondatra.DUTDevice, egressIPs []string, param Param) []*
 	}
 	vrfDefault.NHGs = append(vrfDefault.NHGs, nhgEntry)
 
-	v4VIPAddrs = NewIPPool(iputil.GenerateIPs(V4VIPIPBlock, (param.V4TunnelNHGCount*param.V4TunnelNHGSplitCount)+2))
+	// VRF_RP uses the same VIPs as VRF_T.
+	v4VIPAddrs = NewIPPool(v4VIPAddrs.AllIPs())
 
 	// VRF_RP
 
@@ -264,5 +306,5 @@ func BuildVRFConfig(dut *ondatra.DUTDevice, egressIPs []string, param Param) []*
 		)
 	}
 
-	return []*VRFConfig{vrfDefault, vrfTConf, vrfRConf, vrfRDConf}
+	return []*VRFConfig{vrfDefault, vrfTConf, vrfRConf, vrfRDConf}, nil
 }
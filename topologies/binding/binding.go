@@ -27,6 +27,7 @@ import (
 
 	grpc_retry "github.com/grpc-ecosystem/go-grpc-middleware/retry"
 	"github.com/open-traffic-generator/snappi/gosnappi"
+	"github.com/openconfig/featureprofiles/internal/setrecorder"
 	bindpb "github.com/openconfig/featureprofiles/topologies/proto/binding"
 	gpb "github.com/openconfig/gnmi/proto/gnmi"
 	"github.com/openconfig/gnoigo"
@@ -135,7 +136,7 @@ func (b *staticBind) Release(ctx context.Context) error {
 		return err
 	}
 	b.resv = nil
-	return nil
+	return setrecorder.Flush()
 }
 
 func (b *staticBind) FetchReservation(_ context.Context, id string) (*binding.Reservation, error) {
@@ -674,6 +675,7 @@ func dialOpts(bopts *bindpb.Options) ([]grpc.DialOption, error) {
 			grpcutil.WithStreamDefaultTimeout(timeout),
 		)
 	}
+	opts = append(opts, setrecorder.DialOptions()...)
 	return opts, nil
 }
 